@@ -89,6 +89,7 @@ func main() {
 	start = time.Now()
 
 	prOpts := algorithms.DefaultPageRankOptions()
+	prOpts.TopK = 5
 	prResult, err := algorithms.PageRank(graph, prOpts)
 	if err != nil {
 		log.Fatalf("PageRank failed: %v", err)
@@ -115,11 +116,9 @@ func main() {
 	duration = time.Since(start)
 	fmt.Printf("✅ Betweenness Centrality completed in %v\n", duration)
 
-	// Find top nodes by betweenness
-	topBetweenness := findTopNFromMap(betweenness, 5)
 	fmt.Printf("  Top 5 nodes by Betweenness:\n")
-	for i, item := range topBetweenness {
-		fmt.Printf("    %d. Node %d (score: %.6f)\n", i+1, item.nodeID, item.score)
+	for i, item := range betweenness.Top(5) {
+		fmt.Printf("    %d. Node %d (score: %.6f)\n", i+1, item.NodeID, item.Score)
 	}
 
 	// Benchmark 3: Degree Centrality
@@ -134,10 +133,9 @@ func main() {
 	duration = time.Since(start)
 	fmt.Printf("✅ Degree Centrality completed in %v\n", duration)
 
-	topDegree := findTopNFromMap(degree, 5)
 	fmt.Printf("  Top 5 nodes by Degree:\n")
-	for i, item := range topDegree {
-		fmt.Printf("    %d. Node %d (score: %.6f)\n", i+1, item.nodeID, item.score)
+	for i, item := range degree.Top(5) {
+		fmt.Printf("    %d. Node %d (score: %.6f)\n", i+1, item.NodeID, item.Score)
 	}
 
 	// Benchmark 4: Clustering Coefficient
@@ -171,7 +169,7 @@ func main() {
 	fmt.Printf("\n📊 Benchmark 6: Label Propagation (Community Detection)\n")
 	start = time.Now()
 
-	labelProp, err := algorithms.LabelPropagation(graph, 100)
+	labelProp, err := algorithms.LabelPropagation(graph, 100, 1)
 	if err != nil {
 		log.Fatalf("Label Propagation failed: %v", err)
 	}
@@ -195,35 +193,6 @@ func main() {
 	fmt.Printf("\n✅ Benchmark complete!\n")
 }
 
-type scoreItem struct {
-	nodeID uint64
-	score  float64
-}
-
-func findTopNFromMap(scores map[uint64]float64, n int) []scoreItem {
-	items := make([]scoreItem, 0, len(scores))
-	for nodeID, score := range scores {
-		items = append(items, scoreItem{nodeID, score})
-	}
-
-	// Simple selection sort for top N
-	if n > len(items) {
-		n = len(items)
-	}
-
-	for i := 0; i < n; i++ {
-		maxIdx := i
-		for j := i + 1; j < len(items); j++ {
-			if items[j].score > items[maxIdx].score {
-				maxIdx = j
-			}
-		}
-		items[i], items[maxIdx] = items[maxIdx], items[i]
-	}
-
-	return items[:n]
-}
-
 func findLargestComponent(result *algorithms.CommunityDetectionResult) int {
 	maxSize := 0
 	for _, community := range result.Communities {
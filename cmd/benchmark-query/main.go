@@ -121,8 +121,7 @@ func benchmarkFindAllUsers(graph *storage.GraphStorage) {
 	start := time.Now()
 
 	proceduralCount := 0
-	stats := graph.GetStatistics()
-	for nodeID := uint64(1); nodeID <= stats.NodeCount; nodeID++ {
+	for _, nodeID := range graph.GetAllNodeIDs() {
 		node, err := graph.GetNode(nodeID)
 		if err != nil {
 			continue
@@ -184,8 +183,7 @@ func benchmarkPropertyFilter(graph *storage.GraphStorage) {
 	start := time.Now()
 
 	proceduralCount := 0
-	stats := graph.GetStatistics()
-	for nodeID := uint64(1); nodeID <= stats.NodeCount; nodeID++ {
+	for _, nodeID := range graph.GetAllNodeIDs() {
 		node, err := graph.GetNode(nodeID)
 		if err != nil {
 			continue
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/algorithms"
+	"github.com/dd0wney/graphdb/pkg/plugins"
+)
+
+// algoOptions are the flags accepted by the algo command. Each algorithm
+// reads the ones that apply to it and ignores the rest.
+type algoOptions struct {
+	damping float64
+	iters   int
+	top     int
+	seeds   []string // node IDs or @names, resolved by the runner
+}
+
+// cliAlgorithm is one entry in the algo command's registry.
+type cliAlgorithm struct {
+	description string
+	run         func(cli *CLI, opts algoOptions) error
+}
+
+// cliAlgorithms maps the names accepted by `algo <name>` to their runners.
+// Adding an algorithm to the CLI is one entry here, not a new command.
+var cliAlgorithms = map[string]cliAlgorithm{
+	"pagerank": {
+		description: "PageRank (--damping, --iters, --top)",
+		run: func(cli *CLI, opts algoOptions) error {
+			return cli.algoPageRank(opts, nil)
+		},
+	},
+	"pagerank-personalized": {
+		description: "PageRank restarting at --seeds=<id|@name,...> (--damping, --iters, --top)",
+		run: func(cli *CLI, opts algoOptions) error {
+			if len(opts.seeds) == 0 {
+				return fmt.Errorf("--seeds is required")
+			}
+			seeds := make(map[uint64]float64, len(opts.seeds))
+			for _, arg := range opts.seeds {
+				id, err := cli.resolveNode(arg)
+				if err != nil {
+					return fmt.Errorf("--seeds: %w", err)
+				}
+				seeds[id] = 1
+			}
+			return cli.algoPageRank(opts, seeds)
+		},
+	},
+	"betweenness": {
+		description: "Betweenness centrality (--top)",
+		run: func(cli *CLI, opts algoOptions) error {
+			scores, err := algorithms.BetweennessCentrality(cli.graph)
+			if err != nil {
+				return err
+			}
+			printTopScores(scores, opts.top)
+			return nil
+		},
+	},
+	"closeness": {
+		description: "Closeness centrality (--top)",
+		run: func(cli *CLI, opts algoOptions) error {
+			scores, err := algorithms.ClosenessCentrality(cli.graph)
+			if err != nil {
+				return err
+			}
+			printTopScores(scores, opts.top)
+			return nil
+		},
+	},
+	"degree": {
+		description: "Degree centrality (--top)",
+		run: func(cli *CLI, opts algoOptions) error {
+			scores, err := algorithms.DegreeCentrality(cli.graph)
+			if err != nil {
+				return err
+			}
+			printTopScores(scores, opts.top)
+			return nil
+		},
+	},
+	"components": {
+		description: "Connected components, largest first (--top)",
+		run: func(cli *CLI, opts algoOptions) error {
+			result, err := algorithms.ConnectedComponents(cli.graph)
+			if err != nil {
+				return err
+			}
+			printTopCommunities(result, opts.top)
+			return nil
+		},
+	},
+	"sources": {
+		description: "Nodes with no incoming edges, the entry points (--top)",
+		run: func(cli *CLI, opts algoOptions) error {
+			cli.printNodeList("Sources", algorithms.Sources(cli.graph), opts.top)
+			return nil
+		},
+	},
+	"sinks": {
+		description: "Nodes with no outgoing edges, the dead ends (--top)",
+		run: func(cli *CLI, opts algoOptions) error {
+			cli.printNodeList("Sinks", algorithms.Sinks(cli.graph), opts.top)
+			return nil
+		},
+	},
+}
+
+// algoNames returns the built-in and plugin algorithm names in sorted
+// order. A plugin can't shadow a built-in of the same name.
+func algoNames() []string {
+	names := make([]string, 0, len(cliAlgorithms))
+	for name := range cliAlgorithms {
+		names = append(names, name)
+	}
+	for _, name := range plugins.Algorithms().Names() {
+		if _, builtin := cliAlgorithms[name]; !builtin {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseAlgoOptions parses `--damping=0.85 --iters=50 --top=20 --seeds=1,@Eve`
+// (the `--flag value` form works too).
+func parseAlgoOptions(args []string) (algoOptions, error) {
+	defaults := algorithms.DefaultPageRankOptions()
+	opts := algoOptions{}
+	var seeds string
+
+	fs := flag.NewFlagSet("algo", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Float64Var(&opts.damping, "damping", defaults.DampingFactor, "PageRank damping factor")
+	fs.IntVar(&opts.iters, "iters", defaults.MaxIterations, "maximum iterations")
+	fs.IntVar(&opts.top, "top", 10, "number of results to print")
+	fs.StringVar(&seeds, "seeds", "", "comma-separated personalization node IDs or @names")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	if opts.damping <= 0 || opts.damping >= 1 {
+		return opts, fmt.Errorf("--damping must be in (0, 1), got %g", opts.damping)
+	}
+	if opts.iters <= 0 {
+		return opts, fmt.Errorf("--iters must be positive, got %d", opts.iters)
+	}
+	if opts.top <= 0 {
+		return opts, fmt.Errorf("--top must be positive, got %d", opts.top)
+	}
+	for _, s := range strings.Split(seeds, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			opts.seeds = append(opts.seeds, s)
+		}
+	}
+	return opts, nil
+}
+
+func (cli *CLI) runAlgorithm(name string, args []string) {
+	algo, ok := cliAlgorithms[name]
+	if !ok {
+		if _, ok := plugins.Algorithms().Get(name); ok {
+			cli.runPluginAlgorithm(name, args)
+			return
+		}
+		fmt.Printf("❌ Unknown algorithm: %s (available: %s)\n", name, strings.Join(algoNames(), ", "))
+		return
+	}
+	opts, err := parseAlgoOptions(args)
+	if err != nil {
+		fmt.Printf("❌ %s: %v\n", name, err)
+		return
+	}
+
+	start := time.Now()
+	fmt.Printf("📊 %s\n", name)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if err := algo.run(cli, opts); err != nil {
+		fmt.Printf("❌ %s error: %v\n", name, err)
+		return
+	}
+	fmt.Printf("\nTime: %v\n", time.Since(start))
+}
+
+// runPluginAlgorithm runs an algorithm registered with
+// plugins.RegisterAlgorithm, passing its --key=value flags as parameters
+// and printing the result as JSON.
+func (cli *CLI) runPluginAlgorithm(name string, args []string) {
+	params, err := parsePluginParams(args)
+	if err != nil {
+		fmt.Printf("❌ %s: %v\n", name, err)
+		return
+	}
+
+	start := time.Now()
+	fmt.Printf("📊 %s (plugin)\n", name)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	result, err := plugins.Algorithms().Run(name, cli.graph, params)
+	if err != nil {
+		fmt.Printf("❌ %s error: %v\n", name, err)
+		return
+	}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Printf("❌ %s: result is not JSON-encodable: %v\n", name, err)
+		return
+	}
+	fmt.Println(string(out))
+	fmt.Printf("\nTime: %v\n", time.Since(start))
+}
+
+// parsePluginParams turns `--key=value` flags into plugin parameters,
+// typed as JSON would decode them: numbers are float64, true/false are
+// bool, anything else a string. A bare `--key` is true.
+func parsePluginParams(args []string) (map[string]any, error) {
+	params := make(map[string]any, len(args))
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			return nil, fmt.Errorf("unexpected argument %q (want --key=value)", arg)
+		}
+		key, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if key == "" {
+			return nil, fmt.Errorf("empty flag name in %q", arg)
+		}
+		if !hasValue {
+			params[key] = true
+			continue
+		}
+		switch f, err := strconv.ParseFloat(value, 64); {
+		case err == nil:
+			params[key] = f
+		case value == "true" || value == "false":
+			params[key] = value == "true"
+		default:
+			params[key] = value
+		}
+	}
+	return params, nil
+}
+
+func (cli *CLI) algoPageRank(opts algoOptions, personalization map[uint64]float64) error {
+	result, err := algorithms.PageRank(cli.graph, algorithms.PageRankOptions{
+		DampingFactor:         opts.damping,
+		MaxIterations:         opts.iters,
+		Tolerance:             algorithms.DefaultPageRankOptions().Tolerance,
+		PersonalizationVector: personalization,
+		TopK:                  opts.top,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Iterations: %d (converged: %v)\n\n", result.Iterations, result.Converged)
+	top := result.TopN(opts.top)
+	fmt.Printf("Top %d Nodes:\n", len(top))
+	for i, ranked := range top {
+		fmt.Printf("  #%d: Node %d (score: %.6f)\n", i+1, ranked.NodeID, ranked.Score)
+	}
+	return nil
+}
+
+// printTopScores prints the top n scores, highest first, ties by ascending
+// node ID.
+func printTopScores(scores algorithms.ScoreResult, n int) {
+	top := scores.Top(n)
+	fmt.Printf("Top %d Nodes:\n", len(top))
+	for i, entry := range top {
+		fmt.Printf("  #%d: Node %d (score: %.6f)\n", i+1, entry.NodeID, entry.Score)
+	}
+}
+
+// printTopCommunities prints the n largest communities, ties by ascending
+// community ID.
+func printTopCommunities(result *algorithms.CommunityDetectionResult, n int) {
+	comms := append([]*algorithms.Community(nil), result.Communities...)
+	sort.Slice(comms, func(i, j int) bool {
+		if comms[i].Size != comms[j].Size {
+			return comms[i].Size > comms[j].Size
+		}
+		return comms[i].ID < comms[j].ID
+	})
+	if n > len(comms) {
+		n = len(comms)
+	}
+
+	fmt.Printf("Components: %d\n\n", len(comms))
+	for i, c := range comms[:n] {
+		members := append([]uint64(nil), c.Nodes...)
+		sort.Slice(members, func(a, b int) bool { return members[a] < members[b] })
+		fmt.Printf("  #%d: %d nodes %v\n", i+1, c.Size, members)
+	}
+}
+
+// printNodeList prints the first n of ids with their labels and name.
+func (cli *CLI) printNodeList(title string, ids []uint64, n int) {
+	fmt.Printf("%s: %d\n\n", title, len(ids))
+	for i, id := range ids {
+		if i == n {
+			fmt.Printf("  ... and %d more\n", len(ids)-n)
+			break
+		}
+		node, err := cli.graph.GetNode(id)
+		if err != nil {
+			continue
+		}
+		fmt.Printf("  [%d] %v", id, node.Labels)
+		if value, ok := node.Properties[nameProperty]; ok {
+			if name, err := value.AsString(); err == nil {
+				fmt.Printf(" %s", name)
+			}
+		}
+		fmt.Println()
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -115,6 +117,14 @@ func (cli *CLI) executeCommand(input string) {
 			fmt.Println("Usage: query <cypher-query>")
 			return
 		}
+		if strings.ToLower(parts[1]) == "explain" {
+			if len(parts) < 3 {
+				fmt.Println("Usage: query explain <cypher-query>")
+				return
+			}
+			cli.explainQuery(strings.Join(parts[2:], " "))
+			return
+		}
 		queryStr := strings.Join(parts[1:], " ")
 		cli.executeQuery(queryStr)
 
@@ -129,37 +139,40 @@ func (cli *CLI) executeCommand(input string) {
 
 	case "get-node", "gn":
 		if len(parts) < 2 {
-			fmt.Println("Usage: get-node <node-id>")
+			fmt.Println("Usage: get-node <node-id|@name>")
 			return
 		}
-		nodeID, _ := strconv.ParseUint(parts[1], 10, 64)
-		cli.getNode(nodeID)
+		if ids, ok := cli.resolveNodes(parts[1]); ok {
+			cli.getNode(ids[0])
+		}
 
 	case "neighbors":
 		if len(parts) < 2 {
-			fmt.Println("Usage: neighbors <node-id>")
+			fmt.Println("Usage: neighbors <node-id|@name>")
 			return
 		}
-		nodeID, _ := strconv.ParseUint(parts[1], 10, 64)
-		cli.showNeighbors(nodeID)
+		if ids, ok := cli.resolveNodes(parts[1]); ok {
+			cli.showNeighbors(ids[0])
+		}
 
 	case "traverse":
 		if len(parts) < 3 {
-			fmt.Println("Usage: traverse <node-id> <max-depth>")
+			fmt.Println("Usage: traverse <node-id|@name> <max-depth>")
 			return
 		}
-		nodeID, _ := strconv.ParseUint(parts[1], 10, 64)
 		maxDepth, _ := strconv.Atoi(parts[2])
-		cli.traverse(nodeID, maxDepth)
+		if ids, ok := cli.resolveNodes(parts[1]); ok {
+			cli.traverse(ids[0], maxDepth)
+		}
 
 	case "path":
 		if len(parts) < 3 {
-			fmt.Println("Usage: path <from-id> <to-id>")
+			fmt.Println("Usage: path <from-id|@name> <to-id|@name>")
 			return
 		}
-		fromID, _ := strconv.ParseUint(parts[1], 10, 64)
-		toID, _ := strconv.ParseUint(parts[2], 10, 64)
-		cli.findPath(fromID, toID)
+		if ids, ok := cli.resolveNodes(parts[1], parts[2]); ok {
+			cli.findPath(ids[0], ids[1])
+		}
 
 	case "pagerank", "pr":
 		cli.runPageRank()
@@ -167,6 +180,35 @@ func (cli *CLI) executeCommand(input string) {
 	case "betweenness", "bc":
 		cli.runBetweenness()
 
+	case "metrics":
+		cli.showGraphMetrics()
+
+	case "algo":
+		if len(parts) < 2 {
+			fmt.Printf("Usage: algo <name> [--damping=0.85 --iters=100 --top=10 --seeds=1,@Eve]\n")
+			fmt.Printf("Algorithms: %s\n", strings.Join(algoNames(), ", "))
+			return
+		}
+		cli.runAlgorithm(strings.ToLower(parts[1]), parts[2:])
+
+	case "export":
+		if len(parts) < 2 || len(parts) > 3 {
+			fmt.Println("Usage: export <file> [json|dot|graphml]")
+			return
+		}
+		format := ""
+		if len(parts) == 3 {
+			format = strings.ToLower(parts[2])
+		}
+		cli.exportGraph(parts[1], format)
+
+	case "import":
+		if len(parts) < 2 || len(parts) > 3 {
+			fmt.Println("Usage: import <file.json> | import <nodes.csv> [edges.csv]")
+			return
+		}
+		cli.importGraph(parts[1:])
+
 	case "demo":
 		cli.runDemo()
 
@@ -185,6 +227,7 @@ func (cli *CLI) showHelp() {
 🔍 Query & Inspection:
   query <query>          Execute a Cypher-like query
   q <query>             Shorthand for query
+  query explain <query> Show the plan (scans, estimated rows) without running it
   stats                 Show database statistics
   list-nodes            List all nodes
   ln                    Shorthand for list-nodes
@@ -192,6 +235,10 @@ func (cli *CLI) showHelp() {
   gn <id>               Shorthand for get-node
   neighbors <id>        Show neighbors of a node
 
+  Wherever a node <id> is expected, @name (or @Label:name) looks the
+  node up by its "name" property instead (a scan, unless the database
+  already has a "name" property index).
+
 🛠️  Data Manipulation:
   create-node           Interactive node creation
   cn                    Shorthand for create-node
@@ -207,6 +254,19 @@ func (cli *CLI) showHelp() {
   pr                    Shorthand for pagerank
   betweenness           Run Betweenness Centrality
   bc                    Shorthand for betweenness
+  metrics               Diameter and average path length
+  algo <name> [flags]   Run pagerank, pagerank-personalized, betweenness,
+                        closeness, degree, components, sources or sinks;
+                        flags are --damping, --iters, --top, --seeds.
+                        Registered plugin algorithms take --key=value
+                        parameters
+
+💾 Files:
+  export <file> [fmt]   Write the graph to disk (json, dot or graphml;
+                        inferred from the extension, default json)
+  import <file.json>    Load a graph written by 'export <file> json'
+  import <nodes.csv> [edges.csv]
+                        Load nodes (and edges, resolved by name) from CSV
 
 🎮 Other:
   demo                  Run interactive demo
@@ -219,7 +279,11 @@ func (cli *CLI) showHelp() {
   create-node
   neighbors 1
   path 1 5
+  path @Alice @Eve
   pagerank
+  algo pagerank --damping=0.9 --top=20
+  algo pagerank-personalized --seeds=3
+  export scenario.graphml
 `
 	fmt.Println(help)
 }
@@ -238,21 +302,31 @@ func (cli *CLI) showStats() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 }
 
-func (cli *CLI) executeQuery(queryStr string) {
-	start := time.Now()
-
-	// Parse query
+// parseQuery parses queryStr, printing the error and returning nil if it
+// does not parse.
+func parseQuery(queryStr string) *query.Query {
 	lexer := query.NewLexer(queryStr)
 	tokens, err := lexer.Tokenize()
 	if err != nil {
 		fmt.Printf("❌ Lexer error: %v\n", err)
-		return
+		return nil
 	}
 
 	parser := query.NewParser(tokens)
 	parsedQuery, err := parser.Parse()
 	if err != nil {
 		fmt.Printf("❌ Parser error: %v\n", err)
+		return nil
+	}
+	return parsedQuery
+}
+
+func (cli *CLI) executeQuery(queryStr string) {
+	start := time.Now()
+
+	// Parse query
+	parsedQuery := parseQuery(queryStr)
+	if parsedQuery == nil {
 		return
 	}
 
@@ -288,6 +362,35 @@ func (cli *CLI) executeQuery(queryStr string) {
 	}
 }
 
+func (cli *CLI) explainQuery(queryStr string) {
+	parsedQuery := parseQuery(queryStr)
+	if parsedQuery == nil {
+		return
+	}
+
+	plan, err := cli.executor.Explain(parsedQuery)
+	if err != nil {
+		fmt.Printf("❌ Explain error: %v\n", err)
+		return
+	}
+
+	fmt.Println("📋 Query Plan")
+	fmt.Println("━━━━━━━━━━━━━")
+	for i, step := range plan.Steps {
+		fmt.Printf("%d. %-18s %s\n", i+1, step.Step, step.Detail)
+		if step.Scan != "" {
+			fmt.Printf("   scan: %s", step.Scan)
+			if step.EstimatedRows != nil {
+				fmt.Printf(", ~%d rows", *step.EstimatedRows)
+			}
+			fmt.Println()
+		}
+		for _, hop := range step.Traversal {
+			fmt.Printf("   %s\n", hop)
+		}
+	}
+}
+
 func (cli *CLI) createNodeInteractive() {
 	reader := bufio.NewReader(os.Stdin)
 
@@ -336,13 +439,17 @@ func (cli *CLI) createEdgeInteractive() {
 	fmt.Println("🔗 Create New Edge")
 	fmt.Println("━━━━━━━━━━━━━━━━━━")
 
-	fmt.Print("From Node ID: ")
+	fmt.Print("From Node (ID or @name): ")
 	fromStr, _ := reader.ReadString('\n')
-	fromID, _ := strconv.ParseUint(strings.TrimSpace(fromStr), 10, 64)
 
-	fmt.Print("To Node ID: ")
+	fmt.Print("To Node (ID or @name): ")
 	toStr, _ := reader.ReadString('\n')
-	toID, _ := strconv.ParseUint(strings.TrimSpace(toStr), 10, 64)
+
+	ids, ok := cli.resolveNodes(strings.TrimSpace(fromStr), strings.TrimSpace(toStr))
+	if !ok {
+		return
+	}
+	fromID, toID := ids[0], ids[1]
 
 	fmt.Print("Edge Type: ")
 	edgeType, _ := reader.ReadString('\n')
@@ -373,7 +480,7 @@ func (cli *CLI) listNodes() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	count := 0
-	for nodeID := uint64(1); nodeID <= stats.NodeCount; nodeID++ {
+	for _, nodeID := range cli.graph.GetAllNodeIDs() {
 		node, err := cli.graph.GetNode(nodeID)
 		if err != nil {
 			continue
@@ -434,7 +541,12 @@ func (cli *CLI) getNode(nodeID uint64) {
 }
 
 func (cli *CLI) showNeighbors(nodeID uint64) {
-	outgoing, err := cli.graph.GetOutgoingEdges(nodeID)
+	outgoing, err := cli.graph.Neighbors(nodeID, storage.DirectionOutgoing, nil)
+	if err != nil {
+		fmt.Printf("❌ Node %d not found\n", nodeID)
+		return
+	}
+	incoming, err := cli.graph.Neighbors(nodeID, storage.DirectionIncoming, nil)
 	if err != nil {
 		fmt.Printf("❌ Node %d not found\n", nodeID)
 		return
@@ -443,50 +555,37 @@ func (cli *CLI) showNeighbors(nodeID uint64) {
 	fmt.Printf("👥 Neighbors of Node %d\n", nodeID)
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	if len(outgoing) == 0 {
-		fmt.Println("  No outgoing edges")
+	if len(outgoing) == 0 && len(incoming) == 0 {
+		fmt.Println("  No neighbors")
 		return
 	}
 
-	for i, edge := range outgoing {
-		if i >= 20 {
-			fmt.Printf("  ... and %d more neighbors\n", len(outgoing)-i)
-			break
-		}
-
-		neighbor, _ := cli.graph.GetNode(edge.ToNodeID)
-		if neighbor != nil {
-			fmt.Printf("  [%d] -[%s]-> %v\n", edge.ToNodeID, edge.Type, neighbor.Labels)
+	printNeighbors := func(arrow string, neighbors []*storage.Node) {
+		for i, neighbor := range neighbors {
+			if i >= 20 {
+				fmt.Printf("  ... and %d more neighbors\n", len(neighbors)-i)
+				break
+			}
+			fmt.Printf("  %s [%d] %v\n", arrow, neighbor.ID, neighbor.Labels)
 		}
 	}
+	printNeighbors("->", outgoing)
+	printNeighbors("<-", incoming)
 }
 
 func (cli *CLI) traverse(nodeID uint64, maxDepth int) {
 	start := time.Now()
 
-	visited := make(map[uint64]bool)
-	nodes := make([]*storage.Node, 0)
-
-	var traverseFrom func(uint64, int)
-	traverseFrom = func(id uint64, depth int) {
-		if depth > maxDepth || visited[id] {
-			return
-		}
-
-		visited[id] = true
-		node, err := cli.graph.GetNode(id)
-		if err != nil {
-			return
-		}
-		nodes = append(nodes, node)
-
-		edges, _ := cli.graph.GetOutgoingEdges(id)
-		for _, edge := range edges {
-			traverseFrom(edge.ToNodeID, depth+1)
-		}
+	result, err := query.NewTraverser(cli.graph).DFS(query.TraversalOptions{
+		StartNodeID: nodeID,
+		Direction:   query.DirectionOutgoing,
+		MaxDepth:    maxDepth,
+	})
+	if err != nil {
+		fmt.Printf("❌ Traversal failed: %v\n", err)
+		return
 	}
-
-	traverseFrom(nodeID, 0)
+	nodes := result.Nodes
 
 	fmt.Printf("🌐 Traversal from Node %d (max depth: %d)\n", nodeID, maxDepth)
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -533,6 +632,7 @@ func (cli *CLI) runPageRank() {
 		MaxIterations: 20,
 		DampingFactor: 0.85,
 		Tolerance:     1e-6,
+		TopK:          10,
 	}
 
 	result, err := algorithms.PageRank(cli.graph, opts)
@@ -548,13 +648,8 @@ func (cli *CLI) runPageRank() {
 	fmt.Printf("Time: %v\n\n", time.Since(start))
 
 	fmt.Println("Top 10 Nodes:")
-	count := 0
-	for _, ranked := range result.TopNodes {
-		if count >= 10 {
-			break
-		}
-		fmt.Printf("  #%d: Node %d (score: %.6f)\n", count+1, ranked.NodeID, ranked.Score)
-		count++
+	for i, ranked := range result.TopN(10) {
+		fmt.Printf("  #%d: Node %d (score: %.6f)\n", i+1, ranked.NodeID, ranked.Score)
 	}
 }
 
@@ -571,29 +666,130 @@ func (cli *CLI) runBetweenness() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("Time: %v\n\n", time.Since(start))
 
-	// Sort and show top 10
-	type scored struct {
-		id    uint64
-		score float64
+	printTopScores(scores, 10)
+}
+
+func (cli *CLI) showGraphMetrics() {
+	start := time.Now()
+
+	diameter, err := algorithms.Diameter(cli.graph)
+	if err != nil {
+		fmt.Printf("❌ Metrics error: %v\n", err)
+		return
 	}
-	sorted := make([]scored, 0, len(scores))
-	for id, score := range scores {
-		sorted = append(sorted, scored{id, score})
+	avgPath, err := algorithms.AveragePathLength(cli.graph)
+	if err != nil {
+		fmt.Printf("❌ Metrics error: %v\n", err)
+		return
 	}
 
-	// Simple bubble sort for top 10
-	for i := 0; i < len(sorted) && i < 10; i++ {
-		for j := i + 1; j < len(sorted); j++ {
-			if sorted[j].score > sorted[i].score {
-				sorted[i], sorted[j] = sorted[j], sorted[i]
-			}
+	fmt.Println("📐 Graph Metrics")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("  Diameter:            %d hops\n", diameter)
+	fmt.Printf("  Average path length: %.2f hops\n", avgPath)
+	fmt.Printf("  Time:                %v\n", time.Since(start))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+}
+
+// exportFormatFromPath infers an export format from a file extension,
+// defaulting to JSON.
+func exportFormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".dot", ".gv":
+		return "dot"
+	case ".graphml":
+		return "graphml"
+	default:
+		return "json"
+	}
+}
+
+func (cli *CLI) exportGraph(path, format string) {
+	if format == "" {
+		format = exportFormatFromPath(path)
+	}
+
+	var export func(w io.Writer) error
+	switch format {
+	case "json":
+		export = func(w io.Writer) error {
+			return storage.ExportJSON(cli.graph, w, storage.JSONOptions{Indent: true})
+		}
+	case "dot":
+		export = func(w io.Writer) error {
+			return storage.ExportDOT(cli.graph, w, storage.DOTOptions{EdgeLabels: true})
 		}
+	case "graphml":
+		export = func(w io.Writer) error {
+			return storage.ExportGraphML(cli.graph, w, storage.GraphMLOptions{})
+		}
+	default:
+		fmt.Printf("❌ Unknown export format: %s (use json, dot or graphml)\n", format)
+		return
 	}
 
-	fmt.Println("Top 10 Nodes:")
-	for i := 0; i < 10 && i < len(sorted); i++ {
-		fmt.Printf("  #%d: Node %d (score: %.6f)\n", i+1, sorted[i].id, sorted[i].score)
+	start := time.Now()
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("❌ Export error: %v\n", err)
+		return
+	}
+	if err := export(f); err != nil {
+		f.Close()
+		fmt.Printf("❌ Export error: %v\n", err)
+		return
+	}
+	if err := f.Close(); err != nil {
+		fmt.Printf("❌ Export error: %v\n", err)
+		return
+	}
+
+	nodes := len(cli.graph.GetAllNodesForTenant(storage.DefaultTenantID))
+	edges := len(cli.graph.GetAllEdgesForTenant(storage.DefaultTenantID))
+	fmt.Printf("💾 Exported %d nodes and %d edges to %s (%s) in %v\n", nodes, edges, path, format, time.Since(start))
+}
+
+func (cli *CLI) importGraph(paths []string) {
+	start := time.Now()
+	var nodes, edges int
+	var err error
+
+	if strings.EqualFold(filepath.Ext(paths[0]), ".json") {
+		if len(paths) > 1 {
+			fmt.Println("❌ A JSON import takes a single file")
+			return
+		}
+		nodes, edges, err = importFile(paths[0], func(r io.Reader) (int, int, error) {
+			return storage.ImportJSON(cli.graph, r)
+		})
+	} else {
+		nodes, _, err = importFile(paths[0], func(r io.Reader) (int, int, error) {
+			n, err := storage.ImportNodesCSV(cli.graph, r)
+			return n, 0, err
+		})
+		if err == nil && len(paths) > 1 {
+			_, edges, err = importFile(paths[1], func(r io.Reader) (int, int, error) {
+				e, err := storage.ImportEdgesCSV(cli.graph, r)
+				return 0, e, err
+			})
+		}
+	}
+
+	fmt.Printf("📥 Imported %d nodes and %d edges in %v\n", nodes, edges, time.Since(start))
+	if err != nil {
+		fmt.Printf("❌ Import error: %v\n", err)
+	}
+}
+
+// importFile opens path and hands it to load, which reports the nodes and
+// edges it created.
+func importFile(path string, load func(io.Reader) (int, int, error)) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
 	}
+	defer f.Close()
+	return load(f)
 }
 
 func (cli *CLI) runDemo() {
@@ -648,5 +844,6 @@ func (cli *CLI) runDemo() {
 	fmt.Println("  query MATCH (p:Person) RETURN p")
 	fmt.Println("  neighbors", nodeIDs[0])
 	fmt.Println("  path", nodeIDs[0], nodeIDs[4])
+	fmt.Println("  path @Alice @Eve")
 	fmt.Println("  pagerank")
 }
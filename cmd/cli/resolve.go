@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// nameProperty is the node property `@name` arguments are resolved against.
+const nameProperty = "name"
+
+// resolveNode turns a node argument into an ID. It accepts a numeric ID,
+// `@name`, or `@Label:name` to disambiguate by label. A name matching more
+// than one node is an error that lists the candidates.
+func (cli *CLI) resolveNode(arg string) (uint64, error) {
+	if !strings.HasPrefix(arg, "@") {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid node %q: want a numeric ID or @name", arg)
+		}
+		return id, nil
+	}
+
+	ref := strings.TrimPrefix(arg, "@")
+	if ref == "" {
+		return 0, fmt.Errorf("empty node name in %q", arg)
+	}
+	matches, err := cli.findNodesByName(ref, "")
+	if err != nil {
+		return 0, err
+	}
+	// Only treat "Label:" as a filter when the whole ref isn't itself a name.
+	if label, name, ok := strings.Cut(ref, ":"); ok && len(matches) == 0 && label != "" && name != "" {
+		if matches, err = cli.findNodesByName(name, label); err != nil {
+			return 0, err
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no node named %q", ref)
+	case 1:
+		return matches[0].ID, nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d nodes; use an ID or @Label:name:", ref, len(matches))
+	for _, n := range matches {
+		fmt.Fprintf(&b, "\n    %d %v", n.ID, n.Labels)
+	}
+	return 0, fmt.Errorf("%s", b.String())
+}
+
+// findNodesByName returns the nodes whose name property equals name, limited
+// to those carrying label when it is non-empty. It uses the database's
+// "name" property index when there is one and scans the nodes otherwise;
+// the CLI never creates the index itself, as that would change the database
+// it was only asked to read.
+func (cli *CLI) findNodesByName(name, label string) ([]*storage.Node, error) {
+	value := storage.StringValue(name)
+	var nodes []*storage.Node
+	var err error
+	if cli.graph.HasPropertyIndex(nameProperty) {
+		nodes, err = cli.graph.FindNodesByPropertyIndexed(nameProperty, value)
+	} else {
+		nodes, err = cli.graph.FindNodesByProperty(nameProperty, value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if label == "" {
+		return nodes, nil
+	}
+	filtered := nodes[:0]
+	for _, n := range nodes {
+		if n.HasLabel(label) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered, nil
+}
+
+// resolveNodes resolves each argument with resolveNode, printing the first
+// failure. ok is false if any argument failed.
+func (cli *CLI) resolveNodes(args ...string) (ids []uint64, ok bool) {
+	ids = make([]uint64, len(args))
+	for i, arg := range args {
+		id, err := cli.resolveNode(arg)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return nil, false
+		}
+		ids[i] = id
+	}
+	return ids, true
+}
@@ -13,9 +13,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 	"github.com/dd0wney/graphdb/pkg/licensing"
 )
 
+// maxBodyBytes caps request bodies; Stripe webhook events and license
+// requests are a few KiB.
+const maxBodyBytes = 1 << 20 // 1 MiB
+
 var (
 	port          = flag.String("port", "", "HTTP server port (or set PORT)")
 	databaseURL   = flag.String("database-url", "", "PostgreSQL connection string (or set DATABASE_URL)")
@@ -168,10 +173,24 @@ func main() {
 	mux.HandleFunc("/licenses", server.requireAuth(server.handleListLicenses))
 	mux.HandleFunc("/licenses/create", server.requireAuth(server.handleCreateLicense))
 
+	// Middleware, outermost first: recover panics as 500s, tag requests
+	// with an X-Request-ID, write JSON access logs alongside the slog
+	// output, and bound bodies (webhook and license payloads are small).
+	handler := middleware.Chain(
+		middleware.PanicRecovery(),
+		middleware.RequestID(),
+		middleware.LoggingWithConfig(&middleware.LoggingConfig{
+			Format:       middleware.LogFormatJSON,
+			GetRequestID: middleware.GetRequestID,
+			Output:       os.Stdout,
+		}),
+		middleware.BodySizeLimit(maxBodyBytes),
+	)(mux)
+
 	// Create HTTP server
 	httpServer := &http.Server{
 		Addr:         ":" + *port,
-		Handler:      mux,
+		Handler:      handler,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/dd0wney/graphdb/pkg/api"
+	"github.com/dd0wney/graphdb/pkg/constraints"
 	"github.com/dd0wney/graphdb/pkg/editions"
 	"github.com/dd0wney/graphdb/pkg/encryption"
 	"github.com/dd0wney/graphdb/pkg/licensing"
@@ -48,10 +49,15 @@ func loadLicense(logger *slog.Logger) (*licensing.License, error) {
 
 		// Simple license object with key only
 		// In production, this should call the license server to validate and get full details
+		expiresAt, err := licenseExpiryFromEnv()
+		if err != nil {
+			return nil, err
+		}
 		license := &licensing.License{
-			Key:    licenseKey,
-			Status: "active",
-			Type:   licensing.LicenseTypeEnterprise,
+			Key:       licenseKey,
+			Status:    "active",
+			Type:      licensing.LicenseTypeEnterprise,
+			ExpiresAt: expiresAt,
 		}
 
 		// Basic key format validation
@@ -92,10 +98,15 @@ func loadLicense(logger *slog.Logger) (*licensing.License, error) {
 			// If not JSON, treat as plain license key
 			licenseKey := string(data)
 			if licensing.ValidateLicenseKey(licenseKey) {
+				expiresAt, err := licenseExpiryFromEnv()
+				if err != nil {
+					return nil, err
+				}
 				return &licensing.License{
-					Key:    licenseKey,
-					Status: "active",
-					Type:   licensing.LicenseTypeEnterprise,
+					Key:       licenseKey,
+					Status:    "active",
+					Type:      licensing.LicenseTypeEnterprise,
+					ExpiresAt: expiresAt,
 				}, nil
 			}
 
@@ -106,6 +117,32 @@ func loadLicense(logger *slog.Logger) (*licensing.License, error) {
 	return nil, fmt.Errorf("no license found (tried env vars GRAPHDB_LICENSE, GRAPHDB_LICENSE_KEY, and standard paths)")
 }
 
+// licenseExpiryFromEnv returns GRAPHDB_LICENSE_EXPIRES_AT (RFC 3339), the
+// expiry of a license given as a bare key. JSON licenses carry expires_at
+// themselves. Unset means the key never expires.
+func licenseExpiryFromEnv() (*time.Time, error) {
+	v := os.Getenv("GRAPHDB_LICENSE_EXPIRES_AT")
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GRAPHDB_LICENSE_EXPIRES_AT (want RFC 3339): %w", err)
+	}
+	return &t, nil
+}
+
+// licenseExpiryWarningWindow returns GRAPHDB_LICENSE_EXPIRY_WARN_DAYS as a
+// duration, defaulting to licensing.DefaultExpiryWarningWindow.
+func licenseExpiryWarningWindow() time.Duration {
+	if v := os.Getenv("GRAPHDB_LICENSE_EXPIRY_WARN_DAYS"); v != "" {
+		if days, err := strconv.Atoi(v); err == nil && days >= 0 {
+			return time.Duration(days) * 24 * time.Hour
+		}
+	}
+	return licensing.DefaultExpiryWarningWindow
+}
+
 // loadTLSConfig loads TLS configuration from environment variables
 func loadTLSConfig(logger *slog.Logger) *tlspkg.Config {
 	// Check if TLS is enabled
@@ -232,6 +269,11 @@ func hexCharToByte(c byte) byte {
 func main() {
 	port := flag.Int("port", 0, "HTTP server port (default 8080, or set PORT)")
 	dataDir := flag.String("data", "./data/server", "Data directory")
+	// CORS flags default to their env vars and override them when given.
+	corsOrigins := flag.String("cors-origins", os.Getenv("CORS_ALLOWED_ORIGINS"), "Comma-separated allowed CORS origins (empty disables CORS; or set CORS_ALLOWED_ORIGINS)")
+	corsMethods := flag.String("cors-methods", os.Getenv("CORS_ALLOWED_METHODS"), "Comma-separated allowed CORS methods (or set CORS_ALLOWED_METHODS)")
+	corsHeaders := flag.String("cors-headers", os.Getenv("CORS_ALLOWED_HEADERS"), "Comma-separated allowed CORS request headers (or set CORS_ALLOWED_HEADERS)")
+	corsCredentials := flag.Bool("cors-allow-credentials", os.Getenv("CORS_ALLOW_CREDENTIALS") == "true", "Allow credentialed CORS requests; refused with origin * (or set CORS_ALLOW_CREDENTIALS)")
 	flag.Parse()
 
 	// Get port from env if not provided
@@ -293,6 +335,7 @@ func main() {
 	// This ensures encrypted snapshots can be decrypted during storage initialization
 	var encryptionEngine *encryption.Engine
 	var keyManager *encryption.KeyManager
+	var storageEngine encryption.EncryptDecrypter // encryptionEngine, or a KeyRing mid-rotation
 
 	encryptionEnabled := os.Getenv("ENCRYPTION_ENABLED")
 	if encryptionEnabled == "true" || encryptionEnabled == "1" {
@@ -329,6 +372,31 @@ func main() {
 			os.Exit(1)
 		}
 
+		storageEngine = encryptionEngine
+
+		// Master-key rotation: retired keys listed here stay usable for
+		// decryption, and storage re-encrypts everything under the current
+		// key when it opens. Remove them once the server has started.
+		if prev := os.Getenv("ENCRYPTION_PREVIOUS_KEYS"); prev != "" {
+			var previousKeys [][]byte
+			for _, hexKey := range strings.Split(prev, ",") {
+				key, err := decodeHexKey(strings.TrimSpace(hexKey))
+				if err != nil {
+					logger.Error("failed to decode previous encryption key", "error", err)
+					os.Exit(1)
+				}
+				previousKeys = append(previousKeys, key)
+			}
+			ring, err := encryption.NewKeyRing(masterKey, previousKeys...)
+			if err != nil {
+				logger.Error("invalid previous encryption key", "error", err)
+				os.Exit(1)
+			}
+			storageEngine = ring
+			logger.Info("encryption key rotation: data will be re-encrypted under the current master key",
+				"previous_keys", len(previousKeys))
+		}
+
 		// Create key manager
 		keyDir := os.Getenv("ENCRYPTION_KEY_DIR")
 		if keyDir == "" {
@@ -372,10 +440,20 @@ func main() {
 	logger.Info("initializing graph storage", "data_dir", *dataDir)
 	storageConfig := storage.DefaultStorageConfig(*dataDir)
 	if encryptionEngine != nil && keyManager != nil {
-		storageConfig.EncryptionEngine = encryptionEngine
+		storageConfig.EncryptionEngine = storageEngine
 		storageConfig.KeyManager = keyManager
 		logger.Info("encryption connected to storage layer")
 	}
+	// Field-level encryption: values of these property keys are sealed
+	// individually in the snapshot and WAL (needs ENCRYPTION_ENABLED).
+	if sensitive := os.Getenv("SENSITIVE_PROPERTIES"); sensitive != "" {
+		for _, key := range strings.Split(sensitive, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				storageConfig.SensitiveProperties = append(storageConfig.SensitiveProperties, key)
+			}
+		}
+		logger.Info("sensitive properties encrypted at rest", "keys", storageConfig.SensitiveProperties)
+	}
 	// mmap-backed lazy reopen is the default (v1.2). It falls back to the JSON
 	// path automatically when ineligible (encryption enabled, disk-backed edges,
 	// or no snapshot.mmap present). GRAPHDB_STORAGE_MODE=json forces the JSON
@@ -387,6 +465,25 @@ func main() {
 	default:
 		logger.Info("mmap-backed lazy reopen enabled (default; set GRAPHDB_STORAGE_MODE=json to opt out)")
 	}
+	// Edge policy: self-loops and parallel (from, to, type) edges are
+	// allowed unless configured otherwise.
+	storageConfig.RejectSelfLoops = os.Getenv("GRAPHDB_REJECT_SELF_LOOPS") == "true"
+	if v := os.Getenv("GRAPHDB_MULTI_EDGES"); v != "" {
+		policy, err := storage.ParseMultiEdgePolicy(v)
+		if err != nil {
+			logger.Error("invalid GRAPHDB_MULTI_EDGES", "error", err)
+			os.Exit(1)
+		}
+		storageConfig.MultiEdges = policy
+	}
+	if storageConfig.RejectSelfLoops || storageConfig.MultiEdges != storage.MultiEdgesAllow {
+		logger.Info("edge policy configured",
+			"reject_self_loops", storageConfig.RejectSelfLoops,
+			"multi_edges", storageConfig.MultiEdges.String())
+	}
+	// Share repeated string property values (zones, roles, ...) between
+	// nodes and edges instead of storing one copy apiece.
+	storageConfig.InternPropertyStrings = os.Getenv("GRAPHDB_INTERN_PROPERTY_STRINGS") == "true"
 	graph, err := storage.NewGraphStorageWithConfig(storageConfig)
 	if err != nil {
 		logger.Error("failed to create graph storage", "error", err)
@@ -416,8 +513,11 @@ func main() {
 			os.Exit(1)
 		}
 
-		// Validate license
-		if err := license.Validate(); err != nil {
+		// Validate license, refusing to start on an expired one. A license
+		// that expires while the server runs drops the Enterprise
+		// algorithms to Community (licensing.HasFeature checks expiry).
+		now := time.Now()
+		if err := licensing.Validate(license, now); err != nil {
 			logger.Error("license validation failed", "error", err)
 			logger.Error("Enterprise edition requires a valid license")
 			logger.Info("To use GraphDB without a license, set GRAPHDB_EDITION=community")
@@ -428,6 +528,12 @@ func main() {
 			"type", license.Type,
 			"email", license.Email,
 		)
+		if remaining, soon := license.ExpiresWithin(now, licenseExpiryWarningWindow()); soon {
+			logger.Warn("license expires soon; renew it to keep Enterprise features",
+				"expires_at", license.ExpiresAt.Format(time.RFC3339),
+				"days_left", int(remaining.Hours()/24),
+			)
+		}
 
 		// Load Enterprise plugins. The loader requires an absolute path
 		// (M-15 — a CWD-relative plugin dir is a code-execution ambush);
@@ -511,11 +617,39 @@ func main() {
 	// self-describing. Set once at startup — before any request can land.
 	api.BuildVersion = Version
 
+	// Gate the Enterprise algorithms on the loaded license (nil in
+	// Community edition, which keeps the basic algorithms).
+	server.SetLicense(license)
+
+	// Write-time constraint checks: POST /nodes and POST /edges refuse
+	// violating entities with 422. Off unless a constraints file is given.
+	if constraintsFile := os.Getenv("GRAPHDB_CONSTRAINTS_FILE"); constraintsFile != "" {
+		validator, err := constraints.LoadValidatorFromFile(constraintsFile)
+		if err != nil {
+			logger.Error("Failed to load constraints", "error", err)
+			os.Exit(1)
+		}
+		server.SetConstraintValidator(validator)
+		logger.Info("constraints loaded", "file", constraintsFile, "count", len(validator.GetConstraints()))
+	}
+
 	// Apply TLS configuration if enabled
 	if tlsConfig != nil {
 		server.SetTLSConfig(tlsConfig)
 	}
 
+	// NewServer already applied the CORS env vars; rebuild only when a
+	// flag overrides them.
+	corsFlagSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, "cors-") {
+			corsFlagSet = true
+		}
+	})
+	if corsFlagSet {
+		server.SetCORSConfig(api.NewCORSConfig(*corsOrigins, *corsMethods, *corsHeaders, *corsCredentials))
+	}
+
 	// Apply encryption configuration if enabled
 	if encryptionEngine != nil && keyManager != nil {
 		server.SetEncryption(encryptionEngine, keyManager)
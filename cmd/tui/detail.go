@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+var detailCursorStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#FFFFFF")).
+	Background(lipgloss.Color("#FF00FF"))
+
+// detailEdge is one row of the node detail pane's edge list.
+type detailEdge struct {
+	edge     *storage.Edge
+	neighbor *storage.Node // nil if the other endpoint couldn't be loaded
+	outgoing bool
+}
+
+// nodeDetail is the Nodes view drill-down: a node, its edges in both
+// directions, and the trail of nodes visited to get here.
+type nodeDetail struct {
+	node    *storage.Node
+	edges   []detailEdge
+	cursor  int
+	history []uint64 // previously viewed node IDs, most recent last
+}
+
+// openSelectedNode opens the detail pane for the node under the table cursor.
+func (m *model) openSelectedNode() {
+	row := m.nodeTable.SelectedRow()
+	if row == nil {
+		return
+	}
+	id, err := strconv.ParseUint(row[0], 10, 64)
+	if err != nil {
+		return
+	}
+	m.showNodeDetail(id, nil)
+}
+
+// showNodeDetail loads nodeID and its edges into the detail pane. history is
+// the trail to keep for the back key.
+func (m *model) showNodeDetail(nodeID uint64, history []uint64) {
+	node, err := m.graph.GetNode(nodeID)
+	if err != nil {
+		m.message = fmt.Sprintf("Node %d: %v", nodeID, err)
+		m.messageErr = true
+		return
+	}
+
+	var edges []detailEdge
+	if out, err := m.graph.GetOutgoingEdges(nodeID); err == nil {
+		for _, e := range out {
+			edges = append(edges, detailEdge{edge: e, outgoing: true})
+		}
+	}
+	if in, err := m.graph.GetIncomingEdges(nodeID); err == nil {
+		for _, e := range in {
+			edges = append(edges, detailEdge{edge: e})
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].outgoing != edges[j].outgoing {
+			return edges[i].outgoing
+		}
+		return edges[i].edge.ID < edges[j].edge.ID
+	})
+	for i := range edges {
+		if n, err := m.graph.GetNode(edges[i].neighborID()); err == nil {
+			edges[i].neighbor = n
+		}
+	}
+
+	m.detail = &nodeDetail{node: node, edges: edges, history: history}
+	m.message = ""
+}
+
+// neighborID is the endpoint of the edge that isn't the detail node.
+func (d detailEdge) neighborID() uint64 {
+	if d.outgoing {
+		return d.edge.ToNodeID
+	}
+	return d.edge.FromNodeID
+}
+
+func (m *model) detailUp() {
+	if m.detail.cursor > 0 {
+		m.detail.cursor--
+	}
+}
+
+func (m *model) detailDown() {
+	if m.detail.cursor < len(m.detail.edges)-1 {
+		m.detail.cursor++
+	}
+}
+
+// followDetailEdge moves the detail pane to the neighbor under the cursor.
+func (m *model) followDetailEdge() {
+	d := m.detail
+	if len(d.edges) == 0 {
+		return
+	}
+	history := append(append([]uint64(nil), d.history...), d.node.ID)
+	m.showNodeDetail(d.edges[d.cursor].neighborID(), history)
+}
+
+// detailBack returns to the previously viewed node, or closes the pane when
+// there is none.
+func (m *model) detailBack() {
+	d := m.detail
+	if len(d.history) == 0 {
+		m.detail = nil
+		return
+	}
+	prev := d.history[len(d.history)-1]
+	m.showNodeDetail(prev, d.history[:len(d.history)-1])
+}
+
+// nodeTitle renders a node as "Label 3 (name)".
+func nodeTitle(n *storage.Node) string {
+	label := "Node"
+	if len(n.Labels) > 0 {
+		label = strings.Join(n.Labels, ":")
+	}
+	if name, ok := n.Properties["name"]; ok {
+		return fmt.Sprintf("%s %d (%s)", label, n.ID, name)
+	}
+	return fmt.Sprintf("%s %d", label, n.ID)
+}
+
+func (m model) renderNodeDetail() string {
+	d := m.detail
+	var s strings.Builder
+
+	s.WriteString(headerStyle.Render(nodeTitle(d.node)))
+	s.WriteString("\n\n")
+
+	keys := make([]string, 0, len(d.node.Properties))
+	for k := range d.node.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	props := "(none)"
+	if len(keys) > 0 {
+		lines := make([]string, len(keys))
+		for i, k := range keys {
+			lines[i] = fmt.Sprintf("%s: %s", k, d.node.Properties[k])
+		}
+		props = strings.Join(lines, "\n")
+	}
+	s.WriteString(statsBoxStyle.Render("Properties\n━━━━━━━━━━━━━━━\n" + props))
+	s.WriteString("\n\n")
+
+	if len(d.edges) == 0 {
+		s.WriteString("No edges")
+	}
+	for i, e := range d.edges {
+		other := fmt.Sprintf("Node %d", e.neighborID())
+		if e.neighbor != nil {
+			other = nodeTitle(e.neighbor)
+		}
+		line := fmt.Sprintf("←[%s]─ %s", e.edge.Type, other)
+		if e.outgoing {
+			line = fmt.Sprintf("─[%s]→ %s", e.edge.Type, other)
+		}
+		if i == d.cursor {
+			s.WriteString(detailCursorStyle.Render("▸ " + line))
+		} else {
+			s.WriteString("  " + line)
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(helpStyle.Render("↑/↓ select edge • enter go to neighbor • esc back"))
+
+	return contentStyle.Render(s.String())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -94,6 +95,8 @@ type keyMap struct {
 	Down     key.Binding
 	Left     key.Binding
 	Right    key.Binding
+	Back     key.Binding
+	Refresh  key.Binding
 }
 
 var keys = keyMap{
@@ -129,6 +132,14 @@ var keys = keyMap{
 		key.WithKeys("right", "l"),
 		key.WithHelp("→/l", "right"),
 	),
+	Back: key.NewBinding(
+		key.WithKeys("esc", "backspace"),
+		key.WithHelp("esc", "back"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
@@ -139,7 +150,7 @@ func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Tab, k.ShiftTab, k.Enter},
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.Quit},
+		{k.Back, k.Refresh, k.Quit},
 	}
 }
 
@@ -150,6 +161,14 @@ type model struct {
 	queryInput  textinput.Model
 	nodeList    list.Model
 	nodeTable   table.Model
+	resultTable table.Model
+	nodesLoaded bool        // whether the node table has been filled, by a load or a query
+	detail      *nodeDetail // non-nil while the Nodes view shows a node's detail pane
+	pageRank    *algorithms.PageRankResult
+	pageRankDur time.Duration
+	pageRankHit bool                    // pageRank came from algoCache
+	algoCache   *algorithms.ResultCache // reuses PageRank while the graph version is unchanged
+	metricsPage int
 	help        help.Model
 	keys        keyMap
 	width       int
@@ -158,8 +177,12 @@ type model struct {
 	messageErr  bool
 	startTime   time.Time
 	stats       storage.Statistics
+	labelCounts map[string]int // nodes per label, refreshed with stats
+	typeCounts  map[string]int // edges per type, refreshed with stats
+	changes     <-chan storage.ChangeEvent
 }
 
+// tickMsg redraws the uptime clock; statistics refresh on changeMsg instead.
 type tickMsg time.Time
 
 func tickCmd() tea.Cmd {
@@ -168,7 +191,31 @@ func tickCmd() tea.Cmd {
 	})
 }
 
-func initialModel(graph *storage.GraphStorage) model {
+// changeMsg reports that the graph was mutated since the last refresh.
+type changeMsg struct{}
+
+// waitForChange blocks until the next graph change event, then drains any
+// further buffered events so a burst of writes costs one refresh. It
+// returns nil (no message) once the channel is closed.
+func waitForChange(ch <-chan storage.ChangeEvent) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-ch; !ok {
+			return nil
+		}
+		for {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					return changeMsg{}
+				}
+			default:
+				return changeMsg{}
+			}
+		}
+	}
+}
+
+func initialModel(graph *storage.GraphStorage, changes <-chan storage.ChangeEvent) model {
 	ti := textinput.New()
 	ti.Placeholder = "MATCH (n:Person) RETURN n"
 	ti.CharLimit = 200
@@ -198,6 +245,12 @@ func initialModel(graph *storage.GraphStorage) model {
 		Bold(false)
 	t.SetStyles(s)
 
+	rt := table.New(
+		table.WithFocused(false),
+		table.WithHeight(10),
+	)
+	rt.SetStyles(s)
+
 	executor, _ := queryutil.WireCapabilities(query.NewExecutor(graph), graph)
 	return model{
 		graph:       graph,
@@ -205,10 +258,15 @@ func initialModel(graph *storage.GraphStorage) model {
 		currentView: dashboardView,
 		queryInput:  ti,
 		nodeTable:   t,
+		resultTable: rt,
 		help:        help.New(),
 		keys:        keys,
 		startTime:   time.Now(),
 		stats:       graph.GetStatistics(),
+		labelCounts: graph.LabelCounts(),
+		typeCounts:  graph.EdgeTypeCounts(),
+		changes:     changes,
+		algoCache:   algorithms.NewResultCache(1, nil),
 	}
 }
 
@@ -216,6 +274,7 @@ func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		textinput.Blink,
 		tickCmd(),
+		waitForChange(m.changes),
 	)
 }
 
@@ -230,39 +289,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.help.Width = msg.Width
 
 	case tickMsg:
-		m.stats = m.graph.GetStatistics()
 		return m, tickCmd()
 
+	case changeMsg:
+		m.stats = m.graph.GetStatistics()
+		m.labelCounts = m.graph.LabelCounts()
+		m.typeCounts = m.graph.EdgeTypeCounts()
+		return m, waitForChange(m.changes)
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keys.Tab):
-			m.currentView = (m.currentView + 1) % 5
-			if m.currentView == queryView {
-				m.queryInput.Focus()
-			} else {
-				m.queryInput.Blur()
-			}
+			m.switchView((m.currentView + 1) % 5)
+			return m, nil
 
 		case key.Matches(msg, m.keys.ShiftTab):
 			if m.currentView == 0 {
-				m.currentView = 4
+				m.switchView(4)
 			} else {
-				m.currentView--
-			}
-			if m.currentView == queryView {
-				m.queryInput.Focus()
-			} else {
-				m.queryInput.Blur()
+				m.switchView(m.currentView - 1)
 			}
+			return m, nil
 
 		case key.Matches(msg, m.keys.Enter):
 			if m.currentView == queryView && m.queryInput.Focused() {
 				m.executeQuery()
 			}
 		}
+
+		if m.currentView == nodesView {
+			return m.updateNodesView(msg)
+		}
+		if m.currentView == metricsView {
+			m.updateMetricsView(msg)
+			return m, nil
+		}
 	}
 
 	// Update focused component
@@ -278,6 +342,135 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// switchView makes v the current view, moving input focus and loading any
+// data the view needs on entry.
+func (m *model) switchView(v view) {
+	m.currentView = v
+	if v == queryView {
+		m.queryInput.Focus()
+	} else {
+		m.queryInput.Blur()
+	}
+	if v == nodesView && !m.nodesLoaded {
+		m.loadNodes()
+	}
+	if v == metricsView {
+		m.runPageRank()
+	}
+}
+
+// maxNodeRows caps the node table so a large graph doesn't stall rendering.
+const maxNodeRows = 1000
+
+// loadNodes fills the node table from storage in ascending-ID order.
+func (m *model) loadNodes() {
+	nodes := m.graph.GetAllNodesAcrossTenants()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	shown := nodes[:min(len(nodes), maxNodeRows)]
+	rows := make([]table.Row, 0, len(shown))
+	for _, node := range shown {
+		rows = append(rows, nodeRow(node))
+	}
+	m.nodeTable.SetRows(rows)
+	m.nodeTable.GotoTop()
+	m.nodesLoaded = true
+
+	m.message = fmt.Sprintf("Loaded %d nodes", len(nodes))
+	if len(nodes) > len(shown) {
+		m.message = fmt.Sprintf("Loaded first %d of %d nodes", len(shown), len(nodes))
+	}
+	m.messageErr = false
+}
+
+// metricsPageSize is how many ranked nodes the Metrics view shows per page.
+const metricsPageSize = 10
+
+// runPageRank computes PageRank for the Metrics view. It runs on entry to
+// the view rather than on every render, which on a large graph would redo
+// the whole computation once a second, and re-entering the view reuses the
+// previous result unless the graph has changed since.
+func (m *model) runPageRank() {
+	m.pageRank = nil
+	m.metricsPage = 0
+	if m.stats.NodeCount == 0 {
+		return
+	}
+	opts := algorithms.PageRankOptions{
+		MaxIterations: 10,
+		DampingFactor: 0.85,
+		Tolerance:     1e-6,
+	}
+	computed := false
+	start := time.Now()
+	result, err := m.algoCache.Do(m.graph, "pagerank", "", func() (any, error) {
+		computed = true
+		return algorithms.PageRank(m.graph, opts)
+	})
+	if err != nil {
+		m.message = fmt.Sprintf("PageRank error: %v", err)
+		m.messageErr = true
+		return
+	}
+	m.pageRank = result.(*algorithms.PageRankResult)
+	m.pageRankHit = !computed
+	if computed {
+		m.pageRankDur = time.Since(start)
+	}
+}
+
+// metricsPages is the number of pages in the Metrics view's ranking.
+func (m model) metricsPages() int {
+	if m.pageRank == nil {
+		return 0
+	}
+	return (len(m.pageRank.TopNodes) + metricsPageSize - 1) / metricsPageSize
+}
+
+// updateMetricsView pages through the PageRank ranking.
+func (m *model) updateMetricsView(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, m.keys.Right), key.Matches(msg, m.keys.Down):
+		if m.metricsPage < m.metricsPages()-1 {
+			m.metricsPage++
+		}
+	case key.Matches(msg, m.keys.Left), key.Matches(msg, m.keys.Up):
+		if m.metricsPage > 0 {
+			m.metricsPage--
+		}
+	}
+}
+
+// updateNodesView handles keys in the Nodes view: the table, or the detail
+// pane when one is open.
+func (m model) updateNodesView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.detail == nil {
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			m.openSelectedNode()
+			return m, nil
+		case key.Matches(msg, m.keys.Refresh):
+			m.loadNodes()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.nodeTable, cmd = m.nodeTable.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.detailUp()
+	case key.Matches(msg, m.keys.Down):
+		m.detailDown()
+	case key.Matches(msg, m.keys.Enter), key.Matches(msg, m.keys.Right):
+		m.followDetailEdge()
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Left):
+		m.detailBack()
+	}
+	return m, nil
+}
+
 func (m *model) executeQuery() {
 	queryStr := m.queryInput.Value()
 	if queryStr == "" {
@@ -311,32 +504,105 @@ func (m *model) executeQuery() {
 	}
 
 	elapsed := time.Since(start)
+	// Query counters are not graph changes, so no event refreshes them.
+	m.stats = m.graph.GetStatistics()
 	m.message = fmt.Sprintf("Query executed successfully! Found %d rows in %s", results.Count, elapsed)
 	m.messageErr = false
 
+	m.updateResultTable(results)
+
 	// Update node table with results if they're nodes
 	m.updateNodeTable(results)
 }
 
+// maxResultColumnWidth caps a result column so one long property map
+// doesn't push the remaining columns off screen.
+const maxResultColumnWidth = 40
+
+// maxResultRows is how many result rows the Query view shows at once.
+const maxResultRows = 10
+
+// updateResultTable shows a ResultSet in the Query view as-is: one table
+// column per results.Columns entry, one row per result row, whatever the
+// value types (nodes, edges, scalars, aggregates).
+func (m *model) updateResultTable(results *query.ResultSet) {
+	columns := make([]table.Column, len(results.Columns))
+	for i, col := range results.Columns {
+		columns[i] = table.Column{Title: col, Width: lipgloss.Width(col)}
+	}
+
+	rows := make([]table.Row, 0, len(results.Rows))
+	for _, row := range results.Rows {
+		cells := make(table.Row, len(results.Columns))
+		for i, col := range results.Columns {
+			cells[i] = formatResultValue(row[col])
+			if w := lipgloss.Width(cells[i]); w > columns[i].Width {
+				columns[i].Width = w
+			}
+		}
+		rows = append(rows, cells)
+	}
+	for i := range columns {
+		columns[i].Width = min(max(columns[i].Width, 6), maxResultColumnWidth)
+	}
+
+	// Clear rows first: the table renders existing rows against the new
+	// columns, which panics if the column count shrinks.
+	m.resultTable.SetRows(nil)
+	m.resultTable.SetColumns(columns)
+	m.resultTable.SetRows(rows)
+	m.resultTable.SetHeight(min(max(len(rows), 1), maxResultRows) + 2) // + header and its border
+}
+
+// formatResultValue renders one result cell.
+func formatResultValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case *storage.Node:
+		label := "Node"
+		if len(val.Labels) > 0 {
+			label = strings.Join(val.Labels, ":")
+		}
+		if name, ok := val.Properties["name"]; ok {
+			return fmt.Sprintf("(%s %d %s)", label, val.ID, name)
+		}
+		return fmt.Sprintf("(%s %d)", label, val.ID)
+	case *storage.Edge:
+		return fmt.Sprintf("[%d -%s-> %d]", val.FromNodeID, val.Type, val.ToNodeID)
+	case storage.Value:
+		return val.String()
+	case float64:
+		return fmt.Sprintf("%g", val)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
 func (m *model) updateNodeTable(results *query.ResultSet) {
 	rows := make([]table.Row, 0)
 
 	for _, row := range results.Rows {
 		for _, col := range results.Columns {
 			if node, ok := row[col].(*storage.Node); ok {
-				labels := strings.Join(node.Labels, ", ")
-				props := formatProperties(node.Properties)
-				rows = append(rows, table.Row{
-					fmt.Sprintf("%d", node.ID),
-					labels,
-					props,
-				})
+				rows = append(rows, nodeRow(node))
 			}
 		}
 	}
 
 	if len(rows) > 0 {
 		m.nodeTable.SetRows(rows)
+		m.nodeTable.GotoTop()
+		m.nodesLoaded = true
+	}
+}
+
+// nodeRow renders a node as a node table row.
+func nodeRow(node *storage.Node) table.Row {
+	return table.Row{
+		fmt.Sprintf("%d", node.ID),
+		strings.Join(node.Labels, ", "),
+		formatProperties(node.Properties),
 	}
 }
 
@@ -413,6 +679,51 @@ func (m model) renderTabs() string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs...)
 }
 
+// renderOperationStats formats per-mutation counts and average latencies
+// for the dashboard, one line per operation in a fixed order.
+func renderOperationStats(ops map[string]storage.OperationStats) string {
+	order := []string{
+		storage.OpCreateNode, storage.OpUpdateNode, storage.OpDeleteNode,
+		storage.OpCreateEdge, storage.OpUpdateEdge, storage.OpDeleteEdge,
+	}
+	lines := make([]string, 0, len(order))
+	for _, op := range order {
+		st := ops[op]
+		lines = append(lines, fmt.Sprintf("%-12s %6d  %.2f ms", op, st.Count, st.AvgLatencyMs))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// maxBreakdownRows caps each list in the dashboard's breakdown box.
+const maxBreakdownRows = 8
+
+// renderCounts formats a label or edge-type breakdown, largest first (ties
+// by name), folding anything past maxBreakdownRows into one line.
+func renderCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "(none)"
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	lines := make([]string, 0, maxBreakdownRows+1)
+	for i, name := range names {
+		if i == maxBreakdownRows {
+			lines = append(lines, fmt.Sprintf("… %d more", len(names)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%-14s %6d", name, counts[name]))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m model) renderDashboard() string {
 	uptime := time.Since(m.startTime).Round(time.Second)
 
@@ -425,12 +736,17 @@ Queries:   %d
 
 ⚡ Performance
 ━━━━━━━━━━━━━━━
-Avg Query: %.2f ms`,
+Avg Query: %.2f ms
+
+✏️  Writes
+━━━━━━━━━━━━━━━
+%s`,
 		m.stats.NodeCount,
 		m.stats.EdgeCount,
 		uptime,
 		m.stats.TotalQueries,
 		m.stats.AvgQueryTime,
+		renderOperationStats(m.stats.Operations),
 	)
 
 	quickActions := `⚡ Quick Actions
@@ -446,15 +762,31 @@ Avg Query: %.2f ms`,
 • Real-time Metrics
 • Visual Graph View`
 
+	breakdown := fmt.Sprintf(`🏷️  Labels
+━━━━━━━━━━━━━━━
+%s
+
+🔗 Edge Types
+━━━━━━━━━━━━━━━
+%s`,
+		renderCounts(m.labelCounts),
+		renderCounts(m.typeCounts),
+	)
+
 	statsBox := statsBoxStyle.Render(statsContent)
+	breakdownBox := statsBoxStyle.Render(breakdown)
 	actionsBox := statsBoxStyle.Render(quickActions)
 
 	return contentStyle.Render(
-		lipgloss.JoinHorizontal(lipgloss.Top, statsBox, actionsBox),
+		lipgloss.JoinHorizontal(lipgloss.Top, statsBox, breakdownBox, actionsBox),
 	)
 }
 
 func (m model) renderNodes() string {
+	if m.detail != nil {
+		return m.renderNodeDetail()
+	}
+
 	var s strings.Builder
 
 	s.WriteString(headerStyle.Render("Node Browser"))
@@ -463,7 +795,7 @@ func (m model) renderNodes() string {
 	s.WriteString(m.nodeTable.View())
 
 	s.WriteString("\n\n")
-	s.WriteString(helpStyle.Render("Navigate with ↑/↓ • Press 'r' to refresh"))
+	s.WriteString(helpStyle.Render("Navigate with ↑/↓ • enter for details • Press 'r' to refresh"))
 
 	return contentStyle.Render(s.String())
 }
@@ -477,6 +809,14 @@ func (m model) renderQuery() string {
 	s.WriteString("Enter a Cypher-like query:\n\n")
 	s.WriteString(m.queryInput.View())
 
+	if len(m.resultTable.Columns()) > 0 {
+		s.WriteString("\n\n")
+		s.WriteString(m.resultTable.View())
+		if n := len(m.resultTable.Rows()); n > maxResultRows {
+			s.WriteString(helpStyle.Render(fmt.Sprintf("\nShowing %d of %d rows", maxResultRows, n)))
+		}
+	}
+
 	s.WriteString("\n\n")
 	s.WriteString(helpStyle.Render("Examples:\n"))
 	s.WriteString(helpStyle.Render("  MATCH (n:Person) RETURN n\n"))
@@ -510,12 +850,18 @@ func (m model) generateGraphViz() string {
 	s.WriteString(fmt.Sprintf("Graph with %d nodes and %d edges\n\n", stats.NodeCount, stats.EdgeCount))
 
 	// Show first few nodes with connections
-	maxDisplay := 5
-	if stats.NodeCount < 5 {
-		maxDisplay = int(stats.NodeCount)
+	const maxDisplay = 5
+	nodeIDs := m.graph.GetAllNodeIDs()
+	total := len(nodeIDs)
+	if total > maxDisplay {
+		nodeIDs = nodeIDs[:maxDisplay]
+	}
+	shown := make(map[uint64]bool, len(nodeIDs))
+	for _, id := range nodeIDs {
+		shown[id] = true
 	}
 
-	for i := uint64(1); i <= uint64(maxDisplay); i++ {
+	for _, i := range nodeIDs {
 		node, err := m.graph.GetNode(i)
 		if err != nil {
 			continue
@@ -537,103 +883,74 @@ func (m model) generateGraphViz() string {
 		edges, err := m.graph.GetOutgoingEdges(i)
 		if err == nil && len(edges) > 0 {
 			for _, edge := range edges {
-				if edge.ToNodeID <= uint64(maxDisplay) {
+				if shown[edge.ToNodeID] {
 					s.WriteString(fmt.Sprintf("  └─[%s]→ Node %d\n", edge.Type, edge.ToNodeID))
 				}
 			}
 		}
 	}
 
-	if stats.NodeCount > uint64(maxDisplay) {
-		s.WriteString(fmt.Sprintf("\n... and %d more nodes\n", stats.NodeCount-uint64(maxDisplay)))
+	if total > maxDisplay {
+		s.WriteString(fmt.Sprintf("\n... and %d more nodes\n", total-maxDisplay))
 	}
 
 	return s.String()
 }
 
+// cachedNote marks a figure that was reused rather than recomputed.
+func cachedNote(cached bool) string {
+	if cached {
+		return " (cached)"
+	}
+	return ""
+}
+
 func (m model) renderMetrics() string {
 	var s strings.Builder
 
 	s.WriteString(headerStyle.Render("Performance Metrics"))
 	s.WriteString("\n\n")
 
-	// Run PageRank if we have nodes
-	if m.stats.NodeCount > 0 {
-		opts := algorithms.PageRankOptions{
-			MaxIterations: 10,
-			DampingFactor: 0.85,
-			Tolerance:     1e-6,
-		}
-
-		start := time.Now()
-		result, _ := algorithms.PageRank(m.graph, opts)
-		elapsed := time.Since(start)
+	result := m.pageRank
+	if result == nil {
+		s.WriteString(helpStyle.Render("No data available for metrics\n\nCreate some nodes and edges to see analytics!"))
+		return contentStyle.Render(s.String())
+	}
 
-		metricsContent := fmt.Sprintf(`📈 PageRank Analysis
+	metricsContent := fmt.Sprintf(`📈 PageRank Analysis
 ━━━━━━━━━━━━━━━━━━━
 Iterations:  %d
 Converged:   %v
-Time:        %s
+Time:        %s%s
 Nodes:       %d
 
 Top Nodes by PageRank:`,
-			result.Iterations,
-			result.Converged,
-			elapsed,
-			len(result.Scores),
-		)
-
-		s.WriteString(statsBoxStyle.Render(metricsContent))
-		s.WriteString("\n\n")
-
-		// Show top nodes
-		topNodes := getTopPageRankNodes(result.Scores, 5)
-		for i, score := range topNodes {
-			node, err := m.graph.GetNode(score.NodeID)
-			if err != nil {
-				continue
-			}
+		result.Iterations,
+		result.Converged,
+		m.pageRankDur,
+		cachedNote(m.pageRankHit),
+		len(result.Scores),
+	)
 
-			name := fmt.Sprintf("Node %d", score.NodeID)
-			if nameVal, ok := node.Properties["name"]; ok {
-				name = fmt.Sprintf("%v", nameVal)
-			}
+	s.WriteString(statsBoxStyle.Render(metricsContent))
+	s.WriteString("\n\n")
 
-			bar := strings.Repeat("█", int(score.Score*50))
-			s.WriteString(fmt.Sprintf("  %d. %-15s %.6f %s\n", i+1, name, score.Score, bar))
+	first := m.metricsPage * metricsPageSize
+	last := min(first+metricsPageSize, len(result.TopNodes))
+	for i, score := range result.TopNodes[first:last] {
+		name := fmt.Sprintf("Node %d", score.NodeID)
+		if nameVal, ok := score.Node.Properties["name"]; ok {
+			name = fmt.Sprintf("%v", nameVal)
 		}
-	} else {
-		s.WriteString(helpStyle.Render("No data available for metrics\n\nCreate some nodes and edges to see analytics!"))
-	}
-
-	return contentStyle.Render(s.String())
-}
 
-type nodeScore struct {
-	NodeID uint64
-	Score  float64
-}
-
-func getTopPageRankNodes(scores map[uint64]float64, limit int) []nodeScore {
-	nodeScores := make([]nodeScore, 0, len(scores))
-	for id, score := range scores {
-		nodeScores = append(nodeScores, nodeScore{NodeID: id, Score: score})
+		bar := strings.Repeat("█", int(score.Score*50))
+		s.WriteString(fmt.Sprintf("  %3d. %-15s %.6f %s\n", first+i+1, name, score.Score, bar))
 	}
 
-	// Simple bubble sort for top N
-	for i := 0; i < len(nodeScores); i++ {
-		for j := i + 1; j < len(nodeScores); j++ {
-			if nodeScores[j].Score > nodeScores[i].Score {
-				nodeScores[i], nodeScores[j] = nodeScores[j], nodeScores[i]
-			}
-		}
-	}
+	s.WriteString(helpStyle.Render(fmt.Sprintf("Page %d/%d • ←/→ change page • tab away and back to recompute",
+		m.metricsPage+1, m.metricsPages())))
 
-	if len(nodeScores) > limit {
-		nodeScores = nodeScores[:limit]
-	}
-
-	return nodeScores
+	return contentStyle.Render(s.String())
 }
 
 func main() {
@@ -648,7 +965,10 @@ func main() {
 	}
 	defer graph.Close()
 
-	p := tea.NewProgram(initialModel(graph), tea.WithAltScreen())
+	changes, unsubscribe := graph.Subscribe()
+	defer unsubscribe()
+
+	p := tea.NewProgram(initialModel(graph, changes), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		log.Fatalf("Error running program: %v", err)
 	}
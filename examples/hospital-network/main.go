@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/dd0wney/graphdb/pkg/algorithms"
+	"github.com/dd0wney/graphdb/pkg/query"
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
@@ -111,6 +112,9 @@ func main() {
 	analyseBetweenness(flat, "FLAT")
 	analyseBetweenness(segmented, "SEGMENTED")
 
+	// 6. VLAN suggestion from lateral-movement conflicts
+	analyseVLANZones(flat)
+
 	// Final summary
 	fmt.Println()
 	fmt.Println("=========================================================================")
@@ -890,11 +894,7 @@ func analyseBlastRadius(model *HospitalModel, label string) map[string]ZoneStats
 		log.Fatalf("Failed to compute blast radius for %s: %v", label, err)
 	}
 
-	// Build zone totals from the model
-	zoneTotals := make(map[string]int)
-	for _, info := range model.Nodes {
-		zoneTotals[info.Zone]++
-	}
+	zoneTotals := zoneSizes(model.Graph)
 
 	// Count infected nodes per zone
 	zoneInfected := make(map[string]int)
@@ -981,6 +981,31 @@ func analyseBlastRadius(model *HospitalModel, label string) map[string]ZoneStats
 	return result
 }
 
+// zoneSizes counts the nodes of each zone with a grouped query.
+func zoneSizes(graph *storage.GraphStorage) map[string]int {
+	const q = `MATCH (n) RETURN n.zone AS zone, COUNT(n) AS total`
+	tokens, err := query.NewLexer(q).Tokenize()
+	if err != nil {
+		log.Fatalf("Failed to tokenize query: %v", err)
+	}
+	parsed, err := query.NewParser(tokens).Parse()
+	if err != nil {
+		log.Fatalf("Failed to parse query: %v", err)
+	}
+	result, err := query.NewExecutor(graph).Execute(parsed)
+	if err != nil {
+		log.Fatalf("Failed to run query: %v", err)
+	}
+
+	sizes := make(map[string]int, len(result.Rows))
+	for _, row := range result.Rows {
+		zone, _ := row["zone"].(string)
+		total, _ := row["total"].(int)
+		sizes[zone] = total
+	}
+	return sizes
+}
+
 // analyseBetweenness computes betweenness centrality and shows the most
 // critical chokepoints in the network.
 func analyseBetweenness(model *HospitalModel, label string) {
@@ -1002,8 +1027,8 @@ func analyseBetweenness(model *HospitalModel, label string) {
 	}
 
 	var ranked []rankedNode
-	for nodeID, score := range bc {
-		name, exists := model.NodeByID[nodeID]
+	for _, entry := range bc.Sorted() {
+		name, exists := model.NodeByID[entry.NodeID]
 		if !exists {
 			continue
 		}
@@ -1011,14 +1036,10 @@ func analyseBetweenness(model *HospitalModel, label string) {
 		ranked = append(ranked, rankedNode{
 			Name:  name,
 			Zone:  info.Zone,
-			Score: score,
+			Score: entry.Score,
 		})
 	}
 
-	sort.Slice(ranked, func(i, j int) bool {
-		return ranked[i].Score > ranked[j].Score
-	})
-
 	fmt.Printf("  %-4s %-28s %-22s %s\n", "Rank", "Node", "Zone", "BC Score")
 	fmt.Println("  " + strings.Repeat("-", 70))
 
@@ -1033,6 +1054,56 @@ func analyseBetweenness(model *HospitalModel, label string) {
 	fmt.Println()
 }
 
+// analyseVLANZones colours the flat network's SMB lateral-movement graph so
+// that no two hosts that can reach each other over SMB share a VLAN. Greedy
+// colouring is a heuristic: the VLAN count is a workable upper bound, not the
+// proven minimum.
+func analyseVLANZones(model *HospitalModel) {
+	fmt.Println()
+	fmt.Println("=========================================================================")
+	fmt.Println(" 6. Suggested VLAN Assignment -- FLAT Network")
+	fmt.Println("=========================================================================")
+	fmt.Println()
+
+	colors, numColors, err := algorithms.GreedyColoringWithOptions(model.Graph, algorithms.ColoringOptions{
+		EdgeTypes: []string{"SMB_LATERAL"},
+	})
+	if err != nil {
+		log.Fatalf("Failed to colour lateral-movement graph: %v", err)
+	}
+
+	// Only hosts with at least one SMB link constrain the assignment.
+	vlans := make([][]string, numColors)
+	for name, info := range model.Nodes {
+		if hasEdgeOfType(model, info.ID, "SMB_LATERAL") {
+			vlans[colors[info.ID]] = append(vlans[colors[info.ID]], name)
+		}
+	}
+
+	fmt.Println("  Hosts joined by SMB_LATERAL links must not share a VLAN.")
+	fmt.Printf("  Greedy colouring needs %d VLANs (a heuristic upper bound,\n", numColors)
+	fmt.Println("  not necessarily the minimum possible).")
+	fmt.Println()
+	for i, members := range vlans {
+		sort.Strings(members)
+		fmt.Printf("  VLAN %d: %s\n", 100+i*10, strings.Join(members, ", "))
+	}
+	fmt.Println()
+}
+
+// hasEdgeOfType reports whether the node has an edge of edgeType in either
+// direction, so hosts that are only ever the target of a link count too.
+func hasEdgeOfType(model *HospitalModel, id uint64, edgeType string) bool {
+	out, _ := model.Graph.GetOutgoingEdges(id)
+	in, _ := model.Graph.GetIncomingEdges(id)
+	for _, e := range append(out, in...) {
+		if e.Type == edgeType {
+			return true
+		}
+	}
+	return false
+}
+
 // analyseSegmentationImpact prints a side-by-side comparison of blast radius
 // between the flat and segmented networks. This is the emotional peak of the
 // analysis -- showing how segmentation prevents patient harm.
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"strings"
 
 	"github.com/dd0wney/graphdb/pkg/algorithms"
 	"github.com/dd0wney/graphdb/pkg/constraints"
+	"github.com/dd0wney/graphdb/pkg/query"
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
@@ -278,6 +280,9 @@ func main() {
 		}, 1.0)
 	fmt.Println("   ✓ CHANGE_AUTHORITY: Standard Change → Historian")
 
+	// Flow properties are reachable from the query language.
+	printDiodeFlows(graph)
+
 	fmt.Println("\n3. Running ISO 15288 System Validation...")
 
 	// ========================================
@@ -299,7 +304,10 @@ func main() {
 	if len(cycles) == 0 {
 		fmt.Println("         ✓ No cycles detected")
 	} else {
-		fmt.Printf("         ✗ Found %d cycles\n", len(cycles))
+		fmt.Printf("         ✗ Found %d cycles:\n", len(cycles))
+		for _, cycle := range cycles {
+			fmt.Printf("            - %s\n", describeCycle(graph, cycle))
+		}
 	}
 
 	// ========================================
@@ -341,7 +349,7 @@ func main() {
 	} else {
 		fmt.Printf("         ✗ Found %d priority inversions:\n", len(violations))
 		for _, v := range violations {
-			fmt.Printf("            - %s\n", v)
+			fmt.Printf("            - %s\n", describeViolation(graph, v))
 		}
 	}
 
@@ -355,7 +363,7 @@ func main() {
 	} else {
 		fmt.Printf("         ✗ Found %d Steve Test violations:\n", len(steveViolations))
 		for _, v := range steveViolations {
-			fmt.Printf("            - %s\n", v)
+			fmt.Printf("            - %s\n", describeViolation(graph, v))
 		}
 	}
 
@@ -380,64 +388,111 @@ func main() {
 	fmt.Printf("   Total Flows: %d\n", stats.EdgeCount)
 }
 
-// checkPriorityFlows validates that all flows respect priority ordering
-func checkPriorityFlows(graph *storage.GraphStorage) []string {
-	violations := []string{}
-	stats := graph.GetStatistics()
+// describeCycle renders a cycle as "a -> b -> ... -> a" using each
+// element's name, role or function so the offending loop can be found.
+func describeCycle(graph *storage.GraphStorage, cycle algorithms.Cycle) string {
+	names := make([]string, 0, len(cycle)+1)
+	for _, id := range cycle {
+		names = append(names, elementName(graph, id))
+	}
+	names = append(names, names[0])
+	return strings.Join(names, " -> ")
+}
 
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		fromNode, err := graph.GetNode(i)
-		if err != nil {
-			continue
+// elementName labels a node by its name, role or function property, falling
+// back to its labels and ID.
+func elementName(graph *storage.GraphStorage, id uint64) string {
+	node, err := graph.GetNode(id)
+	if err != nil {
+		return fmt.Sprintf("#%d", id)
+	}
+	for _, key := range []string{"name", "role", "function"} {
+		if v, ok := node.GetProperty(key); ok {
+			if s, err := v.AsString(); err == nil {
+				return s
+			}
 		}
+	}
+	return fmt.Sprintf("%s#%d", strings.Join(node.Labels, ":"), id)
+}
 
-		fromPriority, ok := fromNode.GetProperty("priority")
-		if !ok {
+// printDiodeFlows lists the data flows enforced by a data diode, filtering
+// on the edge's enforcement property in a query.
+func printDiodeFlows(graph *storage.GraphStorage) {
+	const q = `MATCH (a)-[f:DATA_FLOW]->(b) WHERE f.enforcement = 'data_diode' RETURN a, b, f.bandwidth`
+	tokens, err := query.NewLexer(q).Tokenize()
+	if err != nil {
+		log.Fatalf("Failed to tokenize query: %v", err)
+	}
+	parsed, err := query.NewParser(tokens).Parse()
+	if err != nil {
+		log.Fatalf("Failed to parse query: %v", err)
+	}
+	result, err := query.NewExecutor(graph).Execute(parsed)
+	if err != nil {
+		log.Fatalf("Failed to run query: %v", err)
+	}
+	fmt.Printf("   Data-diode flows (%s):\n", q)
+	for _, row := range result.Rows {
+		from, _ := row["a."].(*storage.Node)
+		to, _ := row["b."].(*storage.Node)
+		if from == nil || to == nil {
 			continue
 		}
-		fromPriorityInt, _ := fromPriority.AsInt()
-
-		outgoing, _ := graph.GetOutgoingEdges(i)
-		for _, edge := range outgoing {
-			// Skip certain edge types that don't follow priority flow
-			if edge.Type == "PHYSICAL_ACCESS" || edge.Type == "INFORMATION_FLOW" {
-				continue
-			}
-
-			toNode, _ := graph.GetNode(edge.ToNodeID)
-			toPriority, ok := toNode.GetProperty("priority")
-			if !ok {
-				continue
-			}
-			toPriorityInt, _ := toPriority.AsInt()
-
-			// Check: high priority should NOT flow to higher priority
-			// (except for valid upward flows like INFORMATION_FLOW)
-			if edge.Type == "COMMAND_AUTHORITY" && fromPriorityInt < toPriorityInt {
-				fromName, _ := fromNode.GetProperty("role")
-				toName, _ := toNode.GetProperty("function")
-				fromNameStr, _ := fromName.AsString()
-				toNameStr, _ := toName.AsString()
-
-				violations = append(violations, fmt.Sprintf(
-					"%s: %s (priority %d) → %s (priority %d)",
-					edge.Type, fromNameStr, fromPriorityInt, toNameStr, toPriorityInt,
-				))
-			}
-		}
+		fmt.Printf("      %s → %s (bandwidth %v)\n",
+			elementName(graph, from.ID), elementName(graph, to.ID), row["f.bandwidth"])
 	}
-
-	return violations
 }
 
-// runSteveTest checks for low-priority humans with high betweenness
-func runSteveTest(graph *storage.GraphStorage) []string {
-	violations := []string{}
+// checkPriorityFlows validates that command authority respects priority
+// ordering, declared as edge constraints on the endpoints' labels.
+func checkPriorityFlows(graph *storage.GraphStorage) []constraints.Violation {
+	validator := constraints.NewValidator()
+
+	// Only humans may hold command authority, and only over technical systems.
+	validator.AddConstraint(&constraints.EdgeConstraint{
+		EdgeType:   "COMMAND_AUTHORITY",
+		FromLabels: []string{"Human"},
+		ToLabels:   []string{"Technical"},
+	})
+
+	// Priority inversion: commanding a safety-critical system requires
+	// safety certification.
+	validator.AddConstraint(&constraints.EdgeConstraint{
+		EdgeType:   "COMMAND_AUTHORITY",
+		FromLabels: []string{"!SafetyCertified"},
+		ToLabels:   []string{"SafetyCritical"},
+		Forbidden:  true,
+	})
 
-	// Note: BetweennessCentrality not yet implemented in this demo
-	// This is a placeholder showing how it would be used
+	result, err := validator.Validate(graph)
+	if err != nil {
+		log.Fatalf("Priority flow validation failed: %v", err)
+	}
+	return result.Violations
+}
 
-	fmt.Println("         (Betweenness centrality calculation skipped in demo)")
+// describeViolation renders a violation using element names: edge
+// violations as "TYPE: from → to", Steve Test findings as "who bridges
+// what", anything else by its own message.
+func describeViolation(graph *storage.GraphStorage, v constraints.Violation) string {
+	if bridged, ok := v.Details["bridged_node"].(uint64); ok && v.NodeID != nil {
+		return fmt.Sprintf("%s (betweenness %.3f) bridges %s",
+			elementName(graph, *v.NodeID), v.Details["betweenness"], elementName(graph, bridged))
+	}
+	if v.EdgeID == nil {
+		return v.Message
+	}
+	edge, err := graph.GetEdge(*v.EdgeID)
+	if err != nil {
+		return v.Message
+	}
+	return fmt.Sprintf("%s: %s → %s",
+		edge.Type, elementName(graph, edge.FromNodeID), elementName(graph, edge.ToNodeID))
+}
 
-	return violations
+// runSteveTest checks for humans below operator priority (1000) with high
+// betweenness that bridge systems at or above it.
+func runSteveTest(graph *storage.GraphStorage) []constraints.Violation {
+	return constraints.SteveTest(graph, "Human", "priority", 1000, 0.01)
 }
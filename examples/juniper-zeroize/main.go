@@ -191,10 +191,17 @@ func buildISPNetwork(dataDir string, excludedNodes []string) (*ISPModel, error)
 		}
 	}
 
-	// Helper to create a bidirectional (undirected) edge pair.
-	addBiEdge := func(aName, bName, edgeType string) {
-		addEdge(aName, bName, edgeType)
-		addEdge(bName, aName, edgeType)
+	// Helper to create an undirected edge, stored once and traversed both ways.
+	addUndirectedEdge := func(aName, bName, edgeType string) {
+		a := model.Nodes[aName]
+		b := model.Nodes[bName]
+		if a == nil || b == nil {
+			return // skip edges involving excluded nodes
+		}
+		_, err := gs.CreateUndirectedEdge(a.ID, b.ID, edgeType, map[string]storage.Value{}, 1.0)
+		if err != nil {
+			log.Fatalf("Failed to create edge %s -- %s: %v", aName, bName, err)
+		}
 	}
 
 	// ---------------------------------------------------------------
@@ -262,52 +269,52 @@ func buildISPNetwork(dataDir string, excludedNodes []string) (*ISPModel, error)
 	addEdge("Upstream_Provider_A", "Core_Router_A", "BGP_PEER")
 	addEdge("Upstream_Provider_B", "Core_Router_B", "BGP_PEER")
 
-	// Core interconnections (bidirectional trunks)
-	addBiEdge("Core_Router_A", "Core_Router_B", "TRUNK")
-	addBiEdge("Core_Router_A", "Agg_Switch_Hub", "TRUNK")
-	addBiEdge("Core_Router_B", "Agg_Switch_Hub", "TRUNK")
-	addBiEdge("Core_Router_A", "Route_Reflector", "TRUNK")
-	addBiEdge("Core_Router_B", "Route_Reflector", "TRUNK")
-
-	// Hub aggregation to PoP aggregation (bidirectional distribution)
-	addBiEdge("Agg_Switch_Hub", "Agg_Switch_PoP1", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub", "Agg_Switch_PoP2", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub", "Agg_Switch_PoP3", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub", "Agg_Switch_PoP4", "DISTRIBUTION")
-
-	// PoP aggregation to access switches (bidirectional)
-	addBiEdge("Agg_Switch_PoP1", "Access_Switch_PoP1", "ACCESS")
-	addBiEdge("Agg_Switch_PoP2", "Access_Switch_PoP2", "ACCESS")
-	addBiEdge("Agg_Switch_PoP3", "Access_Switch_PoP3", "ACCESS")
-	addBiEdge("Agg_Switch_PoP4", "Access_Switch_PoP4", "ACCESS")
-
-	// Access switches to customer edge routers (bidirectional)
-	addBiEdge("Access_Switch_PoP1", "Hospital_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP1", "Clinic_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP2", "Water_Utility_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP2", "Emergency_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP3", "Power_Company_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP3", "Manufacturer_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP4", "Gov_Firewall", "CUSTOMER_PE")
-
-	// Customer edge to customer systems (bidirectional)
-	addBiEdge("Hospital_CE", "Hospital_EHR", "CUSTOMER_LAN")
-	addBiEdge("Clinic_CE", "Clinic_Systems", "CUSTOMER_LAN")
-	addBiEdge("Water_Utility_CE", "Water_SCADA", "CUSTOMER_LAN")
-	addBiEdge("Emergency_CE", "E911_System", "CUSTOMER_LAN")
-	addBiEdge("Power_Company_CE", "Power_EMS", "CUSTOMER_LAN")
-	addBiEdge("Manufacturer_CE", "Manufacturing_MES", "CUSTOMER_LAN")
-	addBiEdge("Gov_Firewall", "Municipal_Systems", "CUSTOMER_LAN")
-	addBiEdge("Gov_Firewall", "Police_CAD", "CUSTOMER_LAN")
-	addBiEdge("Gov_Firewall", "Fire_Dispatch", "CUSTOMER_LAN")
-
-	// Management plane connections (bidirectional)
-	addBiEdge("Agg_Switch_Hub", "NOC_Workstation", "MANAGEMENT")
-	addBiEdge("NOC_Workstation", "Jump_Host", "MANAGEMENT")
-	addBiEdge("NOC_Workstation", "SNMP_Monitor", "MANAGEMENT")
-	addBiEdge("NOC_Workstation", "Syslog_Server", "MANAGEMENT")
-	addBiEdge("Jump_Host", "TACACS_Server", "MANAGEMENT")
-	addBiEdge("Jump_Host", "Oxidized_Server", "MANAGEMENT")
+	// Core interconnections (undirected trunks)
+	addUndirectedEdge("Core_Router_A", "Core_Router_B", "TRUNK")
+	addUndirectedEdge("Core_Router_A", "Agg_Switch_Hub", "TRUNK")
+	addUndirectedEdge("Core_Router_B", "Agg_Switch_Hub", "TRUNK")
+	addUndirectedEdge("Core_Router_A", "Route_Reflector", "TRUNK")
+	addUndirectedEdge("Core_Router_B", "Route_Reflector", "TRUNK")
+
+	// Hub aggregation to PoP aggregation (undirected distribution)
+	addUndirectedEdge("Agg_Switch_Hub", "Agg_Switch_PoP1", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub", "Agg_Switch_PoP2", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub", "Agg_Switch_PoP3", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub", "Agg_Switch_PoP4", "DISTRIBUTION")
+
+	// PoP aggregation to access switches (undirected)
+	addUndirectedEdge("Agg_Switch_PoP1", "Access_Switch_PoP1", "ACCESS")
+	addUndirectedEdge("Agg_Switch_PoP2", "Access_Switch_PoP2", "ACCESS")
+	addUndirectedEdge("Agg_Switch_PoP3", "Access_Switch_PoP3", "ACCESS")
+	addUndirectedEdge("Agg_Switch_PoP4", "Access_Switch_PoP4", "ACCESS")
+
+	// Access switches to customer edge routers (undirected)
+	addUndirectedEdge("Access_Switch_PoP1", "Hospital_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP1", "Clinic_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP2", "Water_Utility_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP2", "Emergency_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP3", "Power_Company_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP3", "Manufacturer_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP4", "Gov_Firewall", "CUSTOMER_PE")
+
+	// Customer edge to customer systems (undirected)
+	addUndirectedEdge("Hospital_CE", "Hospital_EHR", "CUSTOMER_LAN")
+	addUndirectedEdge("Clinic_CE", "Clinic_Systems", "CUSTOMER_LAN")
+	addUndirectedEdge("Water_Utility_CE", "Water_SCADA", "CUSTOMER_LAN")
+	addUndirectedEdge("Emergency_CE", "E911_System", "CUSTOMER_LAN")
+	addUndirectedEdge("Power_Company_CE", "Power_EMS", "CUSTOMER_LAN")
+	addUndirectedEdge("Manufacturer_CE", "Manufacturing_MES", "CUSTOMER_LAN")
+	addUndirectedEdge("Gov_Firewall", "Municipal_Systems", "CUSTOMER_LAN")
+	addUndirectedEdge("Gov_Firewall", "Police_CAD", "CUSTOMER_LAN")
+	addUndirectedEdge("Gov_Firewall", "Fire_Dispatch", "CUSTOMER_LAN")
+
+	// Management plane connections (undirected)
+	addUndirectedEdge("Agg_Switch_Hub", "NOC_Workstation", "MANAGEMENT")
+	addUndirectedEdge("NOC_Workstation", "Jump_Host", "MANAGEMENT")
+	addUndirectedEdge("NOC_Workstation", "SNMP_Monitor", "MANAGEMENT")
+	addUndirectedEdge("NOC_Workstation", "Syslog_Server", "MANAGEMENT")
+	addUndirectedEdge("Jump_Host", "TACACS_Server", "MANAGEMENT")
+	addUndirectedEdge("Jump_Host", "Oxidized_Server", "MANAGEMENT")
 
 	return model, nil
 }
@@ -368,9 +375,16 @@ func buildDualAggNetwork(dataDir string, excludedNodes []string) (*ISPModel, err
 		}
 	}
 
-	addBiEdge := func(aName, bName, edgeType string) {
-		addEdge(aName, bName, edgeType)
-		addEdge(bName, aName, edgeType)
+	addUndirectedEdge := func(aName, bName, edgeType string) {
+		a := model.Nodes[aName]
+		b := model.Nodes[bName]
+		if a == nil || b == nil {
+			return
+		}
+		_, err := gs.CreateUndirectedEdge(a.ID, b.ID, edgeType, map[string]storage.Value{}, 1.0)
+		if err != nil {
+			log.Fatalf("Failed to create edge %s -- %s: %v", aName, bName, err)
+		}
 	}
 
 	// ---------------------------------------------------------------
@@ -437,59 +451,59 @@ func buildDualAggNetwork(dataDir string, excludedNodes []string) (*ISPModel, err
 	addEdge("Upstream_Provider_B", "Core_Router_B", "BGP_PEER")
 
 	// Core interconnections
-	addBiEdge("Core_Router_A", "Core_Router_B", "TRUNK")
-	addBiEdge("Core_Router_A", "Agg_Switch_Hub_A", "TRUNK")
-	addBiEdge("Core_Router_A", "Agg_Switch_Hub_B", "TRUNK")
-	addBiEdge("Core_Router_B", "Agg_Switch_Hub_A", "TRUNK")
-	addBiEdge("Core_Router_B", "Agg_Switch_Hub_B", "TRUNK")
-	addBiEdge("Agg_Switch_Hub_A", "Agg_Switch_Hub_B", "TRUNK")
-	addBiEdge("Core_Router_A", "Route_Reflector", "TRUNK")
-	addBiEdge("Core_Router_B", "Route_Reflector", "TRUNK")
+	addUndirectedEdge("Core_Router_A", "Core_Router_B", "TRUNK")
+	addUndirectedEdge("Core_Router_A", "Agg_Switch_Hub_A", "TRUNK")
+	addUndirectedEdge("Core_Router_A", "Agg_Switch_Hub_B", "TRUNK")
+	addUndirectedEdge("Core_Router_B", "Agg_Switch_Hub_A", "TRUNK")
+	addUndirectedEdge("Core_Router_B", "Agg_Switch_Hub_B", "TRUNK")
+	addUndirectedEdge("Agg_Switch_Hub_A", "Agg_Switch_Hub_B", "TRUNK")
+	addUndirectedEdge("Core_Router_A", "Route_Reflector", "TRUNK")
+	addUndirectedEdge("Core_Router_B", "Route_Reflector", "TRUNK")
 
 	// DUAL distribution — each PoP connects to BOTH hubs
-	addBiEdge("Agg_Switch_Hub_A", "Agg_Switch_PoP1", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub_B", "Agg_Switch_PoP1", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub_A", "Agg_Switch_PoP2", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub_B", "Agg_Switch_PoP2", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub_A", "Agg_Switch_PoP3", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub_B", "Agg_Switch_PoP3", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub_A", "Agg_Switch_PoP4", "DISTRIBUTION")
-	addBiEdge("Agg_Switch_Hub_B", "Agg_Switch_PoP4", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub_A", "Agg_Switch_PoP1", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub_B", "Agg_Switch_PoP1", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub_A", "Agg_Switch_PoP2", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub_B", "Agg_Switch_PoP2", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub_A", "Agg_Switch_PoP3", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub_B", "Agg_Switch_PoP3", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub_A", "Agg_Switch_PoP4", "DISTRIBUTION")
+	addUndirectedEdge("Agg_Switch_Hub_B", "Agg_Switch_PoP4", "DISTRIBUTION")
 
 	// PoP aggregation to access switches
-	addBiEdge("Agg_Switch_PoP1", "Access_Switch_PoP1", "ACCESS")
-	addBiEdge("Agg_Switch_PoP2", "Access_Switch_PoP2", "ACCESS")
-	addBiEdge("Agg_Switch_PoP3", "Access_Switch_PoP3", "ACCESS")
-	addBiEdge("Agg_Switch_PoP4", "Access_Switch_PoP4", "ACCESS")
+	addUndirectedEdge("Agg_Switch_PoP1", "Access_Switch_PoP1", "ACCESS")
+	addUndirectedEdge("Agg_Switch_PoP2", "Access_Switch_PoP2", "ACCESS")
+	addUndirectedEdge("Agg_Switch_PoP3", "Access_Switch_PoP3", "ACCESS")
+	addUndirectedEdge("Agg_Switch_PoP4", "Access_Switch_PoP4", "ACCESS")
 
 	// Access to customer edge
-	addBiEdge("Access_Switch_PoP1", "Hospital_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP1", "Clinic_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP2", "Water_Utility_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP2", "Emergency_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP3", "Power_Company_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP3", "Manufacturer_CE", "CUSTOMER_PE")
-	addBiEdge("Access_Switch_PoP4", "Gov_Firewall", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP1", "Hospital_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP1", "Clinic_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP2", "Water_Utility_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP2", "Emergency_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP3", "Power_Company_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP3", "Manufacturer_CE", "CUSTOMER_PE")
+	addUndirectedEdge("Access_Switch_PoP4", "Gov_Firewall", "CUSTOMER_PE")
 
 	// Customer LAN
-	addBiEdge("Hospital_CE", "Hospital_EHR", "CUSTOMER_LAN")
-	addBiEdge("Clinic_CE", "Clinic_Systems", "CUSTOMER_LAN")
-	addBiEdge("Water_Utility_CE", "Water_SCADA", "CUSTOMER_LAN")
-	addBiEdge("Emergency_CE", "E911_System", "CUSTOMER_LAN")
-	addBiEdge("Power_Company_CE", "Power_EMS", "CUSTOMER_LAN")
-	addBiEdge("Manufacturer_CE", "Manufacturing_MES", "CUSTOMER_LAN")
-	addBiEdge("Gov_Firewall", "Municipal_Systems", "CUSTOMER_LAN")
-	addBiEdge("Gov_Firewall", "Police_CAD", "CUSTOMER_LAN")
-	addBiEdge("Gov_Firewall", "Fire_Dispatch", "CUSTOMER_LAN")
+	addUndirectedEdge("Hospital_CE", "Hospital_EHR", "CUSTOMER_LAN")
+	addUndirectedEdge("Clinic_CE", "Clinic_Systems", "CUSTOMER_LAN")
+	addUndirectedEdge("Water_Utility_CE", "Water_SCADA", "CUSTOMER_LAN")
+	addUndirectedEdge("Emergency_CE", "E911_System", "CUSTOMER_LAN")
+	addUndirectedEdge("Power_Company_CE", "Power_EMS", "CUSTOMER_LAN")
+	addUndirectedEdge("Manufacturer_CE", "Manufacturing_MES", "CUSTOMER_LAN")
+	addUndirectedEdge("Gov_Firewall", "Municipal_Systems", "CUSTOMER_LAN")
+	addUndirectedEdge("Gov_Firewall", "Police_CAD", "CUSTOMER_LAN")
+	addUndirectedEdge("Gov_Firewall", "Fire_Dispatch", "CUSTOMER_LAN")
 
 	// Management plane — connects via BOTH hubs
-	addBiEdge("Agg_Switch_Hub_A", "NOC_Workstation", "MANAGEMENT")
-	addBiEdge("Agg_Switch_Hub_B", "NOC_Workstation", "MANAGEMENT")
-	addBiEdge("NOC_Workstation", "Jump_Host", "MANAGEMENT")
-	addBiEdge("NOC_Workstation", "SNMP_Monitor", "MANAGEMENT")
-	addBiEdge("NOC_Workstation", "Syslog_Server", "MANAGEMENT")
-	addBiEdge("Jump_Host", "TACACS_Server", "MANAGEMENT")
-	addBiEdge("Jump_Host", "Oxidized_Server", "MANAGEMENT")
+	addUndirectedEdge("Agg_Switch_Hub_A", "NOC_Workstation", "MANAGEMENT")
+	addUndirectedEdge("Agg_Switch_Hub_B", "NOC_Workstation", "MANAGEMENT")
+	addUndirectedEdge("NOC_Workstation", "Jump_Host", "MANAGEMENT")
+	addUndirectedEdge("NOC_Workstation", "SNMP_Monitor", "MANAGEMENT")
+	addUndirectedEdge("NOC_Workstation", "Syslog_Server", "MANAGEMENT")
+	addUndirectedEdge("Jump_Host", "TACACS_Server", "MANAGEMENT")
+	addUndirectedEdge("Jump_Host", "Oxidized_Server", "MANAGEMENT")
 
 	return model, nil
 }
@@ -595,17 +609,16 @@ func analyseBetweennessCentrality(model *ISPModel) {
 		Info  *NodeInfo
 	}
 	var entries []bcEntry
-	for name, info := range model.Nodes {
+	for _, ranked := range bc.Sorted() {
+		name := model.NodeByID[ranked.NodeID]
+		info := model.Nodes[name]
 		entries = append(entries, bcEntry{
 			Name:  name,
 			Zone:  info.Zone,
-			Score: bc[info.ID],
+			Score: ranked.Score,
 			Info:  info,
 		})
 	}
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Score > entries[j].Score
-	})
 
 	fmt.Println("--- Top 15 Nodes by Betweenness Centrality ---")
 	fmt.Println()
@@ -66,7 +66,7 @@ func Analyse(meta *Metadata, modelName string, bc map[uint64]float64, opts Analy
 	result := ModelResult{
 		ModelName:           modelName,
 		NodeCount:           int(stats.NodeCount),
-		EdgeCount:           int(stats.EdgeCount) / 2, // Divide by 2 since edges are bidirectional
+		EdgeCount:           int(stats.EdgeCount),
 		Rankings:            results,
 		InvisibleNodeShare:  invisibleShare,
 		TopInvisibleNode:    topInvisible.Name,
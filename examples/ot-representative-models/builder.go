@@ -15,7 +15,6 @@ type GraphBuilder struct {
 	nodeTypes     map[uint64]string
 	nodeLevels    map[uint64]string
 	nodeFunctions map[uint64]string
-	nodeIDs       map[string]uint64
 	err           error // captures first error for deferred checking
 }
 
@@ -32,7 +31,6 @@ func NewGraphBuilder(dataPath string) (*GraphBuilder, error) {
 		nodeTypes:     make(map[uint64]string),
 		nodeLevels:    make(map[uint64]string),
 		nodeFunctions: make(map[uint64]string),
-		nodeIDs:       make(map[string]uint64),
 	}, nil
 }
 
@@ -51,7 +49,9 @@ func (b *GraphBuilder) AddNode(def models.NodeDef) *GraphBuilder {
 		props["function"] = storage.StringValue(def.Function)
 	}
 
-	node, err := b.graph.CreateNode(def.Labels, props)
+	// The name doubles as the node's external key, so edges can refer to
+	// nodes by name without a separate name -> ID map.
+	node, err := b.graph.CreateNodeWithKey(def.Name, def.Labels, props)
 	if err != nil {
 		b.err = fmt.Errorf("failed to create node %s: %w", def.Name, err)
 		return b
@@ -63,7 +63,6 @@ func (b *GraphBuilder) AddNode(def models.NodeDef) *GraphBuilder {
 	if def.Function != "" {
 		b.nodeFunctions[node.ID] = def.Function
 	}
-	b.nodeIDs[def.Name] = node.ID
 
 	return b
 }
@@ -79,33 +78,28 @@ func (b *GraphBuilder) AddNodes(defs []models.NodeDef) *GraphBuilder {
 	return b
 }
 
-// AddUndirectedEdge creates edges in both directions to simulate undirected behaviour
+// AddUndirectedEdge creates a single undirected edge, traversable both ways
 func (b *GraphBuilder) AddUndirectedEdge(from, to, edgeType string) *GraphBuilder {
 	if b.err != nil {
 		return b
 	}
 
-	fromID, ok := b.nodeIDs[from]
-	if !ok {
+	fromNode, err := b.graph.GetNodeByKey(from)
+	if err != nil {
 		b.err = fmt.Errorf("node not found: %s", from)
 		return b
 	}
 
-	toID, ok := b.nodeIDs[to]
-	if !ok {
+	toNode, err := b.graph.GetNodeByKey(to)
+	if err != nil {
 		b.err = fmt.Errorf("node not found: %s", to)
 		return b
 	}
 
 	props := map[string]storage.Value{}
 
-	if _, err := b.graph.CreateEdge(fromID, toID, edgeType, props, 1.0); err != nil {
-		b.err = fmt.Errorf("failed to create forward edge %s -> %s: %w", from, to, err)
-		return b
-	}
-
-	if _, err := b.graph.CreateEdge(toID, fromID, edgeType, props, 1.0); err != nil {
-		b.err = fmt.Errorf("failed to create reverse edge %s -> %s: %w", to, from, err)
+	if _, err := b.graph.CreateUndirectedEdge(fromNode.ID, toNode.ID, edgeType, props, 1.0); err != nil {
+		b.err = fmt.Errorf("failed to create edge %s -- %s: %w", from, to, err)
 		return b
 	}
 
@@ -141,12 +135,12 @@ func (b *GraphBuilder) AddEdgePairs(pairs [][2]string, edgeType string) *GraphBu
 func (b *GraphBuilder) AddEdgePairsWithAutoType(pairs [][2]string, defaultType string) *GraphBuilder {
 	for _, pair := range pairs {
 		edgeType := defaultType
-		fromID := b.nodeIDs[pair[0]]
-		toID := b.nodeIDs[pair[1]]
+		fromType := b.nodeTypeByName(pair[0])
+		toType := b.nodeTypeByName(pair[1])
 
-		if b.nodeTypes[fromID] == models.NodeTypeProcess || b.nodeTypes[toID] == models.NodeTypeProcess {
+		if fromType == models.NodeTypeProcess || toType == models.NodeTypeProcess {
 			edgeType = models.EdgeTypeProcess
-		} else if b.nodeTypes[fromID] == models.NodeTypeHuman || b.nodeTypes[toID] == models.NodeTypeHuman {
+		} else if fromType == models.NodeTypeHuman || toType == models.NodeTypeHuman {
 			edgeType = models.EdgeTypeHumanAccess
 		}
 
@@ -158,6 +152,16 @@ func (b *GraphBuilder) AddEdgePairsWithAutoType(pairs [][2]string, defaultType s
 	return b
 }
 
+// nodeTypeByName returns the node type recorded for the named node, or ""
+// if no node holds that name.
+func (b *GraphBuilder) nodeTypeByName(name string) string {
+	node, err := b.graph.GetNodeByKey(name)
+	if err != nil {
+		return ""
+	}
+	return b.nodeTypes[node.ID]
+}
+
 // Error returns any error that occurred during building
 func (b *GraphBuilder) Error() error {
 	return b.err
@@ -177,7 +181,6 @@ func (b *GraphBuilder) Build() (*Metadata, error) {
 		NodeTypes:     b.nodeTypes,
 		NodeLevels:    b.nodeLevels,
 		NodeFunctions: b.nodeFunctions,
-		NodeIDs:       b.nodeIDs,
 	}, nil
 }
 
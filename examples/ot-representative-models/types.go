@@ -33,7 +33,6 @@ type Metadata struct {
 	NodeTypes     map[uint64]string // ID -> "technical", "human", "process", "external"
 	NodeLevels    map[uint64]string // ID -> level description
 	NodeFunctions map[uint64]string // ID -> function description (optional, used by telecom)
-	NodeIDs       map[string]uint64 // name -> ID (reverse lookup)
 }
 
 // BCResult holds betweenness centrality results for a single node.
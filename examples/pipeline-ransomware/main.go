@@ -48,6 +48,25 @@ var zoneOrder = []string{
 	"external",
 }
 
+// IT-side and OT-side zones. The "boundary" zone sits between them.
+var (
+	itZones = map[string]bool{"corporate_it": true, "external": true}
+	otZones = map[string]bool{"ot_network": true, "pump_station": true, "field": true, "terminal": true}
+)
+
+// crossingProperty marks edges that span the IT/OT boundary. It is stamped at
+// build time and indexed per edge type, so the boundary analysis is a direct
+// lookup rather than a scan of every node's outgoing edges.
+const crossingProperty = "crosses_it_ot"
+
+// crossesITOT reports whether an edge between the two zones crosses the IT/OT
+// boundary: IT-side to OT-side, or either side to or from the boundary zone.
+func crossesITOT(fromZone, toZone string) bool {
+	return (itZones[fromZone] && (otZones[toZone] || toZone == "boundary")) ||
+		(otZones[fromZone] && (itZones[toZone] || toZone == "boundary")) ||
+		(fromZone == "boundary" && (otZones[toZone] || itZones[toZone]))
+}
+
 func main() {
 	fmt.Println()
 	fmt.Println("=========================================================================")
@@ -136,23 +155,31 @@ func buildPipelineModel(dataDir string) (*PipelineModel, error) {
 		return node.ID
 	}
 
-	// Helper to create a directed edge.
-	addEdge := func(fromName, toName, edgeType string, weight float64) {
+	// Helper to create an edge. Undirected edges are stored once and
+	// traversed both ways.
+	createEdge := func(fromName, toName, edgeType string, weight float64, undirected bool) {
 		from := model.Nodes[fromName]
 		to := model.Nodes[toName]
 		if from == nil || to == nil {
 			log.Fatalf("Edge references unknown node: %s -> %s", fromName, toName)
 		}
-		_, err := gs.CreateEdge(from.ID, to.ID, edgeType, map[string]storage.Value{}, weight)
-		if err != nil {
+		props := map[string]storage.Value{}
+		if crossesITOT(from.Zone, to.Zone) {
+			props[crossingProperty] = storage.BoolValue(true)
+		}
+		create := gs.CreateEdge
+		if undirected {
+			create = gs.CreateUndirectedEdge
+		}
+		if _, err := create(from.ID, to.ID, edgeType, props, weight); err != nil {
 			log.Fatalf("Failed to create edge %s -> %s: %v", fromName, toName, err)
 		}
 	}
-
-	// Helper to create a bidirectional (undirected) edge pair.
-	addBiEdge := func(aName, bName, edgeType string, weight float64) {
-		addEdge(aName, bName, edgeType, weight)
-		addEdge(bName, aName, edgeType, weight)
+	addEdge := func(fromName, toName, edgeType string, weight float64) {
+		createEdge(fromName, toName, edgeType, weight, false)
+	}
+	addUndirectedEdge := func(aName, bName, edgeType string, weight float64) {
+		createEdge(aName, bName, edgeType, weight, true)
 	}
 
 	// ---------------------------------------------------------------
@@ -227,14 +254,14 @@ func buildPipelineModel(dataDir string) (*PipelineModel, error) {
 	// VPN access (directed)
 	addEdge("Compromised_VPN_Cred", "Corp_Firewall", "VPN_ACCESS", 1.0)
 
-	// IT Lateral Movement (bidirectional, type: LATERAL) -- credential reuse paths
-	addBiEdge("AD_Server", "Email_Server", "LATERAL", 1.0)
-	addBiEdge("AD_Server", "Billing_System", "LATERAL", 1.0)
-	addBiEdge("AD_Server", "Scheduling_System", "LATERAL", 1.0)
-	addBiEdge("AD_Server", "ERP_System", "LATERAL", 1.0)
-	addBiEdge("AD_Server", "Backup_Server", "LATERAL", 1.0)
-	addBiEdge("Email_Server", "Finance_PC", "LATERAL", 1.0)
-	addBiEdge("Email_Server", "Admin_PC", "LATERAL", 1.0)
+	// IT Lateral Movement (undirected, type: LATERAL) -- credential reuse paths
+	addUndirectedEdge("AD_Server", "Email_Server", "LATERAL", 1.0)
+	addUndirectedEdge("AD_Server", "Billing_System", "LATERAL", 1.0)
+	addUndirectedEdge("AD_Server", "Scheduling_System", "LATERAL", 1.0)
+	addUndirectedEdge("AD_Server", "ERP_System", "LATERAL", 1.0)
+	addUndirectedEdge("AD_Server", "Backup_Server", "LATERAL", 1.0)
+	addUndirectedEdge("Email_Server", "Finance_PC", "LATERAL", 1.0)
+	addUndirectedEdge("Email_Server", "Admin_PC", "LATERAL", 1.0)
 
 	// IT/OT Boundary (directed, type: BOUNDARY)
 	addEdge("Corp_Switch", "Historian_Bridge", "BOUNDARY", 1.0)
@@ -243,36 +270,43 @@ func buildPipelineModel(dataDir string) (*PipelineModel, error) {
 	addEdge("Corp_Switch", "Jump_Host", "BOUNDARY", 1.0)
 	addEdge("Jump_Host", "OT_Firewall", "BOUNDARY", 1.0)
 
-	// OT Network (bidirectional, type: SCADA_CONTROL)
-	addBiEdge("OT_Firewall", "OT_Switch", "SCADA_CONTROL", 1.0)
-	addBiEdge("OT_Switch", "SCADA_Server", "SCADA_CONTROL", 1.0)
-	addBiEdge("OT_Switch", "Eng_Workstation", "SCADA_CONTROL", 1.0)
-	addBiEdge("OT_Switch", "Leak_Det_Server", "SCADA_CONTROL", 1.0)
+	// OT Network (undirected, type: SCADA_CONTROL)
+	addUndirectedEdge("OT_Firewall", "OT_Switch", "SCADA_CONTROL", 1.0)
+	addUndirectedEdge("OT_Switch", "SCADA_Server", "SCADA_CONTROL", 1.0)
+	addUndirectedEdge("OT_Switch", "Eng_Workstation", "SCADA_CONTROL", 1.0)
+	addUndirectedEdge("OT_Switch", "Leak_Det_Server", "SCADA_CONTROL", 1.0)
 	for i := 1; i <= 5; i++ {
-		addBiEdge("SCADA_Server", fmt.Sprintf("PS%d_RTU", i), "SCADA_CONTROL", 1.0)
+		addUndirectedEdge("SCADA_Server", fmt.Sprintf("PS%d_RTU", i), "SCADA_CONTROL", 1.0)
 	}
 
-	// Pump Station Internal (bidirectional, type: CONTROLS)
+	// Pump Station Internal (undirected, type: CONTROLS)
 	for i := 1; i <= 5; i++ {
 		prefix := fmt.Sprintf("PS%d", i)
-		addBiEdge(prefix+"_RTU", prefix+"_PLC", "CONTROLS", 1.0)
-		addBiEdge(prefix+"_PLC", prefix+"_VFD", "CONTROLS", 1.0)
+		addUndirectedEdge(prefix+"_RTU", prefix+"_PLC", "CONTROLS", 1.0)
+		addUndirectedEdge(prefix+"_PLC", prefix+"_VFD", "CONTROLS", 1.0)
 	}
 
-	// Leak Detection (bidirectional, type: MONITORS)
+	// Leak Detection (undirected, type: MONITORS)
 	for i := 1; i <= 5; i++ {
-		addBiEdge("Leak_Det_Server", fmt.Sprintf("Leak_Sensor_%d", i), "MONITORS", 1.0)
+		addUndirectedEdge("Leak_Det_Server", fmt.Sprintf("Leak_Sensor_%d", i), "MONITORS", 1.0)
 	}
 
-	// Pipeline Flow (bidirectional, type: PIPELINE)
-	addBiEdge("Tank_Farm_North", "PS1_PLC", "PIPELINE", 1.0)
-	addBiEdge("PS1_PLC", "PS2_PLC", "PIPELINE", 1.0)
-	addBiEdge("PS2_PLC", "PS3_PLC", "PIPELINE", 1.0)
-	addBiEdge("PS3_PLC", "PS4_PLC", "PIPELINE", 1.0)
-	addBiEdge("PS4_PLC", "PS5_PLC", "PIPELINE", 1.0)
-	addBiEdge("PS5_PLC", "Tank_Farm_South", "PIPELINE", 1.0)
-	addBiEdge("PS1_PLC", "Terminal_West", "PIPELINE", 1.0)
-	addBiEdge("PS5_PLC", "Terminal_East", "PIPELINE", 1.0)
+	// Pipeline Flow (undirected, type: PIPELINE)
+	addUndirectedEdge("Tank_Farm_North", "PS1_PLC", "PIPELINE", 1.0)
+	addUndirectedEdge("PS1_PLC", "PS2_PLC", "PIPELINE", 1.0)
+	addUndirectedEdge("PS2_PLC", "PS3_PLC", "PIPELINE", 1.0)
+	addUndirectedEdge("PS3_PLC", "PS4_PLC", "PIPELINE", 1.0)
+	addUndirectedEdge("PS4_PLC", "PS5_PLC", "PIPELINE", 1.0)
+	addUndirectedEdge("PS5_PLC", "Tank_Farm_South", "PIPELINE", 1.0)
+	addUndirectedEdge("PS1_PLC", "Terminal_West", "PIPELINE", 1.0)
+	addUndirectedEdge("PS5_PLC", "Terminal_East", "PIPELINE", 1.0)
+
+	// Index the boundary-crossing flag on every edge type
+	for _, edgeType := range gs.GetEdgeTypesForTenant("") {
+		if err := gs.CreateEdgePropertyIndex(edgeType, crossingProperty); err != nil {
+			return nil, fmt.Errorf("failed to index %s.%s: %w", edgeType, crossingProperty, err)
+		}
+	}
 
 	return model, nil
 }
@@ -399,6 +433,39 @@ func analyseRansomwareBlastRadius(model *PipelineModel) {
 		fmt.Printf("    [ENCRYPTED] %s\n", name)
 	}
 	fmt.Println()
+
+	exportBlastRadiusDOT(model, distances, "./data/ransomware_blast_radius.dot")
+}
+
+// exportBlastRadiusDOT writes just the encrypted region (the reached nodes and
+// the edges among them) to a Graphviz file, coloured by zone.
+func exportBlastRadiusDOT(model *PipelineModel, distances map[uint64]int, path string) {
+	seeds := make([]uint64, 0, len(distances))
+	for id := range distances {
+		seeds = append(seeds, id)
+	}
+	infected, _, err := storage.ExtractSubgraph(model.Graph, seeds, 0)
+	if err != nil {
+		log.Fatalf("Failed to extract blast-radius subgraph: %v", err)
+	}
+	defer infected.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Failed to create %s: %v", path, err)
+	}
+	defer f.Close()
+	if err := storage.ExportDOT(infected, f, storage.DOTOptions{
+		GraphName:  "ransomware_blast_radius",
+		ColorBy:    "zone",
+		EdgeLabels: true,
+	}); err != nil {
+		log.Fatalf("Failed to export blast radius: %v", err)
+	}
+
+	stats := infected.GetStatistics()
+	fmt.Printf("  Blast-radius subgraph (%d nodes, %d edges) written to %s\n", stats.NodeCount, stats.EdgeCount, path)
+	fmt.Println()
 }
 
 // ========================================================================
@@ -411,11 +478,6 @@ func analyseITOTBoundary(model *PipelineModel) {
 	fmt.Println("=========================================================================")
 	fmt.Println()
 
-	// Identify boundary-crossing edges: edges where from-zone and to-zone differ
-	// between IT-side zones and OT-side zones
-	itZones := map[string]bool{"corporate_it": true, "external": true}
-	otZones := map[string]bool{"ot_network": true, "pump_station": true, "field": true, "terminal": true}
-
 	type boundaryEdge struct {
 		FromName string
 		ToName   string
@@ -427,39 +489,23 @@ func analyseITOTBoundary(model *PipelineModel) {
 	var crossings []boundaryEdge
 	boundaryNodes := make(map[string]bool)
 
-	// Check every node's outgoing edges for boundary crossings
-	for name, info := range model.Nodes {
-		edges, err := model.Graph.GetOutgoingEdges(info.ID)
+	// Look up the flagged edges of each type through the edge property index
+	for _, edgeType := range model.Graph.GetEdgeTypesForTenant("") {
+		edges, err := model.Graph.FindEdgesByPropertyAcrossTenants(edgeType, crossingProperty, storage.BoolValue(true))
 		if err != nil {
-			continue
+			log.Fatalf("Failed to find boundary-crossing %s edges: %v", edgeType, err)
 		}
 		for _, edge := range edges {
-			toName, ok := model.NodeByID[edge.ToNodeID]
-			if !ok {
-				continue
-			}
-			toInfo := model.Nodes[toName]
-			if toInfo == nil {
-				continue
-			}
-
-			// Crossing: edge spans between IT-side and OT-side (or boundary to either)
-			crosses := (itZones[info.Zone] && (otZones[toInfo.Zone] || toInfo.Zone == "boundary")) ||
-				(otZones[info.Zone] && (itZones[toInfo.Zone] || toInfo.Zone == "boundary")) ||
-				(info.Zone == "boundary" && otZones[toInfo.Zone]) ||
-				(info.Zone == "boundary" && itZones[toInfo.Zone])
-
-			if crosses {
-				crossings = append(crossings, boundaryEdge{
-					FromName: name,
-					ToName:   toName,
-					FromZone: info.Zone,
-					ToZone:   toInfo.Zone,
-					EdgeType: edge.Type,
-				})
-				boundaryNodes[name] = true
-				boundaryNodes[toName] = true
-			}
+			fromName, toName := model.NodeByID[edge.FromNodeID], model.NodeByID[edge.ToNodeID]
+			crossings = append(crossings, boundaryEdge{
+				FromName: fromName,
+				ToName:   toName,
+				FromZone: model.Nodes[fromName].Zone,
+				ToZone:   model.Nodes[toName].Zone,
+				EdgeType: edge.Type,
+			})
+			boundaryNodes[fromName] = true
+			boundaryNodes[toName] = true
 		}
 	}
 
@@ -839,16 +885,14 @@ func analyseBetweennessCentrality(model *PipelineModel) {
 		Score float64
 	}
 	var entries []bcEntry
-	for name, info := range model.Nodes {
+	for _, ranked := range bc.Sorted() {
+		name := model.NodeByID[ranked.NodeID]
 		entries = append(entries, bcEntry{
 			Name:  name,
-			Zone:  info.Zone,
-			Score: bc[info.ID],
+			Zone:  model.Nodes[name].Zone,
+			Score: ranked.Score,
 		})
 	}
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Score > entries[j].Score
-	})
 
 	// Print top 15
 	fmt.Println("--- Top 15 Nodes by Betweenness Centrality ---")
@@ -880,23 +924,30 @@ func analyseBetweennessCentrality(model *PipelineModel) {
 	}
 	fmt.Println()
 
-	// Compare average BC: IT vs OT
+	// Compare average BC: IT vs OT, each side read from the whole-network
+	// scores above.
 	itZones := map[string]bool{"corporate_it": true, "external": true, "boundary": true}
 	otZones := map[string]bool{"ot_network": true, "pump_station": true, "field": true, "terminal": true}
 
-	var itSum, otSum float64
-	var itCount, otCount int
-
-	for _, e := range entries {
-		if itZones[e.Zone] {
-			itSum += e.Score
-			itCount++
+	var itIDs, otIDs []uint64
+	for _, info := range model.Nodes {
+		if itZones[info.Zone] {
+			itIDs = append(itIDs, info.ID)
 		}
-		if otZones[e.Zone] {
-			otSum += e.Score
-			otCount++
+		if otZones[info.Zone] {
+			otIDs = append(otIDs, info.ID)
 		}
 	}
+	itBC, otBC := bc.Subset(itIDs), bc.Subset(otIDs)
+
+	var itSum, otSum float64
+	for _, score := range itBC {
+		itSum += score
+	}
+	for _, score := range otBC {
+		otSum += score
+	}
+	itCount, otCount := len(itBC), len(otBC)
 
 	itAvg := 0.0
 	if itCount > 0 {
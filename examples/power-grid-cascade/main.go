@@ -253,20 +253,14 @@ func buildGrid(dataPath string, removedNodes []string) (*GridModel, error) {
 
 		props := map[string]storage.Value{}
 
-		_, createErr := gs.CreateEdge(fromInfo.ID, toInfo.ID, spec.Type, props, 1.0)
-		if createErr != nil {
+		create := gs.CreateEdge
+		if spec.Undirected {
+			create = gs.CreateUndirectedEdge
+		}
+		if _, createErr := create(fromInfo.ID, toInfo.ID, spec.Type, props, 1.0); createErr != nil {
 			gs.Close()
 			return nil, fmt.Errorf("failed to create edge %s -> %s: %w", spec.From, spec.To, createErr)
 		}
-
-		// Undirected edges get a reverse edge as well
-		if spec.Undirected {
-			_, createErr = gs.CreateEdge(toInfo.ID, fromInfo.ID, spec.Type, props, 1.0)
-			if createErr != nil {
-				gs.Close()
-				return nil, fmt.Errorf("failed to create reverse edge %s -> %s: %w", spec.To, spec.From, createErr)
-			}
-		}
 	}
 
 	return model, nil
@@ -330,7 +324,7 @@ func main() {
 	defer fullModel.Graph.Close()
 
 	stats := fullModel.Graph.GetStatistics()
-	fmt.Printf(" Grid model: %d nodes, %d edges (directed)\n", stats.NodeCount, stats.EdgeCount)
+	fmt.Printf(" Grid model: %d nodes, %d edges\n", stats.NodeCount, stats.EdgeCount)
 	fmt.Println()
 
 	analyseAttackPaths(fullModel)
@@ -362,6 +356,12 @@ func analyseAttackPaths(model *GridModel) {
 	fmt.Println(" at distribution substations to cause a cascading blackout.")
 	fmt.Println()
 
+	// Entry points have no incoming edges; dead ends have no outgoing ones.
+	// Both-way links (SCADA control, power flow) make neither.
+	fmt.Printf(" Entry points (sources): %s\n", strings.Join(nodeNames(model, algorithms.Sources(model.Graph)), ", "))
+	fmt.Printf(" Dead ends (sinks):      %s\n", strings.Join(nodeNames(model, algorithms.Sinks(model.Graph)), ", "))
+	fmt.Println()
+
 	// Path 1: Internet -> SCADA_Master
 	printAttackPath(model, "Internet", "SCADA_Master",
 		"Primary objective: compromise the SCADA master station")
@@ -377,6 +377,15 @@ func analyseAttackPaths(model *GridModel) {
 	fmt.Println()
 }
 
+// nodeNames maps node IDs to their model names.
+func nodeNames(model *GridModel, ids []uint64) []string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = model.NodeByID[id]
+	}
+	return names
+}
+
 // printAttackPath finds and prints a single shortest path with context.
 func printAttackPath(model *GridModel, fromName, toName, description string) {
 	fromInfo, fromOK := model.Nodes[fromName]
@@ -474,10 +483,19 @@ func analyseCascadeFailure() {
 		CustomerPct    int
 		ServedLoads    []string
 		LostLoads      []string
+		Removed        *storage.GraphDiff // structural change vs the baseline
 	}
 
 	results := make([]scenarioResult, 0, len(scenarios))
 
+	// The baseline stays open so each degraded grid can be diffed against it.
+	var baseline *storage.GraphStorage
+	defer func() {
+		if baseline != nil {
+			baseline.Close()
+		}
+	}()
+
 	for i, scenario := range scenarios {
 		dataPath := fmt.Sprintf("./data/cascade_%d", i)
 		model, err := buildDegradedGrid(dataPath, scenario.RemovedNodes)
@@ -544,6 +562,15 @@ func analyseCascadeFailure() {
 			customerPct = (len(servedLoads) * 100) / len(allLoads)
 		}
 
+		var removed *storage.GraphDiff
+		if baseline != nil {
+			removed, err = storage.Diff(baseline, model.Graph)
+			if err != nil {
+				model.Graph.Close()
+				log.Fatalf("Failed to diff %q against the baseline: %v", scenario.Name, err)
+			}
+		}
+
 		results = append(results, scenarioResult{
 			Name:           scenario.Name,
 			Components:     len(components.Communities),
@@ -554,9 +581,14 @@ func analyseCascadeFailure() {
 			CustomerPct:    customerPct,
 			ServedLoads:    servedLoads,
 			LostLoads:      lostLoads,
+			Removed:        removed,
 		})
 
-		model.Graph.Close()
+		if baseline == nil {
+			baseline = model.Graph
+		} else {
+			model.Graph.Close()
+		}
 	}
 
 	// Print the progressive cascade table
@@ -586,6 +618,8 @@ func analyseCascadeFailure() {
 		}
 
 		fmt.Printf(" Step %d: %s\n", i, r.Name)
+		fmt.Printf("   Removed from baseline: %d nodes, %d edges\n",
+			len(r.Removed.RemovedNodes), len(r.Removed.RemovedEdges))
 		if len(r.LostLoads) > 0 {
 			fmt.Printf("   LOST: %s\n", strings.Join(r.LostLoads, ", "))
 		}
@@ -727,19 +761,15 @@ func analyseBetweenness(model *GridModel) {
 	}
 
 	ranked := make([]rankedNode, 0, len(bc))
-	for nodeID, score := range bc {
-		name, ok := model.NodeByID[nodeID]
+	for _, entry := range bc.Sorted() {
+		name, ok := model.NodeByID[entry.NodeID]
 		if !ok {
 			continue
 		}
 		info := model.Nodes[name]
-		ranked = append(ranked, rankedNode{name, score, info.Zone})
+		ranked = append(ranked, rankedNode{name, entry.Score, info.Zone})
 	}
 
-	sort.Slice(ranked, func(i, j int) bool {
-		return ranked[i].BC > ranked[j].BC
-	})
-
 	// Print top 15
 	limit := 15
 	if len(ranked) < limit {
@@ -70,6 +70,7 @@ func main() {
 	analyseAttackPaths(model)
 	analyseBlastRadius(model)
 	analyseBetweenness(model)
+	analyseMonitoringPlacement(model)
 
 	// --- Degraded model: SCADA_Server removed ---
 	analyseCascadeFailure(model)
@@ -134,12 +135,21 @@ func buildModel(dataPath string) (*WaterModel, error) {
 		return nil
 	}
 
-	// createUndirectedEdge creates edges in both directions.
+	// createUndirectedEdge creates a single edge traversable both ways.
 	createUndirectedEdge := func(aName, bName, edgeType string) error {
-		if err := createDirectedEdge(aName, bName, edgeType); err != nil {
-			return err
+		aMeta, ok := wm.Nodes[aName]
+		if !ok {
+			return fmt.Errorf("source node %q not found", aName)
+		}
+		bMeta, ok := wm.Nodes[bName]
+		if !ok {
+			return fmt.Errorf("target node %q not found", bName)
+		}
+		_, err := gs.CreateUndirectedEdge(aMeta.ID, bMeta.ID, edgeType, map[string]storage.Value{}, 1.0)
+		if err != nil {
+			return fmt.Errorf("failed to create edge %s -- %s: %w", aName, bName, err)
 		}
-		return createDirectedEdge(bName, aName, edgeType)
+		return nil
 	}
 
 	// ========================================
@@ -302,7 +312,7 @@ func analyseAttackPaths(model *WaterModel) {
 
 	// (a) Shortest path in the full graph — this will find the TeamViewer
 	//     shortcut because it has fewer hops.
-	tvPath, err := algorithms.ShortestPath(model.Graph, internetID, targetID)
+	tvPath, err := algorithms.ShortestPathDetailed(model.Graph, internetID, targetID)
 	if err != nil {
 		log.Fatalf("Failed to compute shortest path: %v", err)
 	}
@@ -311,15 +321,16 @@ func analyseAttackPaths(model *WaterModel) {
 	if tvPath == nil {
 		fmt.Println("      No path found!")
 	} else {
-		fmt.Printf("      Hops: %d\n", len(tvPath)-1)
+		fmt.Printf("      Hops: %d\n", len(tvPath))
 		fmt.Print("      Path: ")
-		printPath(model, tvPath)
+		printSteps(model, tvPath)
 		fmt.Println()
 
-		// Determine whether the path uses TeamViewer
+		// The edge types say directly whether the path rides the
+		// TeamViewer shortcut rather than the firewalled NETWORK links.
 		usesTV := false
-		for _, nodeID := range tvPath {
-			if model.NodeByID[nodeID] == "TeamViewer_Relay" {
+		for _, step := range tvPath {
+			if step.EdgeType == "REMOTE_ACCESS" {
 				usesTV = true
 				break
 			}
@@ -361,10 +372,7 @@ func analyseAttackPaths(model *WaterModel) {
 	fmt.Println()
 
 	// Compare
-	tvHops := 0
-	if tvPath != nil {
-		tvHops = len(tvPath) - 1
-	}
+	tvHops := len(tvPath)
 	dmzHops := len(legitimatePath) - 1
 
 	fmt.Println("  Comparison:")
@@ -497,20 +505,16 @@ func analyseBetweenness(model *WaterModel) {
 	}
 
 	entries := make([]bcEntry, 0, len(bc))
-	for nodeID, score := range bc {
-		meta := model.MetaByID[nodeID]
+	for _, ranked := range bc.Sorted() {
+		meta := model.MetaByID[ranked.NodeID]
 		entries = append(entries, bcEntry{
 			name:        meta.Name,
 			zone:        meta.Zone,
 			criticality: meta.Criticality,
-			bc:          score,
+			bc:          ranked.Score,
 		})
 	}
 
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].bc > entries[j].bc
-	})
-
 	// Print top 15
 	top := 15
 	if len(entries) < top {
@@ -562,20 +566,68 @@ func analyseBetweenness(model *WaterModel) {
 
 	fmt.Println("  Key Insight: Betweenness centrality reveals which nodes are structural")
 	fmt.Println("  chokepoints — the bridges between network zones. High-BC nodes are")
-	fmt.Println("  both the best places to deploy monitoring (IDS/IPS) and the most")
-	fmt.Println("  attractive targets for an attacker seeking lateral movement.")
+	fmt.Println("  the most attractive targets for an attacker seeking lateral movement")
+	fmt.Println("  (section 4 turns to where to put the monitoring).")
 	fmt.Println("  Nodes that connect the OT network to control systems (like SCADA_Server")
 	fmt.Println("  and OT_Switch) tend to dominate because all cross-zone traffic must")
 	fmt.Println("  flow through them.")
 	fmt.Println()
 }
 
+// analyseMonitoringPlacement picks sensor locations that observe the whole
+// facility: a vertex cover sees the traffic on every link, a dominating
+// set sees every device from itself or a neighbour.
+func analyseMonitoringPlacement(model *WaterModel) {
+	fmt.Println("=========================================================================")
+	fmt.Println(" 4. MONITORING PLACEMENT — Covering Every Link")
+	fmt.Println("    What is the smallest set of nodes to monitor to see all traffic?")
+	fmt.Println("=========================================================================")
+	fmt.Println()
+
+	cover, err := algorithms.GreedyVertexCover(model.Graph)
+	if err != nil {
+		log.Fatalf("Failed to compute vertex cover: %v", err)
+	}
+	dominating, err := algorithms.GreedyDominatingSet(model.Graph)
+	if err != nil {
+		log.Fatalf("Failed to compute dominating set: %v", err)
+	}
+
+	stats := model.Graph.GetStatistics()
+	fmt.Printf("  Traffic taps (vertex cover): %d of %d nodes see every one of the %d links.\n",
+		len(cover), stats.NodeCount, stats.EdgeCount)
+	fmt.Println("  In deployment order — each tap covers the most links not yet seen:")
+	fmt.Println()
+	fmt.Printf("  %-4s  %-24s  %-16s  %s\n", "#", "Node", "Zone", "Criticality")
+	fmt.Printf("  %-4s  %-24s  %-16s  %s\n", "----", "------------------------", "----------------", "----------")
+	for i, id := range cover {
+		meta := model.MetaByID[id]
+		fmt.Printf("  %-4d  %-24s  %-16s  %s\n", i+1, meta.Name, meta.Zone, meta.Criticality)
+	}
+	fmt.Println()
+
+	names := make([]string, 0, len(dominating))
+	for _, id := range dominating {
+		names = append(names, model.NodeByID[id])
+	}
+	fmt.Printf("  Host sensors (dominating set): %d nodes put every device one hop from a sensor:\n", len(dominating))
+	fmt.Printf("    %s\n", strings.Join(names, ", "))
+	fmt.Println()
+
+	fmt.Println("  Key Insight: Betweenness ranks chokepoints but says nothing about")
+	fmt.Println("  coverage — monitoring only the top-BC nodes can still leave links")
+	fmt.Println("  nobody watches. A vertex cover guarantees every link, including the")
+	fmt.Println("  TeamViewer bypass, has a monitored endpoint; budget-limited teams")
+	fmt.Println("  deploy down the list and stop where the money runs out.")
+	fmt.Println()
+}
+
 // analyseCascadeFailure builds a second graph without SCADA_Server and examines
 // how the network fragments, simulating a ransomware or destructive attack on
 // the central supervisory system.
 func analyseCascadeFailure(model *WaterModel) {
 	fmt.Println("=========================================================================")
-	fmt.Println(" 4. CASCADING FAILURE SIMULATION")
+	fmt.Println(" 5. CASCADING FAILURE SIMULATION")
 	fmt.Println("    What happens when SCADA_Server is destroyed?")
 	fmt.Println("=========================================================================")
 	fmt.Println()
@@ -753,9 +805,7 @@ func buildDegradedGraph(dataPath string, original *WaterModel) (*WaterModel, err
 
 	// Recreate all edges that don't touch SCADA_Server
 	scadaID := original.Nodes["SCADA_Server"].ID
-	stats := original.Graph.GetStatistics()
-
-	for i := uint64(1); i <= stats.NodeCount; i++ {
+	for _, i := range original.Graph.GetAllNodeIDs() {
 		if i == scadaID {
 			continue
 		}
@@ -791,6 +841,20 @@ func printPath(model *WaterModel, path []uint64) {
 	fmt.Println(strings.Join(names, " -> "))
 }
 
+// printSteps prints a detailed path, labelling each hop with its edge type.
+func printSteps(model *WaterModel, steps []algorithms.PathStep) {
+	if len(steps) == 0 {
+		fmt.Println()
+		return
+	}
+	var b strings.Builder
+	b.WriteString(model.NodeByID[steps[0].FromID])
+	for _, step := range steps {
+		fmt.Fprintf(&b, " -[%s]-> %s", step.EdgeType, model.NodeByID[step.ToID])
+	}
+	fmt.Println(b.String())
+}
+
 // printDegradedPath prints a path from the degraded model.
 func printDegradedPath(model *WaterModel, path []uint64) {
 	names := make([]string, len(path))
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 )
 
 // BlueGreenManager manages blue-green deployments
@@ -253,7 +255,7 @@ func (bgm *BlueGreenManager) RegisterHandlers(mux *http.ServeMux) {
 
 func (bgm *BlueGreenManager) handleStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		middleware.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -261,20 +263,20 @@ func (bgm *BlueGreenManager) handleStatus(w http.ResponseWriter, r *http.Request
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(status); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		middleware.WriteError(w, r, http.StatusInternalServerError, err.Error())
 		return
 	}
 }
 
 func (bgm *BlueGreenManager) handleSwitch(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		middleware.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	var req SwitchRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		middleware.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
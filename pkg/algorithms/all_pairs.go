@@ -0,0 +1,163 @@
+package algorithms
+
+import (
+	"container/list"
+	"errors"
+	"math"
+)
+
+// ErrNegativeCycle is returned by AllPairsWeightedShortestPaths when edge
+// weights form a cycle of negative total weight, so no shortest path exists.
+var ErrNegativeCycle = errors.New("graph contains a negative-weight cycle")
+
+// AllPairsShortestPaths returns the hop distance between every ordered pair of
+// nodes, following edge direction: result[from][to] is the fewest hops from
+// `from` to `to`. Each node maps to itself at distance 0; unreachable targets
+// are absent. Runs a BFS from every node, so it costs O(V·(V+E)) time and
+// O(V²) memory — intended for small infrastructure graphs, not whole
+// production datasets. Tenant-blind.
+func AllPairsShortestPaths(graph Graph) (map[uint64]map[uint64]int, error) {
+	return allPairsShortestPathsView(newTenantBlindView(graph))
+}
+
+func allPairsShortestPathsView(view graphView) (map[uint64]map[uint64]int, error) {
+	nodes := view.AllNodes()
+	result := make(map[uint64]map[uint64]int, len(nodes))
+
+	for _, source := range nodes {
+		distances := map[uint64]int{source.ID: 0}
+		queue := list.New()
+		queue.PushBack(source.ID)
+
+		for queue.Len() > 0 {
+			currentID, ok := queue.Remove(queue.Front()).(uint64)
+			if !ok {
+				continue
+			}
+			edges, err := view.OutgoingEdges(currentID)
+			if err != nil {
+				continue
+			}
+			for _, edge := range edges {
+				if _, seen := distances[edge.ToNodeID]; !seen {
+					distances[edge.ToNodeID] = distances[currentID] + 1
+					queue.PushBack(edge.ToNodeID)
+				}
+			}
+		}
+		result[source.ID] = distances
+	}
+
+	return result, nil
+}
+
+// AllPairsWeightedShortestPaths is the weighted counterpart of
+// AllPairsShortestPaths, summing edge weights with Floyd-Warshall
+// (O(V³) time, O(V²) memory). Parallel edges contribute their lightest
+// weight. Negative weights are allowed, but a negative-weight cycle returns
+// ErrNegativeCycle. Tenant-blind.
+func AllPairsWeightedShortestPaths(graph Graph) (map[uint64]map[uint64]float64, error) {
+	view := newTenantBlindView(graph)
+	nodes := view.AllNodes()
+	n := len(nodes)
+
+	index := make(map[uint64]int, n)
+	for i, node := range nodes {
+		index[node.ID] = i
+	}
+
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			dist[i][j] = math.Inf(1)
+		}
+		dist[i][i] = 0
+	}
+	for i, node := range nodes {
+		edges, err := view.OutgoingEdges(node.ID)
+		if err != nil {
+			continue
+		}
+		for _, edge := range edges {
+			j, ok := index[edge.ToNodeID]
+			if ok && edge.Weight < dist[i][j] {
+				dist[i][j] = edge.Weight
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(dist[i][k], 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if d := dist[i][k] + dist[k][j]; d < dist[i][j] {
+					dist[i][j] = d
+				}
+			}
+		}
+	}
+
+	result := make(map[uint64]map[uint64]float64, n)
+	for i, from := range nodes {
+		if dist[i][i] < 0 {
+			return nil, ErrNegativeCycle
+		}
+		row := make(map[uint64]float64)
+		for j, to := range nodes {
+			if !math.IsInf(dist[i][j], 1) {
+				row[to.ID] = dist[i][j]
+			}
+		}
+		result[from.ID] = row
+	}
+
+	return result, nil
+}
+
+// Diameter returns the longest shortest-path hop count between any two nodes
+// where the second is reachable from the first. Unreachable pairs are
+// ignored rather than treated as infinite, so a disconnected graph reports
+// the diameter of its widest reachable part. Returns 0 for an empty graph.
+func Diameter(graph Graph) (int, error) {
+	matrix, err := AllPairsShortestPaths(graph)
+	if err != nil {
+		return 0, err
+	}
+
+	diameter := 0
+	for _, row := range matrix {
+		for _, d := range row {
+			if d > diameter {
+				diameter = d
+			}
+		}
+	}
+	return diameter, nil
+}
+
+// AveragePathLength returns the mean hop count over all ordered pairs of
+// distinct nodes where the target is reachable from the source. Returns 0
+// when no such pair exists.
+func AveragePathLength(graph Graph) (float64, error) {
+	matrix, err := AllPairsShortestPaths(graph)
+	if err != nil {
+		return 0, err
+	}
+
+	total, pairs := 0, 0
+	for from, row := range matrix {
+		for to, d := range row {
+			if from != to {
+				total += d
+				pairs++
+			}
+		}
+	}
+	if pairs == 0 {
+		return 0, nil
+	}
+	return float64(total) / float64(pairs), nil
+}
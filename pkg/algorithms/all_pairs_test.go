@@ -0,0 +1,92 @@
+package algorithms
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAllPairsShortestPaths_Chain(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	a, _ := gs.CreateNode([]string{"N"}, nil)
+	b, _ := gs.CreateNode([]string{"N"}, nil)
+	c, _ := gs.CreateNode([]string{"N"}, nil)
+	d, _ := gs.CreateNode([]string{"N"}, nil) // isolated
+	_, _ = gs.CreateEdge(a.ID, b.ID, "NEXT", nil, 1.0)
+	_, _ = gs.CreateEdge(b.ID, c.ID, "NEXT", nil, 1.0)
+
+	matrix, err := AllPairsShortestPaths(gs)
+	if err != nil {
+		t.Fatalf("AllPairsShortestPaths: %v", err)
+	}
+
+	if got := matrix[a.ID][c.ID]; got != 2 {
+		t.Errorf("a->c = %d, want 2", got)
+	}
+	if got, ok := matrix[a.ID][a.ID]; !ok || got != 0 {
+		t.Errorf("a->a = %d (present %v), want 0", got, ok)
+	}
+	if _, ok := matrix[c.ID][a.ID]; ok {
+		t.Error("c->a should be unreachable (edges are directed)")
+	}
+	if row := matrix[d.ID]; len(row) != 1 {
+		t.Errorf("isolated node row = %v, want only itself", row)
+	}
+
+	diameter, err := Diameter(gs)
+	if err != nil || diameter != 2 {
+		t.Errorf("Diameter = %d, %v; want 2", diameter, err)
+	}
+
+	// Reachable distinct pairs: a->b 1, a->c 2, b->c 1.
+	avg, err := AveragePathLength(gs)
+	if err != nil || math.Abs(avg-4.0/3.0) > 1e-9 {
+		t.Errorf("AveragePathLength = %v, %v; want 1.333", avg, err)
+	}
+}
+
+func TestAllPairsShortestPaths_EmptyGraph(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	matrix, err := AllPairsShortestPaths(gs)
+	if err != nil || len(matrix) != 0 {
+		t.Errorf("matrix = %v, err = %v; want empty", matrix, err)
+	}
+	if d, _ := Diameter(gs); d != 0 {
+		t.Errorf("Diameter = %d, want 0", d)
+	}
+	if avg, _ := AveragePathLength(gs); avg != 0 {
+		t.Errorf("AveragePathLength = %v, want 0", avg)
+	}
+}
+
+func TestAllPairsWeightedShortestPaths(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	a, _ := gs.CreateNode([]string{"N"}, nil)
+	b, _ := gs.CreateNode([]string{"N"}, nil)
+	c, _ := gs.CreateNode([]string{"N"}, nil)
+	_, _ = gs.CreateEdge(a.ID, c.ID, "DIRECT", nil, 10.0)
+	_, _ = gs.CreateEdge(a.ID, b.ID, "HOP", nil, 2.0)
+	_, _ = gs.CreateEdge(b.ID, c.ID, "HOP", nil, 3.0)
+
+	matrix, err := AllPairsWeightedShortestPaths(gs)
+	if err != nil {
+		t.Fatalf("AllPairsWeightedShortestPaths: %v", err)
+	}
+	if got := matrix[a.ID][c.ID]; got != 5.0 {
+		t.Errorf("a->c = %v, want 5 via b", got)
+	}
+	if _, ok := matrix[c.ID][a.ID]; ok {
+		t.Error("c->a should be unreachable")
+	}
+
+	_, _ = gs.CreateEdge(c.ID, a.ID, "BACK", nil, -6.0)
+	if _, err := AllPairsWeightedShortestPaths(gs); !errors.Is(err, ErrNegativeCycle) {
+		t.Errorf("err = %v, want ErrNegativeCycle", err)
+	}
+}
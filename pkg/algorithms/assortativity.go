@@ -0,0 +1,71 @@
+package algorithms
+
+// DegreeAssortativity is the Pearson correlation between the degrees of the
+// two ends of each edge, treating edges as undirected and counting each
+// neighbor once. Tenant-blind.
+//
+// It runs from -1 to 1. Strongly negative values mean hubs attach mostly to
+// low-degree nodes — a hub-and-spoke topology, where losing a hub strands
+// its spokes (a star scores exactly -1). Positive values mean hubs attach to
+// hubs, as in a meshed core. Returns 0 when the correlation is undefined:
+// no edges, or every edge joins nodes of equal degree (a ring, a complete
+// graph).
+func DegreeAssortativity(graph Graph) (float64, error) {
+	view := newTenantBlindView(graph)
+	nodes := view.AllNodes()
+	neighbors := undirectedNeighbors(view, nodes, nil)
+
+	// Each undirected edge contributes both orientations, (du, dv) and
+	// (dv, du), which makes the correlation symmetric. The sums are of
+	// degrees, so they are kept as integers: exact, and walked in node ID
+	// order, so the result is the same on every run.
+	var pairs, sum, sumSq, sumProd uint64
+	for _, node := range nodes {
+		nbs := neighbors[node.ID]
+		du := uint64(len(nbs))
+		for v := range nbs {
+			dv := uint64(len(neighbors[v]))
+			pairs++
+			sum += du
+			sumSq += du * du
+			sumProd += du * dv
+		}
+	}
+	if pairs == 0 {
+		return 0, nil
+	}
+
+	n := float64(pairs)
+	mean := float64(sum) / n
+	variance := float64(sumSq)/n - mean*mean
+	if variance <= 1e-12 {
+		return 0, nil
+	}
+	return (float64(sumProd)/n - mean*mean) / variance, nil
+}
+
+// AverageNeighborDegree returns, for every node, the mean degree of its
+// neighbors, treating edges as undirected and counting each neighbor once.
+// Isolated nodes score 0. Tenant-blind.
+//
+// Read against a node's own degree it shows where it sits: a spoke whose
+// only neighbor is a hub scores high, while the hub itself, surrounded by
+// spokes, scores close to 1.
+func AverageNeighborDegree(graph Graph) (map[uint64]float64, error) {
+	view := newTenantBlindView(graph)
+	neighbors := undirectedNeighbors(view, view.AllNodes(), nil)
+
+	avg := make(map[uint64]float64, len(neighbors))
+	for id, nbs := range neighbors {
+		if len(nbs) == 0 {
+			avg[id] = 0
+			continue
+		}
+		total := 0
+		for nb := range nbs {
+			total += len(neighbors[nb])
+		}
+		avg[id] = float64(total) / float64(len(nbs))
+	}
+	return avg, nil
+}
@@ -0,0 +1,105 @@
+package algorithms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDegreeAssortativity(t *testing.T) {
+	t.Run("star is perfectly disassortative", func(t *testing.T) {
+		gs := setupTestGraph(t)
+		defer func() { _ = gs.Close() }()
+
+		hub, _ := gs.CreateNode([]string{"Router"}, nil)
+		var leaves []uint64
+		for i := 0; i < 5; i++ {
+			leaf, _ := gs.CreateNode([]string{"Host"}, nil)
+			leaves = append(leaves, leaf.ID)
+			_, _ = gs.CreateEdge(leaf.ID, hub.ID, "LAN", nil, 1.0)
+		}
+		// A reverse edge is the same undirected link, not a second neighbor.
+		_, _ = gs.CreateEdge(hub.ID, leaves[0], "LAN", nil, 1.0)
+
+		r, err := DegreeAssortativity(gs)
+		if err != nil {
+			t.Fatalf("DegreeAssortativity: %v", err)
+		}
+		if math.Abs(r+1) > 1e-9 {
+			t.Errorf("DegreeAssortativity(star) = %f, want -1", r)
+		}
+
+		avg, err := AverageNeighborDegree(gs)
+		if err != nil {
+			t.Fatalf("AverageNeighborDegree: %v", err)
+		}
+		if avg[hub.ID] != 1 {
+			t.Errorf("hub average neighbor degree = %f, want 1", avg[hub.ID])
+		}
+		for _, leaf := range leaves {
+			if avg[leaf] != 5 {
+				t.Errorf("leaf %d average neighbor degree = %f, want 5", leaf, avg[leaf])
+			}
+		}
+	})
+
+	t.Run("hubs linked to hubs are assortative", func(t *testing.T) {
+		gs := setupTestGraph(t)
+		defer func() { _ = gs.Close() }()
+
+		// A 4-clique core (degree 3 everywhere) beside a 3-node path
+		// (degrees 1-2-1): high meets high, low meets low.
+		core := make([]uint64, 4)
+		for i := range core {
+			n, _ := gs.CreateNode([]string{"Core"}, nil)
+			core[i] = n.ID
+		}
+		for i := range core {
+			for j := i + 1; j < len(core); j++ {
+				_, _ = gs.CreateEdge(core[i], core[j], "LINK", nil, 1.0)
+			}
+		}
+		a, _ := gs.CreateNode([]string{"Edge"}, nil)
+		b, _ := gs.CreateNode([]string{"Edge"}, nil)
+		c, _ := gs.CreateNode([]string{"Edge"}, nil)
+		_, _ = gs.CreateEdge(a.ID, b.ID, "LINK", nil, 1.0)
+		_, _ = gs.CreateEdge(b.ID, c.ID, "LINK", nil, 1.0)
+		isolated, _ := gs.CreateNode([]string{"Edge"}, nil)
+
+		r, err := DegreeAssortativity(gs)
+		if err != nil {
+			t.Fatalf("DegreeAssortativity: %v", err)
+		}
+		if r <= 0.5 {
+			t.Errorf("DegreeAssortativity = %f, want strongly positive", r)
+		}
+
+		avg, _ := AverageNeighborDegree(gs)
+		if avg[core[0]] != 3 || avg[b.ID] != 1 || avg[a.ID] != 2 {
+			t.Errorf("average neighbor degree core=%f mid=%f end=%f, want 3, 1, 2", avg[core[0]], avg[b.ID], avg[a.ID])
+		}
+		if v, ok := avg[isolated.ID]; !ok || v != 0 {
+			t.Errorf("isolated node average neighbor degree = %f (present %v), want 0", v, ok)
+		}
+	})
+
+	t.Run("undefined cases are 0", func(t *testing.T) {
+		gs := setupTestGraph(t)
+		defer func() { _ = gs.Close() }()
+
+		if r, err := DegreeAssortativity(gs); err != nil || r != 0 {
+			t.Errorf("DegreeAssortativity(empty) = %f, %v; want 0", r, err)
+		}
+
+		ring := make([]uint64, 5)
+		for i := range ring {
+			n, _ := gs.CreateNode([]string{"N"}, nil)
+			ring[i] = n.ID
+		}
+		for i := range ring {
+			_, _ = gs.CreateEdge(ring[i], ring[(i+1)%len(ring)], "LINK", nil, 1.0)
+		}
+		if r, err := DegreeAssortativity(gs); err != nil || r != 0 {
+			t.Errorf("DegreeAssortativity(ring) = %f, %v; want 0", r, err)
+		}
+	})
+}
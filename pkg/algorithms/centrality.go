@@ -5,8 +5,6 @@ import (
 	"container/list"
 	"context"
 	"sort"
-
-	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
 // predEdge tracks a predecessor node and the edge used to reach it during BFS.
@@ -110,20 +108,36 @@ func brandesCentrality(ctx context.Context, view graphView) (nodeBetweenness map
 	return nodeBetweenness, edgeBetweenness, nodeIDs, nil
 }
 
+// BetweennessOptions configures BetweennessCentralityWithOptions.
+type BetweennessOptions struct {
+	// Nodes restricts the result to these node IDs: nil scores every node,
+	// and an empty, non-nil slice scores none.
+	// Shortest paths are still counted across the whole graph, so each
+	// node scores exactly what it would without the option: use it to ask
+	// "how central are the OT nodes" without ranking the IT ones.
+	Nodes []uint64
+}
+
 // BetweennessCentrality computes betweenness centrality for all nodes
 // (tenant-blind). Measures how often a node appears on shortest paths.
-func BetweennessCentrality(graph *storage.GraphStorage) (map[uint64]float64, error) {
-	return betweennessCentralityView(context.Background(), newTenantBlindView(graph))
+func BetweennessCentrality(graph Graph) (ScoreResult, error) {
+	return betweennessCentralityView(context.Background(), newTenantBlindView(graph), BetweennessOptions{})
+}
+
+// BetweennessCentralityWithOptions is BetweennessCentrality scoring only
+// opts.Nodes.
+func BetweennessCentralityWithOptions(graph Graph, opts BetweennessOptions) (ScoreResult, error) {
+	return betweennessCentralityView(context.Background(), newTenantBlindView(graph), opts)
 }
 
 // BetweennessCentralityForTenant restricts computation to the
 // caller's tenant subgraph. Audit A6c-algorithms. ctx cancels the
 // O(V·E) computation when the request deadline fires (H-6).
-func BetweennessCentralityForTenant(ctx context.Context, graph *storage.GraphStorage, tenantID string) (map[uint64]float64, error) {
-	return betweennessCentralityView(ctx, newTenantScopedView(graph, tenantID))
+func BetweennessCentralityForTenant(ctx context.Context, graph Graph, tenantID string) (ScoreResult, error) {
+	return betweennessCentralityView(ctx, newTenantScopedView(graph, tenantID), BetweennessOptions{})
 }
 
-func betweennessCentralityView(ctx context.Context, view graphView) (map[uint64]float64, error) {
+func betweennessCentralityView(ctx context.Context, view graphView, opts BetweennessOptions) (ScoreResult, error) {
 	nodeBetweenness, _, nodeIDs, err := brandesCentrality(ctx, view)
 	if err != nil {
 		return nil, err
@@ -136,6 +150,9 @@ func betweennessCentralityView(ctx context.Context, view graphView) (map[uint64]
 		}
 	}
 
+	if opts.Nodes != nil {
+		return ScoreResult(nodeBetweenness).Subset(opts.Nodes), nil
+	}
 	return nodeBetweenness, nil
 }
 
@@ -154,21 +171,22 @@ type EdgeBetweennessResult struct {
 	ByEdgeID map[uint64]float64 `json:"by_edge_id"`
 	// ByNodePair maps [fromNodeID, toNodeID] to the directed edge's BC score.
 	ByNodePair map[[2]uint64]float64 `json:"by_node_pair"`
-	// TopEdges lists the top edges ranked by BC score (descending).
+	// TopEdges lists the top edges ranked by BC score (descending, ties by
+	// ascending edge ID).
 	TopEdges []RankedEdge `json:"top_edges"`
 }
 
 // EdgeBetweennessCentrality computes betweenness centrality for all
 // edges (tenant-blind). Measures how often an edge appears on
 // shortest paths between all node pairs.
-func EdgeBetweennessCentrality(graph *storage.GraphStorage) (*EdgeBetweennessResult, error) {
+func EdgeBetweennessCentrality(graph Graph) (*EdgeBetweennessResult, error) {
 	return edgeBetweennessCentralityView(context.Background(), newTenantBlindView(graph))
 }
 
 // EdgeBetweennessCentralityForTenant restricts computation to the
 // caller's tenant subgraph. Audit A6c-algorithms. ctx cancels the
 // O(V·E) computation when the request deadline fires (H-6).
-func EdgeBetweennessCentralityForTenant(ctx context.Context, graph *storage.GraphStorage, tenantID string) (*EdgeBetweennessResult, error) {
+func EdgeBetweennessCentralityForTenant(ctx context.Context, graph Graph, tenantID string) (*EdgeBetweennessResult, error) {
 	return edgeBetweennessCentralityView(ctx, newTenantScopedView(graph, tenantID))
 }
 
@@ -211,9 +229,19 @@ func edgeBetweennessCentralityView(ctx context.Context, view graphView) (*EdgeBe
 type rankedEdgeHeap []RankedEdge
 
 func (h rankedEdgeHeap) Len() int           { return len(h) }
-func (h rankedEdgeHeap) Less(i, j int) bool { return h[i].Score < h[j].Score }
+func (h rankedEdgeHeap) Less(i, j int) bool { return rankedEdgeLess(h[i], h[j]) }
 func (h rankedEdgeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
 
+// rankedEdgeLess reports whether a ranks below b: lower score, or equal
+// score and higher edge ID. Mirrors rankedNodeLess, so which of several
+// tied edges make the top n does not depend on map order.
+func rankedEdgeLess(a, b RankedEdge) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+	return a.EdgeID > b.EdgeID
+}
+
 func (h *rankedEdgeHeap) Push(x any) {
 	// heap.Interface.Push contract: callers always pass the heap's
 	// element type. The explicit comma-ok + panic preserves the same
@@ -259,7 +287,7 @@ func findTopEdgesView(view graphView, scores map[uint64]float64, n int) []Ranked
 
 		if h.Len() < n {
 			heap.Push(&h, re)
-		} else if score > h[0].Score {
+		} else if rankedEdgeLess(h[0], re) {
 			heap.Pop(&h)
 			heap.Push(&h, re)
 		}
@@ -290,9 +318,9 @@ func findTopEdgesView(view graphView, scores map[uint64]float64, n int) []Ranked
 
 // CentralityResult contains centrality measures for all nodes and edges.
 type CentralityResult struct {
-	Betweenness          map[uint64]float64
-	Closeness            map[uint64]float64
-	Degree               map[uint64]float64
+	Betweenness          ScoreResult
+	Closeness            ScoreResult
+	Degree               ScoreResult
 	EdgeBetweenness      *EdgeBetweennessResult
 	TopByBetweenness     []RankedNode
 	TopByCloseness       []RankedNode
@@ -302,7 +330,7 @@ type CentralityResult struct {
 
 // ComputeAllCentrality computes all centrality measures in a single pass where
 // possible. Node and edge betweenness share one Brandes traversal.
-func ComputeAllCentrality(graph *storage.GraphStorage) (*CentralityResult, error) {
+func ComputeAllCentrality(graph Graph) (*CentralityResult, error) {
 	view := newTenantBlindView(graph)
 	nodeBetweenness, edgeBetweennessRaw, nodeIDs, err := brandesCentrality(context.Background(), view)
 	if err != nil {
@@ -343,7 +371,7 @@ func ComputeAllCentrality(graph *storage.GraphStorage) (*CentralityResult, error
 		TopEdges:   topEdges,
 	}
 
-	closeness, err := ClosenessCentrality(graph)
+	closeness, err := closenessCentralityView(context.Background(), view)
 	if err != nil {
 		return nil, err
 	}
@@ -365,21 +393,32 @@ func ComputeAllCentrality(graph *storage.GraphStorage) (*CentralityResult, error
 	}, nil
 }
 
-// ClosenessCentrality computes closeness centrality for all nodes.
-// Measures average distance from a node to all other nodes.
-func ClosenessCentrality(graph *storage.GraphStorage) (map[uint64]float64, error) {
-	stats := graph.GetStatistics()
+// ClosenessCentrality computes closeness centrality for all nodes
+// (tenant-blind). Measures average distance from a node to all other nodes.
+func ClosenessCentrality(graph Graph) (ScoreResult, error) {
+	return closenessCentralityView(context.Background(), newTenantBlindView(graph))
+}
 
-	nodeIDs := make([]uint64, 0, stats.NodeCount)
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		if node, err := graph.GetNode(i); err == nil && node != nil {
-			nodeIDs = append(nodeIDs, i)
-		}
+// ClosenessCentralityForTenant restricts computation to the caller's
+// tenant subgraph. One BFS per node makes this O(V·E), so ctx is checked
+// before each source like BetweennessCentralityForTenant.
+func ClosenessCentralityForTenant(ctx context.Context, graph Graph, tenantID string) (ScoreResult, error) {
+	return closenessCentralityView(ctx, newTenantScopedView(graph, tenantID))
+}
+
+func closenessCentralityView(ctx context.Context, view graphView) (ScoreResult, error) {
+	allNodes := view.AllNodes()
+	nodeIDs := make([]uint64, 0, len(allNodes))
+	for _, n := range allNodes {
+		nodeIDs = append(nodeIDs, n.ID)
 	}
 
 	closeness := make(map[uint64]float64)
 
 	for _, source := range nodeIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		distance := make(map[uint64]int)
 		for _, nodeID := range nodeIDs {
 			distance[nodeID] = -1
@@ -395,7 +434,7 @@ func ClosenessCentrality(graph *storage.GraphStorage) (map[uint64]float64, error
 				continue
 			}
 
-			edges, err := graph.GetOutgoingEdges(v)
+			edges, err := view.OutgoingEdges(v)
 			if err != nil {
 				continue
 			}
@@ -430,15 +469,8 @@ func ClosenessCentrality(graph *storage.GraphStorage) (map[uint64]float64, error
 
 // DegreeCentrality computes degree centrality for all nodes.
 // Simple count of connections (in-degree + out-degree).
-func DegreeCentrality(graph *storage.GraphStorage) (map[uint64]float64, error) {
-	stats := graph.GetStatistics()
-
-	nodeIDs := make([]uint64, 0, stats.NodeCount)
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		if node, err := graph.GetNode(i); err == nil && node != nil {
-			nodeIDs = append(nodeIDs, i)
-		}
-	}
+func DegreeCentrality(graph Graph) (ScoreResult, error) {
+	nodeIDs := allNodeIDs(graph)
 
 	degree := make(map[uint64]float64)
 
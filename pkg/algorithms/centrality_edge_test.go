@@ -2,6 +2,7 @@ package algorithms
 
 import (
 	"math"
+	"slices"
 	"testing"
 )
 
@@ -199,6 +200,36 @@ func TestEdgeBetweennessCentrality_TopEdgesOrdering(t *testing.T) {
 	}
 }
 
+// TestFindTopEdges_TiesByEdgeID checks that when more edges tie than fit,
+// the lowest edge IDs are kept, in ascending order, on every run.
+func TestFindTopEdges_TiesByEdgeID(t *testing.T) {
+	gs := setupCentralityTestGraph(t)
+	hub, _ := gs.CreateNode([]string{"Node"}, nil)
+	scores := make(map[uint64]float64)
+	var edgeIDs []uint64
+	for i := 0; i < 12; i++ {
+		leaf, _ := gs.CreateNode([]string{"Node"}, nil)
+		e, err := gs.CreateEdge(hub.ID, leaf.ID, "LINKS", nil, 1.0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scores[e.ID] = 0.5
+		edgeIDs = append(edgeIDs, e.ID)
+	}
+	slices.Sort(edgeIDs)
+
+	for run := 0; run < 20; run++ {
+		top := findTopEdgesView(newTenantBlindView(gs), scores, 5)
+		got := make([]uint64, len(top))
+		for i, e := range top {
+			got[i] = e.EdgeID
+		}
+		if !slices.Equal(got, edgeIDs[:5]) {
+			t.Fatalf("run %d: top edges %v, want %v", run, got, edgeIDs[:5])
+		}
+	}
+}
+
 // TestEdgeBetweennessCentrality_StevesUtility reconstructs the full 33-node,
 // 70-undirected-edge model and verifies edge BC values against NetworkX ground
 // truth (nx.edge_betweenness_centrality on DiGraph, normalized=True).
@@ -785,3 +785,45 @@ func TestBetweennessCentrality_StevesUtility(t *testing.T) {
 		}
 	}
 }
+
+// TestBetweennessCentrality_NodesSubset checks that BetweennessOptions.Nodes
+// restricts the output while paths are still counted over the whole graph.
+func TestBetweennessCentrality_NodesSubset(t *testing.T) {
+	gs := setupCentralityTestGraph(t)
+
+	// a -> b -> c -> d: b and c carry every path through the middle.
+	ids := make([]uint64, 4)
+	for i := range ids {
+		n, _ := gs.CreateNode([]string{"Node"}, nil)
+		ids[i] = n.ID
+	}
+	for i := 0; i+1 < len(ids); i++ {
+		_, _ = gs.CreateEdge(ids[i], ids[i+1], "LINK", nil, 1.0)
+	}
+
+	full, err := BetweennessCentrality(gs)
+	if err != nil {
+		t.Fatalf("BetweennessCentrality failed: %v", err)
+	}
+	subset, err := BetweennessCentralityWithOptions(gs, BetweennessOptions{Nodes: []uint64{ids[0], ids[2]}})
+	if err != nil {
+		t.Fatalf("BetweennessCentralityWithOptions failed: %v", err)
+	}
+
+	if len(subset) != 2 {
+		t.Fatalf("got %d scores, want 2: %v", len(subset), subset)
+	}
+	if subset[ids[2]] == 0 || subset[ids[2]] != full[ids[2]] {
+		t.Errorf("c: subset score %f, full score %f; want equal and non-zero", subset[ids[2]], full[ids[2]])
+	}
+	if _, ok := subset.Get(ids[1]); ok {
+		t.Error("b is outside the subset but was scored")
+	}
+
+	if all, _ := BetweennessCentralityWithOptions(gs, BetweennessOptions{}); len(all) != len(ids) {
+		t.Errorf("nil Nodes scored %d nodes, want all %d", len(all), len(ids))
+	}
+	if none, _ := BetweennessCentralityWithOptions(gs, BetweennessOptions{Nodes: []uint64{}}); len(none) != 0 {
+		t.Errorf("empty Nodes scored %d nodes, want none", len(none))
+	}
+}
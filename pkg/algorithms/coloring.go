@@ -0,0 +1,101 @@
+package algorithms
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// ColoringOptions configures GreedyColoring.
+type ColoringOptions struct {
+	// EdgeTypes restricts which edges count as conflicts (empty = all).
+	// Use it to colour, say, only the lateral-movement links of a network.
+	EdgeTypes []string
+}
+
+// GreedyColoring assigns each node a colour 0..numColors-1 such that no two
+// adjacent nodes share a colour, treating every edge as undirected.
+// Tenant-blind.
+//
+// This is the Welsh-Powell heuristic: nodes are coloured in descending
+// degree order (ties by ascending ID, so results are deterministic), each
+// taking the smallest colour not used by an already-coloured neighbour. It
+// uses at most maxDegree+1 colours but is NOT guaranteed to find the
+// chromatic number — computing that is NP-hard. Self-loops are ignored,
+// since no colouring can satisfy them.
+func GreedyColoring(graph Graph) (map[uint64]int, int, error) {
+	return GreedyColoringWithOptions(graph, ColoringOptions{})
+}
+
+// GreedyColoringWithOptions is GreedyColoring with edge filtering.
+func GreedyColoringWithOptions(graph Graph, opts ColoringOptions) (map[uint64]int, int, error) {
+	return greedyColoringView(newTenantBlindView(graph), opts)
+}
+
+func greedyColoringView(view graphView, opts ColoringOptions) (map[uint64]int, int, error) {
+	nodes := view.AllNodes()
+	neighbors := undirectedNeighbors(view, nodes, opts.EdgeTypes)
+
+	order := make([]uint64, 0, len(nodes))
+	for _, n := range nodes {
+		order = append(order, n.ID)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		di, dj := len(neighbors[order[i]]), len(neighbors[order[j]])
+		if di != dj {
+			return di > dj
+		}
+		return order[i] < order[j]
+	})
+
+	colors := make(map[uint64]int, len(order))
+	numColors := 0
+	for _, id := range order {
+		used := make(map[int]bool, len(neighbors[id]))
+		for nb := range neighbors[id] {
+			if c, ok := colors[nb]; ok {
+				used[c] = true
+			}
+		}
+		color := 0
+		for used[color] {
+			color++
+		}
+		colors[id] = color
+		if color+1 > numColors {
+			numColors = color + 1
+		}
+	}
+
+	return colors, numColors, nil
+}
+
+// undirectedNeighbors builds the undirected adjacency of nodes: each edge
+// of the listed types (empty = all) links both endpoints. Self-loops and
+// edges to nodes outside the list are dropped.
+func undirectedNeighbors(view graphView, nodes []*storage.Node, edgeTypes []string) map[uint64]map[uint64]bool {
+	neighbors := make(map[uint64]map[uint64]bool, len(nodes))
+	for _, n := range nodes {
+		neighbors[n.ID] = make(map[uint64]bool)
+	}
+
+	for _, n := range nodes {
+		edges, err := view.OutgoingEdges(n.ID)
+		if err != nil {
+			continue
+		}
+		for _, edge := range edges {
+			if len(edgeTypes) > 0 && !slices.Contains(edgeTypes, edge.Type) {
+				continue
+			}
+			to := edge.ToNodeID
+			if to == n.ID || neighbors[to] == nil {
+				continue // self-loop, or endpoint outside the view
+			}
+			neighbors[n.ID][to] = true
+			neighbors[to][n.ID] = true
+		}
+	}
+	return neighbors
+}
@@ -0,0 +1,104 @@
+package algorithms
+
+import "testing"
+
+// assertProperColoring fails if any edge (in either direction) joins two
+// nodes of the same colour.
+func assertProperColoring(t *testing.T, graph Graph, colors map[uint64]int) {
+	t.Helper()
+	for id := range colors {
+		edges, _ := graph.GetOutgoingEdges(id)
+		for _, e := range edges {
+			if e.ToNodeID != id && colors[e.ToNodeID] == colors[id] {
+				t.Errorf("nodes %d and %d are adjacent but share colour %d", id, e.ToNodeID, colors[id])
+			}
+		}
+	}
+}
+
+func TestGreedyColoring_OddCycleNeedsThreeColours(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	ids := make([]uint64, 5)
+	for i := range ids {
+		n, _ := gs.CreateNode([]string{"N"}, nil)
+		ids[i] = n.ID
+	}
+	// Directed 5-cycle; colouring uses the undirected projection.
+	for i := range ids {
+		_, _ = gs.CreateEdge(ids[i], ids[(i+1)%len(ids)], "LINK", nil, 1.0)
+	}
+	_, _ = gs.CreateEdge(ids[0], ids[0], "SELF", nil, 1.0) // ignored
+
+	colors, numColors, err := GreedyColoring(gs)
+	if err != nil {
+		t.Fatalf("GreedyColoring: %v", err)
+	}
+	if numColors != 3 {
+		t.Errorf("numColors = %d, want 3 for an odd cycle", numColors)
+	}
+	if len(colors) != len(ids) {
+		t.Errorf("coloured %d nodes, want %d", len(colors), len(ids))
+	}
+	assertProperColoring(t, gs, colors)
+}
+
+func TestGreedyColoring_BipartiteAndIsolated(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	hub, _ := gs.CreateNode([]string{"Switch"}, nil)
+	for i := 0; i < 4; i++ {
+		leaf, _ := gs.CreateNode([]string{"Host"}, nil)
+		_, _ = gs.CreateEdge(leaf.ID, hub.ID, "LAN", nil, 1.0)
+	}
+	lonely, _ := gs.CreateNode([]string{"Host"}, nil)
+
+	colors, numColors, err := GreedyColoring(gs)
+	if err != nil {
+		t.Fatalf("GreedyColoring: %v", err)
+	}
+	if numColors != 2 {
+		t.Errorf("numColors = %d, want 2 for a star", numColors)
+	}
+	if colors[hub.ID] != 0 {
+		t.Errorf("highest-degree node coloured %d, want 0", colors[hub.ID])
+	}
+	if colors[lonely.ID] != 0 {
+		t.Errorf("isolated node coloured %d, want 0", colors[lonely.ID])
+	}
+	assertProperColoring(t, gs, colors)
+}
+
+func TestGreedyColoringWithOptions_EdgeTypes(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	a, _ := gs.CreateNode([]string{"PC"}, nil)
+	b, _ := gs.CreateNode([]string{"PC"}, nil)
+	c, _ := gs.CreateNode([]string{"PC"}, nil)
+	_, _ = gs.CreateEdge(a.ID, b.ID, "SMB_LATERAL", nil, 1.0)
+	_, _ = gs.CreateEdge(b.ID, c.ID, "LAN", nil, 1.0)
+
+	colors, numColors, err := GreedyColoringWithOptions(gs, ColoringOptions{EdgeTypes: []string{"SMB_LATERAL"}})
+	if err != nil {
+		t.Fatalf("GreedyColoringWithOptions: %v", err)
+	}
+	if numColors != 2 || colors[a.ID] == colors[b.ID] {
+		t.Errorf("colors = %v (%d), want a and b apart in 2 colours", colors, numColors)
+	}
+	if colors[c.ID] != 0 {
+		t.Errorf("c only has a LAN edge and should take colour 0, got %d", colors[c.ID])
+	}
+}
+
+func TestGreedyColoring_EmptyGraph(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	colors, numColors, err := GreedyColoring(gs)
+	if err != nil || numColors != 0 || len(colors) != 0 {
+		t.Errorf("got %v, %d, %v; want empty, 0, nil", colors, numColors, err)
+	}
+}
@@ -1,6 +1,9 @@
 package algorithms
 
-import "github.com/dd0wney/graphdb/pkg/storage"
+import (
+	"maps"
+	"slices"
+)
 
 // ClusteringCoefficient computes local clustering coefficient for all nodes
 // Measures how close a node's neighbors are to being a complete graph.
@@ -8,8 +11,8 @@ import "github.com/dd0wney/graphdb/pkg/storage"
 // Enumerates nodes via GetAllNodeIDs rather than scanning IDs 1..NodeCount+buffer.
 // The previous scan-based approach was both fragile (relied on stats.NodeCount
 // being accurate at call time) and slow (one GetNode call per scanned ID).
-func ClusteringCoefficient(graph *storage.GraphStorage) (map[uint64]float64, error) {
-	nodeIDs := graph.GetAllNodeIDs()
+func ClusteringCoefficient(graph Graph) (map[uint64]float64, error) {
+	nodeIDs := allNodeIDs(graph)
 	coefficients := make(map[uint64]float64)
 
 	for _, nodeID := range nodeIDs {
@@ -72,7 +75,7 @@ func ClusteringCoefficient(graph *storage.GraphStorage) (map[uint64]float64, err
 }
 
 // AverageClusteringCoefficient computes the average clustering coefficient
-func AverageClusteringCoefficient(graph *storage.GraphStorage) (float64, error) {
+func AverageClusteringCoefficient(graph Graph) (float64, error) {
 	coefficients, err := ClusteringCoefficient(graph)
 	if err != nil {
 		return 0.0, err
@@ -82,9 +85,10 @@ func AverageClusteringCoefficient(graph *storage.GraphStorage) (float64, error)
 		return 0.0, nil
 	}
 
+	// Summed in node ID order so the average is reproducible to the bit.
 	sum := 0.0
-	for _, coef := range coefficients {
-		sum += coef
+	for _, nodeID := range slices.Sorted(maps.Keys(coefficients)) {
+		sum += coefficients[nodeID]
 	}
 
 	return sum / float64(len(coefficients)), nil
@@ -2,22 +2,32 @@ package algorithms
 
 import (
 	"container/list"
-
-	"github.com/dd0wney/graphdb/pkg/storage"
+	"context"
+	"slices"
 )
 
 // ConnectedComponents finds all connected components in the graph
-func ConnectedComponents(graph *storage.GraphStorage) (*CommunityDetectionResult, error) {
-	stats := graph.GetStatistics()
+// (tenant-blind). Edges are treated as undirected. Multi-tenant API
+// callers must use ConnectedComponentsForTenant.
+func ConnectedComponents(graph Graph) (*CommunityDetectionResult, error) {
+	return connectedComponentsView(context.Background(), newTenantBlindView(graph), allNodeIDs(graph))
+}
 
-	// Get all node IDs - use uint64 to avoid overflow
-	nodeIDs := make([]uint64, 0, stats.NodeCount)
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		if node, err := graph.GetNode(i); err == nil && node != nil {
-			nodeIDs = append(nodeIDs, i)
-		}
+// ConnectedComponentsForTenant finds connected components within the
+// caller's tenant subgraph. ctx is checked before each new component so
+// a request deadline stops the walk (H-6).
+func ConnectedComponentsForTenant(ctx context.Context, graph Graph, tenantID string) (*CommunityDetectionResult, error) {
+	view := newTenantScopedView(graph, tenantID)
+	nodes := view.AllNodes()
+	nodeIDs := make([]uint64, 0, len(nodes))
+	for _, n := range nodes {
+		nodeIDs = append(nodeIDs, n.ID)
 	}
+	slices.Sort(nodeIDs)
+	return connectedComponentsView(ctx, view, nodeIDs)
+}
 
+func connectedComponentsView(ctx context.Context, view graphView, nodeIDs []uint64) (*CommunityDetectionResult, error) {
 	visited := make(map[uint64]bool)
 	nodeCommunity := make(map[uint64]int)
 	communities := make([]*Community, 0)
@@ -28,6 +38,9 @@ func ConnectedComponents(graph *storage.GraphStorage) (*CommunityDetectionResult
 		if visited[startNode] {
 			continue
 		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
 		// New component found
 		component := &Community{
@@ -48,8 +61,8 @@ func ConnectedComponents(graph *storage.GraphStorage) (*CommunityDetectionResult
 			nodeCommunity[nodeID] = communityID
 
 			// Get all neighbors (both incoming and outgoing)
-			outEdges, _ := graph.GetOutgoingEdges(nodeID)
-			inEdges, _ := graph.GetIncomingEdges(nodeID)
+			outEdges, _ := view.OutgoingEdges(nodeID)
+			inEdges, _ := view.IncomingEdges(nodeID)
 
 			for _, edge := range outEdges {
 				if !visited[edge.ToNodeID] {
@@ -66,6 +79,7 @@ func ConnectedComponents(graph *storage.GraphStorage) (*CommunityDetectionResult
 			}
 		}
 
+		slices.Sort(component.Nodes)
 		component.Size = len(component.Nodes)
 		communities = append(communities, component)
 		communityID++
@@ -74,6 +88,6 @@ func ConnectedComponents(graph *storage.GraphStorage) (*CommunityDetectionResult
 	return &CommunityDetectionResult{
 		Communities:   communities,
 		NodeCommunity: nodeCommunity,
-		Modularity:    CalculateModularity(graph, nodeCommunity),
+		Modularity:    calculateModularityView(view, nodeCommunity),
 	}, nil
 }
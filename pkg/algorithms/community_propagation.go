@@ -1,63 +1,76 @@
 package algorithms
 
-import "github.com/dd0wney/graphdb/pkg/storage"
-
-// LabelPropagation performs label propagation for community detection
-// Fast, scalable algorithm for large graphs
-func LabelPropagation(graph *storage.GraphStorage, maxIterations int) (*CommunityDetectionResult, error) {
-	stats := graph.GetStatistics()
-
-	// Get all node IDs - use uint64 to avoid overflow
-	nodeIDs := make([]uint64, 0, stats.NodeCount)
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		if node, err := graph.GetNode(i); err == nil && node != nil {
-			nodeIDs = append(nodeIDs, i)
-		}
-	}
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// LabelPropagation performs label propagation for community detection.
+// Fast, near-linear pass for graphs where modularity optimization is too
+// slow; edges count in either direction.
+//
+// Each iteration visits the nodes in a shuffled order and moves each to
+// the label most common among its neighbors, keeping its own label when
+// that is among the most common and otherwise breaking the tie at random.
+// All randomness comes from seed, so the same graph and seed always give
+// the same communities. Communities are numbered in order of their
+// smallest node ID and list their nodes in ascending order.
+func LabelPropagation(graph Graph, maxIterations int, seed int64) (*CommunityDetectionResult, error) {
+	nodeIDs := allNodeIDs(graph)
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
 
 	// Initialize: each node in its own community
-	labels := make(map[uint64]int)
+	labels := make(map[uint64]int, len(nodeIDs))
 	for i, nodeID := range nodeIDs {
 		labels[nodeID] = i
 	}
 
+	order := slices.Clone(nodeIDs)
+	var ties []int
+
 	// Iterate until convergence or max iterations
 	for iter := 0; iter < maxIterations; iter++ {
 		changed := false
+		rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
 
-		for _, nodeID := range nodeIDs {
+		for _, nodeID := range order {
 			// Count neighbor labels
 			labelCount := make(map[int]int)
 
-			// Get neighbors
 			outEdges, _ := graph.GetOutgoingEdges(nodeID)
 			inEdges, _ := graph.GetIncomingEdges(nodeID)
 
 			for _, edge := range outEdges {
-				neighbor := edge.ToNodeID
-				labelCount[labels[neighbor]]++
+				labelCount[labels[edge.ToNodeID]]++
 			}
-
 			for _, edge := range inEdges {
-				neighbor := edge.FromNodeID
-				labelCount[labels[neighbor]]++
+				labelCount[labels[edge.FromNodeID]]++
+			}
+			if len(labelCount) == 0 {
+				continue
 			}
 
-			// Find most frequent label
+			// Find the most frequent labels
 			maxCount := 0
-			maxLabel := labels[nodeID]
+			ties = ties[:0]
 			for label, count := range labelCount {
-				if count > maxCount {
+				switch {
+				case count > maxCount:
 					maxCount = count
-					maxLabel = label
+					ties = append(ties[:0], label)
+				case count == maxCount:
+					ties = append(ties, label)
 				}
 			}
 
-			// Update label if changed
-			if maxLabel != labels[nodeID] {
-				labels[nodeID] = maxLabel
-				changed = true
+			current := labels[nodeID]
+			if labelCount[current] == maxCount {
+				continue
 			}
+			// Map order is random per run; sort before the seeded pick.
+			slices.Sort(ties)
+			labels[nodeID] = ties[rng.IntN(len(ties))]
+			changed = true
 		}
 
 		if !changed {
@@ -65,33 +78,21 @@ func LabelPropagation(graph *storage.GraphStorage, maxIterations int) (*Communit
 		}
 	}
 
-	// Build communities from labels
-	communityNodes := make(map[int][]uint64)
-	for nodeID, label := range labels {
-		communityNodes[label] = append(communityNodes[label], nodeID)
-	}
-
-	communities := make([]*Community, 0, len(communityNodes))
-	nodeCommunity := make(map[uint64]int)
-	communityID := 0
-
-	for _, nodes := range communityNodes {
-		if len(nodes) == 0 {
-			continue
-		}
-
-		community := &Community{
-			ID:    communityID,
-			Nodes: nodes,
-			Size:  len(nodes),
+	// Build communities from labels, in ascending node ID order
+	communities := make([]*Community, 0)
+	nodeCommunity := make(map[uint64]int, len(nodeIDs))
+	communityOf := make(map[int]*Community)
+
+	for _, nodeID := range nodeIDs {
+		community, ok := communityOf[labels[nodeID]]
+		if !ok {
+			community = &Community{ID: len(communities)}
+			communityOf[labels[nodeID]] = community
+			communities = append(communities, community)
 		}
-
-		for _, nodeID := range nodes {
-			nodeCommunity[nodeID] = communityID
-		}
-
-		communities = append(communities, community)
-		communityID++
+		community.Nodes = append(community.Nodes, nodeID)
+		community.Size++
+		nodeCommunity[nodeID] = community.ID
 	}
 
 	return &CommunityDetectionResult{
@@ -1,8 +1,12 @@
 package algorithms
 
 import (
+	"context"
+	"errors"
 	"math"
 	"os"
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -188,13 +192,94 @@ func TestConnectedComponents_IsolatedNodes(t *testing.T) {
 	}
 }
 
+// TestConnectedComponents_AfterDelete covers node IDs with gaps: IDs are
+// never reused, so after a delete the highest live ID exceeds NodeCount and
+// a 1..NodeCount scan would miss the newest node.
+func TestConnectedComponents_AfterDelete(t *testing.T) {
+	gs := setupCommunityTestGraph(t)
+
+	nodeA, _ := gs.CreateNode([]string{"Node"}, nil)
+	nodeB, _ := gs.CreateNode([]string{"Node"}, nil)
+	nodeC, _ := gs.CreateNode([]string{"Node"}, nil)
+	if err := gs.DeleteNode(nodeA.ID); err != nil {
+		t.Fatal(err)
+	}
+	nodeD, _ := gs.CreateNode([]string{"Node"}, nil)
+	if nodeD.ID <= gs.GetStatistics().NodeCount {
+		t.Fatalf("node ID %d reused or within NodeCount", nodeD.ID)
+	}
+	if _, err := gs.CreateEdge(nodeC.ID, nodeD.ID, "LINKS", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ConnectedComponents(gs)
+	if err != nil {
+		t.Fatalf("ConnectedComponents failed: %v", err)
+	}
+	if len(result.Communities) != 2 {
+		t.Errorf("Expected 2 components, got %d", len(result.Communities))
+	}
+	if _, ok := result.NodeCommunity[nodeD.ID]; !ok {
+		t.Error("Newest node missing from the components")
+	}
+	if result.NodeCommunity[nodeC.ID] != result.NodeCommunity[nodeD.ID] || result.NodeCommunity[nodeB.ID] == result.NodeCommunity[nodeC.ID] {
+		t.Errorf("Expected {B} and {C, D}, got %v", result.NodeCommunity)
+	}
+
+	degree, err := DegreeCentrality(gs)
+	if err != nil {
+		t.Fatalf("DegreeCentrality failed: %v", err)
+	}
+	if len(degree) != 3 || degree[nodeD.ID] == 0 {
+		t.Errorf("Expected degree for B, C and D with D linked, got %v", degree)
+	}
+}
+
+// TestConnectedComponentsForTenant_IgnoresOtherTenants checks that another
+// tenant's nodes neither appear as components nor count toward modularity.
+func TestConnectedComponentsForTenant_IgnoresOtherTenants(t *testing.T) {
+	gs := setupCommunityTestGraph(t)
+
+	a1, _ := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	a2, _ := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	a3, _ := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	b1, _ := gs.CreateNodeWithTenant("tenant-B", []string{"N"}, nil)
+	b2, _ := gs.CreateNodeWithTenant("tenant-B", []string{"N"}, nil)
+	if _, err := gs.CreateEdgeWithTenant("tenant-A", a1.ID, a2.ID, "LINKS", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gs.CreateEdgeWithTenant("tenant-B", b1.ID, b2.ID, "LINKS", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ConnectedComponentsForTenant(context.Background(), gs, "tenant-A")
+	if err != nil {
+		t.Fatalf("ConnectedComponentsForTenant failed: %v", err)
+	}
+	if len(result.Communities) != 2 {
+		t.Fatalf("Expected {a1, a2} and {a3}, got %d components", len(result.Communities))
+	}
+	if len(result.NodeCommunity) != 3 {
+		t.Errorf("Expected only tenant-A nodes, got %v", result.NodeCommunity)
+	}
+	if result.NodeCommunity[a1.ID] != result.NodeCommunity[a2.ID] || result.NodeCommunity[a1.ID] == result.NodeCommunity[a3.ID] {
+		t.Errorf("Expected {a1, a2} and {a3}, got %v", result.NodeCommunity)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ConnectedComponentsForTenant(ctx, gs, "tenant-A"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
 // TestLabelPropagation_SingleNode tests label propagation with single node
 func TestLabelPropagation_SingleNode(t *testing.T) {
 	gs := setupCommunityTestGraph(t)
 
 	node, _ := gs.CreateNode([]string{"Node"}, nil)
 
-	result, err := LabelPropagation(gs, 10)
+	result, err := LabelPropagation(gs, 10, 1)
 
 	if err != nil {
 		t.Fatalf("LabelPropagation failed: %v", err)
@@ -232,7 +317,7 @@ func TestLabelPropagation_FullyConnected(t *testing.T) {
 		}
 	}
 
-	result, err := LabelPropagation(gs, 10)
+	result, err := LabelPropagation(gs, 10, 1)
 
 	if err != nil {
 		t.Fatalf("LabelPropagation failed: %v", err)
@@ -279,7 +364,7 @@ func TestLabelPropagation_TwoClusters(t *testing.T) {
 	// Weak link between clusters (B -> E only)
 	_, _ = gs.CreateEdge(nodeB.ID, nodeE.ID, "LINKS", nil, 1.0)
 
-	result, err := LabelPropagation(gs, 20)
+	result, err := LabelPropagation(gs, 20, 1)
 
 	if err != nil {
 		t.Fatalf("LabelPropagation failed: %v", err)
@@ -617,6 +702,50 @@ func TestModularity_ConnectedComponentsIntegration(t *testing.T) {
 	}
 }
 
+// TestLabelPropagation_SeedDeterminism runs label propagation on a graph
+// full of ties (a ring, where every node starts with two equally common
+// neighbor labels) and checks the same seed always gives the same result.
+func TestLabelPropagation_SeedDeterminism(t *testing.T) {
+	gs := setupCommunityTestGraph(t)
+
+	nodes := make([]*storage.Node, 12)
+	for i := range nodes {
+		nodes[i], _ = gs.CreateNode([]string{"Node"}, nil)
+	}
+	for i := range nodes {
+		_, _ = gs.CreateEdge(nodes[i].ID, nodes[(i+1)%len(nodes)].ID, "LINKS", nil, 1.0)
+	}
+
+	for seed := int64(0); seed < 5; seed++ {
+		first, err := LabelPropagation(gs, 20, seed)
+		if err != nil {
+			t.Fatalf("LabelPropagation failed: %v", err)
+		}
+		for run := 0; run < 3; run++ {
+			again, _ := LabelPropagation(gs, 20, seed)
+			if !reflect.DeepEqual(first.NodeCommunity, again.NodeCommunity) {
+				t.Fatalf("seed %d: run %d assigned %v, first run %v", seed, run, again.NodeCommunity, first.NodeCommunity)
+			}
+		}
+
+		// Communities are numbered by smallest member and list members in
+		// ascending order.
+		var lastFirst uint64
+		for i, community := range first.Communities {
+			if community.ID != i || community.Size != len(community.Nodes) {
+				t.Errorf("seed %d: community %d has ID %d, size %d for %d nodes", seed, i, community.ID, community.Size, len(community.Nodes))
+			}
+			if !slices.IsSorted(community.Nodes) {
+				t.Errorf("seed %d: community %d nodes not sorted: %v", seed, i, community.Nodes)
+			}
+			if i > 0 && community.Nodes[0] <= lastFirst {
+				t.Errorf("seed %d: community %d is out of order", seed, i)
+			}
+			lastFirst = community.Nodes[0]
+		}
+	}
+}
+
 // TestModularity_LabelPropagationIntegration tests that LabelPropagation calculates modularity
 func TestModularity_LabelPropagationIntegration(t *testing.T) {
 	gs := setupCommunityTestGraph(t)
@@ -631,7 +760,7 @@ func TestModularity_LabelPropagationIntegration(t *testing.T) {
 	_, _ = gs.CreateEdge(nodeB.ID, nodeC.ID, "LINKS", nil, 1.0)
 	_, _ = gs.CreateEdge(nodeC.ID, nodeB.ID, "LINKS", nil, 1.0)
 
-	result, err := LabelPropagation(gs, 10)
+	result, err := LabelPropagation(gs, 10, 1)
 	if err != nil {
 		t.Fatalf("LabelPropagation failed: %v", err)
 	}
@@ -1,6 +1,9 @@
 package algorithms
 
-import "github.com/dd0wney/graphdb/pkg/storage"
+import (
+	"maps"
+	"slices"
+)
 
 // Community represents a detected community
 type Community struct {
@@ -27,7 +30,13 @@ type CommunityDetectionResult struct {
 //   - m = total number of edges
 //   - l_c = number of edges within community c
 //   - d_c = sum of degrees of nodes in community c
-func CalculateModularity(graph *storage.GraphStorage, nodeCommunity map[uint64]int) float64 {
+func CalculateModularity(graph Graph, nodeCommunity map[uint64]int) float64 {
+	return calculateModularityView(newTenantBlindView(graph), nodeCommunity)
+}
+
+// calculateModularityView is CalculateModularity against a graphView, so
+// tenant-scoped callers only count edges inside their tenant.
+func calculateModularityView(view graphView, nodeCommunity map[uint64]int) float64 {
 	if len(nodeCommunity) == 0 {
 		return 0.0
 	}
@@ -37,11 +46,11 @@ func CalculateModularity(graph *storage.GraphStorage, nodeCommunity map[uint64]i
 	nodeDegree := make(map[uint64]int)
 
 	for nodeID := range nodeCommunity {
-		outEdges, err := graph.GetOutgoingEdges(nodeID)
+		outEdges, err := view.OutgoingEdges(nodeID)
 		if err != nil {
 			continue
 		}
-		inEdges, err := graph.GetIncomingEdges(nodeID)
+		inEdges, err := view.IncomingEdges(nodeID)
 		if err != nil {
 			continue
 		}
@@ -66,10 +75,13 @@ func CalculateModularity(graph *storage.GraphStorage, nodeCommunity map[uint64]i
 		communityNodes[commID] = append(communityNodes[commID], nodeID)
 	}
 
-	// Calculate modularity
+	// Calculate modularity, summing communities in ID order so the
+	// float result does not depend on map iteration order.
+	commIDs := slices.Sorted(maps.Keys(communityNodes))
 	modularity := 0.0
 
-	for _, nodes := range communityNodes {
+	for _, commID := range commIDs {
+		nodes := communityNodes[commID]
 		// Create set for fast lookup
 		nodeSet := make(map[uint64]bool)
 		for _, n := range nodes {
@@ -84,7 +96,7 @@ func CalculateModularity(graph *storage.GraphStorage, nodeCommunity map[uint64]i
 		for _, nodeID := range nodes {
 			degreeSum += nodeDegree[nodeID]
 
-			outEdges, err := graph.GetOutgoingEdges(nodeID)
+			outEdges, err := view.OutgoingEdges(nodeID)
 			if err != nil {
 				continue
 			}
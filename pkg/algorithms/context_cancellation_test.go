@@ -72,6 +72,18 @@ func TestAlgorithms_HonorContextCancellation(t *testing.T) {
 			_, e := NodeSimilarityAllForTenant(ctx, gs, DefaultNodeSimilarityOptions(), tid)
 			return e
 		}},
+		{"pagerank", func() error {
+			_, e := PageRankForTenant(ctx, gs, DefaultPageRankOptions(), tid)
+			return e
+		}},
+		{"closeness", func() error {
+			_, e := ClosenessCentralityForTenant(ctx, gs, tid)
+			return e
+		}},
+		{"cycles", func() error {
+			_, e := DetectCyclesWithOptionsForTenant(ctx, gs, CycleDetectionOptions{}, tid)
+			return e
+		}},
 	}
 
 	for _, c := range checks {
@@ -0,0 +1,133 @@
+package algorithms
+
+import "container/heap"
+
+// GreedyVertexCover returns a small set of nodes that touches every edge,
+// treating edges as undirected: monitor these nodes and every link's
+// traffic passes through a monitored node. Tenant-blind.
+//
+// Nodes are taken greedily, each time the one touching the most edges not
+// yet covered (ties by ascending ID, so results are deterministic), and
+// are returned in the order taken, so a prefix is the greedy placement for
+// a smaller monitoring budget. Finding the minimum cover is NP-hard; the
+// greedy cover is at most H(maxDegree) ≈ ln(maxDegree)+1 times larger.
+// Self-loops are ignored.
+func GreedyVertexCover(graph Graph) ([]uint64, error) {
+	return greedyVertexCoverView(newTenantBlindView(graph))
+}
+
+func greedyVertexCoverView(view graphView) ([]uint64, error) {
+	neighbors := undirectedNeighbors(view, view.AllNodes(), nil)
+	inCover := make(map[uint64]bool)
+
+	// An edge is uncovered while neither endpoint is in the cover, so a
+	// candidate covers one new edge per neighbor still outside it.
+	gain := func(id uint64) int {
+		n := 0
+		for nb := range neighbors[id] {
+			if !inCover[nb] {
+				n++
+			}
+		}
+		return n
+	}
+	take := func(id uint64) { inCover[id] = true }
+
+	return lazyGreedy(neighbors, gain, take), nil
+}
+
+// GreedyDominatingSet returns a small set of nodes such that every node is
+// in the set or adjacent to a member, treating edges as undirected: a
+// sensor on each member observes every node directly or from next door.
+// Isolated nodes can only dominate themselves, so they are always members.
+// Tenant-blind.
+//
+// Like GreedyVertexCover this is the greedy set-cover heuristic — each
+// step takes the node dominating the most nodes not yet dominated, ties by
+// ascending ID — with members returned in the order taken. The minimum
+// dominating set is NP-hard; the greedy set is at most H(maxDegree+1)
+// times larger.
+func GreedyDominatingSet(graph Graph) ([]uint64, error) {
+	return greedyDominatingSetView(newTenantBlindView(graph))
+}
+
+func greedyDominatingSetView(view graphView) ([]uint64, error) {
+	neighbors := undirectedNeighbors(view, view.AllNodes(), nil)
+	dominated := make(map[uint64]bool)
+
+	gain := func(id uint64) int {
+		n := 0
+		if !dominated[id] {
+			n++
+		}
+		for nb := range neighbors[id] {
+			if !dominated[nb] {
+				n++
+			}
+		}
+		return n
+	}
+	take := func(id uint64) {
+		dominated[id] = true
+		for nb := range neighbors[id] {
+			dominated[nb] = true
+		}
+	}
+
+	return lazyGreedy(neighbors, gain, take), nil
+}
+
+// lazyGreedy runs the greedy set-cover loop over the nodes of neighbors:
+// take the node with the largest gain (ties by ascending ID) until no node
+// gains anything, returning the nodes in the order taken. Gains only fall
+// as nodes are taken, so a queued gain is an upper bound: the top of the
+// queue is re-scored on pop and taken only if its gain still holds.
+func lazyGreedy(neighbors map[uint64]map[uint64]bool, gain func(uint64) int, take func(uint64)) []uint64 {
+	queue := make(gainQueue, 0, len(neighbors))
+	for id := range neighbors {
+		if g := gain(id); g > 0 {
+			queue = append(queue, gainItem{id: id, gain: g})
+		}
+	}
+	heap.Init(&queue)
+
+	var chosen []uint64
+	for queue.Len() > 0 {
+		top := heap.Pop(&queue).(gainItem)
+		g := gain(top.id)
+		if g == 0 {
+			continue
+		}
+		if g < top.gain {
+			heap.Push(&queue, gainItem{id: top.id, gain: g})
+			continue
+		}
+		take(top.id)
+		chosen = append(chosen, top.id)
+	}
+	return chosen
+}
+
+type gainItem struct {
+	id   uint64
+	gain int
+}
+
+// gainQueue is a max-heap on gain, ties by ascending node ID.
+type gainQueue []gainItem
+
+func (q gainQueue) Len() int { return len(q) }
+func (q gainQueue) Less(i, j int) bool {
+	if q[i].gain != q[j].gain {
+		return q[i].gain > q[j].gain
+	}
+	return q[i].id < q[j].id
+}
+func (q gainQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *gainQueue) Push(x any)   { *q = append(*q, x.(gainItem)) }
+func (q *gainQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
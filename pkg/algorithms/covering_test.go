@@ -0,0 +1,141 @@
+package algorithms
+
+import (
+	"slices"
+	"testing"
+)
+
+// assertVertexCover fails unless every non-loop edge has an endpoint in cover.
+func assertVertexCover(t *testing.T, graph Graph, ids, cover []uint64) {
+	t.Helper()
+	for _, id := range ids {
+		edges, _ := graph.GetOutgoingEdges(id)
+		for _, e := range edges {
+			if e.ToNodeID != e.FromNodeID && !slices.Contains(cover, e.FromNodeID) && !slices.Contains(cover, e.ToNodeID) {
+				t.Errorf("edge %d -> %d is not covered by %v", e.FromNodeID, e.ToNodeID, cover)
+			}
+		}
+	}
+}
+
+// assertDominatingSet fails unless every node is in set or adjacent to a
+// member, in either direction.
+func assertDominatingSet(t *testing.T, graph Graph, ids, set []uint64) {
+	t.Helper()
+	dominated := make(map[uint64]bool)
+	for _, id := range set {
+		dominated[id] = true
+		out, _ := graph.GetOutgoingEdges(id)
+		for _, e := range out {
+			dominated[e.ToNodeID] = true
+		}
+		in, _ := graph.GetIncomingEdges(id)
+		for _, e := range in {
+			dominated[e.FromNodeID] = true
+		}
+	}
+	for _, id := range ids {
+		if !dominated[id] {
+			t.Errorf("node %d is not dominated by %v", id, set)
+		}
+	}
+}
+
+func TestGreedyCovering_Path(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	ids := make([]uint64, 5)
+	for i := range ids {
+		n, _ := gs.CreateNode([]string{"N"}, nil)
+		ids[i] = n.ID
+	}
+	for i := 0; i+1 < len(ids); i++ {
+		_, _ = gs.CreateEdge(ids[i], ids[i+1], "LINK", nil, 1.0)
+	}
+	_, _ = gs.CreateEdge(ids[0], ids[0], "SELF", nil, 1.0) // ignored
+
+	// 0-1-2-3-4: both heuristics take 1, then 3 (beating 2 and 4 on gain
+	// or ID), which is optimal for each.
+	want := []uint64{ids[1], ids[3]}
+
+	cover, err := GreedyVertexCover(gs)
+	if err != nil {
+		t.Fatalf("GreedyVertexCover: %v", err)
+	}
+	if !slices.Equal(cover, want) {
+		t.Errorf("GreedyVertexCover = %v, want %v", cover, want)
+	}
+
+	set, err := GreedyDominatingSet(gs)
+	if err != nil {
+		t.Fatalf("GreedyDominatingSet: %v", err)
+	}
+	if !slices.Equal(set, want) {
+		t.Errorf("GreedyDominatingSet = %v, want %v", set, want)
+	}
+}
+
+func TestGreedyCovering_SegmentedNetwork(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	// Two switched segments joined by a firewall, plus an air-gapped host.
+	var ids []uint64
+	node := func() uint64 {
+		n, _ := gs.CreateNode([]string{"N"}, nil)
+		ids = append(ids, n.ID)
+		return n.ID
+	}
+	firewall := node()
+	var switches []uint64
+	for s := 0; s < 2; s++ {
+		sw := node()
+		switches = append(switches, sw)
+		_, _ = gs.CreateEdge(firewall, sw, "LINK", nil, 1.0)
+		for h := 0; h < 4; h++ {
+			_, _ = gs.CreateEdge(node(), sw, "LAN", nil, 1.0)
+		}
+	}
+	airGapped := node()
+
+	cover, err := GreedyVertexCover(gs)
+	if err != nil {
+		t.Fatalf("GreedyVertexCover: %v", err)
+	}
+	if !slices.Equal(cover, switches) {
+		t.Errorf("GreedyVertexCover = %v, want the switches %v", cover, switches)
+	}
+	assertVertexCover(t, gs, ids, cover)
+
+	set, err := GreedyDominatingSet(gs)
+	if err != nil {
+		t.Fatalf("GreedyDominatingSet: %v", err)
+	}
+	if want := append(slices.Clone(switches), airGapped); !slices.Equal(set, want) {
+		t.Errorf("GreedyDominatingSet = %v, want the switches and the air-gapped host %v", set, want)
+	}
+	assertDominatingSet(t, gs, ids, set)
+
+	// Deterministic across runs despite map iteration inside.
+	for i := 0; i < 5; i++ {
+		if again, _ := GreedyVertexCover(gs); !slices.Equal(again, cover) {
+			t.Fatalf("run %d: GreedyVertexCover = %v, first run %v", i, again, cover)
+		}
+		if again, _ := GreedyDominatingSet(gs); !slices.Equal(again, set) {
+			t.Fatalf("run %d: GreedyDominatingSet = %v, first run %v", i, again, set)
+		}
+	}
+}
+
+func TestGreedyCovering_EmptyGraph(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	if cover, err := GreedyVertexCover(gs); err != nil || len(cover) != 0 {
+		t.Errorf("GreedyVertexCover(empty) = %v, %v", cover, err)
+	}
+	if set, err := GreedyDominatingSet(gs); err != nil || len(set) != 0 {
+		t.Errorf("GreedyDominatingSet(empty) = %v, %v", set, err)
+	}
+}
@@ -1,177 +1,308 @@
 package algorithms
 
 import (
+	"context"
+	"slices"
+
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
 // Cycle represents a detected cycle as a sequence of node IDs
+//
+// The IDs are in edge order — each node has an edge to the next, and the
+// last has an edge back to the first — and the sequence starts at the
+// cycle's smallest node ID, so a cycle has exactly one representation
+// regardless of where the search entered it. A self-loop is a one-node
+// cycle.
 type Cycle []uint64
 
-// DetectCycles finds all cycles in the graph using DFS with
-// three-color marking. Tenant-blind. Multi-tenant API callers must
+// DefaultMaxCycles caps how many cycles a single detection reports when
+// CycleDetectionOptions.MaxCycles is zero. Dense graphs can hold
+// exponentially many simple cycles, so enumeration must be bounded.
+const DefaultMaxCycles = 10000
+
+// DetectCycles finds the simple (elementary) cycles in the graph, up to
+// DefaultMaxCycles of them. Tenant-blind. Multi-tenant API callers must
 // use DetectCyclesForTenant.
 //
-// Algorithm: depth-first search with three colors:
-//   - WHITE (0): Unvisited node
-//   - GRAY (1): Currently visiting (in recursion stack)
-//   - BLACK (2): Finished visiting (all descendants explored)
-//
-// A GRAY node found during DFS is a back edge, indicating a cycle.
-func DetectCycles(graph storage.Storage) ([]Cycle, error) {
-	return detectCyclesView(newTenantBlindView(graph))
+// Algorithm: Johnson's circuit enumeration. Nodes are taken in ascending
+// ID order; for each start node s the search only visits nodes with larger
+// IDs, so every cycle is reported once, from its smallest node. Nodes that
+// cannot currently reach s are blocked until a path through them might
+// appear again, which keeps the cost proportional to the number of cycles
+// found rather than the number of paths.
+func DetectCycles(graph Graph) ([]Cycle, error) {
+	return detectCyclesView(context.Background(), newTenantBlindView(graph))
 }
 
 // DetectCyclesForTenant finds cycles within the caller's tenant
-// subgraph. Audit A6c-algorithms (2026-05-08).
-func DetectCyclesForTenant(graph storage.Storage, tenantID string) ([]Cycle, error) {
-	return detectCyclesView(newTenantScopedView(graph, tenantID))
+// subgraph. Audit A6c-algorithms (2026-05-08). ctx is checked before
+// each start node's circuit search.
+func DetectCyclesForTenant(ctx context.Context, graph Graph, tenantID string) ([]Cycle, error) {
+	return detectCyclesView(ctx, newTenantScopedView(graph, tenantID))
 }
 
-func detectCyclesView(view graphView) ([]Cycle, error) {
-	const (
-		WHITE = 0
-		GRAY  = 1
-		BLACK = 2
-	)
-	_ = GRAY
-	_ = BLACK
+func detectCyclesView(ctx context.Context, view graphView) ([]Cycle, error) {
+	return detectCyclesWithOptionsView(ctx, view, CycleDetectionOptions{})
+}
 
-	color := make(map[uint64]int)
-	parent := make(map[uint64]uint64)
-	cycles := make([]Cycle, 0)
+// CycleDetectionOptions configures cycle detection behavior
+type CycleDetectionOptions struct {
+	MinCycleLength int                      // Minimum cycle length to report (0 = all)
+	MaxCycleLength int                      // Maximum cycle length to report (0 = unlimited)
+	MaxCycles      int                      // Stop after this many cycles (0 = DefaultMaxCycles, <0 = unlimited)
+	NodePredicate  func(*storage.Node) bool // Only include cycles with nodes matching predicate
+	EdgeTypes      []string                 // Only follow edges of these types (empty = all types)
+}
 
-	allNodes := view.AllNodes()
-	if len(allNodes) == 0 {
-		return cycles, nil
+// DetectCyclesWithOptions finds cycles matching the given criteria
+// (tenant-blind).
+func DetectCyclesWithOptions(graph Graph, opts CycleDetectionOptions) ([]Cycle, error) {
+	return detectCyclesWithOptionsView(context.Background(), newTenantBlindView(graph), opts)
+}
+
+// DetectCyclesWithOptionsForTenant restricts the cycle search and
+// filtering to the caller's tenant. Audit A6c-algorithms. ctx is checked
+// before each start node's circuit search.
+func DetectCyclesWithOptionsForTenant(ctx context.Context, graph Graph, opts CycleDetectionOptions, tenantID string) ([]Cycle, error) {
+	return detectCyclesWithOptionsView(ctx, newTenantScopedView(graph, tenantID), opts)
+}
+
+func detectCyclesWithOptionsView(ctx context.Context, view graphView, opts CycleDetectionOptions) ([]Cycle, error) {
+	limit := opts.MaxCycles
+	if limit == 0 {
+		limit = DefaultMaxCycles
 	}
 
-	for _, n := range allNodes {
-		if color[n.ID] == WHITE {
-			dfsDetectCycleView(view, n.ID, color, parent, &cycles)
+	// NodePredicate applies to every node of a reported cycle, so
+	// non-matching nodes can be dropped from the search up front.
+	nodeIDs := make([]uint64, 0)
+	for _, n := range view.AllNodes() {
+		if opts.NodePredicate == nil || opts.NodePredicate(n) {
+			nodeIDs = append(nodeIDs, n.ID)
+		}
+	}
+	slices.Sort(nodeIDs)
+
+	search := newCycleSearch(nodeIDs, cycleAdjacency(view, nodeIDs, opts.EdgeTypes))
+	search.minLength = opts.MinCycleLength
+	search.maxLength = opts.MaxCycleLength
+	search.limit = limit
+	for start := range nodeIDs {
+		if search.done() {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
+		search.reset(start)
+		search.circuit(start)
 	}
 
-	return cycles, nil
+	return search.cycles, nil
 }
 
-func dfsDetectCycleView(
-	view graphView,
-	nodeID uint64,
-	color map[uint64]int,
-	parent map[uint64]uint64,
-	cycles *[]Cycle,
-) {
-	const (
-		WHITE = 0
-		GRAY  = 1
-		BLACK = 2
-	)
-
-	color[nodeID] = GRAY
-
-	edges, err := view.OutgoingEdges(nodeID)
-	if err != nil {
-		color[nodeID] = BLACK
-		return
+// cycleAdjacency builds the de-duplicated, ID-sorted successor lists of
+// the searched subgraph. Parallel edges would otherwise report the same
+// node cycle more than once. Edges between different strongly connected
+// components can never lie on a cycle, so they are dropped; this keeps
+// the per-start-node search from wandering down acyclic tails.
+func cycleAdjacency(view graphView, nodeIDs []uint64, edgeTypes []string) map[uint64][]uint64 {
+	inSubgraph := make(map[uint64]bool, len(nodeIDs))
+	for _, id := range nodeIDs {
+		inSubgraph[id] = true
 	}
 
-	for _, edge := range edges {
-		neighborID := edge.ToNodeID
-
-		// Self-loop detected
-		if neighborID == nodeID {
-			*cycles = append(*cycles, Cycle{nodeID})
+	adj := make(map[uint64][]uint64, len(nodeIDs))
+	for _, id := range nodeIDs {
+		edges, err := view.OutgoingEdges(id)
+		if err != nil {
 			continue
 		}
-
-		switch color[neighborID] {
-		case WHITE:
-			parent[neighborID] = nodeID
-			dfsDetectCycleView(view, neighborID, color, parent, cycles)
-		case GRAY:
-			cycle := extractCycle(neighborID, nodeID, parent)
-			*cycles = append(*cycles, cycle)
+		seen := make(map[uint64]bool)
+		for _, edge := range edges {
+			if len(edgeTypes) > 0 && !slices.Contains(edgeTypes, edge.Type) {
+				continue
+			}
+			if inSubgraph[edge.ToNodeID] && !seen[edge.ToNodeID] {
+				seen[edge.ToNodeID] = true
+				adj[id] = append(adj[id], edge.ToNodeID)
+			}
 		}
+		slices.Sort(adj[id])
 	}
 
-	color[nodeID] = BLACK
+	component := componentsOf(adj, nodeIDs)
+	for id, succ := range adj {
+		adj[id] = slices.DeleteFunc(succ, func(w uint64) bool {
+			return component[w] != component[id]
+		})
+	}
+	return adj
 }
 
-// extractCycle reconstructs the cycle from parent pointers
-// Given a back edge from 'end' to 'start', we trace back from 'end' to 'start' using parent pointers
-func extractCycle(start, end uint64, parent map[uint64]uint64) Cycle {
-	cycle := make(Cycle, 0)
-	cycle = append(cycle, start)
-
-	current := end
-	for current != start {
-		cycle = append(cycle, current)
-		if p, exists := parent[current]; exists {
-			current = p
-		} else {
-			// Safety: shouldn't happen if algorithm is correct
-			break
+// componentsOf labels each node with its strongly connected component
+// (Tarjan's algorithm over adj).
+func componentsOf(adj map[uint64][]uint64, nodeIDs []uint64) map[uint64]int {
+	state := make(map[uint64]*tarjanState, len(nodeIDs))
+	component := make(map[uint64]int, len(nodeIDs))
+	var stack []uint64
+	index, next := 0, 0
+
+	var strongconnect func(u uint64)
+	strongconnect = func(u uint64) {
+		su := &tarjanState{index: index, lowlink: index, onStack: true}
+		state[u] = su
+		index++
+		stack = append(stack, u)
+
+		for _, v := range adj[u] {
+			if sv, seen := state[v]; !seen {
+				strongconnect(v)
+				su.lowlink = min(su.lowlink, state[v].lowlink)
+			} else if sv.onStack {
+				su.lowlink = min(su.lowlink, sv.index)
+			}
+		}
+
+		if su.lowlink == su.index {
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				state[w].onStack = false
+				component[w] = next
+				if w == u {
+					break
+				}
+			}
+			next++
 		}
 	}
 
-	return cycle
+	for _, id := range nodeIDs {
+		if _, seen := state[id]; !seen {
+			strongconnect(id)
+		}
+	}
+	return component
 }
 
-// CycleDetectionOptions configures cycle detection behavior
-type CycleDetectionOptions struct {
-	MinCycleLength int                      // Minimum cycle length to report (0 = all)
-	MaxCycleLength int                      // Maximum cycle length to report (0 = unlimited)
-	NodePredicate  func(*storage.Node) bool // Only include cycles with nodes matching predicate
-	EdgeTypes      []string                 // Only follow edges of these types (empty = all types)
+// cycleSearch is the state of one Johnson's-algorithm enumeration. Nodes
+// are addressed by their index in the ascending ID list, so "w < start"
+// is the same test on indexes as on IDs and the per-start state can live
+// in slices rather than maps.
+type cycleSearch struct {
+	ids       []uint64
+	adj       [][]int
+	minLength int
+	maxLength int
+	limit     int // <0 = unlimited
+
+	start     int
+	stack     []int
+	blocked   []bool
+	blockedBy [][]int // w -> nodes to unblock when w unblocks
+	touched   []int   // nodes whose blocked state needs resetting
+
+	cycles []Cycle
 }
 
-// DetectCyclesWithOptions finds cycles matching the given criteria
-// (tenant-blind).
-func DetectCyclesWithOptions(graph storage.Storage, opts CycleDetectionOptions) ([]Cycle, error) {
-	return detectCyclesWithOptionsView(newTenantBlindView(graph), opts)
+func newCycleSearch(ids []uint64, adj map[uint64][]uint64) *cycleSearch {
+	index := make(map[uint64]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	cs := &cycleSearch{
+		ids:       ids,
+		adj:       make([][]int, len(ids)),
+		blocked:   make([]bool, len(ids)),
+		blockedBy: make([][]int, len(ids)),
+		cycles:    make([]Cycle, 0),
+	}
+	for i, id := range ids {
+		for _, w := range adj[id] {
+			cs.adj[i] = append(cs.adj[i], index[w])
+		}
+	}
+	return cs
 }
 
-// DetectCyclesWithOptionsForTenant restricts the cycle search and
-// filtering to the caller's tenant. Audit A6c-algorithms.
-func DetectCyclesWithOptionsForTenant(graph storage.Storage, opts CycleDetectionOptions, tenantID string) ([]Cycle, error) {
-	return detectCyclesWithOptionsView(newTenantScopedView(graph, tenantID), opts)
+func (cs *cycleSearch) reset(start int) {
+	for _, v := range cs.touched {
+		cs.blocked[v] = false
+		cs.blockedBy[v] = cs.blockedBy[v][:0]
+	}
+	cs.touched = cs.touched[:0]
+	cs.start = start
 }
 
-func detectCyclesWithOptionsView(view graphView, opts CycleDetectionOptions) ([]Cycle, error) {
-	allCycles, err := detectCyclesView(view)
-	if err != nil {
-		return nil, err
-	}
+func (cs *cycleSearch) done() bool {
+	return cs.limit >= 0 && len(cs.cycles) >= cs.limit
+}
 
-	filtered := make([]Cycle, 0)
-	for _, cycle := range allCycles {
-		// Filter by length
-		if opts.MinCycleLength > 0 && len(cycle) < opts.MinCycleLength {
-			continue
+// circuit extends the current path from v, reporting every cycle that
+// closes back at cs.start. It returns true if any cycle (or a branch cut
+// short by maxLength) was found through v, in which case v is unblocked.
+func (cs *cycleSearch) circuit(v int) bool {
+	found := false
+	cs.stack = append(cs.stack, v)
+	cs.blocked[v] = true
+	cs.touched = append(cs.touched, v)
+
+	for _, w := range cs.adj[v] {
+		if cs.done() {
+			break
 		}
-		if opts.MaxCycleLength > 0 && len(cycle) > opts.MaxCycleLength {
-			continue
+		if w < cs.start {
+			continue // cycles through w were reported from w
+		}
+		if w == cs.start {
+			cs.report()
+			found = true
+		} else if cs.maxLength > 0 && len(cs.stack) >= cs.maxLength {
+			// Too long to report; treat as found so v is unblocked
+			// rather than wrongly marked as unable to reach start.
+			found = true
+		} else if !cs.blocked[w] && cs.circuit(w) {
+			found = true
 		}
+	}
 
-		// Filter by node predicate
-		if opts.NodePredicate != nil {
-			allMatch := true
-			for _, nodeID := range cycle {
-				node, err := view.Node(nodeID)
-				if err != nil || !opts.NodePredicate(node) {
-					allMatch = false
-					break
-				}
-			}
-			if !allMatch {
-				continue
+	if found {
+		cs.unblock(v)
+	} else {
+		for _, w := range cs.adj[v] {
+			if w >= cs.start && !slices.Contains(cs.blockedBy[w], v) {
+				cs.blockedBy[w] = append(cs.blockedBy[w], v)
+				cs.touched = append(cs.touched, w)
 			}
 		}
+	}
+
+	cs.stack = cs.stack[:len(cs.stack)-1]
+	return found
+}
 
-		filtered = append(filtered, cycle)
+func (cs *cycleSearch) unblock(v int) {
+	cs.blocked[v] = false
+	waiting := cs.blockedBy[v]
+	cs.blockedBy[v] = nil
+	for _, w := range waiting {
+		if cs.blocked[w] {
+			cs.unblock(w)
+		}
 	}
+}
 
-	return filtered, nil
+func (cs *cycleSearch) report() {
+	if len(cs.stack) < cs.minLength {
+		return
+	}
+	cycle := make(Cycle, len(cs.stack))
+	for i, v := range cs.stack {
+		cycle[i] = cs.ids[v]
+	}
+	cs.cycles = append(cs.cycles, cycle)
 }
 
 // CycleStats provides statistics about detected cycles
@@ -218,13 +349,13 @@ func AnalyzeCycles(cycles []Cycle) CycleStats {
 
 // HasCycle checks if the graph contains any cycles (tenant-blind,
 // faster than detecting all cycles).
-func HasCycle(graph storage.Storage) (bool, error) {
+func HasCycle(graph Graph) (bool, error) {
 	return hasCycleView(newTenantBlindView(graph))
 }
 
 // HasCycleForTenant checks if the caller's tenant subgraph contains
 // any cycles. Audit A6c-algorithms.
-func HasCycleForTenant(graph storage.Storage, tenantID string) (bool, error) {
+func HasCycleForTenant(graph Graph, tenantID string) (bool, error) {
 	return hasCycleView(newTenantScopedView(graph, tenantID))
 }
 
@@ -1,6 +1,7 @@
 package algorithms
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -156,6 +157,111 @@ func TestDetectCycles_ComplexGraph(t *testing.T) {
 	}
 }
 
+// TestDetectCycles_ReportsEverySimpleCycleInOrder pins the node sequences:
+// in the graph from TestDetectCycles_ComplexGraph both 1->2->4->5->1 and
+// 1->2->3->6->4->5->1 are simple cycles, and each must come back once,
+// in edge order, starting from its smallest node ID.
+func TestDetectCycles_ReportsEverySimpleCycleInOrder(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	nodes := make([]*storage.Node, 7)
+	for i := 1; i <= 6; i++ {
+		nodes[i], _ = gs.CreateNode([]string{"Node"}, nil)
+	}
+	id := func(i int) uint64 { return nodes[i].ID }
+
+	_, _ = gs.CreateEdge(id(1), id(2), "E", nil, 1.0)
+	_, _ = gs.CreateEdge(id(2), id(3), "E", nil, 1.0)
+	_, _ = gs.CreateEdge(id(2), id(4), "E", nil, 1.0)
+	_, _ = gs.CreateEdge(id(3), id(6), "E", nil, 1.0)
+	_, _ = gs.CreateEdge(id(4), id(5), "E", nil, 1.0)
+	_, _ = gs.CreateEdge(id(5), id(1), "E", nil, 1.0)
+	_, _ = gs.CreateEdge(id(6), id(4), "E", nil, 1.0)
+	// A parallel edge must not produce a duplicate cycle.
+	_, _ = gs.CreateEdge(id(4), id(5), "E", nil, 1.0)
+
+	cycles, err := DetectCycles(gs)
+	if err != nil {
+		t.Fatalf("DetectCycles failed: %v", err)
+	}
+
+	want := []Cycle{
+		{id(1), id(2), id(3), id(6), id(4), id(5)},
+		{id(1), id(2), id(4), id(5)},
+	}
+	if len(cycles) != len(want) {
+		t.Fatalf("got %d cycles %v, want %v", len(cycles), cycles, want)
+	}
+	for i := range want {
+		if !slices.Equal(cycles[i], want[i]) {
+			t.Errorf("cycle %d = %v, want %v", i, cycles[i], want[i])
+		}
+	}
+}
+
+// TestDetectCyclesWithOptions_MaxCycles bounds enumeration on a complete
+// digraph, which has far more simple cycles than the cap.
+func TestDetectCyclesWithOptions_MaxCycles(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	nodes := make([]*storage.Node, 7)
+	for i := range nodes {
+		nodes[i], _ = gs.CreateNode([]string{"Node"}, nil)
+	}
+	for _, from := range nodes {
+		for _, to := range nodes {
+			if from != to {
+				_, _ = gs.CreateEdge(from.ID, to.ID, "E", nil, 1.0)
+			}
+		}
+	}
+
+	cycles, err := DetectCyclesWithOptions(gs, CycleDetectionOptions{MaxCycles: 25})
+	if err != nil {
+		t.Fatalf("DetectCyclesWithOptions failed: %v", err)
+	}
+	if len(cycles) != 25 {
+		t.Errorf("got %d cycles, want the cap of 25", len(cycles))
+	}
+
+	// Unlimited: K7 has sum_{k=2..7} C(7,k)*(k-1)! = 2365 simple cycles.
+	all, err := DetectCyclesWithOptions(gs, CycleDetectionOptions{MaxCycles: -1})
+	if err != nil {
+		t.Fatalf("DetectCyclesWithOptions failed: %v", err)
+	}
+	if len(all) != 2365 {
+		t.Errorf("got %d cycles, want 2365", len(all))
+	}
+
+	// Length-bounded search: only the 21 two-cycles.
+	short, _ := DetectCyclesWithOptions(gs, CycleDetectionOptions{MaxCycleLength: 2, MaxCycles: -1})
+	if len(short) != 21 {
+		t.Errorf("got %d cycles of length <= 2, want 21", len(short))
+	}
+}
+
+// TestDetectCyclesWithOptions_EdgeTypes only follows the listed edge types.
+func TestDetectCyclesWithOptions_EdgeTypes(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	a, _ := gs.CreateNode([]string{"Step"}, nil)
+	b, _ := gs.CreateNode([]string{"Step"}, nil)
+	_, _ = gs.CreateEdge(a.ID, b.ID, "APPROVES", nil, 1.0)
+	_, _ = gs.CreateEdge(b.ID, a.ID, "REFERENCES", nil, 1.0)
+
+	cycles, _ := DetectCyclesWithOptions(gs, CycleDetectionOptions{EdgeTypes: []string{"APPROVES"}})
+	if len(cycles) != 0 {
+		t.Errorf("APPROVES-only search found %v", cycles)
+	}
+	cycles, _ = DetectCyclesWithOptions(gs, CycleDetectionOptions{EdgeTypes: []string{"APPROVES", "REFERENCES"}})
+	if len(cycles) != 1 {
+		t.Errorf("got %d cycles, want 1", len(cycles))
+	}
+}
+
 // TestDetectCycles_EmptyGraph tests an empty graph
 func TestDetectCycles_EmptyGraph(t *testing.T) {
 	gs := setupTestGraph(t)
@@ -0,0 +1,26 @@
+// Package algorithms implements graph analytics over graphdb's storage:
+// centrality, PageRank, community detection, paths, similarity and link
+// prediction, and structural measures such as cycles, triangles and covers.
+//
+// Most algorithms come in two forms: a tenant-blind function for embedded and
+// CLI use, and a ForTenant variant that only sees one tenant's subgraph. The
+// API must use the ForTenant variants. [AsOf] runs any of them against a
+// valid-time slice of the graph.
+//
+// # Output order
+//
+// Every ordered output is deterministic: the same graph and arguments give
+// the same result, byte for byte, on every run. Algorithms visit nodes in
+// ascending ID order and never let map iteration order decide a result.
+//
+//   - Ranked lists (TopNodes, TopEdges, [ScoreResult.Sorted], similarity and
+//     link-prediction results) are by descending score, ties by ascending
+//     node or edge ID, so a top-k cut keeps the same entries every time.
+//   - Community and component members are listed in ascending node ID.
+//     Connected components are numbered by their smallest node ID; strongly
+//     connected components in the order Tarjan's algorithm completes them.
+//   - [LabelPropagation] is randomized, but all randomness comes from its
+//     seed.
+//   - Floating-point sums run in a fixed order, so scores are reproducible
+//     to the last bit rather than merely to a tolerance.
+package algorithms
@@ -0,0 +1,73 @@
+package algorithms
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestBetweennessCentrality_ViewUnaffectedByConcurrentWrites runs an
+// algorithm against a GraphView while writers mutate the live graph; the
+// result must match a run on the same view with no writers at all.
+func TestBetweennessCentrality_ViewUnaffectedByConcurrentWrites(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	var ids []uint64
+	for i := 0; i < 30; i++ {
+		n, err := gs.CreateNode([]string{"N"}, nil)
+		if err != nil {
+			t.Fatalf("CreateNode: %v", err)
+		}
+		ids = append(ids, n.ID)
+	}
+	for i := 1; i < len(ids); i++ {
+		if _, err := gs.CreateEdge(ids[i-1], ids[i], "NEXT", nil, 1.0); err != nil {
+			t.Fatalf("CreateEdge: %v", err)
+		}
+	}
+
+	view, err := gs.View()
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+	want, err := BetweennessCentrality(view)
+	if err != nil {
+		t.Fatalf("BetweennessCentrality: %v", err)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			n, err := gs.CreateNode([]string{"N"}, nil)
+			if err != nil {
+				return
+			}
+			_, _ = gs.CreateEdge(ids[i%len(ids)], n.ID, "NEXT", nil, 1.0)
+			_ = gs.DeleteNode(n.ID)
+		}
+	}()
+
+	got, err := BetweennessCentrality(view)
+	close(stop)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("BetweennessCentrality during writes: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d scores, want %d", len(got), len(want))
+	}
+	for id, score := range want {
+		if got[id] != score {
+			t.Errorf("node %d: score %v during writes, want %v", id, got[id], score)
+		}
+	}
+}
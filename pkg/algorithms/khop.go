@@ -1,10 +1,6 @@
 package algorithms
 
-import (
-	"fmt"
-
-	"github.com/dd0wney/graphdb/pkg/storage"
-)
+import "fmt"
 
 // KHopOptions configures the k-hop neighbourhood traversal.
 type KHopOptions struct {
@@ -39,7 +35,7 @@ type bfsEntry struct {
 // returning all discovered nodes grouped by distance. Tenant-blind —
 // runs across every tenant. Multi-tenant API callers must use
 // KHopNeighboursForTenant. The source node is never included in results.
-func KHopNeighbours(graph storage.Storage, sourceNodeID uint64, opts KHopOptions) (*KHopResult, error) {
+func KHopNeighbours(graph Graph, sourceNodeID uint64, opts KHopOptions) (*KHopResult, error) {
 	return kHopNeighboursView(newTenantBlindView(graph), sourceNodeID, opts)
 }
 
@@ -48,7 +44,7 @@ func KHopNeighbours(graph storage.Storage, sourceNodeID uint64, opts KHopOptions
 // expansion uses the tenant-scoped *ForTenant edge accessors, so
 // foreign-tenant node IDs never appear in ByHop / Distances. Pairs
 // with the tenant-strict fix to the /algorithms khop handler.
-func KHopNeighboursForTenant(graph storage.Storage, sourceNodeID uint64, opts KHopOptions, tenantID string) (*KHopResult, error) {
+func KHopNeighboursForTenant(graph Graph, sourceNodeID uint64, opts KHopOptions, tenantID string) (*KHopResult, error) {
 	return kHopNeighboursView(newTenantScopedView(graph, tenantID), sourceNodeID, opts)
 }
 
@@ -1,10 +1,10 @@
 package algorithms
 
 import (
+	"maps"
 	"math"
+	"slices"
 	"sort"
-
-	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
 // LinkPredictionMethod selects the scoring formula for link prediction.
@@ -46,7 +46,7 @@ type LinkPrediction struct {
 // LinkPredictionResult holds predictions for a single source node.
 type LinkPredictionResult struct {
 	SourceNodeID uint64
-	Predictions  []LinkPrediction // sorted desc by Score
+	Predictions  []LinkPrediction // sorted desc by Score, then asc by ToNodeID
 }
 
 // DefaultLinkPredictionOptions returns sensible defaults.
@@ -61,13 +61,13 @@ func DefaultLinkPredictionOptions() LinkPredictionOptions {
 
 // PredictLinkScore computes the link prediction score between two
 // specific nodes (tenant-blind).
-func PredictLinkScore(graph storage.Storage, fromNodeID, toNodeID uint64, opts LinkPredictionOptions) (float64, error) {
+func PredictLinkScore(graph Graph, fromNodeID, toNodeID uint64, opts LinkPredictionOptions) (float64, error) {
 	return predictLinkScoreView(newTenantBlindView(graph), fromNodeID, toNodeID, opts)
 }
 
 // PredictLinkScoreForTenant restricts to the caller's tenant.
 // Audit A6c-algorithms.
-func PredictLinkScoreForTenant(graph storage.Storage, fromNodeID, toNodeID uint64, opts LinkPredictionOptions, tenantID string) (float64, error) {
+func PredictLinkScoreForTenant(graph Graph, fromNodeID, toNodeID uint64, opts LinkPredictionOptions, tenantID string) (float64, error) {
 	return predictLinkScoreView(newTenantScopedView(graph, tenantID), fromNodeID, toNodeID, opts)
 }
 
@@ -79,13 +79,13 @@ func predictLinkScoreView(view graphView, fromNodeID, toNodeID uint64, opts Link
 
 // PredictLinksFor predicts links for a source node against all other
 // nodes (tenant-blind).
-func PredictLinksFor(graph storage.Storage, sourceNodeID uint64, opts LinkPredictionOptions) (*LinkPredictionResult, error) {
+func PredictLinksFor(graph Graph, sourceNodeID uint64, opts LinkPredictionOptions) (*LinkPredictionResult, error) {
 	return predictLinksForView(newTenantBlindView(graph), sourceNodeID, opts)
 }
 
 // PredictLinksForForTenant restricts to caller's tenant.
 // Audit A6c-algorithms.
-func PredictLinksForForTenant(graph storage.Storage, sourceNodeID uint64, opts LinkPredictionOptions, tenantID string) (*LinkPredictionResult, error) {
+func PredictLinksForForTenant(graph Graph, sourceNodeID uint64, opts LinkPredictionOptions, tenantID string) (*LinkPredictionResult, error) {
 	return predictLinksForView(newTenantScopedView(graph, tenantID), sourceNodeID, opts)
 }
 
@@ -125,7 +125,10 @@ func predictLinksForView(view graphView, sourceNodeID uint64, opts LinkPredictio
 	}
 
 	sort.Slice(predictions, func(i, j int) bool {
-		return predictions[i].Score > predictions[j].Score
+		if predictions[i].Score != predictions[j].Score {
+			return predictions[i].Score > predictions[j].Score
+		}
+		return predictions[i].ToNodeID < predictions[j].ToNodeID
 	})
 	if opts.TopK > 0 && len(predictions) > opts.TopK {
 		predictions = predictions[:opts.TopK]
@@ -162,7 +165,9 @@ func computeLinkScore(view graphView, setA, setB map[uint64]bool, opts LinkPredi
 		if len(setA) > len(setB) {
 			small, big = setB, setA
 		}
-		for id := range small {
+		// Sum in ascending ID order: float addition is not associative, and
+		// map order would let tied predictions swap places between runs.
+		for _, id := range slices.Sorted(maps.Keys(small)) {
 			if big[id] {
 				// Degree of the common neighbor
 				degree := len(getNeighborSet(view, id, opts.Direction, opts.EdgeTypes))
@@ -3,6 +3,7 @@ package algorithms
 import (
 	"math"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -275,3 +276,37 @@ func TestPredictLinksFor_TopK(t *testing.T) {
 		t.Errorf("TopK=1 but got %d predictions", len(result.Predictions))
 	}
 }
+
+// TestPredictLinksFor_TiesByNodeID checks that tied predictions are listed
+// by ascending target ID, so TopK keeps the same ones every run.
+func TestPredictLinksFor_TiesByNodeID(t *testing.T) {
+	gs := setupLinkPredictionTestGraph(t)
+
+	a, _ := gs.CreateNode([]string{"Node"}, nil)
+	hub, _ := gs.CreateNode([]string{"Node"}, nil)
+	_, _ = gs.CreateEdge(a.ID, hub.ID, "LINKS", nil, 1.0)
+	var peers []uint64
+	for i := 0; i < 5; i++ {
+		p, _ := gs.CreateNode([]string{"Node"}, nil)
+		_, _ = gs.CreateEdge(p.ID, hub.ID, "LINKS", nil, 1.0)
+		peers = append(peers, p.ID)
+	}
+
+	opts := DefaultLinkPredictionOptions()
+	opts.Direction = DirectionOut
+	opts.TopK = 3
+
+	for run := 0; run < 20; run++ {
+		result, err := PredictLinksFor(gs, a.ID, opts)
+		if err != nil {
+			t.Fatalf("PredictLinksFor failed: %v", err)
+		}
+		got := make([]uint64, len(result.Predictions))
+		for i, p := range result.Predictions {
+			got[i] = p.ToNodeID
+		}
+		if !slices.Equal(got, peers[:3]) {
+			t.Fatalf("run %d: predictions %v, want %v", run, got, peers[:3])
+		}
+	}
+}
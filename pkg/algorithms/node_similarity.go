@@ -1,11 +1,10 @@
 package algorithms
 
 import (
+	"cmp"
 	"context"
 	"math"
-	"sort"
-
-	"github.com/dd0wney/graphdb/pkg/storage"
+	"slices"
 )
 
 // NeighborDirection controls which edges to follow when building neighbor sets.
@@ -44,7 +43,7 @@ type NodeSimilarityScore struct {
 // NodeSimilarityResult holds similarity results for a single source node.
 type NodeSimilarityResult struct {
 	SourceNodeID uint64
-	Similar      []NodeSimilarityScore // sorted desc by Score, zeros excluded
+	Similar      []NodeSimilarityScore // sorted desc by Score then asc by NodeB, zeros excluded
 }
 
 // DefaultNodeSimilarityOptions returns sensible defaults.
@@ -146,14 +145,25 @@ func computeSimilarity(setA, setB map[uint64]bool, metric SimilarityMetric) floa
 	}
 }
 
+// sortSimilarityScores orders scores by descending Score, ties by
+// ascending NodeB, so TopK cuts the same nodes on every run.
+func sortSimilarityScores(scores []NodeSimilarityScore) {
+	slices.SortFunc(scores, func(a, b NodeSimilarityScore) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.NodeB, b.NodeB)
+	})
+}
+
 // NodeSimilarityPair computes similarity between two nodes (tenant-blind).
-func NodeSimilarityPair(graph storage.Storage, nodeA, nodeB uint64, opts NodeSimilarityOptions) (float64, error) {
+func NodeSimilarityPair(graph Graph, nodeA, nodeB uint64, opts NodeSimilarityOptions) (float64, error) {
 	return nodeSimilarityPairView(newTenantBlindView(graph), nodeA, nodeB, opts)
 }
 
 // NodeSimilarityPairForTenant computes similarity restricted to the
 // caller's tenant. Audit A6c-algorithms.
-func NodeSimilarityPairForTenant(graph storage.Storage, nodeA, nodeB uint64, opts NodeSimilarityOptions, tenantID string) (float64, error) {
+func NodeSimilarityPairForTenant(graph Graph, nodeA, nodeB uint64, opts NodeSimilarityOptions, tenantID string) (float64, error) {
 	return nodeSimilarityPairView(newTenantScopedView(graph, tenantID), nodeA, nodeB, opts)
 }
 
@@ -166,13 +176,13 @@ func nodeSimilarityPairView(view graphView, nodeA, nodeB uint64, opts NodeSimila
 // NodeSimilarityFor computes similarity of sourceNodeID against all
 // other nodes (tenant-blind). Multi-tenant API callers must use
 // NodeSimilarityForTenant.
-func NodeSimilarityFor(graph storage.Storage, sourceNodeID uint64, opts NodeSimilarityOptions) (*NodeSimilarityResult, error) {
+func NodeSimilarityFor(graph Graph, sourceNodeID uint64, opts NodeSimilarityOptions) (*NodeSimilarityResult, error) {
 	return nodeSimilarityForView(newTenantBlindView(graph), sourceNodeID, opts)
 }
 
 // NodeSimilarityForForTenant restricts to caller's tenant.
 // Audit A6c-algorithms.
-func NodeSimilarityForForTenant(graph storage.Storage, sourceNodeID uint64, opts NodeSimilarityOptions, tenantID string) (*NodeSimilarityResult, error) {
+func NodeSimilarityForForTenant(graph Graph, sourceNodeID uint64, opts NodeSimilarityOptions, tenantID string) (*NodeSimilarityResult, error) {
 	return nodeSimilarityForView(newTenantScopedView(graph, tenantID), sourceNodeID, opts)
 }
 
@@ -201,9 +211,7 @@ func nodeSimilarityForView(view graphView, sourceNodeID uint64, opts NodeSimilar
 		}
 	}
 
-	sort.Slice(scores, func(i, j int) bool {
-		return scores[i].Score > scores[j].Score
-	})
+	sortSimilarityScores(scores)
 
 	if opts.TopK > 0 && len(scores) > opts.TopK {
 		scores = scores[:opts.TopK]
@@ -217,14 +225,14 @@ func nodeSimilarityForView(view graphView, sourceNodeID uint64, opts NodeSimilar
 
 // NodeSimilarityAll computes similarity for every node against every
 // other node (tenant-blind).
-func NodeSimilarityAll(graph storage.Storage, opts NodeSimilarityOptions) ([]NodeSimilarityResult, error) {
+func NodeSimilarityAll(graph Graph, opts NodeSimilarityOptions) ([]NodeSimilarityResult, error) {
 	return nodeSimilarityAllView(context.Background(), newTenantBlindView(graph), opts)
 }
 
 // NodeSimilarityAllForTenant restricts to caller's tenant.
 // Audit A6c-algorithms. ctx cancels the O(V²) all-pairs computation when
 // the request deadline fires (H-6).
-func NodeSimilarityAllForTenant(ctx context.Context, graph storage.Storage, opts NodeSimilarityOptions, tenantID string) ([]NodeSimilarityResult, error) {
+func NodeSimilarityAllForTenant(ctx context.Context, graph Graph, opts NodeSimilarityOptions, tenantID string) ([]NodeSimilarityResult, error) {
 	return nodeSimilarityAllView(ctx, newTenantScopedView(graph, tenantID), opts)
 }
 
@@ -263,9 +271,7 @@ func nodeSimilarityAllView(ctx context.Context, view graphView, opts NodeSimilar
 			}
 		}
 
-		sort.Slice(scores, func(i, j int) bool {
-			return scores[i].Score > scores[j].Score
-		})
+		sortSimilarityScores(scores)
 		if opts.TopK > 0 && len(scores) > opts.TopK {
 			scores = scores[:opts.TopK]
 		}
@@ -278,3 +284,62 @@ func nodeSimilarityAllView(ctx context.Context, view graphView, opts NodeSimilar
 
 	return results, nil
 }
+
+// JaccardSimilarity is the Jaccard index of the neighborhoods of a and b,
+// |N(a)∩N(b)| / |N(a)∪N(b)|, counting edges in either direction: 1 for
+// two nodes wired to exactly the same peers (a redundant pair), 0 for
+// nodes with nothing in common. Returns ErrNodeNotFound if either node
+// does not exist. Tenant-blind; see JaccardSimilarityForTenant.
+func JaccardSimilarity(graph Graph, a, b uint64) (float64, error) {
+	return jaccardSimilarityView(newTenantBlindView(graph), a, b)
+}
+
+// JaccardSimilarityForTenant restricts both neighborhoods to the caller's
+// tenant.
+func JaccardSimilarityForTenant(graph Graph, a, b uint64, tenantID string) (float64, error) {
+	return jaccardSimilarityView(newTenantScopedView(graph, tenantID), a, b)
+}
+
+func jaccardSimilarityView(view graphView, a, b uint64) (float64, error) {
+	for _, id := range []uint64{a, b} {
+		if _, err := view.Node(id); err != nil {
+			return 0, err
+		}
+	}
+	return nodeSimilarityPairView(view, a, b, jaccardOptions(0))
+}
+
+// MostSimilar returns up to k nodes with the highest JaccardSimilarity to
+// a, best first, leaving out a itself and nodes that share no neighbor
+// with it. k <= 0 returns every such node. Tenant-blind; see
+// MostSimilarForTenant.
+func MostSimilar(graph Graph, a uint64, k int) ([]NodeSimilarityScore, error) {
+	return mostSimilarView(newTenantBlindView(graph), a, k)
+}
+
+// MostSimilarForTenant restricts the candidates and neighborhoods to the
+// caller's tenant.
+func MostSimilarForTenant(graph Graph, a uint64, k int, tenantID string) ([]NodeSimilarityScore, error) {
+	return mostSimilarView(newTenantScopedView(graph, tenantID), a, k)
+}
+
+func mostSimilarView(view graphView, a uint64, k int) ([]NodeSimilarityScore, error) {
+	if _, err := view.Node(a); err != nil {
+		return nil, err
+	}
+	result, err := nodeSimilarityForView(view, a, jaccardOptions(k))
+	if err != nil {
+		return nil, err
+	}
+	return result.Similar, nil
+}
+
+// jaccardOptions are the options behind JaccardSimilarity and MostSimilar:
+// structural similarity ignores edge direction.
+func jaccardOptions(topK int) NodeSimilarityOptions {
+	return NodeSimilarityOptions{
+		Metric:    SimilarityJaccard,
+		Direction: DirectionBoth,
+		TopK:      max(topK, 0),
+	}
+}
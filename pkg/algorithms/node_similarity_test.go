@@ -1,8 +1,10 @@
 package algorithms
 
 import (
+	"errors"
 	"math"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -298,6 +300,41 @@ func TestNodeSimilarityFor(t *testing.T) {
 	_ = f
 }
 
+// TestNodeSimilarityFor_TiesByNodeID checks that equally similar nodes are
+// listed by ascending ID, so TopK cuts the same ones every run.
+func TestNodeSimilarityFor_TiesByNodeID(t *testing.T) {
+	gs := setupSimilarityTestGraph(t)
+
+	// Source and five peers all point at the same hub: every peer scores 1.
+	hub, _ := gs.CreateNode([]string{"Node"}, nil)
+	src, _ := gs.CreateNode([]string{"Node"}, nil)
+	_, _ = gs.CreateEdge(src.ID, hub.ID, "LINKS", nil, 1.0)
+	var peers []uint64
+	for i := 0; i < 5; i++ {
+		p, _ := gs.CreateNode([]string{"Node"}, nil)
+		_, _ = gs.CreateEdge(p.ID, hub.ID, "LINKS", nil, 1.0)
+		peers = append(peers, p.ID)
+	}
+
+	opts := DefaultNodeSimilarityOptions()
+	opts.Direction = DirectionOut
+	opts.TopK = 3
+
+	for run := 0; run < 20; run++ {
+		result, err := NodeSimilarityFor(gs, src.ID, opts)
+		if err != nil {
+			t.Fatalf("NodeSimilarityFor failed: %v", err)
+		}
+		got := make([]uint64, len(result.Similar))
+		for i, s := range result.Similar {
+			got[i] = s.NodeB
+		}
+		if !slices.Equal(got, peers[:3]) {
+			t.Fatalf("run %d: similar nodes %v, want %v", run, got, peers[:3])
+		}
+	}
+}
+
 func TestNodeSimilarityAll(t *testing.T) {
 	gs := setupSimilarityTestGraph(t)
 
@@ -351,3 +388,48 @@ func TestNodeSimilarityPair_IdenticalNeighbors(t *testing.T) {
 		}
 	}
 }
+
+func TestJaccardSimilarity_RedundantPeer(t *testing.T) {
+	gs := setupSimilarityTestGraph(t)
+
+	// Feeders F1, F2 supply substations S1 and S2, which both serve load
+	// L; S3 hangs off F1 alone. S2 is S1's redundant peer.
+	node := func() uint64 {
+		n, _ := gs.CreateNode([]string{"Node"}, nil)
+		return n.ID
+	}
+	f1, f2, s1, s2, s3, l := node(), node(), node(), node(), node(), node()
+	for _, e := range [][2]uint64{
+		{f1, s1}, {f2, s1}, {f1, s2}, {f2, s2}, {f1, s3},
+		{s1, l}, {s2, l},
+	} {
+		if _, err := gs.CreateEdge(e[0], e[1], "FEEDS", nil, 1.0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if score, err := JaccardSimilarity(gs, s1, s2); err != nil || score != 1 {
+		t.Errorf("JaccardSimilarity(S1, S2) = %v, %v; want 1", score, err)
+	}
+	if score, _ := JaccardSimilarity(gs, s1, s3); math.Abs(score-1.0/3.0) > 0.001 {
+		t.Errorf("JaccardSimilarity(S1, S3) = %v, want 1/3", score)
+	}
+	if _, err := JaccardSimilarity(gs, s1, 9999); !errors.Is(err, storage.ErrNodeNotFound) {
+		t.Errorf("JaccardSimilarity with a missing node: err = %v, want ErrNodeNotFound", err)
+	}
+
+	top, err := MostSimilar(gs, s1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 1 || top[0].NodeB != s2 || top[0].Score != 1 {
+		t.Errorf("MostSimilar(S1, 1) = %+v, want S2 with score 1", top)
+	}
+	all, _ := MostSimilar(gs, s1, 0)
+	if len(all) != 2 || all[0].NodeB != s2 || all[1].NodeB != s3 {
+		t.Errorf("MostSimilar(S1, 0) = %+v, want S2 then S3", all)
+	}
+	if _, err := MostSimilar(gs, 9999, 1); !errors.Is(err, storage.ErrNodeNotFound) {
+		t.Errorf("MostSimilar for a missing node: err = %v, want ErrNodeNotFound", err)
+	}
+}
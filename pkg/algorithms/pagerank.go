@@ -2,6 +2,8 @@ package algorithms
 
 import (
 	"container/heap"
+	"context"
+	"fmt"
 	"math"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -12,6 +14,27 @@ type PageRankOptions struct {
 	DampingFactor float64 // Usually 0.85
 	MaxIterations int
 	Tolerance     float64 // Convergence threshold
+
+	// PersonalizationVector, when non-nil, makes the random surfer restart
+	// at nodes in proportion to these weights instead of uniformly — i.e.
+	// personalized PageRank. Seeding it with a critical asset (say, the
+	// SCADA master) scores every node by proximity to that asset. Weights
+	// need not sum to 1; they are normalized over the nodes in the graph,
+	// and IDs not in the graph are ignored. Weights must be non-negative
+	// with at least one positive weight on a graph node.
+	PersonalizationVector map[uint64]float64
+
+	// Nodes restricts Scores and TopNodes to these node IDs: nil keeps every
+	// node, and an empty, non-nil slice keeps none. Rank still flows through
+	// the whole graph, so each listed node scores exactly what it would
+	// without the option; the subset's scores no longer sum to 1.
+	Nodes []uint64
+
+	// TopK is how many nodes TopNodes lists. Each entry carries a copy of
+	// its node, so this is the costly part of the result on a large graph:
+	// 0 lists every node, and a negative value lists none, for callers
+	// that only read Scores.
+	TopK int
 }
 
 // DefaultPageRankOptions returns default PageRank configuration
@@ -23,12 +46,24 @@ func DefaultPageRankOptions() PageRankOptions {
 	}
 }
 
+// PageRankVariantDanglingRedistributed identifies the PageRank variant in
+// which the rank held by dangling nodes (nodes with no outgoing edges) is
+// redistributed every iteration along the teleport distribution, so scores
+// always sum to 1. Earlier releases let that rank leak and renormalized
+// once at the end: the converged ranking is the same, but every
+// intermediate vector summed to less than 1, so the Tolerance check ran on
+// shrunken scores and could stop early on sink-heavy graphs.
+const PageRankVariantDanglingRedistributed = "dangling-redistributed"
+
 // PageRankResult contains PageRank scores for all nodes
 type PageRankResult struct {
-	Scores     map[uint64]float64 // Node ID -> PageRank score
-	Iterations int                // Number of iterations performed
-	Converged  bool               // Whether algorithm converged
-	TopNodes   []RankedNode       // Top N nodes by score
+	Scores     ScoreResult  // Node ID -> PageRank score
+	Iterations int          // Number of iterations performed
+	Converged  bool         // Whether algorithm converged
+	TopNodes   []RankedNode // The top PageRankOptions.TopK nodes (default all), by descending score then ascending ID
+
+	Variant       string // Which PageRank variant ran, e.g. PageRankVariantDanglingRedistributed
+	DanglingNodes int    // Nodes with no outgoing edges whose rank was redistributed
 }
 
 // RankedNode represents a node with its rank
@@ -42,8 +77,8 @@ type RankedNode struct {
 // (tenant-blind — runs across every tenant). Used by CLI, demos, and
 // single-tenant deployments. Multi-tenant API callers must use
 // PageRankForTenant.
-func PageRank(graph storage.Storage, opts PageRankOptions) (*PageRankResult, error) {
-	return pageRankView(newTenantBlindView(graph), opts)
+func PageRank(graph Graph, opts PageRankOptions) (*PageRankResult, error) {
+	return pageRankView(context.Background(), newTenantBlindView(graph), opts)
 }
 
 // PageRankForTenant computes PageRank scores for nodes owned by the
@@ -51,20 +86,23 @@ func PageRank(graph storage.Storage, opts PageRankOptions) (*PageRankResult, err
 // body as PageRank, but the underlying graph access is restricted to
 // the tenant — foreign-tenant nodes are excluded from the scoring
 // graph and edges to foreign-tenant nodes are dropped at expansion.
-func PageRankForTenant(graph storage.Storage, opts PageRankOptions, tenantID string) (*PageRankResult, error) {
-	return pageRankView(newTenantScopedView(graph, tenantID), opts)
+// ctx is checked once per iteration, so a cancelled request stops the
+// computation instead of running out MaxIterations.
+func PageRankForTenant(ctx context.Context, graph Graph, opts PageRankOptions, tenantID string) (*PageRankResult, error) {
+	return pageRankView(ctx, newTenantScopedView(graph, tenantID), opts)
 }
 
 // pageRankView is the shared algorithm body. Operates against a
 // graphView so tenant-blind and tenant-scoped public functions can
 // share one implementation — see pkg/algorithms/view.go.
-func pageRankView(view graphView, opts PageRankOptions) (*PageRankResult, error) {
+func pageRankView(ctx context.Context, view graphView, opts PageRankOptions) (*PageRankResult, error) {
 	allNodes := view.AllNodes()
 
 	if len(allNodes) == 0 {
 		return &PageRankResult{
 			Scores:    make(map[uint64]float64),
 			Converged: true,
+			Variant:   PageRankVariantDanglingRedistributed,
 		}, nil
 	}
 
@@ -73,6 +111,11 @@ func pageRankView(view graphView, opts PageRankOptions) (*PageRankResult, error)
 		nodeIDs = append(nodeIDs, n.ID)
 	}
 
+	teleport, err := pageRankTeleport(nodeIDs, opts.PersonalizationVector)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize PageRank scores (uniform distribution)
 	scores := make(map[uint64]float64)
 	initialScore := 1.0 / float64(len(nodeIDs))
@@ -80,13 +123,19 @@ func pageRankView(view graphView, opts PageRankOptions) (*PageRankResult, error)
 		scores[nodeID] = initialScore
 	}
 
-	// Get outgoing edge counts for each node
+	// Get outgoing edge counts for each node. Nodes with none are dangling:
+	// the random surfer has nowhere to go, so their rank is handed out
+	// along the teleport distribution instead of being lost.
 	outDegree := make(map[uint64]int)
+	var dangling []uint64
 	for _, nodeID := range nodeIDs {
 		edges, err := view.OutgoingEdges(nodeID)
 		if err == nil {
 			outDegree[nodeID] = len(edges)
 		}
+		if outDegree[nodeID] == 0 {
+			dangling = append(dangling, nodeID)
+		}
 	}
 
 	// Iterative PageRank calculation
@@ -95,10 +144,18 @@ func pageRankView(view graphView, opts PageRankOptions) (*PageRankResult, error)
 	iterations := 0
 
 	for iterations < opts.MaxIterations {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		iterations++
 
+		danglingMass := 0.0
+		for _, nodeID := range dangling {
+			danglingMass += scores[nodeID]
+		}
+
 		for _, nodeID := range nodeIDs {
-			newScore := (1.0 - opts.DampingFactor) / float64(len(nodeIDs))
+			newScore := (1.0 - opts.DampingFactor + opts.DampingFactor*danglingMass) * teleport[nodeID]
 
 			incomingEdges, err := view.IncomingEdges(nodeID)
 			if err == nil {
@@ -129,10 +186,11 @@ func pageRankView(view graphView, opts PageRankOptions) (*PageRankResult, error)
 		scores, newScores = newScores, scores
 	}
 
-	// Normalize scores to sum to 1
+	// Mass is conserved each iteration; this only absorbs float drift.
+	// Summed in node ID order so the result is bit-for-bit reproducible.
 	sum := 0.0
-	for _, score := range scores {
-		sum += score
+	for _, nodeID := range nodeIDs {
+		sum += scores[nodeID]
 	}
 	if sum > 0 {
 		for nodeID := range scores {
@@ -140,17 +198,59 @@ func pageRankView(view graphView, opts PageRankOptions) (*PageRankResult, error)
 		}
 	}
 
-	topNodes := findTopNodesView(view, scores, 10)
+	if opts.Nodes != nil {
+		scores = ScoreResult(scores).Subset(opts.Nodes)
+	}
+	topK := opts.TopK
+	if topK == 0 || topK > len(scores) {
+		topK = len(scores)
+	}
+	topNodes := findTopNodesView(view, scores, topK)
 
 	return &PageRankResult{
 		Scores:     scores,
 		Iterations: iterations,
 		Converged:  converged,
 		TopNodes:   topNodes,
+
+		Variant:       PageRankVariantDanglingRedistributed,
+		DanglingNodes: len(dangling),
 	}, nil
 }
 
-// rankedNodeHeap implements a min-heap for RankedNode by score.
+// pageRankTeleport returns the restart probability for each node: uniform
+// when personalization is nil, otherwise the personalization weights
+// normalized over nodeIDs.
+func pageRankTeleport(nodeIDs []uint64, personalization map[uint64]float64) (map[uint64]float64, error) {
+	teleport := make(map[uint64]float64, len(nodeIDs))
+	if personalization == nil {
+		uniform := 1.0 / float64(len(nodeIDs))
+		for _, nodeID := range nodeIDs {
+			teleport[nodeID] = uniform
+		}
+		return teleport, nil
+	}
+
+	sum := 0.0
+	for _, nodeID := range nodeIDs {
+		w := personalization[nodeID]
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("personalization weight for node %d must be a non-negative number, got %v", nodeID, w)
+		}
+		teleport[nodeID] = w
+		sum += w
+	}
+	if sum == 0 {
+		return nil, fmt.Errorf("personalization vector has no positive weight on any graph node")
+	}
+	for nodeID := range teleport {
+		teleport[nodeID] /= sum
+	}
+	return teleport, nil
+}
+
+// rankedNodeHeap implements a min-heap for RankedNode by score, with
+// ties ordered so the higher node ID is "smaller" and is evicted first.
 // We use a min-heap to efficiently find top N elements:
 // - Keep at most N elements in the heap
 // - The minimum element is at the root
@@ -159,9 +259,18 @@ func pageRankView(view graphView, opts PageRankOptions) (*PageRankResult, error)
 type rankedNodeHeap []RankedNode
 
 func (h rankedNodeHeap) Len() int           { return len(h) }
-func (h rankedNodeHeap) Less(i, j int) bool { return h[i].Score < h[j].Score } // Min-heap
+func (h rankedNodeHeap) Less(i, j int) bool { return rankedNodeLess(h[i], h[j]) } // Min-heap
 func (h rankedNodeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
 
+// rankedNodeLess reports whether a ranks below b: lower score, or equal
+// score and higher node ID.
+func rankedNodeLess(a, b RankedNode) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+	return a.NodeID > b.NodeID
+}
+
 func (h *rankedNodeHeap) Push(x any) {
 	// heap.Interface.Push contract: callers always pass the heap's
 	// element type. Mirrors rankedEdgeHeap.Push in centrality.go.
@@ -184,12 +293,13 @@ func (h *rankedNodeHeap) Pop() any {
 // haven't yet been migrated to the graphView pattern (centrality,
 // triangles). New callers should construct a graphView and use
 // findTopNodesView directly.
-func findTopNodes(graph storage.Storage, scores map[uint64]float64, n int) []RankedNode {
+func findTopNodes(graph Graph, scores map[uint64]float64, n int) []RankedNode {
 	return findTopNodesView(newTenantBlindView(graph), scores, n)
 }
 
 // findTopNodesView finds the top N nodes by score using a min-heap,
-// fetching node data via the supplied graphView. The view-level
+// fetching node data via the supplied graphView. The result is sorted by
+// descending score, ties broken by ascending node ID. The view-level
 // indirection lets tenant-blind and tenant-scoped algorithm callers
 // share this helper — see pkg/algorithms/view.go.
 //
@@ -217,7 +327,7 @@ func findTopNodesView(view graphView, scores map[uint64]float64, n int) []Ranked
 
 		if h.Len() < n {
 			heap.Push(&h, rn)
-		} else if score > h[0].Score {
+		} else if rankedNodeLess(h[0], rn) {
 			heap.Pop(&h)
 			heap.Push(&h, rn)
 		}
@@ -236,12 +346,23 @@ func findTopNodesView(view graphView, scores map[uint64]float64, n int) []Ranked
 	return result
 }
 
-// GetTopNodesByPageRank returns top N nodes by PageRank score
-func (pr *PageRankResult) GetTopNodesByPageRank(n int) []RankedNode {
-	if n > len(pr.TopNodes) {
+// TopN returns the k highest-ranked nodes, already sorted (descending
+// score, ties by ascending node ID). Returns all of TopNodes when k exceeds
+// its length and nil when k <= 0.
+func (pr *PageRankResult) TopN(k int) []RankedNode {
+	if k <= 0 {
+		return nil
+	}
+	if k > len(pr.TopNodes) {
 		return pr.TopNodes
 	}
-	return pr.TopNodes[:n]
+	return pr.TopNodes[:k]
+}
+
+// GetTopNodesByPageRank returns top N nodes by PageRank score.
+// Equivalent to TopN.
+func (pr *PageRankResult) GetTopNodesByPageRank(n int) []RankedNode {
+	return pr.TopN(n)
 }
 
 // GetNodeRank returns the PageRank score for a specific node
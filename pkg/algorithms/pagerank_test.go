@@ -119,6 +119,69 @@ func TestPageRank_LinearChain(t *testing.T) {
 	}
 }
 
+// TestPageRank_DanglingNodes runs PageRank on an attack-path shaped graph
+// whose leaves are sinks and checks the result is the fixed point with
+// dangling rank redistributed uniformly, summing to 1.
+func TestPageRank_DanglingNodes(t *testing.T) {
+	gs := setupPageRankTestGraph(t)
+
+	internet, _ := gs.CreateNode([]string{"Zone"}, nil)
+	hmi, _ := gs.CreateNode([]string{"HMI"}, nil)
+	plc, _ := gs.CreateNode([]string{"PLC"}, nil)
+	breaker1, _ := gs.CreateNode([]string{"Breaker"}, nil)
+	breaker2, _ := gs.CreateNode([]string{"Breaker"}, nil)
+	sensor, _ := gs.CreateNode([]string{"Sensor"}, nil)
+
+	_, _ = gs.CreateEdge(internet.ID, hmi.ID, "NETWORK", nil, 1.0)
+	_, _ = gs.CreateEdge(hmi.ID, plc.ID, "CONTROLS", nil, 1.0)
+	_, _ = gs.CreateEdge(hmi.ID, sensor.ID, "READS", nil, 1.0)
+	_, _ = gs.CreateEdge(plc.ID, breaker1.ID, "CONTROLS", nil, 1.0)
+	_, _ = gs.CreateEdge(plc.ID, breaker2.ID, "CONTROLS", nil, 1.0)
+
+	opts := DefaultPageRankOptions()
+	opts.Tolerance = 1e-12
+	opts.MaxIterations = 1000
+	result, err := PageRank(gs, opts)
+	if err != nil {
+		t.Fatalf("PageRank failed: %v", err)
+	}
+
+	if result.Variant != PageRankVariantDanglingRedistributed {
+		t.Errorf("Variant = %q, want %q", result.Variant, PageRankVariantDanglingRedistributed)
+	}
+	if result.DanglingNodes != 3 {
+		t.Errorf("DanglingNodes = %d, want 3", result.DanglingNodes)
+	}
+
+	sum := 0.0
+	for _, score := range result.Scores {
+		sum += score
+	}
+	if math.Abs(sum-1.0) > 1e-9 {
+		t.Errorf("Expected scores to sum to 1.0, got %f", sum)
+	}
+
+	// Every score must satisfy the PageRank equation with the sinks' mass
+	// spread uniformly: r(v) = (1-d)/N + d*dangling/N + d*sum(r(u)/out(u)).
+	d := opts.DampingFactor
+	n := float64(len(result.Scores))
+	dangling := result.GetNodeRank(breaker1.ID) + result.GetNodeRank(breaker2.ID) + result.GetNodeRank(sensor.ID)
+	inflow := map[uint64]float64{
+		internet.ID: 0,
+		hmi.ID:      result.GetNodeRank(internet.ID),
+		plc.ID:      result.GetNodeRank(hmi.ID) / 2,
+		sensor.ID:   result.GetNodeRank(hmi.ID) / 2,
+		breaker1.ID: result.GetNodeRank(plc.ID) / 2,
+		breaker2.ID: result.GetNodeRank(plc.ID) / 2,
+	}
+	for id, in := range inflow {
+		want := (1-d)/n + d*dangling/n + d*in
+		if got := result.GetNodeRank(id); math.Abs(got-want) > 1e-9 {
+			t.Errorf("node %d: score %f, want %f", id, got, want)
+		}
+	}
+}
+
 // TestPageRank_Star tests PageRank on star topology (hub and spokes)
 func TestPageRank_Star(t *testing.T) {
 	gs := setupPageRankTestGraph(t)
@@ -342,6 +405,64 @@ func TestPageRank_DampingFactor(t *testing.T) {
 	}
 }
 
+// TestPageRank_PersonalizationVector checks that restarting at a seed ranks
+// nodes by proximity to it on an otherwise symmetric ring.
+func TestPageRank_PersonalizationVector(t *testing.T) {
+	gs := setupPageRankTestGraph(t)
+
+	// Bidirectional ring of 6 nodes: every node looks the same to uniform
+	// PageRank, so any ordering comes from the personalization.
+	ids := make([]uint64, 6)
+	for i := range ids {
+		n, _ := gs.CreateNode([]string{"Node"}, nil)
+		ids[i] = n.ID
+	}
+	for i := range ids {
+		next := ids[(i+1)%len(ids)]
+		_, _ = gs.CreateEdge(ids[i], next, "LINK", nil, 1.0)
+		_, _ = gs.CreateEdge(next, ids[i], "LINK", nil, 1.0)
+	}
+
+	opts := DefaultPageRankOptions()
+	opts.PersonalizationVector = map[uint64]float64{ids[0]: 5, 999999: 1} // unknown ID ignored
+	result, err := PageRank(gs, opts)
+	if err != nil {
+		t.Fatalf("PageRank failed: %v", err)
+	}
+
+	seed := result.GetNodeRank(ids[0])
+	near := result.GetNodeRank(ids[1])
+	far := result.GetNodeRank(ids[3])
+	if !(seed > near && near > far) {
+		t.Errorf("expected seed > neighbour > opposite, got %f, %f, %f", seed, near, far)
+	}
+	if math.Abs(near-result.GetNodeRank(ids[5])) > 1e-6 {
+		t.Errorf("nodes equidistant from the seed should tie: %f vs %f", near, result.GetNodeRank(ids[5]))
+	}
+	if result.TopNodes[0].NodeID != ids[0] {
+		t.Errorf("top node = %d, want seed %d", result.TopNodes[0].NodeID, ids[0])
+	}
+}
+
+// TestPageRank_PersonalizationVectorInvalid rejects vectors that give the
+// surfer nowhere to restart.
+func TestPageRank_PersonalizationVectorInvalid(t *testing.T) {
+	gs := setupPageRankTestGraph(t)
+	node, _ := gs.CreateNode([]string{"Node"}, nil)
+
+	for name, vector := range map[string]map[uint64]float64{
+		"empty":         {},
+		"unknown nodes": {node.ID + 100: 1},
+		"negative":      {node.ID: -1},
+	} {
+		opts := DefaultPageRankOptions()
+		opts.PersonalizationVector = vector
+		if _, err := PageRank(gs, opts); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
 // TestPageRank_GetTopNodesByPageRank tests getting top N nodes
 func TestPageRank_GetTopNodesByPageRank(t *testing.T) {
 	gs := setupPageRankTestGraph(t)
@@ -409,6 +530,62 @@ func TestPageRank_GetTopNodesByPageRank_ExceedsLimit(t *testing.T) {
 	}
 }
 
+// TestPageRank_TopNSortedWithTies checks TopNodes covers every node in
+// descending score order with equal scores ordered by node ID.
+func TestPageRank_TopNSortedWithTies(t *testing.T) {
+	gs := setupPageRankTestGraph(t)
+
+	// Hub with 12 identical leaves pointing at it: 12-way tie below the hub.
+	hub, _ := gs.CreateNode([]string{"Hub"}, nil)
+	for i := 0; i < 12; i++ {
+		leaf, _ := gs.CreateNode([]string{"Leaf"}, nil)
+		_, _ = gs.CreateEdge(leaf.ID, hub.ID, "LINKS", nil, 1.0)
+	}
+
+	result, err := PageRank(gs, DefaultPageRankOptions())
+	if err != nil {
+		t.Fatalf("PageRank failed: %v", err)
+	}
+
+	if len(result.TopNodes) != 13 {
+		t.Fatalf("TopNodes has %d entries, want all 13 nodes", len(result.TopNodes))
+	}
+	if result.TopNodes[0].NodeID != hub.ID {
+		t.Errorf("top node = %d, want hub %d", result.TopNodes[0].NodeID, hub.ID)
+	}
+	for i := 1; i < len(result.TopNodes); i++ {
+		prev, cur := result.TopNodes[i-1], result.TopNodes[i]
+		if cur.Score > prev.Score || (cur.Score == prev.Score && cur.NodeID < prev.NodeID) {
+			t.Errorf("TopNodes[%d] = (%d, %f) out of order after (%d, %f)",
+				i, cur.NodeID, cur.Score, prev.NodeID, prev.Score)
+		}
+	}
+
+	if top := result.TopN(3); len(top) != 3 || top[1].NodeID != result.TopNodes[1].NodeID {
+		t.Errorf("TopN(3) = %v, want first 3 of TopNodes", top)
+	}
+	if top := result.TopN(100); len(top) != 13 {
+		t.Errorf("TopN(100) returned %d nodes, want 13", len(top))
+	}
+	if top := result.TopN(0); top != nil {
+		t.Errorf("TopN(0) = %v, want nil", top)
+	}
+
+	opts := DefaultPageRankOptions()
+	opts.TopK = 3
+	bounded, err := PageRank(gs, opts)
+	if err != nil {
+		t.Fatalf("PageRank failed: %v", err)
+	}
+	if len(bounded.TopNodes) != 3 || bounded.TopNodes[2].NodeID != result.TopNodes[2].NodeID || len(bounded.Scores) != 13 {
+		t.Errorf("TopK=3: %d top nodes, %d scores; want the same first 3 and all 13 scores", len(bounded.TopNodes), len(bounded.Scores))
+	}
+	opts.TopK = -1
+	if none, err := PageRank(gs, opts); err != nil || len(none.TopNodes) != 0 || len(none.Scores) != 13 {
+		t.Errorf("TopK=-1: %v, want no top nodes and all 13 scores", err)
+	}
+}
+
 // TestDefaultPageRankOptions tests default options
 func TestDefaultPageRankOptions(t *testing.T) {
 	opts := DefaultPageRankOptions()
@@ -425,3 +602,52 @@ func TestDefaultPageRankOptions(t *testing.T) {
 		t.Errorf("Expected default tolerance 1e-6, got %e", opts.Tolerance)
 	}
 }
+
+// TestPageRank_NodesSubset checks that Nodes restricts the output without
+// changing the scores: rank still flows through the nodes left out.
+func TestPageRank_NodesSubset(t *testing.T) {
+	gs := setupPageRankTestGraph(t)
+
+	ids := make([]uint64, 5)
+	for i := range ids {
+		n, _ := gs.CreateNode([]string{"Node"}, nil)
+		ids[i] = n.ID
+	}
+	for i := range ids {
+		_, _ = gs.CreateEdge(ids[i], ids[(i+1)%len(ids)], "LINK", nil, 1.0)
+	}
+	_, _ = gs.CreateEdge(ids[0], ids[2], "LINK", nil, 1.0)
+
+	full, err := PageRank(gs, DefaultPageRankOptions())
+	if err != nil {
+		t.Fatalf("PageRank failed: %v", err)
+	}
+
+	opts := DefaultPageRankOptions()
+	opts.Nodes = []uint64{ids[2], ids[4], 9999}
+	subset, err := PageRank(gs, opts)
+	if err != nil {
+		t.Fatalf("PageRank with Nodes failed: %v", err)
+	}
+
+	if len(subset.Scores) != 2 || len(subset.TopNodes) != 2 {
+		t.Fatalf("got %d scores and %d top nodes, want 2 each (unknown IDs skipped)", len(subset.Scores), len(subset.TopNodes))
+	}
+	for _, id := range []uint64{ids[2], ids[4]} {
+		if subset.Scores[id] != full.Scores[id] {
+			t.Errorf("node %d: subset score %f, full score %f", id, subset.Scores[id], full.Scores[id])
+		}
+	}
+	if subset.TopNodes[0].NodeID != ids[2] {
+		t.Errorf("top subset node = %d, want %d (fed by two edges)", subset.TopNodes[0].NodeID, ids[2])
+	}
+
+	opts.Nodes = []uint64{}
+	none, err := PageRank(gs, opts)
+	if err != nil {
+		t.Fatalf("PageRank with empty Nodes failed: %v", err)
+	}
+	if len(none.Scores) != 0 || len(none.TopNodes) != 0 {
+		t.Errorf("empty Nodes kept %d scores and %d top nodes, want none", len(none.Scores), len(none.TopNodes))
+	}
+}
@@ -0,0 +1,129 @@
+package algorithms
+
+import "sync"
+
+// DefaultResultCacheSize is the number of results a ResultCache holds when
+// NewResultCache is given a non-positive size.
+const DefaultResultCacheSize = 256
+
+// Versioned is implemented by graphs that count their mutations, such as
+// *storage.GraphStorage. A ResultCache only caches results for graphs that
+// implement it.
+type Versioned interface {
+	Version() uint64
+}
+
+// CacheRecorder receives one call per ResultCache lookup. The metrics
+// registry implements it.
+type CacheRecorder interface {
+	RecordAlgorithmCache(algorithm string, hit bool)
+}
+
+// resultCacheKey identifies one cached result. Options must encode
+// everything else the result depends on, including the tenant for
+// tenant-scoped runs.
+type resultCacheKey struct {
+	algorithm string
+	options   string
+}
+
+type resultCacheEntry struct {
+	version uint64
+	value   any
+}
+
+// ResultCache memoizes algorithm results keyed by (algorithm, options,
+// graph version). Any mutation advances the graph's Version, so an entry
+// is only ever served for the exact graph it was computed on; stale
+// entries are replaced on the next run and dropped when the cache is full.
+//
+// Cached values are shared between callers and must be treated as
+// read-only. A nil *ResultCache is valid and caches nothing.
+type ResultCache struct {
+	mu       sync.Mutex
+	entries  map[resultCacheKey]resultCacheEntry
+	size     int
+	recorder CacheRecorder
+}
+
+// NewResultCache creates a cache holding up to size results (see
+// DefaultResultCacheSize). recorder may be nil.
+func NewResultCache(size int, recorder CacheRecorder) *ResultCache {
+	if size <= 0 {
+		size = DefaultResultCacheSize
+	}
+	return &ResultCache{
+		entries:  make(map[resultCacheKey]resultCacheEntry),
+		size:     size,
+		recorder: recorder,
+	}
+}
+
+// Do returns the cached result of algorithm for options on graph's current
+// version, or runs compute and caches what it returns. Errors are never
+// cached. Graphs that are not Versioned always run compute.
+//
+// The version is read before compute runs, so a write that lands during
+// the computation leaves the result keyed to the older version, where no
+// later lookup will find it.
+func (c *ResultCache) Do(graph Graph, algorithm, options string, compute func() (any, error)) (any, error) {
+	versioned, ok := graph.(Versioned)
+	if c == nil || !ok {
+		return compute()
+	}
+	key := resultCacheKey{algorithm: algorithm, options: options}
+	version := versioned.Version()
+
+	c.mu.Lock()
+	entry, hit := c.entries[key]
+	c.mu.Unlock()
+	hit = hit && entry.version == version
+	if c.recorder != nil {
+		c.recorder.RecordAlgorithmCache(algorithm, hit)
+	}
+	if hit {
+		return entry.value, nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.size {
+		c.evictLocked(version)
+	}
+	if current, exists := c.entries[key]; !exists || current.version <= version {
+		c.entries[key] = resultCacheEntry{version: version, value: value}
+	}
+	return value, nil
+}
+
+// Len returns the number of cached results, current or stale.
+func (c *ResultCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// evictLocked makes room for one entry: every entry older than version
+// goes, and if none was, an arbitrary one does. Caller holds c.mu.
+func (c *ResultCache) evictLocked(version uint64) {
+	for key, entry := range c.entries {
+		if entry.version < version {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) < c.size {
+		return
+	}
+	for key := range c.entries {
+		delete(c.entries, key)
+		return
+	}
+}
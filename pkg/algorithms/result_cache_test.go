@@ -0,0 +1,108 @@
+package algorithms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+type countingRecorder struct {
+	hits, misses int
+}
+
+func (r *countingRecorder) RecordAlgorithmCache(_ string, hit bool) {
+	if hit {
+		r.hits++
+	} else {
+		r.misses++
+	}
+}
+
+func TestResultCache_InvalidatedByWrites(t *testing.T) {
+	gs, err := storage.NewGraphStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gs.Close()
+	a, _ := gs.CreateNode([]string{"N"}, nil)
+	b, _ := gs.CreateNode([]string{"N"}, nil)
+	if _, err := gs.CreateEdge(a.ID, b.ID, "REL", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &countingRecorder{}
+	cache := NewResultCache(0, recorder)
+	runs := 0
+	pageRank := func() map[uint64]float64 {
+		t.Helper()
+		v, err := cache.Do(gs, "pagerank", "default", func() (any, error) {
+			runs++
+			result, err := PageRank(gs, DefaultPageRankOptions())
+			if err != nil {
+				return nil, err
+			}
+			return result.Scores, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v.(ScoreResult)
+	}
+
+	first := pageRank()
+	second := pageRank()
+	if runs != 1 || len(second) != len(first) {
+		t.Fatalf("unchanged graph: %d runs, want 1", runs)
+	}
+
+	// Another options string is its own entry.
+	if _, err := cache.Do(gs, "pagerank", "other", func() (any, error) { return 0, nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := gs.CreateNode([]string{"N"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if third := pageRank(); runs != 2 || len(third) != 3 {
+		t.Fatalf("after a write: %d runs and %d scores, want 2 and 3", runs, len(third))
+	}
+	if recorder.hits != 1 || recorder.misses != 3 {
+		t.Errorf("hits/misses = %d/%d, want 1/3", recorder.hits, recorder.misses)
+	}
+
+	// Errors are not cached.
+	boom := errors.New("boom")
+	for range 2 {
+		if _, err := cache.Do(gs, "failing", "", func() (any, error) { return nil, boom }); !errors.Is(err, boom) {
+			t.Fatalf("err = %v, want boom", err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d, want 2", cache.Len())
+	}
+}
+
+func TestResultCache_BoundedSize(t *testing.T) {
+	gs, err := storage.NewGraphStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gs.Close()
+
+	cache := NewResultCache(2, nil)
+	for _, options := range []string{"a", "b", "c"} {
+		if _, err := cache.Do(gs, "x", options, func() (any, error) { return options, nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d, want 2", cache.Len())
+	}
+
+	// A nil cache just runs compute.
+	var none *ResultCache
+	if v, err := none.Do(gs, "x", "", func() (any, error) { return 1, nil }); err != nil || v != 1 {
+		t.Errorf("nil cache: %v, %v", v, err)
+	}
+}
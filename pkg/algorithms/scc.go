@@ -2,8 +2,7 @@ package algorithms
 
 import (
 	"context"
-
-	"github.com/dd0wney/graphdb/pkg/storage"
+	"slices"
 )
 
 // SCCResult holds the result of Tarjan's strongly connected components algorithm.
@@ -34,14 +33,14 @@ type tarjanState struct {
 // in O(V+E) time, tenant-blind. Only outgoing edges are followed
 // (directed graph semantics). Multi-tenant API callers must use
 // StronglyConnectedComponentsForTenant.
-func StronglyConnectedComponents(graph storage.Storage) (*SCCResult, error) {
+func StronglyConnectedComponents(graph Graph) (*SCCResult, error) {
 	return sccView(context.Background(), newTenantBlindView(graph))
 }
 
 // StronglyConnectedComponentsForTenant computes SCCs within the
 // caller's tenant subgraph. Audit A6c-algorithms (2026-05-08). ctx
 // cancels the traversal when the request deadline fires (H-6).
-func StronglyConnectedComponentsForTenant(ctx context.Context, graph storage.Storage, tenantID string) (*SCCResult, error) {
+func StronglyConnectedComponentsForTenant(ctx context.Context, graph Graph, tenantID string) (*SCCResult, error) {
 	return sccView(ctx, newTenantScopedView(graph, tenantID))
 }
 
@@ -106,6 +105,7 @@ func sccView(ctx context.Context, view graphView) (*SCCResult, error) {
 				}
 			}
 
+			slices.Sort(members)
 			communities = append(communities, &Community{
 				ID:    sccID,
 				Nodes: members,
@@ -150,7 +150,7 @@ func sccView(ctx context.Context, view graphView) (*SCCResult, error) {
 // Condensation builds the condensation DAG from an SCC result. Each SCC becomes
 // a single node; edges between SCCs are aggregated with their count.
 // Runs in O(E) time over all original edges.
-func Condensation(graph storage.Storage, scc *SCCResult) ([]CondensationEdge, error) {
+func Condensation(graph Graph, scc *SCCResult) ([]CondensationEdge, error) {
 	// Collect all edges and group by (fromSCC, toSCC)
 	type edgeKey struct{ from, to int }
 	counts := make(map[edgeKey]int)
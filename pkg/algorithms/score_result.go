@@ -0,0 +1,67 @@
+package algorithms
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ScoreResult maps node IDs to the scores a centrality algorithm assigned
+// them. It is a plain map underneath — index it, range over it, pass it
+// where a map[uint64]float64 is expected — with the ranking helpers every
+// caller otherwise rewrites.
+type ScoreResult map[uint64]float64
+
+// NodeScore is one entry of a ScoreResult.
+type NodeScore struct {
+	NodeID uint64
+	Score  float64
+}
+
+// Get returns the score of nodeID and whether the result has one.
+func (r ScoreResult) Get(nodeID uint64) (float64, bool) {
+	score, ok := r[nodeID]
+	return score, ok
+}
+
+// Sorted returns every entry by descending score, ties by ascending node
+// ID, so equal scores always come out in the same order.
+func (r ScoreResult) Sorted() []NodeScore {
+	sorted := make([]NodeScore, 0, len(r))
+	for id, score := range r {
+		sorted = append(sorted, NodeScore{NodeID: id, Score: score})
+	}
+	slices.SortFunc(sorted, compareNodeScores)
+	return sorted
+}
+
+// Top returns the k highest-scoring entries, ordered as Sorted. It returns
+// every entry when k exceeds their number and nil when k <= 0.
+func (r ScoreResult) Top(k int) []NodeScore {
+	if k <= 0 {
+		return nil
+	}
+	sorted := r.Sorted()
+	if k < len(sorted) {
+		sorted = sorted[:k:k]
+	}
+	return sorted
+}
+
+// Subset returns the scores of just the listed nodes, skipping IDs the
+// result has no score for.
+func (r ScoreResult) Subset(nodeIDs []uint64) ScoreResult {
+	subset := make(ScoreResult, len(nodeIDs))
+	for _, id := range nodeIDs {
+		if score, ok := r[id]; ok {
+			subset[id] = score
+		}
+	}
+	return subset
+}
+
+func compareNodeScores(a, b NodeScore) int {
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.NodeID, b.NodeID)
+}
@@ -0,0 +1,69 @@
+package algorithms
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScoreResult(t *testing.T) {
+	scores := ScoreResult{5: 0.2, 3: 0.9, 8: 0.2, 1: 0.2, 7: 0.5}
+
+	want := []NodeScore{{3, 0.9}, {7, 0.5}, {1, 0.2}, {5, 0.2}, {8, 0.2}}
+	if got := scores.Sorted(); !slices.Equal(got, want) {
+		t.Errorf("Sorted() = %v, want %v (ties by ascending ID)", got, want)
+	}
+	if got := scores.Top(3); !slices.Equal(got, want[:3]) {
+		t.Errorf("Top(3) = %v, want %v", got, want[:3])
+	}
+	if got := scores.Top(10); !slices.Equal(got, want) {
+		t.Errorf("Top(10) = %v, want every entry", got)
+	}
+	if got := scores.Top(0); got != nil {
+		t.Errorf("Top(0) = %v, want nil", got)
+	}
+
+	if score, ok := scores.Get(7); !ok || score != 0.5 {
+		t.Errorf("Get(7) = %v, %v; want 0.5, true", score, ok)
+	}
+	if _, ok := scores.Get(42); ok {
+		t.Error("Get(42) reported a score for a node with none")
+	}
+}
+
+// TestScoreResult_FromCentrality checks the centrality functions hand back a
+// ScoreResult that ranks like the map it wraps.
+func TestScoreResult_FromCentrality(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	hub, _ := gs.CreateNode([]string{"N"}, nil)
+	for i := 0; i < 3; i++ {
+		leaf, _ := gs.CreateNode([]string{"N"}, nil)
+		_, _ = gs.CreateEdge(leaf.ID, hub.ID, "LINK", nil, 1.0)
+		_, _ = gs.CreateEdge(hub.ID, leaf.ID, "LINK", nil, 1.0)
+	}
+
+	for name, compute := range map[string]func(Graph) (ScoreResult, error){
+		"betweenness": BetweennessCentrality,
+		"closeness":   ClosenessCentrality,
+		"degree":      DegreeCentrality,
+	} {
+		scores, err := compute(gs)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if top := scores.Top(1); len(top) != 1 || top[0].NodeID != hub.ID {
+			t.Errorf("%s: Top(1) = %v, want the hub %d", name, top, hub.ID)
+		}
+	}
+
+	pr, err := PageRank(gs, DefaultPageRankOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, entry := range pr.Scores.Sorted() {
+		if entry.NodeID != pr.TopNodes[i].NodeID {
+			t.Fatalf("PageRank Scores.Sorted()[%d] = node %d, TopNodes has %d", i, entry.NodeID, pr.TopNodes[i].NodeID)
+		}
+	}
+}
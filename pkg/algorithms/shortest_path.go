@@ -1,10 +1,6 @@
 package algorithms
 
-import (
-	"container/list"
-
-	"github.com/dd0wney/graphdb/pkg/storage"
-)
+import "container/list"
 
 // ShortestPathForTenant finds the shortest path between two nodes
 // using bidirectional BFS, restricted to edges owned by the given
@@ -20,7 +16,7 @@ import (
 // so a tenant-stamped edge cannot point at a foreign-tenant node.
 // That means the edge-tenant filter is sufficient — no per-neighbor
 // GetNodeForTenant in the BFS hot loop.
-func ShortestPathForTenant(graph storage.Storage, startID, endID uint64, tenantID string) ([]uint64, error) {
+func ShortestPathForTenant(graph Graph, startID, endID uint64, tenantID string) ([]uint64, error) {
 	if startID == endID {
 		return []uint64{startID}, nil
 	}
@@ -56,7 +52,7 @@ func ShortestPathForTenant(graph storage.Storage, startID, endID uint64, tenantI
 // expandFrontierForTenant mirrors expandFrontier but only follows
 // edges owned by tenantID.
 func expandFrontierForTenant(
-	graph storage.Storage,
+	graph Graph,
 	queue *list.List,
 	visited map[uint64]uint64,
 	otherVisited map[uint64]uint64,
@@ -94,7 +90,7 @@ func expandFrontierForTenant(
 
 // ShortestPath finds the shortest path between two nodes using bidirectional BFS
 // This is 2x faster than unidirectional BFS for large graphs
-func ShortestPath(graph storage.Storage, startID, endID uint64) ([]uint64, error) {
+func ShortestPath(graph Graph, startID, endID uint64) ([]uint64, error) {
 	if startID == endID {
 		return []uint64{startID}, nil
 	}
@@ -135,7 +131,7 @@ func ShortestPath(graph storage.Storage, startID, endID uint64) ([]uint64, error
 
 // expandFrontier expands one level of BFS from the queue
 func expandFrontier(
-	graph storage.Storage,
+	graph Graph,
 	queue *list.List,
 	visited map[uint64]uint64,
 	otherVisited map[uint64]uint64,
@@ -211,7 +207,7 @@ func reconstructPath(
 }
 
 // AllShortestPaths finds all shortest paths from a source node using BFS
-func AllShortestPaths(graph storage.Storage, sourceID uint64) (map[uint64]int, error) {
+func AllShortestPaths(graph Graph, sourceID uint64) (map[uint64]int, error) {
 	distances := make(map[uint64]int)
 	distances[sourceID] = 0
 
@@ -243,7 +239,7 @@ func AllShortestPaths(graph storage.Storage, sourceID uint64) (map[uint64]int, e
 }
 
 // WeightedShortestPath finds shortest path with edge weights using Dijkstra's algorithm
-func WeightedShortestPath(graph storage.Storage, startID, endID uint64) ([]uint64, float64, error) {
+func WeightedShortestPath(graph Graph, startID, endID uint64) ([]uint64, float64, error) {
 	// Priority queue using simple slice (for simplicity, not optimal)
 	type pqItem struct {
 		nodeID   uint64
@@ -0,0 +1,94 @@
+package algorithms
+
+import "container/list"
+
+// PathStep is one hop of a path: the edge traversed and its endpoints.
+type PathStep struct {
+	FromID   uint64
+	ToID     uint64
+	EdgeID   uint64
+	EdgeType string
+	Weight   float64
+}
+
+// ShortestPathDetailed finds a fewest-hops path from startID to endID and
+// returns it as the sequence of edges traversed, so callers can see which
+// relationship type each hop used. It follows outgoing edges only. When
+// several edges tie for a hop, the first in adjacency order wins.
+//
+// Returns an empty slice when startID == endID and nil when no path exists,
+// matching ShortestPath. Tenant-blind; multi-tenant API callers must use
+// ShortestPathDetailedForTenant.
+func ShortestPathDetailed(graph Graph, startID, endID uint64) ([]PathStep, error) {
+	return shortestPathDetailedView(newTenantBlindView(graph), startID, endID)
+}
+
+// ShortestPathDetailedForTenant is ShortestPathDetailed restricted to edges
+// owned by tenantID. As with ShortestPathForTenant the filter is applied at
+// edge expansion, never by post-filtering a tenant-blind path.
+func ShortestPathDetailedForTenant(graph Graph, startID, endID uint64, tenantID string) ([]PathStep, error) {
+	return shortestPathDetailedView(newTenantScopedView(graph, tenantID), startID, endID)
+}
+
+func shortestPathDetailedView(view graphView, startID, endID uint64) ([]PathStep, error) {
+	if startID == endID {
+		return []PathStep{}, nil
+	}
+
+	// via records the edge that first reached each node; BFS order makes
+	// that edge part of a fewest-hops path.
+	via := make(map[uint64]PathStep)
+	visited := map[uint64]bool{startID: true}
+
+	queue := list.New()
+	queue.PushBack(startID)
+
+	for queue.Len() > 0 {
+		currentID, ok := queue.Remove(queue.Front()).(uint64)
+		if !ok {
+			continue
+		}
+
+		edges, err := view.OutgoingEdges(currentID)
+		if err != nil {
+			continue
+		}
+
+		for _, edge := range edges {
+			neighborID := edge.ToNodeID
+			if visited[neighborID] {
+				continue
+			}
+			visited[neighborID] = true
+			via[neighborID] = PathStep{
+				FromID:   currentID,
+				ToID:     neighborID,
+				EdgeID:   edge.ID,
+				EdgeType: edge.Type,
+				Weight:   edge.Weight,
+			}
+			if neighborID == endID {
+				return reconstructSteps(via, startID, endID), nil
+			}
+			queue.PushBack(neighborID)
+		}
+	}
+
+	return nil, nil // No path found
+}
+
+// reconstructSteps walks the via chain back from endID and returns the
+// steps in start-to-end order.
+func reconstructSteps(via map[uint64]PathStep, startID, endID uint64) []PathStep {
+	steps := make([]PathStep, 0)
+	for node := endID; node != startID; {
+		step := via[node]
+		steps = append(steps, step)
+		node = step.FromID
+	}
+
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
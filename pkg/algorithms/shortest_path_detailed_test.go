@@ -0,0 +1,86 @@
+package algorithms
+
+import "testing"
+
+// TestShortestPathDetailed_ReportsEdgeTypes builds a long NETWORK route and a
+// REMOTE_ACCESS shortcut; the detailed path must take the shortcut and say so.
+func TestShortestPathDetailed_ReportsEdgeTypes(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	internet, _ := gs.CreateNode([]string{"Host"}, nil)
+	firewall, _ := gs.CreateNode([]string{"Host"}, nil)
+	hmi, _ := gs.CreateNode([]string{"Host"}, nil)
+	plc, _ := gs.CreateNode([]string{"Host"}, nil)
+
+	_, _ = gs.CreateEdge(internet.ID, firewall.ID, "NETWORK", nil, 1.0)
+	_, _ = gs.CreateEdge(firewall.ID, hmi.ID, "NETWORK", nil, 1.0)
+	remote, _ := gs.CreateEdge(internet.ID, hmi.ID, "REMOTE_ACCESS", nil, 2.5)
+	control, _ := gs.CreateEdge(hmi.ID, plc.ID, "CONTROLS", nil, 1.0)
+
+	steps, err := ShortestPathDetailed(gs, internet.ID, plc.ID)
+	if err != nil {
+		t.Fatalf("ShortestPathDetailed: %v", err)
+	}
+
+	want := []PathStep{
+		{FromID: internet.ID, ToID: hmi.ID, EdgeID: remote.ID, EdgeType: "REMOTE_ACCESS", Weight: 2.5},
+		{FromID: hmi.ID, ToID: plc.ID, EdgeID: control.ID, EdgeType: "CONTROLS", Weight: 1.0},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("got %d steps %+v, want %d", len(steps), steps, len(want))
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("step %d = %+v, want %+v", i, steps[i], want[i])
+		}
+	}
+
+	// The node sequence must agree with ShortestPath's hop count.
+	path, _ := ShortestPath(gs, internet.ID, plc.ID)
+	if len(path)-1 != len(steps) {
+		t.Errorf("ShortestPath has %d hops, detailed has %d", len(path)-1, len(steps))
+	}
+}
+
+func TestShortestPathDetailed_SameNodeAndNoPath(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	a, _ := gs.CreateNode([]string{"N"}, nil)
+	b, _ := gs.CreateNode([]string{"N"}, nil)
+	_, _ = gs.CreateEdge(b.ID, a.ID, "REL", nil, 1.0)
+
+	steps, err := ShortestPathDetailed(gs, a.ID, a.ID)
+	if err != nil || steps == nil || len(steps) != 0 {
+		t.Errorf("same node: steps = %v, err = %v; want empty, nil", steps, err)
+	}
+
+	// Only b -> a exists; edges are followed in their own direction.
+	steps, err = ShortestPathDetailed(gs, a.ID, b.ID)
+	if err != nil || steps != nil {
+		t.Errorf("no path: steps = %v, err = %v; want nil, nil", steps, err)
+	}
+}
+
+func TestShortestPathDetailedForTenant_IgnoresOtherTenants(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	a1, _ := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	a2, _ := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	b1, _ := gs.CreateNodeWithTenant("tenant-B", []string{"N"}, nil)
+	b2, _ := gs.CreateNodeWithTenant("tenant-B", []string{"N"}, nil)
+	e, _ := gs.CreateEdgeWithTenant("tenant-A", a1.ID, a2.ID, "REL", nil, 1.0)
+	_, _ = gs.CreateEdgeWithTenant("tenant-B", b1.ID, b2.ID, "REL", nil, 1.0)
+
+	steps, err := ShortestPathDetailedForTenant(gs, a1.ID, a2.ID, "tenant-A")
+	if err != nil || len(steps) != 1 || steps[0].EdgeID != e.ID {
+		t.Errorf("tenant-A path = %+v, err = %v; want single step over edge %d", steps, err, e.ID)
+	}
+
+	steps, err = ShortestPathDetailedForTenant(gs, b1.ID, b2.ID, "tenant-A")
+	if err != nil || steps != nil {
+		t.Errorf("tenant-A reached tenant-B path: %+v, err = %v", steps, err)
+	}
+}
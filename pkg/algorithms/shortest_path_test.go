@@ -407,3 +407,38 @@ func TestAllShortestPaths_EmptyGraph(t *testing.T) {
 		t.Errorf("Expected distance 0 to self, got %d", distances[node.ID])
 	}
 }
+
+// TestShortestPath_UndirectedEdges checks that undirected edges are crossed
+// against their stored orientation.
+func TestShortestPath_UndirectedEdges(t *testing.T) {
+	gs := setupTestGraph(t)
+	defer func() { _ = gs.Close() }()
+
+	a, _ := gs.CreateNode([]string{"Node"}, nil)
+	b, _ := gs.CreateNode([]string{"Node"}, nil)
+	c, _ := gs.CreateNode([]string{"Node"}, nil)
+	if _, err := gs.CreateUndirectedEdge(a.ID, b.ID, "LINK", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gs.CreateUndirectedEdge(b.ID, c.ID, "LINK", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := ShortestPath(gs, c.ID, a.ID)
+	if err != nil {
+		t.Fatalf("ShortestPath failed: %v", err)
+	}
+	if len(path) != 3 || path[0] != c.ID || path[1] != b.ID || path[2] != a.ID {
+		t.Errorf("Expected path [%d, %d, %d], got %v", c.ID, b.ID, a.ID, path)
+	}
+
+	matrix, err := AllPairsShortestPaths(gs)
+	if err != nil {
+		t.Fatalf("AllPairsShortestPaths failed: %v", err)
+	}
+	for _, from := range []uint64{a.ID, b.ID, c.ID} {
+		if len(matrix[from]) != 3 {
+			t.Errorf("row %d = %v, want every node reachable", from, matrix[from])
+		}
+	}
+}
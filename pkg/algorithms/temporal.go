@@ -0,0 +1,98 @@
+package algorithms
+
+import "github.com/dd0wney/graphdb/pkg/storage"
+
+// AsOf returns graph as it stood at timestamp (Unix seconds): every node,
+// but only the edges valid then (storage.Edge.ValidAt, from the valid_from
+// / valid_to properties). Any algorithm run against it sees the time
+// slice, so one temporal graph can stand in for a graph rebuilt per phase:
+//
+//	for _, t := range phases {
+//		scores, err := PageRank(AsOf(graph, t), DefaultPageRankOptions())
+//		...
+//	}
+//
+// Nodes carry no valid time and are all present in every slice. The
+// wrapper filters on every read rather than copying the graph, so it is
+// as cheap to create as it is to throw away.
+func AsOf(graph Graph, timestamp int64) Graph {
+	return &asOfGraph{Graph: graph, timestamp: timestamp}
+}
+
+type asOfGraph struct {
+	Graph
+	timestamp int64
+}
+
+// GetStatistics reports the edges valid at the timestamp: IsTree and
+// friends compare EdgeCount with NodeCount.
+func (g *asOfGraph) GetStatistics() storage.Statistics {
+	stats := g.Graph.GetStatistics()
+	valid := make(map[uint64]struct{})
+	for _, id := range allNodeIDs(g.Graph) {
+		edges, err := g.GetOutgoingEdges(id)
+		if err != nil {
+			continue
+		}
+		// Undirected edges show up at both endpoints; count them once.
+		for _, edge := range edges {
+			valid[edge.ID] = struct{}{}
+		}
+	}
+	stats.EdgeCount = uint64(len(valid))
+	return stats
+}
+
+// GetAllNodeIDs keeps allNodeIDs on the underlying graph's fast path.
+func (g *asOfGraph) GetAllNodeIDs() []uint64 {
+	return allNodeIDs(g.Graph)
+}
+
+func (g *asOfGraph) GetEdge(edgeID uint64) (*storage.Edge, error) {
+	return g.validEdge(g.Graph.GetEdge(edgeID))
+}
+
+func (g *asOfGraph) GetEdgeForTenant(edgeID uint64, tenantID string) (*storage.Edge, error) {
+	return g.validEdge(g.Graph.GetEdgeForTenant(edgeID, tenantID))
+}
+
+func (g *asOfGraph) GetOutgoingEdges(nodeID uint64) ([]*storage.Edge, error) {
+	return g.validEdges(g.Graph.GetOutgoingEdges(nodeID))
+}
+
+func (g *asOfGraph) GetIncomingEdges(nodeID uint64) ([]*storage.Edge, error) {
+	return g.validEdges(g.Graph.GetIncomingEdges(nodeID))
+}
+
+func (g *asOfGraph) GetOutgoingEdgesForTenant(nodeID uint64, tenantID string) ([]*storage.Edge, error) {
+	return g.validEdges(g.Graph.GetOutgoingEdgesForTenant(nodeID, tenantID))
+}
+
+func (g *asOfGraph) GetIncomingEdgesForTenant(nodeID uint64, tenantID string) ([]*storage.Edge, error) {
+	return g.validEdges(g.Graph.GetIncomingEdgesForTenant(nodeID, tenantID))
+}
+
+// validEdge hides an edge that was not valid at the timestamp, as if it
+// did not exist.
+func (g *asOfGraph) validEdge(edge *storage.Edge, err error) (*storage.Edge, error) {
+	if err != nil {
+		return nil, err
+	}
+	if !edge.ValidAt(g.timestamp) {
+		return nil, storage.ErrEdgeNotFound
+	}
+	return edge, nil
+}
+
+func (g *asOfGraph) validEdges(edges []*storage.Edge, err error) ([]*storage.Edge, error) {
+	if err != nil {
+		return nil, err
+	}
+	valid := edges[:0:0]
+	for _, edge := range edges {
+		if edge.ValidAt(g.timestamp) {
+			valid = append(valid, edge)
+		}
+	}
+	return valid, nil
+}
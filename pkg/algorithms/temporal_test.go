@@ -0,0 +1,65 @@
+package algorithms
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// TestAsOf_SlicesEdgesByValidTime builds one temporal graph, a -> b -> c
+// where b -> c only exists from t=100 to t=200, and checks that algorithms
+// run against AsOf see the slice.
+func TestAsOf_SlicesEdgesByValidTime(t *testing.T) {
+	gs := setupCommunityTestGraph(t)
+	a, _ := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	b, _ := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	c, _ := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	if _, err := gs.CreateEdgeWithTenant("tenant-A", a.ID, b.ID, "LINK", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	bc, err := gs.CreateEdgeWithTenant("tenant-A", b.ID, c.ID, "LINK", map[string]storage.Value{
+		storage.PropValidFrom: storage.IntValue(100),
+		storage.PropValidTo:   storage.IntValue(200),
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		at         int64
+		components int
+		edges      uint64
+	}{
+		{50, 2, 1},  // before b -> c
+		{100, 1, 2}, // bounds are inclusive
+		{200, 1, 2},
+		{201, 2, 1}, // after
+	} {
+		graph := AsOf(gs, tc.at)
+		result, err := ConnectedComponentsForTenant(context.Background(), graph, "tenant-A")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Communities) != tc.components {
+			t.Errorf("t=%d: %d components, want %d", tc.at, len(result.Communities), tc.components)
+		}
+		if got := graph.GetStatistics().EdgeCount; got != tc.edges {
+			t.Errorf("t=%d: EdgeCount = %d, want %d", tc.at, got, tc.edges)
+		}
+		_, err = graph.GetEdgeForTenant(bc.ID, "tenant-A")
+		if valid := tc.edges == 2; valid != (err == nil) {
+			t.Errorf("t=%d: GetEdgeForTenant(b -> c) err = %v, want valid=%v", tc.at, err, valid)
+		} else if !valid && !errors.Is(err, storage.ErrEdgeNotFound) {
+			t.Errorf("t=%d: err = %v, want ErrEdgeNotFound", tc.at, err)
+		}
+	}
+
+	if tree, _ := IsTree(AsOf(gs, 150)); !tree {
+		t.Error("a -> b -> c at t=150 is not reported as a tree")
+	}
+	if tree, _ := IsTree(AsOf(gs, 50)); tree {
+		t.Error("the disconnected slice at t=50 is reported as a tree")
+	}
+}
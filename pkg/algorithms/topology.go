@@ -2,13 +2,14 @@ package algorithms
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
 // IsDAG checks if the graph is a Directed Acyclic Graph
 // Returns true if the graph contains no cycles
-func IsDAG(graph *storage.GraphStorage) (bool, error) {
+func IsDAG(graph Graph) (bool, error) {
 	hasCycle, err := HasCycle(graph)
 	if err != nil {
 		return false, err
@@ -19,7 +20,7 @@ func IsDAG(graph *storage.GraphStorage) (bool, error) {
 // TopologicalSort returns nodes in topological order using Kahn's algorithm
 // Returns error if graph contains a cycle (not a DAG)
 // The ordering ensures that for every directed edge u->v, u comes before v
-func TopologicalSort(graph *storage.GraphStorage) ([]uint64, error) {
+func TopologicalSort(graph Graph) ([]uint64, error) {
 	// First check if it's a DAG
 	isDAG, err := IsDAG(graph)
 	if err != nil {
@@ -35,12 +36,7 @@ func TopologicalSort(graph *storage.GraphStorage) ([]uint64, error) {
 		return []uint64{}, nil
 	}
 
-	nodeIDs := make([]uint64, 0, stats.NodeCount)
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		if node, err := graph.GetNode(i); err == nil && node != nil {
-			nodeIDs = append(nodeIDs, i)
-		}
-	}
+	nodeIDs := allNodeIDs(graph)
 
 	// Calculate in-degree for each node
 	inDegree := make(map[uint64]int)
@@ -97,13 +93,45 @@ func TopologicalSort(graph *storage.GraphStorage) ([]uint64, error) {
 	return sorted, nil
 }
 
+// Sources returns the nodes with no incoming edges, in ascending ID order:
+// the entry points of a directed model, such as Internet or
+// Phishing_Email in an attack graph. Self-loops don't count, an undirected
+// edge counts as incoming at both ends, and an isolated node is both a
+// source and a sink. Tenant-blind.
+func Sources(graph Graph) []uint64 {
+	return nodesWithoutEdges(graph, graph.GetIncomingEdges)
+}
+
+// Sinks returns the nodes with no outgoing edges, in ascending ID order:
+// the dead ends of a directed model, such as breakers or sensors that
+// only receive. Counted like Sources. Tenant-blind.
+func Sinks(graph Graph) []uint64 {
+	return nodesWithoutEdges(graph, graph.GetOutgoingEdges)
+}
+
+// nodesWithoutEdges lists the nodes for which edges returns nothing but
+// self-loops. A node whose edges cannot be read is left out.
+func nodesWithoutEdges(graph Graph, edges func(nodeID uint64) ([]*storage.Edge, error)) []uint64 {
+	ids := make([]uint64, 0)
+	for _, nodeID := range allNodeIDs(graph) {
+		list, err := edges(nodeID)
+		if err != nil {
+			continue
+		}
+		if !slices.ContainsFunc(list, func(e *storage.Edge) bool { return e.FromNodeID != e.ToNodeID }) {
+			ids = append(ids, nodeID)
+		}
+	}
+	return ids
+}
+
 // IsTree checks if the graph forms a valid tree structure
 // A tree must:
 // - Be connected
 // - Have exactly n-1 edges for n nodes
 // - Contain no cycles
 // - Have a single root (node with in-degree 0)
-func IsTree(graph *storage.GraphStorage) (bool, error) {
+func IsTree(graph Graph) (bool, error) {
 	stats := graph.GetStatistics()
 
 	// Empty graph is not a tree
@@ -140,12 +168,7 @@ func IsTree(graph *storage.GraphStorage) (bool, error) {
 	}
 
 	// Tree should have exactly one root (in-degree 0)
-	nodeIDs := make([]uint64, 0, stats.NodeCount)
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		if node, err := graph.GetNode(i); err == nil && node != nil {
-			nodeIDs = append(nodeIDs, i)
-		}
-	}
+	nodeIDs := allNodeIDs(graph)
 
 	rootCount := 0
 	for _, nodeID := range nodeIDs {
@@ -163,7 +186,7 @@ func IsTree(graph *storage.GraphStorage) (bool, error) {
 
 // IsConnected checks if all nodes in the graph are reachable from any starting node
 // For directed graphs, this checks weak connectivity (treating edges as undirected)
-func IsConnected(graph *storage.GraphStorage) (bool, error) {
+func IsConnected(graph Graph) (bool, error) {
 	stats := graph.GetStatistics()
 
 	// Empty graph is considered connected
@@ -177,12 +200,7 @@ func IsConnected(graph *storage.GraphStorage) (bool, error) {
 	}
 
 	// Get all nodes
-	nodeIDs := make([]uint64, 0, stats.NodeCount)
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		if node, err := graph.GetNode(i); err == nil && node != nil {
-			nodeIDs = append(nodeIDs, i)
-		}
-	}
+	nodeIDs := allNodeIDs(graph)
 
 	if len(nodeIDs) == 0 {
 		return true, nil
@@ -227,7 +245,7 @@ func IsConnected(graph *storage.GraphStorage) (bool, error) {
 // IsBipartite checks if the graph can be colored with two colors
 // such that no two adjacent nodes have the same color
 // Returns (is_bipartite, partition1, partition2, error)
-func IsBipartite(graph *storage.GraphStorage) (bool, []uint64, []uint64, error) {
+func IsBipartite(graph Graph) (bool, []uint64, []uint64, error) {
 	stats := graph.GetStatistics()
 
 	// Empty graph is bipartite
@@ -236,12 +254,7 @@ func IsBipartite(graph *storage.GraphStorage) (bool, []uint64, []uint64, error)
 	}
 
 	// Get all nodes
-	nodeIDs := make([]uint64, 0, stats.NodeCount)
-	for i := uint64(1); i <= stats.NodeCount; i++ {
-		if node, err := graph.GetNode(i); err == nil && node != nil {
-			nodeIDs = append(nodeIDs, i)
-		}
-	}
+	nodeIDs := allNodeIDs(graph)
 
 	// Color map: -1 = uncolored, 0 = color A, 1 = color B
 	color := make(map[uint64]int)
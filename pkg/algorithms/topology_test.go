@@ -1,6 +1,7 @@
 package algorithms
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -489,3 +490,36 @@ func TestIsBipartite_Triangle(t *testing.T) {
 		t.Error("Triangle (odd cycle) should not be bipartite")
 	}
 }
+
+// TestSourcesAndSinks uses a small attack graph: Internet and a phishing
+// email lead through a workstation to a PLC that drives a breaker.
+func TestSourcesAndSinks(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	node := func() uint64 {
+		n, _ := graph.CreateNode([]string{"Node"}, nil)
+		return n.ID
+	}
+	internet, phishing, workstation, plc, breaker, historian, spare := node(), node(), node(), node(), node(), node(), node()
+	_, _ = graph.CreateEdge(internet, workstation, "REACHES", nil, 1.0)
+	_, _ = graph.CreateEdge(phishing, workstation, "REACHES", nil, 1.0)
+	_, _ = graph.CreateEdge(workstation, plc, "CONTROLS", nil, 1.0)
+	_, _ = graph.CreateEdge(plc, breaker, "CONTROLS", nil, 1.0)
+	_, _ = graph.CreateEdge(breaker, breaker, "SELF", nil, 1.0) // ignored
+	// Undirected: traffic flows both ways, so neither end is a dead end.
+	_, _ = graph.CreateUndirectedEdge(plc, historian, "LOGS", nil, 1.0)
+
+	if got, want := Sources(graph), []uint64{internet, phishing, spare}; !slices.Equal(got, want) {
+		t.Errorf("Sources = %v, want %v", got, want)
+	}
+	if got, want := Sinks(graph), []uint64{breaker, spare}; !slices.Equal(got, want) {
+		t.Errorf("Sinks = %v, want %v", got, want)
+	}
+
+	empty := setupTestGraph(t)
+	defer func() { _ = empty.Close() }()
+	if got := Sources(empty); got == nil || len(got) != 0 {
+		t.Errorf("Sources(empty) = %#v, want an empty slice", got)
+	}
+}
@@ -1,10 +1,6 @@
 package algorithms
 
-import (
-	"context"
-
-	"github.com/dd0wney/graphdb/pkg/storage"
-)
+import "context"
 
 // TriangleCountResult holds triangle counting results including per-node counts,
 // global count, clustering coefficients, and top nodes by triangle participation.
@@ -18,14 +14,14 @@ type TriangleCountResult struct {
 // CountTriangles counts triangles in the graph, treating all edges
 // as undirected (tenant-blind). Multi-tenant API callers must use
 // CountTrianglesForTenant.
-func CountTriangles(graph storage.Storage) (*TriangleCountResult, error) {
+func CountTriangles(graph Graph) (*TriangleCountResult, error) {
 	return countTrianglesView(context.Background(), newTenantBlindView(graph))
 }
 
 // CountTrianglesForTenant counts triangles within the caller's
 // tenant subgraph. Audit A6c-algorithms (2026-05-08). ctx cancels the
 // O(V·d²) computation when the request deadline fires (H-6).
-func CountTrianglesForTenant(ctx context.Context, graph storage.Storage, tenantID string) (*TriangleCountResult, error) {
+func CountTrianglesForTenant(ctx context.Context, graph Graph, tenantID string) (*TriangleCountResult, error) {
 	return countTrianglesView(ctx, newTenantScopedView(graph, tenantID))
 }
 
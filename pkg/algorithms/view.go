@@ -1,9 +1,53 @@
 package algorithms
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
+// Graph is the read surface the public algorithm functions need. Both the
+// live *storage.GraphStorage (and any storage.Storage) and a frozen
+// *storage.GraphView satisfy it; run an algorithm against a GraphView from
+// GraphStorage.View when concurrent writes must not disturb the result.
+type Graph interface {
+	GetStatistics() storage.Statistics
+	GetNode(nodeID uint64) (*storage.Node, error)
+	GetEdge(edgeID uint64) (*storage.Edge, error)
+	GetOutgoingEdges(nodeID uint64) ([]*storage.Edge, error)
+	GetIncomingEdges(nodeID uint64) ([]*storage.Edge, error)
+	GetAllNodesAcrossTenants() []*storage.Node
+
+	GetNodeForTenant(nodeID uint64, tenantID string) (*storage.Node, error)
+	GetEdgeForTenant(edgeID uint64, tenantID string) (*storage.Edge, error)
+	GetOutgoingEdgesForTenant(nodeID uint64, tenantID string) ([]*storage.Edge, error)
+	GetIncomingEdgesForTenant(nodeID uint64, tenantID string) ([]*storage.Edge, error)
+	GetAllNodesForTenant(tenantID string) []*storage.Node
+}
+
+var (
+	_ Graph = (storage.Storage)(nil)
+	_ Graph = (*storage.GraphView)(nil)
+)
+
+// allNodeIDs lists every node ID in graph in ascending order, using the
+// cheap ID-only enumerator when the implementation has one. Node IDs are
+// never reused, so after deletes they have gaps: enumerate them rather
+// than counting 1..NodeCount.
+func allNodeIDs(graph Graph) []uint64 {
+	if g, ok := graph.(interface{ GetAllNodeIDs() []uint64 }); ok {
+		return g.GetAllNodeIDs()
+	}
+	nodes := graph.GetAllNodesAcrossTenants()
+	ids := make([]uint64, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.ID
+	}
+	slices.Sort(ids)
+	return ids
+}
+
 // graphView abstracts graph access so tenant-blind and tenant-scoped
 // algorithm callers share the same algorithm bodies.
 //
@@ -17,7 +61,9 @@ import (
 // Methods are added to graphView only when an algorithm needs them —
 // keep the interface minimal so adaptors stay shallow.
 type graphView interface {
-	// AllNodes returns every node visible to this view.
+	// AllNodes returns every node visible to this view in ascending ID
+	// order, so algorithms that walk it break ties the same way on
+	// every run.
 	AllNodes() []*storage.Node
 
 	// Node returns a single node by ID. Returns ErrNodeNotFound for
@@ -45,10 +91,10 @@ type graphView interface {
 // operate across all tenants — CLI, demos, single-tenant
 // deployments.
 type tenantBlindView struct {
-	g storage.Storage
+	g Graph
 }
 
-func newTenantBlindView(g storage.Storage) *tenantBlindView {
+func newTenantBlindView(g Graph) *tenantBlindView {
 	return &tenantBlindView{g: g}
 }
 
@@ -58,7 +104,7 @@ func (v *tenantBlindView) AllNodes() []*storage.Node {
 	// running through tenantBlindView are CLI / single-tenant /
 	// admin paths — not API-reachable, so the cross-tenant
 	// "everything" view is the correct semantic.
-	return v.g.GetAllNodesAcrossTenants()
+	return sortedByID(v.g.GetAllNodesAcrossTenants())
 }
 
 func (v *tenantBlindView) Node(id uint64) (*storage.Node, error) {
@@ -81,16 +127,16 @@ func (v *tenantBlindView) Edge(id uint64) (*storage.Edge, error) {
 // pinning every read to a specific tenant. Used by the new
 // XForTenant public algorithm functions.
 type tenantScopedView struct {
-	g        storage.Storage
+	g        Graph
 	tenantID string
 }
 
-func newTenantScopedView(g storage.Storage, tenantID string) *tenantScopedView {
+func newTenantScopedView(g Graph, tenantID string) *tenantScopedView {
 	return &tenantScopedView{g: g, tenantID: tenantID}
 }
 
 func (v *tenantScopedView) AllNodes() []*storage.Node {
-	return v.g.GetAllNodesForTenant(v.tenantID)
+	return sortedByID(v.g.GetAllNodesForTenant(v.tenantID))
 }
 
 func (v *tenantScopedView) Node(id uint64) (*storage.Node, error) {
@@ -108,3 +154,12 @@ func (v *tenantScopedView) IncomingEdges(id uint64) ([]*storage.Edge, error) {
 func (v *tenantScopedView) Edge(id uint64) (*storage.Edge, error) {
 	return v.g.GetEdgeForTenant(id, v.tenantID)
 }
+
+// sortedByID sorts nodes by ascending ID in place. GraphStorage already
+// returns a tenant's nodes in that order, which the sort checks in O(n).
+func sortedByID(nodes []*storage.Node) []*storage.Node {
+	slices.SortFunc(nodes, func(a, b *storage.Node) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return nodes
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// graphVersionETag is the weak ETag for a tenant's view of the graph at
+// version. It is weak because equal versions give equivalent, not
+// byte-identical, bodies (timings differ). The tenant is folded in so an
+// admin reading several tenants through X-Tenant-ID never gets a 304 for
+// another tenant's result.
+//
+// The graph version restarts at 0 whenever the store is opened, so on its
+// own "version 5" names a different graph after every restart. epoch,
+// fresh for each server, keeps a tag from before a restart (or from
+// another replica) from ever matching one issued now.
+func graphVersionETag(epoch, tenantID string, version uint64) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(tenantID))
+	return fmt.Sprintf(`W/"%s.%d-%08x"`, epoch, version, h.Sum32())
+}
+
+// newGraphEpoch returns a random tag for this server's lifetime; see
+// graphVersionETag. The clock is only a fallback if the system RNG fails.
+func newGraphEpoch() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// notModified sets the response ETag to the caller's graph version and
+// reports whether the request's If-None-Match already names it, in which
+// case it has answered 304 and the handler should return. The version is
+// read before the handler computes anything, so the tag never claims a
+// newer graph than the body reflects.
+func (s *Server) notModified(w http.ResponseWriter, r *http.Request) bool {
+	etag := graphVersionETag(s.graphEpoch, getTenantFromContext(r), s.graph.Version())
+	w.Header().Set("ETag", etag)
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header value lists etag,
+// using the weak comparison RFC 9110 §13.1.2 prescribes for it.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// deriveETagKey derives the entity ETag key from the JWT secret, so every
+// server sharing the secret (replicas, restarts) issues the same tags.
+func deriveETagKey(jwtSecret string) []byte {
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte("graphdb entity etag"))
+	return mac.Sum(nil)
+}
+
+// entityETag is the strong ETag for one revision of a node or edge (kind
+// "node" or "edge"). storage revisions are unkeyed digests of the stored,
+// unmasked properties; keying them stops a reader whose view is masked
+// from confirming guesses about a hidden value against the tag.
+func (s *Server) entityETag(kind string, id, revision uint64) string {
+	mac := hmac.New(sha256.New, s.etagKey)
+	mac.Write([]byte(kind))
+	mac.Write(binary.BigEndian.AppendUint64(nil, id))
+	mac.Write(binary.BigEndian.AppendUint64(nil, revision))
+	return `"` + hex.EncodeToString(mac.Sum(nil)[:12]) + `"`
+}
+
+func (s *Server) nodeETag(node *storage.Node) string {
+	return s.entityETag("node", node.ID, node.Revision())
+}
+
+func (s *Server) edgeETag(edge *storage.Edge) string {
+	return s.entityETag("edge", edge.ID, edge.Revision())
+}
+
+// ifMatch reports whether an If-Match header value lists etag. If-Match
+// uses strong comparison, so a weak tag never matches.
+func ifMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
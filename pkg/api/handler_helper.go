@@ -2,12 +2,14 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 	"github.com/dd0wney/graphdb/pkg/storage"
 	"github.com/dd0wney/graphdb/pkg/validation"
 )
@@ -26,6 +28,36 @@ func sanitizeError(err error, operation string) string {
 	return fmt.Sprintf("%s failed", operation)
 }
 
+// storageErrorStatus maps a storage error to the HTTP status for its class
+// (see the error taxonomy in pkg/storage/errors.go). Anything unclassified
+// is an internal failure.
+func storageErrorStatus(err error) int {
+	switch {
+	case storage.IsNotFound(err):
+		return http.StatusNotFound
+	case storage.IsInvalid(err):
+		return http.StatusBadRequest
+	case storage.IsConflict(err):
+		return http.StatusConflict
+	case errors.Is(err, storage.ErrStorageClosed):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondStorageError writes err with the status storageErrorStatus picks.
+// Client errors carry the storage message, which names only what the
+// caller sent; internal failures are logged and sanitized.
+func (s *Server) respondStorageError(w http.ResponseWriter, err error, operation string) {
+	status := storageErrorStatus(err)
+	if status == http.StatusInternalServerError || status == http.StatusServiceUnavailable {
+		s.respondError(w, status, sanitizeError(err, operation))
+		return
+	}
+	s.respondError(w, status, err.Error())
+}
+
 // clientError carries a user-safe message in Error() while preserving the
 // original error chain via Unwrap(). Use when the returned error will be
 // serialized into an HTTP response body (so callers cannot leak internals)
@@ -75,6 +107,11 @@ func (rd *requestDecoder) DecodeJSON(v any) *requestDecoder {
 		return rd
 	}
 	if err := json.NewDecoder(rd.r.Body).Decode(v); err != nil {
+		if middleware.IsBodyTooLarge(err) {
+			rd.err = errors.New("request body too large")
+			rd.statusCode = http.StatusRequestEntityTooLarge
+			return rd
+		}
 		rd.err = fmt.Errorf("invalid request body: %w", err)
 		rd.statusCode = http.StatusBadRequest
 	}
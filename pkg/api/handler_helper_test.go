@@ -303,3 +303,53 @@ func TestMethodRouter(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"missing node", fmt.Errorf("node 7: %w", storage.ErrNodeNotFound), http.StatusNotFound},
+		{"missing endpoint", &storage.EdgeEndpointError{Endpoint: "target", NodeID: 7}, http.StatusNotFound},
+		{"bad argument", fmt.Errorf("%w: direction 9", storage.ErrInvalidArgument), http.StatusBadRequest},
+		{"bad weight", storage.ErrInvalidEdgeWeight, http.StatusBadRequest},
+		{"self-loop", storage.ErrSelfLoop, http.StatusBadRequest},
+		{"unique constraint", &storage.UniqueConstraintError{Label: "Claim"}, http.StatusConflict},
+		{"duplicate edge", storage.ErrDuplicateEdge, http.StatusConflict},
+		{"closed", storage.ErrStorageClosed, http.StatusServiceUnavailable},
+		{"internal", fmt.Errorf("write snapshot: disk full"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := storageErrorStatus(tt.err); got != tt.want {
+				t.Errorf("storageErrorStatus(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondStorageError_Envelope(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	rr := httptest.NewRecorder()
+	server.respondStorageError(rr, &storage.EdgeEndpointError{Endpoint: "source", NodeID: 42}, "create edge")
+	var body ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %q: %v", rr.Body.String(), err)
+	}
+	if rr.Code != http.StatusNotFound || body.Status != http.StatusNotFound || body.Code != "NOT_FOUND" {
+		t.Errorf("got %d %+v, want a 404 envelope", rr.Code, body)
+	}
+	if body.Error != "source node 42 not found" {
+		t.Errorf("error = %q", body.Error)
+	}
+
+	// Internal failures don't leak their cause.
+	rr = httptest.NewRecorder()
+	server.respondStorageError(rr, fmt.Errorf("open /var/lib/graphdb/wal: permission denied"), "create edge")
+	if rr.Code != http.StatusInternalServerError || bytes.Contains(rr.Body.Bytes(), []byte("/var/lib")) {
+		t.Errorf("internal error: %d %s", rr.Code, rr.Body.String())
+	}
+}
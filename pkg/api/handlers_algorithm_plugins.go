@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/plugins"
+)
+
+// handleAlgorithmPlugin implements POST /algorithms/{name}: run an
+// algorithm registered with plugins.RegisterAlgorithm. The body is
+// {"parameters": {...}}, as for POST /algorithms, and may be empty. The
+// caller's tenant is passed as parameters[plugins.TenantParam]. Unlike the
+// built-in algorithms this needs a write role: a plugin is handed the whole
+// store and nothing stops it from writing. For the same reason as_of is
+// refused: a plugin reads the live store, not a time slice of it.
+func (s *Server) handleAlgorithmPlugin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/algorithms/")
+	registry := plugins.Algorithms()
+	if _, ok := registry.Get(name); !ok {
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Unknown plugin algorithm %q (registered: %s)", name, strings.Join(registry.Names(), ", ")))
+		return
+	}
+
+	var req AlgorithmRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	if req.AsOf != nil {
+		s.respondError(w, http.StatusBadRequest, "as_of is not supported for plugin algorithms")
+		return
+	}
+	if req.Parameters == nil {
+		req.Parameters = make(map[string]any)
+	}
+	req.Parameters[plugins.TenantParam] = getTenantFromContext(r)
+
+	start := time.Now()
+	result, err := registry.Run(name, s.graph, req.Parameters)
+	if err != nil {
+		if errors.Is(err, plugins.ErrAlgorithmNotFound) {
+			s.respondError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		s.respondError(w, http.StatusBadRequest, wrapForClient(err, "plugin algorithm "+name).Error())
+		return
+	}
+
+	results, ok := result.(map[string]any)
+	if !ok {
+		results = map[string]any{"result": result}
+	}
+	s.respondJSON(w, http.StatusOK, AlgorithmResponse{
+		Algorithm: name,
+		Results:   results,
+		Time:      time.Since(start).String(),
+	})
+}
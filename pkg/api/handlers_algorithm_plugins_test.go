@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/auth"
+	"github.com/dd0wney/graphdb/pkg/plugins"
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// echoAlgorithm returns its parameters, so the test can see what the
+// server passed in.
+type echoAlgorithm struct{}
+
+func (echoAlgorithm) Name() string { return "test-echo" }
+
+func (echoAlgorithm) Run(_ *storage.GraphStorage, params map[string]any) (any, error) {
+	return params, nil
+}
+
+func TestAlgorithmPlugin_RunsRegisteredAlgorithm(t *testing.T) {
+	if err := plugins.RegisterAlgorithm(echoAlgorithm{}); err != nil && !errors.Is(err, plugins.ErrAlgorithmExists) {
+		t.Fatal(err)
+	}
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	token := mintTestToken(t, server, auth.RoleEditor, "plugin-user", "")
+	viewer := mintTestToken(t, server, auth.RoleViewer, "plugin-viewer", "")
+
+	mux := http.NewServeMux()
+	server.registerRoutes(mux)
+	postAs := func(token, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	post := func(path, body string) *httptest.ResponseRecorder { return postAs(token, path, body) }
+
+	rr := post("/algorithms/test-echo", `{"parameters":{"k":3,"tenant_id":"spoofed"}}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("POST /algorithms/test-echo = %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp AlgorithmResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Algorithm != "test-echo" || resp.Results["k"] != float64(3) {
+		t.Errorf("response = %+v, want the echoed parameters", resp)
+	}
+	if resp.Results[plugins.TenantParam] == "spoofed" {
+		t.Error("client-supplied tenant_id reached the plugin")
+	}
+
+	if rr := post("/algorithms/test-echo", ""); rr.Code != http.StatusOK {
+		t.Errorf("empty body = %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+	if rr := post("/algorithms/test-echo", `{"as_of":100}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("as_of = %d, want 400 (plugins only see the live graph)", rr.Code)
+	}
+	if rr := post("/algorithms/no-such-algorithm", ""); rr.Code != http.StatusNotFound || !strings.Contains(rr.Body.String(), "test-echo") {
+		t.Errorf("unknown algorithm = %d, want 404 listing the registered ones: %s", rr.Code, rr.Body.String())
+	}
+	// A plugin gets the whole store and may write to it, so viewers can't run one.
+	if rr := postAs(viewer, "/algorithms/test-echo", ""); rr.Code != http.StatusForbidden {
+		t.Errorf("viewer = %d, want 403", rr.Code)
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/dd0wney/graphdb/pkg/algorithms"
+	"github.com/dd0wney/graphdb/pkg/licensing"
 	"github.com/dd0wney/graphdb/pkg/tenant"
 )
 
@@ -24,6 +26,38 @@ func (s *Server) respondAlgorithmError(w http.ResponseWriter, err error, fallbac
 	s.respondError(w, fallback, err.Error())
 }
 
+// cachedAlgorithm runs compute through the server's algorithm result cache.
+// The caller's tenant and as_of are part of the key, so options only needs
+// whatever else the result depends on. A cached value is shared: callers
+// build their response around it and never modify it.
+func (s *Server) cachedAlgorithm(ctx context.Context, algorithm, options string, compute func() (any, error)) (any, error) {
+	key := tenant.MustFromContext(ctx) + "|" + options
+	if asOf, ok := ctx.Value(asOfKey).(int64); ok {
+		key += "|as_of=" + strconv.FormatInt(asOf, 10)
+	}
+	return s.algorithmCache.Do(s.graph, algorithm, key, compute)
+}
+
+type asOfKeyType struct{}
+
+var asOfKey = asOfKeyType{}
+
+// withAsOf has the algorithms run under ctx evaluate the graph as it stood
+// at timestamp (Unix seconds); see algorithmGraph.
+func withAsOf(ctx context.Context, timestamp int64) context.Context {
+	return context.WithValue(ctx, asOfKey, timestamp)
+}
+
+// algorithmGraph is the graph the executors read: the live graph, or with
+// as_of set its slice at that time (only the edges valid then, per their
+// valid_from / valid_to properties).
+func (s *Server) algorithmGraph(ctx context.Context) algorithms.Graph {
+	if asOf, ok := ctx.Value(asOfKey).(int64); ok {
+		return algorithms.AsOf(s.graph, asOf)
+	}
+	return s.graph
+}
+
 func (s *Server) handleAlgorithm(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		s.respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -36,9 +70,19 @@ func (s *Server) handleAlgorithm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if feature, gated := algorithmFeature(req); gated {
+		if err := licensing.CheckFeature(s.license, feature); err != nil {
+			s.respondError(w, http.StatusForbidden, err.Error())
+			return
+		}
+	}
+
 	// Create context with timeout for all algorithms
 	ctx, cancel := context.WithTimeout(r.Context(), DefaultAlgorithmTimeout)
 	defer cancel()
+	if req.AsOf != nil {
+		ctx = withAsOf(ctx, *req.AsOf)
+	}
 
 	start := time.Now()
 	var results map[string]any
@@ -138,6 +182,18 @@ func (s *Server) handleAlgorithm(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// algorithmFeature returns the licensing feature a request needs beyond
+// Community, if any. Only the Enterprise variants are gated; the basic
+// algorithms run in every edition.
+func algorithmFeature(req AlgorithmRequest) (licensing.Feature, bool) {
+	if req.Algorithm == "pagerank" {
+		if _, ok := req.Parameters["personalization"]; ok {
+			return licensing.FeaturePersonalizedPageRank, true
+		}
+	}
+	return licensing.Feature{}, false
+}
+
 // executePageRank runs the PageRank algorithm with validated parameters
 func (s *Server) executePageRank(ctx context.Context, params map[string]any) (map[string]any, error) {
 	iterations := DefaultPageRankIterations
@@ -166,6 +222,28 @@ func (s *Server) executePageRank(ctx context.Context, params map[string]any) (ma
 		return nil, fmt.Errorf("damping_factor must be between %.1f and %.1f", MinDampingFactor, MaxDampingFactor)
 	}
 
+	// personalization: {"<node id>": weight, ...} restarts the random
+	// surfer at those nodes (Enterprise; gated by algorithmFeature).
+	var personalization map[uint64]float64
+	if v, ok := params["personalization"]; ok {
+		weights, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("personalization must be an object of node ID to weight")
+		}
+		personalization = make(map[uint64]float64, len(weights))
+		for k, w := range weights {
+			id, err := strconv.ParseUint(k, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("personalization: invalid node ID %q", k)
+			}
+			weight, ok := w.(float64)
+			if !ok {
+				return nil, fmt.Errorf("personalization: weight for node %s must be a number", k)
+			}
+			personalization[id] = weight
+		}
+	}
+
 	// Check for context cancellation before expensive operation
 	select {
 	case <-ctx.Done():
@@ -174,18 +252,35 @@ func (s *Server) executePageRank(ctx context.Context, params map[string]any) (ma
 	}
 
 	opts := algorithms.PageRankOptions{
-		MaxIterations: iterations,
-		DampingFactor: dampingFactor,
-		Tolerance:     1e-6,
+		MaxIterations:         iterations,
+		DampingFactor:         dampingFactor,
+		Tolerance:             1e-6,
+		PersonalizationVector: personalization,
+		TopK:                  -1, // the response carries scores only
 	}
 
-	// Audit A6c-algorithms: tenant-scoped PageRank.
+	// Audit A6c-algorithms: tenant-scoped PageRank. Personalized runs
+	// are not cached: the vector would have to be part of the key.
 	tenantID := tenant.MustFromContext(ctx)
-	pageRankResult, err := algorithms.PageRankForTenant(s.graph, opts, tenantID)
+	compute := func() (any, error) {
+		return algorithms.PageRankForTenant(ctx, s.algorithmGraph(ctx), opts, tenantID)
+	}
+	var result any
+	var err error
+	if personalization == nil {
+		result, err = s.cachedAlgorithm(ctx, "pagerank", fmt.Sprintf("%d|%g", iterations, dampingFactor), compute)
+	} else {
+		result, err = compute()
+	}
 	if err != nil {
 		return nil, wrapForClient(err, "PageRank computation")
 	}
-	return map[string]any{"scores": pageRankResult.Scores}, nil
+	pageRankResult := result.(*algorithms.PageRankResult)
+	return map[string]any{
+		"scores":         pageRankResult.Scores,
+		"variant":        pageRankResult.Variant,
+		"dangling_nodes": pageRankResult.DanglingNodes,
+	}, nil
 }
 
 // executeBetweenness runs the betweenness centrality algorithm
@@ -198,7 +293,9 @@ func (s *Server) executeBetweenness(ctx context.Context) (map[string]any, error)
 	}
 
 	// Audit A6c-algorithms: tenant-scoped betweenness.
-	centrality, err := algorithms.BetweennessCentralityForTenant(ctx, s.graph, tenant.MustFromContext(ctx))
+	centrality, err := s.cachedAlgorithm(ctx, "betweenness", "", func() (any, error) {
+		return algorithms.BetweennessCentralityForTenant(ctx, s.algorithmGraph(ctx), tenant.MustFromContext(ctx))
+	})
 	if err != nil {
 		return nil, wrapForClient(err, "betweenness centrality")
 	}
@@ -214,10 +311,13 @@ func (s *Server) executeEdgeBetweenness(ctx context.Context) (map[string]any, er
 	}
 
 	// Audit A6c-algorithms: tenant-scoped edge betweenness.
-	result, err := algorithms.EdgeBetweennessCentralityForTenant(ctx, s.graph, tenant.MustFromContext(ctx))
+	cached, err := s.cachedAlgorithm(ctx, "edge_betweenness", "", func() (any, error) {
+		return algorithms.EdgeBetweennessCentralityForTenant(ctx, s.algorithmGraph(ctx), tenant.MustFromContext(ctx))
+	})
 	if err != nil {
 		return nil, wrapForClient(err, "edge betweenness centrality")
 	}
+	result := cached.(*algorithms.EdgeBetweennessResult)
 
 	// Convert ByNodePair to JSON-friendly string keys
 	byNodePair := make(map[string]float64, len(result.ByNodePair))
@@ -246,6 +346,11 @@ func (s *Server) executeDetectCycles(ctx context.Context, params map[string]any)
 			opts.MaxCycleLength = int(i)
 		}
 	}
+	if v, ok := params["max_cycles"]; ok {
+		if i, ok := v.(float64); ok {
+			opts.MaxCycles = int(i)
+		}
+	}
 
 	// Validate cycle length parameters
 	if opts.MinCycleLength < 0 {
@@ -263,6 +368,14 @@ func (s *Server) executeDetectCycles(ctx context.Context, params map[string]any)
 	if opts.MinCycleLength > 0 && opts.MaxCycleLength > 0 && opts.MinCycleLength > opts.MaxCycleLength {
 		return nil, fmt.Errorf("min_length cannot be greater than max_length")
 	}
+	// Unlimited enumeration (negative MaxCycles) is not exposed over HTTP.
+	if opts.MaxCycles < 0 || opts.MaxCycles > algorithms.DefaultMaxCycles {
+		return nil, fmt.Errorf("max_cycles must be between 0 and %d", algorithms.DefaultMaxCycles)
+	}
+	limit := opts.MaxCycles
+	if limit == 0 {
+		limit = algorithms.DefaultMaxCycles
+	}
 
 	// Check for context cancellation before expensive operation
 	select {
@@ -273,13 +386,7 @@ func (s *Server) executeDetectCycles(ctx context.Context, params map[string]any)
 
 	// Audit A6c-algorithms: tenant-scoped cycle detection.
 	tenantID := tenant.MustFromContext(ctx)
-	var cycles []algorithms.Cycle
-	var err error
-	if opts.MinCycleLength > 0 || opts.MaxCycleLength > 0 {
-		cycles, err = algorithms.DetectCyclesWithOptionsForTenant(s.graph, opts, tenantID)
-	} else {
-		cycles, err = algorithms.DetectCyclesForTenant(s.graph, tenantID)
-	}
+	cycles, err := algorithms.DetectCyclesWithOptionsForTenant(ctx, s.algorithmGraph(ctx), opts, tenantID)
 	if err != nil {
 		return nil, wrapForClient(err, "cycle detection")
 	}
@@ -288,7 +395,8 @@ func (s *Server) executeDetectCycles(ctx context.Context, params map[string]any)
 	stats := algorithms.AnalyzeCycles(cycles)
 
 	return map[string]any{
-		"cycles": cycles,
+		"cycles":    cycles,
+		"truncated": len(cycles) >= limit,
 		"stats": map[string]any{
 			"total_cycles":   stats.TotalCycles,
 			"shortest_cycle": stats.ShortestCycle,
@@ -309,7 +417,7 @@ func (s *Server) executeHasCycle(ctx context.Context) (map[string]any, error) {
 	}
 
 	// Audit A6c-algorithms: tenant-scoped cycle existence check.
-	hasCycle, err := algorithms.HasCycleForTenant(s.graph, tenant.MustFromContext(ctx))
+	hasCycle, err := algorithms.HasCycleForTenant(s.algorithmGraph(ctx), tenant.MustFromContext(ctx))
 	if err != nil {
 		return nil, wrapForClient(err, "cycle check")
 	}
@@ -325,10 +433,13 @@ func (s *Server) executeTriangles(ctx context.Context) (map[string]any, error) {
 	}
 
 	// Audit A6c-algorithms: tenant-scoped triangle counting.
-	result, err := algorithms.CountTrianglesForTenant(ctx, s.graph, tenant.MustFromContext(ctx))
+	cached, err := s.cachedAlgorithm(ctx, "triangles", "", func() (any, error) {
+		return algorithms.CountTrianglesForTenant(ctx, s.algorithmGraph(ctx), tenant.MustFromContext(ctx))
+	})
 	if err != nil {
 		return nil, wrapForClient(err, "triangle counting")
 	}
+	result := cached.(*algorithms.TriangleCountResult)
 	return map[string]any{
 		"per_node":                result.PerNode,
 		"global_count":            result.GlobalCount,
@@ -345,10 +456,13 @@ func (s *Server) executeSCC(ctx context.Context) (map[string]any, error) {
 	}
 
 	// Audit A6c-algorithms: tenant-scoped SCC.
-	result, err := algorithms.StronglyConnectedComponentsForTenant(ctx, s.graph, tenant.MustFromContext(ctx))
+	cached, err := s.cachedAlgorithm(ctx, "scc", "", func() (any, error) {
+		return algorithms.StronglyConnectedComponentsForTenant(ctx, s.algorithmGraph(ctx), tenant.MustFromContext(ctx))
+	})
 	if err != nil {
 		return nil, wrapForClient(err, "SCC")
 	}
+	result := cached.(*algorithms.SCCResult)
 
 	var largestSize int
 	if result.LargestSCC != nil {
@@ -406,7 +520,7 @@ func (s *Server) executeNodeSimilarity(ctx context.Context, params map[string]an
 			return nil, fmt.Errorf("node_a and node_b must be numeric IDs")
 		}
 		// Audit A6c-algorithms: tenant-scoped pair similarity.
-		score, err := algorithms.NodeSimilarityPairForTenant(s.graph, uint64(a), uint64(b), opts, tenant.MustFromContext(ctx))
+		score, err := algorithms.NodeSimilarityPairForTenant(s.algorithmGraph(ctx), uint64(a), uint64(b), opts, tenant.MustFromContext(ctx))
 		if err != nil {
 			return nil, wrapForClient(err, "node similarity")
 		}
@@ -420,7 +534,7 @@ func (s *Server) executeNodeSimilarity(ctx context.Context, params map[string]an
 			return nil, fmt.Errorf("node_a must be a numeric ID")
 		}
 		// Audit A6c-algorithms: tenant-scoped per-source similarity.
-		result, err := algorithms.NodeSimilarityForForTenant(s.graph, uint64(a), opts, tenant.MustFromContext(ctx))
+		result, err := algorithms.NodeSimilarityForForTenant(s.algorithmGraph(ctx), uint64(a), opts, tenant.MustFromContext(ctx))
 		if err != nil {
 			return nil, wrapForClient(err, "node similarity")
 		}
@@ -431,7 +545,7 @@ func (s *Server) executeNodeSimilarity(ctx context.Context, params map[string]an
 	}
 
 	// All-pairs mode (tenant-scoped).
-	results, err := algorithms.NodeSimilarityAllForTenant(ctx, s.graph, opts, tenant.MustFromContext(ctx))
+	results, err := algorithms.NodeSimilarityAllForTenant(ctx, s.algorithmGraph(ctx), opts, tenant.MustFromContext(ctx))
 	if err != nil {
 		return nil, wrapForClient(err, "node similarity")
 	}
@@ -486,7 +600,7 @@ func (s *Server) executeLinkPrediction(ctx context.Context, params map[string]an
 			return nil, fmt.Errorf("from_node and to_node must be numeric IDs")
 		}
 		// Audit A6c-algorithms: tenant-scoped pair link prediction.
-		score, err := algorithms.PredictLinkScoreForTenant(s.graph, uint64(f), uint64(t), opts, tenant.MustFromContext(ctx))
+		score, err := algorithms.PredictLinkScoreForTenant(s.algorithmGraph(ctx), uint64(f), uint64(t), opts, tenant.MustFromContext(ctx))
 		if err != nil {
 			return nil, wrapForClient(err, "link prediction")
 		}
@@ -500,7 +614,7 @@ func (s *Server) executeLinkPrediction(ctx context.Context, params map[string]an
 			return nil, fmt.Errorf("from_node must be a numeric ID")
 		}
 		// Audit A6c-algorithms: tenant-scoped per-source link prediction.
-		result, err := algorithms.PredictLinksForForTenant(s.graph, uint64(f), opts, tenant.MustFromContext(ctx))
+		result, err := algorithms.PredictLinksForForTenant(s.algorithmGraph(ctx), uint64(f), opts, tenant.MustFromContext(ctx))
 		if err != nil {
 			return nil, wrapForClient(err, "link prediction")
 		}
@@ -550,7 +664,7 @@ func (s *Server) executeKHop(ctx context.Context, params map[string]any) (map[st
 	// registered behind requireAuth + withTenant in server.go; calling
 	// the tenant-blind variant here would leak foreign-tenant node IDs
 	// via by_hop / distances during BFS expansion.
-	result, err := algorithms.KHopNeighboursForTenant(s.graph, uint64(src), opts, tenant.MustFromContext(ctx))
+	result, err := algorithms.KHopNeighboursForTenant(s.algorithmGraph(ctx), uint64(src), opts, tenant.MustFromContext(ctx))
 	if err != nil {
 		return nil, wrapForClient(err, "k-hop neighbours")
 	}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/algorithms"
+	"github.com/dd0wney/graphdb/pkg/tenant"
+)
+
+// RankedScore is one entry of a top-N ranking returned by the GET
+// algorithm endpoints.
+type RankedScore struct {
+	NodeID uint64  `json:"node_id"`
+	Score  float64 `json:"score"`
+}
+
+// handleAlgorithmByName serves /algorithms/{name}. GET runs one of the
+// built-in read endpoints (handleAlgorithmRead); any other method is a
+// plugin run (handleAlgorithmPlugin).
+func (s *Server) handleAlgorithmByName(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		s.handleAlgorithmRead(w, r)
+		return
+	}
+	s.handleAlgorithmPlugin(w, r)
+}
+
+// handleAlgorithmRead implements the query-string flavour of the common
+// algorithms, for clients that would rather not build a POST /algorithms
+// body:
+//
+//	GET /algorithms/pagerank?iterations=20&damping=0.85&top=10
+//	GET /algorithms/betweenness?top=10
+//	GET /algorithms/centrality/closeness?top=10
+//	GET /algorithms/components?top=5
+//
+// Every result is scoped to the caller's tenant, and as_of=<unix seconds>
+// evaluates any of them on the graph as it stood then. top=N replaces the full
+// score map with the N highest-scoring nodes (for components, the N
+// largest components). Responses carry the graph version as their ETag,
+// so a client polling with If-None-Match gets a 304 until the graph
+// changes.
+func (s *Server) handleAlgorithmRead(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/algorithms/")
+	if !slices.Contains(readAlgorithms, name) {
+		s.respondError(w, http.StatusNotFound, "Unknown algorithm (GET supports: "+strings.Join(readAlgorithms, ", ")+")")
+		return
+	}
+	query := r.URL.Query()
+
+	top, err := parseTopParam(query)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var asOf *int64
+	if v := query.Get("as_of"); v != "" {
+		t, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, "as_of must be a Unix timestamp in seconds")
+			return
+		}
+		asOf = &t
+	}
+	var pageRankParams map[string]any
+	if name == "pagerank" {
+		if pageRankParams, err = pageRankQueryParams(query); err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if s.notModified(w, r) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), DefaultAlgorithmTimeout)
+	defer cancel()
+	if asOf != nil {
+		ctx = withAsOf(ctx, *asOf)
+	}
+
+	start := time.Now()
+	var results map[string]any
+
+	switch name {
+	case "pagerank":
+		results, err = s.executePageRank(ctx, pageRankParams)
+		if err != nil {
+			s.respondAlgorithmError(w, err, http.StatusBadRequest)
+			return
+		}
+		rankResults(results, "scores", top)
+
+	case "betweenness":
+		results, err = s.executeBetweenness(ctx)
+		if err != nil {
+			s.respondAlgorithmError(w, err, http.StatusInternalServerError)
+			return
+		}
+		rankResults(results, "centrality", top)
+
+	case "centrality/closeness":
+		results, err = s.executeCloseness(ctx)
+		if err != nil {
+			s.respondAlgorithmError(w, err, http.StatusInternalServerError)
+			return
+		}
+		rankResults(results, "centrality", top)
+
+	case "components":
+		results, err = s.executeComponents(ctx, top)
+		if err != nil {
+			s.respondAlgorithmError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+	}
+
+	s.respondJSON(w, http.StatusOK, AlgorithmResponse{
+		Algorithm: name,
+		Results:   results,
+		Time:      time.Since(start).String(),
+	})
+}
+
+// readAlgorithms are the names handleAlgorithmRead serves.
+var readAlgorithms = []string{"pagerank", "betweenness", "centrality/closeness", "components"}
+
+// parseTopParam reads ?top=N. Zero (or absent) means no ranking.
+func parseTopParam(query url.Values) (int, error) {
+	v := query.Get("top")
+	if v == "" {
+		return 0, nil
+	}
+	top, err := strconv.Atoi(v)
+	if err != nil || top < 0 {
+		return 0, fmt.Errorf("top must be a non-negative integer")
+	}
+	return top, nil
+}
+
+// pageRankQueryParams maps ?iterations and ?damping onto the parameter
+// map executePageRank takes. The limits are checked here as well so a bad
+// query is a 400 even when its If-None-Match would have matched.
+func pageRankQueryParams(query url.Values) (map[string]any, error) {
+	params := make(map[string]any)
+	if v := query.Get("iterations"); v != "" {
+		iterations, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("iterations must be an integer")
+		}
+		if iterations < MinPageRankIterations || iterations > MaxPageRankIterations {
+			return nil, fmt.Errorf("iterations must be between %d and %d", MinPageRankIterations, MaxPageRankIterations)
+		}
+		params["iterations"] = float64(iterations)
+	}
+	if v := query.Get("damping"); v != "" {
+		damping, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("damping must be a number")
+		}
+		if damping < MinDampingFactor || damping > MaxDampingFactor {
+			return nil, fmt.Errorf("damping must be between %.1f and %.1f", MinDampingFactor, MaxDampingFactor)
+		}
+		params["damping_factor"] = damping
+	}
+	return params, nil
+}
+
+// rankResults replaces results[key], an algorithms.ScoreResult, with the
+// top highest-scoring nodes under results["top"]. top <= 0 leaves results
+// as is.
+func rankResults(results map[string]any, key string, top int) {
+	if top <= 0 {
+		return
+	}
+	scores, ok := results[key].(algorithms.ScoreResult)
+	if !ok {
+		return
+	}
+	delete(results, key)
+	ranked := make([]RankedScore, 0, min(top, len(scores)))
+	for _, entry := range scores.Top(top) {
+		ranked = append(ranked, RankedScore{NodeID: entry.NodeID, Score: entry.Score})
+	}
+	results["top"] = ranked
+}
+
+// executeCloseness runs closeness centrality
+func (s *Server) executeCloseness(ctx context.Context) (map[string]any, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("request timed out")
+	default:
+	}
+
+	centrality, err := s.cachedAlgorithm(ctx, "closeness", "", func() (any, error) {
+		return algorithms.ClosenessCentralityForTenant(ctx, s.algorithmGraph(ctx), tenant.MustFromContext(ctx))
+	})
+	if err != nil {
+		return nil, wrapForClient(err, "closeness centrality")
+	}
+	return map[string]any{"centrality": centrality}, nil
+}
+
+// executeComponents finds weakly connected components. With top > 0 only
+// the top largest components are listed; count still reports them all.
+func (s *Server) executeComponents(ctx context.Context, top int) (map[string]any, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("request timed out")
+	default:
+	}
+
+	cached, err := s.cachedAlgorithm(ctx, "components", "", func() (any, error) {
+		return algorithms.ConnectedComponentsForTenant(ctx, s.algorithmGraph(ctx), tenant.MustFromContext(ctx))
+	})
+	if err != nil {
+		return nil, wrapForClient(err, "connected components")
+	}
+	result := cached.(*algorithms.CommunityDetectionResult)
+
+	results := map[string]any{
+		"count":      len(result.Communities),
+		"modularity": result.Modularity,
+	}
+	if top <= 0 {
+		results["communities"] = result.Communities
+		results["node_community"] = result.NodeCommunity
+		return results, nil
+	}
+
+	largest := slices.Clone(result.Communities)
+	slices.SortStableFunc(largest, func(a, b *algorithms.Community) int {
+		return cmp.Compare(b.Size, a.Size)
+	})
+	results["communities"] = largest[:min(top, len(largest))]
+	return results, nil
+}
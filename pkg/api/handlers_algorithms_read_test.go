@@ -0,0 +1,342 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/algorithms"
+	"github.com/dd0wney/graphdb/pkg/auth"
+	"github.com/dd0wney/graphdb/pkg/storage"
+	"github.com/dd0wney/graphdb/pkg/tenant"
+)
+
+// TestAlgorithmRead_TenantScoped runs each GET endpoint through the real
+// routes as a tenant-A viewer and checks that only tenant-A nodes come
+// back, and that top=N ranks the results.
+func TestAlgorithmRead_TenantScoped(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	// tenant-A: a1 -> a2 -> a3 plus an isolated a4; tenant-B: b1 -> b2.
+	a1, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	a2, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	a3, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	a4, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	b1, _ := server.graph.CreateNodeWithTenant("tenant-B", []string{"N"}, nil)
+	b2, _ := server.graph.CreateNodeWithTenant("tenant-B", []string{"N"}, nil)
+	for _, e := range [][2]uint64{{a1.ID, a2.ID}, {a2.ID, a3.ID}} {
+		if _, err := server.graph.CreateEdgeWithTenant("tenant-A", e[0], e[1], "REL", nil, 1.0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := server.graph.CreateEdgeWithTenant("tenant-B", b1.ID, b2.ID, "REL", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.tenantStore.Create(&tenant.Tenant{ID: "tenant-A", Name: "tenant-A", Status: tenant.TenantStatusActive}); err != nil {
+		t.Fatal(err)
+	}
+	tenantA := map[float64]bool{float64(a1.ID): true, float64(a2.ID): true, float64(a3.ID): true, float64(a4.ID): true}
+
+	token := mintTestToken(t, server, auth.RoleViewer, "algo-reader", "tenant-A")
+	mux := http.NewServeMux()
+	server.registerRoutes(mux)
+	get := func(path string) (int, AlgorithmResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		var resp AlgorithmResponse
+		if rr.Code == http.StatusOK {
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return rr.Code, resp
+	}
+
+	for _, path := range []string{
+		"/algorithms/pagerank?iterations=30&damping=0.9&top=2",
+		"/algorithms/betweenness?top=2",
+		"/algorithms/centrality/closeness?top=2",
+	} {
+		code, resp := get(path)
+		if code != http.StatusOK {
+			t.Fatalf("GET %s = %d", path, code)
+		}
+		ranked, ok := resp.Results["top"].([]any)
+		if !ok || len(ranked) != 2 {
+			t.Fatalf("GET %s: top = %v, want 2 entries", path, resp.Results["top"])
+		}
+		var prev float64
+		for i, entry := range ranked {
+			e := entry.(map[string]any)
+			if !tenantA[e["node_id"].(float64)] {
+				t.Errorf("GET %s: node %v is not tenant-A's", path, e["node_id"])
+			}
+			score := e["score"].(float64)
+			if i > 0 && score > prev {
+				t.Errorf("GET %s: top is not sorted by descending score: %v", path, ranked)
+			}
+			prev = score
+		}
+	}
+
+	// Without top the full score map is returned, still tenant-scoped.
+	code, resp := get("/algorithms/pagerank")
+	if code != http.StatusOK {
+		t.Fatalf("GET /algorithms/pagerank = %d", code)
+	}
+	if scores, ok := resp.Results["scores"].(map[string]any); !ok || len(scores) != 4 {
+		t.Errorf("pagerank scores = %v, want tenant-A's 4 nodes", resp.Results["scores"])
+	}
+
+	code, resp = get("/algorithms/components")
+	if code != http.StatusOK {
+		t.Fatalf("GET /algorithms/components = %d", code)
+	}
+	if resp.Results["count"] != float64(2) {
+		t.Errorf("components count = %v, want 2 ({a1,a2,a3} and {a4})", resp.Results["count"])
+	}
+	code, resp = get("/algorithms/components?top=1")
+	if code != http.StatusOK {
+		t.Fatalf("GET /algorithms/components?top=1 = %d", code)
+	}
+	if comps, ok := resp.Results["communities"].([]any); !ok || len(comps) != 1 || comps[0].(map[string]any)["Size"] != float64(3) {
+		t.Errorf("components?top=1 = %v, want the 3-node component", resp.Results["communities"])
+	}
+
+	for path, want := range map[string]int{
+		"/algorithms/pagerank?damping=1.5":    http.StatusBadRequest,
+		"/algorithms/pagerank?iterations=abc": http.StatusBadRequest,
+		"/algorithms/betweenness?top=-1":      http.StatusBadRequest,
+		"/algorithms/louvain":                 http.StatusNotFound,
+	} {
+		if code, _ := get(path); code != want {
+			t.Errorf("GET %s = %d, want %d", path, code, want)
+		}
+	}
+}
+
+type algorithmCacheCounter struct{ hits, misses int }
+
+func (c *algorithmCacheCounter) RecordAlgorithmCache(_ string, hit bool) {
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+}
+
+// TestAlgorithmRead_CachedUntilWrite checks that a repeated read is served
+// from the result cache and that a write in between forces a recompute.
+func TestAlgorithmRead_CachedUntilWrite(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	counter := &algorithmCacheCounter{}
+	server.algorithmCache = algorithms.NewResultCache(0, counter)
+
+	a1, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	a2, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	if _, err := server.graph.CreateEdgeWithTenant("tenant-A", a1.ID, a2.ID, "REL", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+
+	betweenness := func() map[string]any {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/algorithms/betweenness", nil)
+		req = req.WithContext(tenant.WithTenant(req.Context(), "tenant-A"))
+		rr := httptest.NewRecorder()
+		server.handleAlgorithmByName(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("GET /algorithms/betweenness = %d: %s", rr.Code, rr.Body.String())
+		}
+		var resp AlgorithmResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Results["centrality"].(map[string]any)
+	}
+
+	betweenness()
+	betweenness()
+	if counter.hits != 1 || counter.misses != 1 {
+		t.Fatalf("two reads: hits/misses = %d/%d, want 1/1", counter.hits, counter.misses)
+	}
+
+	if _, err := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := betweenness(); len(got) != 3 || counter.misses != 2 {
+		t.Errorf("after a write: %d scores and %d misses, want 3 and 2", len(got), counter.misses)
+	}
+
+	// Another tenant's read is never served the tenant-A entry.
+	req := httptest.NewRequest(http.MethodGet, "/algorithms/betweenness", nil)
+	req = req.WithContext(tenant.WithTenant(req.Context(), "tenant-B"))
+	rr := httptest.NewRecorder()
+	server.handleAlgorithmByName(rr, req)
+	if counter.misses != 3 {
+		t.Errorf("tenant-B read: misses = %d, want 3", counter.misses)
+	}
+}
+
+// TestAlgorithmRead_ETag checks that GET results carry the graph version
+// as a weak ETag, that If-None-Match on an unchanged graph is a 304, and
+// that a write changes the tag.
+func TestAlgorithmRead_ETag(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	a1, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	a2, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	if _, err := server.graph.CreateEdgeWithTenant("tenant-A", a1.ID, a2.ID, "REL", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(tenantID, path, ifNoneMatch string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req = req.WithContext(tenant.WithTenant(req.Context(), tenantID))
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rr := httptest.NewRecorder()
+		server.handleAlgorithmByName(rr, req)
+		return rr
+	}
+
+	rr := get("tenant-A", "/algorithms/pagerank", "")
+	etag := rr.Header().Get("ETag")
+	if rr.Code != http.StatusOK || !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("first GET = %d, ETag %q; want 200 and a weak ETag", rr.Code, etag)
+	}
+
+	rr = get("tenant-A", "/algorithms/pagerank", `"other", `+etag)
+	if rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+		t.Errorf("unchanged graph: %d with %d body bytes, want an empty 304", rr.Code, rr.Body.Len())
+	}
+	if got := get("tenant-B", "/algorithms/pagerank", etag); got.Code != http.StatusOK {
+		t.Errorf("another tenant's tag: %d, want 200", got.Code)
+	}
+	if got := get("tenant-A", "/algorithms/pagerank?damping=2", etag); got.Code != http.StatusBadRequest {
+		t.Errorf("invalid params with a matching tag: %d, want 400", got.Code)
+	}
+
+	if _, err := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	rr = get("tenant-A", "/algorithms/pagerank", etag)
+	if rr.Code != http.StatusOK || rr.Header().Get("ETag") == etag {
+		t.Errorf("after a write: %d with ETag %q, want 200 and a new tag", rr.Code, rr.Header().Get("ETag"))
+	}
+
+	// A restarted server counts versions from 0 again; its tags must not
+	// match ones handed out before the restart.
+	etag = rr.Header().Get("ETag")
+	server.graphEpoch = newGraphEpoch()
+	if rr = get("tenant-A", "/algorithms/pagerank", etag); rr.Code != http.StatusOK {
+		t.Errorf("tag from a previous server epoch: %d, want 200", rr.Code)
+	}
+}
+
+// TestAlgorithmAsOf checks that as_of slices the graph by edge valid time
+// for GET and POST algorithm runs, /traverse and /shortest-path: a -> b
+// always, b -> c only from t=100.
+func TestAlgorithmAsOf(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	a, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	b, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	c, _ := server.graph.CreateNodeWithTenant("tenant-A", []string{"N"}, nil)
+	if _, err := server.graph.CreateEdgeWithTenant("tenant-A", a.ID, b.ID, "LINK", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.graph.CreateEdgeWithTenant("tenant-A", b.ID, c.ID, "LINK", map[string]storage.Value{
+		storage.PropValidFrom: storage.IntValue(100),
+	}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	components := func(rr *httptest.ResponseRecorder) any {
+		t.Helper()
+		if rr.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", rr.Code, rr.Body.String())
+		}
+		var resp AlgorithmResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Results["count"]
+	}
+	get := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		server.handleAlgorithmByName(rr, reqWithTenant(t, http.MethodGet, path, nil, "tenant-A"))
+		return rr
+	}
+
+	// Each as_of is its own cache entry, so the answers can't bleed into
+	// one another whatever order the map runs them in.
+	for path, want := range map[string]float64{
+		"/algorithms/components":           1,
+		"/algorithms/components?as_of=50":  2,
+		"/algorithms/components?as_of=150": 1,
+	} {
+		if got := components(get(path)); got != want {
+			t.Errorf("GET %s: count = %v, want %v", path, got, want)
+		}
+	}
+	if rr := get("/algorithms/components?as_of=yesterday"); rr.Code != http.StatusBadRequest {
+		t.Errorf("as_of=yesterday: %d, want 400", rr.Code)
+	}
+
+	asOf := int64(50)
+	rr := httptest.NewRecorder()
+	server.handleAlgorithm(rr, reqWithTenant(t, http.MethodPost, "/algorithms", AlgorithmRequest{Algorithm: "triangles", AsOf: &asOf}, "tenant-A"))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("POST /algorithms with as_of: %d: %s", rr.Code, rr.Body.String())
+	}
+
+	traverse := func(asOf *int64) int {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.handleTraversal(rr, reqWithTenant(t, http.MethodPost, "/traverse", TraversalRequest{
+			StartNodeID: a.ID, MaxDepth: 5, AsOf: asOf,
+		}, "tenant-A"))
+		var resp TraversalResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Count
+	}
+	if got := traverse(&asOf); got != 2 {
+		t.Errorf("traverse as_of=50 reached %d nodes, want 2 (a, b)", got)
+	}
+	if got := traverse(nil); got != 3 {
+		t.Errorf("traverse without as_of reached %d nodes, want 3", got)
+	}
+
+	for _, weighted := range []bool{false, true} {
+		found := func(asOf *int64) bool {
+			t.Helper()
+			rr := httptest.NewRecorder()
+			server.handleShortestPath(rr, reqWithTenant(t, http.MethodPost, "/shortest-path", ShortestPathRequest{
+				StartNodeID: a.ID, EndNodeID: c.ID, Weighted: weighted, AsOf: asOf,
+			}, "tenant-A"))
+			var resp ShortestPathResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			return resp.Found
+		}
+		later := int64(150)
+		if found(&asOf) || !found(&later) || !found(nil) {
+			t.Errorf("weighted=%v: a -> c found at as_of=50/150/live = %v/%v/%v, want false/true/true",
+				weighted, found(&asOf), found(&later), found(nil))
+		}
+	}
+}
@@ -3,10 +3,14 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/dd0wney/graphdb/pkg/licensing"
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
@@ -103,6 +107,106 @@ func TestHandleTraversal(t *testing.T) {
 	}
 }
 
+// TestHandleTraversal_CollectPaths checks that collect_paths returns the
+// route to every reached node, and nothing when it isn't asked for.
+func TestHandleTraversal_CollectPaths(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	// A -> B -> C
+	a, _ := server.graph.CreateNode([]string{"Node"}, nil)
+	b, _ := server.graph.CreateNode([]string{"Node"}, nil)
+	c, _ := server.graph.CreateNode([]string{"Node"}, nil)
+	_, _ = server.graph.CreateEdge(a.ID, b.ID, "LINKED", nil, 1.0)
+	_, _ = server.graph.CreateEdge(b.ID, c.ID, "LINKED", nil, 1.0)
+
+	run := func(collect bool) TraversalResponse {
+		body, _ := json.Marshal(TraversalRequest{StartNodeID: a.ID, MaxDepth: 3, CollectPaths: collect})
+		req := httptest.NewRequest(http.MethodPost, "/traverse", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		server.handleTraversal(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("traverse = %d: %s", rr.Code, rr.Body.String())
+		}
+		var resp TraversalResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := run(true)
+	want := map[uint64][]uint64{a.ID: {a.ID}, b.ID: {a.ID, b.ID}, c.ID: {a.ID, b.ID, c.ID}}
+	if len(resp.Paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", resp.Paths, want)
+	}
+	for id, path := range want {
+		if fmt.Sprint(resp.Paths[id]) != fmt.Sprint(path) {
+			t.Errorf("path to %d = %v, want %v", id, resp.Paths[id], path)
+		}
+	}
+
+	if resp := run(false); resp.Paths != nil {
+		t.Errorf("paths without collect_paths = %v", resp.Paths)
+	}
+}
+
+// TestHandleTraversal_PropertyFilters checks that a node excluded by
+// node_exclude blocks the traversal (what lies behind it is unreachable)
+// and that edge filters apply to the edges followed.
+func TestHandleTraversal_PropertyFilters(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	// host -> firewall -> db, host -(LAN)-> printer
+	node := func(zone string) uint64 {
+		n, _ := server.graph.CreateNode([]string{"Asset"}, map[string]storage.Value{"zone": storage.StringValue(zone)})
+		return n.ID
+	}
+	host, firewall, db, printer := node("office"), node("safety"), node("plant"), node("office")
+	_, _ = server.graph.CreateEdge(host, firewall, "LINK", nil, 1.0)
+	_, _ = server.graph.CreateEdge(firewall, db, "LINK", nil, 1.0)
+	_, _ = server.graph.CreateEdge(host, printer, "LINK", map[string]storage.Value{"medium": storage.StringValue("wifi")}, 1.0)
+
+	reached := func(body string) []uint64 {
+		req := httptest.NewRequest(http.MethodPost, "/traverse", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		server.handleTraversal(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("traverse = %d: %s", rr.Code, rr.Body.String())
+		}
+		var resp TraversalResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]uint64, 0, len(resp.Nodes))
+		for _, n := range resp.Nodes {
+			ids = append(ids, n.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []uint64
+	}{
+		{"no filter", ``, []uint64{host, firewall, db, printer}},
+		{"node_exclude blocks crossing", `,"node_exclude":{"zone":"safety"}`, []uint64{host, printer}},
+		{"node_filter", `,"node_filter":{"zone":"office"}`, []uint64{host, printer}},
+		{"edge_filter", `,"edge_filter":{"medium":"wifi"}`, []uint64{host, printer}},
+		{"edge_exclude", `,"edge_exclude":{"medium":"wifi"}`, []uint64{host, firewall, db}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reached(fmt.Sprintf(`{"start_node_id":%d,"max_depth":3%s}`, host, tt.filter))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("reached %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestHandleShortestPath tests shortest path algorithm
 func TestHandleShortestPath(t *testing.T) {
 	server, cleanup := setupTestServer(t)
@@ -201,6 +305,74 @@ func TestHandleShortestPath(t *testing.T) {
 	}
 }
 
+// TestHandleShortestPath_Weighted checks weighted=true returns the
+// least-cost path and its cost, where the unweighted request returns the
+// fewest hops.
+func TestHandleShortestPath_Weighted(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	// A -> D costs 10 directly and 3 via B and C.
+	var a, b, c, d *storage.Node
+	for _, n := range []**storage.Node{&a, &b, &c, &d} {
+		node, err := server.graph.CreateNode([]string{"Station"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		*n = node
+	}
+	for _, e := range []struct {
+		from, to *storage.Node
+		weight   float64
+	}{
+		{a, d, 10}, {a, b, 1}, {b, c, 1}, {c, d, 1},
+	} {
+		if _, err := server.graph.CreateEdge(e.from.ID, e.to.ID, "ROUTE", nil, e.weight); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	shortestPath := func(weighted bool) ShortestPathResponse {
+		t.Helper()
+		body, _ := json.Marshal(ShortestPathRequest{StartNodeID: a.ID, EndNodeID: d.ID, Weighted: weighted})
+		req := httptest.NewRequest(http.MethodPost, "/shortest-path", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		server.handleShortestPath(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("weighted=%v: status %d: %s", weighted, rr.Code, rr.Body.String())
+		}
+		var resp ShortestPathResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	weighted := shortestPath(true)
+	if !weighted.Found || weighted.Cost != 3 || weighted.Length != 4 {
+		t.Errorf("weighted: found=%v cost=%g length=%d, want true 3 4", weighted.Found, weighted.Cost, weighted.Length)
+	}
+	if want := []uint64{a.ID, b.ID, c.ID, d.ID}; !slices.Equal(weighted.Path, want) {
+		t.Errorf("weighted path = %v, want %v", weighted.Path, want)
+	}
+
+	hops := shortestPath(false)
+	if !hops.Found || hops.Cost != 1 || hops.Length != 2 {
+		t.Errorf("unweighted: found=%v cost=%g length=%d, want true 1 2", hops.Found, hops.Cost, hops.Length)
+	}
+
+	// A negative weight makes the least-cost path undefined: 400.
+	if _, err := server.graph.CreateEdge(b.ID, d.ID, "ROUTE", nil, -5); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(ShortestPathRequest{StartNodeID: a.ID, EndNodeID: d.ID, Weighted: true})
+	rr := httptest.NewRecorder()
+	server.handleShortestPath(rr, httptest.NewRequest(http.MethodPost, "/shortest-path", bytes.NewReader(body)))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("negative weight: want 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
 // TestHandleAlgorithm tests general algorithm endpoint
 func TestHandleAlgorithm(t *testing.T) {
 	server, cleanup := setupTestServer(t)
@@ -256,6 +428,24 @@ func TestHandleAlgorithm(t *testing.T) {
 			expectStatus: http.StatusOK,
 			expectError:  false,
 		},
+		{
+			name: "Detect cycles with max_cycles",
+			request: AlgorithmRequest{
+				Algorithm:  "detect_cycles",
+				Parameters: map[string]any{"max_cycles": 5},
+			},
+			expectStatus: http.StatusOK,
+			expectError:  false,
+		},
+		{
+			name: "Detect cycles rejects unbounded max_cycles",
+			request: AlgorithmRequest{
+				Algorithm:  "detect_cycles",
+				Parameters: map[string]any{"max_cycles": -1},
+			},
+			expectStatus: http.StatusBadRequest,
+			expectError:  true,
+		},
 		{
 			name: "Has cycle check",
 			request: AlgorithmRequest{
@@ -302,6 +492,39 @@ func TestHandleAlgorithm(t *testing.T) {
 	}
 }
 
+// TestHandleAlgorithm_EditionGating pins that personalized PageRank needs
+// an Enterprise license while plain PageRank runs in Community edition.
+func TestHandleAlgorithm_EditionGating(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	a, _ := server.graph.CreateNode([]string{"Node"}, nil)
+	b, _ := server.graph.CreateNode([]string{"Node"}, nil)
+	_, _ = server.graph.CreateEdge(a.ID, b.ID, "LINKED", nil, 1.0)
+
+	run := func(params map[string]any) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(AlgorithmRequest{Algorithm: "pagerank", Parameters: params})
+		req := httptest.NewRequest(http.MethodPost, "/algorithms", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		server.handleAlgorithm(rr, req)
+		return rr
+	}
+	personalized := map[string]any{"personalization": map[string]any{fmt.Sprint(a.ID): 1.0}}
+
+	if rr := run(nil); rr.Code != http.StatusOK {
+		t.Errorf("Community pagerank = %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+	rr := run(personalized)
+	if rr.Code != http.StatusForbidden || !strings.Contains(rr.Body.String(), "personalized_pagerank") {
+		t.Errorf("Community personalized pagerank = %d, want 403 naming the feature: %s", rr.Code, rr.Body.String())
+	}
+
+	server.SetLicense(&licensing.License{Type: licensing.LicenseTypeEnterprise, Status: "active"})
+	if rr := run(personalized); rr.Code != http.StatusOK {
+		t.Errorf("Enterprise personalized pagerank = %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+}
+
 // TestHandleAlgorithm_LargeGraph tests algorithm performance on larger graphs
 func TestHandleAlgorithm_LargeGraph(t *testing.T) {
 	if testing.Short() {
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/dd0wney/graphdb/pkg/algorithms"
+	"github.com/dd0wney/graphdb/pkg/query"
+	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
 // errTraversalTruncated unwinds the BFS recursion when the result set
@@ -52,7 +55,8 @@ func (s *Server) handleTraversal(w http.ResponseWriter, r *http.Request) {
 	// cross-tenant or missing start-node both surface as 404 — same
 	// existence-leak guard as A6a's getNode.
 	tenantID := getTenantFromContext(r)
-	if _, err := s.graph.GetNodeForTenant(req.StartNodeID, tenantID); err != nil {
+	startNode, err := s.graph.GetNodeForTenant(req.StartNodeID, tenantID)
+	if err != nil {
 		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Start node %d not found", req.StartNodeID))
 		return
 	}
@@ -76,7 +80,19 @@ func (s *Server) handleTraversal(w http.ResponseWriter, r *http.Request) {
 			edgeTypes[t] = true
 		}
 	}
-	opts := traverseOpts{maxDepth: req.MaxDepth, direction: direction, edgeTypes: edgeTypes}
+	opts := traverseOpts{
+		maxDepth:    req.MaxDepth,
+		direction:   direction,
+		edgeTypes:   edgeTypes,
+		nodeMatch:   s.propertyPredicate(req.NodeFilter),
+		nodeExclude: s.propertyPredicate(req.NodeExclude),
+		edgeMatch:   s.propertyPredicate(req.EdgeFilter),
+		edgeExclude: s.propertyPredicate(req.EdgeExclude),
+		asOf:        req.AsOf,
+	}
+	if req.CollectPaths {
+		opts.parents = make(map[uint64]uint64)
+	}
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(r.Context(), DefaultAlgorithmTimeout)
@@ -88,7 +104,7 @@ func (s *Server) handleTraversal(w http.ResponseWriter, r *http.Request) {
 	visited := make(map[uint64]bool)
 	nodes := make([]*NodeResponse, 0)
 	truncated := false
-	if err := s.traverseFromWithContext(ctx, tenantID, req.StartNodeID, 0, opts, visited, &nodes); err != nil {
+	if err := s.traverseFromWithContext(ctx, tenantID, startNode, req.StartNodeID, 0, opts, visited, &nodes); err != nil {
 		switch {
 		case errors.Is(err, errTraversalTruncated):
 			// Hit the node cap — return what we have, flag it.
@@ -111,10 +127,28 @@ func (s *Server) handleTraversal(w http.ResponseWriter, r *http.Request) {
 		Time:      time.Since(start).String(),
 		Truncated: truncated,
 	}
+	if opts.parents != nil {
+		response.Paths = make(map[uint64][]uint64, len(nodes))
+		for _, n := range nodes {
+			response.Paths[n.ID] = traversalPath(opts.parents, req.StartNodeID, n.ID)
+		}
+	}
 
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// traversalPath walks the predecessor tree from id back to start and
+// returns the route start → id.
+func traversalPath(parents map[uint64]uint64, start, id uint64) []uint64 {
+	path := []uint64{id}
+	for id != start {
+		id = parents[id]
+		path = append(path, id)
+	}
+	slices.Reverse(path)
+	return path
+}
+
 // Traversal directions for the /traverse `direction` parameter.
 const (
 	directionOutgoing = "outgoing"
@@ -129,19 +163,62 @@ type traverseOpts struct {
 	maxDepth  int
 	direction string
 	edgeTypes map[string]bool
+	parents   map[uint64]uint64 // non-nil (collect_paths): reached node -> node it was reached from
+
+	// Property filters (node_filter, node_exclude, edge_filter,
+	// edge_exclude); nil/empty = no filter.
+	nodeMatch, nodeExclude map[string]storage.Value
+	edgeMatch, edgeExclude map[string]storage.Value
+
+	asOf *int64 // as_of: follow only edges valid at this time; nil = all
 }
 
-// allowsType reports whether an edge of the given type passes the edge-type
-// filter. An empty filter admits every type.
-func (o traverseOpts) allowsType(edgeType string) bool {
-	return len(o.edgeTypes) == 0 || o.edgeTypes[edgeType]
+// allowsEdge reports whether the traversal may follow edge: it passes the
+// edge-type filter (empty admits every type), was valid at as_of if one is
+// set, and passes the edge property filters.
+func (o traverseOpts) allowsEdge(edge *storage.Edge) bool {
+	if len(o.edgeTypes) > 0 && !o.edgeTypes[edge.Type] {
+		return false
+	}
+	if o.asOf != nil && !edge.ValidAt(*o.asOf) {
+		return false
+	}
+	return passesPropertyFilters(edge.Properties, o.edgeMatch, o.edgeExclude)
 }
 
-// traverseFromWithContext performs BFS traversal with context
-// cancellation support, scoped to the given tenant.
+// allowsNode reports whether node may be returned and traversed through.
+func (o traverseOpts) allowsNode(node *storage.Node) bool {
+	return passesPropertyFilters(node.Properties, o.nodeMatch, o.nodeExclude)
+}
+
+// passesPropertyFilters reports whether props match every entry of match
+// and don't match all of exclude (an empty exclude excludes nothing).
+func passesPropertyFilters(props, match, exclude map[string]storage.Value) bool {
+	if len(match) > 0 && !matchesPropertyFilter(props, match) {
+		return false
+	}
+	return len(exclude) == 0 || !matchesPropertyFilter(props, exclude)
+}
+
+// propertyPredicate converts a request's property filter to typed values,
+// once, for matchesPropertyFilter. Nil for an empty filter.
+func (s *Server) propertyPredicate(filter map[string]any) map[string]storage.Value {
+	if len(filter) == 0 {
+		return nil
+	}
+	predicate := make(map[string]storage.Value, len(filter))
+	for k, v := range filter {
+		predicate[k] = s.convertToValue(v)
+	}
+	return predicate
+}
+
+// traverseFromWithContext performs BFS traversal from node with context
+// cancellation support, scoped to the given tenant. Each node's neighbours
+// are hydrated with one GetNodesForTenant call rather than one lookup apiece.
 //
 // Audit A6b: dual-filter — both edges (GetOutgoingEdgesForTenant /
-// GetIncomingEdgesForTenant) and nodes (GetNodeForTenant) are scoped.
+// GetIncomingEdgesForTenant) and nodes (GetNodesForTenant) are scoped.
 //
 // As of the A6a follow-up, the node filter is technically belt-and-
 // braces against the API surface: CreateEdgeWithTenant is now
@@ -156,7 +233,7 @@ func (o traverseOpts) allowsType(edgeType string) bool {
 // in the in-memory GraphStorage instance the API serves, the node
 // filter is the only thing keeping their cross-tenant edges out of
 // /traverse results.
-func (s *Server) traverseFromWithContext(ctx context.Context, tenantID string, nodeID uint64, depth int, opts traverseOpts, visited map[uint64]bool, nodes *[]*NodeResponse) error {
+func (s *Server) traverseFromWithContext(ctx context.Context, tenantID string, node *storage.Node, from uint64, depth int, opts traverseOpts, visited map[uint64]bool, nodes *[]*NodeResponse) error {
 	// Check for cancellation
 	select {
 	case <-ctx.Done():
@@ -171,18 +248,24 @@ func (s *Server) traverseFromWithContext(ctx context.Context, tenantID string, n
 		return errTraversalTruncated
 	}
 
+	nodeID := node.ID
 	if depth > opts.maxDepth || visited[nodeID] {
 		return nil
 	}
 
 	visited[nodeID] = true
 
-	node, err := s.graph.GetNodeForTenant(nodeID, tenantID)
-	if err != nil {
-		return nil // Skip missing or cross-tenant nodes (no leak).
+	if !opts.allowsNode(node) {
+		return nil // Filtered out: neither returned nor crossed.
 	}
 
 	*nodes = append(*nodes, s.nodeToResponse(ctx, node))
+	if opts.parents != nil && depth > 0 {
+		opts.parents[nodeID] = from
+	}
+	if depth == opts.maxDepth {
+		return nil
+	}
 
 	// Collect neighbours per the requested direction, filtered by edge type.
 	// outgoing → edge.ToNodeID; incoming → edge.FromNodeID; both → union.
@@ -191,7 +274,7 @@ func (s *Server) traverseFromWithContext(ctx context.Context, tenantID string, n
 		edges, err := s.graph.GetOutgoingEdgesForTenant(nodeID, tenantID)
 		if err == nil {
 			for _, edge := range edges {
-				if opts.allowsType(edge.Type) {
+				if opts.allowsEdge(edge) && !visited[edge.ToNodeID] {
 					neighbors = append(neighbors, edge.ToNodeID)
 				}
 			}
@@ -201,15 +284,27 @@ func (s *Server) traverseFromWithContext(ctx context.Context, tenantID string, n
 		edges, err := s.graph.GetIncomingEdgesForTenant(nodeID, tenantID)
 		if err == nil {
 			for _, edge := range edges {
-				if opts.allowsType(edge.Type) {
+				if opts.allowsEdge(edge) && !visited[edge.FromNodeID] {
 					neighbors = append(neighbors, edge.FromNodeID)
 				}
 			}
 		}
 	}
+	if len(neighbors) == 0 {
+		return nil
+	}
 
-	for _, nb := range neighbors {
-		if err := s.traverseFromWithContext(ctx, tenantID, nb, depth+1, opts, visited, nodes); err != nil {
+	// Missing or cross-tenant neighbours come back nil and are skipped
+	// (no leak).
+	hydrated, err := s.graph.GetNodesForTenant(neighbors, tenantID)
+	if err != nil {
+		return err
+	}
+	for _, nb := range hydrated {
+		if nb == nil {
+			continue
+		}
+		if err := s.traverseFromWithContext(ctx, tenantID, nb, nodeID, depth+1, opts, visited, nodes); err != nil {
 			return err
 		}
 	}
@@ -272,7 +367,14 @@ func (s *Server) handleShortestPath(w http.ResponseWriter, r *http.Request) {
 	// otherwise pick a shorter cross-tenant route and rejecting it
 	// after the fact would deny a path that *does* exist within the
 	// caller's subgraph).
-	path, err := algorithms.ShortestPathForTenant(s.graph, req.StartNodeID, req.EndNodeID, tenantID)
+	if req.Weighted {
+		s.respondWeightedShortestPath(w, req, tenantID, start)
+		return
+	}
+	if req.AsOf != nil {
+		ctx = withAsOf(ctx, *req.AsOf)
+	}
+	path, err := algorithms.ShortestPathForTenant(s.algorithmGraph(ctx), req.StartNodeID, req.EndNodeID, tenantID)
 	if err != nil {
 		// Log the error but still return a valid response indicating no path found
 		log.Printf("ShortestPath algorithm error: %v", err)
@@ -284,6 +386,46 @@ func (s *Server) handleShortestPath(w http.ResponseWriter, r *http.Request) {
 		Found:  err == nil && len(path) > 0,
 		Time:   time.Since(start).String(),
 	}
+	if response.Found {
+		response.Cost = float64(len(path) - 1)
+	}
 
 	s.respondJSON(w, http.StatusOK, response)
 }
+
+// respondWeightedShortestPath answers a weighted /shortest-path request
+// with the least-cost path (Dijkstra over edge weights), tenant-scoped and
+// as_of-sliced at edge expansion like the BFS. A negative edge weight on
+// the way is a 400: the least-cost path isn't well defined, so no answer is
+// better than a wrong one.
+func (s *Server) respondWeightedShortestPath(w http.ResponseWriter, req ShortestPathRequest, tenantID string, start time.Time) {
+	var validAt func(*storage.Edge) bool
+	if req.AsOf != nil {
+		asOf := *req.AsOf
+		validAt = func(edge *storage.Edge) bool { return edge.ValidAt(asOf) }
+	}
+	traverser := query.NewTraverser(s.graph)
+	path, cost, err := traverser.FindWeightedShortestPathForTenant(req.StartNodeID, req.EndNodeID, nil, tenantID, validAt)
+	if errors.Is(err, query.ErrNegativeEdgeWeight) {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("Weighted ShortestPath error: %v", err)
+	}
+
+	ids := make([]uint64, len(path.Nodes))
+	for i, node := range path.Nodes {
+		ids[i] = node.ID
+	}
+	response := ShortestPathResponse{
+		Path:   ids,
+		Length: len(ids),
+		Found:  err == nil && len(ids) > 0,
+		Time:   time.Since(start).String(),
+	}
+	if response.Found {
+		response.Cost = cost
+	}
+	s.respondJSON(w, http.StatusOK, response)
+}
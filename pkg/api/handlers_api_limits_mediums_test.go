@@ -79,6 +79,7 @@ func TestBodyLimitMiddleware_AuthPathTightCap(t *testing.T) {
 		{"auth within cap", "/auth/login", 256, http.StatusOK},
 		{"general within general cap", "/nodes", maxAuthBodyBytes + 1, http.StatusOK},
 		{"general oversized", "/nodes", maxGeneralBodyBytes + 1, http.StatusRequestEntityTooLarge},
+		{"import within import cap", "/nodes/batch", maxGeneralBodyBytes + 1, http.StatusOK},
 	}
 
 	for _, tc := range cases {
@@ -93,6 +94,63 @@ func TestBodyLimitMiddleware_AuthPathTightCap(t *testing.T) {
 	}
 }
 
+// TestBodyLimitMiddleware_DecodeOverflowIs413 checks that a body which
+// only trips the cap while a handler decodes it (no Content-Length, so the
+// up-front check can't see it) comes back as a 413 envelope, not the
+// handler's generic "invalid request body" 400.
+func TestBodyLimitMiddleware_DecodeOverflowIs413(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	server.SetBodyLimitConfig(&BodyLimitConfig{Default: 64})
+
+	wrapped := server.bodyLimitMiddleware(http.HandlerFunc(server.handleNodes))
+	body := `{"labels":["Doc"],"properties":{"text":"` + strings.Repeat("x", 256) + `"}}`
+	r := httptest.NewRequest(http.MethodPost, "/nodes", strings.NewReader(body))
+	r.ContentLength = -1
+	rr := httptest.NewRecorder()
+	wrapped.ServeHTTP(rr, r)
+
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("want 413, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("body is not a JSON envelope: %v (%q)", err, rr.Body.String())
+	}
+	if resp.Code != "PAYLOAD_TOO_LARGE" {
+		t.Errorf("code = %q, want PAYLOAD_TOO_LARGE", resp.Code)
+	}
+}
+
+// TestInitBodyLimitsFromEnv checks the default and import caps can be
+// overridden while the auth cap stays put.
+func TestInitBodyLimitsFromEnv(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	t.Setenv("MAX_REQUEST_BODY_BYTES", "2048")
+	t.Setenv("MAX_IMPORT_BODY_BYTES", "4096")
+	server.InitBodyLimitsFromEnv()
+
+	cfg := server.bodyLimitConfig()
+	for path, want := range map[string]int64{
+		"/nodes":       2048,
+		"/nodes/batch": 4096,
+		"/import":      4096,
+		"/auth/login":  maxAuthBodyBytes,
+	} {
+		if got := cfg.Limit(path); got != want {
+			t.Errorf("Limit(%q) = %d, want %d", path, got, want)
+		}
+	}
+
+	t.Setenv("MAX_REQUEST_BODY_BYTES", "lots")
+	server.InitBodyLimitsFromEnv()
+	if got := server.bodyLimitConfig().Limit("/nodes"); got != maxGeneralBodyBytes {
+		t.Errorf("invalid value: Limit(/nodes) = %d, want default %d", got, maxGeneralBodyBytes)
+	}
+}
+
 // TestSecurityAuditLogs_LimitCapped pins security audit finding M-16: the
 // caller-supplied ?limit was accepted unbounded, so one request could
 // force serialization of the entire ring buffer. It is now capped at
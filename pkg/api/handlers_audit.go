@@ -0,0 +1,183 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
+	"github.com/dd0wney/graphdb/pkg/audit"
+	"github.com/dd0wney/graphdb/pkg/auth"
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// mutationAuditBufferSize is how many mutation events GET /audit can page
+// through. Older events live only in the persistent sink.
+const mutationAuditBufferSize = 10000
+
+// auditMutation records one change to the graph in the mutation audit
+// trail: who (the authenticated principal), which tenant, what operation
+// on which entity, and a summary of the entity before and after. It is a
+// separate sink from the per-request log written by auditMiddleware, so
+// read traffic can't evict mutations from GET /audit.
+//
+// Summaries carry labels, types, endpoints and property KEYS, never
+// property values: the trail must not become a second copy of the data
+// (including sensitive properties, which are encrypted at rest).
+func (s *Server) auditMutation(r *http.Request, action audit.Action, resourceType audit.ResourceType, resourceID string, before, after map[string]any) {
+	event := &audit.Event{
+		TenantID:     getTenantFromContext(r),
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Status:       audit.StatusSuccess,
+		IPAddress:    getIPAddress(r),
+		RequestID:    middleware.GetRequestID(r),
+		Before:       before,
+		After:        after,
+	}
+	if claims, ok := r.Context().Value(claimsContextKey).(*auth.Claims); ok {
+		event.UserID = claims.UserID
+		event.Username = claims.Username
+	}
+
+	if err := s.mutationAuditLogger.Log(event); err != nil {
+		log.Printf("Failed to write mutation audit log: %v", err)
+	}
+	if s.persistentMutationAudit != nil {
+		if err := s.persistentMutationAudit.Log(event); err != nil {
+			log.Printf("Failed to write persistent mutation audit log: %v", err)
+		}
+	}
+}
+
+func (s *Server) auditNodeMutation(r *http.Request, action audit.Action, nodeID uint64, before, after *storage.Node) {
+	s.auditMutation(r, action, audit.ResourceNode, strconv.FormatUint(nodeID, 10), nodeAuditSummary(before), nodeAuditSummary(after))
+}
+
+func (s *Server) auditEdgeMutation(r *http.Request, action audit.Action, edgeID uint64, before, after *storage.Edge) {
+	s.auditMutation(r, action, audit.ResourceEdge, strconv.FormatUint(edgeID, 10), edgeAuditSummary(before), edgeAuditSummary(after))
+}
+
+// auditStatement records a mutating Cypher query or GraphQL mutation. The
+// entities it touched aren't known at this layer, and the statement text
+// carries literal values, so the summary is the language and the clauses
+// (Cypher keywords or GraphQL mutation fields) only.
+func (s *Server) auditStatement(r *http.Request, language string, clauses []string) {
+	s.auditMutation(r, audit.ActionQuery, audit.ResourceQuery, "", nil, map[string]any{
+		"language": language,
+		"clauses":  clauses,
+	})
+}
+
+func nodeAuditSummary(node *storage.Node) map[string]any {
+	if node == nil {
+		return nil
+	}
+	return map[string]any{
+		"labels":     node.Labels,
+		"properties": propertyKeys(node.Properties),
+	}
+}
+
+func edgeAuditSummary(edge *storage.Edge) map[string]any {
+	if edge == nil {
+		return nil
+	}
+	return map[string]any{
+		"type":       edge.Type,
+		"from":       edge.FromNodeID,
+		"to":         edge.ToNodeID,
+		"weight":     edge.Weight,
+		"properties": propertyKeys(edge.Properties),
+	}
+}
+
+func propertyKeys(props map[string]storage.Value) []string {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// handleAuditLog implements GET /audit (admin only): the mutation audit
+// trail, newest first, filtered by tenant, user_id, action, resource_type,
+// resource_id, start_time and end_time, and paginated with limit/offset.
+func (s *Server) handleAuditLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := &audit.Filter{
+		TenantID:     q.Get("tenant"),
+		UserID:       q.Get("user_id"),
+		Action:       audit.Action(q.Get("action")),
+		ResourceType: audit.ResourceType(q.Get("resource_type")),
+		ResourceID:   q.Get("resource_id"),
+		RequestID:    q.Get("request_id"),
+	}
+	if v := q.Get("start_time"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, "start_time must be RFC 3339")
+			return
+		}
+		filter.StartTime = &t
+	}
+	if v := q.Get("end_time"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, "end_time must be RFC 3339")
+			return
+		}
+		filter.EndTime = &t
+	}
+
+	limit := 100
+	if v := q.Get("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil && l > 0 {
+			limit = l
+		}
+	}
+	if limit > maxAuditLogLimit {
+		limit = maxAuditLogLimit
+	}
+	offset := 0
+	if v := q.Get("offset"); v != "" {
+		if o, err := strconv.Atoi(v); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	// GetEvents is oldest first; the trail reads newest first.
+	events := s.mutationAuditLogger.GetEvents(filter)
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+
+	total := len(events)
+	start := offset
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	page := events[start:end]
+
+	s.respondJSON(w, http.StatusOK, map[string]any{
+		"events":   page,
+		"count":    len(page),
+		"total":    total,
+		"offset":   offset,
+		"limit":    limit,
+		"has_more": end < total,
+	})
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
+	"github.com/dd0wney/graphdb/pkg/audit"
+	"github.com/dd0wney/graphdb/pkg/auth"
+)
+
+// TestAuditLog_RecordsMutationsWithPrincipal pins the mutation trail: each
+// REST write and each mutating query lands in GET /audit with the caller's
+// identity and a before/after summary, newest first, and the endpoint is
+// admin-only.
+func TestAuditLog_RecordsMutationsWithPrincipal(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	admin := mintTestToken(t, server, auth.RoleAdmin, "audit-admin", "")
+	editor := mintTestToken(t, server, auth.RoleEditor, "audit-editor", "")
+
+	mux := http.NewServeMux()
+	server.registerRoutes(mux)
+	do := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := do(editor, http.MethodPost, "/nodes", `{"labels":["Person"],"properties":{"name":"a","ssn":"123"}}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("create node = %d: %s", rr.Code, rr.Body.String())
+	}
+	var created NodeResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	nodePath := fmt.Sprintf("/nodes/%d", created.ID)
+	if rr := do(editor, http.MethodPut, nodePath, `{"properties":{"age":30}}`); rr.Code != http.StatusOK {
+		t.Fatalf("update node = %d", rr.Code)
+	}
+	if rr := do(editor, http.MethodPost, "/query", `{"query":"CREATE (n:Person {name: 'secret-literal'})"}`); rr.Code != http.StatusOK {
+		t.Fatalf("CREATE query = %d", rr.Code)
+	}
+	if rr := do(editor, http.MethodGet, "/nodes", ""); rr.Code != http.StatusOK {
+		t.Fatalf("list nodes = %d", rr.Code)
+	}
+	if rr := do(editor, http.MethodDelete, nodePath, ""); rr.Code != http.StatusOK {
+		t.Fatalf("delete node = %d", rr.Code)
+	}
+
+	if rr := do(editor, http.MethodGet, "/audit", ""); rr.Code != http.StatusForbidden {
+		t.Errorf("editor GET /audit = %d, want 403", rr.Code)
+	}
+
+	rr = do(admin, http.MethodGet, "/audit?limit=3", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("GET /audit = %d: %s", rr.Code, rr.Body.String())
+	}
+	var page struct {
+		Events  []audit.Event `json:"events"`
+		Total   int           `json:"total"`
+		HasMore bool          `json:"has_more"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &page); err != nil {
+		t.Fatal(err)
+	}
+	// The read is not a mutation: create, update, query, delete.
+	if page.Total != 4 || len(page.Events) != 3 || !page.HasMore {
+		t.Fatalf("total = %d, page = %d, has_more = %v; want 4, 3, true", page.Total, len(page.Events), page.HasMore)
+	}
+
+	wantOrder := []audit.Action{audit.ActionDelete, audit.ActionQuery, audit.ActionUpdate}
+	for i, ev := range page.Events {
+		if ev.Action != wantOrder[i] {
+			t.Errorf("event %d action = %q, want %q", i, ev.Action, wantOrder[i])
+		}
+		if ev.Username != "audit-editor" || ev.UserID == "" {
+			t.Errorf("event %d principal = %q/%q, want audit-editor", i, ev.UserID, ev.Username)
+		}
+	}
+
+	del := page.Events[0]
+	if del.ResourceType != audit.ResourceNode || del.ResourceID != fmt.Sprint(created.ID) {
+		t.Errorf("delete event resource = %s/%s", del.ResourceType, del.ResourceID)
+	}
+	if del.Before == nil || del.After != nil {
+		t.Errorf("delete event before = %v after = %v, want a before summary only", del.Before, del.After)
+	}
+	if strings.Contains(rr.Body.String(), "123") {
+		t.Error("audit trail holds a property value")
+	}
+	if strings.Contains(rr.Body.String(), "secret-literal") {
+		t.Error("audit trail holds a query literal")
+	}
+	if q := page.Events[1].After; q["language"] != "cypher" || fmt.Sprint(q["clauses"]) != "[CREATE]" {
+		t.Errorf("query event summary = %v, want cypher [CREATE]", q)
+	}
+
+	rr = do(admin, http.MethodGet, "/audit?offset=3&resource_type=node", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &page); err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 3 || len(page.Events) != 0 || page.HasMore {
+		t.Errorf("filtered past-the-end page: total = %d, page = %d, has_more = %v", page.Total, len(page.Events), page.HasMore)
+	}
+}
+
+// TestAuditLog_CarriesRequestID checks a mutation event records the
+// X-Request-ID of the call that made it and can be looked up by it.
+func TestAuditLog_CarriesRequestID(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	admin := mintTestToken(t, server, auth.RoleAdmin, "audit-admin", "")
+
+	mux := http.NewServeMux()
+	server.registerRoutes(mux)
+	handler := middleware.RequestID()(mux)
+	do := func(method, path, body, requestID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+admin)
+		req.Header.Set("Content-Type", "application/json")
+		if requestID != "" {
+			req.Header.Set(middleware.RequestIDHeader, requestID)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := do(http.MethodPost, "/nodes", `{"labels":["Person"]}`, "client-req-1"); rr.Code != http.StatusCreated {
+		t.Fatalf("create node = %d: %s", rr.Code, rr.Body.String())
+	}
+	rr := do(http.MethodPost, "/nodes", `{"labels":["Person"]}`, "")
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("create node = %d: %s", rr.Code, rr.Body.String())
+	}
+	generated := rr.Header().Get(middleware.RequestIDHeader)
+	if generated == "" {
+		t.Fatal("no X-Request-ID on the response")
+	}
+
+	for _, requestID := range []string{"client-req-1", generated} {
+		rr := do(http.MethodGet, "/audit?request_id="+requestID, "", "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("GET /audit = %d: %s", rr.Code, rr.Body.String())
+		}
+		var page struct {
+			Events []audit.Event `json:"events"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &page); err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Events) != 1 || page.Events[0].RequestID != requestID {
+			t.Errorf("request_id=%s: got %+v, want the one create", requestID, page.Events)
+		}
+	}
+}
+
+// TestAuditLog_VectorIndexesAndRestores checks the trail covers vector index
+// creation and deletion and named-snapshot restores.
+func TestAuditLog_VectorIndexesAndRestores(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	admin := mintTestToken(t, server, auth.RoleAdmin, "audit-admin", "")
+	mux := http.NewServeMux()
+	server.registerRoutes(mux)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+admin)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := do(http.MethodPost, "/vector-indexes", `{"property_name":"embedding","dimensions":3}`); rr.Code != http.StatusCreated {
+		t.Fatalf("create vector index = %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := do(http.MethodDelete, "/vector-indexes/embedding", ""); rr.Code != http.StatusNoContent {
+		t.Fatalf("delete vector index = %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := do(http.MethodPost, "/admin/snapshots", `{"name":"before"}`); rr.Code != http.StatusCreated {
+		t.Fatalf("save snapshot = %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := do(http.MethodPost, "/admin/snapshots/before/restore", ""); rr.Code != http.StatusOK {
+		t.Fatalf("restore snapshot = %d: %s", rr.Code, rr.Body.String())
+	}
+
+	for _, tc := range []struct {
+		resourceType audit.ResourceType
+		want         []audit.Action
+		resourceID   string
+	}{
+		{audit.ResourceVectorIndex, []audit.Action{audit.ActionDelete, audit.ActionCreate}, "embedding"},
+		{audit.ResourceSnapshot, []audit.Action{audit.ActionUpdate}, "before"},
+	} {
+		rr := do(http.MethodGet, "/audit?resource_type="+string(tc.resourceType), "")
+		var page struct {
+			Events []audit.Event `json:"events"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &page); err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Events) != len(tc.want) {
+			t.Fatalf("%s: %d events, want %d", tc.resourceType, len(page.Events), len(tc.want))
+		}
+		for i, ev := range page.Events {
+			if ev.Action != tc.want[i] || ev.ResourceID != tc.resourceID {
+				t.Errorf("%s event %d = %s %q, want %s %q", tc.resourceType, i, ev.Action, ev.ResourceID, tc.want[i], tc.resourceID)
+			}
+		}
+	}
+}
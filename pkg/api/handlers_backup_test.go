@@ -3,8 +3,10 @@ package api
 import (
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
+	"github.com/dd0wney/graphdb/pkg/storage"
 	dto "github.com/prometheus/client_model/go"
 )
 
@@ -100,3 +102,68 @@ func TestHandleBackup_MethodNotAllowed(t *testing.T) {
 		t.Fatalf("status = %d, want 405", rr.Code)
 	}
 }
+
+// portableBackupReq issues a GET /backup through requireAdmin, like backupReq.
+func portableBackupReq(t *testing.T, server *Server, role, username, method string) *httptest.ResponseRecorder {
+	t.Helper()
+	user, err := server.userStore.CreateUser(username, "Password123!", role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := server.jwtManager.GenerateToken(user.ID, user.Username, user.Role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(method, "/backup", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	server.requireAdmin(server.handlePortableBackup)(rr, req)
+	return rr
+}
+
+// TestHandlePortableBackup_RestoresViaStorage verifies GET /backup streams a
+// dump that storage.Restore accepts and that carries the graph.
+func TestHandlePortableBackup_RestoresViaStorage(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	a, err := server.graph.CreateNode([]string{"Person"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := server.graph.CreateNode([]string{"Person"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.graph.CreateEdge(a.ID, b.ID, "KNOWS", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	rr := portableBackupReq(t, server, "admin", "admin-portable-backup", http.MethodGet)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body=%s", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+
+	restored, err := storage.Restore(filepath.Join(t.TempDir(), "restored"), rr.Body)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	defer restored.Close()
+	stats := restored.GetStatistics()
+	if stats.NodeCount != 2 || stats.EdgeCount != 1 {
+		t.Errorf("restored %d nodes / %d edges, want 2 / 1", stats.NodeCount, stats.EdgeCount)
+	}
+}
+
+func TestHandlePortableBackup_AuthAndMethod(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	if rr := portableBackupReq(t, server, "viewer", "viewer-portable-backup", http.MethodGet); rr.Code != http.StatusForbidden {
+		t.Errorf("viewer status = %d, want 403", rr.Code)
+	}
+	if rr := portableBackupReq(t, server, "admin", "admin-portable-post", http.MethodPost); rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rr.Code)
+	}
+}
@@ -278,12 +278,14 @@ func TestComplianceAuditLog_LimitCap(t *testing.T) {
 }
 
 // TestComplianceAuditLog_MethodNotAllowed pins POST/PUT/DELETE return 405.
+// The caller is an editor: a viewer is refused with 403 before the handler
+// sees the method.
 func TestComplianceAuditLog_MethodNotAllowed(t *testing.T) {
 	server, cleanup := setupTestServer(t)
 	defer cleanup()
 	seedComplianceTestTenants(t, server)
 
-	user, _ := server.userStore.CreateUser("alice-405", "AlicePassword123!", auth.RoleViewer)
+	user, _ := server.userStore.CreateUser("alice-405", "AlicePassword123!", auth.RoleEditor)
 	token, _ := server.jwtManager.GenerateTokenWithTenant(user.ID, user.Username, user.Role, "tenant-a")
 
 	inner := http.HandlerFunc(server.handleComplianceAuditLog)
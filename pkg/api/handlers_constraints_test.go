@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/constraints"
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// TestCreate_ConstraintValidator pins write-time constraint enforcement:
+// with a validator registered, POST /nodes and POST /edges reject
+// constraint-breaking entities with 422 and store nothing.
+func TestCreate_ConstraintValidator(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	validator := constraints.NewValidator()
+	validator.AddConstraint(&constraints.EnumConstraint{
+		NodeLabel:    "Asset",
+		PropertyName: "criticality",
+		Allowed:      []storage.Value{storage.StringValue("low"), storage.StringValue("high")},
+	})
+	validator.AddConstraint(&constraints.EdgeConstraint{
+		EdgeType:   "COMMAND_AUTHORITY",
+		FromLabels: []string{"Human"},
+	})
+	server.SetConstraintValidator(validator)
+
+	post := func(t *testing.T, path string, body any, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		t.Helper()
+		buf, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(buf))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+	createNode := func(t *testing.T, labels []string, props map[string]any) *httptest.ResponseRecorder {
+		return post(t, "/nodes", NodeRequest{Labels: labels, Properties: props}, server.handleNodes)
+	}
+
+	before := server.graph.GetStatistics().NodeCount
+	rr := createNode(t, []string{"Asset"}, map[string]any{"criticality": "criticaL"})
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("typo'd enum should return 422, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	var body ValidationErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode 422 body: %v", err)
+	}
+	if len(body.Violations) != 1 || body.Violations[0].Type != constraints.InvalidValue ||
+		body.Violations[0].Property != "criticality" || body.Status != http.StatusUnprocessableEntity {
+		t.Errorf("unexpected 422 body: %+v", body)
+	}
+	if after := server.graph.GetStatistics().NodeCount; after != before {
+		t.Errorf("rejected node was stored: node count %d -> %d", before, after)
+	}
+
+	var asset, human NodeResponse
+	rr = createNode(t, []string{"Asset"}, map[string]any{"criticality": "high"})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("valid node should be created, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &asset)
+	rr = createNode(t, []string{"Human"}, nil)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("human node should be created, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &human)
+
+	edge := func(from, to uint64) EdgeRequest {
+		return EdgeRequest{FromNodeID: from, ToNodeID: to, Type: "COMMAND_AUTHORITY", Weight: 1.0}
+	}
+	rr = post(t, "/edges", edge(asset.ID, human.ID), server.handleEdges)
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("edge from non-Human should return 422, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	rr = post(t, "/edges", edge(human.ID, asset.ID), server.handleEdges)
+	if rr.Code != http.StatusCreated {
+		t.Errorf("edge from Human should be created, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/audit"
 	"github.com/dd0wney/graphdb/pkg/storage"
 	"github.com/dd0wney/graphdb/pkg/validation"
 )
@@ -203,19 +204,41 @@ func (s *Server) createEdge(w http.ResponseWriter, r *http.Request) {
 	// CreateEdgeWithTenant refuses cross-tenant references with
 	// ErrNodeNotFound, surfaced here as 404 (no existence-leak).
 	tenantID := getTenantFromContext(r)
-	edge, err := s.graph.CreateEdgeWithTenant(tenantID, req.FromNodeID, req.ToNodeID, req.Type, props, req.Weight)
-	if err != nil {
-		if errors.Is(err, storage.ErrNodeNotFound) {
-			s.respondError(w, http.StatusNotFound, "Source or target node not found")
-			return
-		}
-		if errors.Is(err, storage.ErrInvalidEdgeWeight) {
-			s.respondError(w, http.StatusBadRequest, "weight must be a finite number")
-			return
+
+	// Endpoint-label rules need both nodes; a missing endpoint is left for
+	// CreateEdgeWithTenant to report as 404.
+	if s.constraintValidator != nil {
+		from, fromErr := s.graph.GetNodeForTenant(req.FromNodeID, tenantID)
+		to, toErr := s.graph.GetNodeForTenant(req.ToNodeID, tenantID)
+		if fromErr == nil && toErr == nil {
+			candidate := &storage.Edge{
+				FromNodeID: req.FromNodeID,
+				ToNodeID:   req.ToNodeID,
+				Type:       req.Type,
+				Properties: props,
+				Weight:     req.Weight,
+				Undirected: req.Undirected,
+			}
+			if violations := s.constraintValidator.ValidateEdge(candidate, from, to); len(violations) > 0 {
+				s.respondViolations(w, violations)
+				return
+			}
 		}
-		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "create edge"))
+	}
+
+	create := s.graph.CreateEdgeWithTenant
+	if req.Undirected {
+		create = s.graph.CreateUndirectedEdgeWithTenant
+	}
+	edge, err := create(tenantID, req.FromNodeID, req.ToNodeID, req.Type, props, req.Weight)
+	if err != nil {
+		// A missing or cross-tenant endpoint is a 404 naming the endpoint
+		// (no existence leak: both read the same); a bad weight or
+		// self-loop is a 400; a rejected duplicate a 409.
+		s.respondStorageError(w, err, "create edge")
 		return
 	}
+	s.auditEdgeMutation(r, audit.ActionCreate, edge.ID, nil, edge)
 
 	response := s.edgeToResponse(r.Context(), edge)
 	s.respondJSON(w, http.StatusCreated, response)
@@ -245,6 +268,7 @@ func (s *Server) getEdge(w http.ResponseWriter, r *http.Request, edgeID uint64)
 		return
 	}
 
+	w.Header().Set("ETag", s.edgeETag(edge))
 	response := s.edgeToResponse(r.Context(), edge)
 	s.respondJSON(w, http.StatusOK, response)
 }
@@ -272,38 +296,53 @@ func (s *Server) updateEdge(w http.ResponseWriter, r *http.Request, edgeID uint6
 
 	// Audit A6b: tenant-scoped update. Cross-tenant or missing edge both
 	// surface as ErrEdgeNotFound → 404 (no existence-leak side channel).
-	// req.Weight is a pointer: nil leaves the weight unchanged.
+	// req.Weight is a pointer: nil leaves the weight unchanged. If-Match
+	// works as in updateNode.
 	tenantID := getTenantFromContext(r)
-	if err := s.graph.UpdateEdgeForTenant(edgeID, props, req.Weight, tenantID); err != nil {
+	before, _ := s.graph.GetEdgeForTenant(edgeID, tenantID)
+	var err error
+	switch match := r.Header.Get("If-Match"); {
+	case match == "" || before == nil:
+		err = s.graph.UpdateEdgeForTenant(edgeID, props, req.Weight, tenantID)
+	case !ifMatch(match, s.edgeETag(before)):
+		err = storage.ErrConflict
+	default:
+		err = s.graph.UpdateEdgeForTenantIfRevision(edgeID, props, req.Weight, tenantID, before.Revision())
+	}
+	if err != nil {
 		if errors.Is(err, storage.ErrEdgeNotFound) {
 			s.respondError(w, http.StatusNotFound, "Edge not found")
 			return
 		}
-		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "update edge"))
+		s.respondStorageError(w, err, "update edge")
 		return
 	}
 
 	edge, err := s.graph.GetEdgeForTenant(edgeID, tenantID)
+	s.auditEdgeMutation(r, audit.ActionUpdate, edgeID, before, edge)
 	if err != nil {
 		// Update succeeded but re-fetch failed — return success-with-id.
 		s.respondJSON(w, http.StatusOK, map[string]any{"updated": edgeID})
 		return
 	}
+	w.Header().Set("ETag", s.edgeETag(edge))
 	response := s.edgeToResponse(r.Context(), edge)
 	s.respondJSON(w, http.StatusOK, response)
 }
 
 func (s *Server) deleteEdge(w http.ResponseWriter, r *http.Request, edgeID uint64) {
 	tenantID := getTenantFromContext(r)
+	before, _ := s.graph.GetEdgeForTenant(edgeID, tenantID)
 	if err := s.graph.DeleteEdgeForTenant(edgeID, tenantID); err != nil {
 		// Cross-tenant or missing → 404 (no existence leak).
 		if errors.Is(err, storage.ErrEdgeNotFound) {
 			s.respondError(w, http.StatusNotFound, "Edge not found")
 			return
 		}
-		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "delete edge"))
+		s.respondStorageError(w, err, "delete edge")
 		return
 	}
+	s.auditEdgeMutation(r, audit.ActionDelete, edgeID, before, nil)
 
 	s.respondJSON(w, http.StatusOK, map[string]any{"deleted": edgeID})
 }
@@ -353,10 +392,15 @@ func (s *Server) handleBatchEdges(w http.ResponseWriter, r *http.Request) {
 		props := converter.ConvertAndSanitize(edgeReq.Properties, s.convertToValue)
 
 		// Audit A6a: scoped create.
-		edge, err := s.graph.CreateEdgeWithTenant(tenantID, edgeReq.FromNodeID, edgeReq.ToNodeID, edgeReq.Type, props, edgeReq.Weight)
+		create := s.graph.CreateEdgeWithTenant
+		if edgeReq.Undirected {
+			create = s.graph.CreateUndirectedEdgeWithTenant
+		}
+		edge, err := create(tenantID, edgeReq.FromNodeID, edgeReq.ToNodeID, edgeReq.Type, props, edgeReq.Weight)
 		if err != nil {
 			continue
 		}
+		s.auditEdgeMutation(r, audit.ActionCreate, edge.ID, nil, edge)
 
 		edges = append(edges, s.edgeToResponse(r.Context(), edge))
 	}
@@ -146,6 +146,47 @@ func TestCreateEdge(t *testing.T) {
 }
 
 // TestGetEdge tests the GET /edges/{id} endpoint
+// TestCreateEdge_Undirected covers "undirected": true on POST /edges and its
+// listing from the target endpoint's side.
+func TestCreateEdge_Undirected(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	const tenantID = "acme"
+	a, _ := server.graph.CreateNodeWithTenant(tenantID, []string{"N"}, nil)
+	b, _ := server.graph.CreateNodeWithTenant(tenantID, []string{"N"}, nil)
+
+	rr := httptest.NewRecorder()
+	server.handleEdges(rr, reqWithTenant(t, http.MethodPost, "/edges", EdgeRequest{
+		FromNodeID: a.ID, ToNodeID: b.ID, Type: "PEER", Weight: 1, Undirected: true,
+	}, tenantID))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("create: want 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var created EdgeResponse
+	if err := json.NewDecoder(rr.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if !created.Undirected {
+		t.Error("created edge is not marked undirected")
+	}
+
+	rr = httptest.NewRecorder()
+	server.handleEdges(rr, reqWithTenant(t, http.MethodGet, fmt.Sprintf("/edges?from=%d", b.ID), nil, tenantID))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("list: want 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var listed []EdgeResponse
+	if err := json.NewDecoder(rr.Body).Decode(&listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].ID != created.ID || listed[0].FromNodeID != b.ID || listed[0].ToNodeID != a.ID {
+		t.Errorf("edges from node %d = %+v, want edge %d oriented %d->%d", b.ID, listed, created.ID, b.ID, a.ID)
+	}
+	if n := server.graph.CountEdgesForTenant(tenantID); n != 1 {
+		t.Errorf("CountEdgesForTenant = %d, want 1", n)
+	}
+}
+
 func TestGetEdge(t *testing.T) {
 	server, cleanup := setupTestServer(t)
 	defer cleanup()
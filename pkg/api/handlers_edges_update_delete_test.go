@@ -143,6 +143,50 @@ func TestCreateEdge_NonFiniteWeightRejected(t *testing.T) {
 	}
 }
 
+// TestCreateEdge_EdgePolicy: with the store refusing self-loops and
+// duplicate edges, POST /edges maps them to 400 and 409.
+func TestCreateEdge_EdgePolicy(t *testing.T) {
+	dir := t.TempDir()
+	cfg := storage.DefaultStorageConfig(dir)
+	cfg.RejectSelfLoops = true
+	cfg.MultiEdges = storage.MultiEdgesReject
+	gs, err := storage.NewGraphStorageWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = gs.Close() }()
+	server, err := NewServerWithDataDir(gs, 8080, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const tenantID = "acme"
+	a, _ := gs.CreateNodeWithTenant(tenantID, []string{"N"}, nil)
+	b, _ := gs.CreateNodeWithTenant(tenantID, []string{"N"}, nil)
+	post := func(from, to uint64) int {
+		body := fmt.Sprintf(`{"from_node_id": %d, "to_node_id": %d, "type": "T", "weight": 1}`, from, to)
+		req := httptest.NewRequest(http.MethodPost, "/edges", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(tenant.WithTenant(req.Context(), tenantID))
+		rr := httptest.NewRecorder()
+		server.createEdge(rr, req)
+		return rr.Code
+	}
+
+	if code := post(a.ID, b.ID); code != http.StatusCreated {
+		t.Fatalf("first edge: want 201, got %d", code)
+	}
+	if code := post(a.ID, b.ID); code != http.StatusConflict {
+		t.Errorf("duplicate edge: want 409, got %d", code)
+	}
+	if code := post(a.ID, a.ID); code != http.StatusBadRequest {
+		t.Errorf("self-loop: want 400, got %d", code)
+	}
+	if n := gs.CountEdgesForTenant(tenantID); n != 1 {
+		t.Errorf("CountEdgesForTenant = %d, want 1", n)
+	}
+}
+
 func TestDeleteEdge(t *testing.T) {
 	server, cleanup := setupTestServer(t)
 	defer cleanup()
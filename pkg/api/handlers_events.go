@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// eventsKeepAlive is how often GET /events writes an SSE comment on an idle
+// stream, so proxies and load balancers don't reap the connection.
+const eventsKeepAlive = 15 * time.Second
+
+// changeEventPayload is the JSON data of one GET /events message.
+type changeEventPayload struct {
+	Op   storage.ChangeOp   `json:"op"`
+	Type storage.EntityType `json:"type"`
+	ID   uint64             `json:"id"`
+}
+
+// handleEvents implements GET /events: a Server-Sent Events stream of graph
+// changes in the caller's tenant, one `data: {"op","type","id"}` message per
+// node or edge create, update or delete. It is a refresh signal, not a change
+// log — a client that falls far behind loses events (see
+// storage.GraphStorage.Subscribe) and should re-read what it displays.
+//
+// The stream ends when the client disconnects, the server shuts down or the
+// store closes; the subscription is released in every case.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	rc := http.NewResponseController(w)
+	// Lift the server-wide WriteTimeout, which would cut the stream after
+	// 30s. Writers without deadlines (ErrNotSupported) have nothing to lift.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	tenantID := getTenantFromContext(r)
+	events, unsubscribe := s.graph.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx: don't buffer the stream
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.streamsStopCh:
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if ev.TenantID != tenantID {
+				continue
+			}
+			data, err := json.Marshal(changeEventPayload{Op: ev.Op, Type: ev.EntityType, ID: ev.ID})
+			if err != nil {
+				log.Printf("ERROR [events stream]: %v", err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// stopStreams ends every open GET /events stream; registered with the HTTP
+// server's shutdown hooks by Start.
+func (s *Server) stopStreams() {
+	s.streamsStopOnce.Do(func() {
+		if s.streamsStopCh != nil {
+			close(s.streamsStopCh)
+		}
+	})
+}
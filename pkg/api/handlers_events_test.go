@@ -0,0 +1,146 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/tenant"
+)
+
+// eventsTestServer serves handleEvents with the tenant taken from the
+// X-Test-Tenant header (standing in for withTenant), and reports on done
+// each time a handler invocation returns.
+func eventsTestServer(t *testing.T, server *Server) (*httptest.Server, <-chan struct{}) {
+	t.Helper()
+	done := make(chan struct{}, 4)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() { done <- struct{}{} }()
+		ctx := tenant.WithTenant(r.Context(), r.Header.Get("X-Test-Tenant"))
+		server.handleEvents(w, r.WithContext(ctx))
+	}))
+	t.Cleanup(ts.Close)
+	return ts, done
+}
+
+// openEvents connects to the stream as tenantID; the returned response's
+// headers have arrived, so the subscription is live.
+func openEvents(t *testing.T, ctx context.Context, url, tenantID string) (*http.Response, *bufio.Reader) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Test-Tenant", tenantID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	return resp, bufio.NewReader(resp.Body)
+}
+
+// nextEvent reads up to the next data message and decodes it.
+func nextEvent(t *testing.T, rd *bufio.Reader) changeEventPayload {
+	t.Helper()
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading stream: %v", err)
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			var ev changeEventPayload
+			if err := json.Unmarshal([]byte(data), &ev); err != nil {
+				t.Fatalf("decoding %q: %v", data, err)
+			}
+			return ev
+		}
+	}
+}
+
+// TestHandleEvents_StreamsCallerTenantOnly: another tenant's writes never
+// reach the stream; the caller's own create and delete do, in order.
+func TestHandleEvents_StreamsCallerTenantOnly(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	ts, _ := eventsTestServer(t, server)
+
+	resp, rd := openEvents(t, context.Background(), ts.URL, "tenant-A")
+	defer resp.Body.Close()
+
+	if _, err := server.graph.CreateNodeWithTenant("tenant-B", []string{"Secret"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	n, err := server.graph.CreateNodeWithTenant("tenant-A", []string{"Doc"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.graph.DeleteNodeForTenant(n.ID, "tenant-A"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := nextEvent(t, rd), (changeEventPayload{Op: "create", Type: "node", ID: n.ID}); got != want {
+		t.Errorf("first event = %+v, want %+v", got, want)
+	}
+	if got, want := nextEvent(t, rd), (changeEventPayload{Op: "delete", Type: "node", ID: n.ID}); got != want {
+		t.Errorf("second event = %+v, want %+v", got, want)
+	}
+}
+
+// TestHandleEvents_EndsOnDisconnectAndShutdown: the handler returns (and so
+// unsubscribes) when the client goes away, and open streams end when the
+// server shuts down.
+func TestHandleEvents_EndsOnDisconnectAndShutdown(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	ts, done := eventsTestServer(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, _ := openEvents(t, ctx, ts.URL, "default")
+	cancel()
+	resp.Body.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler still running after client disconnect")
+	}
+
+	resp, rd := openEvents(t, context.Background(), ts.URL, "default")
+	defer resp.Body.Close()
+	server.stopStreams()
+	server.stopStreams() // idempotent
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler still running after stopStreams")
+	}
+	if _, err := rd.ReadString('\n'); err == nil {
+		t.Error("stream still open after stopStreams")
+	}
+}
+
+func TestHandleEvents_RouteRequiresAuthAndGET(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	rr := httptest.NewRecorder()
+	buildTestMux(server).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/events", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated GET /events = %d, want 401", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	server.handleEvents(rr, reqWithTenant(t, http.MethodPost, "/events", nil, "default"))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /events = %d, want 405", rr.Code)
+	}
+}
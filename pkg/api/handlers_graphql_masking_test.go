@@ -116,8 +116,9 @@ func TestGraphQL_Masking_PolicyFollowsTenant(t *testing.T) {
 			t.Fatalf("schema regenerate: %d %s", rr.Code, rr.Body.String())
 		}
 	}
-	regen(userATok)
-	regen(userBTok)
+	adminTokenB, _ := server.jwtManager.GenerateTokenWithTenant(admin.ID, admin.Username, admin.Role, "tenant-b")
+	regen(adminTokenA)
+	regen(adminTokenB)
 
 	// Tenant-A: full-mask email. The viewer queries their own tenant.
 	codeA, respA := graphqlQueryAs(t, server, userATok, `{ persons { id properties } }`)
@@ -168,10 +169,13 @@ func TestGraphQL_Masking_NoPolicy_PassthroughPreservesPreF3Behavior(t *testing.T
 
 	user, _ := server.userStore.CreateUser("alice", "AlicePassword123!", auth.RoleViewer)
 	userTok, _ := server.jwtManager.GenerateTokenWithTenant(user.ID, user.Username, user.Role, "tenant-a")
+	admin, _ := server.userStore.CreateUser("root", "RootPassword123!", auth.RoleAdmin)
+	adminTok, _ := server.jwtManager.GenerateTokenWithTenant(admin.ID, admin.Username, admin.Role, "tenant-a")
 
-	// Regenerate schema so the Person label is visible.
+	// Regenerate schema so the Person label is visible (an admin
+	// operation; viewers are read-only).
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/schema/regenerate", nil)
-	req.Header.Set("Authorization", "Bearer "+userTok)
+	req.Header.Set("Authorization", "Bearer "+adminTok)
 	rr := httptest.NewRecorder()
 	handler := server.requireAuth(server.withTenant(http.HandlerFunc(server.handleSchemaRegenerate)))
 	handler.ServeHTTP(rr, req)
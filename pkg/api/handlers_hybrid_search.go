@@ -122,16 +122,16 @@ func (s *Server) handleHybridSearch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Label post-filter. FTS-stage candidates already carry a hydrated
-	// node; LSA-only candidates need an on-demand GetNode. Cost is
+	// node; LSA-only candidates need an on-demand GetNodeForTenant. Cost is
 	// bounded by overFetchK, which in turn is bounded by 3*(limit+offset).
-	// Filter mismatches (and GetNode failures from deleted nodes) are
-	// dropped from the result set.
+	// Filter mismatches (and lookup failures from deleted or cross-tenant
+	// nodes) are dropped from the result set.
 	if len(req.Labels) > 0 {
 		filtered := hits[:0]
 		for _, h := range hits {
 			node := hydrated[h.NodeID]
 			if node == nil {
-				gnode, gerr := s.graph.GetNode(h.NodeID)
+				gnode, gerr := s.graph.GetNodeForTenant(h.NodeID, tenantID)
 				if gerr != nil || gnode == nil {
 					continue
 				}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/health"
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
@@ -107,6 +108,17 @@ func TestHandleMetrics(t *testing.T) {
 		t.Errorf("Expected at least 2 edges, got %d", response.EdgeCount)
 	}
 
+	if response.LabelCounts["Node"] < 5 {
+		t.Errorf("Expected at least 5 Node-labelled nodes, got %v", response.LabelCounts)
+	}
+	if response.EdgeTypeCounts["LINK"] < 2 {
+		t.Errorf("Expected at least 2 LINK edges, got %v", response.EdgeTypeCounts)
+	}
+
+	if response.GraphVersion != server.graph.Version() {
+		t.Errorf("graph_version = %d, want %d", response.GraphVersion, server.graph.Version())
+	}
+
 	if response.Uptime == "" {
 		t.Error("Expected non-empty uptime")
 	}
@@ -361,3 +373,44 @@ func TestMonitoring_Integration(t *testing.T) {
 	t.Logf("✓ Health still good after data insertion")
 	t.Logf("✓ Complete monitoring integration test passed")
 }
+
+// TestProbes_LivezReadyz: /livez stays 200 while storage is down; /readyz
+// and /health turn 503, and readiness carries the WAL/snapshot details.
+func TestProbes_LivezReadyz(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	mux := buildTestMux(server)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+
+	rr := get("/readyz")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("/readyz = %d, want 200. Body: %s", rr.Code, rr.Body.String())
+	}
+	var ready health.Response
+	if err := json.Unmarshal(rr.Body.Bytes(), &ready); err != nil {
+		t.Fatal(err)
+	}
+	details := ready.Checks["storage"].Details
+	for _, key := range []string{"writable", "wal_enabled", "wal_lsn", "wal_lag"} {
+		if _, ok := details[key]; !ok {
+			t.Errorf("/readyz storage details missing %q: %v", key, details)
+		}
+	}
+
+	if err := server.graph.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if rr := get("/livez"); rr.Code != http.StatusOK {
+		t.Errorf("/livez with storage closed = %d, want 200", rr.Code)
+	}
+	for _, path := range []string{"/readyz", "/health"} {
+		if rr := get(path); rr.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s with storage closed = %d, want 503", path, rr.Code)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/audit"
 	"github.com/dd0wney/graphdb/pkg/storage"
 	"github.com/dd0wney/graphdb/pkg/validation"
 )
@@ -54,12 +55,26 @@ func (s *Server) countNodes(w http.ResponseWriter, r *http.Request) {
 // deleteAllNodes removes all nodes and edges for the CALLER's tenant only.
 // Used by single-tenant consumers (e.g. wiki-graph) before a full reload.
 // Tenant-scoped (audit/ROADMAP B1): the previous global DeleteAllNodes let any
-// authenticated tenant wipe every tenant's data.
+// authenticated tenant wipe every tenant's data. With ?label= only the
+// nodes carrying that label go, and the response reports how many.
 func (s *Server) deleteAllNodes(w http.ResponseWriter, r *http.Request) {
-	if err := s.graph.DeleteAllNodesForTenant(getTenantFromContext(r)); err != nil {
+	tenantID := getTenantFromContext(r)
+	if label := r.URL.Query().Get("label"); label != "" {
+		deleted, err := s.graph.DeleteNodesByLabelForTenant(tenantID, label)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "delete nodes by label"))
+			return
+		}
+		s.auditMutation(r, audit.ActionDelete, audit.ResourceNode, "*", map[string]any{"nodes": deleted, "label": label}, nil)
+		s.respondJSON(w, http.StatusOK, map[string]any{"status": "deleted", "deleted": deleted})
+		return
+	}
+	count := s.graph.CountNodesForTenant(tenantID)
+	if err := s.graph.DeleteAllNodesForTenant(tenantID); err != nil {
 		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "delete all nodes"))
 		return
 	}
+	s.auditMutation(r, audit.ActionDelete, audit.ResourceNode, "*", map[string]any{"nodes": count}, nil)
 	s.respondJSON(w, http.StatusOK, map[string]string{"status": "cleared"})
 }
 
@@ -120,6 +135,16 @@ func (s *Server) createNode(w http.ResponseWriter, r *http.Request) {
 	// at the storage layer.
 	tenantID := getTenantFromContext(r)
 
+	// Reject constraint-breaking nodes at ingestion rather than leaving
+	// them for a later full Validate scan to find.
+	if s.constraintValidator != nil {
+		candidate := &storage.Node{Labels: req.Labels, Properties: props}
+		if violations := s.constraintValidator.ValidateNode(candidate); len(violations) > 0 {
+			s.respondViolations(w, violations)
+			return
+		}
+	}
+
 	// H4.4: B-lite mirror. Route single-label :Claim creation through the
 	// unique-property helper so REST callers can't bypass the at-most-one-
 	// active-Claim-per-(tenant, for_task) rule that the GraphQL resolver
@@ -144,13 +169,10 @@ func (s *Server) createNode(w http.ResponseWriter, r *http.Request) {
 		node, err = s.graph.CreateNodeWithTenant(tenantID, req.Labels, props)
 	}
 	if err != nil {
-		if errors.Is(err, storage.ErrUniqueConstraintViolation) {
-			s.respondError(w, http.StatusConflict, err.Error())
-			return
-		}
-		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "create node"))
+		s.respondStorageError(w, err, "create node")
 		return
 	}
+	s.auditNodeMutation(r, audit.ActionCreate, node.ID, nil, node)
 
 	response := s.nodeToResponse(r.Context(), node)
 	s.respondJSON(w, http.StatusCreated, response)
@@ -182,6 +204,7 @@ func (s *Server) getNode(w http.ResponseWriter, r *http.Request, nodeID uint64)
 		return
 	}
 
+	w.Header().Set("ETag", s.nodeETag(node))
 	response := s.nodeToResponse(r.Context(), node)
 	s.respondJSON(w, http.StatusOK, response)
 }
@@ -210,8 +233,21 @@ func (s *Server) updateNode(w http.ResponseWriter, r *http.Request, nodeID uint6
 	converter := newPropertyConverter()
 	props := converter.ConvertAndSanitize(req.Properties, s.convertToValue)
 
+	// If-Match makes the update conditional: it must name the ETag of
+	// the node as read here, and the storage update then only applies if
+	// the node is still at that revision. Either mismatch is a 409.
 	tenantID := getTenantFromContext(r)
-	if err := s.graph.UpdateNodeForTenant(nodeID, props, tenantID); err != nil {
+	before, _ := s.graph.GetNodeForTenant(nodeID, tenantID)
+	var err error
+	switch match := r.Header.Get("If-Match"); {
+	case match == "" || before == nil:
+		err = s.graph.UpdateNodeForTenant(nodeID, props, tenantID)
+	case !ifMatch(match, s.nodeETag(before)):
+		err = storage.ErrConflict
+	default:
+		err = s.graph.UpdateNodeForTenantIfRevision(nodeID, props, tenantID, before.Revision())
+	}
+	if err != nil {
 		// Cross-tenant update or genuinely-missing node both surface as
 		// ErrNodeNotFound — return 404 to avoid an existence-leak side
 		// channel. Only true storage errors should 500.
@@ -219,32 +255,36 @@ func (s *Server) updateNode(w http.ResponseWriter, r *http.Request, nodeID uint6
 			s.respondError(w, http.StatusNotFound, "Node not found")
 			return
 		}
-		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "update node"))
+		s.respondStorageError(w, err, "update node")
 		return
 	}
 
 	node, err := s.graph.GetNodeForTenant(nodeID, tenantID)
+	s.auditNodeMutation(r, audit.ActionUpdate, nodeID, before, node)
 	if err != nil {
 		// Update succeeded but couldn't retrieve the updated node — unusual
 		// but not fatal. Don't leak the cause; return success-with-id.
 		s.respondJSON(w, http.StatusOK, map[string]any{"updated": nodeID})
 		return
 	}
+	w.Header().Set("ETag", s.nodeETag(node))
 	response := s.nodeToResponse(r.Context(), node)
 	s.respondJSON(w, http.StatusOK, response)
 }
 
 func (s *Server) deleteNode(w http.ResponseWriter, r *http.Request, nodeID uint64) {
 	tenantID := getTenantFromContext(r)
+	before, _ := s.graph.GetNodeForTenant(nodeID, tenantID)
 	if err := s.graph.DeleteNodeForTenant(nodeID, tenantID); err != nil {
 		// Cross-tenant or missing → 404 (no existence leak).
 		if errors.Is(err, storage.ErrNodeNotFound) {
 			s.respondError(w, http.StatusNotFound, "Node not found")
 			return
 		}
-		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "delete node"))
+		s.respondStorageError(w, err, "delete node")
 		return
 	}
+	s.auditNodeMutation(r, audit.ActionDelete, nodeID, before, nil)
 
 	s.respondJSON(w, http.StatusOK, map[string]any{"deleted": nodeID})
 }
@@ -291,6 +331,7 @@ func (s *Server) handleBatchNodes(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			continue
 		}
+		s.auditNodeMutation(r, audit.ActionCreate, node.ID, nil, node)
 
 		nodes = append(nodes, s.nodeToResponse(r.Context(), node))
 	}
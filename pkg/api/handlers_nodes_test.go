@@ -323,6 +323,36 @@ func TestDeleteNode(t *testing.T) {
 	}
 }
 
+// TestDeleteNodesByLabel tests DELETE /nodes?label=
+func TestDeleteNodesByLabel(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for _, label := range []string{"Workstation", "Workstation", "Server"} {
+		if _, err := server.graph.CreateNode([]string{label}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/nodes?label=Workstation", nil)
+	rr := httptest.NewRecorder()
+	server.handleNodes(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var response map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response["deleted"] != float64(2) {
+		t.Errorf("Expected 2 deleted, got %v", response["deleted"])
+	}
+	if stats := server.graph.GetStatistics(); stats.NodeCount != 1 {
+		t.Errorf("Expected the Server node to remain, got %d nodes", stats.NodeCount)
+	}
+}
+
 // TestHandleNode_InvalidID tests handling of invalid node IDs
 func TestHandleNode_InvalidID(t *testing.T) {
 	server, cleanup := setupTestServer(t)
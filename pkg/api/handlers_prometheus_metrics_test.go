@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// TestPrometheusMetrics_Scrape: GET /metrics serves Prometheus text with
+// route-labelled HTTP metrics and graph gauges that are current at scrape
+// time (not up to one updater tick stale).
+func TestPrometheusMetrics_Scrape(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for i := 0; i < 3; i++ {
+		if _, err := server.graph.CreateNode([]string{"Node"}, map[string]storage.Value{}); err != nil {
+			t.Fatalf("CreateNode: %v", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	server.registerRoutes(mux)
+	handler := server.metricsMiddleware(mux)
+
+	// Two requests for different IDs must land in one ":id" series.
+	for _, path := range []string{"/nodes/1", "/nodes/2"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("GET /metrics = %d", rr.Code)
+	}
+	body := rr.Body.String()
+
+	for _, want := range []string{
+		`graphdb_storage_nodes_total 3`,
+		`graphdb_storage_queries`,
+		`graphdb_http_requests_in_flight`,
+		`graphdb_http_requests_total{method="GET",path="/nodes/:id",status="401"} 2`,
+		`graphdb_http_request_duration_seconds_bucket{method="GET",path="/nodes/:id",status="401"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape missing %q", want)
+		}
+	}
+	if strings.Contains(body, `path="/nodes/1"`) {
+		t.Error("raw ID leaked into path label")
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/auth"
+	"github.com/dd0wney/graphdb/pkg/query"
+	"github.com/dd0wney/graphdb/pkg/storage"
+	"github.com/dd0wney/graphdb/pkg/tenant"
+)
+
+func TestQuery_Explain(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for _, name := range []string{"Alice", "Bob"} {
+		if _, err := server.graph.CreateNode([]string{"Person"}, map[string]storage.Value{
+			"name": storage.StringValue(name),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := server.graph.CreatePropertyIndex("name", storage.TypeString); err != nil {
+		t.Fatal(err)
+	}
+
+	post := func(q string, role string) (*httptest.ResponseRecorder, QueryResponse) {
+		t.Helper()
+		body, err := json.Marshal(QueryRequest{Query: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/query?explain=true", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := tenant.WithTenant(req.Context(), "default")
+		if role != "" {
+			ctx = context.WithValue(ctx, claimsContextKey, &auth.Claims{UserID: "u", Username: "u", Role: role})
+		}
+		rr := httptest.NewRecorder()
+		server.handleQuery(rr, req.WithContext(ctx))
+		var resp QueryResponse
+		if rr.Code == http.StatusOK {
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+		}
+		return rr, resp
+	}
+
+	rr, resp := post(`MATCH (n:Person) WHERE n.name = 'Bob' RETURN n`, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d body=%s", rr.Code, rr.Body.String())
+	}
+	if resp.Plan == nil || len(resp.Plan.Steps) == 0 {
+		t.Fatalf("response has no plan: %s", rr.Body.String())
+	}
+	first := resp.Plan.Steps[0]
+	if first.Step != "IndexLookupStep" || first.Scan != query.ScanIndex {
+		t.Errorf("first step = %s (%s), want IndexLookupStep (%s)", first.Step, first.Scan, query.ScanIndex)
+	}
+	if first.EstimatedRows == nil || *first.EstimatedRows != 1 {
+		t.Errorf("EstimatedRows = %v, want 1", first.EstimatedRows)
+	}
+	if resp.Count != len(resp.Plan.Steps) || resp.Columns[0] != "step" {
+		t.Errorf("table = %v with %d rows, want the plan's %d steps", resp.Columns, resp.Count, len(resp.Plan.Steps))
+	}
+
+	// Explaining a write executes nothing, so a viewer may do it.
+	rr, _ = post(`CREATE (n:Person {name: 'Eve'})`, auth.RoleViewer)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("viewer explain of CREATE: status %d body=%s", rr.Code, rr.Body.String())
+	}
+	if got := server.graph.CountNodesByLabelForTenant("default", "Person"); got != 2 {
+		t.Errorf("Person count = %d after explain, want 2", got)
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/query"
+	"github.com/dd0wney/graphdb/pkg/tenant"
+)
+
+func TestQuery_Limits(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	for i := 0; i < 5; i++ {
+		if _, err := server.graph.CreateNode([]string{"Host"}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server.executor.SetLimits(query.Limits{MaxRows: 4, MaxDuration: 60 * time.Second})
+
+	post := func(req QueryRequest) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r = r.WithContext(tenant.WithTenant(r.Context(), "default"))
+		rr := httptest.NewRecorder()
+		server.handleQuery(rr, r)
+		return rr
+	}
+	intPtr := func(n int) *int { return &n }
+
+	for _, tc := range []struct {
+		name     string
+		req      QueryRequest
+		status   int
+		contains string
+	}{
+		{"under the server cap", QueryRequest{Query: "MATCH (n:Host) RETURN n LIMIT 4"}, http.StatusOK, ""},
+		{"over the server cap", QueryRequest{Query: "MATCH (n:Host) RETURN n"}, http.StatusUnprocessableEntity, "query exceeded limit"},
+		{"over a request cap", QueryRequest{Query: "MATCH (n:Host) RETURN n LIMIT 3", MaxRows: intPtr(2)}, http.StatusUnprocessableEntity, "more than 2 rows"},
+		{"request cap above the server's", QueryRequest{Query: "MATCH (n:Host) RETURN n", MaxRows: intPtr(10)}, http.StatusBadRequest, "at most 4"},
+		{"zero request cap", QueryRequest{Query: "MATCH (n:Host) RETURN n", MaxRows: intPtr(0)}, http.StatusBadRequest, "at least 1"},
+		{"timeout above the server's", QueryRequest{Query: "MATCH (n:Host) RETURN n LIMIT 1", TimeoutSeconds: intPtr(61)}, http.StatusBadRequest, "at most 60"},
+		{"timeout within the server's", QueryRequest{Query: "MATCH (n:Host) RETURN n LIMIT 1", TimeoutSeconds: intPtr(60)}, http.StatusOK, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := post(tc.req)
+			if rr.Code != tc.status || !strings.Contains(rr.Body.String(), tc.contains) {
+				t.Errorf("status %d body=%s; want %d containing %q", rr.Code, rr.Body.String(), tc.status, tc.contains)
+			}
+		})
+	}
+}
@@ -48,3 +48,36 @@ func TestQuery_ParametersHonoredEndToEnd(t *testing.T) {
 		t.Errorf("n.name = %#v, want \"gizmo\" (param not substituted via /query?)", got)
 	}
 }
+
+// The short "params" key is accepted as an alias for "parameters".
+func TestQuery_ParamsAlias(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	post := func(body string) QueryResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(tenant.WithTenant(req.Context(), "default"))
+		rr := httptest.NewRecorder()
+		server.handleQuery(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("status %d body=%s", rr.Code, rr.Body.String())
+		}
+		var resp QueryResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		return resp
+	}
+
+	post(`{"query": "CREATE (n:Widget {name: $name})", "params": {"name": "sprocket"}}`)
+
+	resp := post(`{"query": "MATCH (n:Widget) WHERE n.name = $name RETURN n.name", "params": {"name": "sprocket"}}`)
+	if resp.Count != 1 || len(resp.Columns) != 1 {
+		t.Fatalf("count = %d, columns = %v; want 1 row, 1 column", resp.Count, resp.Columns)
+	}
+	if got := resp.Rows[0]["n.name"]; got != "sprocket" {
+		t.Errorf("n.name = %#v, want \"sprocket\"", got)
+	}
+}
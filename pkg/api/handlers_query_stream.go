@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/query"
+)
+
+const (
+	// queryStreamThreshold is the row count past which /query stops
+	// buffering a read result and switches to NDJSON.
+	queryStreamThreshold = 10000
+
+	// queryStreamFlushEvery is how many NDJSON rows are written between
+	// flushes.
+	queryStreamFlushEvery = 1000
+
+	// queryStreamWriteGrace is added to the query timeout for the write
+	// deadline of an NDJSON response, which outlives the server-wide
+	// WriteTimeout.
+	queryStreamWriteGrace = 10 * time.Second
+
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// streamQuery answers a read query through ExecuteStream. Rows are buffered
+// up to queryStreamThreshold: a result that stays under it is sent as the
+// usual QueryResponse, a larger one (or any result when the client sent
+// Accept: application/x-ndjson) as NDJSON, one row object per line, written
+// as the rows are produced so the server never holds the whole result.
+//
+// Once NDJSON rows have been sent the status can no longer change, so a
+// later error (including the timeout) is reported as a final
+// {"error": "..."} line.
+func (s *Server) streamQuery(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	parsed *query.Query, params map[string]any, timeout time.Duration, start time.Time) {
+	var (
+		rc        = http.NewResponseController(w)
+		enc       = json.NewEncoder(w)
+		buffered  = make([]map[string]any, 0)
+		streaming bool
+		count     int
+	)
+	forceStream := acceptsNDJSON(r)
+
+	flush := func() error {
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+	begin := func() error {
+		streaming = true
+		_ = rc.SetWriteDeadline(time.Now().Add(timeout + queryStreamWriteGrace))
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.Header().Set("X-Accel-Buffering", "no") // nginx: don't buffer the stream
+		w.WriteHeader(http.StatusOK)
+		for _, row := range buffered {
+			if err := enc.Encode(row); err != nil {
+				return err
+			}
+		}
+		buffered = nil
+		return flush()
+	}
+	emit := func(row map[string]any) error {
+		count++
+		if !streaming {
+			buffered = append(buffered, row)
+			if forceStream || len(buffered) > queryStreamThreshold {
+				return begin()
+			}
+			return nil
+		}
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+		if count%queryStreamFlushEvery == 0 {
+			return flush()
+		}
+		return nil
+	}
+
+	var err error
+	if len(params) > 0 {
+		err = s.executor.ExecuteStreamWithParamsContext(ctx, parsed, params, emit)
+	} else {
+		err = s.executor.ExecuteStreamWithContext(ctx, parsed, emit)
+	}
+
+	if err != nil {
+		status, message := queryErrorResponse(ctx, err, timeout)
+		if !streaming {
+			s.respondError(w, status, message)
+			return
+		}
+		_ = enc.Encode(map[string]string{"error": message})
+		_ = flush()
+		return
+	}
+
+	if streaming || forceStream {
+		if !streaming {
+			_ = begin()
+		}
+		_ = flush()
+		return
+	}
+	s.respondJSON(w, http.StatusOK, QueryResponse{
+		Columns: parsed.ResultColumns(),
+		Rows:    buffered,
+		Count:   count,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// acceptsNDJSON reports whether the client asked for an NDJSON response.
+func acceptsNDJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), ndjsonContentType)
+}
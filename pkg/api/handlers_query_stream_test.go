@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+	"github.com/dd0wney/graphdb/pkg/tenant"
+)
+
+func TestQuery_StreamsNDJSON(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	batch := server.graph.BeginBatch()
+	for i := 0; i < queryStreamThreshold+5; i++ {
+		if _, err := batch.AddNode([]string{"Item"}, map[string]storage.Value{"n": storage.IntValue(int64(i))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	post := func(q, accept string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(QueryRequest{Query: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		req = req.WithContext(tenant.WithTenant(req.Context(), "default"))
+		rr := httptest.NewRecorder()
+		server.handleQuery(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("status %d body=%s", rr.Code, rr.Body.String())
+		}
+		return rr
+	}
+	ndjsonRows := func(rr *httptest.ResponseRecorder) []map[string]any {
+		t.Helper()
+		if ct := rr.Header().Get("Content-Type"); ct != ndjsonContentType {
+			t.Fatalf("Content-Type = %q, want %q", ct, ndjsonContentType)
+		}
+		var rows []map[string]any
+		sc := bufio.NewScanner(rr.Body)
+		for sc.Scan() {
+			var row map[string]any
+			if err := json.Unmarshal(sc.Bytes(), &row); err != nil {
+				t.Fatalf("line %q: %v", sc.Text(), err)
+			}
+			if _, failed := row["error"]; failed {
+				t.Fatalf("stream ended with %v", row)
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	}
+
+	// A small result stays a single JSON document.
+	rr := post(`MATCH (i:Item) RETURN i.n LIMIT 3`, "")
+	var resp QueryResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Count != 3 || len(resp.Columns) != 1 || resp.Columns[0] != "i.n" {
+		t.Errorf("small result = %d rows, columns %v; want 3 rows of i.n", resp.Count, resp.Columns)
+	}
+
+	// Past the threshold the response switches to NDJSON.
+	rows := ndjsonRows(post(`MATCH (i:Item) RETURN i.n`, ""))
+	if len(rows) != queryStreamThreshold+5 {
+		t.Fatalf("streamed %d rows, want %d", len(rows), queryStreamThreshold+5)
+	}
+	if rows[0]["i.n"] != float64(0) || rows[len(rows)-1]["i.n"] != float64(queryStreamThreshold+4) {
+		t.Errorf("first/last rows = %v / %v, want ids in order", rows[0], rows[len(rows)-1])
+	}
+
+	// Asking for NDJSON streams even a small result.
+	if rows := ndjsonRows(post(`MATCH (i:Item) WHERE i.n < 2 RETURN i.n`, ndjsonContentType)); len(rows) != 2 {
+		t.Errorf("Accept: %s returned %d rows, want 2", ndjsonContentType, len(rows))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/dd0wney/graphdb/pkg/retrieval"
+	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
 // RetrieveRequest is the JSON body for POST /v1/retrieve. Shape
@@ -141,11 +142,22 @@ func (s *Server) handleRetrieve(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Map chunks → LangChain documents. IncludeNode hydrates each
-	// node via GetNodeForTenant (same tenant guarantee Retrieve used
-	// internally; double check is cheap and protects against drift).
+	// Map chunks → LangChain documents. IncludeNode hydrates the
+	// nodes in one GetNodesForTenant call (same tenant guarantee Retrieve
+	// used internally; double check is cheap and protects against drift).
+	var nodes []*storage.Node
+	if req.IncludeNode {
+		ids := make([]uint64, len(result.Chunks))
+		for i, c := range result.Chunks {
+			ids[i] = c.NodeID
+		}
+		if nodes, err = s.graph.GetNodesForTenant(ids, tenantID); err != nil {
+			s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "retrieval"))
+			return
+		}
+	}
 	docs := make([]RetrieveDocument, 0, len(result.Chunks))
-	for _, c := range result.Chunks {
+	for i, c := range result.Chunks {
 		doc := RetrieveDocument{
 			PageContent: c.Content,
 			Metadata: RetrieveDocumentMetadata{
@@ -158,10 +170,8 @@ func (s *Server) handleRetrieve(w http.ResponseWriter, r *http.Request) {
 				},
 			},
 		}
-		if req.IncludeNode {
-			if node, err := s.graph.GetNodeForTenant(c.NodeID, tenantID); err == nil {
-				doc.Metadata.Node = s.nodeToResponse(r.Context(), node)
-			}
+		if nodes != nil && nodes[i] != nil {
+			doc.Metadata.Node = s.nodeToResponse(r.Context(), nodes[i])
 		}
 		docs = append(docs, doc)
 	}
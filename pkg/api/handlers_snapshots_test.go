@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// snapshotReq issues a request through the registered mux so path routing
+// (/admin/snapshots vs /admin/snapshots/{name}/restore) is exercised too.
+func snapshotReq(t *testing.T, server *Server, token, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, r)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	mux := http.NewServeMux()
+	server.registerRoutes(mux)
+	mux.ServeHTTP(rr, req)
+	return rr
+}
+
+func snapshotToken(t *testing.T, server *Server, role, username string) string {
+	t.Helper()
+	user, err := server.userStore.CreateUser(username, "Password123!", role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := server.jwtManager.GenerateToken(user.ID, user.Username, user.Role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// TestHandleSnapshots_CreateListRestore drives the full save/load workflow:
+// save a slot, mutate, list, restore, and the mutation is gone.
+func TestHandleSnapshots_CreateListRestore(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	token := snapshotToken(t, server, "admin", "admin-snapshot-user")
+
+	if _, err := server.graph.CreateNode([]string{"Person"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	rr := snapshotReq(t, server, token, http.MethodPost, "/admin/snapshots", `{"name":"scenario-1"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, want 201; body=%s", rr.Code, rr.Body.String())
+	}
+	if _, err := server.graph.CreateNode([]string{"Person"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	rr = snapshotReq(t, server, token, http.MethodGet, "/admin/snapshots", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("list status = %d, want 200; body=%s", rr.Code, rr.Body.String())
+	}
+	var list struct {
+		Snapshots []struct {
+			Name string `json:"name"`
+		} `json:"snapshots"`
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if list.Count != 1 || list.Snapshots[0].Name != "scenario-1" {
+		t.Fatalf("list = %+v, want [scenario-1]", list)
+	}
+
+	rr = snapshotReq(t, server, token, http.MethodPost, "/admin/snapshots/scenario-1/restore", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("restore status = %d, want 200; body=%s", rr.Code, rr.Body.String())
+	}
+	if got := server.graph.GetStatistics().NodeCount; got != 1 {
+		t.Errorf("NodeCount after restore = %d, want 1", got)
+	}
+}
+
+func TestHandleSnapshots_Errors(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	admin := snapshotToken(t, server, "admin", "admin-snapshot-errors")
+	viewer := snapshotToken(t, server, "viewer", "viewer-snapshot-errors")
+
+	cases := []struct {
+		name, token, method, path, body string
+		want                            int
+	}{
+		{"viewer forbidden", viewer, http.MethodGet, "/admin/snapshots", "", http.StatusForbidden},
+		{"invalid name", admin, http.MethodPost, "/admin/snapshots", `{"name":"../x"}`, http.StatusBadRequest},
+		{"bad body", admin, http.MethodPost, "/admin/snapshots", `{`, http.StatusBadRequest},
+		{"restore missing", admin, http.MethodPost, "/admin/snapshots/nope/restore", "", http.StatusNotFound},
+		{"restore wrong method", admin, http.MethodGet, "/admin/snapshots/nope/restore", "", http.StatusMethodNotAllowed},
+		{"unknown subpath", admin, http.MethodPost, "/admin/snapshots/nope", "", http.StatusNotFound},
+		{"collection wrong method", admin, http.MethodDelete, "/admin/snapshots", "", http.StatusMethodNotAllowed},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := snapshotReq(t, server, tc.token, tc.method, tc.path, tc.body)
+			if rr.Code != tc.want {
+				t.Errorf("status = %d, want %d; body=%s", rr.Code, tc.want, rr.Body.String())
+			}
+		})
+	}
+}
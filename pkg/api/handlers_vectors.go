@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/audit"
 	"github.com/dd0wney/graphdb/pkg/storage"
 	"github.com/dd0wney/graphdb/pkg/vector"
 )
@@ -205,6 +206,10 @@ func (s *Server) createVectorIndex(w http.ResponseWriter, r *http.Request) {
 		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "create vector index"))
 		return
 	}
+	s.auditMutation(r, audit.ActionCreate, audit.ResourceVectorIndex, req.PropertyName, nil, map[string]any{
+		"dimensions": req.Dimensions,
+		"metric":     metricToString(metric),
+	})
 
 	s.respondJSON(w, http.StatusCreated, VectorIndexResponse{
 		PropertyName: req.PropertyName,
@@ -251,6 +256,7 @@ func (s *Server) deleteVectorIndex(w http.ResponseWriter, r *http.Request) {
 		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "delete vector index"))
 		return
 	}
+	s.auditMutation(r, audit.ActionDelete, audit.ResourceVectorIndex, propertyName, map[string]any{"property_name": propertyName}, nil)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -343,13 +349,7 @@ func (s *Server) vectorSearch(w http.ResponseWriter, r *http.Request) {
 
 	// Lift the property predicate into storage.Value form once so the
 	// per-node check is a cheap byte comparison.
-	var propertyPredicate map[string]storage.Value
-	if len(req.PropertyFilter) > 0 {
-		propertyPredicate = make(map[string]storage.Value, len(req.PropertyFilter))
-		for k, v := range req.PropertyFilter {
-			propertyPredicate[k] = s.convertToValue(v)
-		}
-	}
+	propertyPredicate := s.propertyPredicate(req.PropertyFilter)
 
 	// Build response with proper filtering. Audit A4 clone-elision
 	// (2026-05-10): WithNodeRefForTenant validates tenant ownership and
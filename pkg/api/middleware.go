@@ -6,13 +6,22 @@ import (
 
 // Re-export types from middleware package for backward compatibility
 type (
+	AuthConfig      = middleware.AuthConfig
+	AuthzConfig     = middleware.AuthzConfig
 	CORSConfig      = middleware.CORSConfig
+	ErrorResponse   = middleware.ErrorResponse
 	RateLimitConfig = middleware.RateLimitConfig
 	RateLimiter     = middleware.RateLimiter
+
+	SecurityHeadersConfig = middleware.SecurityHeadersConfig
+	HSTSConfig            = middleware.HSTSConfig
+	BodyLimitConfig       = middleware.BodyLimitConfig
 )
 
 // Re-export functions from middleware package
 var (
+	DefaultAuthConfig      = middleware.DefaultAuthConfig
+	DefaultAuthzConfig     = middleware.DefaultAuthzConfig
 	DefaultCORSConfig      = middleware.DefaultCORSConfig
 	DefaultRateLimitConfig = middleware.DefaultRateLimitConfig
 	NewRateLimiter         = middleware.NewRateLimiter
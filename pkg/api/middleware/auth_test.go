@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/tenant"
+)
+
+func TestAuth(t *testing.T) {
+	cfg := DefaultAuthConfig()
+	cfg.APIKeys = map[string]Principal{"key-1": {Name: "ingest", TenantID: "acme"}}
+	cfg.BearerTokens = map[string]Principal{"tok-1": {Name: "dashboard"}}
+	cfg.PublicPaths = append(cfg.PublicPaths, "/docs/")
+
+	var got Principal
+	var gotTenant string
+	handler := Auth(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = GetPrincipal(r)
+		gotTenant = tenant.MustFromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name       string
+		path       string
+		header     string
+		value      string
+		wantStatus int
+		wantName   string
+		wantTenant string
+	}{
+		{"api key", "/nodes", "X-API-Key", "key-1", http.StatusOK, "ingest", "acme"},
+		{"bearer token", "/nodes", "Authorization", "Bearer tok-1", http.StatusOK, "dashboard", tenant.DefaultTenantID},
+		{"missing credentials", "/nodes", "", "", http.StatusUnauthorized, "", ""},
+		{"wrong api key", "/nodes", "X-API-Key", "key-2", http.StatusUnauthorized, "", ""},
+		{"api key sent as bearer", "/nodes", "Authorization", "Bearer key-1", http.StatusUnauthorized, "", ""},
+		{"non-bearer scheme", "/nodes", "Authorization", "Basic tok-1", http.StatusUnauthorized, "", ""},
+		{"public probe", "/livez", "", "", http.StatusOK, "", tenant.DefaultTenantID},
+		{"public prefix", "/docs/openapi.json", "", "", http.StatusOK, "", tenant.DefaultTenantID},
+		{"prefix entry is not exact", "/docs", "", "", http.StatusUnauthorized, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotTenant = Principal{}, ""
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+			if rr.Code == http.StatusUnauthorized && rr.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 without WWW-Authenticate header")
+			}
+			if got.Name != tt.wantName || gotTenant != tt.wantTenant {
+				t.Errorf("principal = %q tenant = %q, want %q / %q", got.Name, gotTenant, tt.wantName, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	handler := Authorize(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		name       string
+		role       string // empty = unauthenticated
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"viewer GET", "viewer", "GET", "/nodes", http.StatusOK},
+		{"viewer POST read path", "viewer", "POST", "/query", http.StatusOK},
+		{"viewer POST", "viewer", "POST", "/nodes", http.StatusForbidden},
+		{"viewer PUT", "viewer", "PUT", "/nodes/1", http.StatusForbidden},
+		{"viewer DELETE", "viewer", "DELETE", "/edges/1", http.StatusForbidden},
+		{"viewer snapshot", "viewer", "POST", "/admin/snapshots", http.StatusForbidden},
+		{"unknown role POST", "guest", "POST", "/nodes", http.StatusForbidden},
+		{"editor POST", "editor", "POST", "/nodes", http.StatusOK},
+		{"admin DELETE", "admin", "DELETE", "/nodes/1", http.StatusOK},
+		{"unauthenticated passes through", "", "POST", "/auth/login", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.role != "" {
+				req = req.WithContext(context.WithValue(req.Context(), PrincipalContextKey, Principal{Name: "p", Role: tt.role}))
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+		})
+	}
+}
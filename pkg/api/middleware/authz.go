@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+)
+
+// AuthzConfig holds the role rules Authorize enforces.
+type AuthzConfig struct {
+	// GetRole returns the caller's role and whether the request is
+	// authenticated at all. Defaults to the role of the Principal set by
+	// Auth.
+	GetRole func(*http.Request) (string, bool)
+
+	// WriteRoles may use any method. Defaults to admin and editor.
+	WriteRoles []string
+
+	// ReadPaths are POST endpoints that only read — queries, traversals,
+	// searches — and so stay open to read-only roles. Matched like
+	// AuthConfig.PublicPaths.
+	ReadPaths []string
+
+	// OnDenied writes the 403 response instead of the default plain-text
+	// one. Optional.
+	OnDenied func(w http.ResponseWriter, r *http.Request, role string)
+}
+
+// DefaultAuthzConfig returns an AuthzConfig granting writes to admins and
+// editors, with the server's read-only POST endpoints open to viewers.
+// The roles are auth.RoleAdmin and auth.RoleEditor, spelled out because
+// pkg/auth writes its errors through this package and cannot be imported.
+func DefaultAuthzConfig() *AuthzConfig {
+	return &AuthzConfig{
+		WriteRoles: []string{"admin", "editor"},
+		ReadPaths: []string{
+			"/query", "/graphql", "/traverse", "/shortest-path", "/algorithms",
+			"/vector-search", "/search", "/hybrid-search",
+			"/v1/embeddings", "/v1/retrieve",
+		},
+	}
+}
+
+// CanWrite reports whether role is one of config's WriteRoles. Handlers on
+// ReadPaths use it to refuse requests whose body asks for a write, such as
+// a Cypher CREATE or a GraphQL mutation.
+func (c *AuthzConfig) CanWrite(role string) bool {
+	return slices.Contains(c.WriteRoles, role)
+}
+
+// Authorize creates middleware that refuses requests a read-only role may
+// not make with 403 Forbidden. GET, HEAD and OPTIONS are always allowed, as
+// are POSTs to ReadPaths; every other method needs a WriteRole. Requests
+// with no role (public paths, or before authentication) pass through —
+// rejecting those is Auth's job.
+func Authorize(config *AuthzConfig) func(http.Handler) http.Handler {
+	if config == nil {
+		config = DefaultAuthzConfig()
+	}
+	getRole := config.GetRole
+	if getRole == nil {
+		getRole = func(r *http.Request) (string, bool) {
+			p, ok := GetPrincipal(r)
+			return p.Role, ok
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := getRole(r)
+			if !ok || config.CanWrite(role) || isReadRequest(config, r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if config.OnDenied != nil {
+				config.OnDenied(w, r, role)
+				return
+			}
+			WriteError(w, r, http.StatusForbidden, "Forbidden: role "+role+" is read-only")
+		})
+	}
+}
+
+// isReadRequest reports whether r cannot modify the graph
+func isReadRequest(config *AuthzConfig, r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return isPublicPath(config.ReadPaths, r.URL.Path)
+	}
+	return false
+}
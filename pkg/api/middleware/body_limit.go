@@ -1,27 +1,170 @@
 package middleware
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 )
 
+// Default request body caps. The auth cap is tight because /auth/*
+// payloads are small JSON and are read before the caller is authenticated;
+// the import cap leaves room for bulk loads through the batch endpoints.
+const (
+	DefaultBodyLimit       = 10 * 1024 * 1024  // 10 MiB
+	DefaultAuthBodyLimit   = 64 * 1024         // 64 KiB
+	DefaultImportBodyLimit = 100 * 1024 * 1024 // 100 MiB
+)
+
+// BodyLimitConfig sets the maximum request body size, overall and per
+// route.
+type BodyLimitConfig struct {
+	// Default applies to any path without a Routes entry.
+	Default int64
+
+	// Routes maps path prefixes to their own cap. The longest matching
+	// prefix wins, so "/nodes/batch" can allow more than "/nodes".
+	Routes map[string]int64
+}
+
+// DefaultBodyLimitConfig returns the caps the API server uses:
+// DefaultBodyLimit in general, DefaultAuthBodyLimit under /auth/, and
+// DefaultImportBodyLimit for the bulk import routes.
+func DefaultBodyLimitConfig() *BodyLimitConfig {
+	return &BodyLimitConfig{
+		Default: DefaultBodyLimit,
+		Routes: map[string]int64{
+			"/auth/":       DefaultAuthBodyLimit,
+			"/import":      DefaultImportBodyLimit,
+			"/nodes/batch": DefaultImportBodyLimit,
+			"/edges/batch": DefaultImportBodyLimit,
+		},
+	}
+}
+
+// Limit returns the cap for a request path.
+func (c *BodyLimitConfig) Limit(path string) int64 {
+	limit, matched := c.Default, ""
+	for prefix, routeLimit := range c.Routes {
+		if len(prefix) > len(matched) && strings.HasPrefix(path, prefix) {
+			limit, matched = routeLimit, prefix
+		}
+	}
+	return limit
+}
+
+// MaxLimit returns the largest cap any path is allowed, so inner layers
+// that buffer the body can size themselves to it.
+func (c *BodyLimitConfig) MaxLimit() int64 {
+	limit := c.Default
+	for _, routeLimit := range c.Routes {
+		limit = max(limit, routeLimit)
+	}
+	return limit
+}
+
 // BodySizeLimit creates middleware that limits the size of incoming request bodies
 // to prevent denial-of-service attacks via large payloads.
 // The maxBytes parameter specifies the maximum allowed size in bytes.
 func BodySizeLimit(maxBytes int64) func(http.Handler) http.Handler {
+	return BodyLimit(&BodyLimitConfig{Default: maxBytes})
+}
+
+// BodyLimit creates middleware that caps request bodies per route (see
+// BodyLimitConfig). A nil config applies DefaultBodyLimitConfig.
+//
+// An oversized body is answered with 413 and the JSON error envelope:
+// up front when Content-Length already exceeds the cap, otherwise once a
+// handler's read trips the cap. In the second case any 4xx the handler
+// writes in response (typically a 400 from a truncated JSON decode) is
+// replaced with the 413, so clients see why the request failed.
+func BodyLimit(config *BodyLimitConfig) func(http.Handler) http.Handler {
+	if config == nil {
+		config = DefaultBodyLimitConfig()
+	}
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := config.Limit(r.URL.Path)
+
 			// Check Content-Length header if present
 			// This allows us to reject large requests before reading the body
-			if r.ContentLength > maxBytes {
-				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			if r.ContentLength > limit {
+				WriteError(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
 				return
 			}
 
 			// Also set MaxBytesReader as a safety net in case Content-Length is not set
 			// or is incorrect (this handles chunked transfer encoding)
-			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			body := &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, limit)}
+			r.Body = body
 
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(&bodyLimitResponseWriter{ResponseWriter: w, r: r, body: body}, r)
 		})
 	}
 }
+
+// IsBodyTooLarge reports whether err came from reading past a body cap.
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// limitedBody records whether a read hit the body cap.
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && IsBodyTooLarge(err) {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// bodyLimitResponseWriter turns a handler's client-error response into the
+// 413 envelope once the body cap has been hit.
+type bodyLimitResponseWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	body        *limitedBody
+	replaced    bool
+	wroteHeader bool
+}
+
+func (w *bodyLimitResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if w.body.exceeded && statusCode >= 400 && statusCode < 500 {
+		w.replaced = true
+		WriteError(w.ResponseWriter, w.r, http.StatusRequestEntityTooLarge, "Request body too large")
+		return
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *bodyLimitResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.replaced {
+		// The handler's own error body is dropped in favor of the 413.
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush passes through so streaming handlers work behind the body limit
+func (w *bodyLimitResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *bodyLimitResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
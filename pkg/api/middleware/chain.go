@@ -0,0 +1,29 @@
+package middleware
+
+import "net/http"
+
+// Chain composes middleware into one, applied in the order listed: the
+// first is outermost and sees the request first, the last wraps the
+// handler directly. Chain(a, b, c)(h) is a(b(c(h))).
+//
+// Order matters. The recommended order, outermost first, is
+//
+//	PanicRecovery   so a panic anywhere below becomes a 500 envelope
+//	RequestID       so everything after it can log and report the ID
+//	Logging         so rejected requests are logged too
+//	CORS            so preflights are answered before auth demands credentials
+//	Auth, Authorize so unauthenticated requests stop here
+//	RateLimit       keyed on the authenticated caller where auth provides one
+//	BodyLimit       so no handler reads an unbounded body
+//
+// followed by the handler. The API server departs from this in documented
+// places (see api.Server.Start): it rate-limits before auth, per client IP,
+// and authenticates per route rather than in the chain.
+func Chain(mws ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		for i := len(mws) - 1; i >= 0; i-- {
+			next = mws[i](next)
+		}
+		return next
+	}
+}
@@ -0,0 +1,240 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CompressConfig configures response compression
+type CompressConfig struct {
+	MinSize int // Responses smaller than this many bytes are sent uncompressed
+	Level   int // gzip level (gzip.BestSpeed..gzip.BestCompression)
+
+	// SkipContentTypes lists media types (or "type/" prefixes) that are
+	// already compressed and would only grow if gzipped again.
+	SkipContentTypes []string
+}
+
+// DefaultCompressConfig returns sensible defaults for response compression
+func DefaultCompressConfig() *CompressConfig {
+	return &CompressConfig{
+		MinSize: 1024, // Below ~1 KiB the gzip header/CPU isn't worth it
+		Level:   gzip.DefaultCompression,
+		SkipContentTypes: []string{
+			"image/", "video/", "audio/", "font/woff",
+			"application/gzip", "application/x-gzip", "application/zip",
+			"application/zstd", "application/x-bzip2", "application/x-xz",
+			"application/x-7z-compressed",
+			// Streaming responses are flushed incrementally; buffering them
+			// for compression would defeat the point.
+			"text/event-stream",
+		},
+	}
+}
+
+// Compress creates middleware that gzips response bodies for clients sending
+// "Accept-Encoding: gzip". Output is buffered until MinSize bytes have been
+// written; smaller responses, already-encoded responses, and skipped content
+// types pass through unchanged. Vary: Accept-Encoding is always set so caches
+// keep the two representations apart.
+//
+// A handler that calls Flush before MinSize is reached opts out of
+// compression for that response, so streaming endpoints keep working.
+func Compress(config *CompressConfig) func(http.Handler) http.Handler {
+	if config == nil {
+		config = DefaultCompressConfig()
+	}
+	pool := &sync.Pool{
+		New: func() any {
+			gz, err := gzip.NewWriterLevel(nil, config.Level)
+			if err != nil {
+				gz = gzip.NewWriter(nil)
+			}
+			return gz
+		},
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if r.Method == http.MethodHead || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressResponseWriter{ResponseWriter: w, config: config, pool: pool}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip, i.e.
+// lists "gzip" or "*" without q=0.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compressResponseWriter buffers the start of a response until it can decide
+// whether to compress, then either streams through a gzip.Writer or writes
+// the buffered bytes out as-is.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	config *CompressConfig
+	pool   *sync.Pool
+
+	status  int
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer
+}
+
+func (w *compressResponseWriter) WriteHeader(statusCode int) {
+	if w.decided || w.status != 0 {
+		if w.decided {
+			w.ResponseWriter.WriteHeader(statusCode)
+		}
+		return
+	}
+	// 1xx informational headers go straight out; they don't start the body.
+	if statusCode >= 100 && statusCode < 200 {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	w.status = statusCode
+}
+
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.gz != nil {
+			return w.gz.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) < w.config.MinSize {
+		return len(b), nil
+	}
+	if err := w.decide(true); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Flush sends buffered bytes immediately. If no decision has been made yet
+// the response is committed uncompressed.
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack hands the connection to the handler (e.g. WebSocket upgrades). No
+// compression applies to a hijacked connection.
+func (w *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("compress: underlying ResponseWriter does not support hijacking")
+	}
+	w.decided = true
+	return h.Hijack()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide commits the response headers and flushes the buffer, compressing
+// if allowed is true and the response is eligible.
+func (w *compressResponseWriter) decide(allowed bool) error {
+	w.decided = true
+	h := w.Header()
+	if allowed && w.eligible() {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		gz := w.pool.Get().(*gzip.Writer)
+		gz.Reset(w.ResponseWriter)
+		w.gz = gz
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.gz != nil {
+		_, err := w.gz.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// eligible reports whether the committed response may be gzipped.
+func (w *compressResponseWriter) eligible() bool {
+	switch w.status {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		ct = http.DetectContentType(w.buf)
+		h.Set("Content-Type", ct)
+	}
+	ct = strings.ToLower(ct)
+	for _, skip := range w.config.SkipContentTypes {
+		if strings.HasPrefix(ct, skip) {
+			return false
+		}
+	}
+	return true
+}
+
+// close finishes the response after the handler returns: small responses are
+// written out uncompressed, and an active gzip stream is terminated.
+func (w *compressResponseWriter) close() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.gz.Reset(nil)
+		w.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip;q=0.8", true},
+		{"GZIP", true},
+		{"*", true},
+		{"gzip;q=0", false},
+		{"br, deflate", false},
+	}
+	for _, tt := range tests {
+		if got := acceptsGzip(tt.header); got != tt.want {
+			t.Errorf("acceptsGzip(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestCompress(t *testing.T) {
+	large := `[` + strings.Repeat(`{"id":1,"labels":["Node"]},`, 200) + `{}]`
+
+	serve := func(acceptEncoding, contentType, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		handler := Compress(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, body)
+		}))
+		req := httptest.NewRequest("GET", "/nodes", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("large JSON is gzipped", func(t *testing.T) {
+		rr := serve("gzip", "application/json", large)
+		if rr.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("Content-Encoding = %q, want gzip", rr.Header().Get("Content-Encoding"))
+		}
+		if rr.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("Vary = %q, want Accept-Encoding", rr.Header().Get("Vary"))
+		}
+		if rr.Body.Len() >= len(large) {
+			t.Errorf("compressed size %d not smaller than %d", rr.Body.Len(), len(large))
+		}
+		gz, err := gzip.NewReader(rr.Body)
+		if err != nil {
+			t.Fatalf("gzip.NewReader: %v", err)
+		}
+		got, err := io.ReadAll(gz)
+		if err != nil {
+			t.Fatalf("read gzip body: %v", err)
+		}
+		if string(got) != large {
+			t.Error("decompressed body does not match original")
+		}
+	})
+
+	t.Run("small response passes through", func(t *testing.T) {
+		rr := serve("gzip", "application/json", `{"ok":true}`)
+		if rr.Header().Get("Content-Encoding") != "" {
+			t.Errorf("small response compressed: Content-Encoding = %q", rr.Header().Get("Content-Encoding"))
+		}
+		if rr.Body.String() != `{"ok":true}` {
+			t.Errorf("body = %q", rr.Body.String())
+		}
+	})
+
+	t.Run("client without gzip", func(t *testing.T) {
+		rr := serve("", "application/json", large)
+		if rr.Header().Get("Content-Encoding") != "" || rr.Body.String() != large {
+			t.Error("response compressed for client that did not accept gzip")
+		}
+		if rr.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("Vary = %q, want Accept-Encoding", rr.Header().Get("Vary"))
+		}
+	})
+
+	t.Run("already-compressed content type skipped", func(t *testing.T) {
+		rr := serve("gzip", "application/gzip", large)
+		if rr.Header().Get("Content-Encoding") != "" || rr.Body.String() != large {
+			t.Error("application/gzip response was compressed again")
+		}
+	})
+}
+
+func TestCompress_FlushDisablesCompression(t *testing.T) {
+	handler := Compress(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "event 1\n")
+		w.(http.Flusher).Flush()
+		io.WriteString(w, strings.Repeat("x", 4096))
+	}))
+
+	req := httptest.NewRequest("GET", "/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if !rr.Flushed {
+		t.Error("Flush was not passed through")
+	}
+	if rr.Header().Get("Content-Encoding") != "" {
+		t.Error("flushed response should not be compressed")
+	}
+	if !strings.HasPrefix(rr.Body.String(), "event 1\n") || rr.Body.Len() != 8+4096 {
+		t.Errorf("unexpected body (len %d)", rr.Body.Len())
+	}
+}
@@ -20,7 +20,7 @@ func DefaultCORSConfig() *CORSConfig {
 	return &CORSConfig{
 		AllowedOrigins:   []string{}, // Empty = no CORS (most secure default)
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-API-Key", "X-Request-ID"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-API-Key", "X-Request-ID", "If-Match", "If-None-Match"},
 		AllowCredentials: false,
 		MaxAge:           86400, // 24 hours
 	}
@@ -58,7 +58,7 @@ func CORS(config *CORSConfig) func(http.Handler) http.Handler {
 				w.Header().Set("Vary", "Origin") // Important for caching
 
 				methods := "GET, POST, PUT, DELETE, OPTIONS"
-				headers := "Content-Type, Authorization, X-API-Key, X-Request-ID"
+				headers := "Content-Type, Authorization, X-API-Key, X-Request-ID, If-Match, If-None-Match"
 				if config != nil {
 					if len(config.AllowedMethods) > 0 {
 						methods = strings.Join(config.AllowedMethods, ", ")
@@ -75,6 +75,9 @@ func CORS(config *CORSConfig) func(http.Handler) http.Handler {
 				}
 				w.Header().Set("Access-Control-Allow-Methods", methods)
 				w.Header().Set("Access-Control-Allow-Headers", headers)
+				// Without this, browser scripts can't read the ETag they
+				// need for If-Match / If-None-Match.
+				w.Header().Set("Access-Control-Expose-Headers", "ETag")
 			}
 
 			// Handle preflight OPTIONS request
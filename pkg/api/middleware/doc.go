@@ -11,20 +11,28 @@
 //   - ratelimit.go: Rate limiting middleware with token bucket algorithm
 //   - input_validation.go: Input validation and sanitization middleware
 //   - metrics.go: HTTP metrics collection middleware
+//   - compress.go: gzip response compression middleware
+//   - auth.go: Static API key / bearer token authentication middleware
+//   - authz.go: Role-based authorization (read-only vs read-write) middleware
+//   - errors.go: The JSON error envelope ([WriteError]) every rejection uses
+//   - chain.go: [Chain], which composes middleware in a readable order
 //
-// All middleware follows the standard pattern: func(http.Handler) http.Handler
-// This allows easy chaining: handler = middleware1(middleware2(handler))
+// All middleware follows the standard pattern: func(http.Handler) http.Handler.
+// Compose them with [Chain], outermost first; its doc comment gives the
+// recommended order.
 //
 // Example usage:
 //
 //	mux := http.NewServeMux()
 //	// ... register handlers ...
 //
-//	// Apply middleware chain
-//	handler := middleware.PanicRecovery()(mux)
-//	handler = middleware.RequestID()(handler)
-//	handler = middleware.Logging(middleware.GetRequestID)(handler)
-//	handler = middleware.CORS(middleware.DefaultCORSConfig())(handler)
+//	handler := middleware.Chain(
+//		middleware.PanicRecovery(),
+//		middleware.RequestID(),
+//		middleware.Logging(middleware.GetRequestID),
+//		middleware.CORS(middleware.DefaultCORSConfig()),
+//		middleware.BodyLimit(nil),
+//	)(mux)
 //
 //	http.ListenAndServe(":8080", handler)
 package middleware
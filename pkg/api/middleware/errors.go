@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// ErrorResponse is the JSON envelope every API error is written in, by the
+// middleware here and by the handlers behind it:
+//
+//	{"error": "node 7 not found", "code": "NOT_FOUND", "status": 404, "request_id": "..."}
+//
+// Error is the human-readable message, Code a stable machine-readable class
+// derived from the status, and RequestID the X-Request-ID the request was
+// logged under. Field, when set, names the request field that failed
+// validation.
+type ErrorResponse struct {
+	Error     string `json:"error"`
+	Code      string `json:"code"`
+	Status    int    `json:"status"`
+	RequestID string `json:"request_id,omitempty"`
+	Field     string `json:"field,omitempty"`
+}
+
+// errorCodes names the statuses clients commonly branch on. Others are
+// derived from the status text (e.g. 405 -> METHOD_NOT_ALLOWED).
+var errorCodes = map[int]string{
+	http.StatusBadRequest:            "INVALID_REQUEST",
+	http.StatusUnauthorized:          "UNAUTHORIZED",
+	http.StatusForbidden:             "FORBIDDEN",
+	http.StatusNotFound:              "NOT_FOUND",
+	http.StatusRequestTimeout:        "TIMEOUT",
+	http.StatusConflict:              "CONFLICT",
+	http.StatusRequestEntityTooLarge: "PAYLOAD_TOO_LARGE",
+	http.StatusUnprocessableEntity:   "CONSTRAINT_VIOLATION",
+	http.StatusTooManyRequests:       "RATE_LIMIT_EXCEEDED",
+	http.StatusInternalServerError:   "INTERNAL",
+	http.StatusServiceUnavailable:    "UNAVAILABLE",
+}
+
+// ErrorCode returns the envelope code for an HTTP status.
+func ErrorCode(status int) string {
+	if code, ok := errorCodes[status]; ok {
+		return code
+	}
+	text := http.StatusText(status)
+	if text == "" {
+		return "ERROR"
+	}
+	text = strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text)
+	return strings.ToUpper(text)
+}
+
+// NewErrorResponse builds the envelope for status and message. The request
+// ID is read from r's context or, failing that, the X-Request-ID response
+// header the RequestID middleware sets; r may be nil.
+func NewErrorResponse(w http.ResponseWriter, r *http.Request, status int, message string) ErrorResponse {
+	requestID := ""
+	if r != nil {
+		requestID = GetRequestID(r)
+	}
+	if requestID == "" {
+		requestID = w.Header().Get(RequestIDHeader)
+	}
+	return ErrorResponse{
+		Error:     message,
+		Code:      ErrorCode(status),
+		Status:    status,
+		RequestID: requestID,
+	}
+}
+
+// WriteError writes the JSON error envelope. Use it in place of http.Error,
+// which answers in plain text.
+func WriteError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(NewErrorResponse(w, r, status, message)); err != nil {
+		log.Printf("Error encoding error response: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := map[int]string{
+		http.StatusBadRequest:            "INVALID_REQUEST",
+		http.StatusNotFound:              "NOT_FOUND",
+		http.StatusConflict:              "CONFLICT",
+		http.StatusMethodNotAllowed:      "METHOD_NOT_ALLOWED",
+		http.StatusNotImplemented:        "NOT_IMPLEMENTED",
+		http.StatusRequestEntityTooLarge: "PAYLOAD_TOO_LARGE",
+		799:                              "ERROR",
+	}
+	for status, want := range tests {
+		if got := ErrorCode(status); got != want {
+			t.Errorf("ErrorCode(%d) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+// TestWriteError_Envelope checks that middleware rejections come back as
+// the JSON envelope, tagged with the request's ID.
+func TestWriteError_Envelope(t *testing.T) {
+	handler := RequestID()(BodySizeLimit(10)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler ran for an oversized body")
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/nodes", nil)
+	req.ContentLength = 100
+	req.Header.Set(RequestIDHeader, "req-abc")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var body ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %q: %v", rr.Body.String(), err)
+	}
+	want := ErrorResponse{
+		Error:     "Request body too large",
+		Code:      "PAYLOAD_TOO_LARGE",
+		Status:    http.StatusRequestEntityTooLarge,
+		RequestID: "req-abc",
+	}
+	if rr.Code != want.Status || body != want {
+		t.Errorf("got %d %+v, want %+v", rr.Code, body, want)
+	}
+}
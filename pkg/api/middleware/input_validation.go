@@ -1,12 +1,20 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
 	"strings"
+	"unicode"
 
 	"github.com/dd0wney/graphdb/pkg/security"
+	"github.com/dd0wney/graphdb/pkg/validation"
+	"golang.org/x/text/unicode/norm"
 )
 
 // InputValidationConfig configures input validation middleware
@@ -14,6 +22,14 @@ type InputValidationConfig struct {
 	SkipPaths   []string // Paths to skip validation for
 	MaxBodySize int      // Maximum body size to validate (default: 10MB)
 	ValidateAll bool     // If true, validate all methods (not just POST/PUT/PATCH)
+
+	// GraphPayloadPaths are path prefixes whose write bodies are node or
+	// edge JSON (single, or a batch under "nodes"/"edges"). Their bodies
+	// are checked and normalized by the payload rules below even when
+	// SkipPaths exempts them from the security scan.
+	GraphPayloadPaths []string
+	MaxLabels         int // Labels per node (default: validation.MaxLabels)
+	MaxProperties     int // Properties per node or edge (default: validation.MaxProperties)
 }
 
 // DefaultInputValidationConfig returns default configuration
@@ -27,36 +43,61 @@ func DefaultInputValidationConfig() *InputValidationConfig {
 			"/edges",      // same
 			"/algorithms", // algorithm payloads contain node properties
 		},
-		MaxBodySize: 10 * 1024 * 1024, // 10MB
-		ValidateAll: false,
+		MaxBodySize:       10 * 1024 * 1024, // 10MB
+		ValidateAll:       false,
+		GraphPayloadPaths: []string{"/nodes", "/edges"},
+		MaxLabels:         validation.MaxLabels,
+		MaxProperties:     validation.MaxProperties,
 	}
 }
 
 // InputValidation creates middleware that validates input for security issues.
 // This protects against injection attacks, XSS, path traversal, etc.
+//
+// Writes to GraphPayloadPaths are additionally held to the payload rules
+// before any handler runs:
+//
+//   - a Content-Type other than JSON is a 415, and a body that isn't JSON
+//     a 400;
+//   - more than MaxLabels labels or MaxProperties properties, or a label,
+//     edge type or property key containing control or bidi-override
+//     characters, is a 422 naming the field (e.g. "nodes[2].labels");
+//   - strings are NFC-normalized, and control characters other than tab
+//     and newline are stripped from string property values, so visually
+//     identical input is stored identically.
+//
+// Handlers still run their own semantic checks (label syntax, required
+// fields); this layer rejects malformed payloads before they're decoded.
 func InputValidation(config *InputValidationConfig) func(http.Handler) http.Handler {
 	if config == nil {
 		config = DefaultInputValidationConfig()
 	}
+	limits := payloadLimits{maxLabels: config.MaxLabels, maxProperties: config.MaxProperties}
+	if limits.maxLabels <= 0 {
+		limits.maxLabels = validation.MaxLabels
+	}
+	if limits.maxProperties <= 0 {
+		limits.maxProperties = validation.MaxProperties
+	}
 
 	validator := security.NewInputValidator()
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			isWrite := r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch
+			graphPayload := isWrite && hasPathPrefix(r.URL.Path, config.GraphPayloadPaths)
+
 			// Skip validation for certain paths
-			for _, path := range config.SkipPaths {
-				if strings.HasPrefix(r.URL.Path, path) {
-					next.ServeHTTP(w, r)
-					return
-				}
+			skipScan := hasPathPrefix(r.URL.Path, config.SkipPaths)
+			if skipScan && !graphPayload {
+				next.ServeHTTP(w, r)
+				return
 			}
 
 			// Only validate POST/PUT/PATCH requests with bodies (unless ValidateAll is true)
-			if !config.ValidateAll {
-				if r.Method != http.MethodPost && r.Method != http.MethodPut && r.Method != http.MethodPatch {
-					next.ServeHTTP(w, r)
-					return
-				}
+			if !config.ValidateAll && !isWrite {
+				next.ServeHTTP(w, r)
+				return
 			}
 
 			// Enforce body size limit BEFORE reading to prevent DoS
@@ -67,42 +108,272 @@ func InputValidation(config *InputValidationConfig) func(http.Handler) http.Hand
 			body, err := io.ReadAll(r.Body)
 			if err != nil {
 				// MaxBytesReader returns a specific error type for oversized bodies
-				if err.Error() == "http: request body too large" {
-					http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+				if IsBodyTooLarge(err) {
+					WriteError(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
 					return
 				}
-				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				WriteError(w, r, http.StatusBadRequest, "Failed to read request body")
 				return
 			}
 			defer func() { _ = r.Body.Close() }()
 
-			// Convert to string for validation
-			bodyStr := string(body)
-
 			// Skip validation for empty bodies
-			if len(bodyStr) == 0 {
+			if len(body) == 0 {
+				r.Body = io.NopCloser(bytes.NewReader(body))
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			// Validate for path traversal (most dangerous)
-			if err := validator.ValidateNoPathTraversal(bodyStr); err != nil {
-				log.Printf("Path traversal attempt detected: %v", err)
-				http.Error(w, "Invalid input: potential security threat detected", http.StatusBadRequest)
-				return
+			if graphPayload {
+				if !isJSONContentType(r.Header.Get("Content-Type")) {
+					WriteError(w, r, http.StatusUnsupportedMediaType, "Request body must be application/json")
+					return
+				}
+				normalized, err := normalizeGraphPayload(body, limits)
+				if err != nil {
+					var fieldErr *payloadFieldError
+					if errors.As(err, &fieldErr) {
+						writeFieldError(w, r, fieldErr)
+						return
+					}
+					WriteError(w, r, http.StatusBadRequest, "Request body is not valid JSON")
+					return
+				}
+				body = normalized
 			}
 
-			// Validate maximum length
-			if err := validator.ValidateString(bodyStr, config.MaxBodySize); err != nil {
-				log.Printf("Input validation failed: %v", err)
-				http.Error(w, "Invalid input: request too large", http.StatusBadRequest)
-				return
+			if !skipScan {
+				bodyStr := string(body)
+
+				// Validate for path traversal (most dangerous)
+				if err := validator.ValidateNoPathTraversal(bodyStr); err != nil {
+					log.Printf("Path traversal attempt detected: %v", err)
+					WriteError(w, r, http.StatusBadRequest, "Invalid input: potential security threat detected")
+					return
+				}
+
+				// Validate maximum length
+				if err := validator.ValidateString(bodyStr, config.MaxBodySize); err != nil {
+					log.Printf("Input validation failed: %v", err)
+					WriteError(w, r, http.StatusBadRequest, "Invalid input: request too large")
+					return
+				}
 			}
 
 			// Restore body for next handler
-			r.Body = io.NopCloser(strings.NewReader(bodyStr))
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJSONContentType accepts an absent Content-Type (many clients omit it)
+// and any application/json or +json media type.
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+type payloadLimits struct {
+	maxLabels     int
+	maxProperties int
+}
+
+// payloadFieldError names the payload field that broke a rule.
+type payloadFieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *payloadFieldError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// writeFieldError writes a 422 envelope carrying the failed field.
+func writeFieldError(w http.ResponseWriter, r *http.Request, err *payloadFieldError) {
+	resp := NewErrorResponse(w, r, http.StatusUnprocessableEntity, err.Error())
+	resp.Field = err.Field
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	if encErr := json.NewEncoder(w).Encode(resp); encErr != nil {
+		log.Printf("Error encoding error response: %v", encErr)
+	}
+}
+
+// normalizeGraphPayload checks a node/edge payload against limits and
+// returns it with strings normalized. The body is re-encoded only when
+// normalization changed something; numbers keep their original text.
+func normalizeGraphPayload(body []byte, limits payloadLimits) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var payload any
+	if err := dec.Decode(&payload); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("trailing data after JSON value")
+	}
+
+	obj, ok := payload.(map[string]any)
+	if !ok {
+		// Not an object: leave the shape to the handler to reject.
+		return body, nil
+	}
+	changed, err := normalizeEntity(obj, "", limits)
+	if err != nil {
+		return nil, err
+	}
+	for _, batchKey := range []string{"nodes", "edges"} {
+		items, ok := obj[batchKey].([]any)
+		if !ok {
+			continue
+		}
+		for i, item := range items {
+			entity, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			c, err := normalizeEntity(entity, fmt.Sprintf("%s[%d].", batchKey, i), limits)
+			if err != nil {
+				return nil, err
+			}
+			changed = changed || c
+		}
+	}
+	if !changed {
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(obj); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// normalizeEntity applies the payload rules to one node or edge object.
+// prefix is the field path to it ("" at the top level).
+func normalizeEntity(entity map[string]any, prefix string, limits payloadLimits) (bool, error) {
+	changed := false
+
+	if labels, ok := entity["labels"].([]any); ok {
+		if len(labels) > limits.maxLabels {
+			return false, &payloadFieldError{prefix + "labels", fmt.Sprintf("at most %d labels allowed, got %d", limits.maxLabels, len(labels))}
+		}
+		for i, l := range labels {
+			label, ok := l.(string)
+			if !ok {
+				continue
+			}
+			if hasUnsafeRunes(label) {
+				return false, &payloadFieldError{fmt.Sprintf("%slabels[%d]", prefix, i), "contains control characters"}
+			}
+			if n := norm.NFC.String(label); n != label {
+				labels[i] = n
+				changed = true
+			}
+		}
+	}
+
+	if edgeType, ok := entity["type"].(string); ok {
+		if hasUnsafeRunes(edgeType) {
+			return false, &payloadFieldError{prefix + "type", "contains control characters"}
+		}
+		if n := norm.NFC.String(edgeType); n != edgeType {
+			entity["type"] = n
+			changed = true
+		}
+	}
+
+	props, ok := entity["properties"].(map[string]any)
+	if !ok {
+		return changed, nil
+	}
+	if len(props) > limits.maxProperties {
+		return false, &payloadFieldError{prefix + "properties", fmt.Sprintf("at most %d properties allowed, got %d", limits.maxProperties, len(props))}
+	}
+	for key, value := range props {
+		if hasUnsafeRunes(key) {
+			return false, &payloadFieldError{fmt.Sprintf("%sproperties[%q]", prefix, key), "property key contains control characters"}
+		}
+		newValue, valueChanged := normalizeValue(value)
+		newKey := norm.NFC.String(key)
+		if newKey != key {
+			if _, clash := props[newKey]; clash {
+				return false, &payloadFieldError{fmt.Sprintf("%sproperties[%q]", prefix, key), "property key duplicates another after unicode normalization"}
+			}
+			delete(props, key)
+			changed = true
+		}
+		if newKey != key || valueChanged {
+			props[newKey] = newValue
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// normalizeValue NFC-normalizes the strings in a property value, nested
+// ones included, and strips control characters other than tab, newline
+// and carriage return.
+func normalizeValue(v any) (any, bool) {
+	switch val := v.(type) {
+	case string:
+		n := norm.NFC.String(strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+				return -1
+			}
+			return r
+		}, val))
+		return n, n != val
+	case []any:
+		changed := false
+		for i, item := range val {
+			n, c := normalizeValue(item)
+			val[i] = n
+			changed = changed || c
+		}
+		return val, changed
+	case map[string]any:
+		changed := false
+		for k, item := range val {
+			n, c := normalizeValue(item)
+			val[k] = n
+			changed = changed || c
+		}
+		return val, changed
+	default:
+		return v, false
+	}
+}
+
+// hasUnsafeRunes reports control characters and the bidi overrides and
+// isolates that can make a name render differently from its bytes.
+func hasUnsafeRunes(s string) bool {
+	for _, r := range s {
+		if unicode.IsControl(r) || (r >= '\u202A' && r <= '\u202E') || (r >= '\u2066' && r <= '\u2069') {
+			return true
+		}
+	}
+	return false
+}
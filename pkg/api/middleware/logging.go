@@ -1,29 +1,174 @@
 package middleware
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"time"
 )
 
+// LogFormat selects the access-log line format
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text" // Human-readable line via the standard logger
+	LogFormatJSON LogFormat = "json" // One JSON object per request, for log pipelines
+)
+
+// LoggingConfig configures request logging
+type LoggingConfig struct {
+	Format LogFormat // Defaults to LogFormatText
+
+	// GetRequestID returns the correlation ID for a request (typically
+	// GetRequestID). Optional.
+	GetRequestID func(*http.Request) string
+
+	// GetTenant returns the tenant a request was served for. It is called
+	// after the handler returns, so it may read state that inner middleware
+	// filled in. Optional; JSON format only.
+	GetTenant func(*http.Request) string
+
+	// Output receives JSON lines. Defaults to the standard logger's writer.
+	Output io.Writer
+}
+
+// accessLogEntry is the JSON access-log record
+type accessLogEntry struct {
+	Time       string  `json:"time"`
+	Method     string  `json:"method"`
+	Path       string  `json:"path"`
+	Status     int     `json:"status"`
+	Bytes      int64   `json:"bytes"`
+	DurationMs float64 `json:"duration_ms"`
+	RequestID  string  `json:"request_id,omitempty"`
+	Tenant     string  `json:"tenant,omitempty"`
+}
+
+// responseRecorder wraps http.ResponseWriter to capture the status code and
+// number of body bytes written
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *responseRecorder) WriteHeader(statusCode int) {
+	// 1xx informational headers precede the real status; don't record them.
+	if w.status == 0 && statusCode >= 200 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Flush passes through to the underlying writer so streaming handlers still
+// work behind the logger.
+func (w *responseRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack passes through to the underlying writer so WebSocket upgrades work
+// behind the logger. Bytes written on a hijacked connection are not counted.
+func (w *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("logging: underlying ResponseWriter does not support hijacking")
+	}
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return h.Hijack()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Status returns the response status, defaulting to 200 if the handler never
+// wrote a header.
+func (w *responseRecorder) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
 // Logging creates middleware that logs HTTP requests with timing information.
 // It uses the request ID from context if available.
 func Logging(getRequestID func(*http.Request) string) func(http.Handler) http.Handler {
+	return LoggingWithConfig(&LoggingConfig{
+		Format:       LogFormatText,
+		GetRequestID: getRequestID,
+	})
+}
+
+// LoggingWithConfig creates request-logging middleware with a selectable
+// output format. Both formats include the response status and body size; in
+// JSON mode each request produces one object with method, path, status,
+// bytes, duration_ms, request_id and tenant.
+func LoggingWithConfig(config *LoggingConfig) func(http.Handler) http.Handler {
+	if config == nil {
+		config = &LoggingConfig{}
+	}
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
-			next.ServeHTTP(w, r)
 
-			// Include request ID in logs for tracing
-			requestID := ""
-			if getRequestID != nil {
-				requestID = getRequestID(r)
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			if config.Format != LogFormatJSON {
+				// Include request ID in logs for tracing
+				requestID := ""
+				if config.GetRequestID != nil {
+					requestID = config.GetRequestID(r)
+				}
+
+				if requestID != "" {
+					log.Printf("[%s] %s %s %d %dB %v", requestID, r.Method, r.URL.Path, rec.Status(), rec.bytes, time.Since(start))
+				} else {
+					log.Printf("%s %s %d %dB %v", r.Method, r.URL.Path, rec.Status(), rec.bytes, time.Since(start))
+				}
+				return
 			}
 
-			if requestID != "" {
-				log.Printf("[%s] %s %s %v", requestID, r.Method, r.URL.Path, time.Since(start))
-			} else {
-				log.Printf("%s %s %v", r.Method, r.URL.Path, time.Since(start))
+			entry := accessLogEntry{
+				Time:       start.UTC().Format(time.RFC3339Nano),
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				Status:     rec.Status(),
+				Bytes:      rec.bytes,
+				DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if config.GetRequestID != nil {
+				entry.RequestID = config.GetRequestID(r)
+			}
+			if config.GetTenant != nil {
+				entry.Tenant = config.GetTenant(r)
+			}
+			out := config.Output
+			if out == nil {
+				out = log.Writer()
+			}
+			// Encode issues a single Write per entry, so concurrent
+			// requests don't interleave within a line.
+			if err := json.NewEncoder(out).Encode(entry); err != nil {
+				log.Printf("access log: %v", err)
 			}
 		})
 	}
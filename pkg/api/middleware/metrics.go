@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -32,8 +33,76 @@ func (w *metricsResponseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
-// Metrics creates middleware that tracks HTTP request metrics.
+// Flush passes through so streaming handlers work behind the metrics wrapper
+func (w *metricsResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *metricsResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// OtherRoute is the route label for requests that match no registered route,
+// so arbitrary 404 URLs cannot mint new time series.
+const OtherRoute = "other"
+
+// RouteMatcher reports whether a request targets a registered route.
+type RouteMatcher func(r *http.Request) bool
+
+// ServeMuxRoutes returns a RouteMatcher that accepts the requests mux has a
+// pattern for.
+func ServeMuxRoutes(mux *http.ServeMux) RouteMatcher {
+	return func(r *http.Request) bool {
+		_, pattern := mux.Handler(r)
+		return pattern != ""
+	}
+}
+
+// requestRouteLabel is RouteLabel for r's path, or OtherRoute when routes is
+// set and does not match r.
+func requestRouteLabel(r *http.Request, routes RouteMatcher) string {
+	if routes != nil && !routes(r) {
+		return OtherRoute
+	}
+	return RouteLabel(r.URL.Path)
+}
+
+// RouteLabel reduces a request path to a bounded-cardinality metrics label by
+// replacing ID-like segments (all digits, or UUIDs) with ":id", so
+// /nodes/42/edges and /nodes/43/edges share one time series.
+func RouteLabel(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if isIDSegment(seg) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment is a numeric ID or a UUID.
+func isIDSegment(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	if len(seg) == 36 && seg[8] == '-' && seg[13] == '-' && seg[18] == '-' && seg[23] == '-' {
+		return strings.Trim(strings.ToLower(seg), "0123456789abcdef-") == ""
+	}
+	return strings.Trim(seg, "0123456789") == ""
+}
+
+// Metrics creates middleware that tracks HTTP request metrics. Paths are
+// recorded via RouteLabel to keep label cardinality bounded.
 func Metrics(recorder MetricsRecorder) func(http.Handler) http.Handler {
+	return MetricsForRoutes(recorder, nil)
+}
+
+// MetricsForRoutes is Metrics that records requests routes does not match
+// under OtherRoute. A nil routes matches every request.
+func MetricsForRoutes(recorder MetricsRecorder, routes RouteMatcher) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if recorder == nil {
@@ -61,8 +130,10 @@ func Metrics(recorder MetricsRecorder) func(http.Handler) http.Handler {
 			duration := time.Since(start)
 			statusStr := strconv.Itoa(wrapper.statusCode)
 
-			recorder.RecordHTTPRequest(r.Method, r.URL.Path, statusStr, duration)
-			recorder.RecordResponseSize(r.Method, r.URL.Path, float64(wrapper.bytesWritten))
+			route := requestRouteLabel(r, routes)
+
+			recorder.RecordHTTPRequest(r.Method, route, statusStr, duration)
+			recorder.RecordResponseSize(r.Method, route, float64(wrapper.bytesWritten))
 		})
 	}
 }
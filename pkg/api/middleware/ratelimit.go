@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"container/list"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"sync"
@@ -15,6 +17,23 @@ type RateLimitConfig struct {
 	CleanupInterval   time.Duration // How often to clean up expired buckets
 	ClientExpiration  time.Duration // How long to keep inactive client buckets
 	MaxClients        int           // Maximum number of tracked clients (prevents DoS via memory exhaustion)
+
+	// EvictLRU changes what happens when MaxClients is reached: instead of
+	// rejecting requests from new clients, the least-recently-used bucket is
+	// dropped to make room. Memory stays bounded either way; eviction keeps
+	// new tenants/IPs from being locked out by a table full of idle keys.
+	EvictLRU bool
+
+	// KeyLimits overrides RequestsPerSecond/BurstSize for specific client
+	// keys, as produced by the ClientIDFunc (e.g. "tenant:acme"). Keys not
+	// listed use the defaults above.
+	KeyLimits map[string]KeyLimit
+}
+
+// KeyLimit is a per-key rate/burst override. See RateLimitConfig.KeyLimits.
+type KeyLimit struct {
+	RequestsPerSecond float64
+	BurstSize         int
 }
 
 // DefaultRateLimitConfig returns sensible defaults for rate limiting
@@ -32,6 +51,9 @@ func DefaultRateLimitConfig() *RateLimitConfig {
 type tokenBucket struct {
 	tokens     float64
 	lastRefill time.Time
+	rate       float64       // tokens per second for this key
+	burst      int           // capacity for this key
+	lruElem    *list.Element // position in RateLimiter.lru (EvictLRU only)
 	mu         sync.Mutex
 }
 
@@ -39,6 +61,7 @@ type tokenBucket struct {
 type RateLimiter struct {
 	config   *RateLimitConfig
 	clients  map[string]*tokenBucket
+	lru      *list.List // client IDs, most recently used at the front (EvictLRU only)
 	mu       sync.RWMutex
 	stopChan chan struct{}
 }
@@ -52,6 +75,7 @@ func NewRateLimiter(config *RateLimitConfig) *RateLimiter {
 	rl := &RateLimiter{
 		config:   config,
 		clients:  make(map[string]*tokenBucket),
+		lru:      list.New(),
 		stopChan: make(chan struct{}),
 	}
 
@@ -78,9 +102,9 @@ func (rl *RateLimiter) Allow(clientID string) bool {
 	elapsed := now.Sub(bucket.lastRefill).Seconds()
 
 	// Refill tokens based on elapsed time
-	bucket.tokens += elapsed * rl.config.RequestsPerSecond
-	if bucket.tokens > float64(rl.config.BurstSize) {
-		bucket.tokens = float64(rl.config.BurstSize)
+	bucket.tokens += elapsed * bucket.rate
+	if bucket.tokens > float64(bucket.burst) {
+		bucket.tokens = float64(bucket.burst)
 	}
 	bucket.lastRefill = now
 
@@ -93,9 +117,45 @@ func (rl *RateLimiter) Allow(clientID string) bool {
 	return false
 }
 
+// RetryAfter returns how long clientID must wait before its next request
+// would be allowed. It is zero for an untracked client or one with a token
+// available, and never less than one second for a client that cannot refill
+// (zero rate or zero burst), so callers always have a finite hint.
+func (rl *RateLimiter) RetryAfter(clientID string) time.Duration {
+	rl.mu.RLock()
+	bucket, exists := rl.clients[clientID]
+	rl.mu.RUnlock()
+	if !exists {
+		return 0
+	}
+
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	if bucket.tokens >= 1 {
+		return 0
+	}
+	if bucket.rate <= 0 || bucket.burst < 1 {
+		return time.Second
+	}
+	return time.Duration((1 - bucket.tokens) / bucket.rate * float64(time.Second))
+}
+
+// limitsFor returns the rate and burst that apply to clientID.
+func (rl *RateLimiter) limitsFor(clientID string) (float64, int) {
+	if kl, ok := rl.config.KeyLimits[clientID]; ok {
+		return kl.RequestsPerSecond, kl.BurstSize
+	}
+	return rl.config.RequestsPerSecond, rl.config.BurstSize
+}
+
 // getBucket gets or creates a token bucket for a client.
 // Returns nil if the client limit has been reached and no existing bucket exists.
 func (rl *RateLimiter) getBucket(clientID string) *tokenBucket {
+	if rl.config.EvictLRU {
+		return rl.getBucketLRU(clientID)
+	}
+
 	rl.mu.RLock()
 	bucket, exists := rl.clients[clientID]
 	clientCount := len(rl.clients)
@@ -126,14 +186,46 @@ func (rl *RateLimiter) getBucket(clientID string) *tokenBucket {
 		return nil
 	}
 
-	bucket = &tokenBucket{
-		tokens:     float64(rl.config.BurstSize), // Start with full bucket
-		lastRefill: time.Now(),
+	bucket = rl.newBucket(clientID)
+	rl.clients[clientID] = bucket
+	return bucket
+}
+
+// getBucketLRU is getBucket for EvictLRU mode. Every hit reorders the LRU
+// list, so it takes the write lock unconditionally; at MaxClients the
+// least-recently-used bucket is evicted rather than the request rejected.
+func (rl *RateLimiter) getBucketLRU(clientID string) *tokenBucket {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if bucket, exists := rl.clients[clientID]; exists {
+		rl.lru.MoveToFront(bucket.lruElem)
+		return bucket
 	}
+
+	if rl.config.MaxClients > 0 && len(rl.clients) >= rl.config.MaxClients {
+		if oldest := rl.lru.Back(); oldest != nil {
+			delete(rl.clients, rl.lru.Remove(oldest).(string))
+		}
+	}
+
+	bucket := rl.newBucket(clientID)
+	bucket.lruElem = rl.lru.PushFront(clientID)
 	rl.clients[clientID] = bucket
 	return bucket
 }
 
+// newBucket returns a full bucket sized by clientID's limits.
+func (rl *RateLimiter) newBucket(clientID string) *tokenBucket {
+	rate, burst := rl.limitsFor(clientID)
+	return &tokenBucket{
+		tokens:     float64(burst), // Start with full bucket
+		lastRefill: time.Now(),
+		rate:       rate,
+		burst:      burst,
+	}
+}
+
 // cleanupLoop periodically removes expired client buckets
 func (rl *RateLimiter) cleanupLoop() {
 	ticker := time.NewTicker(rl.config.CleanupInterval)
@@ -181,6 +273,9 @@ func (rl *RateLimiter) cleanup() {
 			bucket.mu.Lock()
 			if now.Sub(bucket.lastRefill) > rl.config.ClientExpiration {
 				delete(rl.clients, clientID)
+				if bucket.lruElem != nil {
+					rl.lru.Remove(bucket.lruElem)
+				}
 			}
 			bucket.mu.Unlock()
 		}
@@ -212,7 +307,8 @@ func (rl *RateLimiter) GetConfig() *RateLimitConfig {
 	return rl.config
 }
 
-// ClientIDFunc is a function that extracts a client identifier from a request
+// ClientIDFunc is a function that extracts a client identifier from a request.
+// Its result is the bucket key: one token bucket is kept per distinct value.
 type ClientIDFunc func(*http.Request) string
 
 // RateLimit creates middleware that applies rate limiting per client.
@@ -240,10 +336,16 @@ func RateLimit(limiter *RateLimiter, getClientID ClientIDFunc, onLimited func(w
 					onLimited(w, r, clientID)
 				}
 
-				// Return 429 Too Many Requests with Retry-After header
-				w.Header().Set("Retry-After", "1")
-				w.Header().Set("X-RateLimit-Limit", strconv.FormatFloat(limiter.config.RequestsPerSecond, 'f', 0, 64))
-				http.Error(w, "Rate limit exceeded. Please retry after 1 second.", http.StatusTooManyRequests)
+				// Return 429 Too Many Requests with Retry-After header,
+				// rounded up to whole seconds as the header requires.
+				retry := int(math.Ceil(limiter.RetryAfter(clientID).Seconds()))
+				if retry < 1 {
+					retry = 1
+				}
+				rate, _ := limiter.limitsFor(clientID)
+				w.Header().Set("Retry-After", strconv.Itoa(retry))
+				w.Header().Set("X-RateLimit-Limit", strconv.FormatFloat(rate, 'f', 0, 64))
+				WriteError(w, r, http.StatusTooManyRequests, "Rate limit exceeded. Please retry after "+strconv.Itoa(retry)+" second(s).")
 				return
 			}
 
@@ -6,26 +6,99 @@ import (
 	"runtime/debug"
 )
 
+// PanicRecorder counts recovered handler panics (e.g. *metrics.Registry).
+type PanicRecorder interface {
+	RecordHTTPPanic(method, path string)
+}
+
 // PanicRecovery creates middleware that recovers from panics in HTTP handlers.
 // This prevents server crashes and returns a proper error response.
 // Internal details are logged but not exposed to clients.
 func PanicRecovery() func(http.Handler) http.Handler {
+	return PanicRecoveryWithRecorder(nil)
+}
+
+// PanicRecoveryWithRecorder is PanicRecovery that also counts each panic in
+// recorder, by method and route (see RouteLabel). A nil recorder counts
+// nothing.
+//
+// A recovered panic is logged with its stack trace, request ID and route,
+// and answered with the 500 error envelope carrying the request ID, so the
+// client has something to quote and the log line can be found from it. If
+// the handler had already started its response, the status can no longer
+// change; the panic is still logged and counted. http.ErrAbortHandler is
+// re-panicked, as net/http uses it to abort a response deliberately.
+func PanicRecoveryWithRecorder(recorder PanicRecorder) func(http.Handler) http.Handler {
+	return PanicRecoveryForRoutes(recorder, nil)
+}
+
+// PanicRecoveryForRoutes is PanicRecoveryWithRecorder that labels panics on
+// requests routes does not match with OtherRoute. A nil routes matches every
+// request.
+func PanicRecoveryForRoutes(recorder PanicRecorder, routes RouteMatcher) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tw := &panicResponseWriter{ResponseWriter: w}
 			defer func() {
-				if err := recover(); err != nil {
-					// Log the panic with stack trace (for debugging)
-					stack := debug.Stack()
-					log.Printf("PANIC in HTTP handler [%s %s]: %v\n%s",
-						r.Method, r.URL.Path, err, stack)
-
-					// Return generic error to client (don't expose internal details)
-					http.Error(w,
-						"Internal server error",
-						http.StatusInternalServerError)
+				err := recover()
+				if err == nil {
+					return
+				}
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+
+				// RequestID usually sits inside this middleware, so the ID
+				// is on the response header rather than in r's context.
+				requestID := GetRequestID(r)
+				if requestID == "" {
+					requestID = w.Header().Get(RequestIDHeader)
+				}
+				route := requestRouteLabel(r, routes)
+				log.Printf("ERROR [panic] request_id=%s route=%s %s %s: %v\n%s",
+					requestID, route, r.Method, r.URL.Path, err, debug.Stack())
+
+				if recorder != nil {
+					recorder.RecordHTTPPanic(r.Method, route)
+				}
+
+				if tw.wroteHeader {
+					return
 				}
+				// Return generic error to client (don't expose internal details)
+				WriteError(w, r, http.StatusInternalServerError, "Internal server error")
 			}()
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(tw, r)
 		})
 	}
 }
+
+// panicResponseWriter records whether the response has been started, so a
+// panic after that point doesn't try to write a second status.
+type panicResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *panicResponseWriter) WriteHeader(statusCode int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *panicResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush passes through so streaming handlers work behind panic recovery
+func (w *panicResponseWriter) Flush() {
+	w.wroteHeader = true
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *panicResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
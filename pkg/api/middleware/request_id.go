@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 )
 
@@ -17,62 +19,72 @@ const RequestIDContextKey ContextKey = "request_id"
 // RequestIDHeader is the header name for request IDs
 const RequestIDHeader = "X-Request-ID"
 
-// generateRequestID creates a unique request ID
-// Uses timestamp + random suffix for uniqueness without external dependencies
+// maxRequestIDLength bounds a client-supplied request ID.
+const maxRequestIDLength = 64
+
+// generateRequestID creates a unique request ID: a millisecond timestamp,
+// for rough ordering when grepping logs, and 64 random bits, so IDs from
+// concurrent requests never collide.
+// Format: timestamp_randomhex (e.g., 1699876543210_a1b2c3d4e5f60718)
 func generateRequestID() string {
-	// Format: timestamp_randomhex (e.g., 1699876543_a1b2c3d4)
-	timestamp := time.Now().UnixNano()
-	// Use simple counter + timestamp for uniqueness
-	return fmt.Sprintf("%d_%08x", timestamp/1000000, timestamp%0xFFFFFFFF)
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand failing is not expected; fall back to the clock.
+		binary.BigEndian.PutUint64(b[:], uint64(time.Now().UnixNano()))
+	}
+	return fmt.Sprintf("%d_%s", time.Now().UnixMilli(), hex.EncodeToString(b[:]))
 }
 
 // GetRequestID extracts request ID from request context
 func GetRequestID(r *http.Request) string {
-	if id, ok := r.Context().Value(RequestIDContextKey).(string); ok {
+	return RequestIDFromContext(r.Context())
+}
+
+// RequestIDFromContext returns the request ID the RequestID middleware
+// stored in ctx, or "". Layers below the handlers that only see a
+// context (audit, storage callbacks) use it to tag what they record.
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(RequestIDContextKey).(string); ok {
 		return id
 	}
 	return ""
 }
 
-// sanitizeRequestID removes potentially dangerous characters from request IDs
-func sanitizeRequestID(id string) string {
-	var result strings.Builder
-	result.Grow(len(id))
+// WithRequestID returns a copy of ctx carrying id, for work started
+// outside an HTTP request that should still be correlated with one.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, RequestIDContextKey, id)
+}
 
+// validRequestID reports whether a client-supplied request ID can be used
+// as is: 1 to 64 characters, each alphanumeric, dash, underscore or dot.
+// Anything else is replaced rather than repaired, since a rewritten ID
+// would no longer match the client's own logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
 	for _, c := range id {
-		// Allow alphanumeric, dash, underscore, and dot
-		if (c >= 'a' && c <= 'z') ||
-			(c >= 'A' && c <= 'Z') ||
-			(c >= '0' && c <= '9') ||
-			c == '-' || c == '_' || c == '.' {
-			result.WriteRune(c)
+		if (c < 'a' || c > 'z') &&
+			(c < 'A' || c > 'Z') &&
+			(c < '0' || c > '9') &&
+			c != '-' && c != '_' && c != '.' {
+			return false
 		}
 	}
-
-	return result.String()
+	return true
 }
 
 // RequestID creates middleware that adds a unique request ID to each request.
-// If the client provides X-Request-ID header, it will be used (after sanitization).
-// Otherwise, a new ID is generated.
+// If the client provides a valid X-Request-ID header, it is used; otherwise
+// (absent, too long, or containing other characters) a new ID is generated.
+// The ID is echoed in the X-Request-ID response header and stored in the
+// request context, where audit events and error envelopes pick it up.
 func RequestID() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Check for existing request ID from client
 			requestID := r.Header.Get(RequestIDHeader)
-
-			// Validate and sanitize incoming request ID
-			if requestID != "" {
-				// Limit length to prevent abuse
-				if len(requestID) > 64 {
-					requestID = requestID[:64]
-				}
-				// Remove any potentially problematic characters
-				requestID = sanitizeRequestID(requestID)
-			}
-
-			// Generate new ID if none provided or invalid
-			if requestID == "" {
+			if !validRequestID(requestID) {
 				requestID = generateRequestID()
 			}
 
@@ -80,9 +92,7 @@ func RequestID() func(http.Handler) http.Handler {
 			w.Header().Set(RequestIDHeader, requestID)
 
 			// Add to context for downstream handlers
-			ctx := context.WithValue(r.Context(), RequestIDContextKey, requestID)
-
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), requestID)))
 		})
 	}
 }
@@ -2,16 +2,107 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
+	"time"
+)
+
+// HeaderDisabled, as a SecurityHeadersConfig header value, omits the header
+// entirely (e.g. a CSP for an API-only deployment that serves no HTML).
+const HeaderDisabled = "off"
+
+// Default header values, used for any SecurityHeadersConfig field left empty.
+const (
+	DefaultContentSecurityPolicy = "default-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'"
+	DefaultReferrerPolicy        = "strict-origin-when-cross-origin"
+	DefaultPermissionsPolicy     = "geolocation=(), microphone=(), camera=()"
+	DefaultHSTSMaxAge            = 365 * 24 * time.Hour
 )
 
 // SecurityHeadersConfig holds configuration for security headers
 type SecurityHeadersConfig struct {
 	TLSEnabled bool // Whether TLS is enabled (for HSTS header)
+
+	// Header values, sent verbatim. Empty selects the Default* value;
+	// HeaderDisabled omits the header.
+	ContentSecurityPolicy string
+	ReferrerPolicy        string
+	PermissionsPolicy     string
+
+	// HSTS shapes Strict-Transport-Security, sent only when TLSEnabled.
+	// Nil selects DefaultHSTSConfig.
+	HSTS *HSTSConfig
+}
+
+// HSTSConfig configures the Strict-Transport-Security header. A MaxAge of
+// zero or less omits the header. Preload only takes effect with browsers'
+// preload lists if MaxAge is at least a year and IncludeSubDomains is set.
+type HSTSConfig struct {
+	MaxAge            time.Duration
+	IncludeSubDomains bool
+	Preload           bool
+}
+
+// DefaultHSTSConfig returns the HSTS policy used when none is configured:
+// one year, including subdomains, not preloaded.
+func DefaultHSTSConfig() *HSTSConfig {
+	return &HSTSConfig{MaxAge: DefaultHSTSMaxAge, IncludeSubDomains: true}
+}
+
+// Value renders the Strict-Transport-Security header value, or "" when
+// the header should be omitted.
+func (c *HSTSConfig) Value() string {
+	if c == nil || c.MaxAge <= 0 {
+		return ""
+	}
+	v := "max-age=" + strconv.FormatInt(int64(c.MaxAge/time.Second), 10)
+	if c.IncludeSubDomains {
+		v += "; includeSubDomains"
+	}
+	if c.Preload {
+		v += "; preload"
+	}
+	return v
+}
+
+// DefaultSecurityHeadersConfig returns the secure defaults: every header
+// on, with the Default* values.
+func DefaultSecurityHeadersConfig() *SecurityHeadersConfig {
+	return &SecurityHeadersConfig{HSTS: DefaultHSTSConfig()}
+}
+
+// headerValue resolves a configured header value against its default.
+func headerValue(configured, fallback string) string {
+	switch configured {
+	case "":
+		return fallback
+	case HeaderDisabled:
+		return ""
+	default:
+		return configured
+	}
 }
 
 // SecurityHeaders creates middleware that adds security headers to responses.
 // This protects against clickjacking, MIME sniffing, XSS, and other attacks.
+// A nil config applies DefaultSecurityHeadersConfig without HSTS.
 func SecurityHeaders(config *SecurityHeadersConfig) func(http.Handler) http.Handler {
+	if config == nil {
+		config = DefaultSecurityHeadersConfig()
+	}
+	// Resolve once; the values don't change per request.
+	configurable := map[string]string{
+		"Content-Security-Policy": headerValue(config.ContentSecurityPolicy, DefaultContentSecurityPolicy),
+		"Referrer-Policy":         headerValue(config.ReferrerPolicy, DefaultReferrerPolicy),
+		"Permissions-Policy":      headerValue(config.PermissionsPolicy, DefaultPermissionsPolicy),
+	}
+	if config.TLSEnabled {
+		hsts := config.HSTS
+		if hsts == nil {
+			hsts = DefaultHSTSConfig()
+		}
+		configurable["Strict-Transport-Security"] = hsts.Value()
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Prevent clickjacking
@@ -23,20 +114,13 @@ func SecurityHeaders(config *SecurityHeadersConfig) func(http.Handler) http.Hand
 			// Enable XSS protection
 			w.Header().Set("X-XSS-Protection", "1; mode=block")
 
-			// Enforce HTTPS (if TLS is enabled)
-			if config != nil && config.TLSEnabled {
-				w.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+			// CSP, Referrer-Policy, Permissions-Policy and (with TLS) HSTS
+			for header, value := range configurable {
+				if value != "" {
+					w.Header().Set(header, value)
+				}
 			}
 
-			// Content Security Policy
-			w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'")
-
-			// Referrer policy
-			w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
-
-			// Permissions policy
-			w.Header().Set("Permissions-Policy", "geolocation=(), microphone=(), camera=()")
-
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -59,9 +59,6 @@ func (s *Server) auditMiddleware(next http.Handler) http.Handler {
 			status = audit.StatusFailure
 		}
 
-		// Get request ID for correlation
-		requestID := middleware.GetRequestID(r)
-
 		// Log the audit event
 		event := &audit.Event{
 			UserID:       userID,
@@ -72,12 +69,12 @@ func (s *Server) auditMiddleware(next http.Handler) http.Handler {
 			Status:       status,
 			IPAddress:    getIPAddress(r),
 			UserAgent:    r.UserAgent(),
+			RequestID:    middleware.GetRequestID(r),
 			Metadata: map[string]any{
 				"method":      r.Method,
 				"path":        r.URL.Path,
 				"status_code": wrapper.statusCode,
 				"duration_ms": time.Since(start).Milliseconds(),
-				"request_id":  requestID,
 			},
 		}
 
@@ -97,6 +94,19 @@ func (w *statusResponseWriter) WriteHeader(statusCode int) {
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Flush passes through so streaming handlers (GET /events) work behind the
+// audit wrapper
+func (w *statusResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // determineResourceAndAction determines resource type and action from HTTP request
 func determineResourceAndAction(method, path string) (audit.ResourceType, audit.Action) {
 	// Determine resource type from path
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 	"github.com/dd0wney/graphdb/pkg/audit"
 	"github.com/dd0wney/graphdb/pkg/auth"
 )
@@ -75,8 +76,11 @@ func (s *Server) requireAdminClaims(w http.ResponseWriter, r *http.Request) *aut
 	return claims
 }
 
-// requireAuth middleware validates JWT tokens or API keys and protects endpoints
+// requireAuth middleware validates JWT tokens or API keys and protects endpoints.
+// Authenticated requests then pass through the role policy from authzConfig,
+// so a viewer can read but not write.
 func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	authorized := middleware.Authorize(s.authzConfig())(next)
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Try JWT token first (Authorization: Bearer <token>)
 		authHeader := r.Header.Get("Authorization")
@@ -116,7 +120,7 @@ func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
 			// needed instead of context lookups.
 			ctx := context.WithValue(r.Context(), claimsContextKey, claims)
 			setAuditUser(ctx, claims.UserID, claims.Username)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			authorized.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
 
@@ -160,7 +164,7 @@ func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
 			// write mirrors the JWT path above.
 			ctx := context.WithValue(r.Context(), claimsContextKey, claims)
 			setAuditUser(ctx, claims.UserID, claims.Username)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			authorized.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
 
@@ -168,3 +172,46 @@ func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
 		s.respondError(w, http.StatusUnauthorized, "Missing authentication (Bearer token or X-API-Key header required)")
 	}
 }
+
+// authzConfig is the role policy requireAuth enforces: admins and editors
+// may write, viewers may only GET and use the read-only POST endpoints.
+// Denials are audited like a refused admin request.
+func (s *Server) authzConfig() *middleware.AuthzConfig {
+	cfg := middleware.DefaultAuthzConfig()
+	cfg.GetRole = func(r *http.Request) (string, bool) {
+		claims, ok := r.Context().Value(claimsContextKey).(*auth.Claims)
+		if !ok {
+			return "", false
+		}
+		return claims.Role, true
+	}
+	cfg.OnDenied = func(w http.ResponseWriter, r *http.Request, role string) {
+		claims, _ := r.Context().Value(claimsContextKey).(*auth.Claims)
+		s.logAuditEvent(&audit.Event{
+			UserID:       claims.UserID,
+			Username:     claims.Username,
+			Action:       audit.ActionAuth,
+			ResourceType: audit.ResourceAuth,
+			Status:       audit.StatusFailure,
+			IPAddress:    getIPAddress(r),
+			UserAgent:    r.UserAgent(),
+			Metadata: map[string]any{
+				"error":  "write access required",
+				"role":   role,
+				"path":   r.URL.Path,
+				"method": r.Method,
+			},
+		})
+		s.respondError(w, http.StatusForbidden, "Write access required")
+	}
+	return cfg
+}
+
+// canWrite reports whether the authenticated caller may modify the graph.
+// Read-only POST endpoints that can also write (Cypher, GraphQL) check it
+// once they know what the request body asks for. Like Authorize, it leaves
+// requests with no claims to requireAuth.
+func (s *Server) canWrite(r *http.Request) bool {
+	claims, ok := r.Context().Value(claimsContextKey).(*auth.Claims)
+	return !ok || middleware.DefaultAuthzConfig().CanWrite(claims.Role)
+}
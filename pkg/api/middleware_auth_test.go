@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/auth"
+)
+
+// TestRequireAuth_ViewerIsReadOnly pins the role policy requireAuth applies
+// through the real route table: a viewer can read and run read-only
+// queries, but every write — REST mutation, Cypher CREATE, GraphQL
+// mutation — is refused with 403, while an editor may write.
+func TestRequireAuth_ViewerIsReadOnly(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	viewer := mintTestToken(t, server, auth.RoleViewer, "authz-viewer", "")
+	editor := mintTestToken(t, server, auth.RoleEditor, "authz-editor", "")
+
+	mux := http.NewServeMux()
+	server.registerRoutes(mux)
+
+	do := func(token, method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	createNode := `{"labels":["Person"],"properties":{"name":"a"}}`
+	tests := []struct {
+		name, token, method, path, body string
+		want                            int
+	}{
+		{"viewer lists nodes", viewer, http.MethodGet, "/nodes", "", http.StatusOK},
+		{"viewer runs read query", viewer, http.MethodPost, "/query", `{"query":"MATCH (n) RETURN n"}`, http.StatusOK},
+		{"viewer creates node", viewer, http.MethodPost, "/nodes", createNode, http.StatusForbidden},
+		{"viewer deletes node", viewer, http.MethodDelete, "/nodes/1", "", http.StatusForbidden},
+		{"viewer runs CREATE query", viewer, http.MethodPost, "/query", `{"query":"CREATE (n:Person {name: 'x'})"}`, http.StatusForbidden},
+		{"viewer runs GraphQL mutation", viewer, http.MethodPost, "/graphql", `{"query":"mutation { createNode(labels: [\"P\"], properties: \"{}\") { id } }"}`, http.StatusForbidden},
+		{"editor creates node", editor, http.MethodPost, "/nodes", createNode, http.StatusCreated},
+		{"editor runs CREATE query", editor, http.MethodPost, "/query", `{"query":"CREATE (n:Person {name: 'x'})"}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := do(tt.token, tt.method, tt.path, tt.body); got != tt.want {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,54 +3,43 @@ package api
 import (
 	"net/http"
 	"runtime"
-	"strconv"
 	"time"
-)
-
-// metricsMiddleware tracks HTTP request metrics
-func (s *Server) metricsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		// Track in-flight requests
-		s.metricsRegistry.HTTPRequestsInFlight.Inc()
-		defer s.metricsRegistry.HTTPRequestsInFlight.Dec()
-
-		// Create a response writer wrapper to capture status code and size
-		wrapper := &metricsResponseWriter{
-			ResponseWriter: w,
-			statusCode:     http.StatusOK,
-			bytesWritten:   0,
-		}
-
-		// Process request
-		next.ServeHTTP(wrapper, r)
 
-		// Record metrics
-		duration := time.Since(start)
-		statusStr := strconv.Itoa(wrapper.statusCode)
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
+	"github.com/dd0wney/graphdb/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
 
-		s.metricsRegistry.RecordHTTPRequest(r.Method, r.URL.Path, statusStr, duration)
-		s.metricsRegistry.HTTPResponseSizeBytes.WithLabelValues(r.Method, r.URL.Path).Observe(float64(wrapper.bytesWritten))
-	})
-}
+// The Prometheus registry feeds middleware.Metrics directly.
+var _ middleware.MetricsRecorder = (*metrics.Registry)(nil)
 
-// metricsResponseWriter wraps http.ResponseWriter to capture status code and bytes written
-type metricsResponseWriter struct {
-	http.ResponseWriter
-	statusCode   int
-	bytesWritten int
+// metricsMiddleware tracks HTTP request metrics (count, latency and response
+// size by route and status, plus in-flight requests) for the /metrics scrape.
+// Requests for unregistered paths are recorded under middleware.OtherRoute.
+func (s *Server) metricsMiddleware(next http.Handler) http.Handler {
+	return middleware.MetricsForRoutes(s.metricsRegistry, s.routes)(next)
 }
 
-func (w *metricsResponseWriter) WriteHeader(statusCode int) {
-	w.statusCode = statusCode
-	w.ResponseWriter.WriteHeader(statusCode)
+// updateGraphMetrics refreshes the graph-level gauges from GetStatistics.
+func (s *Server) updateGraphMetrics() {
+	stats := s.graph.GetStatistics()
+	s.metricsRegistry.StorageNodesTotal.Set(float64(stats.NodeCount))
+	s.metricsRegistry.StorageEdgesTotal.Set(float64(stats.EdgeCount))
+	s.metricsRegistry.StorageQueries.Set(float64(stats.TotalQueries))
 }
 
-func (w *metricsResponseWriter) Write(b []byte) (int, error) {
-	n, err := w.ResponseWriter.Write(b)
-	w.bytesWritten += n
-	return n, err
+// prometheusHandler serves the registry in Prometheus text format. Graph
+// gauges are refreshed on every scrape so they are never a tick stale.
+func (s *Server) prometheusHandler() http.Handler {
+	promHandler := promhttp.HandlerFor(s.metricsRegistry.GetPrometheusRegistry(), promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			s.respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		s.updateGraphMetrics()
+		promHandler.ServeHTTP(w, r)
+	})
 }
 
 // updateMetricsPeriodically updates system metrics every 10 seconds
@@ -76,9 +65,7 @@ func (s *Server) updateMetricsPeriodically() {
 			s.metricsRegistry.MemorySysBytes.Set(float64(m.Sys))
 
 			// Update storage metrics
-			stats := s.graph.GetStatistics()
-			s.metricsRegistry.StorageNodesTotal.Set(float64(stats.NodeCount))
-			s.metricsRegistry.StorageEdgesTotal.Set(float64(stats.EdgeCount))
+			s.updateGraphMetrics()
 		}
 	}
 }
@@ -12,7 +12,10 @@ import (
 	"github.com/dd0wney/graphdb/pkg/auth"
 )
 
-// rateLimitMiddleware applies rate limiting per client IP
+// rateLimitMiddleware applies rate limiting per client. The bucket key is
+// the client IP (or the user ID when claims are already in context). It
+// runs ahead of authentication, so it never keys on the tenant: that is
+// only known once withTenant has resolved it (see tenantRateLimit).
 func (s *Server) rateLimitMiddleware(next http.Handler) http.Handler {
 	getClientID := func(r *http.Request) string {
 		// Get client identifier (IP address, or user ID if authenticated)
@@ -25,24 +28,39 @@ func (s *Server) rateLimitMiddleware(next http.Handler) http.Handler {
 		return clientID
 	}
 
-	onLimited := func(w http.ResponseWriter, r *http.Request, clientID string) {
-		// Record in audit log
-		s.logAuditEvent(&audit.Event{
-			Action:       audit.ActionRead,
-			ResourceType: audit.ResourceQuery,
-			Status:       audit.StatusFailure,
-			IPAddress:    getIPAddress(r),
-			UserAgent:    r.UserAgent(),
-			Metadata: map[string]any{
-				"error":     "rate_limit_exceeded",
-				"client_id": clientID,
-				"path":      r.URL.Path,
-				"method":    r.Method,
-			},
-		})
+	return middleware.RateLimit(s.rateLimiter, getClientID, s.auditRateLimited)(next)
+}
+
+// tenantRateLimit charges the request to its tenant's bucket when
+// RATE_LIMIT_KEY=tenant. withTenant calls it after resolving the tenant —
+// the JWT/API-key tenant, or an admin's validated override — so the key
+// never comes from a header the client controls. The per-IP bucket in
+// rateLimitMiddleware still applies on top.
+func (s *Server) tenantRateLimit(next http.Handler) http.Handler {
+	if !s.rateLimitByTenant {
+		return next
 	}
+	byTenant := func(r *http.Request) string {
+		return "tenant:" + getTenantFromContext(r)
+	}
+	return middleware.RateLimit(s.rateLimiter, byTenant, s.auditRateLimited)(next)
+}
 
-	return middleware.RateLimit(s.rateLimiter, getClientID, onLimited)(next)
+// auditRateLimited records a throttled request in the audit log.
+func (s *Server) auditRateLimited(w http.ResponseWriter, r *http.Request, clientID string) {
+	s.logAuditEvent(&audit.Event{
+		Action:       audit.ActionRead,
+		ResourceType: audit.ResourceQuery,
+		Status:       audit.StatusFailure,
+		IPAddress:    getIPAddress(r),
+		UserAgent:    r.UserAgent(),
+		Metadata: map[string]any{
+			"error":     "rate_limit_exceeded",
+			"client_id": clientID,
+			"path":      r.URL.Path,
+			"method":    r.Method,
+		},
+	})
 }
 
 // InitRateLimiterFromEnv initializes rate limiter from environment variables
@@ -79,8 +97,24 @@ func (s *Server) InitRateLimiterFromEnv() {
 		}
 	}
 
+	// RATE_LIMIT_KEY=tenant adds a bucket per resolved tenant so one noisy
+	// tenant cannot starve the others. The tracked-key table switches to
+	// LRU eviction so idle IP and tenant buckets age out instead of
+	// filling the table and locking new clients out.
+	keyedBy := "client IP"
+	switch key := os.Getenv("RATE_LIMIT_KEY"); key {
+	case "", "ip":
+	case "tenant":
+		s.rateLimitByTenant = true
+		config.EvictLRU = true
+		keyedBy = "tenant"
+	default:
+		log.Printf("Ignoring unknown RATE_LIMIT_KEY=%q (want \"ip\" or \"tenant\"); keying by client IP", key)
+	}
+
 	s.rateLimiter = middleware.NewRateLimiter(config)
-	log.Printf("General API rate limiting enabled: %.0f req/s, burst size %d", config.RequestsPerSecond, config.BurstSize)
+	log.Printf("General API rate limiting enabled: %.0f req/s, burst size %d, keyed by %s",
+		config.RequestsPerSecond, config.BurstSize, keyedBy)
 }
 
 // initAuthRateLimiter initializes the auth-specific rate limiter with stricter limits.
@@ -69,7 +69,7 @@ func (s *Server) withTenant(next http.HandlerFunc) http.HandlerFunc {
 		// events — see middleware_audit_collector.go for the rationale.
 		ctx := tenant.WithTenant(r.Context(), tenantID)
 		setAuditTenant(ctx, tenantID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		s.tenantRateLimit(next).ServeHTTP(w, r.WithContext(ctx))
 	}
 }
 
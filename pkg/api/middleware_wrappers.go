@@ -2,7 +2,7 @@ package api
 
 import (
 	"net/http"
-	"strings"
+	"os"
 
 	"github.com/dd0wney/graphdb/pkg/api/middleware"
 )
@@ -13,38 +13,61 @@ import (
 // the ones inputValidationMiddleware skips, so without this outer layer
 // they had no body bound at all (an unbounded pre-auth read).
 const (
-	maxAuthBodyBytes    = 64 * 1024        // 64 KiB
-	maxGeneralBodyBytes = 10 * 1024 * 1024 // 10 MiB
+	maxAuthBodyBytes    = middleware.DefaultAuthBodyLimit
+	maxGeneralBodyBytes = middleware.DefaultBodyLimit
 )
 
 // bodyLimitMiddleware caps the request body size for EVERY request,
 // including the pre-auth /auth/* paths that inputValidationMiddleware
 // skips (security audit M-4). It runs ahead of input validation so an
-// oversized body is rejected before anything reads it.
+// oversized body is rejected before anything reads it. Caps are per
+// route (see SetBodyLimitConfig); an oversized body is a 413.
 func (s *Server) bodyLimitMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		limit := int64(maxGeneralBodyBytes)
-		if strings.HasPrefix(r.URL.Path, "/auth/") {
-			limit = maxAuthBodyBytes
-		}
-		if r.ContentLength > limit {
-			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
-			return
-		}
-		// Safety net for chunked/absent Content-Length.
-		r.Body = http.MaxBytesReader(w, r.Body, limit)
-		next.ServeHTTP(w, r)
-	})
+	return middleware.BodyLimit(s.bodyLimitConfig())(next)
 }
 
-// panicRecoveryMiddleware recovers from panics in HTTP handlers
+// bodyLimitConfig returns the configured body caps, or the defaults.
+func (s *Server) bodyLimitConfig() *BodyLimitConfig {
+	if s.bodyLimits != nil {
+		return s.bodyLimits
+	}
+	return middleware.DefaultBodyLimitConfig()
+}
+
+// panicRecoveryMiddleware recovers from panics in HTTP handlers, counting
+// them in graphdb_http_panics_total
 func (s *Server) panicRecoveryMiddleware(next http.Handler) http.Handler {
-	return middleware.PanicRecovery()(next)
+	if s.metricsRegistry == nil {
+		return middleware.PanicRecovery()(next)
+	}
+	return middleware.PanicRecoveryForRoutes(s.metricsRegistry, s.routes)(next)
 }
 
-// loggingMiddleware logs HTTP requests with timing information
+// loggingMiddleware logs HTTP requests with timing information.
+// ACCESS_LOG_FORMAT=json switches to one JSON object per request for log
+// pipelines; the tenant comes from the audit collector, which withTenant
+// fills in further down the chain.
 func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
-	return middleware.Logging(middleware.GetRequestID)(next)
+	config := &middleware.LoggingConfig{
+		Format:       middleware.LogFormatText,
+		GetRequestID: middleware.GetRequestID,
+		GetTenant: func(r *http.Request) string {
+			if c := getAuditCollector(r.Context()); c != nil {
+				_, _, tenantID := c.snapshot()
+				return tenantID
+			}
+			return ""
+		},
+	}
+	if os.Getenv("ACCESS_LOG_FORMAT") == string(middleware.LogFormatJSON) {
+		config.Format = middleware.LogFormatJSON
+	}
+	return middleware.LoggingWithConfig(config)(next)
+}
+
+// compressMiddleware gzips large responses for clients that accept it
+func (s *Server) compressMiddleware(next http.Handler) http.Handler {
+	return middleware.Compress(nil)(next)
 }
 
 // corsMiddleware handles Cross-Origin Resource Sharing
@@ -64,13 +87,20 @@ func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
 
 // securityHeadersMiddleware adds security headers to responses
 func (s *Server) securityHeadersMiddleware(next http.Handler) http.Handler {
-	config := &middleware.SecurityHeadersConfig{
-		TLSEnabled: s.tlsConfig != nil && s.tlsConfig.Enabled,
+	config := middleware.DefaultSecurityHeadersConfig()
+	if s.securityHeaders != nil {
+		c := *s.securityHeaders
+		config = &c
 	}
+	config.TLSEnabled = s.tlsConfig != nil && s.tlsConfig.Enabled
 	return middleware.SecurityHeaders(config)(next)
 }
 
-// inputValidationMiddleware validates input for security issues
+// inputValidationMiddleware validates input for security issues. Its own
+// read cap is raised to the largest route cap so it never undercuts
+// bodyLimitMiddleware, which is what enforces the per-route limits.
 func (s *Server) inputValidationMiddleware(next http.Handler) http.Handler {
-	return middleware.InputValidation(nil)(next)
+	config := middleware.DefaultInputValidationConfig()
+	config.MaxBodySize = int(max(int64(config.MaxBodySize), s.bodyLimitConfig().MaxLimit()))
+	return middleware.InputValidation(config)(next)
 }
@@ -1,6 +1,13 @@
 package api
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/auth"
+	"github.com/dd0wney/graphdb/pkg/tenant"
+)
 
 // TestRateLimiter_ActiveByDefault pins security audit finding H-5: after
 // server construction both the auth brute-force limiter and the general
@@ -34,3 +41,75 @@ func TestRateLimiter_GeneralDisabledViaEnv(t *testing.T) {
 		t.Error("general rate limiter should be nil when RATE_LIMIT_ENABLED=false")
 	}
 }
+
+// TestRateLimiter_KeyByTenantViaEnv: RATE_LIMIT_KEY=tenant switches the
+// general limiter to per-tenant buckets with LRU eviction.
+func TestRateLimiter_KeyByTenantViaEnv(t *testing.T) {
+	t.Setenv("RATE_LIMIT_ENABLED", "")
+	t.Setenv("RATE_LIMIT_KEY", "tenant")
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	if !server.rateLimitByTenant {
+		t.Error("rateLimitByTenant should be set for RATE_LIMIT_KEY=tenant")
+	}
+	if server.rateLimiter == nil || !server.rateLimiter.GetConfig().EvictLRU {
+		t.Error("per-tenant keying should enable LRU eviction of tracked keys")
+	}
+}
+
+// TestRateLimiter_TenantKeyIgnoresHeader: in tenant mode a client cannot
+// mint fresh buckets by varying X-Tenant-ID. The edge limiter keys on the
+// client IP, and the tenant bucket is keyed on the tenant withTenant
+// resolved, which for a non-admin is the token's tenant whatever the
+// header says.
+func TestRateLimiter_TenantKeyIgnoresHeader(t *testing.T) {
+	t.Setenv("RATE_LIMIT_ENABLED", "")
+	t.Setenv("RATE_LIMIT_KEY", "tenant")
+	t.Setenv("RATE_LIMIT_RPS", "0.001")
+	t.Setenv("RATE_LIMIT_BURST", "1")
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+	if server.tenantStore == nil {
+		t.Skip("tenant store not configured")
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	do := func(h http.Handler, remoteAddr, tenantHeader, token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/_probe", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(TenantIDHeader, tenantHeader)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	edge := server.rateLimitMiddleware(ok)
+	if code := do(edge, "192.0.2.1:1000", "spoof-1", ""); code != http.StatusOK {
+		t.Fatalf("first unauthenticated request: got %d", code)
+	}
+	if code := do(edge, "192.0.2.1:1000", "spoof-2", ""); code != http.StatusTooManyRequests {
+		t.Errorf("new X-Tenant-ID from the same IP got %d, want 429", code)
+	}
+
+	if err := server.tenantStore.Create(&tenant.Tenant{
+		ID:     "ratelimited-corp",
+		Name:   "Rate Limited Corp (test)",
+		Status: tenant.TenantStatusActive,
+	}); err != nil {
+		t.Fatalf("create tenant: %v", err)
+	}
+	token := mintTestToken(t, server, auth.RoleEditor, "rl-user", "ratelimited-corp")
+	tenanted := server.requireAuth(server.withTenant(ok))
+	if code := do(tenanted, "192.0.2.2:1000", "", token); code != http.StatusOK {
+		t.Fatalf("first tenant request: got %d", code)
+	}
+	if code := do(tenanted, "192.0.2.3:1000", "other-tenant", token); code != http.StatusTooManyRequests {
+		t.Errorf("same tenant from a new IP with a spoofed header got %d, want 429", code)
+	}
+}
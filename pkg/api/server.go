@@ -7,8 +7,7 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 	tlspkg "github.com/dd0wney/graphdb/pkg/tls"
 )
 
@@ -21,7 +20,11 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/health", s.handleHealth)
 	mux.Handle("/health/ready", s.healthChecker.ReadinessHandler())
 	mux.Handle("/health/live", s.healthChecker.LivenessHandler())
-	mux.Handle("/metrics", promhttp.HandlerFor(s.metricsRegistry.GetPrometheusRegistry(), promhttp.HandlerOpts{}))
+	// Kubernetes-style probe aliases: /livez is process-up only, /readyz
+	// returns 503 while storage can't take writes.
+	mux.Handle("/livez", s.healthChecker.LivenessHandler())
+	mux.Handle("/readyz", s.healthChecker.ReadinessHandler())
+	mux.Handle("/metrics", s.prometheusHandler())
 
 	// Authentication endpoints (public, but with stricter rate limiting)
 	// Auth rate limiter is always enabled to protect against brute-force attacks
@@ -68,6 +71,11 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 
 	// Algorithm endpoints (protected, tenant-scoped — audit A5).
 	mux.HandleFunc("/algorithms", s.requireAuth(s.withTenant(s.handleAlgorithm)))
+	mux.HandleFunc("/algorithms/", s.requireAuth(s.withTenant(s.handleAlgorithmByName))) // GET built-ins, POST plugins
+
+	// Change stream (protected, tenant-scoped): Server-Sent Events for the
+	// caller's tenant only.
+	mux.HandleFunc("/events", s.requireAuth(s.withTenant(s.handleEvents)))
 
 	// Vector search endpoints (protected, tenant-scoped)
 	mux.HandleFunc("/vector-indexes", s.requireAuth(s.withTenant(s.handleVectorIndexes)))
@@ -119,6 +127,9 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/v1/security/audit/export", s.requireAdmin(s.handleSecurityAuditExport))
 	mux.HandleFunc("/api/v1/security/health", s.requireAdmin(s.handleSecurityHealth))
 
+	// Mutation audit trail (admin only): who changed what, newest first.
+	mux.HandleFunc("/audit", s.requireAdmin(s.handleAuditLog))
+
 	// Software update endpoints (admin only). See pkg/updater for the
 	// download/verify/swap pipeline and docs/internals/design/AUDIT_pkg_updater_2026-05-13.md
 	// for the threat model this surface implements.
@@ -126,6 +137,9 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/admin/update/apply", s.requireAdmin(s.handleUpdateApply))
 	mux.HandleFunc("/admin/update/jobs/", s.requireAdmin(s.handleUpdateJob))
 	mux.HandleFunc("/admin/backup", s.requireAdmin(s.handleBackup))
+	mux.HandleFunc("/backup", s.requireAdmin(s.handlePortableBackup))
+	mux.HandleFunc("/admin/snapshots", s.requireAdmin(s.handleSnapshots))
+	mux.HandleFunc("/admin/snapshots/", s.requireAdmin(s.handleSnapshot))
 
 	// API Key management endpoints (admin only)
 	mux.HandleFunc("/api/v1/apikeys", s.requireAdmin(s.handleAPIKeys))
@@ -157,6 +171,7 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 func (s *Server) Start() error {
 	mux := http.NewServeMux()
 	s.registerRoutes(mux)
+	s.routes = middleware.ServeMuxRoutes(mux)
 
 	addr := fmt.Sprintf(":%d", s.port)
 
@@ -174,6 +189,7 @@ func (s *Server) Start() error {
 	log.Printf("   Health:        GET  %s://%s/health (public)", protocol, addr)
 	log.Printf("   Readiness:     GET  %s://%s/health/ready (public)", protocol, addr)
 	log.Printf("   Liveness:      GET  %s://%s/health/live (public)", protocol, addr)
+	log.Printf("   Probes:        GET  %s://%s/livez, /readyz (public)", protocol, addr)
 	log.Printf("   Metrics:       GET  %s://%s/metrics (public, Prometheus format)", protocol, addr)
 	log.Printf("   API Metrics:   GET  %s://%s/api/metrics (admin, JSON format)", protocol, addr)
 	log.Printf("   Login:         POST %s://%s/auth/login (public)", protocol, addr)
@@ -193,6 +209,9 @@ func (s *Server) Start() error {
 	log.Printf("   Traverse:      POST %s://%s/traverse (requires auth)", protocol, addr)
 	log.Printf("   Shortest Path: POST %s://%s/shortest-path (requires auth)", protocol, addr)
 	log.Printf("   Algorithms:    POST %s://%s/algorithms (requires auth)", protocol, addr)
+	log.Printf("   Plugin Algo:   POST %s://%s/algorithms/{name} (requires auth)", protocol, addr)
+	log.Printf("   Algo Results:  GET %s://%s/algorithms/{pagerank,betweenness,centrality/closeness,components} (requires auth)", protocol, addr)
+	log.Printf("   Events:        GET  %s://%s/events (requires auth, SSE)", protocol, addr)
 	log.Printf("🔍 Vector Search (requires auth):")
 	log.Printf("   Indexes:       GET/POST %s://%s/vector-indexes", protocol, addr)
 	log.Printf("   Index:         GET/DELETE %s://%s/vector-indexes/{name}", protocol, addr)
@@ -242,10 +261,13 @@ func (s *Server) Start() error {
 	suil := newSuilClient()
 
 	// Create HTTP server with timeouts for production security
-	// Middleware chain: suil -> metrics -> panicRecovery -> requestID -> rateLimit -> securityHeaders -> bodyLimit -> inputValidation -> auditCollector -> audit -> logging -> CORS -> routes
+	// Middleware chain, outermost first (see middleware.Chain for the
+	// general recommended order). Rate limiting runs ahead of auth, per
+	// client IP, and auth is per route in registerRoutes.
 	// bodyLimit sits ahead of inputValidation so EVERY request — including
 	// the /auth/* paths inputValidation skips — has a body bound before
 	// anything reads it (security audit M-4).
+	// compress sits inside logging so logged sizes are on-the-wire bytes.
 	// auditCollector must wrap audit so the per-request mutable identity
 	// holder is in context before audit emits the event; inner per-route
 	// middlewares (requireAuth, withTenant) then write through the
@@ -256,11 +278,27 @@ func (s *Server) Start() error {
 		Addr: addr,
 		// tracingMiddleware is outermost so the request span covers the full
 		// chain. It is a no-op unless a TracerProvider is installed (pkg/tracing).
-		Handler:      tracingMiddleware(suilMiddleware(suil)(s.metricsMiddleware(s.panicRecoveryMiddleware(s.requestIDMiddleware(s.rateLimitMiddleware(s.securityHeadersMiddleware(s.bodyLimitMiddleware(s.inputValidationMiddleware(s.auditCollectorMiddleware(s.auditMiddleware(s.loggingMiddleware(s.corsMiddleware(mux))))))))))))),
+		Handler: middleware.Chain(
+			tracingMiddleware,
+			suilMiddleware(suil),
+			s.metricsMiddleware,
+			s.panicRecoveryMiddleware,
+			s.requestIDMiddleware,
+			s.rateLimitMiddleware,
+			s.securityHeadersMiddleware,
+			s.bodyLimitMiddleware,
+			s.inputValidationMiddleware,
+			s.auditCollectorMiddleware,
+			s.auditMiddleware,
+			s.loggingMiddleware,
+			s.compressMiddleware,
+			s.corsMiddleware,
+		)(mux),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	server.RegisterOnShutdown(s.stopStreams)
 	s.httpServer.Store(server)
 
 	// Start server with or without TLS
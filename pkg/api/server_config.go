@@ -3,9 +3,15 @@ package api
 import (
 	"log"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
+	"github.com/dd0wney/graphdb/pkg/constraints"
 	"github.com/dd0wney/graphdb/pkg/encryption"
+	"github.com/dd0wney/graphdb/pkg/licensing"
 	tlspkg "github.com/dd0wney/graphdb/pkg/tls"
 )
 
@@ -19,40 +25,175 @@ func (s *Server) SetCORSConfig(cfg *CORSConfig) {
 	s.corsConfig = cfg
 }
 
-// InitCORSFromEnv initializes CORS configuration from environment variables
-// CORS_ALLOWED_ORIGINS: comma-separated list of allowed origins (e.g., "https://app.example.com,https://admin.example.com")
-// Use "*" to allow all origins (NOT recommended for production)
+// SetSecurityHeadersConfig sets the CSP, HSTS, Referrer-Policy and
+// Permissions-Policy the security headers middleware sends. HSTS is still
+// only sent when TLS is enabled. Call before Start.
+func (s *Server) SetSecurityHeadersConfig(cfg *SecurityHeadersConfig) {
+	s.securityHeaders = cfg
+}
+
+// SetBodyLimitConfig sets the request body caps: a default plus per-route
+// overrides by path prefix, longest match winning. Bodies over the cap are
+// refused with 413. Call before Start; nil restores DefaultBodyLimitConfig.
+func (s *Server) SetBodyLimitConfig(cfg *BodyLimitConfig) {
+	s.bodyLimits = cfg
+}
+
+// SetConstraintValidator enables write-time constraint checks. POST /nodes
+// and POST /edges run the candidate through ValidateNode / ValidateEdge and
+// reject it with 422 Unprocessable Entity, listing the violations, instead
+// of storing it. Only constraints that can judge one entity in isolation
+// apply; graph-wide ones (uniqueness, cardinality) still need a full
+// Validate pass. Call before Start; nil disables the checks.
+func (s *Server) SetConstraintValidator(v *constraints.Validator) {
+	s.constraintValidator = v
+}
+
+// SetLicense sets the license loaded at startup, which gates the
+// Enterprise algorithms (see algorithmFeature). Nil, the default, is the
+// Community edition: the basic algorithms run, the Enterprise ones are
+// refused with 403. Call before Start.
+func (s *Server) SetLicense(license *licensing.License) {
+	s.license = license
+}
+
+// InitCORSFromEnv initializes CORS configuration from environment variables:
+//
+//	CORS_ALLOWED_ORIGINS   comma-separated origins, e.g. "https://app.example.com,https://admin.example.com";
+//	                       "*" allows all (NOT recommended for production). Unset disables CORS.
+//	CORS_ALLOWED_METHODS   comma-separated methods (default GET, POST, PUT, DELETE, OPTIONS)
+//	CORS_ALLOWED_HEADERS   comma-separated request headers (default Content-Type, Authorization, X-API-Key, X-Request-ID)
+//	CORS_ALLOW_CREDENTIALS "true" to allow cookies/auth headers; ignored with "*"
 func (s *Server) InitCORSFromEnv() {
-	originsEnv := os.Getenv("CORS_ALLOWED_ORIGINS")
-	if originsEnv == "" {
-		// No CORS configured - secure default (no cross-origin requests allowed)
-		s.corsConfig = DefaultCORSConfig()
-		log.Printf("ℹ️  CORS: No origins configured (CORS_ALLOWED_ORIGINS not set). Cross-origin requests disabled.")
-		return
-	}
+	s.corsConfig = NewCORSConfig(
+		os.Getenv("CORS_ALLOWED_ORIGINS"),
+		os.Getenv("CORS_ALLOWED_METHODS"),
+		os.Getenv("CORS_ALLOWED_HEADERS"),
+		os.Getenv("CORS_ALLOW_CREDENTIALS") == "true",
+	)
+}
 
-	origins := strings.Split(originsEnv, ",")
-	for i, o := range origins {
-		origins[i] = strings.TrimSpace(o)
-	}
+// InitSecurityHeadersFromEnv initializes the security headers from
+// environment variables. Unset keeps the secure default; "off" omits a
+// header, e.g. the CSP on an API-only deployment:
+//
+//	SECURITY_CSP                      Content-Security-Policy value
+//	SECURITY_REFERRER_POLICY          Referrer-Policy value
+//	SECURITY_PERMISSIONS_POLICY       Permissions-Policy value
+//	SECURITY_HSTS_MAX_AGE             HSTS max-age in seconds (default 31536000; 0 or "off" omits HSTS)
+//	SECURITY_HSTS_INCLUDE_SUBDOMAINS  "false" drops includeSubDomains
+//	SECURITY_HSTS_PRELOAD             "true" adds preload
+func (s *Server) InitSecurityHeadersFromEnv() {
+	cfg := middleware.DefaultSecurityHeadersConfig()
+	cfg.ContentSecurityPolicy = os.Getenv("SECURITY_CSP")
+	cfg.ReferrerPolicy = os.Getenv("SECURITY_REFERRER_POLICY")
+	cfg.PermissionsPolicy = os.Getenv("SECURITY_PERMISSIONS_POLICY")
 
-	// Check for wildcard and warn
-	for _, o := range origins {
-		if o == "*" {
-			log.Printf("⚠️  WARNING: CORS allows all origins (*). This is NOT recommended for production!")
+	switch maxAge := os.Getenv("SECURITY_HSTS_MAX_AGE"); maxAge {
+	case "":
+	case middleware.HeaderDisabled:
+		cfg.HSTS.MaxAge = 0
+	default:
+		seconds, err := strconv.Atoi(maxAge)
+		if err != nil || seconds < 0 {
+			log.Printf("⚠️  WARNING: invalid SECURITY_HSTS_MAX_AGE %q; keeping %d", maxAge, int(cfg.HSTS.MaxAge.Seconds()))
 			break
 		}
+		cfg.HSTS.MaxAge = time.Duration(seconds) * time.Second
 	}
+	if os.Getenv("SECURITY_HSTS_INCLUDE_SUBDOMAINS") == "false" {
+		cfg.HSTS.IncludeSubDomains = false
+	}
+	cfg.HSTS.Preload = os.Getenv("SECURITY_HSTS_PRELOAD") == "true"
 
-	s.corsConfig = &CORSConfig{
-		AllowedOrigins:   origins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-API-Key", "X-Request-ID"},
-		AllowCredentials: os.Getenv("CORS_ALLOW_CREDENTIALS") == "true",
-		MaxAge:           86400, // 24 hours
+	s.securityHeaders = cfg
+}
+
+// InitBodyLimitsFromEnv initializes the request body caps from environment
+// variables, on top of DefaultBodyLimitConfig. Sizes are in bytes:
+//
+//	MAX_REQUEST_BODY_BYTES  cap for routes without an override (default 10 MiB)
+//	MAX_IMPORT_BODY_BYTES   cap for the bulk import routes, /import, /nodes/batch
+//	                        and /edges/batch (default 100 MiB)
+//
+// The 64 KiB /auth/ cap is not configurable.
+func (s *Server) InitBodyLimitsFromEnv() {
+	cfg := middleware.DefaultBodyLimitConfig()
+	if limit, ok := bodyLimitFromEnv("MAX_REQUEST_BODY_BYTES"); ok {
+		cfg.Default = limit
+	}
+	if limit, ok := bodyLimitFromEnv("MAX_IMPORT_BODY_BYTES"); ok {
+		for prefix, routeLimit := range cfg.Routes {
+			if routeLimit == middleware.DefaultImportBodyLimit {
+				cfg.Routes[prefix] = limit
+			}
+		}
 	}
+	s.bodyLimits = cfg
+}
 
-	log.Printf("✅ CORS configured with %d allowed origins", len(origins))
+// bodyLimitFromEnv parses a positive byte count from an env var, warning
+// about and ignoring anything else.
+func bodyLimitFromEnv(name string) (int64, bool) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false
+	}
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit <= 0 {
+		log.Printf("⚠️  WARNING: invalid %s %q; keeping the default", name, value)
+		return 0, false
+	}
+	return limit, true
+}
+
+// NewCORSConfig builds a CORS configuration from comma-separated origin,
+// method and header lists, as taken from env (InitCORSFromEnv) or the
+// server's command-line flags. Empty methods/headers keep the
+// DefaultCORSConfig values; empty origins disable cross-origin requests.
+//
+// The middleware reflects the caller's specific origin rather than sending
+// "*". Combined with a "*" origin list that would let any site make
+// credentialed requests, so credentials are refused alongside "*".
+func NewCORSConfig(origins, methods, headers string, allowCredentials bool) *CORSConfig {
+	cfg := DefaultCORSConfig()
+	if m := splitCommaList(methods); len(m) > 0 {
+		cfg.AllowedMethods = m
+	}
+	if h := splitCommaList(headers); len(h) > 0 {
+		cfg.AllowedHeaders = h
+	}
+
+	cfg.AllowedOrigins = splitCommaList(origins)
+	if len(cfg.AllowedOrigins) == 0 {
+		// No CORS configured - secure default (no cross-origin requests allowed)
+		log.Printf("ℹ️  CORS: No origins configured (CORS_ALLOWED_ORIGINS not set). Cross-origin requests disabled.")
+		return cfg
+	}
+
+	cfg.AllowCredentials = allowCredentials
+	if slices.Contains(cfg.AllowedOrigins, "*") {
+		log.Printf("⚠️  WARNING: CORS allows all origins (*). This is NOT recommended for production!")
+		if allowCredentials {
+			log.Printf("⚠️  WARNING: CORS credentials cannot be combined with origin *; credentials disabled. List origins explicitly to allow them.")
+			cfg.AllowCredentials = false
+		}
+	}
+
+	log.Printf("✅ CORS configured with %d allowed origins", len(cfg.AllowedOrigins))
+	return cfg
+}
+
+// splitCommaList splits a comma-separated list, trimming whitespace and
+// dropping empty entries.
+func splitCommaList(list string) []string {
+	var out []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
 }
 
 // SetEncryption sets the encryption engine and key manager for the server.
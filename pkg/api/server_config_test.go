@@ -0,0 +1,59 @@
+package api
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewCORSConfig(t *testing.T) {
+	cfg := NewCORSConfig(" https://a.example , https://b.example,", "GET, POST", "Content-Type", true)
+	if want := []string{"https://a.example", "https://b.example"}; !slices.Equal(cfg.AllowedOrigins, want) {
+		t.Errorf("AllowedOrigins = %q, want %q", cfg.AllowedOrigins, want)
+	}
+	if want := []string{"GET", "POST"}; !slices.Equal(cfg.AllowedMethods, want) {
+		t.Errorf("AllowedMethods = %q, want %q", cfg.AllowedMethods, want)
+	}
+	if want := []string{"Content-Type"}; !slices.Equal(cfg.AllowedHeaders, want) {
+		t.Errorf("AllowedHeaders = %q, want %q", cfg.AllowedHeaders, want)
+	}
+	if !cfg.AllowCredentials {
+		t.Error("AllowCredentials = false with explicit origins")
+	}
+
+	// Defaults fill in unset methods/headers; no origins means no CORS.
+	cfg = NewCORSConfig("", "", "", true)
+	if len(cfg.AllowedOrigins) != 0 || cfg.AllowCredentials {
+		t.Errorf("empty origins: AllowedOrigins=%q AllowCredentials=%v, want none/false", cfg.AllowedOrigins, cfg.AllowCredentials)
+	}
+	if !slices.Equal(cfg.AllowedMethods, DefaultCORSConfig().AllowedMethods) {
+		t.Errorf("AllowedMethods = %q, want defaults", cfg.AllowedMethods)
+	}
+
+	// Credentials are refused alongside the wildcard.
+	if cfg = NewCORSConfig("*", "", "", true); cfg.AllowCredentials {
+		t.Error("AllowCredentials = true with origin *")
+	}
+}
+
+func TestInitSecurityHeadersFromEnv(t *testing.T) {
+	t.Setenv("SECURITY_CSP", "off")
+	t.Setenv("SECURITY_REFERRER_POLICY", "no-referrer")
+	t.Setenv("SECURITY_HSTS_MAX_AGE", "63072000")
+	t.Setenv("SECURITY_HSTS_PRELOAD", "true")
+
+	s := &Server{}
+	s.InitSecurityHeadersFromEnv()
+	cfg := s.securityHeaders
+	if cfg.ContentSecurityPolicy != "off" || cfg.ReferrerPolicy != "no-referrer" || cfg.PermissionsPolicy != "" {
+		t.Errorf("policies = %+v", cfg)
+	}
+	if got, want := cfg.HSTS.Value(), "max-age=63072000; includeSubDomains; preload"; got != want {
+		t.Errorf("HSTS = %q, want %q", got, want)
+	}
+
+	t.Setenv("SECURITY_HSTS_MAX_AGE", "soon")
+	s.InitSecurityHeadersFromEnv()
+	if got, want := s.securityHeaders.HSTS.Value(), "max-age=31536000; includeSubDomains; preload"; got != want {
+		t.Errorf("invalid max-age: HSTS = %q, want the default %q", got, want)
+	}
+}
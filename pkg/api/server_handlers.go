@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -20,6 +21,7 @@ import (
 	"github.com/dd0wney/graphdb/pkg/graphql"
 	"github.com/dd0wney/graphdb/pkg/health"
 	"github.com/dd0wney/graphdb/pkg/query"
+	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -56,6 +58,35 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, httpStatus, response)
 }
 
+// storageHealthCheck reports the store unhealthy when it can't take writes
+// (closed, failed WAL append/fsync, unwritable data dir), with the last
+// snapshot time and WAL lag as details for /readyz.
+func storageHealthCheck(graph *storage.GraphStorage) health.CheckFunc {
+	return func() health.Check {
+		h := graph.HealthCheck()
+		check := health.Check{
+			Name: "storage",
+			Details: map[string]any{
+				"writable":    h.Writable,
+				"wal_enabled": h.WALEnabled,
+				"wal_lsn":     h.CurrentLSN,
+				"wal_lag":     h.WALLag,
+			},
+		}
+		if !h.LastSnapshot.IsZero() {
+			check.Details["last_snapshot"] = h.LastSnapshot
+		}
+		if h.Writable {
+			check.Status = health.StatusHealthy
+			check.Message = "Writable"
+		} else {
+			check.Status = health.StatusUnhealthy
+			check.Message = h.Error
+		}
+		return check
+	}
+}
+
 func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		s.respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -76,6 +107,11 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 		EdgeCount:    stats.EdgeCount,
 		TotalQueries: stats.TotalQueries,
 		AvgQueryTime: stats.AvgQueryTime,
+		GraphVersion: stats.Version,
+		Operations:   make(map[string]OperationMetrics, len(stats.Operations)),
+
+		LabelCounts:    s.graph.LabelCounts(),
+		EdgeTypeCounts: s.graph.EdgeTypeCounts(),
 
 		// System stats
 		MemoryUsedMB:  m.Alloc / 1024 / 1024,
@@ -87,6 +123,9 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 		Uptime:        uptime.String(),
 		UptimeSeconds: int64(uptime.Seconds()),
 	}
+	for op, st := range stats.Operations {
+		response.Operations[op] = OperationMetrics{Count: st.Count, AvgLatencyMs: st.AvgLatencyMs}
+	}
 	s.respondJSON(w, http.StatusOK, response)
 }
 
@@ -115,8 +154,13 @@ func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Determine query timeout
-	timeout := query.DefaultQueryTimeout
+	// Determine query timeout and row cap, within the server's limits
+	limits := s.executor.Limits()
+	maxTimeoutSeconds := maxQueryTimeoutSeconds
+	if limits.MaxDuration > 0 {
+		maxTimeoutSeconds = min(maxTimeoutSeconds, max(int(limits.MaxDuration/time.Second), minQueryTimeoutSeconds))
+	}
+	timeout := min(query.DefaultQueryTimeout, time.Duration(maxTimeoutSeconds)*time.Second)
 	if req.TimeoutSeconds != nil {
 		secs := *req.TimeoutSeconds
 		if secs < minQueryTimeoutSeconds {
@@ -124,13 +168,24 @@ func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
 				fmt.Sprintf("timeout_seconds must be at least %d", minQueryTimeoutSeconds))
 			return
 		}
-		if secs > maxQueryTimeoutSeconds {
+		if secs > maxTimeoutSeconds {
 			s.respondError(w, http.StatusBadRequest,
-				fmt.Sprintf("timeout_seconds must be at most %d", maxQueryTimeoutSeconds))
+				fmt.Sprintf("timeout_seconds must be at most %d", maxTimeoutSeconds))
 			return
 		}
 		timeout = time.Duration(secs) * time.Second
 	}
+	if req.MaxRows != nil {
+		if *req.MaxRows < 1 {
+			s.respondError(w, http.StatusBadRequest, "max_rows must be at least 1")
+			return
+		}
+		if limits.MaxRows > 0 && *req.MaxRows > limits.MaxRows {
+			s.respondError(w, http.StatusBadRequest,
+				fmt.Sprintf("max_rows must be at most %d", limits.MaxRows))
+			return
+		}
+	}
 
 	start := time.Now()
 
@@ -149,29 +204,62 @@ func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// ?explain=true (like an EXPLAIN prefix) only plans the query, so a
+	// read-only caller may explain a write.
+	explain := r.URL.Query().Get("explain") == "true"
+	if parsedQuery.IsWrite() && !explain && !parsedQuery.Explain && !s.canWrite(r) {
+		s.respondError(w, http.StatusForbidden, "Write access required")
+		return
+	}
+
 	// Execute query with timeout context
 	ctx, cancel := context.WithTimeout(r.Context(), timeout)
 	defer cancel()
+	if req.MaxRows != nil {
+		ctx = query.WithLimits(ctx, query.Limits{MaxRows: *req.MaxRows})
+	}
+
+	if explain {
+		plan, err := s.executor.ExplainWithContext(ctx, parsedQuery)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "query explain"))
+			return
+		}
+		table := plan.ResultSet()
+		s.respondJSON(w, http.StatusOK, QueryResponse{
+			Columns: table.Columns,
+			Rows:    table.Rows,
+			Count:   table.Count,
+			Time:    time.Since(start).String(),
+			Plan:    plan,
+		})
+		return
+	}
+
+	// Reads that RETURN rows are streamed, so a large result never has to
+	// fit in memory; writes, EXPLAIN and PROFILE are answered in one piece.
+	if !parsedQuery.IsWrite() && !parsedQuery.Explain && !parsedQuery.Profile && parsedQuery.ResultColumns() != nil {
+		s.streamQuery(ctx, w, r, parsedQuery, req.queryParams(), timeout, start)
+		return
+	}
 
 	// Honor request parameters ($name) when present — ExecuteWithParamsContext
 	// substitutes them before execution. Calling ExecuteWithContext directly
 	// dropped req.Parameters and stored the literal "&{name}" (#237).
 	var results *query.ResultSet
-	if len(req.Parameters) > 0 {
-		results, err = s.executor.ExecuteWithParamsContext(ctx, parsedQuery, req.Parameters)
+	if params := req.queryParams(); len(params) > 0 {
+		results, err = s.executor.ExecuteWithParamsContext(ctx, parsedQuery, params)
 	} else {
 		results, err = s.executor.ExecuteWithContext(ctx, parsedQuery)
 	}
 	if err != nil {
-		// Check if it was a timeout
-		if ctx.Err() == context.DeadlineExceeded {
-			s.respondError(w, http.StatusRequestTimeout,
-				fmt.Sprintf("Query timed out after %v", timeout))
-			return
-		}
-		s.respondError(w, http.StatusInternalServerError, sanitizeError(err, "query execution"))
+		status, message := queryErrorResponse(ctx, err, timeout)
+		s.respondError(w, status, message)
 		return
 	}
+	if parsedQuery.IsWrite() && !parsedQuery.Explain {
+		s.auditStatement(r, "cypher", parsedQuery.Clauses())
+	}
 
 	response := QueryResponse{
 		Columns: results.Columns,
@@ -183,6 +271,19 @@ func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// queryErrorResponse maps a failed query to its status and client message:
+// 422 with the limit named for ErrQueryLimitExceeded, 408 for the request
+// timeout, and a sanitized 500 otherwise.
+func queryErrorResponse(ctx context.Context, err error, timeout time.Duration) (int, string) {
+	if errors.Is(err, query.ErrQueryLimitExceeded) {
+		return http.StatusUnprocessableEntity, err.Error()
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return http.StatusRequestTimeout, fmt.Sprintf("Query timed out after %v", timeout)
+	}
+	return http.StatusInternalServerError, sanitizeError(err, "query execution")
+}
+
 // getGraphQLHandlerForTenant returns the per-tenant GraphQL handler,
 // building it on first call and caching for subsequent requests.
 //
@@ -288,6 +389,11 @@ func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if graphql.IsMutation(gqlReq.Query) && !s.canWrite(r) {
+		s.respondError(w, http.StatusForbidden, "Write access required")
+		return
+	}
+
 	// Validate query complexity before execution (DoS protection)
 	if s.complexityConfig != nil && s.complexityConfig.MaxComplexity > 0 {
 		complexity, err := graphql.ValidateQueryComplexity(gqlReq.Query, s.complexityConfig, gqlReq.Variables)
@@ -328,6 +434,12 @@ func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
 	// for that tenant lazy-rebuilds.
 	r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 	handler.ServeHTTP(w, r)
+
+	// GraphQL reports resolver errors in a 200 body, so this records the
+	// mutation as submitted rather than as applied.
+	if graphql.IsMutation(gqlReq.Query) {
+		s.auditStatement(r, "graphql", graphql.MutationFields(gqlReq.Query))
+	}
 }
 
 // handleSchemaRegenerate invalidates the caller's tenant's cached
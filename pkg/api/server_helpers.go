@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"path/filepath"
 
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
+	"github.com/dd0wney/graphdb/pkg/constraints"
 	"github.com/dd0wney/graphdb/pkg/masking"
 	"github.com/dd0wney/graphdb/pkg/storage"
 	"github.com/dd0wney/graphdb/pkg/tenant"
@@ -51,6 +54,7 @@ func (s *Server) edgeToResponse(ctx context.Context, edge *storage.Edge) *EdgeRe
 		Type:       edge.Type,
 		Properties: props,
 		Weight:     edge.Weight,
+		Undirected: edge.Undirected,
 	}
 }
 
@@ -121,11 +125,21 @@ func (s *Server) respondJSON(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// respondError writes the JSON error envelope. The request ID comes from
+// the X-Request-ID response header, which requestIDMiddleware sets before
+// any handler runs.
 func (s *Server) respondError(w http.ResponseWriter, status int, message string) {
-	response := ErrorResponse{
-		Error:   http.StatusText(status),
-		Message: message,
-		Code:    status,
+	s.respondJSON(w, status, middleware.NewErrorResponse(w, nil, status, message))
+}
+
+// respondViolations writes a 422 listing the constraint violations that
+// blocked a write.
+func (s *Server) respondViolations(w http.ResponseWriter, violations []constraints.Violation) {
+	status := http.StatusUnprocessableEntity
+	response := ValidationErrorResponse{
+		ErrorResponse: middleware.NewErrorResponse(w, nil, status,
+			fmt.Sprintf("write violates %d constraint(s)", len(violations))),
+		Violations: violations,
 	}
 	s.respondJSON(w, status, response)
 }
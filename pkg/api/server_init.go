@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/algorithms"
 	"github.com/dd0wney/graphdb/pkg/audit"
 	"github.com/dd0wney/graphdb/pkg/auth"
 	"github.com/dd0wney/graphdb/pkg/auth/oidc"
@@ -143,6 +144,7 @@ func NewServerWithDataDir(graph *storage.GraphStorage, port int, dataDir string)
 	// Check if persistent audit logging is enabled
 	var auditLogger audit.Logger = inMemoryAuditLogger
 	var persistentAudit *audit.PersistentAuditLogger
+	var persistentMutationAudit *audit.PersistentAuditLogger
 
 	if os.Getenv("AUDIT_PERSISTENT") == "true" {
 		auditDir := os.Getenv("AUDIT_DIR")
@@ -167,6 +169,15 @@ func NewServerWithDataDir(graph *storage.GraphStorage, port int, dataDir string)
 			auditLogger = persistentAudit
 			log.Printf("✅ Persistent audit logging enabled (dir: %s)", auditDir)
 		}
+
+		// The mutation trail gets its own files so it can be retained and
+		// verified independently of the per-request log.
+		mutationConfig := *config
+		mutationConfig.LogDir = filepath.Join(auditDir, "mutations")
+		persistentMutationAudit, err = audit.NewPersistentAuditLogger(&mutationConfig)
+		if err != nil {
+			log.Printf("⚠️  WARNING: Failed to initialize persistent mutation audit logging: %v", err)
+		}
 	}
 
 	// Initialize metrics and health monitoring
@@ -178,11 +189,11 @@ func NewServerWithDataDir(graph *storage.GraphStorage, port int, dataDir string)
 		return health.SimpleCheck("api")
 	})
 
-	healthChecker.RegisterReadinessCheck("storage", health.DatabaseCheck(func() error {
-		// Check if storage is accessible
-		_ = graph.GetStatistics()
-		return nil
-	}))
+	// Storage must be writable to be ready; it also gates /health so a
+	// wedged WAL or full disk stops reporting "healthy".
+	storageCheck := storageHealthCheck(graph)
+	healthChecker.RegisterReadinessCheck("storage", storageCheck)
+	healthChecker.RegisterCheck("storage", storageCheck)
 
 	healthChecker.RegisterCheck("memory", health.MemoryCheck(func() (uint64, uint64) {
 		var m runtime.MemStats
@@ -263,6 +274,13 @@ func NewServerWithDataDir(graph *storage.GraphStorage, port int, dataDir string)
 	// tenant-aware DSL search() is a follow-up.
 	executor, _ := queryutil.WireCapabilities(query.NewExecutor(graph), graph)
 
+	// Server-wide query guardrails. A /query request may tighten them
+	// (max_rows, timeout_seconds) but not exceed them.
+	executor.SetLimits(query.Limits{
+		MaxRows:     getEnvInt("QUERY_MAX_ROWS", 0),
+		MaxDuration: time.Duration(getEnvInt("QUERY_MAX_DURATION_SECONDS", maxQueryTimeoutSeconds)) * time.Second,
+	})
+
 	searchIndexes := search.NewTenantIndexes(graph)
 	lsaIndexes := search.NewTenantLSAIndexes()
 
@@ -287,34 +305,42 @@ func NewServerWithDataDir(graph *storage.GraphStorage, port int, dataDir string)
 		updateJobs:    newUpdateJobManager(),
 		// graphqlHandlers + schemaSingleflight zero-value initialised
 		// (sync.Map and singleflight.Group both work zero-valued).
-		complexityConfig:    complexityConfig,
-		limitConfig:         limitConfig,
-		authHandler:         authHandler,
-		userHandler:         userHandler,
-		jwtManager:          jwtManager,
-		userStore:           userStore,
-		apiKeyStore:         apiKeyStore,
-		auditLogger:         auditLogger,
-		inMemoryAuditLogger: inMemoryAuditLogger,
-		persistentAudit:     persistentAudit,
-		maskingPolicyStore:  masking.NewPolicyStore(),
-		masker:              masking.NewMasker(masking.DefaultMaskingConfig()),
-		metricsRegistry:     metricsRegistry,
-		healthChecker:       healthChecker,
-		tlsConfig:           nil, // TLS disabled by default
-		oidcHandler:         oidcHandler,
-		oidcConfig:          oidcConfig,
-		tokenValidator:      tokenValidator,
-		tenantStore:         tenantStore,
-		startTime:           time.Now(),
-		version:             "1.0.0",
-		port:                port,
-		dataDir:             dataDir,
-		environment:         serverEnv,
+		complexityConfig:        complexityConfig,
+		limitConfig:             limitConfig,
+		authHandler:             authHandler,
+		userHandler:             userHandler,
+		jwtManager:              jwtManager,
+		userStore:               userStore,
+		apiKeyStore:             apiKeyStore,
+		auditLogger:             auditLogger,
+		inMemoryAuditLogger:     inMemoryAuditLogger,
+		persistentAudit:         persistentAudit,
+		mutationAuditLogger:     audit.NewAuditLogger(mutationAuditBufferSize),
+		persistentMutationAudit: persistentMutationAudit,
+		maskingPolicyStore:      masking.NewPolicyStore(),
+		masker:                  masking.NewMasker(masking.DefaultMaskingConfig()),
+		metricsRegistry:         metricsRegistry,
+		algorithmCache:          algorithms.NewResultCache(algorithms.DefaultResultCacheSize, metricsRegistry),
+		etagKey:                 deriveETagKey(jwtSecret),
+		graphEpoch:              newGraphEpoch(),
+		healthChecker:           healthChecker,
+		tlsConfig:               nil, // TLS disabled by default
+		oidcHandler:             oidcHandler,
+		oidcConfig:              oidcConfig,
+		tokenValidator:          tokenValidator,
+		tenantStore:             tenantStore,
+		startTime:               time.Now(),
+		version:                 "1.0.0",
+		port:                    port,
+		dataDir:                 dataDir,
+		environment:             serverEnv,
+		streamsStopCh:           make(chan struct{}),
 	}
 
 	// Initialize CORS from environment variables
 	server.InitCORSFromEnv()
+	server.InitSecurityHeadersFromEnv()
+	server.InitBodyLimitsFromEnv()
 
 	// Bootstrap tenant indexes from environment if configured. Fails
 	// soft — a bad config or corpus-too-small problem logs and continues
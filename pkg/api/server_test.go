@@ -600,24 +600,28 @@ func TestNewServer_FailsClosedOnEmptyJWTSecret(t *testing.T) {
 	}
 }
 
-// TestAPI_GraphQL_CORS tests CORS headers on GraphQL endpoint
+// TestAPI_GraphQL_CORS: /graphql follows the server's configured origins
+// instead of answering every origin with a wildcard.
 func TestAPI_GraphQL_CORS(t *testing.T) {
 	server, cleanup := setupTestServer(t)
 	defer cleanup()
-
-	queryReq := map[string]any{
-		"query": `{ health }`,
-	}
-
-	body, _ := json.Marshal(queryReq)
-	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-
-	rr := httptest.NewRecorder()
-	server.handleGraphQL(rr, req)
-
-	// Verify CORS headers
-	if rr.Header().Get("Access-Control-Allow-Origin") == "" {
-		t.Error("CORS header 'Access-Control-Allow-Origin' not set")
+	server.SetCORSConfig(NewCORSConfig("https://app.example.com", "", "", true))
+	handler := server.corsMiddleware(http.HandlerFunc(server.handleGraphQL))
+
+	for origin, want := range map[string]string{
+		"https://app.example.com": "https://app.example.com",
+		"https://evil.example":    "",
+	} {
+		body, _ := json.Marshal(map[string]any{"query": `{ health }`})
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Origin", origin)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if got := rr.Header().Get("Access-Control-Allow-Origin"); got != want {
+			t.Errorf("Origin %s: Access-Control-Allow-Origin = %q, want %q", origin, got, want)
+		}
 	}
 }
@@ -8,13 +8,17 @@ import (
 
 	"golang.org/x/sync/singleflight"
 
+	"github.com/dd0wney/graphdb/pkg/algorithms"
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 	"github.com/dd0wney/graphdb/pkg/audit"
 	"github.com/dd0wney/graphdb/pkg/auth"
 	"github.com/dd0wney/graphdb/pkg/auth/oidc"
+	"github.com/dd0wney/graphdb/pkg/constraints"
 	"github.com/dd0wney/graphdb/pkg/encryption"
 	gqlpkg "github.com/dd0wney/graphdb/pkg/graphql"
 	"github.com/dd0wney/graphdb/pkg/health"
 	"github.com/dd0wney/graphdb/pkg/intelligence"
+	"github.com/dd0wney/graphdb/pkg/licensing"
 	"github.com/dd0wney/graphdb/pkg/masking"
 	"github.com/dd0wney/graphdb/pkg/metrics"
 	"github.com/dd0wney/graphdb/pkg/query"
@@ -43,37 +47,53 @@ type Server struct {
 	graphqlHandlers    sync.Map           // map[string]*gqlpkg.GraphQLHandler
 	schemaSingleflight singleflight.Group // dedupes concurrent first-request schema builds per tenant
 
-	complexityConfig    *gqlpkg.ComplexityConfig // GraphQL query complexity limits
-	limitConfig         *gqlpkg.LimitConfig      // GraphQL result limits
-	authHandler         *auth.AuthHandler
-	userHandler         *auth.UserManagementHandler
-	jwtManager          *auth.JWTManager
-	userStore           *auth.UserStore
-	apiKeyStore         *auth.APIKeyStore
-	auditLogger         audit.Logger                 // Interface for audit logging (in-memory or persistent)
-	inMemoryAuditLogger *audit.AuditLogger           // In-memory logger for GetEvents/GetRecentEvents
-	persistentAudit     *audit.PersistentAuditLogger // Persistent logger (nil if disabled)
-	maskingPolicyStore  *masking.PolicyStore         // F3: per-tenant masking policies (in-memory; lost on restart)
-	masker              *masking.Masker              // F3: shared Masker (holds token cache across requests)
-	metricsRegistry     *metrics.Registry
-	healthChecker       *health.HealthChecker
-	tlsConfig           *tlspkg.Config
-	corsConfig          *CORSConfig                 // CORS configuration for cross-origin requests
-	rateLimiter         *RateLimiter                // Rate limiter for API requests
-	authRateLimiter     *RateLimiter                // Stricter rate limiter for auth endpoints (brute-force prevention)
-	encryptionEngine    encryption.EncryptDecrypter // Handles data encryption/decryption
-	keyManager          encryption.KeyProvider      // Manages encryption keys
-	oidcHandler         *oidc.OIDCHandler           // OIDC authentication handler (nil if disabled)
-	oidcConfig          *oidc.Config                // OIDC configuration
-	tokenValidator      auth.TokenValidator         // Composite validator for JWT + OIDC
-	tenantStore         *tenant.TenantStore         // Multi-tenant store (nil if single-tenant mode)
-	startTime           time.Time
-	version             string
-	port                int
-	dataDir             string         // Data directory for auth persistence
-	environment         string         // "live" or "test" - for API key environment enforcement
-	metricsStopCh       chan struct{}  // Stop channel for metrics goroutine
-	metricsWg           sync.WaitGroup // WaitGroup for metrics goroutine
+	complexityConfig        *gqlpkg.ComplexityConfig // GraphQL query complexity limits
+	limitConfig             *gqlpkg.LimitConfig      // GraphQL result limits
+	authHandler             *auth.AuthHandler
+	userHandler             *auth.UserManagementHandler
+	jwtManager              *auth.JWTManager
+	userStore               *auth.UserStore
+	apiKeyStore             *auth.APIKeyStore
+	auditLogger             audit.Logger                 // Interface for audit logging (in-memory or persistent)
+	inMemoryAuditLogger     *audit.AuditLogger           // In-memory logger for GetEvents/GetRecentEvents
+	persistentAudit         *audit.PersistentAuditLogger // Persistent logger (nil if disabled)
+	mutationAuditLogger     *audit.AuditLogger           // Mutation audit trail served by GET /audit
+	persistentMutationAudit *audit.PersistentAuditLogger // Persistent mutation trail (nil if disabled)
+	maskingPolicyStore      *masking.PolicyStore         // F3: per-tenant masking policies (in-memory; lost on restart)
+	masker                  *masking.Masker              // F3: shared Masker (holds token cache across requests)
+	metricsRegistry         *metrics.Registry
+	routes                  middleware.RouteMatcher // registered routes, so metrics label unmatched URLs "other"; set in Start
+	algorithmCache          *algorithms.ResultCache // results of /algorithms runs, dropped when the graph version moves; see cachedAlgorithm
+	etagKey                 []byte                  // keys node/edge ETags so they can't fingerprint masked values; see entityETag
+	graphEpoch              string                  // per-process prefix for graph-version ETags, which restart at 0; see graphVersionETag
+	healthChecker           *health.HealthChecker
+	tlsConfig               *tlspkg.Config
+	corsConfig              *CORSConfig                 // CORS configuration for cross-origin requests
+	securityHeaders         *SecurityHeadersConfig      // CSP/HSTS/Referrer/Permissions policy; TLSEnabled comes from tlsConfig
+	bodyLimits              *BodyLimitConfig            // request body caps, default and per route; nil uses DefaultBodyLimitConfig
+	rateLimiter             *RateLimiter                // Rate limiter for API requests
+	rateLimitByTenant       bool                        // Also charge a bucket per resolved tenant (RATE_LIMIT_KEY=tenant)
+	authRateLimiter         *RateLimiter                // Stricter rate limiter for auth endpoints (brute-force prevention)
+	encryptionEngine        encryption.EncryptDecrypter // Handles data encryption/decryption
+	keyManager              encryption.KeyProvider      // Manages encryption keys
+	oidcHandler             *oidc.OIDCHandler           // OIDC authentication handler (nil if disabled)
+	oidcConfig              *oidc.Config                // OIDC configuration
+	tokenValidator          auth.TokenValidator         // Composite validator for JWT + OIDC
+	tenantStore             *tenant.TenantStore         // Multi-tenant store (nil if single-tenant mode)
+	constraintValidator     *constraints.Validator      // Write-time node/edge constraints (nil = no checks)
+	license                 *licensing.License          // Loaded license gating Enterprise algorithms (nil = Community)
+	startTime               time.Time
+	version                 string
+	port                    int
+	dataDir                 string         // Data directory for auth persistence
+	environment             string         // "live" or "test" - for API key environment enforcement
+	metricsStopCh           chan struct{}  // Stop channel for metrics goroutine
+	metricsWg               sync.WaitGroup // WaitGroup for metrics goroutine
+
+	// streamsStopCh is closed when the HTTP server shuts down so long-lived
+	// streams (GET /events) end instead of holding Shutdown to its deadline.
+	streamsStopCh   chan struct{}
+	streamsStopOnce sync.Once
 
 	// autoEmbedPool is the worker pool that backs the AutoEmbedObserver
 	// when GRAPHDB_AUTO_EMBED_ENABLED is true. nil when auto-embed is
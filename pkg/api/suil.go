@@ -188,3 +188,13 @@ func (w *suilStatusWriter) WriteHeader(code int) {
 	w.status = code
 	w.ResponseWriter.WriteHeader(code)
 }
+
+func (w *suilStatusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *suilStatusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
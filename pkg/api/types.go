@@ -1,6 +1,11 @@
 package api
 
-import "time"
+import (
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/constraints"
+	"github.com/dd0wney/graphdb/pkg/query"
+)
 
 // API Request/Response Types
 
@@ -8,7 +13,18 @@ import "time"
 type QueryRequest struct {
 	Query          string         `json:"query"`
 	Parameters     map[string]any `json:"parameters,omitempty"`
-	TimeoutSeconds *int           `json:"timeout_seconds,omitempty"` // Optional per-query timeout (1-300 seconds)
+	Params         map[string]any `json:"params,omitempty"`          // Short alias for Parameters; ignored when Parameters is set
+	TimeoutSeconds *int           `json:"timeout_seconds,omitempty"` // Optional per-query timeout (1-300 seconds, or up to QUERY_MAX_DURATION_SECONDS)
+	MaxRows        *int           `json:"max_rows,omitempty"`        // Optional per-query row cap (at most QUERY_MAX_ROWS when set)
+}
+
+// queryParams returns the request's $name bindings, preferring the
+// long-form "parameters" field over the "params" alias.
+func (r *QueryRequest) queryParams() map[string]any {
+	if len(r.Parameters) > 0 {
+		return r.Parameters
+	}
+	return r.Params
 }
 
 // QueryResponse represents a query execution response
@@ -17,6 +33,9 @@ type QueryResponse struct {
 	Rows    []map[string]any `json:"rows"`
 	Count   int              `json:"count"`
 	Time    string           `json:"time"`
+	// Plan is set instead of executing the query when it was posted with
+	// ?explain=true; Columns and Rows then hold the same plan as a table.
+	Plan *query.Plan `json:"plan,omitempty"`
 }
 
 // NodeRequest represents a node creation/update request
@@ -39,6 +58,9 @@ type EdgeRequest struct {
 	Type       string         `json:"type"`
 	Properties map[string]any `json:"properties,omitempty"`
 	Weight     float64        `json:"weight"`
+	// Undirected creates an edge traversable both ways, listed at both
+	// endpoints by adjacency queries but stored and counted once.
+	Undirected bool `json:"undirected,omitempty"`
 }
 
 // EdgeUpdateRequest is the body for PUT /edges/{id}. Weight is a *pointer* so an
@@ -58,6 +80,7 @@ type EdgeResponse struct {
 	Type       string         `json:"type"`
 	Properties map[string]any `json:"properties"`
 	Weight     float64        `json:"weight"`
+	Undirected bool           `json:"undirected,omitempty"`
 }
 
 // TraversalRequest represents a graph traversal request
@@ -66,6 +89,20 @@ type TraversalRequest struct {
 	MaxDepth    int      `json:"max_depth"`
 	EdgeTypes   []string `json:"edge_types,omitempty"`
 	Direction   string   `json:"direction"` // "outgoing", "incoming", "both"
+	// CollectPaths adds Paths to the response: the route to each reached node.
+	CollectPaths bool `json:"collect_paths,omitempty"`
+	// Property filters, each a map of property -> value compared for
+	// equality. A node must match every NodeFilter entry and must not match
+	// all of NodeExclude to be returned or traversed through; edges
+	// likewise. E.g. node_exclude {"zone": "safety"} stops the traversal
+	// at safety-zone nodes.
+	NodeFilter  map[string]any `json:"node_filter,omitempty"`
+	NodeExclude map[string]any `json:"node_exclude,omitempty"`
+	EdgeFilter  map[string]any `json:"edge_filter,omitempty"`
+	EdgeExclude map[string]any `json:"edge_exclude,omitempty"`
+	// AsOf (Unix seconds) follows only edges valid at that time; see
+	// AlgorithmRequest.AsOf.
+	AsOf *int64 `json:"as_of,omitempty"`
 }
 
 // TraversalResponse represents traversal results
@@ -77,6 +114,10 @@ type TraversalResponse struct {
 	// before exhausting the reachable set (security audit H-8). The same
 	// signal is mirrored in the X-Truncated response header.
 	Truncated bool `json:"truncated,omitempty"`
+	// Paths maps each reached node to the node IDs on the route the
+	// traversal took to it from the start node, both ends included. Only
+	// set when the request asks for collect_paths.
+	Paths map[uint64][]uint64 `json:"paths,omitempty"`
 }
 
 // ShortestPathRequest represents a shortest path query
@@ -84,14 +125,23 @@ type ShortestPathRequest struct {
 	StartNodeID uint64 `json:"start_node_id"`
 	EndNodeID   uint64 `json:"end_node_id"`
 	MaxDepth    int    `json:"max_depth"`
+	// Weighted asks for the least-cost path, each edge costing its weight,
+	// instead of the fewest hops.
+	Weighted bool `json:"weighted,omitempty"`
+	// AsOf (Unix seconds) follows only edges valid at that time; see
+	// AlgorithmRequest.AsOf.
+	AsOf *int64 `json:"as_of,omitempty"`
 }
 
 // ShortestPathResponse represents the shortest path result
 type ShortestPathResponse struct {
 	Path   []uint64 `json:"path"`
 	Length int      `json:"length"`
-	Found  bool     `json:"found"`
-	Time   string   `json:"time"`
+	// Cost is the path's total edge weight for a weighted request, and
+	// its hop count otherwise.
+	Cost  float64 `json:"cost"`
+	Found bool    `json:"found"`
+	Time  string  `json:"time"`
 }
 
 // HealthResponse represents health check response
@@ -113,6 +163,19 @@ type MetricsResponse struct {
 	TotalQueries uint64  `json:"total_queries"`
 	AvgQueryTime float64 `json:"avg_query_time_ms"`
 
+	// Advances on every committed mutation; the GET /algorithms/* ETags
+	// are derived from it
+	GraphVersion uint64 `json:"graph_version"`
+
+	// Per-mutation counts and average latencies, keyed by operation
+	// (create_node, update_edge, ...)
+	Operations map[string]OperationMetrics `json:"operations,omitempty"`
+
+	// Nodes per label (a node with several labels counts under each) and
+	// edges per type
+	LabelCounts    map[string]int `json:"label_counts"`
+	EdgeTypeCounts map[string]int `json:"edge_type_counts"`
+
 	// System stats
 	MemoryUsedMB  uint64 `json:"memory_used_mb"`
 	MemoryTotalMB uint64 `json:"memory_total_mb"`
@@ -124,11 +187,17 @@ type MetricsResponse struct {
 	UptimeSeconds int64  `json:"uptime_seconds"`
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+// OperationMetrics is one entry of MetricsResponse.Operations
+type OperationMetrics struct {
+	Count        uint64  `json:"count"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+// ValidationErrorResponse is the 422 body returned when a write breaks a
+// registered constraint
+type ValidationErrorResponse struct {
+	ErrorResponse
+	Violations []constraints.Violation `json:"violations"`
 }
 
 // BatchNodeRequest represents a batch node creation request
@@ -159,6 +228,9 @@ type BatchEdgeResponse struct {
 type AlgorithmRequest struct {
 	Algorithm  string         `json:"algorithm"` // "pagerank", "betweenness", "louvain"
 	Parameters map[string]any `json:"parameters,omitempty"`
+	// AsOf (Unix seconds) runs the algorithm on the graph as it stood
+	// then: only edges whose valid_from / valid_to cover it are seen.
+	AsOf *int64 `json:"as_of,omitempty"`
 }
 
 // AlgorithmResponse represents algorithm execution results
@@ -24,13 +24,15 @@ const (
 type ResourceType string
 
 const (
-	ResourceNode       ResourceType = "node"
-	ResourceEdge       ResourceType = "edge"
-	ResourceQuery      ResourceType = "query"
-	ResourceAuth       ResourceType = "auth"
-	ResourceUser       ResourceType = "user"
-	ResourceKey        ResourceType = "apikey"
-	ResourceCompliance ResourceType = "compliance" // F3: masking policy CRUD + audit log queries
+	ResourceNode        ResourceType = "node"
+	ResourceEdge        ResourceType = "edge"
+	ResourceQuery       ResourceType = "query"
+	ResourceAuth        ResourceType = "auth"
+	ResourceUser        ResourceType = "user"
+	ResourceKey         ResourceType = "apikey"
+	ResourceCompliance  ResourceType = "compliance" // F3: masking policy CRUD + audit log queries
+	ResourceVectorIndex ResourceType = "vector_index"
+	ResourceSnapshot    ResourceType = "snapshot"
 )
 
 // Status represents the outcome of an action
@@ -55,7 +57,14 @@ type Event struct {
 	ErrorMessage string         `json:"error_message,omitempty"`
 	IPAddress    string         `json:"ip_address,omitempty"`
 	UserAgent    string         `json:"user_agent,omitempty"`
+	RequestID    string         `json:"request_id,omitempty"` // X-Request-ID of the API request that caused the event
 	Metadata     map[string]any `json:"metadata,omitempty"`
+
+	// Before and After summarize the resource on either side of a
+	// mutation (nil for a create's Before and a delete's After). Only
+	// entity-level mutation events set them.
+	Before map[string]any `json:"before,omitempty"`
+	After  map[string]any `json:"after,omitempty"`
 }
 
 // Filter represents filtering criteria for audit events
@@ -66,6 +75,7 @@ type Filter struct {
 	Action       Action
 	ResourceType ResourceType
 	ResourceID   string
+	RequestID    string
 	Status       Status
 	StartTime    *time.Time
 	EndTime      *time.Time
@@ -162,6 +172,9 @@ func (l *AuditLogger) GetEvents(filter *Filter) []*Event {
 			if filter.ResourceID != "" && event.ResourceID != filter.ResourceID {
 				continue
 			}
+			if filter.RequestID != "" && event.RequestID != filter.RequestID {
+				continue
+			}
 			if filter.Status != "" && event.Status != filter.Status {
 				continue
 			}
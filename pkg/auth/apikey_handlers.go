@@ -5,6 +5,8 @@ import (
 	"log"
 	"net/http"
 	"time"
+
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 )
 
 // APIKeyHandler handles API key management endpoints
@@ -113,9 +115,8 @@ func respondJSON(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// respondError writes the API's JSON error envelope (see
+// middleware.ErrorResponse).
 func respondError(w http.ResponseWriter, status int, message string) {
-	respondJSON(w, status, map[string]any{
-		"error":   http.StatusText(status),
-		"message": message,
-	})
+	middleware.WriteError(w, nil, status, message)
 }
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 )
 
 const (
@@ -110,10 +112,8 @@ func (h *AuthHandler) respondJSON(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// respondError writes the API's JSON error envelope (see
+// middleware.ErrorResponse).
 func (h *AuthHandler) respondError(w http.ResponseWriter, status int, message string) {
-	response := ErrorResponse{
-		Error:   http.StatusText(status),
-		Message: message,
-	}
-	h.respondJSON(w, status, response)
+	middleware.WriteError(w, nil, status, message)
 }
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 )
 
 // TestAuthHandler_Login tests the login endpoint
@@ -57,7 +59,15 @@ func TestAuthHandler_Login(t *testing.T) {
 				"password": "WrongPass123!",
 			},
 			expectedStatus: http.StatusUnauthorized,
-			checkResponse:  nil,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var envelope middleware.ErrorResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to parse error: %v", err)
+				}
+				if envelope.Code != "UNAUTHORIZED" || envelope.Status != http.StatusUnauthorized || envelope.Error == "" {
+					t.Errorf("Expected the API error envelope, got %s", rr.Body.String())
+				}
+			},
 		},
 		{
 			name: "Non-existent user",
@@ -46,9 +46,3 @@ type UserResponse struct {
 	Username string `json:"username"`
 	Role     string `json:"role"`
 }
-
-// ErrorResponse represents error responses
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 	"strings"
+
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 )
 
 // UserManagementHandler handles user management endpoints
@@ -296,12 +298,10 @@ func (h *UserManagementHandler) respondJSON(w http.ResponseWriter, status int, d
 	}
 }
 
+// respondError writes the API's JSON error envelope (see
+// middleware.ErrorResponse).
 func (h *UserManagementHandler) respondError(w http.ResponseWriter, status int, message string) {
-	response := ErrorResponse{
-		Error:   http.StatusText(status),
-		Message: message,
-	}
-	h.respondJSON(w, status, response)
+	middleware.WriteError(w, nil, status, message)
 }
 
 // Request/Response types
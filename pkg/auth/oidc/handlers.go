@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 	"github.com/dd0wney/graphdb/pkg/auth"
 )
 
@@ -456,12 +457,6 @@ type TokenValidationRequest struct {
 	IDToken string `json:"id_token"`
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-}
-
 // Helper methods
 
 func (h *OIDCHandler) respondJSON(w http.ResponseWriter, status int, data any) {
@@ -472,10 +467,8 @@ func (h *OIDCHandler) respondJSON(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// respondError writes the API's JSON error envelope (see
+// middleware.ErrorResponse).
 func (h *OIDCHandler) respondError(w http.ResponseWriter, status int, message string) {
-	response := ErrorResponse{
-		Error:   http.StatusText(status),
-		Message: message,
-	}
-	h.respondJSON(w, status, response)
+	middleware.WriteError(w, nil, status, message)
 }
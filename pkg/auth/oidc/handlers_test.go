@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 	"github.com/dd0wney/graphdb/pkg/auth"
 )
 
@@ -298,10 +299,10 @@ func TestOIDCHandler_Callback_InvalidState(t *testing.T) {
 		t.Errorf("Expected status 400, got %d", resp.StatusCode)
 	}
 
-	var errResp ErrorResponse
+	var errResp middleware.ErrorResponse
 	if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-		if !strings.Contains(errResp.Message, "state") {
-			t.Errorf("Expected error about state, got: %s", errResp.Message)
+		if !strings.Contains(errResp.Error, "state") {
+			t.Errorf("Expected error about state, got: %s", errResp.Error)
 		}
 	}
 }
@@ -65,10 +65,11 @@ func (cc *CardinalityConstraint) Validate(graph GraphReader) ([]Violation, error
 		if cc.Min > 0 && edgeCount < cc.Min {
 			nodeID := node.ID
 			violations = append(violations, Violation{
-				Type:       CardinalityViolation,
-				Severity:   Error,
-				NodeID:     &nodeID,
-				Constraint: cc.Name(),
+				Type:           CardinalityViolation,
+				Severity:       Error,
+				NodeID:         &nodeID,
+				Constraint:     cc.Name(),
+				ConstraintType: "CardinalityConstraint",
 				Message: fmt.Sprintf("Node %d has %d %s edge(s) of type '%s', minimum is %d",
 					node.ID, edgeCount, cc.Direction, cc.EdgeType, cc.Min),
 				Details: map[string]any{
@@ -85,10 +86,11 @@ func (cc *CardinalityConstraint) Validate(graph GraphReader) ([]Violation, error
 		if cc.Max > 0 && edgeCount > cc.Max {
 			nodeID := node.ID
 			violations = append(violations, Violation{
-				Type:       CardinalityViolation,
-				Severity:   Error,
-				NodeID:     &nodeID,
-				Constraint: cc.Name(),
+				Type:           CardinalityViolation,
+				Severity:       Error,
+				NodeID:         &nodeID,
+				Constraint:     cc.Name(),
+				ConstraintType: "CardinalityConstraint",
 				Message: fmt.Sprintf("Node %d has %d %s edge(s) of type '%s', maximum is %d",
 					node.ID, edgeCount, cc.Direction, cc.EdgeType, cc.Max),
 				Details: map[string]any{
@@ -0,0 +1,114 @@
+package constraints
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// EdgeConstraint restricts which nodes an edge type may connect, based on the
+// labels of its endpoints.
+//
+// FromLabels and ToLabels each match a node that satisfies ANY entry; an empty
+// list matches every node. An entry is either a label the node must carry or,
+// prefixed with "!", a label it must lack — so []string{"!SafetyCertified"}
+// matches any node without that label.
+//
+// With Forbidden set, every EdgeType edge whose endpoints both match is a
+// violation (a deny rule). Otherwise the rule is an allow rule: every
+// EdgeType edge must have matching endpoints, and any that doesn't is a
+// violation.
+type EdgeConstraint struct {
+	EdgeType   string   // Edge type the rule applies to
+	FromLabels []string // Source label patterns (empty = any)
+	ToLabels   []string // Target label patterns (empty = any)
+	Forbidden  bool     // Deny matching edges instead of requiring them to match
+}
+
+// Name returns the constraint name
+func (ec *EdgeConstraint) Name() string {
+	kind := "Allow"
+	if ec.Forbidden {
+		kind = "Forbid"
+	}
+	return fmt.Sprintf("EdgeConstraint(%s %s:[%s]->[%s])", kind, ec.EdgeType,
+		strings.Join(ec.FromLabels, "|"), strings.Join(ec.ToLabels, "|"))
+}
+
+// Validate checks every edge of EdgeType against the endpoint rule
+func (ec *EdgeConstraint) Validate(graph GraphReader) ([]Violation, error) {
+	violations := make([]Violation, 0)
+
+	edges, err := graph.FindEdgesByTypeAcrossTenants(ec.EdgeType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find edges of type %s: %w", ec.EdgeType, err)
+	}
+
+	for _, edge := range edges {
+		from, err := graph.GetNode(edge.FromNodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source node %d of edge %d: %w", edge.FromNodeID, edge.ID, err)
+		}
+		to, err := graph.GetNode(edge.ToNodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get target node %d of edge %d: %w", edge.ToNodeID, edge.ID, err)
+		}
+
+		violations = append(violations, ec.CheckEdge(edge, from, to)...)
+	}
+
+	return violations, nil
+}
+
+// CheckEdge validates a single edge given its endpoints; edges of other
+// types always pass
+func (ec *EdgeConstraint) CheckEdge(edge *storage.Edge, from, to *storage.Node) []Violation {
+	if edge.Type != ec.EdgeType {
+		return nil
+	}
+	matches := matchesLabelPatterns(from, ec.FromLabels) && matchesLabelPatterns(to, ec.ToLabels)
+	if matches != ec.Forbidden {
+		return nil
+	}
+
+	reason := "forbidden"
+	if !ec.Forbidden {
+		reason = "not allowed"
+	}
+	edgeID := edge.ID
+	return []Violation{{
+		Type:           ForbiddenEdge,
+		Severity:       Error,
+		EdgeID:         &edgeID,
+		Constraint:     ec.Name(),
+		ConstraintType: "EdgeConstraint",
+		Message: fmt.Sprintf("Edge %d %s from node %d %v to node %d %v is %s",
+			edge.ID, edge.Type, from.ID, from.Labels, to.ID, to.Labels, reason),
+		Details: map[string]any{
+			"edge_type":   edge.Type,
+			"from_node":   from.ID,
+			"to_node":     to.ID,
+			"from_labels": from.Labels,
+			"to_labels":   to.Labels,
+		},
+	}}
+}
+
+// matchesLabelPatterns reports whether node satisfies any of the patterns
+// (see EdgeConstraint). An empty pattern list matches every node.
+func matchesLabelPatterns(node *storage.Node, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if label, negated := strings.CutPrefix(pattern, "!"); negated {
+			if !node.HasLabel(label) {
+				return true
+			}
+		} else if node.HasLabel(pattern) {
+			return true
+		}
+	}
+	return false
+}
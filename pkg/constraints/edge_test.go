@@ -0,0 +1,90 @@
+package constraints
+
+import "testing"
+
+// TestEdgeConstraint_Forbidden tests a deny rule with a negated label, the
+// ISO 15288 priority inversion: no COMMAND_AUTHORITY from an uncertified
+// element to a SafetyCritical one
+func TestEdgeConstraint_Forbidden(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	plc, _ := graph.CreateNode([]string{"Technical", "SafetyCritical"}, nil)
+	scada, _ := graph.CreateNode([]string{"Technical"}, nil)
+	officer, _ := graph.CreateNode([]string{"Human", "SafetyCertified"}, nil)
+	admin, _ := graph.CreateNode([]string{"Human"}, nil)
+
+	graph.CreateEdge(officer.ID, plc.ID, "COMMAND_AUTHORITY", nil, 1.0)
+	graph.CreateEdge(admin.ID, scada.ID, "COMMAND_AUTHORITY", nil, 1.0)
+	bad, _ := graph.CreateEdge(admin.ID, plc.ID, "COMMAND_AUTHORITY", nil, 1.0)
+	graph.CreateEdge(admin.ID, plc.ID, "MONITORS", nil, 1.0) // other type: ignored
+
+	constraint := &EdgeConstraint{
+		EdgeType:   "COMMAND_AUTHORITY",
+		FromLabels: []string{"!SafetyCertified"},
+		ToLabels:   []string{"SafetyCritical"},
+		Forbidden:  true,
+	}
+
+	violations, err := constraint.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 violation, got %d: %+v", len(violations), violations)
+	}
+	v := violations[0]
+	if v.EdgeID == nil || *v.EdgeID != bad.ID {
+		t.Errorf("Expected violation for edge %d, got %+v", bad.ID, v.EdgeID)
+	}
+	if v.Type != ForbiddenEdge || v.Details["from_node"] != admin.ID {
+		t.Errorf("Unexpected violation: %+v", v)
+	}
+}
+
+// TestEdgeConstraint_Allowed tests an allow rule flags edges whose endpoints
+// fall outside the permitted labels
+func TestEdgeConstraint_Allowed(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	human, _ := graph.CreateNode([]string{"Human"}, nil)
+	plc, _ := graph.CreateNode([]string{"PLC"}, nil)
+	scada, _ := graph.CreateNode([]string{"SCADA"}, nil)
+	room, _ := graph.CreateNode([]string{"Room"}, nil)
+
+	graph.CreateEdge(human.ID, plc.ID, "COMMAND_AUTHORITY", nil, 1.0)
+	graph.CreateEdge(human.ID, scada.ID, "COMMAND_AUTHORITY", nil, 1.0)
+	machine, _ := graph.CreateEdge(scada.ID, plc.ID, "COMMAND_AUTHORITY", nil, 1.0)
+	toRoom, _ := graph.CreateEdge(human.ID, room.ID, "COMMAND_AUTHORITY", nil, 1.0)
+
+	validator := NewValidator()
+	validator.AddConstraint(&EdgeConstraint{
+		EdgeType:   "COMMAND_AUTHORITY",
+		FromLabels: []string{"Human"},
+		ToLabels:   []string{"PLC", "SCADA"},
+	})
+
+	result, err := validator.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Valid || len(result.Violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %+v", result.Violations)
+	}
+	flagged := map[uint64]bool{}
+	for _, v := range result.Violations {
+		flagged[*v.EdgeID] = true
+	}
+	if !flagged[machine.ID] || !flagged[toRoom.ID] {
+		t.Errorf("Expected edges %d and %d flagged, got %v", machine.ID, toRoom.ID, flagged)
+	}
+}
+
+// TestEdgeConstraint_Name tests the rendered constraint name
+func TestEdgeConstraint_Name(t *testing.T) {
+	c := &EdgeConstraint{EdgeType: "FLOWS", FromLabels: []string{"A", "!B"}, Forbidden: true}
+	if got, want := c.Name(), "EdgeConstraint(Forbid FLOWS:[A|!B]->[])"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+}
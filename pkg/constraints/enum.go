@@ -0,0 +1,86 @@
+package constraints
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// EnumConstraint restricts a node property to a fixed set of values, catching
+// typos such as "criticaL" or an unknown zone name. A value matches only if
+// both its type and content equal one of Allowed; matching is case-sensitive.
+// Nodes without the property are ignored — pair with a Required
+// PropertyConstraint to demand it.
+type EnumConstraint struct {
+	NodeLabel    string          // Label to apply constraint to
+	PropertyName string          // Name of the property
+	Allowed      []storage.Value // Permitted values
+}
+
+// Name returns the constraint name
+func (ec *EnumConstraint) Name() string {
+	return fmt.Sprintf("EnumConstraint(%s.%s)", ec.NodeLabel, ec.PropertyName)
+}
+
+// Validate checks every node with the target label holds an allowed value
+func (ec *EnumConstraint) Validate(graph GraphReader) ([]Violation, error) {
+	violations := make([]Violation, 0)
+
+	nodes, err := graph.FindNodesByLabelAcrossTenants(ec.NodeLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find nodes with label %s: %w", ec.NodeLabel, err)
+	}
+
+	for _, node := range nodes {
+		violations = append(violations, ec.CheckNode(node)...)
+	}
+
+	return violations, nil
+}
+
+// CheckNode validates a single node; nodes without NodeLabel always pass
+func (ec *EnumConstraint) CheckNode(node *storage.Node) []Violation {
+	if !node.HasLabel(ec.NodeLabel) {
+		return nil
+	}
+	propValue, exists := node.GetProperty(ec.PropertyName)
+	if !exists || ec.allows(propValue) {
+		return nil
+	}
+
+	nodeID := node.ID
+	return []Violation{{
+		Type:           InvalidValue,
+		Severity:       Error,
+		NodeID:         &nodeID,
+		Constraint:     ec.Name(),
+		ConstraintType: "EnumConstraint",
+		Property:       ec.PropertyName,
+		Message: fmt.Sprintf("Node %d property '%s' has value '%s', allowed: %s",
+			node.ID, ec.PropertyName, propValue.String(), strings.Join(ec.allowedStrings(), ", ")),
+		Details: map[string]any{
+			"label":    ec.NodeLabel,
+			"property": ec.PropertyName,
+			"value":    propValue.String(),
+			"allowed":  ec.allowedStrings(),
+		},
+	}}
+}
+
+// allows reports whether value equals one of the allowed values
+func (ec *EnumConstraint) allows(value storage.Value) bool {
+	return slices.ContainsFunc(ec.Allowed, func(allowed storage.Value) bool {
+		return allowed.Type == value.Type && string(allowed.Data) == string(value.Data)
+	})
+}
+
+// allowedStrings renders the allowed values for messages
+func (ec *EnumConstraint) allowedStrings() []string {
+	out := make([]string, len(ec.Allowed))
+	for i, v := range ec.Allowed {
+		out[i] = v.String()
+	}
+	return out
+}
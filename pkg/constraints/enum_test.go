@@ -0,0 +1,79 @@
+package constraints
+
+import (
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// TestEnumConstraint_RejectsUnknownValues tests typos and wrong types are flagged
+func TestEnumConstraint_RejectsUnknownValues(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	asset := func(criticality storage.Value) uint64 {
+		n, _ := graph.CreateNode([]string{"Asset"}, map[string]storage.Value{"criticality": criticality})
+		return n.ID
+	}
+	asset(storage.StringValue("high"))
+	asset(storage.StringValue("low"))
+	typo := asset(storage.StringValue("criticaL"))
+	wrongType := asset(storage.IntValue(3))
+	graph.CreateNode([]string{"Asset"}, nil) // missing property is not this constraint's concern
+
+	constraint := &EnumConstraint{
+		NodeLabel:    "Asset",
+		PropertyName: "criticality",
+		Allowed: []storage.Value{
+			storage.StringValue("low"),
+			storage.StringValue("medium"),
+			storage.StringValue("high"),
+		},
+	}
+
+	violations, err := constraint.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %d: %+v", len(violations), violations)
+	}
+
+	byNode := make(map[uint64]Violation)
+	for _, v := range violations {
+		if v.Type != InvalidValue {
+			t.Errorf("Expected InvalidValue, got %v", v.Type)
+		}
+		byNode[*v.NodeID] = v
+	}
+	if v, ok := byNode[typo]; !ok || v.Details["value"] != "criticaL" {
+		t.Errorf("Expected violation for typo node %d with value criticaL, got %+v", typo, v)
+	}
+	if _, ok := byNode[wrongType]; !ok {
+		t.Errorf("Expected violation for int-valued node %d", wrongType)
+	}
+}
+
+// TestEnumConstraint_AllValid tests a clean label passes
+func TestEnumConstraint_AllValid(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	graph.CreateNode([]string{"Device"}, map[string]storage.Value{"zone": storage.StringValue("OT")})
+	graph.CreateNode([]string{"Device"}, map[string]storage.Value{"zone": storage.StringValue("IT")})
+	graph.CreateNode([]string{"Other"}, map[string]storage.Value{"zone": storage.StringValue("DMZ")})
+
+	constraint := &EnumConstraint{
+		NodeLabel:    "Device",
+		PropertyName: "zone",
+		Allowed:      []storage.Value{storage.StringValue("IT"), storage.StringValue("OT")},
+	}
+
+	violations, err := constraint.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected 0 violations, got %d", len(violations))
+	}
+}
@@ -0,0 +1,106 @@
+package constraints
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// constraintSpec is one entry of a constraints file. Kind picks the
+// constraint type; the other fields are read as that type needs them.
+type constraintSpec struct {
+	Kind       string   `json:"kind"` // "property", "enum", "range" or "edge"
+	Label      string   `json:"label"`
+	Property   string   `json:"property"`
+	Required   bool     `json:"required"`
+	Allowed    []any    `json:"allowed"`
+	Min        *float64 `json:"min"`
+	Max        *float64 `json:"max"`
+	EdgeType   string   `json:"edge_type"`
+	FromLabels []string `json:"from_labels"`
+	ToLabels   []string `json:"to_labels"`
+	Forbidden  bool     `json:"forbidden"`
+}
+
+// LoadValidatorFromFile builds a Validator from a JSON file holding an array
+// of constraint definitions, e.g.
+//
+//	[
+//	  {"kind": "property", "label": "User", "property": "email", "required": true},
+//	  {"kind": "enum", "label": "Asset", "property": "zone", "allowed": ["IT", "OT"]},
+//	  {"kind": "range", "label": "Link", "property": "latency_ms", "min": 0},
+//	  {"kind": "edge", "edge_type": "CONTROLS", "from_labels": ["!Certified"],
+//	   "to_labels": ["SafetyCritical"], "forbidden": true}
+//	]
+//
+// Only the kinds that can judge one entity on its own are accepted, as the
+// file exists to configure write-time checks (see ValidateNode).
+func LoadValidatorFromFile(path string) (*Validator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read constraints file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var specs []constraintSpec
+	if err := decoder.Decode(&specs); err != nil {
+		return nil, fmt.Errorf("failed to parse constraints file %s: %w", path, err)
+	}
+
+	validator := NewValidator()
+	for i, spec := range specs {
+		constraint, err := spec.build()
+		if err != nil {
+			return nil, fmt.Errorf("constraints file %s entry %d: %w", path, i, err)
+		}
+		validator.AddConstraint(constraint)
+	}
+	return validator, nil
+}
+
+// build turns the spec into its constraint, checking the fields its kind
+// needs are set.
+func (spec constraintSpec) build() (Constraint, error) {
+	if spec.Kind != "edge" && (spec.Label == "" || spec.Property == "") {
+		return nil, fmt.Errorf("%s constraint needs label and property", spec.Kind)
+	}
+
+	switch spec.Kind {
+	case "property":
+		return &PropertyConstraint{
+			NodeLabel:    spec.Label,
+			PropertyName: spec.Property,
+			Required:     spec.Required,
+		}, nil
+	case "enum":
+		if len(spec.Allowed) == 0 {
+			return nil, fmt.Errorf("enum constraint needs allowed values")
+		}
+		allowed := make([]storage.Value, len(spec.Allowed))
+		for i, v := range spec.Allowed {
+			allowed[i] = storage.ValueFromJSON(v)
+		}
+		return &EnumConstraint{NodeLabel: spec.Label, PropertyName: spec.Property, Allowed: allowed}, nil
+	case "range":
+		if spec.Min == nil && spec.Max == nil {
+			return nil, fmt.Errorf("range constraint needs min or max")
+		}
+		return &RangeConstraint{NodeLabel: spec.Label, PropertyName: spec.Property, Min: spec.Min, Max: spec.Max}, nil
+	case "edge":
+		if spec.EdgeType == "" {
+			return nil, fmt.Errorf("edge constraint needs edge_type")
+		}
+		return &EdgeConstraint{
+			EdgeType:   spec.EdgeType,
+			FromLabels: spec.FromLabels,
+			ToLabels:   spec.ToLabels,
+			Forbidden:  spec.Forbidden,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown constraint kind %q", spec.Kind)
+	}
+}
@@ -0,0 +1,82 @@
+package constraints
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+func writeConstraintsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "constraints.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write constraints file: %v", err)
+	}
+	return path
+}
+
+// TestLoadValidatorFromFile tests each kind loads into a working constraint
+func TestLoadValidatorFromFile(t *testing.T) {
+	path := writeConstraintsFile(t, `[
+		{"kind": "property", "label": "User", "property": "email", "required": true},
+		{"kind": "enum", "label": "Asset", "property": "zone", "allowed": ["IT", "OT"]},
+		{"kind": "range", "label": "Link", "property": "latency_ms", "min": 0, "max": 100},
+		{"kind": "edge", "edge_type": "CONTROLS", "from_labels": ["!Certified"], "to_labels": ["SafetyCritical"], "forbidden": true}
+	]`)
+
+	validator, err := LoadValidatorFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadValidatorFromFile failed: %v", err)
+	}
+	if got := len(validator.GetConstraints()); got != 4 {
+		t.Fatalf("Expected 4 constraints, got %d", got)
+	}
+
+	nodes := []struct {
+		node *storage.Node
+		want ViolationType
+	}{
+		{&storage.Node{Labels: []string{"User"}}, MissingProperty},
+		{&storage.Node{Labels: []string{"Asset"}, Properties: map[string]storage.Value{"zone": storage.StringValue("DMZ")}}, InvalidValue},
+		{&storage.Node{Labels: []string{"Link"}, Properties: map[string]storage.Value{"latency_ms": storage.IntValue(101)}}, OutOfRange},
+	}
+	for _, tt := range nodes {
+		violations := validator.ValidateNode(tt.node)
+		if len(violations) != 1 || violations[0].Type != tt.want {
+			t.Errorf("ValidateNode(%v) = %+v, want one %v", tt.node.Labels, violations, tt.want)
+		}
+	}
+
+	from := &storage.Node{ID: 1, Labels: []string{"Human"}}
+	to := &storage.Node{ID: 2, Labels: []string{"SafetyCritical"}}
+	edge := &storage.Edge{FromNodeID: 1, ToNodeID: 2, Type: "CONTROLS"}
+	if violations := validator.ValidateEdge(edge, from, to); len(violations) != 1 || violations[0].Type != ForbiddenEdge {
+		t.Errorf("ValidateEdge = %+v, want one ForbiddenEdge", violations)
+	}
+}
+
+// TestLoadValidatorFromFile_Invalid tests malformed definitions are refused
+func TestLoadValidatorFromFile_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not JSON":       `{`,
+		"unknown kind":   `[{"kind": "unique", "label": "User", "property": "email"}]`,
+		"unknown field":  `[{"kind": "property", "label": "User", "property": "email", "requird": true}]`,
+		"missing label":  `[{"kind": "property", "property": "email"}]`,
+		"empty enum":     `[{"kind": "enum", "label": "Asset", "property": "zone"}]`,
+		"unbounded":      `[{"kind": "range", "label": "Link", "property": "latency_ms"}]`,
+		"edge sans type": `[{"kind": "edge", "forbidden": true}]`,
+	}
+	for name, content := range tests {
+		if _, err := LoadValidatorFromFile(writeConstraintsFile(t, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	_, err := LoadValidatorFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil || !strings.Contains(err.Error(), "failed to read") {
+		t.Errorf("missing file: got %v, want a read error", err)
+	}
+}
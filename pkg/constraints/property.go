@@ -32,53 +32,91 @@ func (pc *PropertyConstraint) Validate(graph GraphReader) ([]Violation, error) {
 	}
 
 	for _, node := range nodes {
-		// Check if property exists
-		propValue, exists := node.GetProperty(pc.PropertyName)
-
-		if !exists {
-			// Property missing
-			if pc.Required {
-				nodeID := node.ID
-				violations = append(violations, Violation{
-					Type:       MissingProperty,
-					Severity:   Error,
-					NodeID:     &nodeID,
-					Constraint: pc.Name(),
-					Message:    fmt.Sprintf("Node %d missing required property '%s'", node.ID, pc.PropertyName),
-					Details: map[string]any{
-						"label":    pc.NodeLabel,
-						"property": pc.PropertyName,
-					},
-				})
-			}
-			continue
+		nodeViolations, err := pc.checkNode(node)
+		violations = append(violations, nodeViolations...)
+		if err != nil {
+			return violations, err
 		}
+	}
+
+	return violations, nil
+}
 
-		// Check type if specified
-		if pc.Type != 0 && propValue.Type != pc.Type {
+// CheckNode validates a single node; nodes without NodeLabel always pass.
+// A misconfigured Min/Max (wrong type for the property) is reported as an
+// InvalidStructure violation, since CheckNode has no error return.
+func (pc *PropertyConstraint) CheckNode(node *storage.Node) []Violation {
+	if !node.HasLabel(pc.NodeLabel) {
+		return nil
+	}
+	violations, err := pc.checkNode(node)
+	if err != nil {
+		violations = append(violations, Violation{
+			Type:           InvalidStructure,
+			Severity:       Error,
+			Constraint:     pc.Name(),
+			ConstraintType: "PropertyConstraint",
+			Property:       pc.PropertyName,
+			Message:        fmt.Sprintf("Constraint misconfigured: %v", err),
+		})
+	}
+	return violations
+}
+
+// checkNode applies the constraint to one node already known to carry NodeLabel
+func (pc *PropertyConstraint) checkNode(node *storage.Node) ([]Violation, error) {
+	violations := make([]Violation, 0)
+
+	// Check if property exists
+	propValue, exists := node.GetProperty(pc.PropertyName)
+
+	if !exists {
+		// Property missing
+		if pc.Required {
 			nodeID := node.ID
 			violations = append(violations, Violation{
-				Type:       InvalidType,
-				Severity:   Error,
-				NodeID:     &nodeID,
-				Constraint: pc.Name(),
-				Message: fmt.Sprintf("Node %d property '%s' has wrong type",
-					node.ID, pc.PropertyName),
+				Type:           MissingProperty,
+				Severity:       Error,
+				NodeID:         &nodeID,
+				Constraint:     pc.Name(),
+				ConstraintType: "PropertyConstraint",
+				Property:       pc.PropertyName,
+				Message:        fmt.Sprintf("Node %d missing required property '%s'", node.ID, pc.PropertyName),
 				Details: map[string]any{
-					"label":         pc.NodeLabel,
-					"property":      pc.PropertyName,
-					"actual_type":   propValue.Type,
-					"expected_type": pc.Type,
+					"label":    pc.NodeLabel,
+					"property": pc.PropertyName,
 				},
 			})
-			continue // Don't check range if type is wrong
 		}
+		return violations, nil
+	}
 
-		// Check range for numeric types
-		if pc.Min != nil || pc.Max != nil {
-			if err := pc.validateRange(node, propValue, &violations); err != nil {
-				return violations, err
-			}
+	// Check type if specified
+	if pc.Type != 0 && propValue.Type != pc.Type {
+		nodeID := node.ID
+		violations = append(violations, Violation{
+			Type:           InvalidType,
+			Severity:       Error,
+			NodeID:         &nodeID,
+			Constraint:     pc.Name(),
+			ConstraintType: "PropertyConstraint",
+			Property:       pc.PropertyName,
+			Message: fmt.Sprintf("Node %d property '%s' has wrong type",
+				node.ID, pc.PropertyName),
+			Details: map[string]any{
+				"label":         pc.NodeLabel,
+				"property":      pc.PropertyName,
+				"actual_type":   propValue.Type,
+				"expected_type": pc.Type,
+			},
+		})
+		return violations, nil // Don't check range if type is wrong
+	}
+
+	// Check range for numeric types
+	if pc.Min != nil || pc.Max != nil {
+		if err := pc.validateRange(node, propValue, &violations); err != nil {
+			return violations, err
 		}
 	}
 
@@ -103,10 +141,12 @@ func (pc *PropertyConstraint) validateRange(node *storage.Node, propValue storag
 			}
 			if value < minVal {
 				*violations = append(*violations, Violation{
-					Type:       OutOfRange,
-					Severity:   Error,
-					NodeID:     &nodeID,
-					Constraint: pc.Name(),
+					Type:           OutOfRange,
+					Severity:       Error,
+					NodeID:         &nodeID,
+					Constraint:     pc.Name(),
+					ConstraintType: "PropertyConstraint",
+					Property:       pc.PropertyName,
 					Message: fmt.Sprintf("Node %d property '%s' value %d is below minimum %d",
 						node.ID, pc.PropertyName, value, minVal),
 					Details: map[string]any{
@@ -126,10 +166,12 @@ func (pc *PropertyConstraint) validateRange(node *storage.Node, propValue storag
 			}
 			if value > maxVal {
 				*violations = append(*violations, Violation{
-					Type:       OutOfRange,
-					Severity:   Error,
-					NodeID:     &nodeID,
-					Constraint: pc.Name(),
+					Type:           OutOfRange,
+					Severity:       Error,
+					NodeID:         &nodeID,
+					Constraint:     pc.Name(),
+					ConstraintType: "PropertyConstraint",
+					Property:       pc.PropertyName,
 					Message: fmt.Sprintf("Node %d property '%s' value %d is above maximum %d",
 						node.ID, pc.PropertyName, value, maxVal),
 					Details: map[string]any{
@@ -155,10 +197,12 @@ func (pc *PropertyConstraint) validateRange(node *storage.Node, propValue storag
 			}
 			if value < minVal {
 				*violations = append(*violations, Violation{
-					Type:       OutOfRange,
-					Severity:   Error,
-					NodeID:     &nodeID,
-					Constraint: pc.Name(),
+					Type:           OutOfRange,
+					Severity:       Error,
+					NodeID:         &nodeID,
+					Constraint:     pc.Name(),
+					ConstraintType: "PropertyConstraint",
+					Property:       pc.PropertyName,
 					Message: fmt.Sprintf("Node %d property '%s' value %.2f is below minimum %.2f",
 						node.ID, pc.PropertyName, value, minVal),
 					Details: map[string]any{
@@ -178,10 +222,12 @@ func (pc *PropertyConstraint) validateRange(node *storage.Node, propValue storag
 			}
 			if value > maxVal {
 				*violations = append(*violations, Violation{
-					Type:       OutOfRange,
-					Severity:   Error,
-					NodeID:     &nodeID,
-					Constraint: pc.Name(),
+					Type:           OutOfRange,
+					Severity:       Error,
+					NodeID:         &nodeID,
+					Constraint:     pc.Name(),
+					ConstraintType: "PropertyConstraint",
+					Property:       pc.PropertyName,
 					Message: fmt.Sprintf("Node %d property '%s' value %.2f is above maximum %.2f",
 						node.ID, pc.PropertyName, value, maxVal),
 					Details: map[string]any{
@@ -0,0 +1,121 @@
+package constraints
+
+import (
+	"fmt"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// RangeConstraint bounds a numeric node property to the inclusive range
+// [Min, Max]. A nil bound is unbounded on that side. Int and float values
+// are both compared as float64; a non-numeric value is reported as
+// InvalidType. Nodes without the property are ignored — pair with a
+// Required PropertyConstraint to demand it.
+type RangeConstraint struct {
+	NodeLabel    string   // Label to apply constraint to
+	PropertyName string   // Name of the property
+	Min          *float64 // Inclusive lower bound (nil = unbounded)
+	Max          *float64 // Inclusive upper bound (nil = unbounded)
+}
+
+// Name returns the constraint name
+func (rc *RangeConstraint) Name() string {
+	return fmt.Sprintf("RangeConstraint(%s.%s,[%s,%s])",
+		rc.NodeLabel, rc.PropertyName, formatBound(rc.Min, "-inf"), formatBound(rc.Max, "+inf"))
+}
+
+// Validate checks the property of every node with the target label is in range
+func (rc *RangeConstraint) Validate(graph GraphReader) ([]Violation, error) {
+	violations := make([]Violation, 0)
+
+	nodes, err := graph.FindNodesByLabelAcrossTenants(rc.NodeLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find nodes with label %s: %w", rc.NodeLabel, err)
+	}
+
+	for _, node := range nodes {
+		violations = append(violations, rc.CheckNode(node)...)
+	}
+
+	return violations, nil
+}
+
+// CheckNode validates a single node; nodes without NodeLabel always pass
+func (rc *RangeConstraint) CheckNode(node *storage.Node) []Violation {
+	if !node.HasLabel(rc.NodeLabel) {
+		return nil
+	}
+	propValue, exists := node.GetProperty(rc.PropertyName)
+	if !exists {
+		return nil
+	}
+
+	nodeID := node.ID
+	value, ok := numericValue(propValue)
+	if !ok {
+		return []Violation{{
+			Type:           InvalidType,
+			Severity:       Error,
+			NodeID:         &nodeID,
+			Constraint:     rc.Name(),
+			ConstraintType: "RangeConstraint",
+			Property:       rc.PropertyName,
+			Message: fmt.Sprintf("Node %d property '%s' value '%s' is not numeric",
+				node.ID, rc.PropertyName, propValue.String()),
+			Details: map[string]any{
+				"label":       rc.NodeLabel,
+				"property":    rc.PropertyName,
+				"actual_type": propValue.Type,
+			},
+		}}
+	}
+
+	if (rc.Min == nil || value >= *rc.Min) && (rc.Max == nil || value <= *rc.Max) {
+		return nil
+	}
+
+	details := map[string]any{
+		"label":    rc.NodeLabel,
+		"property": rc.PropertyName,
+		"value":    value,
+	}
+	if rc.Min != nil {
+		details["min"] = *rc.Min
+	}
+	if rc.Max != nil {
+		details["max"] = *rc.Max
+	}
+	return []Violation{{
+		Type:           OutOfRange,
+		Severity:       Error,
+		NodeID:         &nodeID,
+		Constraint:     rc.Name(),
+		ConstraintType: "RangeConstraint",
+		Property:       rc.PropertyName,
+		Message: fmt.Sprintf("Node %d property '%s' value %g is outside [%s, %s]",
+			node.ID, rc.PropertyName, value, formatBound(rc.Min, "-inf"), formatBound(rc.Max, "+inf")),
+		Details: details,
+	}}
+}
+
+// numericValue returns an int or float property as float64
+func numericValue(v storage.Value) (float64, bool) {
+	switch v.Type {
+	case storage.TypeInt:
+		i, err := v.AsInt()
+		return float64(i), err == nil
+	case storage.TypeFloat:
+		f, err := v.AsFloat()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// formatBound renders an optional bound, using unbounded when it is nil
+func formatBound(bound *float64, unbounded string) string {
+	if bound == nil {
+		return unbounded
+	}
+	return fmt.Sprintf("%g", *bound)
+}
@@ -0,0 +1,72 @@
+package constraints
+
+import (
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// TestRangeConstraint_Bounds tests inclusive bounds across int and float values
+func TestRangeConstraint_Bounds(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	link := func(v storage.Value) uint64 {
+		n, _ := graph.CreateNode([]string{"Link"}, map[string]storage.Value{"latency_max": v})
+		return n.ID
+	}
+	link(storage.IntValue(1))     // on the lower bound
+	link(storage.FloatValue(100)) // on the upper bound
+	tooLow := link(storage.IntValue(0))
+	tooHigh := link(storage.FloatValue(100.5))
+	notNumeric := link(storage.StringValue("fast"))
+	graph.CreateNode([]string{"Link"}, nil)
+
+	lo, hi := 1.0, 100.0
+	constraint := &RangeConstraint{NodeLabel: "Link", PropertyName: "latency_max", Min: &lo, Max: &hi}
+
+	violations, err := constraint.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 3 {
+		t.Fatalf("Expected 3 violations, got %d: %+v", len(violations), violations)
+	}
+
+	byNode := make(map[uint64]Violation)
+	for _, v := range violations {
+		byNode[*v.NodeID] = v
+	}
+	if v := byNode[tooLow]; v.Type != OutOfRange || v.Details["value"] != 0.0 {
+		t.Errorf("Expected OutOfRange with value 0 for node %d, got %+v", tooLow, v)
+	}
+	if v := byNode[tooHigh]; v.Type != OutOfRange || v.Details["value"] != 100.5 {
+		t.Errorf("Expected OutOfRange with value 100.5 for node %d, got %+v", tooHigh, v)
+	}
+	if v := byNode[notNumeric]; v.Type != InvalidType {
+		t.Errorf("Expected InvalidType for node %d, got %+v", notNumeric, v)
+	}
+}
+
+// TestRangeConstraint_Unbounded tests nil bounds leave that side open
+func TestRangeConstraint_Unbounded(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	graph.CreateNode([]string{"Task"}, map[string]storage.Value{"priority": storage.IntValue(1 << 40)})
+	low, _ := graph.CreateNode([]string{"Task"}, map[string]storage.Value{"priority": storage.IntValue(-5)})
+
+	lo := 0.0
+	constraint := &RangeConstraint{NodeLabel: "Task", PropertyName: "priority", Min: &lo}
+
+	violations, err := constraint.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 1 || *violations[0].NodeID != low.ID {
+		t.Errorf("Expected single violation for node %d, got %+v", low.ID, violations)
+	}
+	if got, want := constraint.Name(), "RangeConstraint(Task.priority,[0,+inf])"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+}
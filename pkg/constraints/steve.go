@@ -0,0 +1,131 @@
+package constraints
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dd0wney/graphdb/pkg/algorithms"
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// SteveTest flags "Steve": a low-priority element that sits on many of the
+// graph's shortest paths, so that higher-priority systems quietly depend on
+// it. Named for the one administrator everything turns out to route through.
+//
+// A node is flagged when it carries label (any node, if label is empty), its
+// (normalized) betweenness centrality exceeds thresholdBC, its priorityProp
+// value is below priorityCutoff, and at least one neighbour in either
+// direction is at or above the cutoff — i.e. a low-priority element bridging
+// high-priority ones. Nodes without a numeric priorityProp are skipped, as
+// are neighbours without one. Violations are ordered by descending
+// betweenness.
+//
+// Betweenness is computed across every tenant. If it cannot be computed, a
+// single InvalidStructure violation describes the failure.
+func SteveTest(g algorithms.Graph, label, priorityProp string, priorityCutoff, thresholdBC float64) []Violation {
+	const name = "SteveTest"
+
+	betweenness, err := algorithms.BetweennessCentrality(g)
+	if err != nil {
+		return []Violation{{
+			Type:           InvalidStructure,
+			Severity:       Error,
+			Constraint:     name,
+			ConstraintType: name,
+			Message:        fmt.Sprintf("Failed to compute betweenness centrality: %v", err),
+		}}
+	}
+
+	violations := make([]Violation, 0)
+	for nodeID, bc := range betweenness {
+		if bc <= thresholdBC {
+			continue
+		}
+		node, err := g.GetNode(nodeID)
+		if err != nil || (label != "" && !node.HasLabel(label)) {
+			continue
+		}
+		priority, ok := priorityOf(node.Properties, priorityProp)
+		if !ok || priority >= priorityCutoff {
+			continue
+		}
+
+		bridgedID, bridgedPriority, found := highestPriorityNeighbor(g, nodeID, priorityProp)
+		if !found || bridgedPriority < priorityCutoff {
+			continue
+		}
+
+		id := nodeID
+		violations = append(violations, Violation{
+			Type:           PriorityBridge,
+			Severity:       Warning,
+			NodeID:         &id,
+			Constraint:     name,
+			ConstraintType: name,
+			Property:       priorityProp,
+			Message: fmt.Sprintf("Node %d (%s %g, below %g) has betweenness %.4f above %.4f and bridges node %d (%s %g)",
+				nodeID, priorityProp, priority, priorityCutoff, bc, thresholdBC, bridgedID, priorityProp, bridgedPriority),
+			Details: map[string]any{
+				"betweenness":      bc,
+				"threshold":        thresholdBC,
+				"priority":         priority,
+				"priority_cutoff":  priorityCutoff,
+				"bridged_node":     bridgedID,
+				"bridged_priority": bridgedPriority,
+			},
+		})
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		bi, _ := violations[i].Details["betweenness"].(float64)
+		bj, _ := violations[j].Details["betweenness"].(float64)
+		if bi != bj {
+			return bi > bj
+		}
+		return *violations[i].NodeID < *violations[j].NodeID
+	})
+	return violations
+}
+
+// highestPriorityNeighbor returns the neighbour of nodeID, in either edge
+// direction, with the highest numeric priorityProp.
+func highestPriorityNeighbor(g algorithms.Graph, nodeID uint64, priorityProp string) (uint64, float64, bool) {
+	var neighbors []uint64
+	if out, err := g.GetOutgoingEdges(nodeID); err == nil {
+		for _, e := range out {
+			neighbors = append(neighbors, e.ToNodeID)
+		}
+	}
+	if in, err := g.GetIncomingEdges(nodeID); err == nil {
+		for _, e := range in {
+			neighbors = append(neighbors, e.FromNodeID)
+		}
+	}
+
+	var bestID uint64
+	var best float64
+	found := false
+	for _, id := range neighbors {
+		if id == nodeID {
+			continue
+		}
+		neighbor, err := g.GetNode(id)
+		if err != nil {
+			continue
+		}
+		p, ok := priorityOf(neighbor.Properties, priorityProp)
+		if ok && (!found || p > best || (p == best && id < bestID)) {
+			bestID, best, found = id, p, true
+		}
+	}
+	return bestID, best, found
+}
+
+// priorityOf reads an int or float property from props as float64
+func priorityOf(props map[string]storage.Value, key string) (float64, bool) {
+	v, ok := props[key]
+	if !ok {
+		return 0, false
+	}
+	return numericValue(v)
+}
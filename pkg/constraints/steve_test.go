@@ -0,0 +1,99 @@
+package constraints
+
+import (
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// TestSteveTest_FlagsLowPriorityBridge tests a low-priority human sitting
+// between two high-priority systems is flagged, while unranked nodes, nodes
+// off the shortest paths and nodes without the label are not
+func TestSteveTest_FlagsLowPriorityBridge(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	element := func(labels []string, priority int64) uint64 {
+		n, _ := graph.CreateNode(labels, map[string]storage.Value{"priority": storage.IntValue(priority)})
+		return n.ID
+	}
+	scada := element([]string{"Technical"}, 10000)
+	plc := element([]string{"Technical", "SafetyCritical"}, 1_000_000)
+	steve := element([]string{"Human"}, 10)
+	historian := element([]string{"Technical"}, 1000)
+	gateway := element([]string{"Technical"}, 1000)
+	unranked, _ := graph.CreateNode([]string{"Human"}, nil)
+
+	// scada -> steve -> plc: every SCADA-to-PLC path runs through Steve.
+	graph.CreateEdge(scada, steve, "REMOTE_ACCESS", nil, 1.0)
+	graph.CreateEdge(steve, plc, "COMMAND_AUTHORITY", nil, 1.0)
+	// historian -> gateway -> scada: gateway bridges up to SCADA.
+	graph.CreateEdge(historian, gateway, "DATA_FLOW", nil, 1.0)
+	graph.CreateEdge(gateway, scada, "DATA_FLOW", nil, 1.0)
+	// An unranked bridge is ignored.
+	graph.CreateEdge(plc, unranked.ID, "REPORTS", nil, 1.0)
+	graph.CreateEdge(unranked.ID, historian, "REPORTS", nil, 1.0)
+
+	violations := SteveTest(graph, "Human", "priority", 1000, 0.0)
+
+	flagged := make(map[uint64]Violation)
+	for _, v := range violations {
+		flagged[*v.NodeID] = v
+	}
+	v, ok := flagged[steve]
+	if !ok {
+		t.Fatalf("Expected Steve (node %d) to be flagged, got %+v", steve, violations)
+	}
+	if v.Type != PriorityBridge || v.Property != "priority" || v.Details["bridged_node"] != plc {
+		t.Errorf("Unexpected Steve violation: %+v", v)
+	}
+	if _, ok := flagged[unranked.ID]; ok {
+		t.Error("Node without priority should be skipped")
+	}
+	if _, ok := flagged[gateway]; ok {
+		t.Error("gateway is not Human and should not be flagged")
+	}
+	if _, ok := flagged[historian]; ok {
+		t.Error("historian has no betweenness and should not be flagged")
+	}
+
+	// Without the label filter the gateway (1000) bridging up to SCADA
+	// (10000) is flagged once the cutoff is above its priority.
+	flagged = make(map[uint64]Violation)
+	for _, v := range SteveTest(graph, "", "priority", 10000, 0.0) {
+		flagged[*v.NodeID] = v
+	}
+	if _, ok := flagged[gateway]; !ok {
+		t.Error("gateway (1000) bridges scada (10000) and should be flagged below a 10000 cutoff")
+	}
+	if _, ok := flagged[scada]; ok {
+		t.Error("scada is at the cutoff and should not be flagged")
+	}
+}
+
+// TestSteveTest_Threshold tests a high threshold or low cutoff suppresses
+// findings
+func TestSteveTest_Threshold(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	a, _ := graph.CreateNode(nil, map[string]storage.Value{"priority": storage.IntValue(100)})
+	b, _ := graph.CreateNode(nil, map[string]storage.Value{"priority": storage.FloatValue(1.5)})
+	c, _ := graph.CreateNode(nil, map[string]storage.Value{"priority": storage.IntValue(100)})
+	graph.CreateEdge(a.ID, b.ID, "LINK", nil, 1.0)
+	graph.CreateEdge(b.ID, c.ID, "LINK", nil, 1.0)
+
+	// b carries the only a->c path: normalized betweenness 1/((3-1)*(3-2)) = 0.5.
+	if got := SteveTest(graph, "", "priority", 100, 0.4); len(got) != 1 || *got[0].NodeID != b.ID {
+		t.Errorf("threshold 0.4: expected b flagged, got %+v", got)
+	}
+	if got := SteveTest(graph, "", "priority", 100, 0.5); len(got) != 0 {
+		t.Errorf("threshold 0.5: betweenness must exceed it, got %+v", got)
+	}
+	if got := SteveTest(graph, "", "priority", 1.5, 0.4); len(got) != 0 {
+		t.Errorf("cutoff 1.5: b's priority must be below it, got %+v", got)
+	}
+	if got := SteveTest(graph, "", "priority", 101, 0.4); len(got) != 0 {
+		t.Errorf("cutoff 101: no neighbour reaches it, got %+v", got)
+	}
+}
@@ -1,6 +1,8 @@
 package constraints
 
 import (
+	"fmt"
+
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
@@ -50,6 +52,23 @@ func (s Severity) String() string {
 	}
 }
 
+// MarshalText encodes the severity as its name ("Error"), so JSON output
+// reads as text rather than an opaque integer.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses a severity name produced by MarshalText.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for _, candidate := range []Severity{Info, Warning, Error} {
+		if candidate.String() == string(text) {
+			*s = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q", text)
+}
+
 // ViolationType categorizes the type of constraint violation
 type ViolationType int
 
@@ -61,6 +80,8 @@ const (
 	ForbiddenEdge
 	InvalidStructure
 	UniquenessViolation
+	InvalidValue
+	PriorityBridge
 )
 
 func (vt ViolationType) String() string {
@@ -79,20 +100,44 @@ func (vt ViolationType) String() string {
 		return "InvalidStructure"
 	case UniquenessViolation:
 		return "UniquenessViolation"
+	case InvalidValue:
+		return "InvalidValue"
+	case PriorityBridge:
+		return "PriorityBridge"
 	default:
 		return "Unknown"
 	}
 }
 
-// Violation represents a constraint violation
+// MarshalText encodes the violation type as its name ("OutOfRange").
+func (vt ViolationType) MarshalText() ([]byte, error) {
+	return []byte(vt.String()), nil
+}
+
+// UnmarshalText parses a violation type name produced by MarshalText.
+func (vt *ViolationType) UnmarshalText(text []byte) error {
+	for candidate := MissingProperty; candidate <= PriorityBridge; candidate++ {
+		if candidate.String() == string(text) {
+			*vt = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown violation type %q", text)
+}
+
+// Violation represents a constraint violation. The fields are structured
+// so callers (and the REST API) can act on a violation without parsing
+// Message, which is for humans.
 type Violation struct {
-	Type       ViolationType
-	Severity   Severity
-	NodeID     *uint64
-	EdgeID     *uint64
-	Constraint string
-	Message    string
-	Details    map[string]any
+	Type           ViolationType  `json:"type"`
+	Severity       Severity       `json:"severity"`
+	NodeID         *uint64        `json:"node_id,omitempty"`  // Offending node, if any
+	EdgeID         *uint64        `json:"edge_id,omitempty"`  // Offending edge, if any
+	Constraint     string         `json:"constraint"`         // Constraint instance name, e.g. "EnumConstraint(Asset.zone)"
+	ConstraintType string         `json:"constraint_type"`    // Constraint kind, e.g. "EnumConstraint"
+	Property       string         `json:"property,omitempty"` // Property checked, for property-based constraints
+	Message        string         `json:"message"`
+	Details        map[string]any `json:"details,omitempty"`
 }
 
 // Constraint is the interface that all constraint types must implement.
@@ -106,3 +151,19 @@ type Constraint interface {
 	// Name returns a human-readable name for the constraint
 	Name() string
 }
+
+// NodeChecker is implemented by constraints that can judge a single node
+// without looking at the rest of the graph (property, enum and range rules).
+// Validator.ValidateNode uses it for write-time checks.
+type NodeChecker interface {
+	// CheckNode returns the violations node would introduce (empty if valid)
+	CheckNode(node *storage.Node) []Violation
+}
+
+// EdgeChecker is implemented by constraints that can judge a single edge
+// from the edge and its two endpoints alone. Validator.ValidateEdge uses it
+// for write-time checks.
+type EdgeChecker interface {
+	// CheckEdge returns the violations edge would introduce (empty if valid)
+	CheckEdge(edge *storage.Edge, from, to *storage.Node) []Violation
+}
@@ -2,6 +2,7 @@ package constraints
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
@@ -82,10 +83,12 @@ func (c *UniquePropertyConstraint) validateGlobal(graph GraphReader) []Violation
 		nodes, err = graph.FindNodesByLabelAcrossTenants(c.NodeLabel)
 		if err != nil {
 			return []Violation{{
-				Type:       InvalidStructure,
-				Severity:   Error,
-				Constraint: c.Name(),
-				Message:    fmt.Sprintf("Failed to query nodes: %v", err),
+				Type:           InvalidStructure,
+				Severity:       Error,
+				Constraint:     c.Name(),
+				ConstraintType: "UniquePropertyConstraint",
+				Property:       c.PropertyKey,
+				Message:        fmt.Sprintf("Failed to query nodes: %v", err),
 			}}
 		}
 	} else {
@@ -114,10 +117,12 @@ func (c *UniquePropertyConstraint) validateGlobal(graph GraphReader) []Violation
 			for i := 1; i < len(nodeIDs); i++ {
 				nodeID := nodeIDs[i]
 				violations = append(violations, Violation{
-					Type:       UniquenessViolation,
-					Severity:   Error,
-					NodeID:     &nodeID,
-					Constraint: c.Name(),
+					Type:           UniquenessViolation,
+					Severity:       Error,
+					NodeID:         &nodeID,
+					Constraint:     c.Name(),
+					ConstraintType: "UniquePropertyConstraint",
+					Property:       c.PropertyKey,
 					Message: fmt.Sprintf("Duplicate value '%s' for property '%s' (also exists on node %d)",
 						valueKey, c.PropertyKey, nodeIDs[0]),
 					Details: map[string]any{
@@ -160,10 +165,12 @@ func (c *UniquePropertyConstraint) validateLabelGroup(graph GraphReader, label s
 	nodes, err := graph.FindNodesByLabelAcrossTenants(label)
 	if err != nil {
 		return []Violation{{
-			Type:       InvalidStructure,
-			Severity:   Error,
-			Constraint: c.Name(),
-			Message:    fmt.Sprintf("Failed to query nodes with label '%s': %v", label, err),
+			Type:           InvalidStructure,
+			Severity:       Error,
+			Constraint:     c.Name(),
+			ConstraintType: "UniquePropertyConstraint",
+			Property:       c.PropertyKey,
+			Message:        fmt.Sprintf("Failed to query nodes with label '%s': %v", label, err),
 		}}
 	}
 
@@ -186,10 +193,12 @@ func (c *UniquePropertyConstraint) validateLabelGroup(graph GraphReader, label s
 			for i := 1; i < len(nodeIDs); i++ {
 				nodeID := nodeIDs[i]
 				violations = append(violations, Violation{
-					Type:       UniquenessViolation,
-					Severity:   Error,
-					NodeID:     &nodeID,
-					Constraint: c.Name(),
+					Type:           UniquenessViolation,
+					Severity:       Error,
+					NodeID:         &nodeID,
+					Constraint:     c.Name(),
+					ConstraintType: "UniquePropertyConstraint",
+					Property:       c.PropertyKey,
 					Message: fmt.Sprintf("Duplicate value '%s' for property '%s' within label '%s' (also exists on node %d)",
 						valueKey, c.PropertyKey, label, nodeIDs[0]),
 					Details: map[string]any{
@@ -207,6 +216,84 @@ func (c *UniquePropertyConstraint) validateLabelGroup(graph GraphReader, label s
 	return violations
 }
 
+// UniqueConstraint ensures no two nodes with NodeLabel share a value for
+// PropertyName — e.g. no two Person nodes with the same name. Unlike
+// UniquePropertyConstraint, which flags every duplicate node separately,
+// it reports each set of clashing nodes as a single violation, so one
+// collision reads as one problem. Values only clash when both type and
+// content match: IntValue(1) and StringValue("1") are distinct.
+//
+// Validation is after the fact. To reject duplicates at write time, create
+// nodes through GraphStorage.CreateNodeWithUniquePropertyForTenant with the
+// same label and property.
+type UniqueConstraint struct {
+	NodeLabel    string
+	PropertyName string
+}
+
+// Name returns a human-readable name for this constraint
+func (c *UniqueConstraint) Name() string {
+	return fmt.Sprintf("UniqueConstraint(%s.%s)", c.NodeLabel, c.PropertyName)
+}
+
+// uniqueValueKey identifies a property value by type and encoded bytes.
+type uniqueValueKey struct {
+	valueType storage.ValueType
+	data      string
+}
+
+// Validate groups NodeLabel nodes by PropertyName and returns one violation
+// per value held by more than one node, ordered by lowest node ID.
+func (c *UniqueConstraint) Validate(graph GraphReader) ([]Violation, error) {
+	nodes, err := graph.FindNodesByLabelAcrossTenants(c.NodeLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find nodes with label %s: %w", c.NodeLabel, err)
+	}
+
+	groups := make(map[uniqueValueKey][]uint64)
+	values := make(map[uniqueValueKey]storage.Value)
+	for _, node := range nodes {
+		prop, exists := node.Properties[c.PropertyName]
+		if !exists {
+			continue
+		}
+		key := uniqueValueKey{valueType: prop.Type, data: string(prop.Data)}
+		groups[key] = append(groups[key], node.ID)
+		values[key] = prop
+	}
+
+	violations := make([]Violation, 0)
+	for key, nodeIDs := range groups {
+		if len(nodeIDs) < 2 {
+			continue
+		}
+		sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i] < nodeIDs[j] })
+		first := nodeIDs[0]
+		value := values[key].String()
+		violations = append(violations, Violation{
+			Type:           UniquenessViolation,
+			Severity:       Error,
+			NodeID:         &first,
+			Constraint:     c.Name(),
+			ConstraintType: "UniqueConstraint",
+			Property:       c.PropertyName,
+			Message: fmt.Sprintf("%d %s nodes share %s '%s': %v",
+				len(nodeIDs), c.NodeLabel, c.PropertyName, value, nodeIDs),
+			Details: map[string]any{
+				"label":    c.NodeLabel,
+				"property": c.PropertyName,
+				"value":    value,
+				"node_ids": nodeIDs,
+			},
+		})
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return *violations[i].NodeID < *violations[j].NodeID
+	})
+	return violations, nil
+}
+
 // UniqueEdgeConstraint ensures only one edge of a specific type exists between two nodes.
 // This is useful for preventing duplicate relationships.
 type UniqueEdgeConstraint struct {
@@ -267,10 +354,11 @@ func (c *UniqueEdgeConstraint) Validate(graph GraphReader) ([]Violation, error)
 			for i := 1; i < len(edgeIDs); i++ {
 				edgeID := edgeIDs[i]
 				violations = append(violations, Violation{
-					Type:       UniquenessViolation,
-					Severity:   Error,
-					EdgeID:     &edgeID,
-					Constraint: c.Name(),
+					Type:           UniquenessViolation,
+					Severity:       Error,
+					EdgeID:         &edgeID,
+					Constraint:     c.Name(),
+					ConstraintType: "UniqueEdgeConstraint",
 					Message: fmt.Sprintf("Duplicate edge of type '%s' between nodes %s (edge %d already exists)",
 						c.EdgeType, pairKey, edgeIDs[0]),
 					Details: map[string]any{
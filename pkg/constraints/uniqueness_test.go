@@ -403,3 +403,79 @@ func TestUniqueEdgeConstraint_WithLabels(t *testing.T) {
 		}
 	}
 }
+
+// TestUniqueConstraint_ReportsEachDuplicateSet tests that each clashing value
+// produces exactly one violation listing every node that holds it
+func TestUniqueConstraint_ReportsEachDuplicateSet(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	person := func(name storage.Value) uint64 {
+		n, _ := graph.CreateNode([]string{"Person"}, map[string]storage.Value{"name": name})
+		return n.ID
+	}
+	alice1 := person(storage.StringValue("Alice"))
+	bob1 := person(storage.StringValue("Bob"))
+	alice2 := person(storage.StringValue("Alice"))
+	bob2 := person(storage.StringValue("Bob"))
+	alice3 := person(storage.StringValue("Alice"))
+	person(storage.StringValue("Carol"))
+	person(storage.StringValue("1"))
+	person(storage.IntValue(1)) // same text, different type: not a duplicate
+	graph.CreateNode([]string{"Person"}, nil)
+	graph.CreateNode([]string{"Company"}, map[string]storage.Value{
+		"name": storage.StringValue("Alice"), // other label: ignored
+	})
+
+	validator := NewValidator()
+	validator.AddConstraint(&UniqueConstraint{NodeLabel: "Person", PropertyName: "name"})
+
+	result, err := validator.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Valid {
+		t.Fatal("Expected result to be invalid")
+	}
+	if len(result.Violations) != 2 {
+		t.Fatalf("Expected 2 violations (Alice, Bob), got %d: %+v", len(result.Violations), result.Violations)
+	}
+
+	aliceSet := result.Violations[0]
+	if *aliceSet.NodeID != alice1 {
+		t.Errorf("First violation NodeID = %d, want %d", *aliceSet.NodeID, alice1)
+	}
+	ids, _ := aliceSet.Details["node_ids"].([]uint64)
+	if len(ids) != 3 || ids[0] != alice1 || ids[1] != alice2 || ids[2] != alice3 {
+		t.Errorf("Alice node_ids = %v, want [%d %d %d]", ids, alice1, alice2, alice3)
+	}
+	if aliceSet.Details["value"] != "Alice" || aliceSet.Type != UniquenessViolation {
+		t.Errorf("Unexpected Alice violation: %+v", aliceSet)
+	}
+
+	ids, _ = result.Violations[1].Details["node_ids"].([]uint64)
+	if len(ids) != 2 || ids[0] != bob1 || ids[1] != bob2 {
+		t.Errorf("Bob node_ids = %v, want [%d %d]", ids, bob1, bob2)
+	}
+}
+
+// TestUniqueConstraint_NoDuplicates tests a clean label passes
+func TestUniqueConstraint_NoDuplicates(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	graph.CreateNode([]string{"Person"}, map[string]storage.Value{"name": storage.StringValue("Alice")})
+	graph.CreateNode([]string{"Person"}, map[string]storage.Value{"name": storage.StringValue("Bob")})
+
+	constraint := &UniqueConstraint{NodeLabel: "Person", PropertyName: "name"}
+	violations, err := constraint.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected 0 violations, got %d", len(violations))
+	}
+	if got := constraint.Name(); got != "UniqueConstraint(Person.name)" {
+		t.Errorf("Name() = %q", got)
+	}
+}
@@ -1,14 +1,23 @@
 package constraints
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
 // ValidationResult contains the results of validating a graph against constraints
 type ValidationResult struct {
-	Valid      bool        // True if no violations found
-	Violations []Violation // List of all violations
-	CheckedAt  time.Time   // When validation was performed
+	Valid      bool        `json:"valid"`      // True if no violations found
+	Violations []Violation `json:"violations"` // List of all violations
+	CheckedAt  time.Time   `json:"checked_at"` // When validation was performed
+}
+
+// ToJSON encodes the result for API responses and reports. Violation types
+// and severities are rendered by name.
+func (vr *ValidationResult) ToJSON() ([]byte, error) {
+	return json.Marshal(vr)
 }
 
 // GetViolationsBySeverity returns violations filtered by severity level
@@ -79,6 +88,45 @@ func (v *Validator) Validate(graph GraphReader) (*ValidationResult, error) {
 	return result, nil
 }
 
+// ValidateNode checks a single node against every constraint that can judge
+// a node on its own (see NodeChecker), e.g. before it is written. Graph-wide
+// constraints such as uniqueness and cardinality are skipped; run Validate
+// for those. Returns nil when the node is valid. A node not yet stored
+// (ID 0) has no ID to report, so its violations carry no NodeID.
+func (v *Validator) ValidateNode(node *storage.Node) []Violation {
+	var violations []Violation
+	for _, constraint := range v.constraints {
+		if checker, ok := constraint.(NodeChecker); ok {
+			violations = append(violations, checker.CheckNode(node)...)
+		}
+	}
+	if node.ID == 0 {
+		for i := range violations {
+			violations[i].NodeID = nil
+		}
+	}
+	return violations
+}
+
+// ValidateEdge checks a single edge and its endpoints against every
+// constraint that can judge an edge on its own (see EdgeChecker). Like
+// ValidateNode, graph-wide constraints are skipped, and an edge not yet
+// stored (ID 0) gets no EdgeID. Returns nil when the edge is valid.
+func (v *Validator) ValidateEdge(edge *storage.Edge, from, to *storage.Node) []Violation {
+	var violations []Violation
+	for _, constraint := range v.constraints {
+		if checker, ok := constraint.(EdgeChecker); ok {
+			violations = append(violations, checker.CheckEdge(edge, from, to)...)
+		}
+	}
+	if edge.ID == 0 {
+		for i := range violations {
+			violations[i].EdgeID = nil
+		}
+	}
+	return violations
+}
+
 // GetConstraints returns all constraints in the validator
 func (v *Validator) GetConstraints() []Constraint {
 	return v.constraints
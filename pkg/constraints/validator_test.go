@@ -1,6 +1,7 @@
 package constraints
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -511,3 +512,145 @@ func TestValidator_ClearConstraints_Idempotent(t *testing.T) {
 		t.Error("Multiple clears caused issues")
 	}
 }
+
+// TestValidator_ValidateNode tests single-node validation without a graph
+func TestValidator_ValidateNode(t *testing.T) {
+	lo := 1.0
+	validator := NewValidator()
+	validator.AddConstraint(&PropertyConstraint{NodeLabel: "Link", PropertyName: "name", Required: true})
+	validator.AddConstraint(&RangeConstraint{NodeLabel: "Link", PropertyName: "latency_max", Min: &lo})
+	validator.AddConstraint(&EnumConstraint{
+		NodeLabel:    "Link",
+		PropertyName: "zone",
+		Allowed:      []storage.Value{storage.StringValue("OT")},
+	})
+	validator.AddConstraint(&UniqueConstraint{NodeLabel: "Link", PropertyName: "name"}) // graph-wide: skipped
+
+	bad := &storage.Node{
+		Labels: []string{"Link"},
+		Properties: map[string]storage.Value{
+			"latency_max": storage.IntValue(0),
+			"zone":        storage.StringValue("ot"),
+		},
+	}
+	violations := validator.ValidateNode(bad)
+	if len(violations) != 3 {
+		t.Fatalf("Expected 3 violations, got %d: %+v", len(violations), violations)
+	}
+	want := []ViolationType{MissingProperty, OutOfRange, InvalidValue}
+	for i, v := range violations {
+		if v.Type != want[i] {
+			t.Errorf("violation %d type = %v, want %v", i, v.Type, want[i])
+		}
+		if v.NodeID != nil {
+			t.Errorf("violation %d NodeID = %d, want none for an unsaved node", i, *v.NodeID)
+		}
+	}
+
+	good := &storage.Node{
+		Labels: []string{"Link"},
+		Properties: map[string]storage.Value{
+			"name":        storage.StringValue("uplink"),
+			"latency_max": storage.IntValue(5),
+			"zone":        storage.StringValue("OT"),
+		},
+	}
+	if violations := validator.ValidateNode(good); violations != nil {
+		t.Errorf("Expected no violations, got %+v", violations)
+	}
+
+	other := &storage.Node{Labels: []string{"Room"}}
+	if violations := validator.ValidateNode(other); violations != nil {
+		t.Errorf("Expected constraints on other labels to be skipped, got %+v", violations)
+	}
+}
+
+// TestValidator_ValidateEdge tests single-edge validation against endpoint rules
+func TestValidator_ValidateEdge(t *testing.T) {
+	validator := NewValidator()
+	validator.AddConstraint(&EdgeConstraint{
+		EdgeType:   "COMMAND_AUTHORITY",
+		FromLabels: []string{"!SafetyCertified"},
+		ToLabels:   []string{"SafetyCritical"},
+		Forbidden:  true,
+	})
+	validator.AddConstraint(&PropertyConstraint{NodeLabel: "Human", PropertyName: "role", Required: true}) // node-only: skipped
+
+	admin := &storage.Node{ID: 1, Labels: []string{"Human"}}
+	officer := &storage.Node{ID: 2, Labels: []string{"Human", "SafetyCertified"}}
+	plc := &storage.Node{ID: 3, Labels: []string{"SafetyCritical"}}
+
+	edge := &storage.Edge{FromNodeID: admin.ID, ToNodeID: plc.ID, Type: "COMMAND_AUTHORITY"}
+	violations := validator.ValidateEdge(edge, admin, plc)
+	if len(violations) != 1 || violations[0].Type != ForbiddenEdge {
+		t.Fatalf("Expected one ForbiddenEdge violation, got %+v", violations)
+	}
+	if violations[0].EdgeID != nil {
+		t.Errorf("EdgeID = %d, want none for an unsaved edge", *violations[0].EdgeID)
+	}
+
+	edge = &storage.Edge{FromNodeID: officer.ID, ToNodeID: plc.ID, Type: "COMMAND_AUTHORITY"}
+	if violations := validator.ValidateEdge(edge, officer, plc); violations != nil {
+		t.Errorf("Expected no violations, got %+v", violations)
+	}
+}
+
+// TestValidationResult_ToJSON tests violations encode with structured,
+// named fields and decode back losslessly
+func TestValidationResult_ToJSON(t *testing.T) {
+	graph := setupTestGraph(t)
+	defer func() { _ = graph.Close() }()
+
+	node, _ := graph.CreateNode([]string{"Asset"}, map[string]storage.Value{
+		"zone": storage.StringValue("DMZZ"),
+	})
+
+	validator := NewValidator()
+	validator.AddConstraint(&EnumConstraint{
+		NodeLabel:    "Asset",
+		PropertyName: "zone",
+		Allowed:      []storage.Value{storage.StringValue("DMZ")},
+	})
+	result, err := validator.Validate(graph)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	data, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	violations, _ := raw["violations"].([]any)
+	if raw["valid"] != false || len(violations) != 1 {
+		t.Fatalf("unexpected JSON: %s", data)
+	}
+	v, _ := violations[0].(map[string]any)
+	for key, want := range map[string]any{
+		"type":            "InvalidValue",
+		"severity":        "Error",
+		"node_id":         float64(node.ID),
+		"constraint_type": "EnumConstraint",
+		"property":        "zone",
+	} {
+		if v[key] != want {
+			t.Errorf("%s = %v, want %v", key, v[key], want)
+		}
+	}
+	if _, ok := v["edge_id"]; ok {
+		t.Error("edge_id should be omitted for node violations")
+	}
+
+	var decoded ValidationResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	got := decoded.Violations[0]
+	if got.Type != InvalidValue || got.Severity != Error || *got.NodeID != node.ID {
+		t.Errorf("round trip lost fields: %+v", got)
+	}
+}
@@ -0,0 +1,61 @@
+package encryption
+
+import "errors"
+
+// KeyRing encrypts with a current master key and decrypts with the current
+// key or any previous one. It carries data across a master-key rotation:
+// ciphertext written under a retired key stays readable until it has been
+// rewritten under the current key, after which the previous keys can be
+// dropped.
+//
+// AES-GCM authenticates every ciphertext, so trying the wrong key fails
+// cleanly with ErrAuthenticationFailed rather than yielding garbage.
+type KeyRing struct {
+	current  *Engine
+	previous []*Engine
+}
+
+// NewKeyRing creates a key ring that encrypts under current and also
+// decrypts under each of previous, tried in order after current.
+func NewKeyRing(current []byte, previous ...[]byte) (*KeyRing, error) {
+	engine, err := NewEngine(current)
+	if err != nil {
+		return nil, err
+	}
+	kr := &KeyRing{current: engine}
+	for _, key := range previous {
+		prev, err := NewEngine(key)
+		if err != nil {
+			return nil, err
+		}
+		kr.previous = append(kr.previous, prev)
+	}
+	return kr, nil
+}
+
+// Encrypt encrypts plaintext under the current key
+func (kr *KeyRing) Encrypt(plaintext []byte) ([]byte, error) {
+	return kr.current.Encrypt(plaintext)
+}
+
+// Decrypt decrypts ciphertext under the current key, falling back to the
+// previous keys. Errors other than authentication failure (e.g. truncated
+// ciphertext) are returned without trying further keys.
+func (kr *KeyRing) Decrypt(ciphertext []byte) ([]byte, error) {
+	plaintext, err := kr.current.Decrypt(ciphertext)
+	for _, prev := range kr.previous {
+		if !errors.Is(err, ErrAuthenticationFailed) {
+			break
+		}
+		plaintext, err = prev.Decrypt(ciphertext)
+	}
+	return plaintext, err
+}
+
+// HasPrevious reports whether the ring holds any retired keys, i.e. whether
+// a rotation is still in progress.
+func (kr *KeyRing) HasPrevious() bool {
+	return len(kr.previous) > 0
+}
+
+var _ EncryptDecrypter = (*KeyRing)(nil)
@@ -0,0 +1,59 @@
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestKeyRing(t *testing.T) {
+	oldKey, _ := GenerateKey()
+	newKey, _ := GenerateKey()
+	oldEngine, _ := NewEngine(oldKey)
+	newEngine, _ := NewEngine(newKey)
+
+	legacy, err := oldEngine.Encrypt([]byte("written before rotation"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ring, err := NewKeyRing(newKey, oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	if !ring.HasPrevious() {
+		t.Error("HasPrevious = false with a previous key")
+	}
+
+	// Previous keys still decrypt.
+	if got, err := ring.Decrypt(legacy); err != nil || string(got) != "written before rotation" {
+		t.Fatalf("Decrypt(old ciphertext) = %q, %v", got, err)
+	}
+
+	// New ciphertext is under the current key only.
+	sealed, err := ring.Encrypt([]byte("written after"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newEngine.Decrypt(sealed); err != nil {
+		t.Errorf("current key cannot decrypt ring ciphertext: %v", err)
+	}
+	if _, err := oldEngine.Decrypt(sealed); err == nil {
+		t.Error("previous key decrypts new ciphertext")
+	}
+
+	// A key the ring does not hold still fails authentication.
+	otherKey, _ := GenerateKey()
+	other, _ := NewEngine(otherKey)
+	foreign, _ := other.Encrypt([]byte("x"))
+	if _, err := ring.Decrypt(foreign); !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("Decrypt(foreign) err = %v, want ErrAuthenticationFailed", err)
+	}
+
+	if _, err := NewKeyRing(newKey, bytes.Repeat([]byte("x"), 7)); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("NewKeyRing(bad previous) err = %v, want ErrInvalidKey", err)
+	}
+	if ring, _ := NewKeyRing(newKey); ring.HasPrevious() {
+		t.Error("HasPrevious = true with no previous keys")
+	}
+}
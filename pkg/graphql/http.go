@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/dd0wney/graphdb/pkg/api/middleware"
 	"github.com/graphql-go/graphql"
 )
 
@@ -38,30 +39,22 @@ func NewGraphQLHandler(schema graphql.Schema) *GraphQLHandler {
 	}
 }
 
-// ServeHTTP handles HTTP requests for GraphQL queries
+// ServeHTTP handles HTTP requests for GraphQL queries. It sets no CORS
+// headers: cross-origin policy belongs to the surrounding middleware
+// (middleware.CORS), which answers preflight requests before they get here.
 func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Set CORS headers
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 	w.Header().Set("Content-Type", "application/json")
 
-	// Handle preflight OPTIONS request
-	if r.Method == "OPTIONS" {
-		w.WriteHeader(http.StatusOK)
-		return
-	}
-
 	// Only allow POST requests
 	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		middleware.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	// Parse request body
 	var req GraphQLRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		middleware.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
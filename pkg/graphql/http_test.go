@@ -209,7 +209,9 @@ func TestGraphQLHTTPHandlerMethodNotAllowed(t *testing.T) {
 	}
 }
 
-// TestGraphQLHTTPHandlerCORS tests CORS headers
+// TestGraphQLHTTPHandlerCORS: the handler leaves CORS to the wrapping
+// middleware; a hardcoded wildcard here overrode the server's configured
+// origins.
 func TestGraphQLHTTPHandlerCORS(t *testing.T) {
 	tmpDir := t.TempDir()
 	config := storage.StorageConfig{
@@ -237,17 +239,13 @@ func TestGraphQLHTTPHandlerCORS(t *testing.T) {
 	body, _ := json.Marshal(queryReq)
 	req := httptest.NewRequest("POST", "/graphql", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Origin", "https://evil.example")
 
 	rr := httptest.NewRecorder()
 	handler.ServeHTTP(rr, req)
 
-	// Verify CORS headers are set
-	if rr.Header().Get("Access-Control-Allow-Origin") == "" {
-		t.Error("CORS header 'Access-Control-Allow-Origin' not set")
-	}
-
-	if rr.Header().Get("Access-Control-Allow-Methods") == "" {
-		t.Error("CORS header 'Access-Control-Allow-Methods' not set")
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want unset", got)
 	}
 }
 
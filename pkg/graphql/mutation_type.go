@@ -2,6 +2,8 @@ package graphql
 
 import (
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
@@ -112,3 +114,45 @@ func buildMutationType(gs *storage.GraphStorage, edgeType *graphql.Object, deps
 		},
 	})
 }
+
+// IsMutation reports whether query defines a mutation operation, so callers
+// can refuse it for read-only principals before execution. Any mutation in
+// the document counts, whichever operation the request names. A query that
+// does not parse reports false; execution rejects it anyway.
+func IsMutation(query string) bool {
+	return len(mutationOperations(query)) > 0
+}
+
+// MutationFields returns the top-level field names selected by the
+// mutation operations in query (e.g. "createNode"), in document order. It
+// never returns argument values, so the result is safe to log.
+func MutationFields(query string) []string {
+	var fields []string
+	for _, op := range mutationOperations(query) {
+		if op.SelectionSet == nil {
+			continue
+		}
+		for _, sel := range op.SelectionSet.Selections {
+			if field, ok := sel.(*ast.Field); ok && field.Name != nil {
+				fields = append(fields, field.Name.Value)
+			}
+		}
+	}
+	return fields
+}
+
+// mutationOperations parses query and returns its mutation operations, or
+// nil if it does not parse.
+func mutationOperations(query string) []*ast.OperationDefinition {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+	var ops []*ast.OperationDefinition
+	for _, def := range document.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok && op.Operation == ast.OperationTypeMutation {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
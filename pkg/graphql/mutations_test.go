@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
 
 	"github.com/graphql-go/graphql"
@@ -384,3 +385,31 @@ func TestMutationErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestIsMutation(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`{ nodes { id } }`, false},
+		{`query Q { nodes { id } }`, false},
+		{`mutation { deleteNode(id: "1") { success } }`, true},
+		{`query Q { nodes { id } } mutation M { deleteNode(id: "1") { success } }`, true},
+		{`not graphql`, false},
+	}
+	for _, tt := range tests {
+		if got := IsMutation(tt.query); got != tt.want {
+			t.Errorf("IsMutation(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMutationFields(t *testing.T) {
+	query := `query Q { nodes { id } } mutation M { createNode(labels: ["P"], properties: "{\"ssn\":\"123\"}") { id } deleteNode(id: "1") { success } }`
+	if got, want := MutationFields(query), []string{"createNode", "deleteNode"}; !slices.Equal(got, want) {
+		t.Errorf("MutationFields = %v, want %v", got, want)
+	}
+	if got := MutationFields(`not graphql`); got != nil {
+		t.Errorf("MutationFields(unparseable) = %v, want nil", got)
+	}
+}
@@ -1,5 +1,7 @@
 package licensing
 
+import "errors"
+
 // Feature represents a GraphDB feature with licensing requirements
 type Feature struct {
 	Name         string
@@ -95,6 +97,24 @@ var (
 		Description:  "Multi-region replication",
 		RequiredTier: TierEnterprise,
 	}
+
+	FeaturePersonalizedPageRank = Feature{
+		Name:         "personalized_pagerank",
+		Description:  "PageRank with a personalization (restart) vector",
+		RequiredTier: TierEnterprise,
+	}
+
+	FeatureLouvain = Feature{
+		Name:         "louvain",
+		Description:  "Louvain modularity community detection",
+		RequiredTier: TierEnterprise,
+	}
+
+	FeatureMaxFlow = Feature{
+		Name:         "max_flow",
+		Description:  "Maximum flow / minimum cut",
+		RequiredTier: TierEnterprise,
+	}
 )
 
 // AllFeatures returns a list of all GraphDB features
@@ -119,6 +139,9 @@ func AllFeatures() []Feature {
 		FeatureSSO,
 		FeaturePrioritySupport,
 		FeatureMultiRegion,
+		FeaturePersonalizedPageRank,
+		FeatureLouvain,
+		FeatureMaxFlow,
 	}
 }
 
@@ -143,3 +166,55 @@ func FeaturesByTier(tier LicenseTier) []Feature {
 
 	return features
 }
+
+// ErrFeatureNotLicensed is matched (via errors.Is) by every
+// FeatureNotAvailableError: the loaded license doesn't cover the feature.
+var ErrFeatureNotLicensed = errors.New("feature not licensed")
+
+// Tier returns the tier a loaded license grants. A nil, inactive or
+// expired license grants Community, so a server without a license still
+// runs every Community feature.
+func (l *License) Tier() LicenseTier {
+	if l == nil || !l.IsActive() {
+		return TierCommunity
+	}
+	switch l.Type {
+	case LicenseTypeEnterprise:
+		return TierEnterprise
+	case LicenseTypeProfessional:
+		return TierPro
+	default:
+		return TierCommunity
+	}
+}
+
+// HasFeature reports whether license (the *License loaded at startup; nil
+// in Community edition) unlocks feature.
+func HasFeature(license *License, feature Feature) bool {
+	return tierIncludes(license.Tier(), feature.RequiredTier)
+}
+
+// CheckFeature returns a *FeatureNotAvailableError, which matches
+// ErrFeatureNotLicensed, if license doesn't unlock feature.
+func CheckFeature(license *License, feature Feature) error {
+	if HasFeature(license, feature) {
+		return nil
+	}
+	return &FeatureNotAvailableError{
+		Feature:      feature,
+		CurrentTier:  license.Tier(),
+		RequiredTier: feature.RequiredTier,
+	}
+}
+
+// tierIncludes reports whether tier meets or exceeds required.
+func tierIncludes(tier, required LicenseTier) bool {
+	switch tier {
+	case TierEnterprise:
+		return true
+	case TierPro:
+		return required == TierCommunity || required == TierPro
+	default:
+		return required == TierCommunity
+	}
+}
@@ -138,12 +138,30 @@ func GenerateLicenseID() (string, error) {
 	return "lic_" + hex.EncodeToString(randomBytes), nil
 }
 
+// DefaultExpiryWarningWindow is how long before ExpiresAt the server
+// starts warning that the license is about to expire.
+const DefaultExpiryWarningWindow = 30 * 24 * time.Hour
+
 // IsExpired checks if a license has expired
 func (l *License) IsExpired() bool {
+	return l.isExpiredAt(time.Now())
+}
+
+func (l *License) isExpiredAt(now time.Time) bool {
 	if l.ExpiresAt == nil {
 		return false // No expiration
 	}
-	return time.Now().After(*l.ExpiresAt)
+	return now.After(*l.ExpiresAt)
+}
+
+// ExpiresWithin reports whether an unexpired license expires within window
+// of now, and how long it has left. A license without ExpiresAt never does.
+func (l *License) ExpiresWithin(now time.Time, window time.Duration) (time.Duration, bool) {
+	if l.ExpiresAt == nil || l.isExpiredAt(now) {
+		return 0, false
+	}
+	remaining := l.ExpiresAt.Sub(now)
+	return remaining, remaining <= window
 }
 
 // IsActive checks if a license is currently active
@@ -183,8 +201,25 @@ func (l *License) Validate() error {
 	return l.ValidateWithOptions(DefaultValidationOptions())
 }
 
+// Validate performs comprehensive license validation as of now, so an
+// expired license is refused rather than treated as perpetually active.
+// A nil license (none loaded) is an error.
+func Validate(license *License, now time.Time) error {
+	if license == nil {
+		return &ValidationError{
+			Code:    "NO_LICENSE",
+			Message: "no license loaded",
+		}
+	}
+	return license.validateAt(DefaultValidationOptions(), now)
+}
+
 // ValidateWithOptions performs license validation with custom options
 func (l *License) ValidateWithOptions(opts ValidationOptions) error {
+	return l.validateAt(opts, time.Now())
+}
+
+func (l *License) validateAt(opts ValidationOptions, now time.Time) error {
 	// Check license key format
 	if !ValidateLicenseKey(l.Key) {
 		return &ValidationError{
@@ -202,10 +237,10 @@ func (l *License) ValidateWithOptions(opts ValidationOptions) error {
 	}
 
 	// Check expiration
-	if opts.CheckExpiration && l.IsExpired() {
+	if opts.CheckExpiration && l.isExpiredAt(now) {
 		return &ValidationError{
 			Code:    "EXPIRED_LICENSE",
-			Message: "license has expired",
+			Message: fmt.Sprintf("license expired at %s", l.ExpiresAt.Format(time.RFC3339)),
 		}
 	}
 
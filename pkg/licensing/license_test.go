@@ -1,8 +1,10 @@
 package licensing
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGenerateLicenseKey(t *testing.T) {
@@ -99,6 +101,40 @@ func TestLicenseIsExpired(t *testing.T) {
 	}
 }
 
+// TestValidate_Expiry pins that Validate judges expiry against the time it
+// is given, so an expired license is refused rather than treated as
+// perpetually active, and that ExpiresWithin flags the warning window.
+func TestValidate_Expiry(t *testing.T) {
+	key, err := GenerateLicenseKey(LicenseTypeEnterprise, "ops@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	expires := now.Add(10 * 24 * time.Hour)
+	license := &License{Key: key, Type: LicenseTypeEnterprise, Status: "active", ExpiresAt: &expires}
+
+	if err := Validate(license, now); err != nil {
+		t.Errorf("Validate before expiry = %v", err)
+	}
+	var verr *ValidationError
+	if err := Validate(license, expires.Add(time.Second)); !errors.As(err, &verr) || verr.Code != "EXPIRED_LICENSE" {
+		t.Errorf("Validate after expiry = %v, want EXPIRED_LICENSE", err)
+	}
+	if err := Validate(nil, now); err == nil {
+		t.Error("Validate(nil) succeeded")
+	}
+
+	if remaining, soon := license.ExpiresWithin(now, DefaultExpiryWarningWindow); !soon || remaining != 10*24*time.Hour {
+		t.Errorf("ExpiresWithin(30d) = %v, %v; want 240h, true", remaining, soon)
+	}
+	if _, soon := license.ExpiresWithin(now, 7*24*time.Hour); soon {
+		t.Error("ExpiresWithin(7d) = true for a license with 10 days left")
+	}
+	if _, soon := (&License{Status: "active"}).ExpiresWithin(now, DefaultExpiryWarningWindow); soon {
+		t.Error("ExpiresWithin = true for a license without ExpiresAt")
+	}
+}
+
 func TestLicenseIsActive(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -194,3 +230,46 @@ func BenchmarkGenerateLicenseKey(b *testing.B) {
 		GenerateLicenseKey(LicenseTypeProfessional, "test@example.com")
 	}
 }
+
+// TestHasFeature pins edition gating on the loaded license: no license
+// (Community edition) still runs the basic algorithms, and the Enterprise
+// algorithms refuse with ErrFeatureNotLicensed until an active Enterprise
+// license is loaded.
+func TestHasFeature(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		license *License
+		tier    LicenseTier
+	}{
+		{"no license", nil, TierCommunity},
+		{"professional", &License{Type: LicenseTypeProfessional, Status: "active"}, TierPro},
+		{"enterprise", &License{Type: LicenseTypeEnterprise, Status: "active"}, TierEnterprise},
+		{"cancelled enterprise", &License{Type: LicenseTypeEnterprise, Status: "cancelled"}, TierCommunity},
+		{"expired enterprise", &License{Type: LicenseTypeEnterprise, Status: "active", ExpiresAt: &past}, TierCommunity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.license.Tier(); got != tt.tier {
+				t.Errorf("Tier() = %s, want %s", got, tt.tier)
+			}
+			for _, feature := range AllFeatures() {
+				want := false
+				for _, f := range FeaturesByTier(tt.tier) {
+					want = want || f.Name == feature.Name
+				}
+				if got := HasFeature(tt.license, feature); got != want {
+					t.Errorf("HasFeature(%s) = %v, want %v", feature.Name, got, want)
+				}
+				err := CheckFeature(tt.license, feature)
+				if want && err != nil {
+					t.Errorf("CheckFeature(%s) = %v, want nil", feature.Name, err)
+				}
+				if !want && !errors.Is(err, ErrFeatureNotLicensed) {
+					t.Errorf("CheckFeature(%s) = %v, want ErrFeatureNotLicensed", feature.Name, err)
+				}
+			}
+		})
+	}
+}
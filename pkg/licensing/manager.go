@@ -134,6 +134,12 @@ func (e *FeatureNotAvailableError) Error() string {
 		e.Feature.Name, e.RequiredTier, e.CurrentTier)
 }
 
+// Unwrap lets callers match any licensing refusal with
+// errors.Is(err, ErrFeatureNotLicensed).
+func (e *FeatureNotAvailableError) Unwrap() error {
+	return ErrFeatureNotLicensed
+}
+
 // Stop stops the license manager
 func (m *Manager) Stop() {
 	if m.client != nil {
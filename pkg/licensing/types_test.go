@@ -369,7 +369,7 @@ func TestFeaturesByTier(t *testing.T) {
 		{
 			name:      "Enterprise tier",
 			tier:      TierEnterprise,
-			wantCount: 14, // all features
+			wantCount: 17, // all features
 			shouldHave: []Feature{
 				FeatureBasicQueries,
 				FeaturePageRank,
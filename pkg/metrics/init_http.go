@@ -38,4 +38,12 @@ func (r *Registry) initHTTPMetrics() {
 		},
 		[]string{"method", "path"},
 	)
+
+	r.HTTPPanicsTotal = promauto.With(r.registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "graphdb_http_panics_total",
+			Help: "Total number of panics recovered in HTTP handlers",
+		},
+		[]string{"method", "path"},
+	)
 }
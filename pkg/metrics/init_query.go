@@ -48,4 +48,12 @@ func (r *Registry) initQueryMetrics() {
 		},
 		[]string{"query_type"},
 	)
+
+	r.AlgorithmCacheLookups = promauto.With(r.registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "graphdb_algorithm_cache_lookups_total",
+			Help: "Algorithm result cache lookups, by algorithm and hit or miss",
+		},
+		[]string{"algorithm", "result"},
+	)
 }
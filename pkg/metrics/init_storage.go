@@ -20,6 +20,13 @@ func (r *Registry) initStorageMetrics() {
 		},
 	)
 
+	r.StorageQueries = promauto.With(r.registry).NewGauge(
+		prometheus.GaugeOpts{
+			Name: "graphdb_storage_queries",
+			Help: "Queries served by the storage engine since it opened, mirrored from its statistics",
+		},
+	)
+
 	r.StorageOperationsTotal = promauto.With(r.registry).NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "graphdb_storage_operations_total",
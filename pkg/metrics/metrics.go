@@ -10,6 +10,26 @@ func (r *Registry) RecordHTTPRequest(method, path, status string, duration time.
 	r.HTTPRequestDuration.WithLabelValues(method, path, status).Observe(duration.Seconds())
 }
 
+// RecordResponseSize records the size of an HTTP response body
+func (r *Registry) RecordResponseSize(method, path string, size float64) {
+	r.HTTPResponseSizeBytes.WithLabelValues(method, path).Observe(size)
+}
+
+// RecordHTTPPanic records a panic recovered in an HTTP handler
+func (r *Registry) RecordHTTPPanic(method, path string) {
+	r.HTTPPanicsTotal.WithLabelValues(method, path).Inc()
+}
+
+// IncHTTPRequestsInFlight marks the start of an HTTP request
+func (r *Registry) IncHTTPRequestsInFlight() {
+	r.HTTPRequestsInFlight.Inc()
+}
+
+// DecHTTPRequestsInFlight marks the end of an HTTP request
+func (r *Registry) DecHTTPRequestsInFlight() {
+	r.HTTPRequestsInFlight.Dec()
+}
+
 // RecordStorageOperation records a storage operation
 func (r *Registry) RecordStorageOperation(operation, status string, duration time.Duration) {
 	r.StorageOperationsTotal.WithLabelValues(operation, status).Inc()
@@ -36,6 +56,16 @@ func (r *Registry) RecordQuery(queryType, status string, duration time.Duration,
 	}
 }
 
+// RecordAlgorithmCache records one algorithm result cache lookup. It
+// satisfies algorithms.CacheRecorder.
+func (r *Registry) RecordAlgorithmCache(algorithm string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	r.AlgorithmCacheLookups.WithLabelValues(algorithm, result).Inc()
+}
+
 // UpdateClusterMetrics updates cluster-related metrics
 func (r *Registry) UpdateClusterMetrics(totalNodes, healthyNodes int, hasQuorum bool, epoch, term uint64) {
 	r.ClusterNodesTotal.Set(float64(totalNodes))
@@ -521,3 +521,29 @@ func BenchmarkSetGauge(b *testing.B) {
 		r.StorageNodesTotal.Set(float64(i))
 	}
 }
+
+func TestRecordHTTPPanic(t *testing.T) {
+	r := NewRegistry()
+
+	r.RecordHTTPPanic("GET", "/nodes/:id")
+	r.RecordHTTPPanic("GET", "/nodes/:id")
+
+	c, _ := r.HTTPPanicsTotal.GetMetricWithLabelValues("GET", "/nodes/:id")
+	if v := counterValue(t, c); v != 2 {
+		t.Errorf(`HTTPPanicsTotal{method="GET",path="/nodes/:id"} = %v, want 2`, v)
+	}
+}
+
+func TestRecordAlgorithmCache(t *testing.T) {
+	r := NewRegistry()
+
+	r.RecordAlgorithmCache("pagerank", false)
+	r.RecordAlgorithmCache("pagerank", true)
+	r.RecordAlgorithmCache("pagerank", true)
+
+	hits, _ := r.AlgorithmCacheLookups.GetMetricWithLabelValues("pagerank", "hit")
+	misses, _ := r.AlgorithmCacheLookups.GetMetricWithLabelValues("pagerank", "miss")
+	if h, m := counterValue(t, hits), counterValue(t, misses); h != 2 || m != 1 {
+		t.Errorf("pagerank hits/misses = %v/%v, want 2/1", h, m)
+	}
+}
@@ -13,10 +13,12 @@ type Registry struct {
 	HTTPRequestDuration   *prometheus.HistogramVec
 	HTTPRequestsInFlight  prometheus.Gauge
 	HTTPResponseSizeBytes *prometheus.HistogramVec
+	HTTPPanicsTotal       *prometheus.CounterVec
 
 	// Storage Metrics
 	StorageNodesTotal        prometheus.Gauge
 	StorageEdgesTotal        prometheus.Gauge
+	StorageQueries           prometheus.Gauge
 	StorageOperationsTotal   *prometheus.CounterVec
 	StorageOperationDuration *prometheus.HistogramVec
 	StorageDiskUsageBytes    prometheus.Gauge
@@ -33,6 +35,10 @@ type Registry struct {
 	QueryEdgesScanned *prometheus.HistogramVec
 	SlowQueries       *prometheus.CounterVec
 
+	// AlgorithmCacheLookups counts algorithm result cache lookups by
+	// result ("hit" or "miss").
+	AlgorithmCacheLookups *prometheus.CounterVec
+
 	// Cluster Metrics (HA)
 	ClusterNodesTotal        prometheus.Gauge
 	ClusterHealthyNodesTotal prometheus.Gauge
@@ -100,12 +100,9 @@ func (pg *PartitionedGraph) IsLocalNode(nodeID uint64) bool {
 
 // GetLocalNodes returns all nodes in this partition
 func (pg *PartitionedGraph) GetLocalNodes() ([]*storage.Node, error) {
-	stats := pg.graph.GetStatistics()
-	nodeCount := int(stats.NodeCount)
-
 	localNodes := make([]*storage.Node, 0)
 
-	for nodeID := uint64(1); nodeID <= uint64(nodeCount); nodeID++ {
+	for _, nodeID := range pg.graph.GetAllNodeIDs() {
 		if pg.IsLocalNode(nodeID) {
 			if node, err := pg.graph.GetNode(nodeID); err == nil {
 				localNodes = append(localNodes, node)
@@ -156,13 +153,12 @@ func ComputePartitionMetrics(graph *storage.GraphStorage, strategy PartitionStra
 	sizes := make([]int, partCount)
 	cuts := make([]int, partCount)
 
-	stats := graph.GetStatistics()
-	nodeCount := int(stats.NodeCount)
+	nodeIDs := graph.GetAllNodeIDs()
 	totalEdges := 0
 	totalCuts := 0
 
 	// Count nodes and edge cuts per partition
-	for nodeID := uint64(1); nodeID <= uint64(nodeCount); nodeID++ {
+	for _, nodeID := range nodeIDs {
 		partition := strategy.GetPartition(nodeID)
 		sizes[partition]++
 
@@ -183,7 +179,7 @@ func ComputePartitionMetrics(graph *storage.GraphStorage, strategy PartitionStra
 	}
 
 	// Calculate load balance (stddev from perfect balance)
-	avgSize := float64(nodeCount) / float64(partCount)
+	avgSize := float64(len(nodeIDs)) / float64(partCount)
 	variance := 0.0
 	for _, size := range sizes {
 		diff := float64(size) - avgSize
@@ -0,0 +1,109 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// TenantParam is the parameter the API server sets on every plugin
+// algorithm run to the caller's tenant, overwriting any client-supplied
+// value. Run receives the whole store, so a plugin that reads nodes must
+// scope itself with it (e.g. storage.GraphStorage.GetNodeForTenant).
+const TenantParam = "tenant_id"
+
+// AlgorithmPlugin is a custom graph algorithm. Registered algorithms are
+// listed and run by the CLI `algo` command and by POST /algorithms/{name}.
+type AlgorithmPlugin interface {
+	// Name is the algorithm's registry key, e.g. "max-clique"
+	Name() string
+
+	// Run executes the algorithm. params are the caller's parameters
+	// (decoded JSON on the API, --key=value flags on the CLI); the result
+	// must be JSON-encodable.
+	Run(graph *storage.GraphStorage, params map[string]any) (any, error)
+}
+
+var (
+	// ErrAlgorithmExists is returned when registering a name twice.
+	ErrAlgorithmExists = errors.New("algorithm already registered")
+
+	// ErrAlgorithmNotFound is returned when no algorithm has the name.
+	ErrAlgorithmNotFound = errors.New("algorithm not found")
+)
+
+// AlgorithmRegistry holds AlgorithmPlugins by name. Safe for concurrent use.
+type AlgorithmRegistry struct {
+	mu         sync.RWMutex
+	algorithms map[string]AlgorithmPlugin
+}
+
+// NewAlgorithmRegistry creates an empty registry
+func NewAlgorithmRegistry() *AlgorithmRegistry {
+	return &AlgorithmRegistry{algorithms: make(map[string]AlgorithmPlugin)}
+}
+
+// Register adds an algorithm. Names are unique; re-registering one is an
+// error rather than a silent replacement.
+func (r *AlgorithmRegistry) Register(algo AlgorithmPlugin) error {
+	if algo == nil || algo.Name() == "" {
+		return fmt.Errorf("algorithm plugin must have a name")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.algorithms[algo.Name()]; exists {
+		return fmt.Errorf("%w: %s", ErrAlgorithmExists, algo.Name())
+	}
+	r.algorithms[algo.Name()] = algo
+	return nil
+}
+
+// Get returns the algorithm registered under name
+func (r *AlgorithmRegistry) Get(name string) (AlgorithmPlugin, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	algo, ok := r.algorithms[name]
+	return algo, ok
+}
+
+// Names returns the registered algorithm names in sorted order
+func (r *AlgorithmRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.algorithms))
+	for name := range r.algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run executes the algorithm registered under name
+func (r *AlgorithmRegistry) Run(name string, graph *storage.GraphStorage, params map[string]any) (any, error) {
+	algo, ok := r.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrAlgorithmNotFound, name)
+	}
+	return algo.Run(graph, params)
+}
+
+// defaultAlgorithms is the process-wide registry the CLI and API server
+// read.
+var defaultAlgorithms = NewAlgorithmRegistry()
+
+// RegisterAlgorithm adds an algorithm to the process-wide registry. Call it
+// at startup, before the server or CLI starts serving: from the init() of a
+// package blank-imported into a custom main, or by exporting a Plugin that
+// also implements AlgorithmPlugin from a .so loaded by PluginLoader.
+func RegisterAlgorithm(algo AlgorithmPlugin) error {
+	return defaultAlgorithms.Register(algo)
+}
+
+// Algorithms returns the process-wide algorithm registry
+func Algorithms() *AlgorithmRegistry {
+	return defaultAlgorithms
+}
@@ -0,0 +1,62 @@
+package plugins
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// nodeCountAlgorithm is a minimal AlgorithmPlugin for testing
+type nodeCountAlgorithm struct{ name string }
+
+func (a nodeCountAlgorithm) Name() string { return a.name }
+
+func (a nodeCountAlgorithm) Run(graph *storage.GraphStorage, params map[string]any) (any, error) {
+	if _, ok := params["fail"]; ok {
+		return nil, errors.New("asked to fail")
+	}
+	return map[string]any{"nodes": graph.GetStatistics().NodeCount}, nil
+}
+
+func TestAlgorithmRegistry(t *testing.T) {
+	r := NewAlgorithmRegistry()
+	for _, name := range []string{"zeta", "alpha"} {
+		if err := r.Register(nodeCountAlgorithm{name: name}); err != nil {
+			t.Fatalf("Register(%s): %v", name, err)
+		}
+	}
+	if err := r.Register(nodeCountAlgorithm{name: "alpha"}); !errors.Is(err, ErrAlgorithmExists) {
+		t.Errorf("duplicate Register = %v, want ErrAlgorithmExists", err)
+	}
+	if err := r.Register(nodeCountAlgorithm{}); err == nil {
+		t.Error("Register accepted an unnamed algorithm")
+	}
+	if got := r.Names(); !reflect.DeepEqual(got, []string{"alpha", "zeta"}) {
+		t.Errorf("Names() = %v, want [alpha zeta]", got)
+	}
+
+	graph, err := storage.NewGraphStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = graph.Close() }()
+	if _, err := graph.CreateNode([]string{"N"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := r.Run("alpha", graph, nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := result.(map[string]any)["nodes"]; got != uint64(1) {
+		t.Errorf("Run result nodes = %v, want 1", got)
+	}
+	if _, err := r.Run("alpha", graph, map[string]any{"fail": true}); err == nil {
+		t.Error("Run swallowed the algorithm's error")
+	}
+	if _, err := r.Run("missing", graph, nil); !errors.Is(err, ErrAlgorithmNotFound) {
+		t.Errorf("Run(missing) = %v, want ErrAlgorithmNotFound", err)
+	}
+}
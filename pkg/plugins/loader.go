@@ -129,6 +129,14 @@ func (l *PluginLoader) loadPlugin(ctx context.Context, path string) error {
 		return fmt.Errorf("failed to initialize plugin %s: %w", name, err)
 	}
 
+	// A plugin that is also an algorithm becomes runnable by name
+	if algo, ok := enterprisePlugin.(AlgorithmPlugin); ok {
+		if err := RegisterAlgorithm(algo); err != nil {
+			return fmt.Errorf("failed to register algorithm plugin %s: %w", name, err)
+		}
+		l.logger.Info("algorithm registered", "name", name)
+	}
+
 	// Store the plugin
 	l.plugins = append(l.plugins, enterprisePlugin)
 	l.pluginsByName[name] = enterprisePlugin
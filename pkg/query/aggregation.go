@@ -37,6 +37,9 @@ func (ac *AggregationComputer) ComputeAggregates(ctx *ExecutionContext, returnIt
 
 		// Extract values from execution context
 		values := ac.extractValues(ctx, item)
+		if item.Distinct && item.Expression != nil {
+			values = distinctValues(values)
+		}
 
 		// Compute aggregate
 		switch item.Aggregate {
@@ -70,28 +73,55 @@ func (ac *AggregationComputer) extractValues(ctx *ExecutionContext, item *Return
 	}
 
 	for _, binding := range ctx.results {
-		if obj, ok := binding.bindings[item.Expression.Variable]; ok {
-			if node, ok := obj.(*storage.Node); ok {
-				if item.Expression.Property != "" {
-					// Extract specific property
-					if prop, exists := node.Properties[item.Expression.Property]; exists {
-						// Extract actual value based on type
-						val := ac.ExtractValue(prop)
-						if val != nil {
-							values = append(values, val)
-						}
-					}
-				} else {
-					// No property specified - count the node itself
-					values = append(values, 1)
+		obj, ok := binding.bindings[item.Expression.Variable]
+		if !ok || obj == nil {
+			continue
+		}
+		switch v := obj.(type) {
+		case *storage.Node:
+			if item.Expression.Property == "" {
+				// No property specified - the node itself
+				values = append(values, v)
+			} else if prop, exists := v.Properties[item.Expression.Property]; exists {
+				// Extract actual value based on type
+				if val := ac.ExtractValue(prop); val != nil {
+					values = append(values, val)
 				}
 			}
+		case *storage.Edge:
+			if item.Expression.Property == "" {
+				values = append(values, v)
+			} else if val := edgePropertyValue(v, item.Expression.Property); val != nil {
+				values = append(values, val)
+			}
+		default:
+			// Raw value bindings (e.g. from WITH projections or UNWIND)
+			if item.Expression.Property == "" {
+				values = append(values, v)
+			} else if m, ok := v.(map[string]any); ok && m[item.Expression.Property] != nil {
+				values = append(values, m[item.Expression.Property])
+			}
 		}
 	}
 
 	return values
 }
 
+// distinctValues drops repeated values, keeping the first of each. Nodes
+// and edges are compared by ID.
+func distinctValues(values []any) []any {
+	seen := make(map[string]bool, len(values))
+	result := make([]any, 0, len(values))
+	for _, v := range values {
+		key := groupKeyPart(v)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 // ExtractValue extracts the actual value from storage.Value
 func (ac *AggregationComputer) ExtractValue(val storage.Value) any {
 	switch val.Type {
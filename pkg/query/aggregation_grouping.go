@@ -2,19 +2,34 @@ package query
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
-// ComputeGroupedAggregates computes aggregates for each group
+// ComputeGroupedAggregates computes aggregates for each group. Rows are
+// grouped by groupByExprs or, when there are none, implicitly by the
+// non-aggregate return items, as in RETURN z.name, COLLECT(n.name). Groups
+// come out in the order their first row was matched.
 func (ac *AggregationComputer) ComputeGroupedAggregates(ctx *ExecutionContext, returnItems []*ReturnItem, groupByExprs []*PropertyExpression) []map[string]any {
-	// Group results by the specified properties
-	groups := ac.groupResults(ctx, groupByExprs)
+	keyExprs := make([]Expression, 0, len(groupByExprs))
+	for _, expr := range groupByExprs {
+		keyExprs = append(keyExprs, expr)
+	}
+	if len(keyExprs) == 0 {
+		keyExprs = groupingExpressions(returnItems)
+	}
+
+	// Group results by the key expressions
+	keys, groups := ac.groupResults(ctx, keyExprs)
 
 	// Compute aggregates for each group
 	results := make([]map[string]any, 0, len(groups))
 
-	for groupKey, groupBindings := range groups {
+	for _, key := range keys {
+		groupBindings := groups[key]
+
 		// Create a temporary execution context for this group.
 		// Audit A6c-query: inherit tenantID from parent ctx —
 		// aggregation must not run cross-tenant.
@@ -27,12 +42,17 @@ func (ac *AggregationComputer) ComputeGroupedAggregates(ctx *ExecutionContext, r
 		// Compute aggregates for this group
 		row := ac.ComputeAggregates(groupCtx, returnItems)
 
-		// Add the group-by values to the row
-		groupValues := ac.parseGroupKey(groupKey)
-		for i, expr := range groupByExprs {
-			if i < len(groupValues) {
-				columnName := fmt.Sprintf("%s.%s", expr.Variable, expr.Property)
-				row[columnName] = groupValues[i]
+		// Add the grouping values, which every row of the group shares
+		first := groupBindings[0].bindings
+		for _, expr := range groupByExprs {
+			row[fmt.Sprintf("%s.%s", expr.Variable, expr.Property)] = extractValue(expr, first)
+		}
+		for _, item := range returnItems {
+			if item.Aggregate != "" {
+				continue
+			}
+			if expr := itemExpression(item); expr != nil {
+				row[buildColumnName(item)] = extractValue(expr, first)
 			}
 		}
 
@@ -42,72 +62,118 @@ func (ac *AggregationComputer) ComputeGroupedAggregates(ctx *ExecutionContext, r
 	return results
 }
 
-// groupResults groups execution context results by the specified properties
-func (ac *AggregationComputer) groupResults(ctx *ExecutionContext, groupByExprs []*PropertyExpression) map[string][]*BindingSet {
+// groupingExpressions returns the expressions of the non-aggregate items,
+// which group the rows of a RETURN that mixes them with aggregates.
+func groupingExpressions(returnItems []*ReturnItem) []Expression {
+	exprs := make([]Expression, 0, len(returnItems))
+	for _, item := range returnItems {
+		if item.Aggregate != "" {
+			continue
+		}
+		if expr := itemExpression(item); expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+	return exprs
+}
+
+// needsGrouping reports whether a RETURN without GROUP BY mixes aggregates
+// with other items, so that the other items group the aggregates.
+func needsGrouping(returnItems []*ReturnItem) bool {
+	return hasAggregates(returnItems) && len(groupingExpressions(returnItems)) > 0
+}
+
+// itemExpression returns the expression a return item evaluates.
+func itemExpression(item *ReturnItem) Expression {
+	if item.ValueExpr != nil {
+		return item.ValueExpr
+	}
+	if item.Expression != nil {
+		return item.Expression
+	}
+	return nil
+}
+
+// groupResults groups execution context results by the values of exprs,
+// returning the group keys in first-seen order alongside the groups.
+func (ac *AggregationComputer) groupResults(ctx *ExecutionContext, exprs []Expression) ([]string, map[string][]*BindingSet) {
+	var keys []string
 	groups := make(map[string][]*BindingSet)
 
 	for _, binding := range ctx.results {
-		// Build group key from the property values
-		key := ac.buildGroupKey(binding, groupByExprs)
+		key := ac.groupKey(binding, exprs)
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
 		groups[key] = append(groups[key], binding)
 	}
 
-	return groups
+	return keys, groups
 }
 
 // buildGroupKey creates a unique key for a group based on property values
 func (ac *AggregationComputer) buildGroupKey(binding *BindingSet, groupByExprs []*PropertyExpression) string {
-	keyParts := make([]string, 0, len(groupByExprs))
-
-	for _, expr := range groupByExprs {
-		if obj, ok := binding.bindings[expr.Variable]; ok {
-			if node, ok := obj.(*storage.Node); ok {
-				if prop, exists := node.Properties[expr.Property]; exists {
-					val := ac.ExtractValue(prop)
-					// Use a delimiter that won't appear in values
-					keyParts = append(keyParts, fmt.Sprintf("%v", val))
-				} else {
-					keyParts = append(keyParts, nullPlaceholder)
-				}
-			}
-		}
+	exprs := make([]Expression, len(groupByExprs))
+	for i, expr := range groupByExprs {
+		exprs[i] = expr
 	}
+	return ac.groupKey(binding, exprs)
+}
 
-	// Join parts with separator (null byte) which won't appear in string values
-	result := ""
-	for i, part := range keyParts {
-		if i > 0 {
-			result += groupKeySeparator
-		}
-		result += part
+// groupKey joins the values of exprs for one binding into a group key.
+func (ac *AggregationComputer) groupKey(binding *BindingSet, exprs []Expression) string {
+	keyParts := make([]string, len(exprs))
+	for i, expr := range exprs {
+		keyParts[i] = groupKeyPart(extractValue(expr, binding.bindings))
 	}
-	return result
+	// Join parts with separator (null byte) which won't appear in string values
+	return strings.Join(keyParts, groupKeySeparator)
 }
 
-// parseGroupKey extracts individual values from a group key
-func (ac *AggregationComputer) parseGroupKey(key string) []any {
-	// Split by separator
-	parts := make([]string, 0)
-	current := ""
-
-	for i := 0; i < len(key); i++ {
-		if key[i] == groupKeySeparator[0] {
-			parts = append(parts, current)
-			current = ""
-		} else {
-			current += string(key[i])
+// groupKeyPart renders a value for grouping and DISTINCT: nodes, edges
+// and paths by identity, lists and maps element by element, and anything
+// else by type and value, so 1 and "1" stay apart.
+func groupKeyPart(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return nullPlaceholder
+	case *storage.Node:
+		return fmt.Sprintf("node:%d", v.ID)
+	case *storage.Edge:
+		return fmt.Sprintf("edge:%d", v.ID)
+	case *Path:
+		parts := make([]string, 0, len(v.Nodes)+len(v.Edges))
+		for _, n := range v.Nodes {
+			parts = append(parts, groupKeyPart(n))
 		}
+		for _, e := range v.Edges {
+			parts = append(parts, groupKeyPart(e))
+		}
+		return "path:[" + strings.Join(parts, ",") + "]"
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = groupKeyPart(item)
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	case []*storage.Edge:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = groupKeyPart(e)
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + ":" + groupKeyPart(v[k])
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	default:
+		return fmt.Sprintf("%T:%v", v, v)
 	}
-
-	if current != "" {
-		parts = append(parts, current)
-	}
-
-	// Convert to any slice
-	values := make([]any, len(parts))
-	for i, part := range parts {
-		values[i] = part
-	}
-
-	return values
 }
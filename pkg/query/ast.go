@@ -28,7 +28,62 @@ type Query struct {
 	InitialBindings []*BindingSet
 }
 
-// MatchClause represents a MATCH pattern
+// IsWrite reports whether q, or any query chained to it by WITH or UNION,
+// creates, merges, updates or deletes graph data.
+func (q *Query) IsWrite() bool {
+	for ; q != nil; q = q.Next {
+		if q.Create != nil || q.Merge != nil || q.Set != nil || q.Remove != nil || q.Delete != nil {
+			return true
+		}
+		if q.UnionNext.IsWrite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Clauses lists the clause keywords of q and the queries chained to it,
+// with WITH and UNION marking the chain points. Within one query part the
+// keywords come in a fixed order (the AST does not keep source order), and
+// nothing from the clause bodies — patterns, literals, parameters — is
+// included, so the list is safe to log.
+func (q *Query) Clauses() []string {
+	var clauses []string
+	for part := q; part != nil; part = part.Next {
+		if part != q {
+			clauses = append(clauses, "WITH")
+		}
+		for _, c := range []struct {
+			present bool
+			keyword string
+		}{
+			{part.Call != nil, "CALL"},
+			{part.Match != nil, "MATCH"},
+			{len(part.OptionalMatches) > 0, "OPTIONAL MATCH"},
+			{part.Unwind != nil, "UNWIND"},
+			{part.Where != nil, "WHERE"},
+			{part.Merge != nil, "MERGE"},
+			{part.Create != nil, "CREATE"},
+			{part.Set != nil, "SET"},
+			{part.Remove != nil, "REMOVE"},
+			{part.Delete != nil, "DELETE"},
+			{part.Return != nil, "RETURN"},
+		} {
+			if c.present {
+				clauses = append(clauses, c.keyword)
+			}
+		}
+		if part.UnionNext != nil {
+			clauses = append(clauses, "UNION")
+			clauses = append(clauses, part.UnionNext.Clauses()...)
+		}
+	}
+	return clauses
+}
+
+// MatchClause represents a MATCH pattern. Its patterns are joined on the
+// variables they share, as are the patterns of consecutive MATCH clauses,
+// which the parser folds into one MatchClause.
 type MatchClause struct {
 	Patterns []*Pattern
 }
@@ -37,6 +92,7 @@ type MatchClause struct {
 type Pattern struct {
 	Nodes         []*NodePattern
 	Relationships []*RelationshipPattern
+	PathVariable  string // p in MATCH p = (a)-[*]->(b); bound to a *Path
 }
 
 // NodePattern represents a node in a pattern
@@ -56,6 +112,7 @@ type RelationshipPattern struct {
 	To         *NodePattern
 	MinHops    int // For variable-length paths
 	MaxHops    int
+	VarLength  bool // Written with '*': binds Variable to the edge list even when MinHops == MaxHops == 1
 }
 
 // Direction represents relationship direction
@@ -100,6 +157,7 @@ type ReturnItem struct {
 	ValueExpr  Expression // Broader type for function calls; if non-nil, takes precedence
 	Alias      string
 	Aggregate  string // COUNT, SUM, AVG, MIN, MAX, COLLECT
+	Distinct   bool   // COUNT(DISTINCT x): aggregate each distinct value once
 }
 
 // OrderByItem represents ordering specification
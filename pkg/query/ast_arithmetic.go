@@ -151,13 +151,9 @@ type UnaryExpression struct {
 func (ue *UnaryExpression) Eval(context map[string]any) (bool, error) {
 	switch ue.Operator {
 	case "NOT":
-		result, err := ue.Operand.Eval(context)
-		if err != nil {
-			// If operand can't eval as bool, extract value and coerce
-			val := extractValue(ue.Operand, context)
-			return !coerceToBool(val), nil
-		}
-		return !result, nil
+		// NOT null is null, which a filter reads as false.
+		t, err := evalTruth(ue, context)
+		return t == truthTrue, err
 	case "-":
 		val, err := ue.EvalValue(context)
 		if err != nil {
@@ -172,12 +168,11 @@ func (ue *UnaryExpression) Eval(context map[string]any) (bool, error) {
 func (ue *UnaryExpression) EvalValue(context map[string]any) (any, error) {
 	switch ue.Operator {
 	case "NOT":
-		result, err := ue.Operand.Eval(context)
+		t, err := evalTruth(ue, context)
 		if err != nil {
-			val := extractValue(ue.Operand, context)
-			return !coerceToBool(val), nil
+			return nil, err
 		}
-		return !result, nil
+		return t.value(), nil
 	case "-":
 		val := extractValue(ue.Operand, context)
 		if val == nil {
@@ -154,8 +154,8 @@ func TestUnaryExpression_NOT(t *testing.T) {
 	}{
 		{"NOT true", &LiteralExpression{Value: true}, false},
 		{"NOT false", &LiteralExpression{Value: false}, true},
-		// NOT nil → true (null is falsy)
-		{"NOT nil", &LiteralExpression{Value: nil}, true},
+		// NOT null is null, which Eval reads as false
+		{"NOT nil", &LiteralExpression{Value: nil}, false},
 		// NOT (nonzero) → false
 		{"NOT nonzero", &LiteralExpression{Value: int64(42)}, false},
 	}
@@ -7,83 +7,169 @@ import (
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
-// Helper function to evaluate comparisons
-func evalComparison(left, right Expression, op string, context map[string]any) (bool, error) {
+// truth is a Cypher boolean, which has three values: a comparison involving
+// null (a missing property, say) is neither true nor false but null.
+// NOT, AND and OR follow three-valued logic over it, and only a WHERE (or
+// any other caller of Expression.Eval) reads null as false. Collapsing to
+// false any earlier would let NOT turn "unknown" into true.
+type truth int8
+
+const (
+	truthFalse truth = iota
+	truthTrue
+	truthNull
+)
+
+// truthOf converts a Go bool.
+func truthOf(b bool) truth {
+	if b {
+		return truthTrue
+	}
+	return truthFalse
+}
+
+// not negates t; NOT null is null.
+func (t truth) not() truth {
+	switch t {
+	case truthTrue:
+		return truthFalse
+	case truthFalse:
+		return truthTrue
+	default:
+		return truthNull
+	}
+}
+
+// value is t as a projected value: true, false or nil.
+func (t truth) value() any {
+	if t == truthNull {
+		return nil
+	}
+	return t == truthTrue
+}
+
+// evalTruth evaluates expr as a three-valued predicate. NOT, AND, OR,
+// comparisons and bare properties are evaluated here so null survives
+// nesting; every other expression falls back to its Eval.
+func evalTruth(expr Expression, context map[string]any) (truth, error) {
+	switch e := expr.(type) {
+	case *BinaryExpression:
+		return e.evalTruth(context)
+	case *UnaryExpression:
+		if e.Operator == "NOT" {
+			t, err := evalTruth(e.Operand, context)
+			if err != nil {
+				// If operand can't eval as bool, extract value and coerce
+				val := extractValue(e.Operand, context)
+				if val == nil {
+					return truthNull, nil
+				}
+				return truthOf(!coerceToBool(val)), nil
+			}
+			return t.not(), nil
+		}
+	case *PropertyExpression:
+		switch v := extractValue(e, context).(type) {
+		case nil:
+			return truthNull, nil
+		case bool:
+			return truthOf(v), nil
+		default:
+			return truthTrue, nil
+		}
+	case *LiteralExpression:
+		if e.Value == nil {
+			return truthNull, nil
+		}
+	}
+	b, err := expr.Eval(context)
+	return truthOf(b), err
+}
+
+// Helper function to evaluate comparisons. A comparison whose result Cypher
+// defines as null (an operand is null, or the types can't be compared) yields
+// truthNull.
+func evalComparison(left, right Expression, op string, context map[string]any) (truth, error) {
 	// Extract actual values
 	leftVal := extractValue(left, context)
 	rightVal := extractValue(right, context)
 
 	switch op {
 	case "=":
-		return leftVal == rightVal, nil
+		if leftVal == nil || rightVal == nil {
+			return truthNull, nil
+		}
+		return truthOf(valuesEqual(leftVal, rightVal)), nil
 	case "!=":
-		return leftVal != rightVal, nil
-	case ">":
-		return compareValues(leftVal, rightVal) > 0, nil
-	case "<":
-		return compareValues(leftVal, rightVal) < 0, nil
-	case ">=":
-		return compareValues(leftVal, rightVal) >= 0, nil
-	case "<=":
-		return compareValues(leftVal, rightVal) <= 0, nil
+		if leftVal == nil || rightVal == nil {
+			return truthNull, nil
+		}
+		return truthOf(!valuesEqual(leftVal, rightVal)), nil
+	case ">", "<", ">=", "<=":
+		// Ordering is only defined between two numbers or two strings;
+		// anything else (null, a missing property, mixed types) is null.
+		if !orderable(leftVal, rightVal) {
+			return truthNull, nil
+		}
+		cmp := compareValues(leftVal, rightVal)
+		switch op {
+		case ">":
+			return truthOf(cmp > 0), nil
+		case "<":
+			return truthOf(cmp < 0), nil
+		case ">=":
+			return truthOf(cmp >= 0), nil
+		default:
+			return truthOf(cmp <= 0), nil
+		}
 	case "IS NULL":
-		return leftVal == nil, nil
+		return truthOf(leftVal == nil), nil
 	case "IS NOT NULL":
-		return leftVal != nil, nil
+		return truthOf(leftVal != nil), nil
 	case "IN":
-		// Cypher: null IN [...] evaluates to null (treated as false in boolean context)
+		// Cypher: null IN [...] is null, and so is a miss against a list
+		// holding a null, since the null element might have matched.
 		if leftVal == nil {
-			return false, nil
+			return truthNull, nil
 		}
 		list, ok := rightVal.([]any)
 		if !ok {
-			return false, fmt.Errorf("IN requires a list on the right side")
+			return truthFalse, fmt.Errorf("IN requires a list on the right side")
 		}
+		sawNull := false
 		for _, item := range list {
 			if item == nil {
-				continue // skip null elements in list
+				sawNull = true
+				continue
 			}
 			if leftVal == item {
-				return true, nil
+				return truthTrue, nil
 			}
 			// Handle numeric type coercion (int64 vs float64)
 			if compareValues(leftVal, item) == 0 {
-				return true, nil
+				return truthTrue, nil
 			}
 		}
-		return false, nil
-	case "STARTS WITH":
-		if leftVal == nil || rightVal == nil {
-			return false, nil
+		if sawNull {
+			return truthNull, nil
 		}
+		return truthFalse, nil
+	case "STARTS WITH", "ENDS WITH", "CONTAINS":
 		lStr, lOk := leftVal.(string)
 		rStr, rOk := rightVal.(string)
 		if !lOk || !rOk {
-			return false, nil
+			return truthNull, nil
 		}
-		return strings.HasPrefix(lStr, rStr), nil
-	case "ENDS WITH":
-		if leftVal == nil || rightVal == nil {
-			return false, nil
+		switch op {
+		case "STARTS WITH":
+			return truthOf(strings.HasPrefix(lStr, rStr)), nil
+		case "ENDS WITH":
+			return truthOf(strings.HasSuffix(lStr, rStr)), nil
+		default:
+			return truthOf(strings.Contains(lStr, rStr)), nil
 		}
-		lStr, lOk := leftVal.(string)
-		rStr, rOk := rightVal.(string)
-		if !lOk || !rOk {
-			return false, nil
-		}
-		return strings.HasSuffix(lStr, rStr), nil
-	case "CONTAINS":
-		if leftVal == nil || rightVal == nil {
-			return false, nil
-		}
-		lStr, lOk := leftVal.(string)
-		rStr, rOk := rightVal.(string)
-		if !lOk || !rOk {
-			return false, nil
-		}
-		return strings.Contains(lStr, rStr), nil
 	default:
-		return false, fmt.Errorf("unknown comparison operator: %s", op)
+		return truthFalse, fmt.Errorf("unknown comparison operator: %s", op)
 	}
 }
 
@@ -107,10 +193,7 @@ func extractValue(expr Expression, context map[string]any) any {
 				if e.Property == "" {
 					return edge
 				}
-				if val, found := edge.Properties[e.Property]; found {
-					return extractStorageValue(val)
-				}
-				return nil
+				return edgePropertyValue(edge, e.Property)
 			}
 			// Fallback to map[string]any for backward compatibility
 			if m, ok := obj.(map[string]any); ok {
@@ -168,6 +251,37 @@ func extractValue(expr Expression, context map[string]any) any {
 	}
 }
 
+// valuesEqual reports whether two non-null values are equal, treating int64
+// and float64 as the same numeric domain (so n.score = 3 matches 3.0).
+func valuesEqual(left, right any) bool {
+	if isNumeric(left) && isNumeric(right) {
+		return compareValues(left, right) == 0
+	}
+	return left == right
+}
+
+// orderable reports whether <, >, <= and >= are defined between left and
+// right: both numeric or both strings.
+func orderable(left, right any) bool {
+	if isNumeric(left) && isNumeric(right) {
+		return true
+	}
+	_, lIsStr := left.(string)
+	_, rIsStr := right.(string)
+	return lIsStr && rIsStr
+}
+
+// isNumeric reports whether v is one of the numeric types compareValues
+// understands.
+func isNumeric(v any) bool {
+	switch v.(type) {
+	case int64, float64, int:
+		return true
+	default:
+		return false
+	}
+}
+
 // Compare values (simplified)
 func compareValues(left, right any) int {
 	// Handle int64
@@ -18,24 +18,55 @@ type BinaryExpression struct {
 	Right    Expression
 }
 
+// Eval evaluates the expression as a WHERE predicate, reading null as false.
 func (be *BinaryExpression) Eval(context map[string]any) (bool, error) {
+	t, err := be.evalTruth(context)
+	return t == truthTrue, err
+}
+
+// evalTruth evaluates the expression with three-valued logic (see truth).
+func (be *BinaryExpression) evalTruth(context map[string]any) (truth, error) {
 	switch be.Operator {
 	case "AND":
-		left, err := be.Left.Eval(context)
-		if err != nil || !left {
-			return false, err
+		// false AND anything is false, even null; otherwise any null
+		// makes the result null.
+		left, err := evalTruth(be.Left, context)
+		if err != nil || left == truthFalse {
+			return truthFalse, err
+		}
+		right, err := evalTruth(be.Right, context)
+		if err != nil {
+			return truthFalse, err
 		}
-		return be.Right.Eval(context)
+		if right == truthFalse {
+			return truthFalse, nil
+		}
+		if left == truthNull || right == truthNull {
+			return truthNull, nil
+		}
+		return truthTrue, nil
 
 	case "OR":
-		left, err := be.Left.Eval(context)
+		// true OR anything is true, even null; otherwise any null makes
+		// the result null.
+		left, err := evalTruth(be.Left, context)
 		if err != nil {
-			return false, err
+			return truthFalse, err
 		}
-		if left {
-			return true, nil
+		if left == truthTrue {
+			return truthTrue, nil
 		}
-		return be.Right.Eval(context)
+		right, err := evalTruth(be.Right, context)
+		if err != nil {
+			return truthFalse, err
+		}
+		if right == truthTrue {
+			return truthTrue, nil
+		}
+		if left == truthNull || right == truthNull {
+			return truthNull, nil
+		}
+		return truthFalse, nil
 
 	case "=", ">", "<", ">=", "<=", "!=", "IS NULL", "IS NOT NULL", "IN",
 		"STARTS WITH", "ENDS WITH", "CONTAINS":
@@ -43,12 +74,17 @@ func (be *BinaryExpression) Eval(context map[string]any) (bool, error) {
 		return evalComparison(be.Left, be.Right, be.Operator, context)
 
 	default:
-		return false, fmt.Errorf("unknown operator: %s", be.Operator)
+		return truthFalse, fmt.Errorf("unknown operator: %s", be.Operator)
 	}
 }
 
+// EvalValue returns the predicate's value: true, false, or nil for null.
 func (be *BinaryExpression) EvalValue(context map[string]any) (any, error) {
-	return be.Eval(context)
+	t, err := be.evalTruth(context)
+	if err != nil {
+		return nil, err
+	}
+	return t.value(), nil
 }
 
 // PropertyExpression represents property access (e.g., n.name)
@@ -57,10 +93,12 @@ type PropertyExpression struct {
 	Property string
 }
 
+// Eval treats a bare property as a predicate (WHERE n.critical): a boolean
+// property evaluates to its value, any other present property to true, and
+// a missing property to null, which a filter reads as false (see evalTruth).
 func (pe *PropertyExpression) Eval(context map[string]any) (bool, error) {
-	// This returns the property value, not a boolean
-	// Used in comparisons
-	return false, fmt.Errorf("property expression must be used in comparison")
+	t, err := evalTruth(pe, context)
+	return t == truthTrue, err
 }
 
 func (pe *PropertyExpression) EvalValue(context map[string]any) (any, error) {
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -311,11 +312,18 @@ func TestPropertyExpression_Eval(t *testing.T) {
 		},
 	}
 
-	// PropertyExpression.Eval should return error (not usable standalone)
+	// A bare property is a predicate: present (non-bool) is true.
 	expr := &PropertyExpression{Variable: "person", Property: "name"}
-	_, err := expr.Eval(context)
-	if err == nil {
-		t.Error("Expected error: property expression must be used in comparison")
+	got, err := expr.Eval(context)
+	if err != nil || !got {
+		t.Errorf("Eval(person.name) = %v, %v; want true, nil", got, err)
+	}
+
+	// Missing property is false.
+	expr = &PropertyExpression{Variable: "person", Property: "age"}
+	got, err = expr.Eval(context)
+	if err != nil || got {
+		t.Errorf("Eval(person.age) = %v, %v; want false, nil", got, err)
 	}
 }
 
@@ -724,3 +732,44 @@ func errorContainsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+// TestQuery_IsWrite checks write detection across clauses and chained queries
+func TestQuery_IsWrite(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"MATCH (n:Person) RETURN n", false},
+		{"MATCH (n) WITH n RETURN n.name", false},
+		{"CREATE (n:Person {name: 'a'})", true},
+		{"MERGE (n:Person {name: 'a'})", true},
+		{"MATCH (n) SET n.age = 1", true},
+		{"MATCH (n) DELETE n", true},
+		{"MATCH (n) WITH n SET n.seen = true", true},
+		{"MATCH (n:A) RETURN n UNION MATCH (n:B) RETURN n", false},
+	}
+	for _, tt := range tests {
+		if got := parseCallInput(t, tt.input).IsWrite(); got != tt.want {
+			t.Errorf("IsWrite(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestQuery_Clauses checks the clause list follows WITH and UNION chains and
+// carries no literals.
+func TestQuery_Clauses(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"CREATE (n:Person {name: 'secret'})", []string{"CREATE"}},
+		{"MATCH (n) WHERE n.ssn = '123' SET n.seen = true", []string{"MATCH", "WHERE", "SET"}},
+		{"MATCH (n) WITH n DELETE n", []string{"MATCH", "WITH", "DELETE"}},
+		{"MATCH (n:A) RETURN n UNION MATCH (n:B) RETURN n", []string{"MATCH", "RETURN", "UNION", "MATCH", "RETURN"}},
+	}
+	for _, tt := range tests {
+		if got := parseCallInput(t, tt.input).Clauses(); !slices.Equal(got, tt.want) {
+			t.Errorf("Clauses(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -74,8 +75,9 @@ func TestConformance_Explain(t *testing.T) {
 
 	result := parseAndExecute(t, executor, `EXPLAIN MATCH (n:Person) WHERE n.age > 25 RETURN n.name`)
 
-	if len(result.Columns) != 2 || result.Columns[0] != "step" {
-		t.Errorf("EXPLAIN should return step/detail columns, got %v", result.Columns)
+	want := []string{"step", "detail", "scan", "estimated_rows", "traversal"}
+	if strings.Join(result.Columns, ",") != strings.Join(want, ",") {
+		t.Errorf("EXPLAIN columns = %v, want %v", result.Columns, want)
 	}
 	if len(result.Rows) < 3 {
 		t.Errorf("Expected at least 3 plan steps, got %d", len(result.Rows))
@@ -119,6 +121,69 @@ func TestConformance_Collect(t *testing.T) {
 	}
 }
 
+// TestConformance_CollectImplicitGrouping pins that non-aggregate RETURN
+// items group the aggregates next to them, without GROUP BY, and that the
+// aggregate names are case-insensitive.
+func TestConformance_CollectImplicitGrouping(t *testing.T) {
+	_, executor, cleanup := setupConformanceGraph(t)
+	defer cleanup()
+
+	result := parseAndExecute(t, executor,
+		`MATCH (n:Person) RETURN n.department AS dept, collect(n.name) AS names, count(*) AS total ORDER BY dept`)
+
+	if result.Count != 2 {
+		t.Fatalf("Expected 2 groups, got %d: %v", result.Count, result.Rows)
+	}
+	want := []struct {
+		dept  string
+		names []any
+		total int
+	}{
+		{"Engineering", []any{"Alice", "Charlie"}, 2},
+		{"Sales", []any{"Bob"}, 1},
+	}
+	for i, w := range want {
+		row := result.Rows[i]
+		if row["dept"] != w.dept || !reflect.DeepEqual(row["names"], w.names) || row["total"] != w.total {
+			t.Errorf("row %d = %v, want %s %v %d", i, row, w.dept, w.names, w.total)
+		}
+	}
+}
+
+// TestConformance_DistinctNodes pins that DISTINCT compares nodes by
+// identity, in RETURN and inside aggregates.
+func TestConformance_DistinctNodes(t *testing.T) {
+	_, executor, cleanup := setupConformanceGraph(t)
+	defer cleanup()
+
+	// Alice knows two people, so she is matched twice.
+	result := parseAndExecute(t, executor, `MATCH (a)-[:KNOWS]->(b) RETURN DISTINCT a`)
+	if result.Count != 1 {
+		t.Errorf("RETURN DISTINCT a: got %d rows, want 1", result.Count)
+	}
+
+	result = parseAndExecute(t, executor,
+		`MATCH (a)-[:KNOWS]->(b) RETURN COUNT(a) AS matches, COUNT(DISTINCT a) AS people, COLLECT(DISTINCT a.name) AS names`)
+	row := result.Rows[0]
+	if row["matches"] != 2 || row["people"] != 1 || !reflect.DeepEqual(row["names"], []any{"Alice"}) {
+		t.Errorf("row = %v, want matches=2 people=1 names=[Alice]", row)
+	}
+
+	result = parseAndExecute(t, executor, `MATCH (a)-[:KNOWS]->(b) RETURN a.name AS name, collect(b) AS friends`)
+	if result.Count != 1 {
+		t.Fatalf("got %d rows, want 1", result.Count)
+	}
+	friends, _ := result.Rows[0]["friends"].([]any)
+	for _, f := range friends {
+		if _, ok := f.(*storage.Node); !ok {
+			t.Errorf("collect(b) element = %T, want *storage.Node", f)
+		}
+	}
+	if len(friends) != 2 {
+		t.Errorf("collect(b) = %v, want 2 nodes", friends)
+	}
+}
+
 func TestConformance_CollectGroupBy(t *testing.T) {
 	_, executor, cleanup := setupConformanceGraph(t)
 	defer cleanup()
@@ -1060,3 +1125,56 @@ func TestConformance_Phase5_AllFeaturesCombined(t *testing.T) {
 		t.Errorf("expected era=recent, got %v", row["era"])
 	}
 }
+
+// TestConformance_RelationshipProperties covers reaching a bound
+// relationship's properties and weight from WHERE, inline property maps,
+// RETURN, ORDER BY and aggregates.
+func TestConformance_RelationshipProperties(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+
+	plc, _ := gs.CreateNode([]string{"PLC"}, map[string]storage.Value{"name": storage.StringValue("plc")})
+	scada, _ := gs.CreateNode([]string{"SCADA"}, map[string]storage.Value{"name": storage.StringValue("scada")})
+	hist, _ := gs.CreateNode([]string{"Historian"}, map[string]storage.Value{"name": storage.StringValue("historian")})
+	_, _ = gs.CreateEdge(plc.ID, scada.ID, "CONTROLS", map[string]storage.Value{
+		"enforcement": storage.StringValue("data_diode"),
+	}, 2.5)
+	_, _ = gs.CreateEdge(scada.ID, hist.ID, "CONTROLS", map[string]storage.Value{
+		"enforcement": storage.StringValue("firewall_rule"),
+	}, 0.5)
+	executor := NewExecutor(gs)
+
+	result := parseAndExecute(t, executor,
+		`MATCH (a)-[r:CONTROLS]->(b) WHERE r.enforcement = 'data_diode' RETURN a.name, r.enforcement, r.weight, b.name`)
+	if result.Count != 1 {
+		t.Fatalf("WHERE on edge property: %d rows, want 1", result.Count)
+	}
+	if row := result.Rows[0]; row["a.name"] != "plc" || row["r.enforcement"] != "data_diode" || row["r.weight"] != 2.5 {
+		t.Errorf("row = %v, want plc's data_diode edge with weight 2.5", row)
+	}
+
+	result = parseAndExecute(t, executor, `MATCH (a)-[r:CONTROLS]->(b) WHERE r.weight < 1 RETURN b.name`)
+	if result.Count != 1 || result.Rows[0]["b.name"] != "historian" {
+		t.Errorf("WHERE r.weight < 1 = %v, want historian", result.Rows)
+	}
+
+	for _, q := range []string{
+		`MATCH (a)-[r:CONTROLS {enforcement: 'firewall_rule'}]->(b) RETURN b.name`,
+		`MATCH (a)-[r {weight: 0.5}]->(b) RETURN b.name`,
+	} {
+		result = parseAndExecute(t, executor, q)
+		if result.Count != 1 || result.Rows[0]["b.name"] != "historian" {
+			t.Errorf("%s = %v, want historian", q, result.Rows)
+		}
+	}
+
+	result = parseAndExecute(t, executor, `MATCH (a)-[r:CONTROLS]->(b) RETURN a.name ORDER BY r.weight`)
+	if result.Count != 2 || result.Rows[0]["a.name"] != "scada" {
+		t.Errorf("ORDER BY r.weight = %v, want scada first", result.Rows)
+	}
+
+	result = parseAndExecute(t, executor, `MATCH (a)-[r:CONTROLS]->(b) RETURN COUNT(r) AS n, SUM(r.weight) AS total`)
+	if row := result.Rows[0]; row["n"] != 2 || row["total"] != 3.0 {
+		t.Errorf("aggregates over r = %v, want n=2 total=3", row)
+	}
+}
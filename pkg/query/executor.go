@@ -25,6 +25,7 @@ type Executor struct {
 	optimizer    *Optimizer
 	cache        *QueryCache
 	queryTimeout time.Duration
+	limits       Limits
 	searchIndex  any // *search.FullTextIndex, stored as any to avoid import cycle
 
 	// Vector search closures (set via SetVectorSearch)
@@ -70,7 +71,27 @@ func (e *Executor) Execute(query *Query) (*ResultSet, error) {
 
 // ExecuteWithContext executes a query with context for cancellation and timeout support.
 // Includes panic recovery to prevent server crashes from malformed queries.
-func (e *Executor) ExecuteWithContext(ctx context.Context, query *Query) (result *ResultSet, err error) {
+// The query is held to the executor's Limits, tightened by any WithLimits
+// on ctx; exceeding them fails with an ErrQueryLimitExceeded error.
+func (e *Executor) ExecuteWithContext(ctx context.Context, query *Query) (*ResultSet, error) {
+	limits := e.limitsFor(ctx)
+	ctx, cancel := limits.apply(ctx)
+	defer cancel()
+
+	result, err := e.executeQuery(ctx, query)
+	if err != nil {
+		return nil, limitError(ctx, err)
+	}
+	if !query.IsWrite() {
+		if err := limits.checkRows(result.Count); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// executeQuery runs query under ctx, recovering from panics.
+func (e *Executor) executeQuery(ctx context.Context, query *Query) (result *ResultSet, err error) {
 	// Panic recovery - prevent server crashes from query execution panics
 	defer func() {
 		if r := recover(); r != nil {
@@ -88,6 +109,15 @@ func (e *Executor) ExecuteWithContext(ctx context.Context, query *Query) (result
 	default:
 	}
 
+	// EXPLAIN: return the plan of every segment without executing
+	if query.Explain {
+		plan, err := e.ExplainWithContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return plan.ResultSet(), nil
+	}
+
 	// Handle UNION before normal execution
 	if query.Union != nil && query.UnionNext != nil {
 		return e.executeUnion(ctx, query)
@@ -106,11 +136,6 @@ func (e *Executor) ExecuteWithContext(ctx context.Context, query *Query) (result
 	// Optimize plan
 	optimizedPlan := e.optimizer.Optimize(plan, query)
 
-	// EXPLAIN: return the plan without executing
-	if query.Explain {
-		return buildExplainResult(optimizedPlan), nil
-	}
-
 	// PROFILE: execute with timing instrumentation
 	if query.Profile {
 		return e.executeWithProfiling(ctx, optimizedPlan, query)
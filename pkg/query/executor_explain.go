@@ -3,7 +3,12 @@ package query
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 	"time"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+	"github.com/dd0wney/graphdb/pkg/tenant"
 )
 
 // StepDescriber provides human-readable descriptions of execution steps
@@ -37,21 +42,393 @@ func (ils *IndexLookupStep) StepDetail() string {
 	return fmt.Sprintf("property=%s variable=%s", ils.propertyKey, ils.variable)
 }
 
-// buildExplainResult serializes the execution plan steps as rows
-func buildExplainResult(plan *ExecutionPlan) *ResultSet {
+// Scan strategies reported by Explain for steps that find nodes.
+const (
+	// ScanIndex looks the start nodes up in a property index.
+	ScanIndex = "index"
+	// ScanFull enumerates every node of the tenant and filters them by
+	// label and inline properties.
+	ScanFull = "full_scan"
+	// ScanBound starts from a node an earlier step already bound, so
+	// nothing is scanned.
+	ScanBound = "bound"
+)
+
+// Plan is the optimized execution plan of a query, as built by Explain.
+type Plan struct {
+	Steps []PlanStep `json:"steps"`
+}
+
+// PlanStep describes one execution step. Scan, EstimatedRows and Traversal
+// are only set on steps that find nodes: MATCH, OPTIONAL MATCH, MERGE and
+// index lookups.
+type PlanStep struct {
+	Step   string `json:"step"`
+	Detail string `json:"detail"`
+	// Scan is the least selective strategy among the step's patterns, so a
+	// single unindexed pattern makes the whole step a full_scan.
+	Scan string `json:"scan,omitempty"`
+	// EstimatedRows is the number of rows the step is expected to produce,
+	// from label and edge type counts. WHERE filters and inline property
+	// maps are not accounted for, so it is an upper bound for most queries.
+	EstimatedRows *int `json:"estimated_rows,omitempty"`
+	// Traversal lists each pattern as its hops, e.g. "(a:Person)-[:KNOWS]->(b)".
+	Traversal []string `json:"traversal,omitempty"`
+}
+
+// Explain builds and optimizes the plan for query without executing it.
+func (e *Executor) Explain(query *Query) (*Plan, error) {
+	return e.ExplainWithContext(context.Background(), query)
+}
+
+// ExplainWithContext is Explain with estimates scoped to the tenant in ctx.
+// WITH and UNION segments are explained in order, each segment's steps
+// following the previous one's.
+func (e *Executor) ExplainWithContext(ctx context.Context, query *Query) (*Plan, error) {
+	if query == nil {
+		return nil, fmt.Errorf("explain: nil query")
+	}
+	x := &planExplainer{
+		graph:    e.graph,
+		tenantID: tenant.MustFromContext(ctx),
+		bound:    make(map[string]bool),
+	}
+	plan := &Plan{Steps: make([]PlanStep, 0)}
+
+	for q := query; q != nil; {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("explain cancelled: %w", err)
+		}
+		optimized := e.optimizer.Optimize(e.buildExecutionPlan(q), q)
+		for _, step := range optimized.Steps {
+			plan.Steps = append(plan.Steps, x.describe(step))
+		}
+
+		switch {
+		case q.With != nil && q.Next != nil:
+			x.bindWith(q.With)
+			q = q.Next
+		case q.Union != nil && q.UnionNext != nil:
+			detail := "UNION"
+			if q.Union.All {
+				detail = "UNION ALL"
+			}
+			plan.Steps = append(plan.Steps, PlanStep{Step: "UnionStep", Detail: detail})
+			x.bound = make(map[string]bool)
+			q = q.UnionNext
+		default:
+			q = nil
+		}
+	}
+	return plan, nil
+}
+
+// planExplainer describes steps in plan order, tracking which variables
+// earlier steps bind so later patterns that start from them report
+// ScanBound.
+type planExplainer struct {
+	graph    *storage.GraphStorage
+	tenantID string
+	bound    map[string]bool
+	rows     int // estimated rows flowing into the next step
+}
+
+func (x *planExplainer) describe(step ExecutionStep) PlanStep {
+	ps := PlanStep{Step: "Unknown"}
+	if describer, ok := step.(StepDescriber); ok {
+		ps.Step = describer.StepName()
+		ps.Detail = describer.StepDetail()
+	}
+
+	switch s := step.(type) {
+	case *IndexLookupStep:
+		ps.Scan = ScanIndex
+		ps.Traversal = []string{formatNodePattern(&NodePattern{Variable: s.variable, Labels: s.labels}) +
+			fmt.Sprintf(" {%s: %s}", s.propertyKey, s.value.String())}
+		x.rows = x.indexRows(s)
+		ps.EstimatedRows = intPtr(x.rows)
+		x.bound[s.variable] = true
+	case *MatchStep:
+		x.describeMatch(&ps, s.match, false)
+	case *OptionalMatchStep:
+		x.describeMatch(&ps, s.match, true)
+	case *MergeStep:
+		x.describeMerge(&ps, s)
+	}
+	return ps
+}
+
+func (x *planExplainer) describeMatch(ps *PlanStep, match *MatchClause, optional bool) {
+	in := max(x.rows, 1)
+	ps.Scan = ScanBound
+	rows := in
+	for _, pattern := range match.Patterns {
+		// Patterns are joined, so each one sees the variables of the
+		// patterns before it as bound.
+		scan, perRow := x.patternEstimate(pattern)
+		if scan == ScanFull {
+			ps.Scan = ScanFull
+		}
+		rows = saturatingMul(rows, perRow)
+		ps.Traversal = append(ps.Traversal, formatPattern(pattern))
+		for _, n := range pattern.Nodes {
+			if n.Variable != "" {
+				x.bound[n.Variable] = true
+			}
+		}
+	}
+	x.rows = rows
+	if optional {
+		// A left-outer join keeps every input row.
+		x.rows = max(x.rows, in)
+	}
+	ps.EstimatedRows = intPtr(x.rows)
+}
+
+// describeMerge reports how MERGE looks for an existing match: through a
+// property index when its single node has an indexed property, otherwise by
+// scanning like MATCH. The estimate is the number of existing matches, or 1
+// when the pattern will be created.
+func (x *planExplainer) describeMerge(ps *PlanStep, s *MergeStep) {
+	ps.Traversal = []string{formatPattern(s.merge.Pattern)}
+	node := s.merge.Pattern.Nodes[0]
+	if key, value, ok := s.indexedProperty(x.graph); ok {
+		ps.Scan = ScanIndex
+		x.rows = x.indexRows(&IndexLookupStep{propertyKey: key, value: value, labels: node.Labels})
+	} else {
+		// MERGE matches from scratch, whatever earlier steps bound.
+		bound := x.bound
+		x.bound = make(map[string]bool)
+		ps.Scan, x.rows = x.patternEstimate(s.merge.Pattern)
+		x.bound = bound
+	}
+	x.rows = max(x.rows, 1)
+	ps.EstimatedRows = intPtr(x.rows)
+	for _, n := range s.merge.Pattern.Nodes {
+		if n.Variable != "" {
+			x.bound[n.Variable] = true
+		}
+	}
+}
+
+// patternEstimate returns how a pattern's start node is found and how many
+// rows the pattern yields per input row.
+func (x *planExplainer) patternEstimate(pattern *Pattern) (string, int) {
+	if len(pattern.Nodes) == 0 {
+		return ScanBound, 0
+	}
+	scan, rows := ScanBound, 1
+	start := pattern.Nodes[0]
+	if start.Variable == "" || !x.bound[start.Variable] {
+		scan, rows = ScanFull, x.labelRows(start.Labels)
+	}
+
+	// Independent nodes without relationships are a cartesian product.
+	if len(pattern.Relationships) == 0 {
+		for _, n := range pattern.Nodes[1:] {
+			if n.Variable == "" || !x.bound[n.Variable] {
+				scan = ScanFull
+				rows = saturatingMul(rows, x.labelRows(n.Labels))
+			}
+		}
+		return scan, rows
+	}
+
+	nodes := float64(max(x.graph.CountNodesForTenant(x.tenantID), 1))
+	estimate := float64(rows)
+	for _, rel := range pattern.Relationships {
+		estimate *= x.hopFanOut(rel, nodes)
+	}
+	return scan, clampRows(estimate)
+}
+
+// hopFanOut estimates the rows one input row becomes across rel, from the
+// average degree of its edge types.
+func (x *planExplainer) hopFanOut(rel *RelationshipPattern, nodes float64) float64 {
+	var edges uint64
+	if rel.Type == "" {
+		edges = x.graph.CountEdgesForTenant(x.tenantID)
+	} else {
+		edges = uint64(x.graph.CountEdgesByTypeForTenant(x.tenantID, rel.Type))
+	}
+	degree := float64(edges) / nodes
+	if rel.Direction == DirectionBoth {
+		degree *= 2
+	}
+	if !isVariableLengthRel(rel) {
+		return degree
+	}
+
+	minHops, maxHops := rel.MinHops, rel.MaxHops
+	if maxHops == -1 || maxHops > MaxAllowedTraversalDepth {
+		maxHops = MaxAllowedTraversalDepth
+	}
+	fanOut, reach := 0.0, 1.0
+	for depth := 0; depth <= maxHops; depth++ {
+		if depth >= minHops {
+			fanOut += reach
+		}
+		reach *= degree
+	}
+	return fanOut
+}
+
+// labelRows is the number of tenant nodes carrying every label, bounded by
+// the smallest label.
+func (x *planExplainer) labelRows(labels []string) int {
+	if len(labels) == 0 {
+		return clampRows(float64(x.graph.CountNodesForTenant(x.tenantID)))
+	}
+	rows := -1
+	for _, label := range labels {
+		if n := x.graph.CountNodesByLabelForTenant(x.tenantID, label); rows < 0 || n < rows {
+			rows = n
+		}
+	}
+	return rows
+}
+
+// indexRows counts the index hits the lookup keeps after its label filter.
+func (x *planExplainer) indexRows(s *IndexLookupStep) int {
+	nodes, err := x.graph.FindNodesByPropertyIndexedForTenant(s.propertyKey, s.value, x.tenantID)
+	if err != nil {
+		return 0
+	}
+	rows := 0
+	for _, node := range nodes {
+		if (&MatchStep{}).hasLabels(node, s.labels) {
+			rows++
+		}
+	}
+	return rows
+}
+
+// bindWith carries the node variables a WITH projects, renamed by their
+// aliases, into the next segment.
+func (x *planExplainer) bindWith(with *WithClause) {
+	bound := make(map[string]bool, len(with.Items))
+	for _, item := range with.Items {
+		if item.ValueExpr != nil || item.Expression == nil || item.Expression.Property != "" {
+			continue
+		}
+		if source := item.Expression.Variable; x.bound[source] {
+			name := item.Alias
+			if name == "" {
+				name = source
+			}
+			bound[name] = true
+		}
+	}
+	x.bound = bound
+}
+
+// isVariableLengthRel mirrors the dispatch in MatchStep.traversePath.
+func isVariableLengthRel(rel *RelationshipPattern) bool {
+	return rel.VarLength ||
+		((rel.MinHops != 1 || rel.MaxHops != 1) && (rel.MinHops != 0 || rel.MaxHops != 0))
+}
+
+// formatPattern renders a pattern the way it would be written in a query,
+// without inline properties.
+func formatPattern(pattern *Pattern) string {
+	var b strings.Builder
+	if pattern.PathVariable != "" {
+		b.WriteString(pattern.PathVariable + " = ")
+	}
+	for i, n := range pattern.Nodes {
+		if i > 0 {
+			if i-1 < len(pattern.Relationships) {
+				b.WriteString(formatRelationship(pattern.Relationships[i-1]))
+			} else {
+				b.WriteString(", ")
+			}
+		}
+		b.WriteString(formatNodePattern(n))
+	}
+	return b.String()
+}
+
+func formatNodePattern(n *NodePattern) string {
+	var b strings.Builder
+	b.WriteString("(")
+	b.WriteString(n.Variable)
+	for _, label := range n.Labels {
+		b.WriteString(":")
+		b.WriteString(label)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func formatRelationship(rel *RelationshipPattern) string {
+	inner := rel.Variable
+	if rel.Type != "" {
+		inner += ":" + rel.Type
+	}
+	if isVariableLengthRel(rel) {
+		inner += "*"
+		switch {
+		case rel.MaxHops == -1:
+			inner += fmt.Sprintf("%d..", rel.MinHops)
+		case rel.MinHops == rel.MaxHops:
+			inner += fmt.Sprintf("%d", rel.MinHops)
+		default:
+			inner += fmt.Sprintf("%d..%d", rel.MinHops, rel.MaxHops)
+		}
+	}
+	switch rel.Direction {
+	case DirectionIncoming:
+		return "<-[" + inner + "]-"
+	case DirectionBoth:
+		return "-[" + inner + "]-"
+	default:
+		return "-[" + inner + "]->"
+	}
+}
+
+func clampRows(f float64) int {
+	if f > MaxIntermediateResults {
+		return MaxIntermediateResults
+	}
+	return int(math.Ceil(f))
+}
+
+func saturatingAdd(a, b int) int { return min(a+b, MaxIntermediateResults) }
+
+func saturatingMul(a, b int) int {
+	if a != 0 && b > MaxIntermediateResults/a {
+		return MaxIntermediateResults
+	}
+	return min(a*b, MaxIntermediateResults)
+}
+
+func intPtr(n int) *int { return &n }
+
+// ResultSet renders the plan as rows, the form EXPLAIN returns. step and
+// detail stay the first two columns; scan, estimated_rows and traversal are
+// nil for steps that do not find nodes.
+func (plan *Plan) ResultSet() *ResultSet {
 	result := &ResultSet{
-		Columns: []string{"step", "detail"},
+		Columns: []string{"step", "detail", "scan", "estimated_rows", "traversal"},
 		Rows:    make([]map[string]any, 0, len(plan.Steps)),
 	}
 
 	for _, step := range plan.Steps {
 		row := map[string]any{
-			"step":   "Unknown",
-			"detail": "",
+			"step":           step.Step,
+			"detail":         step.Detail,
+			"scan":           nil,
+			"estimated_rows": nil,
+			"traversal":      nil,
 		}
-		if describer, ok := step.(StepDescriber); ok {
-			row["step"] = describer.StepName()
-			row["detail"] = describer.StepDetail()
+		if step.Scan != "" {
+			row["scan"] = step.Scan
+		}
+		if step.EstimatedRows != nil {
+			row["estimated_rows"] = *step.EstimatedRows
+		}
+		if len(step.Traversal) > 0 {
+			row["traversal"] = strings.Join(step.Traversal, ", ")
 		}
 		result.Rows = append(result.Rows, row)
 	}
@@ -100,11 +477,7 @@ func (e *Executor) executeWithProfiling(ctx context.Context, plan *ExecutionPlan
 	if query.Return != nil {
 		result = e.buildResultSet(execCtx, query.Return, query.Limit, query.Skip)
 	} else {
-		result = &ResultSet{
-			Columns: []string{"affected"},
-			Rows:    []map[string]any{{"affected": len(execCtx.results)}},
-			Count:   len(execCtx.results),
-		}
+		result = buildWriteResultSet(execCtx)
 	}
 
 	result.Profile = profiles
@@ -153,6 +153,36 @@ func TestExecutor_DeleteNode(t *testing.T) {
 	}
 }
 
+// TestExecutor_DetachDeleteByLabel pins that DETACH DELETE removes each
+// matched node once, with its relationships, and reports the count removed.
+func TestExecutor_DetachDeleteByLabel(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	executor := NewExecutor(gs)
+
+	parseAndExecute(t, executor, `CREATE (a:Workstation {name: 'a'})-[:CONNECTS]->(s:Server {name: 's'}), (b:Workstation {name: 'b'})-[:CONNECTS]->(s)`)
+	parseAndExecute(t, executor, `CREATE (:Workstation {name: 'c'})`)
+
+	// b and a are each matched twice through the cross product.
+	result := parseAndExecute(t, executor, `MATCH (n:Workstation), (m:Workstation) WHERE m.name <> 'c' DETACH DELETE n`)
+	if result.Rows[0]["affected"] != 3 {
+		t.Errorf("affected = %v, want 3", result.Rows[0]["affected"])
+	}
+	if stats := gs.GetStatistics(); stats.NodeCount != 1 || stats.EdgeCount != 0 {
+		t.Errorf("graph has %d nodes/%d edges, want the Server alone", stats.NodeCount, stats.EdgeCount)
+	}
+
+	// Relationships can be deleted on their own, fixed or variable length.
+	parseAndExecute(t, executor, `MATCH (s:Server) CREATE (s)-[:R]->(:X)-[:R]->(:Y)`)
+	result = parseAndExecute(t, executor, `MATCH (:Server)-[r:R*1..2]->(:Y) DELETE r`)
+	if result.Rows[0]["affected"] != 2 {
+		t.Errorf("affected = %v, want 2", result.Rows[0]["affected"])
+	}
+	if stats := gs.GetStatistics(); stats.NodeCount != 3 || stats.EdgeCount != 0 {
+		t.Errorf("graph has %d nodes/%d edges, want 3/0", stats.NodeCount, stats.EdgeCount)
+	}
+}
+
 // TestExecutor_Limit tests LIMIT clause
 
 func TestExecutor_CreateRelationship(t *testing.T) {
@@ -281,3 +311,89 @@ func TestExecutor_CreateRelationship(t *testing.T) {
 }
 
 // TestExecutor_SortRows_Ascending tests sorting rows in ascending order
+
+// TestExecutor_CreateReturnsCreatedEntities pins that a CREATE without RETURN
+// projects the entities it bound, so callers (TUI console, /query) can see
+// what was written.
+func TestExecutor_CreateReturnsCreatedEntities(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	executor := NewExecutor(gs)
+
+	result := parseAndExecute(t, executor, `CREATE (a:Person {name: 'Alice'})-[r:KNOWS]->(b:Person {name: 'Bob'})`)
+
+	// Node variables first, then relationship variables.
+	if len(result.Columns) != 3 || result.Columns[0] != "a" || result.Columns[1] != "b" || result.Columns[2] != "r" {
+		t.Fatalf("columns = %v, want [a b r]", result.Columns)
+	}
+	if result.Count != 1 {
+		t.Fatalf("count = %d, want 1", result.Count)
+	}
+	row := result.Rows[0]
+	alice, ok := row["a"].(*storage.Node)
+	if !ok {
+		t.Fatalf("row[a] = %T, want *storage.Node", row["a"])
+	}
+	bob, ok := row["b"].(*storage.Node)
+	if !ok {
+		t.Fatalf("row[b] = %T, want *storage.Node", row["b"])
+	}
+	edge, ok := row["r"].(*storage.Edge)
+	if !ok {
+		t.Fatalf("row[r] = %T, want *storage.Edge", row["r"])
+	}
+	if edge.FromNodeID != alice.ID || edge.ToNodeID != bob.ID || edge.Type != "KNOWS" {
+		t.Errorf("edge = %d-[%s]->%d, want %d-[KNOWS]->%d", edge.FromNodeID, edge.Type, edge.ToNodeID, alice.ID, bob.ID)
+	}
+
+	if stats := gs.GetStatistics(); stats.NodeCount != 2 || stats.EdgeCount != 1 {
+		t.Errorf("graph has %d nodes/%d edges, want 2/1", stats.NodeCount, stats.EdgeCount)
+	}
+
+	// Anonymous-only CREATE keeps the affected-count shape.
+	result = parseAndExecute(t, executor, `CREATE (:Person {name: 'Carol'})`)
+	if len(result.Columns) != 1 || result.Columns[0] != "affected" {
+		t.Errorf("anonymous CREATE columns = %v, want [affected]", result.Columns)
+	}
+}
+
+// TestExecutor_MatchCreateReusesBoundNodes pins that CREATE endpoints bound
+// by MATCH refer to the matched nodes rather than creating duplicates.
+func TestExecutor_MatchCreateReusesBoundNodes(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	executor := NewExecutor(gs)
+
+	parseAndExecute(t, executor, `CREATE (a:Person {name: 'Alice'})`)
+	result := parseAndExecute(t, executor,
+		`MATCH (a:Person {name: 'Alice'}) CREATE (a)-[r:MANAGES]->(b:Team {name: 'Ops'})`)
+
+	// Only the newly created variables are projected.
+	if len(result.Columns) != 2 || result.Columns[0] != "b" || result.Columns[1] != "r" {
+		t.Errorf("columns = %v, want [b r]", result.Columns)
+	}
+	if stats := gs.GetStatistics(); stats.NodeCount != 2 || stats.EdgeCount != 1 {
+		t.Errorf("graph has %d nodes/%d edges, want 2/1 (MATCH-bound node must be reused)", stats.NodeCount, stats.EdgeCount)
+	}
+}
+
+func TestExecutor_MatchCreateWithNoMatchIsNoOp(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	executor := NewExecutor(gs)
+
+	result := parseAndExecute(t, executor,
+		`MATCH (a:Person {name: 'Nobody'}) CREATE (a)-[r:MANAGES]->(b:Team {name: 'Ops'})`)
+	if len(result.Rows) != 1 || result.Rows[0]["affected"] != 0 {
+		t.Errorf("rows = %v, want one row with affected 0", result.Rows)
+	}
+
+	result = parseAndExecute(t, executor,
+		`MATCH (a:Person {name: 'Nobody'}) CREATE (a)-[r:MANAGES]->(b:Team {name: 'Ops'}) RETURN b`)
+	if len(result.Rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(result.Rows))
+	}
+	if stats := gs.GetStatistics(); stats.NodeCount != 0 || stats.EdgeCount != 0 {
+		t.Errorf("graph has %d nodes/%d edges, want 0/0", stats.NodeCount, stats.EdgeCount)
+	}
+}
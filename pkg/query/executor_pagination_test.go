@@ -1,6 +1,8 @@
 package query
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -300,4 +302,63 @@ func TestExecutor_SortRows_Strings(t *testing.T) {
 	}
 }
 
+// TestExecutor_OrderBySkipLimit_Text pins ORDER BY with multiple keys,
+// expression keys, null placement, and SKIP/LIMIT paging through the full
+// lexer → parser → executor path.
+func TestExecutor_OrderBySkipLimit_Text(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+
+	for i, zone := range []string{"b", "a", "b", "a", "c"} {
+		props := map[string]storage.Value{
+			"zone": storage.StringValue(zone),
+			"n":    storage.IntValue(int64(i)),
+		}
+		if i%2 == 0 {
+			props["rank"] = storage.IntValue(int64(10 - i))
+		}
+		if _, err := gs.CreateNode([]string{"Asset"}, props); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	executor := NewExecutor(gs)
+
+	column := func(result *ResultSet, col string) []any {
+		out := make([]any, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			out = append(out, row[col])
+		}
+		return out
+	}
+
+	cases := []struct {
+		query string
+		col   string
+		want  []any
+	}{
+		{"MATCH (x:Asset) RETURN x.n ORDER BY x.zone ASC, x.n DESC", "x.n", []any{int64(3), int64(1), int64(2), int64(0), int64(4)}},
+		{"MATCH (x:Asset) RETURN x.n ORDER BY x.zone DESC, x.n SKIP 1 LIMIT 2", "x.n", []any{int64(0), int64(2)}},
+		{"MATCH (x:Asset) RETURN x.n ORDER BY x.n * -1 LIMIT 3", "x.n", []any{int64(4), int64(3), int64(2)}},
+		{"MATCH (x:Asset) RETURN x.zone ORDER BY toUpper(x.zone) DESC LIMIT 1", "x.zone", []any{"c"}},
+		// Missing sort keys (null) sort after every present value.
+		{"MATCH (x:Asset) RETURN x.n ORDER BY x.rank, x.n", "x.n", []any{int64(4), int64(2), int64(0), int64(1), int64(3)}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			result := parseAndExecute(t, executor, tc.query)
+			got := column(result, tc.col)
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			for _, row := range result.Rows {
+				for key := range row {
+					if strings.HasPrefix(key, "__order_by_") {
+						t.Errorf("hidden sort column %q leaked into results", key)
+					}
+				}
+			}
+		})
+	}
+}
+
 // TestExecutor_Aggregation_COUNT tests COUNT aggregation
@@ -34,6 +34,16 @@ type ExecutionContext struct {
 	tenantID string         // Snapshotted from context at construction.
 	bindings map[string]any // Variable bindings
 	results  []*BindingSet
+
+	// createdVars lists the variables CREATE bound to new entities, in
+	// pattern order. A write query without RETURN projects them so the
+	// caller sees what was created.
+	createdVars []string
+
+	// deleted counts the nodes and relationships DELETE removed, once one
+	// has run (deleteRan); a write query without RETURN reports it.
+	deleted   int
+	deleteRan bool
 }
 
 // newExecutionContext constructs an ExecutionContext, snapshotting
@@ -204,10 +214,40 @@ func (e *Executor) executePlanWithContext(ctx context.Context, plan *ExecutionPl
 		return e.buildResultSet(execCtx, query.Return, query.Limit, query.Skip), nil
 	}
 
-	// For write queries, return count
+	return buildWriteResultSet(execCtx), nil
+}
+
+// buildWriteResultSet builds the result of a query without RETURN. When
+// CREATE bound named variables, each row carries the created entities under
+// those variables; a DELETE reports the number of entities it removed;
+// otherwise the result is a single "affected" row count.
+func buildWriteResultSet(execCtx *ExecutionContext) *ResultSet {
+	if execCtx.deleteRan && len(execCtx.createdVars) == 0 {
+		return &ResultSet{
+			Columns: []string{"affected"},
+			Rows:    []map[string]any{{"affected": execCtx.deleted}},
+			Count:   execCtx.deleted,
+		}
+	}
+	if len(execCtx.createdVars) == 0 {
+		return &ResultSet{
+			Columns: []string{"affected"},
+			Rows:    []map[string]any{{"affected": len(execCtx.results)}},
+			Count:   len(execCtx.results),
+		}
+	}
+
+	rows := make([]map[string]any, 0, len(execCtx.results))
+	for _, binding := range execCtx.results {
+		row := make(map[string]any, len(execCtx.createdVars))
+		for _, v := range execCtx.createdVars {
+			row[v] = binding.bindings[v]
+		}
+		rows = append(rows, row)
+	}
 	return &ResultSet{
-		Columns: []string{"affected"},
-		Rows:    []map[string]any{{"affected": len(execCtx.results)}},
-		Count:   len(execCtx.results),
-	}, nil
+		Columns: append([]string(nil), execCtx.createdVars...),
+		Rows:    rows,
+		Count:   len(rows),
+	}
 }
@@ -49,10 +49,27 @@ func buildColumnName(item *ReturnItem) string {
 	return invalidColumnName
 }
 
+// ResultColumns returns the columns a query's RETURN produces, in order: the
+// last WITH segment's RETURN, or the first UNION branch's. It returns nil for
+// a query without RETURN, whose columns depend on what it writes.
+func (q *Query) ResultColumns() []string {
+	for q.Next != nil {
+		q = q.Next
+	}
+	if q.Return == nil {
+		return nil
+	}
+	columns := make([]string, len(q.Return.Items))
+	for i, item := range q.Return.Items {
+		columns[i] = buildColumnName(item)
+	}
+	return columns
+}
+
 // buildResultSet builds the final result set
 func (e *Executor) buildResultSet(ctx *ExecutionContext, returnClause *ReturnClause, limit, skip int) *ResultSet {
-	// Check if we have GROUP BY
-	if len(returnClause.GroupBy) > 0 {
+	// Check if we have GROUP BY, or aggregates grouped by the other items
+	if len(returnClause.GroupBy) > 0 || needsGrouping(returnClause.Items) {
 		return e.buildGroupedResultSet(ctx, returnClause, limit, skip)
 	}
 
@@ -65,7 +82,8 @@ func (e *Executor) buildResultSet(ctx *ExecutionContext, returnClause *ReturnCla
 	return e.buildRegularResultSet(ctx, returnClause, limit, skip)
 }
 
-// buildAggregateResultSet builds results for aggregate queries without GROUP BY
+// buildAggregateResultSet builds results for queries whose items are all
+// aggregates
 func (e *Executor) buildAggregateResultSet(ctx *ExecutionContext, returnClause *ReturnClause) *ResultSet {
 	resultSet := &ResultSet{
 		Columns: make([]string, 0),
@@ -86,7 +104,8 @@ func (e *Executor) buildAggregateResultSet(ctx *ExecutionContext, returnClause *
 	return resultSet
 }
 
-// buildGroupedResultSet builds results for GROUP BY queries
+// buildGroupedResultSet builds results for GROUP BY queries and for
+// aggregates mixed with non-aggregate items
 func (e *Executor) buildGroupedResultSet(ctx *ExecutionContext, returnClause *ReturnClause, limit, skip int) *ResultSet {
 	resultSet := &ResultSet{
 		Columns: make([]string, 0),
@@ -129,7 +148,11 @@ func (e *Executor) buildRegularResultSet(ctx *ExecutionContext, returnClause *Re
 		for _, c := range resultSet.Columns {
 			colSet[c] = true
 		}
-		for _, ob := range returnClause.OrderBy {
+		for i, ob := range returnClause.OrderBy {
+			if ob.ValueExpr != nil {
+				extraSortCols = append(extraSortCols, orderByExprColumn(i))
+				continue
+			}
 			if ob.Expression != nil {
 				name := orderByColKey(ob.Expression)
 				if !colSet[name] {
@@ -144,7 +167,11 @@ func (e *Executor) buildRegularResultSet(ctx *ExecutionContext, returnClause *Re
 	computer := &AggregationComputer{}
 	for _, binding := range ctx.results {
 		row := e.buildRow(binding, returnClause.Items, resultSet.Columns, computer)
-		for _, ob := range returnClause.OrderBy {
+		for i, ob := range returnClause.OrderBy {
+			if ob.ValueExpr != nil {
+				row[orderByExprColumn(i)] = extractValue(ob.ValueExpr, binding.bindings)
+				continue
+			}
 			if ob.Expression != nil {
 				name := orderByColKey(ob.Expression)
 				if _, exists := row[name]; !exists {
@@ -218,10 +245,7 @@ func (e *Executor) extractValueFromBinding(binding *BindingSet, expr *PropertyEx
 	// Handle *storage.Edge bindings
 	if edge, ok := obj.(*storage.Edge); ok {
 		if expr.Property != "" {
-			if prop, exists := edge.Properties[expr.Property]; exists {
-				return extractStorageValue(prop)
-			}
-			return nil
+			return edgePropertyValue(edge, expr.Property)
 		}
 		return edge
 	}
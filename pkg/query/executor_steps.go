@@ -3,6 +3,7 @@ package query
 import (
 	"fmt"
 	"log"
+	"slices"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
@@ -133,109 +134,152 @@ func (ils *IndexLookupStep) Execute(ctx *ExecutionContext) error {
 	return nil
 }
 
-// CreateStep executes a CREATE clause
+// CreateStep executes a CREATE clause.
+//
+// Patterns are applied once per input row, mirroring CreateOperator: a node
+// pattern whose variable is already bound in the row (by MATCH, or by an
+// earlier pattern in the same CREATE) reuses that node instead of creating a
+// new one, and relationship variables are bound to the created edge so
+// RETURN can project them. With no input rows (a MATCH that found nothing)
+// there is nothing to create for, so the step is a no-op.
 type CreateStep struct {
 	create *CreateClause
 }
 
 func (cs *CreateStep) Execute(ctx *ExecutionContext) error {
-	for _, pattern := range cs.create.Patterns {
-		// Create nodes first
-		if err := cs.createNodes(ctx, pattern); err != nil {
+	for i, binding := range ctx.results {
+		newlyBound, err := cs.createForBinding(ctx, binding)
+		if err != nil {
 			return err
 		}
-
-		// Create relationships
-		if err := cs.createRelationships(ctx, pattern); err != nil {
-			return err
+		// Every row binds the same variable set, so the first row's is
+		// representative.
+		if i == 0 {
+			ctx.createdVars = appendUnique(ctx.createdVars, newlyBound...)
 		}
 	}
-
 	return nil
 }
 
-// createNodes creates nodes for a pattern
-func (cs *CreateStep) createNodes(ctx *ExecutionContext, pattern *Pattern) error {
-	for _, nodePattern := range pattern.Nodes {
-		// Convert properties
-		props := make(map[string]storage.Value)
-		for key, val := range nodePattern.Properties {
-			sv, err := convertCreateProperty(val)
-			if err != nil {
-				return err
+// createForBinding creates the clause's nodes and relationships for a single
+// row, binding named variables into it. Returns the variables this call
+// bound, in pattern order.
+func (cs *CreateStep) createForBinding(ctx *ExecutionContext, binding *BindingSet) ([]string, error) {
+	var newlyBound []string
+
+	for _, pattern := range cs.create.Patterns {
+		nodes := make(map[*NodePattern]*storage.Node, len(pattern.Nodes))
+
+		for _, nodePattern := range pattern.Nodes {
+			if existing, ok := cs.boundNode(binding, nodePattern.Variable); ok {
+				nodes[nodePattern] = existing
+				continue
 			}
-			props[key] = sv
-		}
 
-		// Audit A6c-query: tenant-scoped node create.
-		node, err := ctx.graph.CreateNodeWithTenant(ctx.tenantID, nodePattern.Labels, props)
-		if err != nil {
-			return err
-		}
+			props, err := convertCreateProperties(nodePattern.Properties)
+			if err != nil {
+				return nil, err
+			}
 
-		// Bind variable
-		if nodePattern.Variable != "" {
-			for _, binding := range ctx.results {
+			// Audit A6c-query: tenant-scoped node create.
+			node, err := ctx.graph.CreateNodeWithTenant(ctx.tenantID, nodePattern.Labels, props)
+			if err != nil {
+				return nil, err
+			}
+			nodes[nodePattern] = node
+			if nodePattern.Variable != "" {
 				binding.bindings[nodePattern.Variable] = node
+				newlyBound = append(newlyBound, nodePattern.Variable)
 			}
 		}
-	}
-	return nil
-}
 
-// createRelationships creates relationships for a pattern
-func (cs *CreateStep) createRelationships(ctx *ExecutionContext, pattern *Pattern) error {
-	for _, relPattern := range pattern.Relationships {
-		// Safe type assertions with validation
-		if len(ctx.results) == 0 {
-			return fmt.Errorf("no bindings available for relationship creation")
-		}
-
-		fromNode, err := cs.getNodeFromBinding(ctx, relPattern.From.Variable, "from")
-		if err != nil {
-			return err
-		}
+		for _, relPattern := range pattern.Relationships {
+			fromNode, err := cs.resolveEndpoint(binding, nodes, relPattern.From, "from")
+			if err != nil {
+				return nil, err
+			}
+			toNode, err := cs.resolveEndpoint(binding, nodes, relPattern.To, "to")
+			if err != nil {
+				return nil, err
+			}
 
-		toNode, err := cs.getNodeFromBinding(ctx, relPattern.To.Variable, "to")
-		if err != nil {
-			return err
-		}
+			props, err := convertCreateProperties(relPattern.Properties)
+			if err != nil {
+				return nil, err
+			}
 
-		props := make(map[string]storage.Value)
-		for key, val := range relPattern.Properties {
-			sv, err := convertCreateProperty(val)
+			// Audit A6c-query: tenant-scoped edge create. Uses
+			// CreateEdgeWithTenant which is tenant-strict on from/to
+			// node verification (A6a follow-up #20), so a Cypher CREATE
+			// referencing a foreign-tenant node ID surfaces
+			// ErrNodeNotFound.
+			edge, err := ctx.graph.CreateEdgeWithTenant(ctx.tenantID, fromNode.ID, toNode.ID, relPattern.Type, props, 1.0)
 			if err != nil {
-				return err
+				return nil, err
+			}
+			if relPattern.Variable != "" {
+				binding.bindings[relPattern.Variable] = edge
+				newlyBound = append(newlyBound, relPattern.Variable)
 			}
-			props[key] = sv
 		}
+	}
+	return newlyBound, nil
+}
 
-		// Audit A6c-query: tenant-scoped edge create. Uses
-		// CreateEdgeWithTenant which is tenant-strict on from/to
-		// node verification (A6a follow-up #20), so a Cypher CREATE
-		// referencing a foreign-tenant node ID surfaces
-		// ErrNodeNotFound.
-		_, err = ctx.graph.CreateEdgeWithTenant(ctx.tenantID, fromNode.ID, toNode.ID, relPattern.Type, props, 1.0)
-		if err != nil {
-			return err
-		}
+// boundNode returns the node already bound to variable in the row, if any.
+func (cs *CreateStep) boundNode(binding *BindingSet, variable string) (*storage.Node, bool) {
+	if variable == "" {
+		return nil, false
 	}
-	return nil
+	node, ok := binding.bindings[variable].(*storage.Node)
+	return node, ok
 }
 
-// getNodeFromBinding extracts a node from bindings with proper error handling
-func (cs *CreateStep) getNodeFromBinding(ctx *ExecutionContext, variable, role string) (*storage.Node, error) {
-	nodeInterface, exists := ctx.results[0].bindings[variable]
+// resolveEndpoint finds the node a relationship endpoint refers to: the node
+// created (or reused) for that pattern element, else the row binding for its
+// variable.
+func (cs *CreateStep) resolveEndpoint(binding *BindingSet, nodes map[*NodePattern]*storage.Node, endpoint *NodePattern, role string) (*storage.Node, error) {
+	if endpoint == nil {
+		return nil, fmt.Errorf("%s node missing in relationship pattern", role)
+	}
+	if node, ok := nodes[endpoint]; ok {
+		return node, nil
+	}
+	nodeInterface, exists := binding.bindings[endpoint.Variable]
 	if !exists {
-		return nil, fmt.Errorf("%s node variable '%s' not bound", role, variable)
+		return nil, fmt.Errorf("%s node variable '%s' not bound", role, endpoint.Variable)
 	}
 	node, ok := nodeInterface.(*storage.Node)
 	if !ok {
-		return nil, fmt.Errorf("%s node variable '%s' is not a Node", role, variable)
+		return nil, fmt.Errorf("%s node variable '%s' is not a Node", role, endpoint.Variable)
 	}
 	return node, nil
 }
 
+// convertCreateProperties converts a CREATE/MERGE property map via
+// convertCreateProperty.
+func convertCreateProperties(in map[string]any) (map[string]storage.Value, error) {
+	props := make(map[string]storage.Value, len(in))
+	for key, val := range in {
+		sv, err := convertCreateProperty(val)
+		if err != nil {
+			return nil, err
+		}
+		props[key] = sv
+	}
+	return props, nil
+}
+
+// appendUnique appends each value not already present in dst.
+func appendUnique(dst []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(dst, v) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
 // SetStep executes a SET clause
 type SetStep struct {
 	set *SetClause
@@ -325,15 +369,21 @@ func (rs *RemoveStep) removeProperty(ctx *ExecutionContext, binding *BindingSet,
 func (rs *RemoveStep) StepName() string   { return "RemoveStep" }
 func (rs *RemoveStep) StepDetail() string { return fmt.Sprintf("items=%d", len(rs.remove.Items)) }
 
-// DeleteStep executes a DELETE clause
+// DeleteStep executes a DELETE clause. Deleting a node removes its
+// relationships too, so DELETE and DETACH DELETE behave the same.
 type DeleteStep struct {
 	delete *DeleteClause
 }
 
 func (ds *DeleteStep) Execute(ctx *ExecutionContext) error {
+	// A node or relationship bound in several rows is deleted once.
+	deletedNodes := make(map[uint64]bool)
+	deletedEdges := make(map[uint64]bool)
+	ctx.deleteRan = true
+
 	for _, binding := range ctx.results {
 		for _, variable := range ds.delete.Variables {
-			if err := ds.deleteVariable(ctx, binding, variable); err != nil {
+			if err := ds.deleteVariable(ctx, binding, variable, deletedNodes, deletedEdges); err != nil {
 				return err
 			}
 		}
@@ -342,23 +392,42 @@ func (ds *DeleteStep) Execute(ctx *ExecutionContext) error {
 	return nil
 }
 
-// deleteVariable deletes a single variable from bindings
-func (ds *DeleteStep) deleteVariable(ctx *ExecutionContext, binding *BindingSet, variable string) error {
-	obj, ok := binding.bindings[variable]
-	if !ok {
-		return nil // Variable not bound, skip
+// deleteVariable deletes the node or relationship bound to variable, unless
+// an earlier row already did. A relationship already removed along with
+// one of its nodes is skipped.
+func (ds *DeleteStep) deleteVariable(ctx *ExecutionContext, binding *BindingSet, variable string, deletedNodes, deletedEdges map[uint64]bool) error {
+	switch obj := binding.bindings[variable].(type) {
+	case *storage.Node:
+		if deletedNodes[obj.ID] {
+			return nil
+		}
+		// Audit A6c-query: tenant-scoped delete (handles edge deletion).
+		if err := ctx.graph.DeleteNodeForTenant(obj.ID, ctx.tenantID); err != nil {
+			return fmt.Errorf("failed to delete node %d: %w", obj.ID, err)
+		}
+		deletedNodes[obj.ID] = true
+		ctx.deleted++
+	case *storage.Edge:
+		return deleteEdge(ctx, obj, deletedNodes, deletedEdges)
+	case []*storage.Edge: // a variable-length relationship
+		for _, edge := range obj {
+			if err := deleteEdge(ctx, edge, deletedNodes, deletedEdges); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
+}
 
-	node, ok := obj.(*storage.Node)
-	if !ok {
-		return nil // Not a node, skip
+func deleteEdge(ctx *ExecutionContext, edge *storage.Edge, deletedNodes, deletedEdges map[uint64]bool) error {
+	if deletedEdges[edge.ID] || deletedNodes[edge.FromNodeID] || deletedNodes[edge.ToNodeID] {
+		return nil
 	}
-
-	// Audit A6c-query: tenant-scoped delete (handles edge deletion).
-	if err := ctx.graph.DeleteNodeForTenant(node.ID, ctx.tenantID); err != nil {
-		return fmt.Errorf("failed to delete node %d: %w", node.ID, err)
+	if err := ctx.graph.DeleteEdgeForTenant(edge.ID, ctx.tenantID); err != nil {
+		return fmt.Errorf("failed to delete relationship %d: %w", edge.ID, err)
 	}
-
+	deletedEdges[edge.ID] = true
+	ctx.deleted++
 	return nil
 }
 
@@ -368,30 +437,15 @@ type MergeStep struct {
 }
 
 func (ms *MergeStep) Execute(ctx *ExecutionContext) error {
-	// Try to match the pattern
-	matchStep := &MatchStep{match: &MatchClause{Patterns: []*Pattern{ms.merge.Pattern}}}
-
-	// Save current results, try matching. Inherit tenantID from
-	// parent ctx (audit A6c-query) — sub-context must scope to the
-	// same tenant.
 	savedResults := ctx.results
-	matchCtx := &ExecutionContext{
-		context:  ctx.context,
-		graph:    ctx.graph,
-		tenantID: ctx.tenantID,
-		bindings: make(map[string]any),
-		results: []*BindingSet{
-			{bindings: make(map[string]any)},
-		},
-	}
-
-	if err := matchStep.Execute(matchCtx); err != nil {
+	matches, err := ms.findExisting(ctx)
+	if err != nil {
 		return err
 	}
 
-	if len(matchCtx.results) > 0 {
+	if len(matches) > 0 {
 		// Found — apply ON MATCH SET if present
-		ctx.results = matchCtx.results
+		ctx.results = matches
 		if ms.merge.OnMatch != nil {
 			setStep := &SetStep{set: ms.merge.OnMatch}
 			if err := setStep.Execute(ctx); err != nil {
@@ -418,6 +472,73 @@ func (ms *MergeStep) Execute(ctx *ExecutionContext) error {
 	return nil
 }
 
+// findExisting returns a binding per existing match of the MERGE pattern.
+// A single-node pattern with an indexed property is looked up in the
+// property index; anything else is matched like MATCH, by scanning.
+func (ms *MergeStep) findExisting(ctx *ExecutionContext) ([]*BindingSet, error) {
+	if key, value, ok := ms.indexedProperty(ctx.graph); ok {
+		if nodes, err := ctx.graph.FindNodesByPropertyIndexedForTenant(key, value, ctx.tenantID); err == nil {
+			node := ms.merge.Pattern.Nodes[0]
+			matcher := &MatchStep{}
+			matches := make([]*BindingSet, 0, len(nodes))
+			for _, n := range nodes {
+				if !matcher.hasLabels(n, node.Labels) || !matcher.matchProperties(n.Properties, node.Properties) {
+					continue
+				}
+				binding := &BindingSet{bindings: make(map[string]any)}
+				if node.Variable != "" {
+					binding.bindings[node.Variable] = n
+				}
+				matches = append(matches, binding)
+			}
+			return matches, nil
+		}
+		// The index rejected the value (e.g. a type mismatch); scan instead.
+	}
+
+	// Match in a sub-context that inherits tenantID from the parent ctx
+	// (audit A6c-query) — it must scope to the same tenant.
+	matchStep := &MatchStep{match: &MatchClause{Patterns: []*Pattern{ms.merge.Pattern}}}
+	matchCtx := &ExecutionContext{
+		context:  ctx.context,
+		graph:    ctx.graph,
+		tenantID: ctx.tenantID,
+		bindings: make(map[string]any),
+		results: []*BindingSet{
+			{bindings: make(map[string]any)},
+		},
+	}
+	if err := matchStep.Execute(matchCtx); err != nil {
+		return nil, err
+	}
+	return matchCtx.results, nil
+}
+
+// indexedProperty picks the property a single-node MERGE pattern can be
+// looked up by: the first, in key order, that has a property index and an
+// indexable literal value.
+func (ms *MergeStep) indexedProperty(graph *storage.GraphStorage) (string, storage.Value, bool) {
+	pattern := ms.merge.Pattern
+	if len(pattern.Nodes) != 1 || len(pattern.Relationships) != 0 {
+		return "", storage.Value{}, false
+	}
+	props := pattern.Nodes[0].Properties
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if !graph.HasPropertyIndex(key) {
+			continue
+		}
+		if value, ok := convertToStorageValueForIndex(props[key]); ok {
+			return key, value, true
+		}
+	}
+	return "", storage.Value{}, false
+}
+
 func (ms *MergeStep) StepName() string   { return "MergeStep" }
 func (ms *MergeStep) StepDetail() string { return "match-or-create" }
 
@@ -485,14 +606,9 @@ func (oms *OptionalMatchStep) Execute(ctx *ExecutionContext) error {
 	newResults := make([]*BindingSet, 0)
 
 	for _, binding := range ctx.results {
-		matches := make([]*BindingSet, 0)
-
-		for _, pattern := range oms.match.Patterns {
-			patternMatches, err := matchHelper.matchPattern(ctx, pattern, binding)
-			if err != nil {
-				return err
-			}
-			matches = append(matches, patternMatches...)
+		matches, err := matchHelper.matchPatterns(ctx, oms.match.Patterns, binding)
+		if err != nil {
+			return err
 		}
 
 		// Apply scoped WHERE filter if present
@@ -519,6 +635,11 @@ func (oms *OptionalMatchStep) Execute(ctx *ExecutionContext) error {
 				nullBinding.bindings[k] = v
 			}
 			for _, pattern := range oms.match.Patterns {
+				if pattern.PathVariable != "" {
+					if _, exists := nullBinding.bindings[pattern.PathVariable]; !exists {
+						nullBinding.bindings[pattern.PathVariable] = nil
+					}
+				}
 				for _, node := range pattern.Nodes {
 					if node.Variable != "" {
 						if _, exists := nullBinding.bindings[node.Variable]; !exists {
@@ -0,0 +1,214 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// streamPageSize is how many start nodes ExecuteStream clones per page.
+const streamPageSize = 1000
+
+// errStreamLimit ends a streamed scan once LIMIT rows have been emitted.
+var errStreamLimit = errors.New("stream limit reached")
+
+// RowFunc receives one result row. Returning an error stops the query and
+// ExecuteStream returns that error.
+type RowFunc func(row map[string]any) error
+
+// ExecuteStream runs query and hands each result row to fn as it is produced,
+// instead of collecting a ResultSet.
+//
+// A plain read of one MATCH pattern (optionally with WHERE, SKIP and LIMIT)
+// is streamed: start nodes are scanned a page at a time and each is matched,
+// filtered and projected on its own, so memory is bounded by a page plus one
+// node's expansion rather than by the result size. Queries that need every
+// row before the first can be emitted (ORDER BY, DISTINCT, aggregates, WITH,
+// UNION, OPTIONAL MATCH) or that write run as Execute does and their rows
+// are then handed to fn one by one.
+func (e *Executor) ExecuteStream(query *Query, fn RowFunc) error {
+	return e.ExecuteStreamWithContext(context.Background(), query, fn)
+}
+
+// ExecuteStreamWithContext is ExecuteStream honouring ctx's deadline,
+// cancellation, tenant and limits. A read stops with an
+// ErrQueryLimitExceeded error as soon as its row past MaxRows is produced;
+// the rows before it have already been handed to fn.
+func (e *Executor) ExecuteStreamWithContext(ctx context.Context, query *Query, fn RowFunc) error {
+	limits := e.limitsFor(ctx)
+	ctx, cancel := limits.apply(ctx)
+	defer cancel()
+
+	if !query.IsWrite() && limits.MaxRows > 0 {
+		emit, rows := fn, 0
+		fn = func(row map[string]any) error {
+			rows++
+			if err := limits.checkRows(rows); err != nil {
+				return err
+			}
+			return emit(row)
+		}
+	}
+	if err := e.executeStream(ctx, query, fn); err != nil {
+		return limitError(ctx, err)
+	}
+	return nil
+}
+
+// executeStream streams query under ctx, which already carries its limits.
+func (e *Executor) executeStream(ctx context.Context, query *Query, fn RowFunc) error {
+	if !isStreamable(query) {
+		return e.executeThenYield(ctx, query, fn)
+	}
+	optimized := e.optimizer.Optimize(e.buildExecutionPlan(query), query)
+	for _, step := range optimized.Steps {
+		switch step.(type) {
+		case *MatchStep, *IndexLookupStep, *FilterStep, *ReturnStep:
+		default:
+			return e.executeThenYield(ctx, query, fn)
+		}
+	}
+	return e.streamMatch(ctx, query, optimized, fn)
+}
+
+// ExecuteStreamWithParamsContext is ExecuteStreamWithContext with $name
+// parameters substituted, as ExecuteWithParamsContext does.
+func (e *Executor) ExecuteStreamWithParamsContext(ctx context.Context, query *Query, params map[string]any, fn RowFunc) error {
+	if err := e.injectParams(query, params); err != nil {
+		return err
+	}
+	return e.ExecuteStreamWithContext(ctx, query, fn)
+}
+
+// isStreamable reports whether query can be answered start node by start
+// node: a single MATCH pattern with a named start node, no writes, and a
+// RETURN whose rows need no other row to be computed.
+func isStreamable(q *Query) bool {
+	if q.Explain || q.Profile || q.Union != nil || q.Next != nil || q.With != nil || q.Call != nil {
+		return false
+	}
+	if len(q.OptionalMatches) > 0 || q.IsWrite() || q.Unwind != nil || len(q.InitialBindings) > 1 {
+		return false
+	}
+	if q.Match == nil || len(q.Match.Patterns) != 1 || q.Return == nil {
+		return false
+	}
+	pattern := q.Match.Patterns[0]
+	if len(pattern.Nodes) == 0 || pattern.Nodes[0].Variable == "" {
+		return false
+	}
+	if len(pattern.Nodes) > 1 && len(pattern.Relationships) == 0 {
+		return false // cartesian product
+	}
+	r := q.Return
+	return !r.Distinct && len(r.OrderBy) == 0 && len(r.GroupBy) == 0 && !hasAggregates(r.Items)
+}
+
+// streamMatch binds each candidate start node in turn and runs the
+// unoptimized plan on it; MatchStep validates a bound start node instead of
+// scanning, so each run only expands that node.
+func (e *Executor) streamMatch(ctx context.Context, query *Query, optimized *ExecutionPlan, fn RowFunc) error {
+	plan := e.buildExecutionPlan(query)
+	start := query.Match.Patterns[0].Nodes[0]
+	execCtx := newExecutionContext(ctx, e.graph)
+
+	var params map[string]any
+	if len(query.InitialBindings) == 1 {
+		params = query.InitialBindings[0].bindings
+	}
+
+	columns := query.ResultColumns()
+	computer := &AggregationComputer{}
+	skipped, emitted := 0, 0
+
+	visit := func(node *storage.Node) error {
+		if err := execCtx.CheckCancellation(); err != nil {
+			return err
+		}
+		binding := &BindingSet{bindings: make(map[string]any, len(params)+1)}
+		for k, v := range params {
+			binding.bindings[k] = v
+		}
+		binding.bindings[start.Variable] = node
+		execCtx.results = []*BindingSet{binding}
+		for _, step := range plan.Steps {
+			if err := step.Execute(execCtx); err != nil {
+				return err
+			}
+		}
+		for _, b := range execCtx.results {
+			if skipped < query.Skip {
+				skipped++
+				continue
+			}
+			if err := fn(e.buildRow(b, query.Return.Items, columns, computer)); err != nil {
+				return err
+			}
+			emitted++
+			if query.Limit > 0 && emitted >= query.Limit {
+				return errStreamLimit
+			}
+		}
+		return nil
+	}
+
+	err := e.scanStartNodes(execCtx.tenantID, start, optimized, visit)
+	if errors.Is(err, errStreamLimit) {
+		return nil
+	}
+	return err
+}
+
+// scanStartNodes calls visit for every node that could bind the pattern's
+// start node: the index hits when the optimizer chose an index lookup,
+// otherwise the tenant's nodes (narrowed to the first label) a page at a
+// time.
+func (e *Executor) scanStartNodes(tenantID string, start *NodePattern, optimized *ExecutionPlan, visit func(*storage.Node) error) error {
+	if lookup, ok := optimized.Steps[0].(*IndexLookupStep); ok {
+		nodes, err := e.graph.FindNodesByPropertyIndexedForTenant(lookup.propertyKey, lookup.value, tenantID)
+		if err != nil {
+			return fmt.Errorf("index lookup failed: %w", err)
+		}
+		for _, node := range nodes {
+			if err := visit(node); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	page := func(after uint64) ([]*storage.Node, uint64) {
+		if len(start.Labels) > 0 {
+			return e.graph.NodesByLabelPageForTenant(tenantID, start.Labels[0], after, streamPageSize)
+		}
+		return e.graph.NodesPageForTenant(tenantID, after, streamPageSize)
+	}
+	for after := uint64(0); ; {
+		nodes, next := page(after)
+		for _, node := range nodes {
+			if err := visit(node); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		after = next
+	}
+}
+
+// executeThenYield runs query to a full ResultSet and hands its rows to fn.
+func (e *Executor) executeThenYield(ctx context.Context, query *Query, fn RowFunc) error {
+	result, err := e.ExecuteWithContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	for _, row := range result.Rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+func parseTestQuery(t *testing.T, queryText string) *Query {
+	t.Helper()
+	tokens, err := NewLexer(queryText).Tokenize()
+	if err != nil {
+		t.Fatalf("Tokenize failed for %q: %v", queryText, err)
+	}
+	query, err := NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("Parse failed for %q: %v", queryText, err)
+	}
+	return query
+}
+
+// TestExecuteStream_MatchesExecute pins that streaming yields exactly the
+// rows Execute returns, in the same order, for streamed and fallback shapes.
+func TestExecuteStream_MatchesExecute(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+
+	var people []*storage.Node
+	for i := 0; i < 2*streamPageSize+5; i++ {
+		n, err := gs.CreateNode([]string{"Person"}, map[string]storage.Value{
+			"name": storage.StringValue(fmt.Sprintf("p%d", i)),
+			"age":  storage.IntValue(int64(i % 50)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		people = append(people, n)
+		if _, err := gs.CreateNode([]string{"City"}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i+1 < len(people); i += 3 {
+		if _, err := gs.CreateEdge(people[i].ID, people[i+1].ID, "KNOWS", nil, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := gs.CreatePropertyIndex("name", storage.TypeString); err != nil {
+		t.Fatal(err)
+	}
+	executor := NewExecutor(gs)
+
+	queries := []string{
+		"MATCH (n:Person) RETURN n.name",
+		"MATCH (n) RETURN n",
+		"MATCH (n:Person) WHERE n.age > 40 RETURN n.name, n.age",
+		"MATCH (a:Person)-[:KNOWS]->(b) RETURN a.name, b.name",
+		"MATCH (n:Person) WHERE n.name = 'p7' RETURN n.age",
+		"MATCH (n:Person) RETURN n.name SKIP 990 LIMIT 30",
+		// Fallbacks: need every row before emitting the first.
+		"MATCH (n:Person) RETURN n.name ORDER BY n.name DESC LIMIT 5",
+		"MATCH (n:Person) RETURN count(n)",
+		"MATCH (n:Person) RETURN DISTINCT n.age",
+	}
+	for _, q := range queries {
+		t.Run(q, func(t *testing.T) {
+			want, err := executor.Execute(parseTestQuery(t, q))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []map[string]any
+			err = executor.ExecuteStream(parseTestQuery(t, q), func(row map[string]any) error {
+				got = append(got, row)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(want.Rows) || (len(got) > 0 && !reflect.DeepEqual(got, want.Rows)) {
+				t.Errorf("streamed %d rows, Execute returned %d; rows differ", len(got), len(want.Rows))
+			}
+		})
+	}
+}
+
+func TestExecuteStream_StopsEarly(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	for i := 0; i < 10; i++ {
+		if _, err := gs.CreateNode([]string{"Person"}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	executor := NewExecutor(gs)
+
+	stop := errors.New("enough")
+	rows := 0
+	err := executor.ExecuteStream(parseTestQuery(t, "MATCH (n:Person) RETURN n"), func(map[string]any) error {
+		rows++
+		if rows == 3 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || rows != 3 {
+		t.Errorf("ExecuteStream = %v after %d rows, want the callback's error after 3", err, rows)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := executor.ExecuteStreamWithContext(ctx, parseTestQuery(t, "MATCH (n:Person) RETURN n"), func(map[string]any) error {
+		return nil
+	}); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled ExecuteStreamWithContext = %v, want context.Canceled", err)
+	}
+}
+
+func TestExecuteStream_Params(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	for _, name := range []string{"Alice", "Bob"} {
+		if _, err := gs.CreateNode([]string{"Person"}, map[string]storage.Value{"name": storage.StringValue(name)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	executor := NewExecutor(gs)
+
+	var got []any
+	err := executor.ExecuteStreamWithParamsContext(context.Background(),
+		parseTestQuery(t, "MATCH (n:Person) WHERE n.name = $name RETURN n.name"),
+		map[string]any{"name": "Bob"},
+		func(row map[string]any) error {
+			got = append(got, row["n.name"])
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "Bob" {
+		t.Errorf("rows = %v, want [Bob]", got)
+	}
+}
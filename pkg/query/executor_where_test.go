@@ -1,6 +1,8 @@
 package query
 
 import (
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -137,3 +139,80 @@ func TestExecutor_WhereClause_Equals(t *testing.T) {
 }
 
 // TestExecutor_CreateRelationship tests creating relationships
+
+// TestExecutor_WhereOperators exercises each WHERE operator end-to-end
+// (lexer → parser → executor) against a small seeded infrastructure graph,
+// including precedence and null/missing-property handling.
+func TestExecutor_WhereOperators(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+
+	seed := []map[string]storage.Value{
+		{"name": storage.StringValue("PLC-1"), "zone": storage.StringValue("control"), "ports": storage.IntValue(2), "critical": storage.BoolValue(true)},
+		{"name": storage.StringValue("HMI-1"), "zone": storage.StringValue("dmz"), "ports": storage.IntValue(5), "critical": storage.BoolValue(false)},
+		{"name": storage.StringValue("Historian"), "zone": storage.StringValue("dmz"), "ports": storage.IntValue(8)},
+		{"name": storage.StringValue("RTU-7"), "zone": storage.StringValue("field"), "score": storage.FloatValue(3.0)},
+	}
+	for _, props := range seed {
+		if _, err := gs.CreateNode([]string{"Asset"}, props); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	executor := NewExecutor(gs)
+
+	cases := []struct {
+		where string
+		want  []string
+	}{
+		{"n.ports > 4", []string{"HMI-1", "Historian"}},
+		{"n.ports < 5", []string{"PLC-1"}},
+		{"n.ports >= 5", []string{"HMI-1", "Historian"}},
+		{"n.ports <= 5", []string{"HMI-1", "PLC-1"}},
+		{"n.zone = 'dmz'", []string{"HMI-1", "Historian"}},
+		{"n.zone <> 'dmz'", []string{"PLC-1", "RTU-7"}},
+		{"n.zone != 'dmz'", []string{"PLC-1", "RTU-7"}},
+		{"n.name > 'Q'", []string{"RTU-7"}},
+		{"n.score = 3", []string{"RTU-7"}},
+		{"n.zone = 'dmz' AND n.ports > 6", []string{"Historian"}},
+		{"n.zone = 'control' OR n.zone = 'field'", []string{"PLC-1", "RTU-7"}},
+		{"NOT n.zone = 'dmz'", []string{"PLC-1", "RTU-7"}},
+		// AND binds tighter than OR.
+		{"n.zone = 'field' OR n.zone = 'dmz' AND n.ports > 6", []string{"Historian", "RTU-7"}},
+		{"(n.zone = 'field' OR n.zone = 'dmz') AND n.ports > 6", []string{"Historian"}},
+		// Bare property: boolean value, or existence for other types.
+		{"n.critical", []string{"PLC-1"}},
+		{"n.score", []string{"RTU-7"}},
+		// A missing property is null, and NOT null is still null.
+		{"NOT n.critical", []string{"HMI-1"}},
+		// A missing property never satisfies an ordering comparison, and
+		// negating one doesn't make it match either.
+		{"n.ports >= 0", []string{"HMI-1", "Historian", "PLC-1"}},
+		{"NOT n.ports > 4", []string{"PLC-1"}},
+		{"NOT n.ports <= 4", []string{"HMI-1", "Historian"}},
+		{"NOT n.score = 3", []string{}},
+		{"NOT (n.ports > 4 AND n.zone = 'dmz')", []string{"PLC-1", "RTU-7"}},
+		// Three-valued AND/OR: false AND null is false, true OR null is
+		// true, and anything else involving null is null.
+		{"n.ports > 4 AND n.zone = 'dmz'", []string{"HMI-1", "Historian"}},
+		{"n.ports > 100 AND n.score = 3", []string{}},
+		{"NOT (n.ports > 100 AND n.score = 3)", []string{"HMI-1", "Historian", "PLC-1"}},
+		{"n.ports > 4 OR n.zone = 'field'", []string{"HMI-1", "Historian", "RTU-7"}},
+		{"NOT (n.ports > 4 OR n.score = 3)", []string{}},
+		{"n.ports IS NULL OR n.ports < 3", []string{"PLC-1", "RTU-7"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.where, func(t *testing.T) {
+			result := parseAndExecute(t, executor, "MATCH (n:Asset) WHERE "+tc.where+" RETURN n.name")
+			got := make([]string, 0, len(result.Rows))
+			for _, row := range result.Rows {
+				name, _ := row["n.name"].(string)
+				got = append(got, name)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("WHERE %s = %v, want %v", tc.where, got, tc.want)
+			}
+		})
+	}
+}
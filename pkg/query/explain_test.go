@@ -184,3 +184,103 @@ func TestProfile_ReturnsTiming(t *testing.T) {
 		t.Error("PROFILE should also return actual query results")
 	}
 }
+
+// explainQuery parses queryText and returns its plan.
+func explainQuery(t *testing.T, executor *Executor, queryText string) *Plan {
+	t.Helper()
+	tokens, err := NewLexer(queryText).Tokenize()
+	if err != nil {
+		t.Fatalf("Tokenize failed for %q: %v", queryText, err)
+	}
+	query, err := NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("Parse failed for %q: %v", queryText, err)
+	}
+	plan, err := executor.Explain(query)
+	if err != nil {
+		t.Fatalf("Explain failed for %q: %v", queryText, err)
+	}
+	return plan
+}
+
+func TestExplain_Plan(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+
+	var people []*storage.Node
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dan"} {
+		n, err := gs.CreateNode([]string{"Person"}, map[string]storage.Value{"name": storage.StringValue(name)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		people = append(people, n)
+	}
+	if _, err := gs.CreateNode([]string{"City"}, map[string]storage.Value{"name": storage.StringValue("Oslo")}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := gs.CreateEdge(people[i].ID, people[i+1].ID, "KNOWS", nil, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := gs.CreatePropertyIndex("name", storage.TypeString); err != nil {
+		t.Fatal(err)
+	}
+	executor := NewExecutor(gs)
+
+	tests := []struct {
+		name      string
+		query     string
+		step      string
+		scan      string
+		rows      int
+		traversal string
+	}{
+		{"label scan", "MATCH (n:Person) RETURN n", "MatchStep", ScanFull, 4, "(n:Person)"},
+		{"index lookup", "MATCH (n:Person) WHERE n.name = 'Bob' RETURN n", "IndexLookupStep", ScanIndex, 1, `(n:Person) {name: Bob}`},
+		// 3 KNOWS edges over 5 nodes: 4 people * 0.6 average out-degree.
+		{"one hop", "MATCH (a:Person)-[:KNOWS]->(b) RETURN b", "MatchStep", ScanFull, 3, "(a:Person)-[:KNOWS]->(b)"},
+		{"variable length", "MATCH (a:City)-[r:KNOWS*1..2]-(b) RETURN b", "MatchStep", ScanFull, 3, "(a:City)-[r:KNOWS*1..2]-(b)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := explainQuery(t, executor, tt.query)
+			first := plan.Steps[0]
+			if first.Step != tt.step || first.Scan != tt.scan {
+				t.Fatalf("first step = %s (%s), want %s (%s)", first.Step, first.Scan, tt.step, tt.scan)
+			}
+			if first.EstimatedRows == nil || *first.EstimatedRows != tt.rows {
+				t.Errorf("EstimatedRows = %v, want %d", first.EstimatedRows, tt.rows)
+			}
+			if len(first.Traversal) != 1 || first.Traversal[0] != tt.traversal {
+				t.Errorf("Traversal = %q, want [%q]", first.Traversal, tt.traversal)
+			}
+			if last := plan.Steps[len(plan.Steps)-1]; last.Scan != "" || last.EstimatedRows != nil {
+				t.Errorf("%s has scan %q and estimate %v, want neither", last.Step, last.Scan, last.EstimatedRows)
+			}
+		})
+	}
+
+	t.Run("optional match from bound variable", func(t *testing.T) {
+		plan := explainQuery(t, executor, "MATCH (a:Person) OPTIONAL MATCH (a)-[:KNOWS]->(b) RETURN a, b")
+		var optional *PlanStep
+		for i := range plan.Steps {
+			if plan.Steps[i].Step == "OptionalMatchStep" {
+				optional = &plan.Steps[i]
+			}
+		}
+		if optional == nil || optional.Scan != ScanBound {
+			t.Fatalf("OPTIONAL MATCH step = %+v, want a %s scan", optional, ScanBound)
+		}
+		if optional.EstimatedRows == nil || *optional.EstimatedRows != 4 {
+			t.Errorf("EstimatedRows = %v, want 4 (every input row is kept)", optional.EstimatedRows)
+		}
+	})
+
+	t.Run("does not execute writes", func(t *testing.T) {
+		explainQuery(t, executor, "CREATE (n:Person {name: 'Eve'})")
+		if got := gs.CountNodesByLabelForTenant("", "Person"); got != 4 {
+			t.Errorf("Person count = %d after Explain, want 4", got)
+		}
+	})
+}
@@ -13,6 +13,8 @@ func init() {
 	RegisterFunction("id", fnID)
 	RegisterFunction("keys", fnKeys)
 	RegisterFunction("properties", fnProperties)
+	RegisterFunction("nodes", fnNodes)
+	RegisterFunction("relationships", fnRelationships)
 }
 
 // fnType returns the type (label) of a relationship
@@ -125,6 +127,63 @@ func fnProperties(args []any) (any, error) {
 	return result, nil
 }
 
+// fnNodes returns the nodes of a path, in order, as []any
+func fnNodes(args []any) (any, error) {
+	path, err := pathArg("nodes", args)
+	if path == nil {
+		return nil, err
+	}
+	result := make([]any, len(path.Nodes))
+	for i, node := range path.Nodes {
+		result[i] = node
+	}
+	return result, nil
+}
+
+// fnRelationships returns the relationships of a path, in order, as []any
+func fnRelationships(args []any) (any, error) {
+	path, err := pathArg("relationships", args)
+	if path == nil {
+		return nil, err
+	}
+	result := make([]any, len(path.Edges))
+	for i, edge := range path.Edges {
+		result[i] = edge
+	}
+	return result, nil
+}
+
+// pathArg returns the path argument of fn, or nil for a null argument.
+func pathArg(fn string, args []any) (*Path, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%s() requires 1 argument", fn)
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+	path, ok := args[0].(*Path)
+	if !ok {
+		return nil, fmt.Errorf("%s() requires a path argument, got %T", fn, args[0])
+	}
+	return path, nil
+}
+
+// edgeWeightKey is the property name under which queries see an edge's
+// Weight, unless the edge has a real property of that name.
+const edgeWeightKey = "weight"
+
+// edgePropertyValue returns what r.key evaluates to for an edge bound to r:
+// the property's native value, the edge weight for "weight", or nil.
+func edgePropertyValue(edge *storage.Edge, key string) any {
+	if val, found := edge.Properties[key]; found {
+		return extractStorageValue(val)
+	}
+	if key == edgeWeightKey {
+		return edge.Weight
+	}
+	return nil
+}
+
 // extractStorageValue converts a storage.Value to its native Go type.
 // Shared helper used by edge property access and schema functions.
 func extractStorageValue(val storage.Value) any {
@@ -191,6 +191,12 @@ func fnSize(args []any) (any, error) {
 }
 
 func fnLength(args []any) (any, error) {
-	// length is an alias for size on strings
+	// length of a path is its number of relationships; otherwise it is an
+	// alias for size
+	if len(args) > 0 {
+		if path, ok := args[0].(*Path); ok {
+			return int64(len(path.Edges)), nil
+		}
+	}
 	return fnSize(args)
 }
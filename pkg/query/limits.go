@@ -0,0 +1,90 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrQueryLimitExceeded is wrapped by the error of a query stopped for
+// returning more than MaxRows rows or running longer than MaxDuration.
+var ErrQueryLimitExceeded = errors.New("query exceeded limit")
+
+// Limits bounds the cost of a single query. A zero field is unlimited.
+type Limits struct {
+	// MaxRows caps the rows a read query returns. Writes are not counted:
+	// by the time their rows exist the write has been applied.
+	MaxRows int
+
+	// MaxDuration caps how long a query runs, in addition to any deadline
+	// on the context it is executed with.
+	MaxDuration time.Duration
+}
+
+// tighten returns the stricter of l and o, field by field.
+func (l Limits) tighten(o Limits) Limits {
+	if o.MaxRows > 0 && (l.MaxRows == 0 || o.MaxRows < l.MaxRows) {
+		l.MaxRows = o.MaxRows
+	}
+	if o.MaxDuration > 0 && (l.MaxDuration == 0 || o.MaxDuration < l.MaxDuration) {
+		l.MaxDuration = o.MaxDuration
+	}
+	return l
+}
+
+// apply bounds ctx by MaxDuration. The returned cancel must be called.
+func (l Limits) apply(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l.MaxDuration <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, l.MaxDuration,
+		fmt.Errorf("%w: ran longer than %v", ErrQueryLimitExceeded, l.MaxDuration))
+}
+
+// checkRows returns an ErrQueryLimitExceeded error once n exceeds MaxRows.
+func (l Limits) checkRows(n int) error {
+	if l.MaxRows > 0 && n > l.MaxRows {
+		return fmt.Errorf("%w: more than %d rows", ErrQueryLimitExceeded, l.MaxRows)
+	}
+	return nil
+}
+
+type limitsContextKey struct{}
+
+// WithLimits returns a context whose queries run under l as well as under
+// the executor's own limits: a per-query override can only tighten them.
+func WithLimits(ctx context.Context, l Limits) context.Context {
+	if existing, ok := ctx.Value(limitsContextKey{}).(Limits); ok {
+		l = existing.tighten(l)
+	}
+	return context.WithValue(ctx, limitsContextKey{}, l)
+}
+
+// SetLimits sets the limits every query run by e is held to.
+func (e *Executor) SetLimits(l Limits) {
+	e.limits = l
+}
+
+// Limits returns the limits set with SetLimits.
+func (e *Executor) Limits() Limits {
+	return e.limits
+}
+
+// limitsFor returns the limits a query executed with ctx runs under.
+func (e *Executor) limitsFor(ctx context.Context) Limits {
+	l := e.limits
+	if override, ok := ctx.Value(limitsContextKey{}).(Limits); ok {
+		l = l.tighten(override)
+	}
+	return l
+}
+
+// limitError returns the ErrQueryLimitExceeded cause when err came from
+// ctx running past MaxDuration, and err otherwise.
+func limitError(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrQueryLimitExceeded) {
+		return cause
+	}
+	return err
+}
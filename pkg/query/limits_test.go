@@ -0,0 +1,83 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+func TestLimits_MaxRows(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	for i := 0; i < 5; i++ {
+		if _, err := gs.CreateNode([]string{"Host"}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	executor := NewExecutor(gs)
+	executor.SetLimits(Limits{MaxRows: 4})
+
+	if _, err := executor.ExecuteWithContext(context.Background(), parseTestQuery(t, "MATCH (n:Host) RETURN n")); !errors.Is(err, ErrQueryLimitExceeded) {
+		t.Errorf("5 rows under MaxRows 4: err = %v, want ErrQueryLimitExceeded", err)
+	}
+	if _, err := executor.ExecuteWithContext(context.Background(), parseTestQuery(t, "MATCH (n:Host) RETURN n LIMIT 4")); err != nil {
+		t.Errorf("LIMIT 4 under MaxRows 4: %v", err)
+	}
+	if _, err := executor.ExecuteWithContext(context.Background(), parseTestQuery(t, "MATCH (n:Host) RETURN COUNT(n) AS c")); err != nil {
+		t.Errorf("an aggregate over 5 rows returns 1: %v", err)
+	}
+
+	// A per-query override tightens the executor's limit but can't raise it.
+	ctx := WithLimits(context.Background(), Limits{MaxRows: 2})
+	if _, err := executor.ExecuteWithContext(ctx, parseTestQuery(t, "MATCH (n:Host) RETURN n LIMIT 3")); !errors.Is(err, ErrQueryLimitExceeded) {
+		t.Errorf("3 rows under override 2: err = %v, want ErrQueryLimitExceeded", err)
+	}
+	ctx = WithLimits(context.Background(), Limits{MaxRows: 100})
+	if _, err := executor.ExecuteWithContext(ctx, parseTestQuery(t, "MATCH (n:Host) RETURN n")); !errors.Is(err, ErrQueryLimitExceeded) {
+		t.Errorf("override 100 over executor 4: err = %v, want ErrQueryLimitExceeded", err)
+	}
+
+	// Writes aren't counted: their rows exist only once they're applied.
+	if _, err := executor.ExecuteWithContext(context.Background(), parseTestQuery(t, "MATCH (n:Host) SET n.seen = true")); err != nil {
+		t.Errorf("write over 5 nodes: %v", err)
+	}
+
+	// Streaming stops at the first row past the limit.
+	var streamed int
+	err := executor.ExecuteStreamWithContext(context.Background(), parseTestQuery(t, "MATCH (n:Host) RETURN n"),
+		func(map[string]any) error { streamed++; return nil })
+	if !errors.Is(err, ErrQueryLimitExceeded) || streamed != 4 {
+		t.Errorf("stream: %d rows, err = %v; want 4 rows then ErrQueryLimitExceeded", streamed, err)
+	}
+}
+
+func TestLimits_MaxDuration(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	if _, err := gs.CreateNode([]string{"Host"}, map[string]storage.Value{"name": storage.StringValue("h")}); err != nil {
+		t.Fatal(err)
+	}
+	executor := NewExecutor(gs)
+
+	ctx := WithLimits(context.Background(), Limits{MaxDuration: time.Nanosecond})
+	_, err := executor.ExecuteWithContext(ctx, parseTestQuery(t, "MATCH (n:Host) RETURN n.name"))
+	if !errors.Is(err, ErrQueryLimitExceeded) {
+		t.Errorf("err = %v, want ErrQueryLimitExceeded", err)
+	}
+	err = executor.ExecuteStreamWithContext(ctx, parseTestQuery(t, "MATCH (n:Host) RETURN n.name"),
+		func(map[string]any) error { return nil })
+	if !errors.Is(err, ErrQueryLimitExceeded) {
+		t.Errorf("stream: err = %v, want ErrQueryLimitExceeded", err)
+	}
+
+	// A caller's own deadline is reported as such, not as a limit.
+	deadline, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-deadline.Done()
+	if _, err := executor.ExecuteWithContext(deadline, parseTestQuery(t, "MATCH (n:Host) RETURN n.name")); err == nil || errors.Is(err, ErrQueryLimitExceeded) {
+		t.Errorf("caller deadline: err = %v, want a plain cancellation", err)
+	}
+}
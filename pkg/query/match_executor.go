@@ -1,6 +1,10 @@
 package query
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
 
 const (
 	// MaxCartesianProductResults limits the maximum size of cartesian product
@@ -21,20 +25,20 @@ func (ms *MatchStep) Execute(ctx *ExecutionContext) error {
 
 	// For each existing binding
 	for _, binding := range ctx.results {
-		// For each pattern
-		for _, pattern := range ms.match.Patterns {
-			// Find matches for this pattern
-			matches, err := ms.matchPattern(ctx, pattern, binding)
-			if err != nil {
-				return err
-			}
-			newResults = append(newResults, matches...)
+		if err := ctx.CheckCancellation(); err != nil {
+			return err
+		}
 
-			// Check intermediate result limit to prevent memory exhaustion
-			if len(newResults) > MaxIntermediateResults {
-				return fmt.Errorf("query produced %d intermediate results, exceeding limit of %d; consider adding more specific filters or LIMIT clause",
-					len(newResults), MaxIntermediateResults)
-			}
+		matches, err := ms.matchPatterns(ctx, ms.match.Patterns, binding)
+		if err != nil {
+			return err
+		}
+		newResults = append(newResults, matches...)
+
+		// Check intermediate result limit to prevent memory exhaustion
+		if len(newResults) > MaxIntermediateResults {
+			return fmt.Errorf("query produced %d intermediate results, exceeding limit of %d; consider adding more specific filters or LIMIT clause",
+				len(newResults), MaxIntermediateResults)
 		}
 	}
 
@@ -45,12 +49,50 @@ func (ms *MatchStep) Execute(ctx *ExecutionContext) error {
 	return nil
 }
 
+// matchPatterns joins patterns left to right: each pattern is matched once
+// per row of the previous ones, so a variable bound by an earlier pattern
+// constrains the later ones. Patterns sharing no variable multiply, bounded
+// by MaxCartesianProductResults.
+func (ms *MatchStep) matchPatterns(ctx *ExecutionContext, patterns []*Pattern, binding *BindingSet) ([]*BindingSet, error) {
+	rows := []*BindingSet{binding}
+	for i, pattern := range patterns {
+		joined := make([]*BindingSet, 0, len(rows))
+		for _, row := range rows {
+			matches, err := ms.matchPattern(ctx, pattern, row)
+			if err != nil {
+				return nil, err
+			}
+			joined = append(joined, matches...)
+			if i > 0 && len(joined) > MaxCartesianProductResults {
+				return nil, fmt.Errorf("joining MATCH patterns produced more than %d results; connect the patterns through a shared variable or add filters",
+					MaxCartesianProductResults)
+			}
+		}
+		rows = joined
+		if len(rows) == 0 {
+			break
+		}
+	}
+	return rows, nil
+}
+
 func (ms *MatchStep) matchPattern(ctx *ExecutionContext, pattern *Pattern, existingBinding *BindingSet) ([]*BindingSet, error) {
 	results := make([]*BindingSet, 0)
 
 	// Simple case: single node
 	if len(pattern.Nodes) == 1 && len(pattern.Relationships) == 0 {
-		return ms.matchNode(ctx, pattern.Nodes[0], existingBinding)
+		if pattern.PathVariable == "" {
+			return ms.matchNode(ctx, pattern.Nodes[0], existingBinding)
+		}
+		for _, node := range ms.candidateNodes(ctx, pattern.Nodes[0], existingBinding) {
+			binding := ms.copyBinding(existingBinding)
+			if pattern.Nodes[0].Variable != "" {
+				binding.bindings[pattern.Nodes[0].Variable] = node
+			}
+			binding.bindings[pattern.PathVariable] = &Path{Nodes: []*storage.Node{node}, Edges: []*storage.Edge{}}
+			results = append(results, binding)
+		}
+		return results, nil
 	}
 
 	// Pattern with relationships
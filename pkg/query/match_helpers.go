@@ -33,6 +33,25 @@ func (ms *MatchStep) matchProperties(nodeProps map[string]storage.Value, pattern
 	return true
 }
 
+// edgeMatchesProperties checks a relationship pattern's inline properties,
+// e.g. [r:CONTROLS {enforcement: 'data_diode'}]. weight matches the edge
+// weight numerically unless the edge has a "weight" property.
+func (ms *MatchStep) edgeMatchesProperties(edge *storage.Edge, patternProps map[string]any) bool {
+	for key, patternValue := range patternProps {
+		if _, exists := edge.Properties[key]; !exists && key == edgeWeightKey {
+			w, ok := toFloat64(patternValue)
+			if !ok || w != edge.Weight {
+				return false
+			}
+			continue
+		}
+		if !ms.matchProperties(edge.Properties, map[string]any{key: patternValue}) {
+			return false
+		}
+	}
+	return true
+}
+
 func (ms *MatchStep) valuesEqual(nodeValue storage.Value, patternValue any) bool {
 	switch v := patternValue.(type) {
 	case string:
@@ -0,0 +1,151 @@
+package query
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/storage"
+)
+
+// setupJoinGraph builds a->b->c, d->b and a lone e, all linked by R.
+func setupJoinGraph(t *testing.T) (*storage.GraphStorage, *Executor, func()) {
+	t.Helper()
+	gs, cleanup := setupExecutorTestGraph(t)
+
+	ids := make(map[string]uint64)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		n, err := gs.CreateNode([]string{"N"}, map[string]storage.Value{"name": storage.StringValue(name)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[name] = n.ID
+	}
+	for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"d", "b"}} {
+		if _, err := gs.CreateEdge(ids[e[0]], ids[e[1]], "R", nil, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return gs, NewExecutor(gs), cleanup
+}
+
+// joinedRows renders each row's columns as "v1,v2" and sorts them.
+func joinedRows(result *ResultSet, columns ...string) []string {
+	rows := make([]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		vals := make([]string, len(columns))
+		for i, c := range columns {
+			vals[i], _ = row[c].(string)
+		}
+		rows = append(rows, strings.Join(vals, ","))
+	}
+	slices.Sort(rows)
+	return rows
+}
+
+func TestMatch_JoinsPatternsOnSharedVariables(t *testing.T) {
+	gs, executor, cleanup := setupJoinGraph(t)
+	defer cleanup()
+
+	for _, tc := range []struct {
+		name, query string
+		want        []string
+	}{
+		{
+			"comma-separated, chained",
+			`MATCH (x)-[:R]->(y), (y)-[:R]->(z) RETURN x.name, z.name`,
+			[]string{"a,c", "d,c"},
+		},
+		{
+			"comma-separated, shared target",
+			`MATCH (x)-[:R]->(y), (w {name: 'd'})-[:R]->(y) RETURN x.name, w.name`,
+			[]string{"a,d", "d,d"},
+		},
+		{
+			"separate MATCH clauses with their own WHERE",
+			`MATCH (x)-[:R]->(y) WHERE x.name = 'a' MATCH (y)-[:R]->(z) WHERE z.name = 'c' RETURN x.name, z.name`,
+			[]string{"a,c"},
+		},
+		{
+			"disconnected patterns multiply",
+			`MATCH (x {name: 'a'}), (y) WHERE y.name IN ['d', 'e'] RETURN x.name, y.name`,
+			[]string{"a,d", "a,e"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result := parseAndExecute(t, executor, tc.query)
+			if got := joinedRows(result, result.Columns...); !slices.Equal(got, tc.want) {
+				t.Errorf("rows = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	// With an index on name the start node is looked up, and the rest of
+	// the pattern and the second pattern are still matched from it.
+	if err := gs.CreatePropertyIndex("name", storage.TypeString); err != nil {
+		t.Fatal(err)
+	}
+	result := parseAndExecute(t, executor, `MATCH (x)-[:R]->(y), (y)-[:R]->(z) WHERE x.name = 'a' RETURN y.name, z.name`)
+	if got := joinedRows(result, "y.name", "z.name"); !slices.Equal(got, []string{"b,c"}) {
+		t.Errorf("indexed start: rows = %v, want [b,c]", got)
+	}
+}
+
+func TestMatch_PathVariable(t *testing.T) {
+	_, executor, cleanup := setupJoinGraph(t)
+	defer cleanup()
+
+	result := parseAndExecute(t, executor,
+		`MATCH p = (x {name: 'a'})-[:R*]->(z {name: 'c'}) RETURN p, length(p) AS hops`)
+	if result.Count != 1 {
+		t.Fatalf("got %d rows, want 1", result.Count)
+	}
+	path, ok := result.Rows[0]["p."].(*Path)
+	if !ok {
+		t.Fatalf("p = %T, want *Path", result.Rows[0]["p."])
+	}
+	var names []string
+	for _, n := range path.Nodes {
+		name, _ := n.Properties["name"].AsString()
+		names = append(names, name)
+	}
+	if !slices.Equal(names, []string{"a", "b", "c"}) || len(path.Edges) != 2 {
+		t.Errorf("path = %v with %d edges, want a,b,c with 2", names, len(path.Edges))
+	}
+	if result.Rows[0]["hops"] != int64(2) {
+		t.Errorf("length(p) = %v, want 2", result.Rows[0]["hops"])
+	}
+
+	// Fixed hops, anonymous nodes, and the path functions.
+	result = parseAndExecute(t, executor,
+		`MATCH p = ()-[:R]->({name: 'b'})-[:R]->() RETURN nodes(p) AS ns, relationships(p) AS rs`)
+	if result.Count != 2 {
+		t.Fatalf("got %d rows, want 2", result.Count)
+	}
+	for _, row := range result.Rows {
+		if ns, _ := row["ns"].([]any); len(ns) != 3 {
+			t.Errorf("nodes(p) = %v, want 3 nodes", row["ns"])
+		}
+		if rs, _ := row["rs"].([]any); len(rs) != 2 {
+			t.Errorf("relationships(p) = %v, want 2 relationships", row["rs"])
+		}
+	}
+
+	// A single-node path has no relationships; an unmatched OPTIONAL path
+	// is null.
+	result = parseAndExecute(t, executor,
+		`MATCH p = (x {name: 'e'}) OPTIONAL MATCH q = (x)-[:R]->() RETURN length(p) AS hops, q`)
+	if result.Count != 1 || result.Rows[0]["hops"] != int64(0) || result.Rows[0]["q."] != nil {
+		t.Errorf("rows = %v, want hops 0 and a null q", result.Rows)
+	}
+}
+
+func TestParse_MatchAfterOptionalMatch(t *testing.T) {
+	tokens, err := NewLexer(`MATCH (a) OPTIONAL MATCH (a)-[:R]->(b) MATCH (b)-[:R]->(c) RETURN c`).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewParser(tokens).Parse(); err == nil || !strings.Contains(err.Error(), "WITH") {
+		t.Errorf("Parse = %v, want an error pointing at WITH", err)
+	}
+}
@@ -10,20 +10,30 @@ import (
 // If the variable is already bound in existingBinding, only validates that the
 // bound node matches labels/properties (avoids re-scanning all nodes).
 func (ms *MatchStep) matchNode(ctx *ExecutionContext, nodePattern *NodePattern, existingBinding *BindingSet) ([]*BindingSet, error) {
-	results := make([]*BindingSet, 0)
+	nodes := ms.candidateNodes(ctx, nodePattern, existingBinding)
+	results := make([]*BindingSet, 0, len(nodes))
+	for _, node := range nodes {
+		newBinding := ms.copyBinding(existingBinding)
+		if nodePattern.Variable != "" {
+			newBinding.bindings[nodePattern.Variable] = node
+		}
+		results = append(results, newBinding)
+	}
+	return results, nil
+}
 
+// candidateNodes returns the nodes nodePattern can bind to: the node its
+// variable is already bound to, if that matches, otherwise every matching
+// node of the tenant.
+func (ms *MatchStep) candidateNodes(ctx *ExecutionContext, nodePattern *NodePattern, existingBinding *BindingSet) []*storage.Node {
 	// If the variable is already bound, validate the bound node instead of scanning
 	if nodePattern.Variable != "" {
 		if existing, ok := existingBinding.bindings[nodePattern.Variable]; ok && existing != nil {
 			if node, ok := existing.(*storage.Node); ok {
-				if len(nodePattern.Labels) > 0 && !ms.hasLabels(node, nodePattern.Labels) {
-					return results, nil
+				if !ms.nodeMatchesPattern(node, nodePattern) {
+					return nil
 				}
-				if !ms.matchProperties(node.Properties, nodePattern.Properties) {
-					return results, nil
-				}
-				newBinding := ms.copyBinding(existingBinding)
-				return append(results, newBinding), nil
+				return []*storage.Node{node}
 			}
 		}
 	}
@@ -32,28 +42,13 @@ func (ms *MatchStep) matchNode(ctx *ExecutionContext, nodePattern *NodePattern,
 	// "iterate 1..stats.NodeCount via GetNode" cross-tenant scan
 	// anti-pattern (same shape as the A6c-graphql-resolvers cleanup).
 	nodes := ctx.graph.GetAllNodesForTenant(ctx.tenantID)
+	matched := make([]*storage.Node, 0)
 	for _, node := range nodes {
-		// Check labels
-		if len(nodePattern.Labels) > 0 {
-			if !ms.hasLabels(node, nodePattern.Labels) {
-				continue
-			}
+		if ms.nodeMatchesPattern(node, nodePattern) {
+			matched = append(matched, node)
 		}
-
-		// Check properties
-		if !ms.matchProperties(node.Properties, nodePattern.Properties) {
-			continue
-		}
-
-		// Create new binding
-		newBinding := ms.copyBinding(existingBinding)
-		if nodePattern.Variable != "" {
-			newBinding.bindings[nodePattern.Variable] = node
-		}
-		results = append(results, newBinding)
 	}
-
-	return results, nil
+	return matched
 }
 
 // matchCartesianProduct handles matching multiple independent nodes (no relationships)
@@ -8,20 +8,22 @@ func (ms *MatchStep) matchPath(ctx *ExecutionContext, pattern *Pattern, existing
 
 	// Get starting nodes
 	startNodePattern := pattern.Nodes[0]
-	startNodes, err := ms.matchNode(ctx, startNodePattern, existingBinding)
-	if err != nil {
-		return nil, err
-	}
+	startNodes := ms.candidateNodes(ctx, startNodePattern, existingBinding)
 
 	// For each starting node, traverse relationships
-	for _, startBinding := range startNodes {
-		nodeInterface, exists := startBinding.bindings[startNodePattern.Variable]
-		if !exists {
-			continue
+	for _, startNode := range startNodes {
+		// Each start node can fan out into a full traversal; stop between
+		// them once the query is cancelled.
+		if err := ctx.CheckCancellation(); err != nil {
+			return nil, err
 		}
-		startNode, ok := nodeInterface.(*storage.Node)
-		if !ok {
-			continue
+
+		startBinding := ms.copyBinding(existingBinding)
+		if startNodePattern.Variable != "" {
+			startBinding.bindings[startNodePattern.Variable] = startNode
+		}
+		if pattern.PathVariable != "" {
+			startBinding.bindings[pattern.PathVariable] = &Path{Nodes: []*storage.Node{startNode}, Edges: []*storage.Edge{}}
 		}
 
 		pathResults := ms.traversePath(ctx, startNode, pattern, 0, startBinding)
@@ -31,6 +33,31 @@ func (ms *MatchStep) matchPath(ctx *ExecutionContext, pattern *Pattern, existing
 	return results, nil
 }
 
+// bindStep binds the node reached over edges (passing through the
+// intermediate nodes of a variable-length hop) into binding: the target
+// node's variable, and the path variable extended by the hop. It reports
+// false when the target variable is already bound to a different node,
+// which is how patterns sharing a variable are joined.
+func (ms *MatchStep) bindStep(binding *BindingSet, pattern *Pattern, target *NodePattern, reached *storage.Node, edges []*storage.Edge, passed []*storage.Node) bool {
+	if target.Variable != "" {
+		if bound, ok := binding.bindings[target.Variable].(*storage.Node); ok && bound.ID != reached.ID {
+			return false
+		}
+		binding.bindings[target.Variable] = reached
+	}
+	if pattern.PathVariable != "" && len(edges) > 0 {
+		if path, ok := binding.bindings[pattern.PathVariable].(*Path); ok {
+			nodes := make([]*storage.Node, 0, len(path.Nodes)+len(passed)+1)
+			nodes = append(append(append(nodes, path.Nodes...), passed...), reached)
+			binding.bindings[pattern.PathVariable] = &Path{
+				Nodes: nodes,
+				Edges: append(append(make([]*storage.Edge, 0, len(path.Edges)+len(edges)), path.Edges...), edges...),
+			}
+		}
+	}
+	return true
+}
+
 // traversePath recursively traverses relationships in a pattern.
 // Dispatches to traverseVariablePath when the relationship has variable-length hops.
 func (ms *MatchStep) traversePath(ctx *ExecutionContext, currentNode *storage.Node, pattern *Pattern, relIndex int, currentBinding *BindingSet) []*BindingSet {
@@ -44,7 +71,10 @@ func (ms *MatchStep) traversePath(ctx *ExecutionContext, currentNode *storage.No
 	// Variable-length path: dispatch to BFS traversal.
 	// MinHops=1,MaxHops=1 is the default single-hop. MinHops=0,MaxHops=0 is the
 	// Go zero value (unset) — also treat as single-hop for backward compatibility.
-	isVariableLength := (rel.MinHops != 1 || rel.MaxHops != 1) && (rel.MinHops != 0 || rel.MaxHops != 0)
+	// VarLength marks an explicit '*' (e.g. *1 or *..1), which must still bind
+	// the relationship variable as an edge list.
+	isVariableLength := rel.VarLength ||
+		((rel.MinHops != 1 || rel.MaxHops != 1) && (rel.MinHops != 0 || rel.MaxHops != 0))
 	if isVariableLength {
 		return ms.traverseVariablePath(ctx, currentNode, pattern, relIndex, currentBinding)
 	}
@@ -72,12 +102,12 @@ func (ms *MatchStep) traverseFixedPath(ctx *ExecutionContext, currentNode *stora
 		}
 
 		newBinding := ms.copyBinding(currentBinding)
+		if !ms.bindStep(newBinding, pattern, targetNodePattern, targetNode, []*storage.Edge{edge}, nil) {
+			continue
+		}
 		if rel.Variable != "" {
 			newBinding.bindings[rel.Variable] = edge
 		}
-		if targetNodePattern.Variable != "" {
-			newBinding.bindings[targetNodePattern.Variable] = targetNode
-		}
 
 		pathResults := ms.traversePath(ctx, targetNode, pattern, relIndex+1, newBinding)
 		results = append(results, pathResults...)
@@ -91,6 +121,7 @@ type bfsEntry struct {
 	node    *storage.Node
 	depth   int
 	edges   []*storage.Edge // path of edges taken to reach this node
+	nodes   []*storage.Node // nodes between the start and this one, kept for path variables
 	visited map[uint64]bool // per-path visited set (prevents cycles within a single path)
 }
 
@@ -125,15 +156,13 @@ func (ms *MatchStep) traverseVariablePath(ctx *ExecutionContext, currentNode *st
 		if entry.depth >= rel.MinHops && entry.depth <= maxHops {
 			if ms.nodeMatchesPattern(entry.node, targetNodePattern) {
 				newBinding := ms.copyBinding(currentBinding)
-				if rel.Variable != "" {
-					newBinding.bindings[rel.Variable] = entry.edges
+				if ms.bindStep(newBinding, pattern, targetNodePattern, entry.node, entry.edges, entry.nodes) {
+					if rel.Variable != "" {
+						newBinding.bindings[rel.Variable] = entry.edges
+					}
+					pathResults := ms.traversePath(ctx, entry.node, pattern, relIndex+1, newBinding)
+					results = append(results, pathResults...)
 				}
-				if targetNodePattern.Variable != "" {
-					newBinding.bindings[targetNodePattern.Variable] = entry.node
-				}
-
-				pathResults := ms.traversePath(ctx, entry.node, pattern, relIndex+1, newBinding)
-				results = append(results, pathResults...)
 			}
 		}
 
@@ -166,10 +195,16 @@ func (ms *MatchStep) traverseVariablePath(ctx *ExecutionContext, currentNode *st
 			copy(newEdges, entry.edges)
 			newEdges[len(entry.edges)] = edge
 
+			var newNodes []*storage.Node
+			if pattern.PathVariable != "" && entry.depth > 0 {
+				newNodes = append(append(make([]*storage.Node, 0, len(entry.nodes)+1), entry.nodes...), entry.node)
+			}
+
 			queue = append(queue, bfsEntry{
 				node:    neighborNode,
 				depth:   entry.depth + 1,
 				edges:   newEdges,
+				nodes:   newNodes,
 				visited: newVisited,
 			})
 		}
@@ -196,13 +231,13 @@ func (ms *MatchStep) getEdges(ctx *ExecutionContext, node *storage.Node, rel *Re
 		edges = append(edges, incoming...)
 	}
 
-	// Filter by edge type
-	if rel.Type == "" {
+	// Filter by edge type and inline properties
+	if rel.Type == "" && len(rel.Properties) == 0 {
 		return edges
 	}
 	filtered := make([]*storage.Edge, 0, len(edges))
 	for _, edge := range edges {
-		if edge.Type == rel.Type {
+		if (rel.Type == "" || edge.Type == rel.Type) && ms.edgeMatchesProperties(edge, rel.Properties) {
 			filtered = append(filtered, edge)
 		}
 	}
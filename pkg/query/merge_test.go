@@ -265,3 +265,63 @@ func TestMerge_OnMatchSet(t *testing.T) {
 		t.Errorf("Expected 1 node, got %d", stats.NodeCount)
 	}
 }
+
+// TestMerge_UsesPropertyIndex pins that re-running a seed script of MERGEs
+// against an indexed key is idempotent, looks matches up in the index, and
+// still honours the pattern's labels and other properties.
+func TestMerge_UsesPropertyIndex(t *testing.T) {
+	gs, cleanup := setupExecutorTestGraph(t)
+	defer cleanup()
+	if err := gs.CreatePropertyIndex("name", storage.TypeString); err != nil {
+		t.Fatal(err)
+	}
+	executor := NewExecutor(gs)
+
+	seed := []string{
+		`MERGE (n:Person {name: "Alice"}) ON CREATE SET n.runs = 1 ON MATCH SET n.runs = 2`,
+		`MERGE (n:Person {name: "Bob", team: "red"})`,
+		`MERGE (n:Robot {name: "Alice"})`,
+	}
+	for run := 0; run < 2; run++ {
+		for _, q := range seed {
+			parseAndExecute(t, executor, q)
+		}
+	}
+
+	if got := gs.GetStatistics().NodeCount; got != 3 {
+		t.Errorf("NodeCount = %d after two seed runs, want 3", got)
+	}
+	alice, err := gs.FindNodesByPropertyIndexedForTenant("name", storage.StringValue("Alice"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range alice {
+		if n.Labels[0] != "Person" {
+			continue
+		}
+		if runs, _ := n.Properties["runs"].AsInt(); runs != 2 {
+			t.Errorf("Alice runs = %d, want 2 (ON MATCH SET on the second run)", runs)
+		}
+	}
+
+	// A differing non-indexed property is not a match.
+	parseAndExecute(t, executor, `MERGE (n:Person {name: "Bob", team: "blue"})`)
+	if got := gs.CountNodesByLabelForTenant("", "Person"); got != 3 {
+		t.Errorf("Person count = %d, want 3 (a second Bob on another team)", got)
+	}
+
+	plan, err := executor.Explain(parseTestQuery(t, `MERGE (n:Person {name: "Alice"})`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step := plan.Steps[0]; step.Step != "MergeStep" || step.Scan != ScanIndex {
+		t.Errorf("plan = %+v, want an indexed MergeStep", step)
+	}
+	plan, err = executor.Explain(parseTestQuery(t, `MERGE (n:Person {team: "red"})`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step := plan.Steps[0]; step.Scan != ScanFull {
+		t.Errorf("unindexed MERGE scan = %q, want %q", step.Scan, ScanFull)
+	}
+}
@@ -45,6 +45,12 @@ func (o *Optimizer) applyIndexSelection(plan *ExecutionPlan, query *Query) *Exec
 		case *MatchStep:
 			optimizedStep := o.optimizeMatchWithIndex(s, query)
 			optimized.Steps = append(optimized.Steps, optimizedStep)
+			// The lookup only binds the first node. Anything more (a
+			// relationship, another pattern, a path variable) is still
+			// matched by the MatchStep, now from the bound start node.
+			if optimizedStep != step && !isSingleNodeMatch(s.match) {
+				optimized.Steps = append(optimized.Steps, s)
+			}
 		default:
 			// OptionalMatchStep and others pass through without index optimization
 			optimized.Steps = append(optimized.Steps, step)
@@ -103,6 +109,16 @@ func (o *Optimizer) optimizeMatchWithIndex(match *MatchStep, query *Query) Execu
 	}
 }
 
+// isSingleNodeMatch reports whether match is one unnamed-path pattern of a
+// single node, which an IndexLookupStep fully replaces.
+func isSingleNodeMatch(match *MatchClause) bool {
+	if match == nil || len(match.Patterns) != 1 {
+		return false
+	}
+	p := match.Patterns[0]
+	return len(p.Nodes) == 1 && len(p.Relationships) == 0 && p.PathVariable == ""
+}
+
 // indexableCondition holds info about an indexable WHERE condition
 type indexableCondition struct {
 	variable    string // e.g., "n" from n.name
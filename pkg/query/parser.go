@@ -61,7 +61,17 @@ func (p *Parser) Parse() (*Query, error) {
 			if err != nil {
 				return nil, err
 			}
-			query.Match = matchClause
+			if query.Match == nil {
+				query.Match = matchClause
+				break
+			}
+			// A later MATCH joins the earlier ones on shared variables. It
+			// can't follow OPTIONAL MATCH: its rows would have to be matched
+			// after the optional ones, which needs a WITH in between.
+			if len(query.OptionalMatches) > 0 {
+				return nil, fmt.Errorf("MATCH after OPTIONAL MATCH at line %d: separate them with WITH", token.Line)
+			}
+			query.Match.Patterns = append(query.Match.Patterns, matchClause.Patterns...)
 
 		case TokenCall:
 			callClause, err := p.parseCall()
@@ -75,6 +85,14 @@ func (p *Parser) Parse() (*Query, error) {
 			if err != nil {
 				return nil, err
 			}
+			if query.Where != nil {
+				// WHERE of a later MATCH clause: both must hold.
+				whereClause.Expression = &BinaryExpression{
+					Left:     query.Where.Expression,
+					Operator: "AND",
+					Right:    whereClause.Expression,
+				}
+			}
 			query.Where = whereClause
 
 		case TokenReturn:
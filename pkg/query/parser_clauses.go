@@ -13,12 +13,18 @@ func (p *Parser) parseMatch() (*MatchClause, error) {
 
 	patterns := make([]*Pattern, 0)
 
-	// Parse comma-separated patterns
+	// Parse comma-separated patterns, each optionally named: p = (a)-->(b)
 	for {
+		pathVariable := ""
+		if p.peek().Type == TokenIdentifier && p.peekAhead(1).Type == TokenEquals {
+			pathVariable = p.advance().Value
+			p.advance() // consume =
+		}
 		pattern, err := p.parsePattern()
 		if err != nil {
 			return nil, err
 		}
+		pattern.PathVariable = pathVariable
 		patterns = append(patterns, pattern)
 
 		if p.peek().Type != TokenComma {
@@ -141,9 +147,10 @@ func (p *Parser) parseReturn() (*ReturnClause, error) {
 	return returnClause, nil
 }
 
-// isAggregateFunction checks if a name is a known aggregate function
+// isAggregateFunction checks if a name is a known aggregate function,
+// in any case
 func isAggregateFunction(name string) bool {
-	switch name {
+	switch strings.ToUpper(name) {
 	case "COUNT", "SUM", "AVG", "MIN", "MAX", "COLLECT":
 		return true
 	default:
@@ -158,15 +165,24 @@ func (p *Parser) parseReturnItem() (*ReturnItem, error) {
 	// Aggregate functions need special handling: COUNT(x), SUM(x), etc.
 	// Detect: identifier + '(' where identifier is a known aggregate
 	if p.peek().Type == TokenIdentifier && p.peekAhead(1).Type == TokenLeftParen && isAggregateFunction(p.peek().Value) {
-		funcName := p.advance().Value // consume function name
-		p.advance()                   // consume (
+		funcName := strings.ToUpper(p.advance().Value) // consume function name
+		p.advance()                                    // consume (
 
-		expr, err := p.parsePrimaryExpression()
-		if err != nil {
-			return nil, err
+		if p.peek().Type == TokenDistinct {
+			p.advance()
+			item.Distinct = true
 		}
-		if propExpr, ok := expr.(*PropertyExpression); ok {
-			item.Expression = propExpr
+
+		if p.peek().Type == TokenStar {
+			p.advance() // COUNT(*): no expression
+		} else {
+			expr, err := p.parsePrimaryExpression()
+			if err != nil {
+				return nil, err
+			}
+			if propExpr, ok := expr.(*PropertyExpression); ok {
+				item.Expression = propExpr
+			}
 		}
 
 		if _, err := p.expect(TokenRightParen); err != nil {
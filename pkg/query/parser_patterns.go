@@ -135,33 +135,11 @@ func (p *Parser) parseRelationship(fromNode *NodePattern) (*RelationshipPattern,
 			rel.Type = typeToken.Value
 		}
 
-		// Variable-length path (optional): *1..5
+		// Variable-length path (optional): *, *2, *1..5, *..5, *2..
 		if p.peek().Type == TokenStar {
 			p.advance()
-			if p.peek().Type == TokenNumber {
-				numToken := p.advance()
-				// The number might be "1" or "1..3" (lexer includes range in number)
-				if strings.Contains(numToken.Value, "..") {
-					parts := strings.Split(numToken.Value, "..")
-					if len(parts) == 2 {
-						if min, err := strconv.Atoi(parts[0]); err == nil {
-							rel.MinHops = min
-						}
-						if parts[1] != "" {
-							if max, err := strconv.Atoi(parts[1]); err == nil {
-								rel.MaxHops = max
-							}
-						} else {
-							rel.MaxHops = -1 // unlimited
-						}
-					}
-				} else {
-					// Just a single number, use it as both min and max
-					if val, err := strconv.Atoi(numToken.Value); err == nil {
-						rel.MinHops = val
-						rel.MaxHops = val
-					}
-				}
+			if err := p.parseVarLength(rel); err != nil {
+				return nil, nil, err
 			}
 		}
 
@@ -209,3 +187,71 @@ func (p *Parser) parseRelationship(fromNode *NodePattern) (*RelationshipPattern,
 
 	return rel, toNode, nil
 }
+
+// parseVarLength parses the hop bounds following '*' in a relationship
+// pattern. Forms: bare * (1..unbounded), *n (exactly n), *min..max,
+// *..max (min 1) and *min.. (unbounded max, stored as MaxHops = -1). The
+// lexer folds "1..3" into one number token but splits "..3" and spaced
+// forms into dots and numbers, so both shapes are accepted.
+func (p *Parser) parseVarLength(rel *RelationshipPattern) error {
+	rel.VarLength = true
+	rel.MinHops, rel.MaxHops = 1, -1
+
+	if p.peek().Type == TokenNumber {
+		numToken := p.advance()
+		if strings.Contains(numToken.Value, "..") {
+			parts := strings.SplitN(numToken.Value, "..", 2)
+			return setHopBounds(rel, parts[0], parts[1])
+		}
+		if p.peek().Type != TokenDot {
+			return setHopBounds(rel, numToken.Value, numToken.Value)
+		}
+		if err := setHopBounds(rel, numToken.Value, ""); err != nil {
+			return err
+		}
+	}
+
+	if p.peek().Type != TokenDot {
+		return nil
+	}
+	p.advance()
+	if _, err := p.expect(TokenDot); err != nil {
+		return fmt.Errorf("expected '..' in variable-length relationship")
+	}
+	if p.peek().Type == TokenNumber {
+		max, err := strconv.Atoi(p.advance().Value)
+		if err != nil {
+			return fmt.Errorf("invalid maximum hop count: %w", err)
+		}
+		rel.MaxHops = max
+	}
+	if rel.MaxHops != -1 && rel.MaxHops < rel.MinHops {
+		return fmt.Errorf("variable-length relationship: maximum hops %d is less than minimum %d", rel.MaxHops, rel.MinHops)
+	}
+	return nil
+}
+
+// setHopBounds applies textual min/max hop bounds; an empty max means
+// unbounded.
+func setHopBounds(rel *RelationshipPattern, minText, maxText string) error {
+	if minText != "" {
+		min, err := strconv.Atoi(minText)
+		if err != nil {
+			return fmt.Errorf("invalid minimum hop count %q", minText)
+		}
+		rel.MinHops = min
+	}
+	if maxText == "" {
+		rel.MaxHops = -1
+		return nil
+	}
+	max, err := strconv.Atoi(maxText)
+	if err != nil {
+		return fmt.Errorf("invalid maximum hop count %q", maxText)
+	}
+	if max < rel.MinHops {
+		return fmt.Errorf("variable-length relationship: maximum hops %d is less than minimum %d", max, rel.MinHops)
+	}
+	rel.MaxHops = max
+	return nil
+}
@@ -525,6 +525,40 @@ func TestParser_VariableLengthPath(t *testing.T) {
 	}
 }
 
+// TestParser_VariableLengthForms covers every hop-bound shape after '*'.
+func TestParser_VariableLengthForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		min, max int
+	}{
+		{"MATCH (a)-[:KNOWS*]->(b)", 1, -1},
+		{"MATCH (a)-[:KNOWS*3]->(b)", 3, 3},
+		{"MATCH (a)-[:KNOWS*2..4]->(b)", 2, 4},
+		{"MATCH (a)-[:KNOWS*..4]->(b)", 1, 4},
+		{"MATCH (a)-[:KNOWS*2..]->(b)", 2, -1},
+		{"MATCH (a)-[r*1 .. 3]->(b)", 1, 3},
+	}
+	for _, tt := range tests {
+		tokens, err := NewLexer(tt.input).Tokenize()
+		if err != nil {
+			t.Fatalf("Tokenize(%q): %v", tt.input, err)
+		}
+		query, err := NewParser(tokens).Parse()
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		rel := query.Match.Patterns[0].Relationships[0]
+		if rel.MinHops != tt.min || rel.MaxHops != tt.max {
+			t.Errorf("%q: hops = %d..%d, want %d..%d", tt.input, rel.MinHops, rel.MaxHops, tt.min, tt.max)
+		}
+	}
+
+	tokens, _ := NewLexer("MATCH (a)-[:KNOWS*3..1]->(b)").Tokenize()
+	if _, err := NewParser(tokens).Parse(); err == nil {
+		t.Error("expected error when max hops < min hops")
+	}
+}
+
 // TestParser_MultipleLabels tests multiple labels on a node
 func TestParser_MultipleLabels(t *testing.T) {
 	input := "MATCH (n:Person:Employee:Manager)"
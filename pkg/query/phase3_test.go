@@ -304,6 +304,36 @@ func TestPhase3_VarLengthPath_WithRelVar(t *testing.T) {
 	}
 }
 
+func TestPhase3_VarLengthPath_BareStarAndUpperBound(t *testing.T) {
+	_, executor, cleanup := setupPhase3Graph(t)
+	defer cleanup()
+
+	// Bare * is 1..unbounded, so it reaches everything *1..3 does.
+	star := parseAndExecute(t, executor,
+		`MATCH (a:Person {name: 'Alice'})-[:KNOWS*]->(b) RETURN DISTINCT b.name`)
+	bounded := parseAndExecute(t, executor,
+		`MATCH (a:Person {name: 'Alice'})-[:KNOWS*1..3]->(b) RETURN DISTINCT b.name`)
+	if star.Count != bounded.Count {
+		t.Errorf("*: %d distinct endpoints, *1..3: %d; want equal", star.Count, bounded.Count)
+	}
+
+	// *..1 is 1..1: direct neighbours only.
+	result := parseAndExecute(t, executor,
+		`MATCH (a:Person {name: 'Alice'})-[r:KNOWS*..1]->(b) RETURN b.name, r`)
+	for _, row := range result.Rows {
+		if name := row["b.name"]; name == "Diana" {
+			t.Errorf("*..1 reached %v, which is 2 hops away", name)
+		}
+		path, ok := row["r."].([]*storage.Edge)
+		if !ok {
+			t.Fatalf("r bound to %T, want []*storage.Edge", row["r."])
+		}
+		if len(path) != 1 {
+			t.Errorf("*..1 bound a %d-edge path", len(path))
+		}
+	}
+}
+
 func TestPhase3_VarLengthPath_UnboundedMax(t *testing.T) {
 	_, executor, cleanup := setupPhase3Graph(t)
 	defer cleanup()
@@ -34,13 +34,14 @@ func (e *Executor) applyPostProcessing(resultSet *ResultSet, returnClause *Retur
 	resultSet.Count = len(resultSet.Rows)
 }
 
-// deduplicateRows removes duplicate rows from results
+// deduplicateRows removes duplicate rows from results. Nodes and edges are
+// compared by ID, so a neighbor reached along two paths is returned once.
 func (e *Executor) deduplicateRows(rows []map[string]any) []map[string]any {
 	seen := make(map[string]bool)
 	result := make([]map[string]any, 0)
 
 	for _, row := range rows {
-		key := fmt.Sprintf("%v", row)
+		key := groupKeyPart(row)
 		if !seen[key] {
 			seen[key] = true
 			result = append(result, row)
@@ -78,12 +79,15 @@ func (e *Executor) sortRows(rows []map[string]any, orderBy []*OrderByItem) {
 	}
 
 	sort.SliceStable(rows, func(i, j int) bool {
-		for _, item := range orderBy {
+		for k, item := range orderBy {
 			colName := orderByColumnName(item, rows[i])
+			if item.ValueExpr != nil {
+				colName = orderByExprColumn(k)
+			}
 			valI := rows[i][colName]
 			valJ := rows[j][colName]
 
-			cmp := compareValues(valI, valJ)
+			cmp := compareForOrder(valI, valJ)
 			if cmp == 0 {
 				continue // tie — check next column
 			}
@@ -95,3 +99,27 @@ func (e *Executor) sortRows(rows []map[string]any, orderBy []*OrderByItem) {
 		return false // all columns equal
 	})
 }
+
+// orderByExprColumn is the hidden row key that holds the evaluated sort key
+// for the i-th ORDER BY item when it is an expression rather than a plain
+// property (ORDER BY toLower(n.name)). The executor strips it before the
+// result set is returned.
+func orderByExprColumn(i int) string {
+	return fmt.Sprintf("__order_by_%d", i)
+}
+
+// compareForOrder orders two values for ORDER BY. Unlike compareValues it
+// gives null a fixed position — after every non-null value, as in Cypher —
+// so rows with a missing sort key sort consistently instead of tying with
+// everything (which breaks the sort's ordering invariant).
+func compareForOrder(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return compareValues(a, b)
+}
@@ -242,8 +242,9 @@ func linkPredictionProcedure(_ context.Context, graph storage.Storage, tenantID
 // callers should consider per-tenant rate limiting before exposing this
 // at unbounded HTTP surfaces. The handoff doc (Phase A) flags this as a
 // follow-up concern, not in-scope for procedure wiring.
-func pageRankProcedure(_ context.Context, graph storage.Storage, tenantID string, args []any) ([]map[string]any, error) {
+func pageRankProcedure(ctx context.Context, graph storage.Storage, tenantID string, args []any) ([]map[string]any, error) {
 	opts := algorithms.DefaultPageRankOptions()
+	opts.TopK = -1 // only scores are yielded
 
 	if len(args) >= 1 {
 		damping, ok := coerceToFloat64(args[0])
@@ -260,7 +261,7 @@ func pageRankProcedure(_ context.Context, graph storage.Storage, tenantID string
 		opts.MaxIterations = maxIter
 	}
 
-	result, err := algorithms.PageRankForTenant(graph, opts, tenantID)
+	result, err := algorithms.PageRankForTenant(ctx, graph, opts, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("algo.pageRank: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/dd0wney/graphdb/pkg/algorithms"
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
@@ -339,7 +340,7 @@ func TestPageRankProcedure_HappyPath(t *testing.T) {
 	if err != nil {
 		t.Fatalf("procedure: %v", err)
 	}
-	scores, ok := results[0]["scores"].(map[uint64]float64)
+	scores, ok := results[0]["scores"].(algorithms.ScoreResult)
 	if !ok {
 		t.Fatalf("scores type: got %T", results[0]["scores"])
 	}
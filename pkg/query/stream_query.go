@@ -23,10 +23,7 @@ func (sq *StreamingQuery) StreamNodes(
 	go func() {
 		defer stream.Close()
 
-		stats := sq.graph.GetStatistics()
-		nodeCount := int(stats.NodeCount)
-
-		for nodeID := uint64(1); nodeID <= uint64(nodeCount); nodeID++ {
+		for _, nodeID := range sq.graph.GetAllNodeIDs() {
 			// Respect context cancellation
 			select {
 			case <-stream.ctx.Done():
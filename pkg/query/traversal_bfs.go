@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -9,6 +10,13 @@ import (
 
 // BFS performs breadth-first search traversal
 func (t *Traverser) BFS(opts TraversalOptions) (*TraversalResult, error) {
+	return t.BFSWithContext(context.Background(), opts)
+}
+
+// BFSWithContext is BFS with cancellation: ctx is checked before each node
+// is visited, and on cancellation the nodes reached so far are returned
+// alongside ctx's error.
+func (t *Traverser) BFSWithContext(ctx context.Context, opts TraversalOptions) (*TraversalResult, error) {
 	// Validate and normalize options
 	if err := ValidateTraversalOptions(&opts); err != nil {
 		return nil, fmt.Errorf("invalid traversal options: %w", err)
@@ -24,9 +32,21 @@ func (t *Traverser) BFS(opts TraversalOptions) (*TraversalResult, error) {
 		Paths:      make([]Path, 0),
 		SkippedIDs: make([]uint64, 0),
 		Errors:     make([]TraversalError, 0),
+		start:      opts.StartNodeID,
+	}
+	// parent records who first enqueued each node, which is a shortest
+	// route to it; it is copied into Predecessors once the node is reached.
+	var parent map[uint64]uint64
+	if opts.CollectPaths {
+		parent = make(map[uint64]uint64)
+		result.Predecessors = make(map[uint64]uint64)
 	}
 
 	for len(queue) > 0 && len(result.Nodes) < opts.MaxResults {
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("BFS cancelled: %w", err)
+		}
+
 		nodeID := queue[0]
 		queue = queue[1:]
 
@@ -54,6 +74,9 @@ func (t *Traverser) BFS(opts TraversalOptions) (*TraversalResult, error) {
 		}
 
 		result.Nodes = append(result.Nodes, node)
+		if p, ok := parent[nodeID]; ok {
+			result.Predecessors[nodeID] = p
+		}
 
 		// Stop if max depth reached
 		currentDepth := depth[nodeID]
@@ -74,9 +97,14 @@ func (t *Traverser) BFS(opts TraversalOptions) (*TraversalResult, error) {
 		}
 
 		for _, neighborID := range neighbors {
-			if !visited[neighborID] {
+			// The first enqueue is at the shortest depth; a later one from
+			// a deeper node must not overwrite it.
+			if _, queued := depth[neighborID]; !queued {
 				queue = append(queue, neighborID)
 				depth[neighborID] = currentDepth + 1
+				if parent != nil {
+					parent[neighborID] = nodeID
+				}
 			}
 		}
 	}
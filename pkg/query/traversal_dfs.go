@@ -7,67 +7,107 @@ import (
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
 
-// DFS performs depth-first search traversal
+// DFS performs depth-first search traversal, honoring the same Direction,
+// EdgeTypes, EdgePredicate, Predicate, MaxDepth and MaxResults options as
+// BFS.
+//
+// Ordering: result.Nodes is in pre-order. The start node comes first, then
+// the subtree of each neighbor in adjacency order: outgoing edges before
+// incoming ones for DirectionBoth, each in the order storage returns them.
+// The order is deterministic for a given graph state.
+//
+// Each node is listed once, where it is first discovered. A node first
+// reached by a long path is expanded again if a shorter path reaches it
+// later, so DFS returns every node within MaxDepth hops. That is the same
+// set BFS returns, unless MaxResults cuts the traversal short.
 func (t *Traverser) DFS(opts TraversalOptions) (*TraversalResult, error) {
 	// Validate and normalize options
 	if err := ValidateTraversalOptions(&opts); err != nil {
 		return nil, fmt.Errorf("invalid traversal options: %w", err)
 	}
 
-	visited := make(map[uint64]bool)
-	result := &TraversalResult{
-		Nodes:      make([]*storage.Node, 0),
-		Paths:      make([]Path, 0),
-		SkippedIDs: make([]uint64, 0),
-		Errors:     make([]TraversalError, 0),
+	s := &dfsState{
+		opts:     opts,
+		expanded: make(map[uint64]int),
+		dead:     make(map[uint64]bool),
+		result: &TraversalResult{
+			Nodes:      make([]*storage.Node, 0),
+			Paths:      make([]Path, 0),
+			SkippedIDs: make([]uint64, 0),
+			Errors:     make([]TraversalError, 0),
+			start:      opts.StartNodeID,
+		},
+	}
+	if opts.CollectPaths {
+		s.result.Predecessors = make(map[uint64]uint64)
 	}
 
-	if err := t.dfsRecursive(opts.StartNodeID, 0, opts, visited, result); err != nil {
-		return result, err
+	if err := t.dfsRecursive(opts.StartNodeID, opts.StartNodeID, 0, s); err != nil {
+		return s.result, err
 	}
 
 	// Log summary if errors occurred
-	if len(result.Errors) > 0 {
-		log.Printf("WARNING: DFS traversal completed with %d errors (%d nodes skipped)", len(result.Errors), len(result.SkippedIDs))
+	if len(s.result.Errors) > 0 {
+		log.Printf("WARNING: DFS traversal completed with %d errors (%d nodes skipped)", len(s.result.Errors), len(s.result.SkippedIDs))
 	}
 
-	return result, nil
+	return s.result, nil
+}
+
+// dfsState is the bookkeeping shared by one DFS
+type dfsState struct {
+	opts     TraversalOptions
+	expanded map[uint64]int  // node ID -> shallowest depth it was expanded at
+	dead     map[uint64]bool // missing or rejected by Predicate: never expanded
+	result   *TraversalResult
 }
 
-// dfsRecursive is the recursive DFS implementation
-func (t *Traverser) dfsRecursive(
-	nodeID uint64,
-	depth int,
-	opts TraversalOptions,
-	visited map[uint64]bool,
-	result *TraversalResult,
-) error {
-	if visited[nodeID] || depth > opts.MaxDepth || len(result.Nodes) >= opts.MaxResults {
+// dfsRecursive is the recursive DFS implementation, visiting nodeID from
+// its parent. Recursion depth is bounded by MaxAllowedTraversalDepth.
+func (t *Traverser) dfsRecursive(nodeID, parent uint64, depth int, s *dfsState) error {
+	opts := s.opts
+	if depth > opts.MaxDepth || len(s.result.Nodes) >= opts.MaxResults || s.dead[nodeID] {
+		return nil
+	}
+	prev, seen := s.expanded[nodeID]
+	if seen && prev <= depth {
 		return nil
 	}
 
-	visited[nodeID] = true
+	if !seen {
+		// Get node
+		node, err := t.storage.GetNode(nodeID)
+		if err != nil {
+			if opts.FailOnMissing {
+				return fmt.Errorf("DFS failed at node %d: %w", nodeID, err)
+			}
+			// Track skipped node and continue
+			s.dead[nodeID] = true
+			s.result.SkippedIDs = append(s.result.SkippedIDs, nodeID)
+			s.result.Errors = append(s.result.Errors, TraversalError{NodeID: nodeID, Err: err})
+			log.Printf("WARNING: DFS skipping node %d: %v", nodeID, err)
+			return nil
+		}
 
-	// Get node
-	node, err := t.storage.GetNode(nodeID)
-	if err != nil {
-		if opts.FailOnMissing {
-			return fmt.Errorf("DFS failed at node %d: %w", nodeID, err)
+		// Apply predicate filter
+		if opts.Predicate != nil && !opts.Predicate(node) {
+			s.dead[nodeID] = true
+			return nil
 		}
-		// Track skipped node and continue
-		result.SkippedIDs = append(result.SkippedIDs, nodeID)
-		result.Errors = append(result.Errors, TraversalError{NodeID: nodeID, Err: err})
-		log.Printf("WARNING: DFS skipping node %d: %v", nodeID, err)
-		return nil
+
+		s.result.Nodes = append(s.result.Nodes, node)
+	}
+	s.expanded[nodeID] = depth
+	// Re-pointed on every shallower expansion. Depth strictly decreases
+	// along the chain, so it always leads back to the start.
+	if s.result.Predecessors != nil && depth > 0 {
+		s.result.Predecessors[nodeID] = parent
 	}
 
-	// Apply predicate filter
-	if opts.Predicate != nil && !opts.Predicate(node) {
+	if depth == opts.MaxDepth {
 		return nil
 	}
 
-	result.Nodes = append(result.Nodes, node)
-
 	// Get neighbors
 	neighbors, err := t.getNeighbors(nodeID, opts.Direction, opts.EdgeTypes, opts.EdgePredicate)
 	if err != nil {
@@ -75,13 +115,13 @@ func (t *Traverser) dfsRecursive(
 			return fmt.Errorf("DFS failed getting neighbors for node %d: %w", nodeID, err)
 		}
 		// Track error but continue traversal
-		result.Errors = append(result.Errors, TraversalError{NodeID: nodeID, Err: fmt.Errorf("get neighbors: %w", err)})
+		s.result.Errors = append(s.result.Errors, TraversalError{NodeID: nodeID, Err: fmt.Errorf("get neighbors: %w", err)})
 		log.Printf("WARNING: DFS skipping neighbors of node %d: %v", nodeID, err)
 		return nil
 	}
 
 	for _, neighborID := range neighbors {
-		if err := t.dfsRecursive(neighborID, depth+1, opts, visited, result); err != nil {
+		if err := t.dfsRecursive(neighborID, nodeID, depth+1, s); err != nil {
 			return err // Propagate error in strict mode
 		}
 	}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"container/heap"
+	"errors"
 	"fmt"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -211,3 +213,121 @@ func (t *Traverser) reconstructPath(
 
 	return path, nil
 }
+
+// ErrNegativeEdgeWeight is returned by the weighted path search when it
+// reaches an edge with a negative weight, for which least-cost paths are
+// not well defined by Dijkstra's algorithm.
+var ErrNegativeEdgeWeight = errors.New("negative edge weight")
+
+// FindWeightedShortestPath finds the least-cost path between two nodes,
+// where each edge costs its Weight (Dijkstra). It returns the path and its
+// total cost. Edge weights must be non-negative.
+func (t *Traverser) FindWeightedShortestPath(fromID, toID uint64, edgeTypes []string) (Path, float64, error) {
+	return t.FindWeightedShortestPathWithPredicate(fromID, toID, edgeTypes, nil)
+}
+
+// FindWeightedShortestPathWithPredicate finds the least-cost path with
+// optional edge filtering
+func (t *Traverser) FindWeightedShortestPathWithPredicate(fromID, toID uint64, edgeTypes []string, edgePredicate func(*storage.Edge) bool) (Path, float64, error) {
+	return t.weightedShortestPath(fromID, toID, t.storage.GetOutgoingEdges, edgeTypes, edgePredicate)
+}
+
+// FindWeightedShortestPathForTenant finds the least-cost path following
+// only edges owned by tenantID, and passing edgePredicate if it is non-nil.
+// As with the unweighted BFS, the filter is applied at edge expansion:
+// post-filtering a path could reject a cheaper cross-tenant route and miss
+// one that exists within the tenant.
+func (t *Traverser) FindWeightedShortestPathForTenant(fromID, toID uint64, edgeTypes []string, tenantID string, edgePredicate func(*storage.Edge) bool) (Path, float64, error) {
+	edgesOf := func(nodeID uint64) ([]*storage.Edge, error) {
+		return t.storage.GetOutgoingEdgesForTenant(nodeID, tenantID)
+	}
+	return t.weightedShortestPath(fromID, toID, edgesOf, edgeTypes, edgePredicate)
+}
+
+// weightedShortestPath is Dijkstra over the edges edgesOf returns.
+func (t *Traverser) weightedShortestPath(
+	fromID, toID uint64,
+	edgesOf func(uint64) ([]*storage.Edge, error),
+	edgeTypes []string,
+	edgePredicate func(*storage.Edge) bool,
+) (Path, float64, error) {
+	if fromID == toID {
+		node, err := t.storage.GetNode(fromID)
+		if err != nil {
+			return Path{}, 0, err
+		}
+		return Path{Nodes: []*storage.Node{node}, Edges: []*storage.Edge{}}, 0, nil
+	}
+
+	dist := map[uint64]float64{fromID: 0}
+	parent := make(map[uint64]uint64)
+	parentEdge := make(map[uint64]*storage.Edge)
+	settled := make(map[uint64]bool)
+
+	pq := &costQueue{{nodeID: fromID, cost: 0}}
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(costItem)
+		if settled[current.nodeID] {
+			continue // stale entry superseded by a cheaper one
+		}
+		settled[current.nodeID] = true
+
+		if current.nodeID == toID {
+			path, err := t.reconstructPath(fromID, toID, parent, parentEdge)
+			if err != nil {
+				return Path{}, 0, err
+			}
+			return path, current.cost, nil
+		}
+
+		edges, err := edgesOf(current.nodeID)
+		if err != nil {
+			continue
+		}
+		for _, edge := range edges {
+			if len(edgeTypes) > 0 && !contains(edgeTypes, edge.Type) {
+				continue
+			}
+			if edgePredicate != nil && !edgePredicate(edge) {
+				continue
+			}
+			if edge.Weight < 0 {
+				return Path{}, 0, fmt.Errorf("%w: edge %d has weight %g", ErrNegativeEdgeWeight, edge.ID, edge.Weight)
+			}
+
+			neighborID := edge.ToNodeID
+			if settled[neighborID] {
+				continue
+			}
+			cost := current.cost + edge.Weight
+			if old, seen := dist[neighborID]; !seen || cost < old {
+				dist[neighborID] = cost
+				parent[neighborID] = current.nodeID
+				parentEdge[neighborID] = edge
+				heap.Push(pq, costItem{nodeID: neighborID, cost: cost})
+			}
+		}
+	}
+
+	return Path{}, 0, fmt.Errorf("no path found between nodes %d and %d", fromID, toID)
+}
+
+// costItem is a node and the cost of the best path to it found so far.
+type costItem struct {
+	nodeID uint64
+	cost   float64
+}
+
+// costQueue is a min-heap of costItems for Dijkstra.
+type costQueue []costItem
+
+func (q costQueue) Len() int           { return len(q) }
+func (q costQueue) Less(i, j int) bool { return q[i].cost < q[j].cost }
+func (q costQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *costQueue) Push(x any)        { *q = append(*q, x.(costItem)) }
+func (q *costQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
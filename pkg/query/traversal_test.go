@@ -1,8 +1,11 @@
 package query
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
@@ -142,6 +145,32 @@ func TestBFS(t *testing.T) {
 	}
 }
 
+// TestBFSWithContext_Cancelled: cancelling mid-traversal stops BFS at the
+// next node and returns what it reached with the context error.
+func TestBFSWithContext_Cancelled(t *testing.T) {
+	gs, cleanup := setupTraversalTestGraph(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	result, err := NewTraverser(gs).BFSWithContext(ctx, TraversalOptions{
+		StartNodeID: 1,
+		Direction:   DirectionOutgoing,
+		MaxDepth:    10,
+		MaxResults:  100,
+		Predicate: func(*storage.Node) bool {
+			cancel()
+			return true
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if len(result.Nodes) != 1 {
+		t.Errorf("reached %d nodes before cancellation, want 1", len(result.Nodes))
+	}
+}
+
 // TestBFS_WithPredicate tests BFS with node filter
 func TestBFS_WithPredicate(t *testing.T) {
 	gs, cleanup := setupTraversalTestGraph(t)
@@ -264,6 +293,143 @@ func TestDFS(t *testing.T) {
 	}
 }
 
+// TestDFS_PreOrderAndShorterPaths pins DFS's ordering guarantee (pre-order,
+// neighbors in adjacency order) and that a node first reached by a long
+// path is re-expanded when a shorter one reaches it, so a depth-limited DFS
+// finds the same nodes as BFS.
+func TestDFS_PreOrderAndShorterPaths(t *testing.T) {
+	gs, err := storage.NewGraphStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = gs.Close() }()
+
+	// a -> b -> c -> d, plus the shortcut a -> c
+	ids := make([]uint64, 4)
+	for i := range ids {
+		n, err := gs.CreateNode([]string{"N"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = n.ID
+	}
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 3}, {0, 2}} {
+		if _, err := gs.CreateEdge(ids[e[0]], ids[e[1]], "NEXT", nil, 1.0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	traverser := NewTraverser(gs)
+	opts := TraversalOptions{StartNodeID: ids[0], Direction: DirectionOutgoing, MaxDepth: 2}
+	dfs, err := traverser.DFS(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []uint64
+	for _, n := range dfs.Nodes {
+		got = append(got, n.ID)
+	}
+	// c is first reached at depth 2 via b, then at depth 1 via the shortcut,
+	// which puts d within reach.
+	if want := ids; !slices.Equal(got, want) {
+		t.Errorf("DFS order = %v, want %v", got, want)
+	}
+
+	bfs, err := traverser.BFS(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bfs.Nodes) != len(dfs.Nodes) {
+		t.Errorf("BFS found %d nodes, DFS %d", len(bfs.Nodes), len(dfs.Nodes))
+	}
+}
+
+// TestTraversal_CollectPaths checks that BFS and DFS record how each node
+// was reached and that PathTo reads a valid route out of it.
+func TestTraversal_CollectPaths(t *testing.T) {
+	gs, cleanup := setupTraversalTestGraph(t)
+	defer cleanup()
+	traverser := NewTraverser(gs)
+
+	opts := TraversalOptions{StartNodeID: 1, Direction: DirectionOutgoing, MaxDepth: 3, CollectPaths: true}
+	bfs, err := traverser.BFS(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, want := range map[uint64][]uint64{1: {1}, 2: {1, 2}, 4: {1, 2, 4}, 5: {1, 2, 5}} {
+		if got := bfs.PathTo(id); !slices.Equal(got, want) {
+			t.Errorf("BFS PathTo(%d) = %v, want %v", id, got, want)
+		}
+	}
+	if got := bfs.PathTo(999); got != nil {
+		t.Errorf("PathTo(unreached) = %v, want nil", got)
+	}
+
+	dfs, err := traverser.DFS(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range dfs.Nodes {
+		path := dfs.PathTo(n.ID)
+		if len(path) == 0 || path[0] != 1 || path[len(path)-1] != n.ID {
+			t.Fatalf("DFS PathTo(%d) = %v, want a route from 1", n.ID, path)
+		}
+		for i := 1; i < len(path); i++ {
+			edges, _ := gs.GetOutgoingEdges(path[i-1])
+			if !slices.ContainsFunc(edges, func(e *storage.Edge) bool { return e.ToNodeID == path[i] }) {
+				t.Errorf("DFS PathTo(%d) = %v: no edge %d -> %d", n.ID, path, path[i-1], path[i])
+			}
+		}
+	}
+
+	opts.CollectPaths = false
+	plain, err := traverser.BFS(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain.Predecessors != nil || plain.PathTo(2) != nil {
+		t.Error("paths collected without CollectPaths")
+	}
+}
+
+// TestTraversal_PredicateBlocksCrossing pins that a node rejected by
+// Predicate is a wall, not just hidden: nothing behind it is reached unless
+// another route goes around it. In the test graph, 2 is the only way to 4.
+func TestTraversal_PredicateBlocksCrossing(t *testing.T) {
+	gs, cleanup := setupTraversalTestGraph(t)
+	defer cleanup()
+	traverser := NewTraverser(gs)
+
+	notBob := func(n *storage.Node) bool {
+		name, _ := n.Properties["name"].AsString()
+		return name != "Bob"
+	}
+	notWorksAt := func(e *storage.Edge) bool { return e.Type != "WORKS_AT" }
+
+	for name, run := range map[string]func(TraversalOptions) (*TraversalResult, error){"BFS": traverser.BFS, "DFS": traverser.DFS} {
+		result, err := run(TraversalOptions{StartNodeID: 1, Direction: DirectionOutgoing, MaxDepth: 5, Predicate: notBob})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []uint64
+		for _, n := range result.Nodes {
+			got = append(got, n.ID)
+		}
+		slices.Sort(got)
+		if want := []uint64{1, 3, 5}; !slices.Equal(got, want) {
+			t.Errorf("%s with node 2 blocked = %v, want %v", name, got, want)
+		}
+
+		result, err = run(TraversalOptions{StartNodeID: 1, Direction: DirectionOutgoing, MaxDepth: 5, EdgePredicate: notWorksAt})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Nodes) != 4 {
+			t.Errorf("%s without WORKS_AT edges reached %d nodes, want 4 (not 5)", name, len(result.Nodes))
+		}
+	}
+}
+
 // TestFindShortestPath tests shortest path finding
 func TestFindShortestPath(t *testing.T) {
 	gs, cleanup := setupTraversalTestGraph(t)
@@ -348,6 +514,112 @@ func TestFindShortestPath(t *testing.T) {
 }
 
 // TestFindAllPaths tests finding all paths
+func TestFindWeightedShortestPath(t *testing.T) {
+	gs, err := storage.NewGraphStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create graph storage: %v", err)
+	}
+	defer func() { _ = gs.Close() }()
+
+	// a -> d costs 10 directly, 2 via b, 6 via c.
+	ids := make(map[string]uint64)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		n, err := gs.CreateNode([]string{"Junction"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[name] = n.ID
+	}
+	for _, e := range []struct {
+		from, to string
+		weight   float64
+	}{
+		{"a", "d", 10}, {"a", "b", 1}, {"b", "d", 1}, {"a", "c", 5}, {"c", "d", 1},
+	} {
+		if _, err := gs.CreateEdge(ids[e.from], ids[e.to], "PIPE", nil, e.weight); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	traverser := NewTraverser(gs)
+
+	path, cost, err := traverser.FindWeightedShortestPath(ids["a"], ids["d"], nil)
+	if err != nil {
+		t.Fatalf("FindWeightedShortestPath failed: %v", err)
+	}
+	if cost != 2 {
+		t.Errorf("Expected cost 2, got %g", cost)
+	}
+	var got []uint64
+	for _, n := range path.Nodes {
+		got = append(got, n.ID)
+	}
+	if want := []uint64{ids["a"], ids["b"], ids["d"]}; !slices.Equal(got, want) {
+		t.Errorf("Expected path %v, got %v", want, got)
+	}
+	if len(path.Edges) != 2 {
+		t.Errorf("Expected 2 edges, got %d", len(path.Edges))
+	}
+
+	// The unweighted search takes the direct, costlier hop.
+	bfsPath, err := traverser.FindShortestPath(ids["a"], ids["d"], nil)
+	if err != nil || len(bfsPath.Nodes) != 2 {
+		t.Errorf("Expected the 1-hop BFS path, got %d nodes (err %v)", len(bfsPath.Nodes), err)
+	}
+
+	// Same node: empty path, zero cost.
+	if path, cost, err := traverser.FindWeightedShortestPath(ids["a"], ids["a"], nil); err != nil || len(path.Nodes) != 1 || cost != 0 {
+		t.Errorf("Expected single-node zero-cost path, got %d nodes, cost %g, err %v", len(path.Nodes), cost, err)
+	}
+
+	// Unreachable.
+	if _, _, err := traverser.FindWeightedShortestPath(ids["d"], ids["a"], nil); err == nil {
+		t.Error("Expected error for unreachable target")
+	}
+
+	// Negative weights are refused rather than giving a wrong answer.
+	if _, err := gs.CreateEdge(ids["b"], ids["c"], "PIPE", nil, -3); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := traverser.FindWeightedShortestPath(ids["a"], ids["d"], nil); !errors.Is(err, ErrNegativeEdgeWeight) {
+		t.Errorf("Expected ErrNegativeEdgeWeight, got %v", err)
+	}
+}
+
+func TestFindWeightedShortestPathForTenant(t *testing.T) {
+	gs, err := storage.NewGraphStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create graph storage: %v", err)
+	}
+	defer func() { _ = gs.Close() }()
+
+	// Tenant t1 owns a -> b -> c (cost 4); t2's shortcut a -> c must not be used.
+	var ids []uint64
+	for range 3 {
+		n, err := gs.CreateNodeWithTenant("t1", []string{"Junction"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, n.ID)
+	}
+	for _, e := range [][2]int{{0, 1}, {1, 2}} {
+		if _, err := gs.CreateEdgeWithTenant("t1", ids[e[0]], ids[e[1]], "PIPE", nil, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := gs.CreateEdge(ids[0], ids[2], "PIPE", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	path, cost, err := NewTraverser(gs).FindWeightedShortestPathForTenant(ids[0], ids[2], nil, "t1", nil)
+	if err != nil {
+		t.Fatalf("FindWeightedShortestPathForTenant failed: %v", err)
+	}
+	if cost != 4 || len(path.Nodes) != 3 {
+		t.Errorf("Expected the 3-node tenant path costing 4, got %d nodes costing %g", len(path.Nodes), cost)
+	}
+}
+
 func TestFindAllPaths(t *testing.T) {
 	gs, cleanup := setupTraversalTestGraph(t)
 	defer cleanup()
@@ -3,6 +3,7 @@ package query
 import (
 	"fmt"
 	"log"
+	"slices"
 
 	"github.com/dd0wney/graphdb/pkg/storage"
 )
@@ -80,9 +81,10 @@ type TraversalOptions struct {
 	EdgeTypes     []string                 // Filter by edge types (empty = all types)
 	MaxDepth      int                      // Maximum traversal depth
 	MaxResults    int                      // Maximum nodes to return
-	Predicate     func(*storage.Node) bool // Node filter function
-	EdgePredicate func(*storage.Edge) bool // Edge filter function (for temporal/property filtering)
+	Predicate     func(*storage.Node) bool // Node filter: a rejected node is neither returned nor traversed through (start node included)
+	EdgePredicate func(*storage.Edge) bool // Edge filter (temporal/property): a rejected edge is not followed
 	FailOnMissing bool                     // If true, return error on first missing node; if false, track and continue
+	CollectPaths  bool                     // If true, fill TraversalResult.Predecessors so PathTo works
 }
 
 // TraversalError records an error encountered during traversal
@@ -101,9 +103,44 @@ type TraversalResult struct {
 	Paths      []Path
 	SkippedIDs []uint64         // Node IDs that were skipped due to errors
 	Errors     []TraversalError // Errors encountered during traversal
+
+	// Predecessors is the predecessor tree, set when CollectPaths is: each
+	// reached node other than the start maps to the node it was reached
+	// from. Use PathTo to read a route out of it.
+	Predecessors map[uint64]uint64
+
+	start uint64
+}
+
+// PathTo returns the node IDs on the route from the start node to id, both
+// ends included: a shortest one (in hops) for BFS, the one the search
+// followed for DFS. It returns nil if id was not reached or the traversal
+// ran without CollectPaths.
+func (r *TraversalResult) PathTo(id uint64) []uint64 {
+	if r.Predecessors == nil {
+		return nil
+	}
+	if id == r.start {
+		if len(r.Nodes) == 0 || r.Nodes[0].ID != r.start {
+			return nil
+		}
+		return []uint64{id}
+	}
+	if _, ok := r.Predecessors[id]; !ok {
+		return nil
+	}
+
+	path := []uint64{id}
+	for id != r.start {
+		id = r.Predecessors[id]
+		path = append(path, id)
+	}
+	slices.Reverse(path)
+	return path
 }
 
-// Path represents a path through the graph
+// Path represents a path through the graph. Path variables in MATCH
+// (p = (a)-[*]->(b)) are bound to a *Path.
 type Path struct {
 	Nodes []*storage.Node
 	Edges []*storage.Edge
@@ -0,0 +1,547 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Portable backup format
+//
+// Backup writes, and Restore reads, a newline-delimited JSON stream that is
+// independent of the on-disk snapshot formats (snapshot.json, snapshot.mmap)
+// and of the in-memory Value encoding, so a backup can be inspected with
+// standard tools and restored by a newer graphdb. The stream is:
+//
+//	{"format":"graphdb-backup","version":1,"created_at":...,"next_node_id":N,"next_edge_id":M,"nodes":n,"edges":m}
+//	{"node":{"id":1,"tenant":"default","labels":[...],"properties":{...},"created_at":...,"updated_at":...}}
+//	...
+//	{"edge":{"id":1,"tenant":"default","from":1,"to":2,"type":"KNOWS","weight":1,"properties":{...},"created_at":...}}
+//	...
+//	{"end":{"nodes":n,"edges":m}}
+//
+// Every node precedes every edge. Property values are tagged with their type
+// — {"type":"int","value":42} — so they round-trip exactly; see
+// backupValueTypes for the names. The trailing "end" record lets Restore tell
+// a complete stream from one truncated in transit.
+
+const (
+	backupFormatName    = "graphdb-backup"
+	backupFormatVersion = 1
+)
+
+// backupValueTypes names each ValueType in the portable format. The names,
+// not the numeric tags, are the stable contract.
+var backupValueTypes = map[ValueType]string{
+	TypeString:      "string",
+	TypeInt:         "int",
+	TypeFloat:       "float",
+	TypeBool:        "bool",
+	TypeBytes:       "bytes",
+	TypeTimestamp:   "timestamp",
+	TypeVector:      "vector",
+	TypeStringArray: "string[]",
+	TypeIntArray:    "int[]",
+	TypeFloatArray:  "float[]",
+	TypeBoolArray:   "bool[]",
+	TypeJSON:        "json",
+}
+
+type backupHeader struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	CreatedAt  time.Time `json:"created_at"`
+	NextNodeID uint64    `json:"next_node_id"`
+	NextEdgeID uint64    `json:"next_edge_id"`
+	Nodes      int       `json:"nodes"`
+	Edges      int       `json:"edges"`
+}
+
+type backupRecord struct {
+	Node *backupNode    `json:"node,omitempty"`
+	Edge *backupEdge    `json:"edge,omitempty"`
+	End  *backupTrailer `json:"end,omitempty"`
+}
+
+type backupNode struct {
+	ID         uint64                 `json:"id"`
+	Tenant     string                 `json:"tenant,omitempty"`
+	Labels     []string               `json:"labels"`
+	Properties map[string]backupValue `json:"properties,omitempty"`
+	CreatedAt  int64                  `json:"created_at"`
+	UpdatedAt  int64                  `json:"updated_at"`
+}
+
+type backupEdge struct {
+	ID         uint64                 `json:"id"`
+	Tenant     string                 `json:"tenant,omitempty"`
+	From       uint64                 `json:"from"`
+	To         uint64                 `json:"to"`
+	Type       string                 `json:"type"`
+	Weight     float64                `json:"weight"`
+	Properties map[string]backupValue `json:"properties,omitempty"`
+	CreatedAt  int64                  `json:"created_at"`
+	Undirected bool                   `json:"undirected,omitempty"`
+}
+
+type backupTrailer struct {
+	Nodes int `json:"nodes"`
+	Edges int `json:"edges"`
+}
+
+type backupValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Backup writes a consistent, portable dump of every tenant's nodes and edges
+// to w (format above). It reads from a [GraphView], so the dump reflects one
+// point in time while writers carry on; the cost is a transient in-memory
+// copy of the graph. Index definitions are not included.
+//
+// An error part-way through leaves w holding a stream without its "end"
+// record, which Restore rejects.
+func (gs *GraphStorage) Backup(w io.Writer) error {
+	view, err := gs.View()
+	if err != nil {
+		return err
+	}
+	header := backupHeader{
+		Format:     backupFormatName,
+		Version:    backupFormatVersion,
+		CreatedAt:  view.TakenAt().UTC(),
+		NextNodeID: view.nextNodeID,
+		NextEdgeID: view.nextEdgeID,
+		Nodes:      len(view.nodes),
+		Edges:      len(view.edges),
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := enc.Encode(header); err != nil {
+		return fmt.Errorf("backup: write header: %w", err)
+	}
+	for _, id := range view.nodeIDs {
+		node := view.nodes[id]
+		props, err := encodeBackupProperties(node.Properties)
+		if err != nil {
+			return fmt.Errorf("backup: node %d: %w", id, err)
+		}
+		rec := backupRecord{Node: &backupNode{
+			ID:         node.ID,
+			Tenant:     node.TenantID,
+			Labels:     node.Labels,
+			Properties: props,
+			CreatedAt:  node.CreatedAt,
+			UpdatedAt:  node.UpdatedAt,
+		}}
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("backup: write node %d: %w", id, err)
+		}
+	}
+	edgeIDs := make([]uint64, 0, len(view.edges))
+	for id := range view.edges {
+		edgeIDs = append(edgeIDs, id)
+	}
+	slices.Sort(edgeIDs)
+	for _, id := range edgeIDs {
+		edge := view.edges[id]
+		props, err := encodeBackupProperties(edge.Properties)
+		if err != nil {
+			return fmt.Errorf("backup: edge %d: %w", id, err)
+		}
+		rec := backupRecord{Edge: &backupEdge{
+			ID:         edge.ID,
+			Tenant:     edge.TenantID,
+			From:       edge.FromNodeID,
+			To:         edge.ToNodeID,
+			Type:       edge.Type,
+			Weight:     edge.Weight,
+			Properties: props,
+			CreatedAt:  edge.CreatedAt,
+			Undirected: edge.Undirected,
+		}}
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("backup: write edge %d: %w", id, err)
+		}
+	}
+	if err := enc.Encode(backupRecord{End: &backupTrailer{Nodes: header.Nodes, Edges: header.Edges}}); err != nil {
+		return fmt.Errorf("backup: write trailer: %w", err)
+	}
+	return bw.Flush()
+}
+
+// Restore creates a new store in dataDir from a stream written by Backup and
+// returns it open. Node and edge IDs, tenants, and timestamps are preserved.
+// dataDir must not already hold a store (it may be missing or empty), so a
+// restore can never overwrite live data. The whole stream is read and
+// validated before the store is created; on success the restored graph has
+// been snapshotted to disk.
+func Restore(dataDir string, r io.Reader) (*GraphStorage, error) {
+	if entries, err := os.ReadDir(dataDir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("restore: %s is not empty", dataDir)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("restore: %w", err)
+	}
+
+	header, nodes, edges, err := readBackup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	gs, err := NewGraphStorage(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := gs.restoreBackup(header, nodes, edges); err != nil {
+		gs.Close()
+		return nil, err
+	}
+	return gs, nil
+}
+
+// restoreBackup publishes the decoded records into an empty store and
+// checkpoints it. Records bypass the WAL: the closing Snapshot is the
+// durability point; a crash before it leaves a partial dataDir to clear and
+// restore into again.
+func (gs *GraphStorage) restoreBackup(header *backupHeader, nodes []*Node, edges []*Edge) error {
+	var vectorPlans []vectorInsertPlan
+	gs.mu.Lock()
+	for _, node := range nodes {
+		plans, err := gs.persistNodeLocked(node)
+		if err != nil {
+			gs.mu.Unlock()
+			return fmt.Errorf("restore: node %d: %w", node.ID, err)
+		}
+		vectorPlans = append(vectorPlans, plans...)
+		raiseIDCounter(&gs.nextNodeID, node.ID+1)
+	}
+	for _, edge := range edges {
+		if err := gs.persistEdgeLocked(edge); err != nil {
+			gs.mu.Unlock()
+			return fmt.Errorf("restore: edge %d: %w", edge.ID, err)
+		}
+		raiseIDCounter(&gs.nextEdgeID, edge.ID+1)
+	}
+	raiseIDCounter(&gs.nextNodeID, header.NextNodeID)
+	raiseIDCounter(&gs.nextEdgeID, header.NextEdgeID)
+	gs.mu.Unlock()
+
+	gs.applyNodeVectorInserts(vectorPlans)
+	if err := gs.Snapshot(); err != nil {
+		return fmt.Errorf("restore: snapshot: %w", err)
+	}
+	return nil
+}
+
+// readBackup decodes and validates a complete backup stream.
+func readBackup(r io.Reader) (*backupHeader, []*Node, []*Edge, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	var header backupHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: header: %v", ErrInvalidBackup, err)
+	}
+	if header.Format != backupFormatName {
+		return nil, nil, nil, fmt.Errorf("%w: format %q", ErrInvalidBackup, header.Format)
+	}
+	if header.Version < 1 || header.Version > backupFormatVersion {
+		return nil, nil, nil, fmt.Errorf("%w: version %d is not supported (max %d)",
+			ErrInvalidBackup, header.Version, backupFormatVersion)
+	}
+
+	nodes := make([]*Node, 0, header.Nodes)
+	edges := make([]*Edge, 0, header.Edges)
+	nodeIDs := make(map[uint64]struct{}, header.Nodes)
+	edgeIDs := make(map[uint64]struct{}, header.Edges)
+	for {
+		var rec backupRecord
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil, nil, fmt.Errorf("%w: stream ends without an end record (truncated?)", ErrInvalidBackup)
+			}
+			return nil, nil, nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+		switch {
+		case rec.Node != nil:
+			if len(edges) > 0 {
+				return nil, nil, nil, fmt.Errorf("%w: node %d after edges", ErrInvalidBackup, rec.Node.ID)
+			}
+			node, err := rec.Node.toNode()
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("%w: node %d: %v", ErrInvalidBackup, rec.Node.ID, err)
+			}
+			if _, dup := nodeIDs[node.ID]; dup || node.ID == 0 {
+				return nil, nil, nil, fmt.Errorf("%w: invalid or duplicate node ID %d", ErrInvalidBackup, node.ID)
+			}
+			nodeIDs[node.ID] = struct{}{}
+			nodes = append(nodes, node)
+		case rec.Edge != nil:
+			edge, err := rec.Edge.toEdge()
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("%w: edge %d: %v", ErrInvalidBackup, rec.Edge.ID, err)
+			}
+			if _, dup := edgeIDs[edge.ID]; dup || edge.ID == 0 {
+				return nil, nil, nil, fmt.Errorf("%w: invalid or duplicate edge ID %d", ErrInvalidBackup, edge.ID)
+			}
+			_, fromOK := nodeIDs[edge.FromNodeID]
+			_, toOK := nodeIDs[edge.ToNodeID]
+			if !fromOK || !toOK {
+				return nil, nil, nil, fmt.Errorf("%w: edge %d references a missing node", ErrInvalidBackup, edge.ID)
+			}
+			if err := validateEdgeWeight(edge.Weight); err != nil {
+				return nil, nil, nil, fmt.Errorf("%w: edge %d: %v", ErrInvalidBackup, edge.ID, err)
+			}
+			edgeIDs[edge.ID] = struct{}{}
+			edges = append(edges, edge)
+		case rec.End != nil:
+			if rec.End.Nodes != len(nodes) || rec.End.Edges != len(edges) {
+				return nil, nil, nil, fmt.Errorf("%w: end record counts %d nodes/%d edges, stream has %d/%d",
+					ErrInvalidBackup, rec.End.Nodes, rec.End.Edges, len(nodes), len(edges))
+			}
+			return &header, nodes, edges, nil
+		default:
+			return nil, nil, nil, fmt.Errorf("%w: empty record", ErrInvalidBackup)
+		}
+	}
+}
+
+func (bn *backupNode) toNode() (*Node, error) {
+	props, err := decodeBackupProperties(bn.Properties)
+	if err != nil {
+		return nil, err
+	}
+	labels := bn.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return &Node{
+		ID:         bn.ID,
+		TenantID:   bn.Tenant,
+		Labels:     labels,
+		Properties: props,
+		CreatedAt:  bn.CreatedAt,
+		UpdatedAt:  bn.UpdatedAt,
+	}, nil
+}
+
+func (be *backupEdge) toEdge() (*Edge, error) {
+	if be.Type == "" {
+		return nil, errors.New("empty edge type")
+	}
+	props, err := decodeBackupProperties(be.Properties)
+	if err != nil {
+		return nil, err
+	}
+	return &Edge{
+		ID:         be.ID,
+		TenantID:   be.Tenant,
+		FromNodeID: be.From,
+		ToNodeID:   be.To,
+		Type:       be.Type,
+		Weight:     be.Weight,
+		Properties: props,
+		CreatedAt:  be.CreatedAt,
+		Undirected: be.Undirected,
+	}, nil
+}
+
+func encodeBackupProperties(props map[string]Value) (map[string]backupValue, error) {
+	if len(props) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]backupValue, len(props))
+	for k, v := range props {
+		bv, err := encodeBackupValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", k, err)
+		}
+		out[k] = bv
+	}
+	return out, nil
+}
+
+func decodeBackupProperties(props map[string]backupValue) (map[string]Value, error) {
+	out := make(map[string]Value, len(props))
+	for k, bv := range props {
+		v, err := decodeBackupValue(bv)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", k, err)
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// encodeBackupValue converts a Value to its tagged portable form. Non-finite
+// floats (scalars, vector and float[] elements), which JSON numbers cannot
+// carry, are written as the strings "NaN", "+Inf" and "-Inf".
+func encodeBackupValue(v Value) (backupValue, error) {
+	name, ok := backupValueTypes[v.Type]
+	if !ok {
+		return backupValue{}, fmt.Errorf("unknown value type %d", v.Type)
+	}
+	var (
+		native any
+		err    error
+	)
+	switch v.Type {
+	case TypeString:
+		native, err = v.AsString()
+	case TypeInt:
+		native, err = v.AsInt()
+	case TypeFloat:
+		var f float64
+		f, err = v.AsFloat()
+		native = backupFloat(f)
+	case TypeBool:
+		native, err = v.AsBool()
+	case TypeBytes:
+		native = v.Data // base64 via encoding/json
+	case TypeTimestamp:
+		var t time.Time
+		t, err = v.AsTimestamp()
+		native = t.UTC().Format(time.RFC3339)
+	case TypeVector:
+		var vec []float32
+		vec, err = v.AsVector()
+		floats := make([]any, len(vec))
+		for i, f := range vec {
+			floats[i] = backupFloat(float64(f))
+		}
+		native = floats
+	case TypeStringArray:
+		native, err = v.AsStringArray()
+	case TypeIntArray:
+		native, err = v.AsIntArray()
+	case TypeFloatArray:
+		var arr []float64
+		arr, err = v.AsFloatArray()
+		floats := make([]any, len(arr))
+		for i, f := range arr {
+			floats[i] = backupFloat(f)
+		}
+		native = floats
+	case TypeBoolArray:
+		native, err = v.AsBoolArray()
+	case TypeJSON:
+		if !json.Valid(v.Data) {
+			return backupValue{}, errors.New("invalid JSON value")
+		}
+		return backupValue{Type: name, Value: json.RawMessage(v.Data)}, nil
+	}
+	if err != nil {
+		return backupValue{}, err
+	}
+	raw, err := json.Marshal(native)
+	if err != nil {
+		return backupValue{}, err
+	}
+	return backupValue{Type: name, Value: raw}, nil
+}
+
+// decodeBackupValue is the inverse of encodeBackupValue.
+func decodeBackupValue(bv backupValue) (Value, error) {
+	switch bv.Type {
+	case "string":
+		var s string
+		err := json.Unmarshal(bv.Value, &s)
+		return StringValue(s), err
+	case "int":
+		var i int64
+		err := json.Unmarshal(bv.Value, &i)
+		return IntValue(i), err
+	case "float":
+		f, err := parseBackupFloat(bv.Value)
+		return FloatValue(f), err
+	case "bool":
+		var b bool
+		err := json.Unmarshal(bv.Value, &b)
+		return BoolValue(b), err
+	case "bytes":
+		var b []byte
+		err := json.Unmarshal(bv.Value, &b)
+		return BytesValue(b), err
+	case "timestamp":
+		var s string
+		if err := json.Unmarshal(bv.Value, &s); err != nil {
+			return Value{}, err
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		return TimestampValue(t), err
+	case "vector":
+		floats, err := parseBackupFloats(bv.Value)
+		vec := make([]float32, len(floats))
+		for i, f := range floats {
+			vec[i] = float32(f)
+		}
+		return VectorValue(vec), err
+	case "string[]":
+		var arr []string
+		err := json.Unmarshal(bv.Value, &arr)
+		return StringArrayValue(arr), err
+	case "int[]":
+		var arr []int64
+		err := json.Unmarshal(bv.Value, &arr)
+		return IntArrayValue(arr), err
+	case "float[]":
+		arr, err := parseBackupFloats(bv.Value)
+		return FloatArrayValue(arr), err
+	case "bool[]":
+		var arr []bool
+		err := json.Unmarshal(bv.Value, &arr)
+		return BoolArrayValue(arr), err
+	case "json":
+		if !json.Valid(bv.Value) {
+			return Value{}, errors.New("invalid JSON value")
+		}
+		return Value{Type: TypeJSON, Data: slices.Clone([]byte(bv.Value))}, nil
+	default:
+		return Value{}, fmt.Errorf("unknown value type %q", bv.Type)
+	}
+}
+
+// backupFloat returns f as a JSON-encodable value: the number itself, or
+// "NaN" / "+Inf" / "-Inf", which JSON numbers cannot represent.
+func backupFloat(f float64) any {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return f
+}
+
+// parseBackupFloat is the inverse of backupFloat.
+func parseBackupFloat(raw json.RawMessage) (float64, error) {
+	var f float64
+	if err := json.Unmarshal(raw, &f); err == nil {
+		return f, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseBackupFloats(raw json.RawMessage) ([]float64, error) {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(elems))
+	for i, elem := range elems {
+		f, err := parseBackupFloat(elem)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = f
+	}
+	return out, nil
+}
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync/atomic"
 
@@ -19,7 +18,7 @@ import (
 func (b *Batch) Commit() error {
 	b.graph.mu.Lock()
 
-	b.haveObservers = len(b.graph.observers) > 0
+	b.haveObservers = b.graph.wantsNodeNotifyLocked()
 	b.haveVectorIndex = b.graph.vectorIndex.HasAnyIndex()
 
 	// Execute all operations
@@ -38,11 +37,15 @@ func (b *Batch) Commit() error {
 			err = b.executeDeleteEdge(op)
 		}
 		if err != nil {
+			// Operations before the failing one stay applied.
+			b.graph.bumpVersion()
 			b.graph.mu.Unlock()
 			return err
 		}
 	}
 
+	// One bump for the whole batch, WAL or not (BulkImportMode has none).
+	b.graph.bumpVersion()
 	b.graph.mu.Unlock()
 
 	// Off-lock: apply the collected HNSW vector inserts + node-delete vector
@@ -103,7 +106,7 @@ func (b *Batch) executeCreateNode(op batchOp) error {
 
 	// Write to WAL for durability
 	if b.graph.hasWAL() {
-		nodeData, err := json.Marshal(node)
+		nodeData, err := b.graph.marshalWAL(node)
 		if err != nil {
 			return fmt.Errorf("failed to marshal node %d for WAL: %w", node.ID, err)
 		}
@@ -122,6 +125,22 @@ func (b *Batch) executeCreateEdge(op batchOp) error {
 	if err := validateEdgeWeight(op.weight); err != nil {
 		return err
 	}
+	// Endpoints must exist (created earlier in this batch or before it);
+	// without this check a batch could leave a dangling edge.
+	if err := b.graph.verifyNodeExists(op.fromNodeID, "source"); err != nil {
+		return err
+	}
+	if err := b.graph.verifyNodeExists(op.toNodeID, "target"); err != nil {
+		return err
+	}
+	// Earlier ops in this batch are already applied, so the duplicate check
+	// sees them. A dedupe hit creates nothing; the ID AddEdge reserved for
+	// this op stays unused.
+	if existing, err := b.graph.checkEdgePolicyLocked(DefaultTenantID, op.fromNodeID, op.toNodeID, op.edgeType); err != nil {
+		return err
+	} else if existing != nil {
+		return nil
+	}
 	edge := &Edge{
 		ID:         op.edgeID,
 		FromNodeID: op.fromNodeID,
@@ -148,10 +167,12 @@ func (b *Batch) executeCreateEdge(op batchOp) error {
 	// Maintain the per-tenant edge indexes (tenantEdgesByType + tenantEdgeIDs
 	// + tenant stats), mirroring persistEdgeLocked -> addEdgeToTenantIndex.
 	b.graph.addEdgeToTenantIndex(edge)
+	b.graph.indexEdgePropertiesLocked(edge)
+	b.graph.publishChangeLocked(ChangeCreate, EntityEdge, edge.ID, edge.TenantID)
 
 	// Write to WAL for durability
 	if b.graph.hasWAL() {
-		edgeData, err := json.Marshal(edge)
+		edgeData, err := b.graph.marshalWAL(edge)
 		if err != nil {
 			return fmt.Errorf("failed to marshal edge %d for WAL: %w", edge.ID, err)
 		}
@@ -169,7 +190,7 @@ func (b *Batch) executeUpdateNode(op batchOp) error {
 	node, exists := b.graph.materializeNodeLocked(op.nodeID)
 	b.graph.unlockShard(op.nodeID)
 	if !exists {
-		return fmt.Errorf("node %d not found", op.nodeID)
+		return fmt.Errorf("node %d: %w", op.nodeID, ErrNodeNotFound)
 	}
 
 	// Pre-mutation snapshot for the observer (must be captured before the merge).
@@ -192,7 +213,7 @@ func (b *Batch) executeUpdateNode(op batchOp) error {
 
 	// Update properties
 	for key, value := range op.properties {
-		node.Properties[key] = value
+		node.Properties[key] = b.graph.interner.value(value)
 	}
 
 	// Re-index
@@ -218,10 +239,7 @@ func (b *Batch) executeUpdateNode(op batchOp) error {
 
 	// Write to WAL for durability
 	if b.graph.hasWAL() {
-		updateData, err := json.Marshal(struct {
-			NodeID     uint64
-			Properties map[string]Value
-		}{
+		updateData, err := b.graph.marshalWAL(nodePropertiesUpdate{
 			NodeID:     op.nodeID,
 			Properties: op.properties,
 		})
@@ -280,6 +298,8 @@ func (b *Batch) executeDeleteNode(op batchOp) error {
 	for _, edgeID := range outgoing {
 		if edge, ok := b.graph.resolveEdgeRefLocked(edgeID); ok {
 			b.graph.removeEdgeFromTenantIndex(edge)
+			b.graph.unindexEdgePropertiesLocked(edge)
+			b.graph.publishChangeLocked(ChangeDelete, EntityEdge, edge.ID, edge.TenantID)
 			removeFromLabelIndexKeepEmpty(b.graph.edgesByType, edge.Type, edgeID)
 			// a->X: drop the edge from X's incoming adjacency overlay.
 			b.graph.incomingEdges[edge.ToNodeID] = removeEdgeFromList(b.graph.incomingEdges[edge.ToNodeID], edgeID)
@@ -293,6 +313,8 @@ func (b *Batch) executeDeleteNode(op batchOp) error {
 	for _, edgeID := range incoming {
 		if edge, ok := b.graph.resolveEdgeRefLocked(edgeID); ok {
 			b.graph.removeEdgeFromTenantIndex(edge)
+			b.graph.unindexEdgePropertiesLocked(edge)
+			b.graph.publishChangeLocked(ChangeDelete, EntityEdge, edge.ID, edge.TenantID)
 			removeFromLabelIndexKeepEmpty(b.graph.edgesByType, edge.Type, edgeID)
 			// Y->a: drop the edge from Y's outgoing adjacency.
 			b.graph.outgoingEdges[edge.FromNodeID] = removeEdgeFromList(b.graph.outgoingEdges[edge.FromNodeID], edgeID)
@@ -325,7 +347,7 @@ func (b *Batch) executeDeleteNode(op batchOp) error {
 
 	// Write to WAL for durability
 	if b.graph.hasWAL() {
-		nodeData, err := json.Marshal(node)
+		nodeData, err := b.graph.marshalWAL(node)
 		if err != nil {
 			return fmt.Errorf("failed to marshal node deletion %d for WAL: %w", op.nodeID, err)
 		}
@@ -351,6 +373,8 @@ func (b *Batch) executeDeleteEdge(op batchOp) error {
 	// non-batch DeleteEdge — the #288 create-path gap on the delete side (CC6).
 	// Pure index maintenance; the OpDeleteEdge WAL write below is unchanged.
 	b.graph.removeEdgeFromTenantIndex(edge)
+	b.graph.unindexEdgePropertiesLocked(edge)
+	b.graph.publishChangeLocked(ChangeDelete, EntityEdge, edge.ID, edge.TenantID)
 
 	// Remove from adjacency lists
 	outgoing := b.graph.outgoingEdges[edge.FromNodeID]
@@ -376,7 +400,7 @@ func (b *Batch) executeDeleteEdge(op batchOp) error {
 
 	// Write to WAL for durability
 	if b.graph.hasWAL() {
-		edgeData, err := json.Marshal(edge)
+		edgeData, err := b.graph.marshalWAL(edge)
 		if err != nil {
 			return fmt.Errorf("failed to marshal edge deletion %d for WAL: %w", op.edgeID, err)
 		}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetNodes(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"N"}, map[string]Value{"name": StringValue("a")})
+	b := testNode(t, gs, []string{"N"}, map[string]Value{"name": StringValue("b")})
+	other, err := gs.CreateNodeWithTenant("other", []string{"N"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const missing = 9999
+
+	ids := []uint64{b.ID, missing, a.ID, b.ID, other.ID}
+	nodes, err := gs.GetNodes(ids)
+	if err != nil {
+		t.Fatalf("GetNodes: %v", err)
+	}
+	wantIDs := []uint64{b.ID, 0, a.ID, b.ID, other.ID}
+	for i, n := range nodes {
+		if got := nodeIDOrZero(n); got != wantIDs[i] {
+			t.Errorf("nodes[%d] = %d, want %d", i, got, wantIDs[i])
+		}
+	}
+
+	// Scoped to the default tenant, the other tenant's node reads as missing.
+	nodes, err = gs.GetNodesForTenant(ids, "")
+	if err != nil {
+		t.Fatalf("GetNodesForTenant: %v", err)
+	}
+	if nodes[4] != nil || nodeIDOrZero(nodes[0]) != b.ID {
+		t.Errorf("GetNodesForTenant = %v, want other tenant's node nil", nodes)
+	}
+
+	// The results are copies: mutating one leaves the stored node alone.
+	nodes[0].Properties["name"] = StringValue("changed")
+	n, err := gs.GetNode(b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := n.Properties["name"].AsString(); name != "b" {
+		t.Errorf("stored node renamed to %q through a batch result", name)
+	}
+
+	if _, err := gs.GetNodes(ids, FailOnMissing()); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("FailOnMissing: err = %v, want ErrNodeNotFound", err)
+	}
+	if _, err := gs.GetNodesForTenant([]uint64{a.ID, other.ID}, "", FailOnMissing()); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("FailOnMissing cross-tenant: err = %v, want ErrNodeNotFound", err)
+	}
+	if nodes, err := gs.GetNodes(nil); err != nil || len(nodes) != 0 {
+		t.Errorf("GetNodes(nil) = %v, %v; want empty", nodes, err)
+	}
+}
+
+func TestGetEdges(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"N"}, nil)
+	b := testNode(t, gs, []string{"N"}, nil)
+	e1 := testEdge(t, gs, a.ID, b.ID, "R", nil, 1)
+	e2 := testEdge(t, gs, b.ID, a.ID, "R", nil, 2)
+
+	edges, err := gs.GetEdges([]uint64{e2.ID, 9999, e1.ID})
+	if err != nil {
+		t.Fatalf("GetEdges: %v", err)
+	}
+	if len(edges) != 3 || edges[0].ID != e2.ID || edges[1] != nil || edges[2].ID != e1.ID {
+		t.Errorf("GetEdges = %v, want [e2 nil e1]", edges)
+	}
+
+	if _, err := gs.GetEdgesForTenant([]uint64{e1.ID}, "other", FailOnMissing()); !errors.Is(err, ErrEdgeNotFound) {
+		t.Errorf("FailOnMissing cross-tenant: err = %v, want ErrEdgeNotFound", err)
+	}
+}
+
+func nodeIDOrZero(n *Node) uint64 {
+	if n == nil {
+		return 0
+	}
+	return n.ID
+}
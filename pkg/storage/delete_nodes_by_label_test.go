@@ -0,0 +1,47 @@
+package storage
+
+import "testing"
+
+func TestDeleteNodesByLabel(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	var workstations []*Node
+	for i := 0; i < 3; i++ {
+		workstations = append(workstations, testNode(t, gs, []string{"Workstation"}, nil))
+	}
+	server := testNode(t, gs, []string{"Server"}, nil)
+	testEdge(t, gs, workstations[0].ID, server.ID, "CONNECTS", nil, 1)
+	testEdge(t, gs, server.ID, workstations[1].ID, "CONNECTS", nil, 1)
+	other, err := gs.CreateNodeWithTenant("other", []string{"Workstation"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := gs.DeleteNodesByLabel("Workstation")
+	if err != nil {
+		t.Fatalf("DeleteNodesByLabel: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("deleted %d nodes, want 3", n)
+	}
+	if c := gs.CountNodesByLabelForTenant(DefaultTenantID, "Workstation"); c != 0 {
+		t.Errorf("%d Workstation nodes left, want 0", c)
+	}
+	if _, err := gs.GetNode(server.ID); err != nil {
+		t.Errorf("Server node was removed: %v", err)
+	}
+	if c := gs.CountEdgesForTenant(DefaultTenantID); c != 0 {
+		t.Errorf("%d edges left, want their endpoints' deletes to remove them", c)
+	}
+	if _, err := gs.GetNodeForTenant(other.ID, "other"); err != nil {
+		t.Errorf("another tenant's Workstation was removed: %v", err)
+	}
+
+	// Scoped to the other tenant; a second pass finds nothing.
+	if n, err := gs.DeleteNodesByLabelForTenant("other", "Workstation"); err != nil || n != 1 {
+		t.Errorf("DeleteNodesByLabelForTenant = %d, %v; want 1", n, err)
+	}
+	if n, err := gs.DeleteNodesByLabel("Workstation"); err != nil || n != 0 {
+		t.Errorf("second DeleteNodesByLabel = %d, %v; want 0", n, err)
+	}
+}
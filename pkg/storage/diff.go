@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// GraphDiff is the structural difference between two graphs, as computed by
+// Diff: what must be added to, removed from or changed in A to get B. Every
+// list is sorted by Key.
+type GraphDiff struct {
+	AddedNodes   []*Node // in B only
+	RemovedNodes []*Node // in A only
+	ChangedNodes []NodeChange
+	AddedEdges   []*Edge // in B only
+	RemovedEdges []*Edge // in A only
+	ChangedEdges []EdgeChange
+}
+
+// NodeChange is a node present in both graphs whose labels or properties
+// differ.
+type NodeChange struct {
+	Key               string
+	Before, After     *Node
+	LabelsChanged     bool
+	ChangedProperties []string // added, removed or modified keys, sorted
+}
+
+// EdgeChange is an edge present in both graphs whose weight or properties
+// differ.
+type EdgeChange struct {
+	Key               string
+	Before, After     *Edge
+	WeightChanged     bool
+	ChangedProperties []string // added, removed or modified keys, sorted
+}
+
+// DiffCounts summarizes one half (nodes or edges) of a GraphDiff.
+type DiffCounts struct {
+	Added, Removed, Changed int
+}
+
+// NodeCounts returns the number of added, removed and changed nodes.
+func (d *GraphDiff) NodeCounts() DiffCounts {
+	return DiffCounts{len(d.AddedNodes), len(d.RemovedNodes), len(d.ChangedNodes)}
+}
+
+// EdgeCounts returns the number of added, removed and changed edges.
+func (d *GraphDiff) EdgeCounts() DiffCounts {
+	return DiffCounts{len(d.AddedEdges), len(d.RemovedEdges), len(d.ChangedEdges)}
+}
+
+// Empty reports whether the two graphs were structurally identical.
+func (d *GraphDiff) Empty() bool {
+	return d.NodeCounts() == DiffCounts{} && d.EdgeCounts() == DiffCounts{}
+}
+
+// String summarizes the counts, e.g. "nodes +0 -3 ~1, edges +0 -7 ~0".
+func (d *GraphDiff) String() string {
+	n, e := d.NodeCounts(), d.EdgeCounts()
+	return fmt.Sprintf("nodes +%d -%d ~%d, edges +%d -%d ~%d",
+		n.Added, n.Removed, n.Changed, e.Added, e.Removed, e.Changed)
+}
+
+// Diff compares two graphs, matching nodes by their "name" property, or by
+// node ID for nodes without one. It suits "baseline vs degraded" models built
+// separately, where the same component gets a different ID in each graph.
+// See DiffByKey.
+func Diff(a, b *GraphStorage) (*GraphDiff, error) {
+	return DiffByKey(a, b, "name")
+}
+
+// DiffByKey compares two graphs, matching nodes by the string form of
+// property keyProperty (node ID when empty, or for nodes lacking the
+// property). Edges match by (from key, to key, type); parallel edges with the
+// same match key pair up in ID order. Nodes and edges are only ever matched
+// within the same tenant.
+//
+// Each graph is read through a consistent View. A key that appears on two
+// nodes of the same tenant cannot be matched and is an error.
+func DiffByKey(a, b *GraphStorage, keyProperty string) (*GraphDiff, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("diff: nil graph")
+	}
+	before, err := indexForDiff(a, keyProperty)
+	if err != nil {
+		return nil, fmt.Errorf("diff: graph A: %w", err)
+	}
+	after, err := indexForDiff(b, keyProperty)
+	if err != nil {
+		return nil, fmt.Errorf("diff: graph B: %w", err)
+	}
+
+	d := &GraphDiff{}
+	for _, k := range sortedDiffKeys(before.nodes, after.nodes) {
+		was, wasOK := before.nodes[k]
+		now, nowOK := after.nodes[k]
+		switch {
+		case !nowOK:
+			d.RemovedNodes = append(d.RemovedNodes, was)
+		case !wasOK:
+			d.AddedNodes = append(d.AddedNodes, now)
+		default:
+			labelsChanged := !sameLabels(was.Labels, now.Labels)
+			props := changedProperties(was.Properties, now.Properties)
+			if labelsChanged || len(props) > 0 {
+				d.ChangedNodes = append(d.ChangedNodes, NodeChange{
+					Key: k.key, Before: was, After: now,
+					LabelsChanged: labelsChanged, ChangedProperties: props,
+				})
+			}
+		}
+	}
+	for _, k := range sortedDiffKeys(before.edges, after.edges) {
+		was, wasOK := before.edges[k]
+		now, nowOK := after.edges[k]
+		switch {
+		case !nowOK:
+			d.RemovedEdges = append(d.RemovedEdges, was)
+		case !wasOK:
+			d.AddedEdges = append(d.AddedEdges, now)
+		default:
+			weightChanged := was.Weight != now.Weight
+			props := changedProperties(was.Properties, now.Properties)
+			if weightChanged || len(props) > 0 {
+				d.ChangedEdges = append(d.ChangedEdges, EdgeChange{
+					Key: k.key, Before: was, After: now,
+					WeightChanged: weightChanged, ChangedProperties: props,
+				})
+			}
+		}
+	}
+	return d, nil
+}
+
+// diffKey identifies a node or edge across the two graphs.
+type diffKey struct {
+	tenant string
+	key    string
+}
+
+// diffIndex is one graph's nodes and edges keyed for matching.
+type diffIndex struct {
+	nodes map[diffKey]*Node
+	edges map[diffKey]*Edge
+}
+
+func indexForDiff(g *GraphStorage, keyProperty string) (*diffIndex, error) {
+	view, err := g.View()
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &diffIndex{nodes: make(map[diffKey]*Node), edges: make(map[diffKey]*Edge)}
+	nodeKeys := make(map[uint64]string)
+	for _, n := range view.GetAllNodesAcrossTenants() {
+		key := "#" + strconv.FormatUint(n.ID, 10)
+		if v, ok := n.Properties[keyProperty]; ok && keyProperty != "" {
+			key = v.String()
+		}
+		k := diffKey{effectiveTenantID(n.TenantID).String(), key}
+		if _, dup := idx.nodes[k]; dup {
+			return nil, fmt.Errorf("duplicate node key %q in tenant %s", key, k.tenant)
+		}
+		idx.nodes[k] = n
+		nodeKeys[n.ID] = key
+	}
+
+	// Parallel edges share (from, to, type); number them in ID order so
+	// the i-th in A pairs with the i-th in B.
+	seen := make(map[diffKey]int)
+	for _, id := range view.GetAllNodeIDs() {
+		edges, _ := view.GetOutgoingEdges(id)
+		sort.Slice(edges, func(i, j int) bool { return edges[i].ID < edges[j].ID })
+		for _, e := range edges {
+			base := diffKey{
+				effectiveTenantID(e.TenantID).String(),
+				nodeKeys[e.FromNodeID] + " -[" + e.Type + "]-> " + nodeKeys[e.ToNodeID],
+			}
+			if e.Undirected {
+				// Listed from both endpoints; keep the copy whose endpoint
+				// keys are in order so the key is the same in A and B.
+				if nodeKeys[e.FromNodeID] > nodeKeys[e.ToNodeID] {
+					continue
+				}
+				base.key = nodeKeys[e.FromNodeID] + " -[" + e.Type + "]- " + nodeKeys[e.ToNodeID]
+			}
+			k := base
+			if n := seen[base]; n > 0 {
+				k.key += " #" + strconv.Itoa(n+1)
+			}
+			seen[base]++
+			idx.edges[k] = e
+		}
+	}
+	return idx, nil
+}
+
+// sortedDiffKeys returns the union of both maps' keys, ordered by key then
+// tenant.
+func sortedDiffKeys[V any](a, b map[diffKey]V) []diffKey {
+	keys := make([]diffKey, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].key != keys[j].key {
+			return keys[i].key < keys[j].key
+		}
+		return keys[i].tenant < keys[j].tenant
+	})
+	return keys
+}
+
+// sameLabels compares label sets, ignoring order.
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x, y := slices.Clone(a), slices.Clone(b)
+	slices.Sort(x)
+	slices.Sort(y)
+	return slices.Equal(x, y)
+}
+
+// changedProperties returns the sorted keys whose presence, type or value
+// differs between a and b.
+func changedProperties(a, b map[string]Value) []string {
+	var changed []string
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || va.Type != vb.Type || string(va.Data) != string(vb.Data) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+)
+
+// buildDiffGraph builds the same small network in a fresh store; nodes are
+// created in the given order so IDs differ between graphs built with
+// different orders.
+func buildDiffGraph(t *testing.T, order []string, skip map[string]bool) (*GraphStorage, map[string]uint64) {
+	t.Helper()
+	gs := testGraphStorage(t)
+	ids := make(map[string]uint64)
+	for _, name := range order {
+		if skip[name] {
+			continue
+		}
+		n := testNode(t, gs, []string{"Substation"}, map[string]Value{
+			"name": StringValue(name), "load": IntValue(10),
+		})
+		ids[name] = n.ID
+	}
+	link := func(from, to string) {
+		if _, ok := ids[from]; !ok {
+			return
+		}
+		if _, ok := ids[to]; !ok {
+			return
+		}
+		testEdge(t, gs, ids[from], ids[to], "FEEDS", nil, 1)
+	}
+	link("gen", "sub1")
+	link("gen", "sub2")
+	link("sub1", "load")
+	link("sub2", "load")
+	return gs, ids
+}
+
+func diffNodeKeys(nodes []*Node) []string {
+	var keys []string
+	for _, n := range nodes {
+		s, _ := n.Properties["name"].AsString()
+		keys = append(keys, s)
+	}
+	return keys
+}
+
+// TestDiff_MatchesByNameAcrossIDs: identical content built in a different
+// order (so different IDs) diffs empty; removing a node reports it and its
+// edges.
+func TestDiff_MatchesByNameAcrossIDs(t *testing.T) {
+	baseline, _ := buildDiffGraph(t, []string{"gen", "sub1", "sub2", "load"}, nil)
+	reordered, _ := buildDiffGraph(t, []string{"load", "sub2", "sub1", "gen"}, nil)
+
+	d, err := Diff(baseline, reordered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Empty() {
+		t.Errorf("reordered build: diff = %s, want empty", d)
+	}
+
+	degraded, _ := buildDiffGraph(t, []string{"gen", "sub1", "sub2", "load"}, map[string]bool{"sub1": true})
+	d, err = Diff(baseline, degraded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.String(); got != "nodes +0 -1 ~0, edges +0 -2 ~0" {
+		t.Errorf("degraded diff = %s", got)
+	}
+	if got := diffNodeKeys(d.RemovedNodes); !slices.Equal(got, []string{"sub1"}) {
+		t.Errorf("RemovedNodes = %v, want [sub1]", got)
+	}
+
+	// Reverse direction reports the same facts as additions.
+	d, err = Diff(degraded, baseline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, e := d.NodeCounts(), d.EdgeCounts(); n != (DiffCounts{Added: 1}) || e != (DiffCounts{Added: 2}) {
+		t.Errorf("reverse diff = %s", d)
+	}
+}
+
+func TestDiff_Changes(t *testing.T) {
+	a, _ := buildDiffGraph(t, []string{"gen", "sub1", "sub2", "load"}, nil)
+	b, ids := buildDiffGraph(t, []string{"gen", "sub1", "sub2", "load"}, nil)
+
+	if err := b.UpdateNode(ids["sub2"], map[string]Value{"load": IntValue(0), "tripped": BoolValue(true)}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := b.GetOutgoingEdges(ids["gen"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range out {
+		if e.ToNodeID == ids["sub1"] {
+			w := 0.5
+			if err := b.UpdateEdge(e.ID, nil, &w); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	d, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.ChangedNodes) != 1 {
+		t.Fatalf("ChangedNodes = %+v, want one", d.ChangedNodes)
+	}
+	c := d.ChangedNodes[0]
+	if c.Key != "sub2" || c.LabelsChanged || !slices.Equal(c.ChangedProperties, []string{"load", "tripped"}) {
+		t.Errorf("node change = key %q labels %v props %v", c.Key, c.LabelsChanged, c.ChangedProperties)
+	}
+	if len(d.ChangedEdges) != 1 {
+		t.Fatalf("ChangedEdges = %+v, want one", d.ChangedEdges)
+	}
+	ec := d.ChangedEdges[0]
+	if ec.Key != "gen -[FEEDS]-> sub1" || !ec.WeightChanged || len(ec.ChangedProperties) != 0 {
+		t.Errorf("edge change = %+v", ec)
+	}
+	if ec.Before.Weight != 1 || ec.After.Weight != 0.5 {
+		t.Errorf("edge weights before/after = %v/%v", ec.Before.Weight, ec.After.Weight)
+	}
+}
+
+// TestDiffByKey_NodeID: with no key property, nodes match by ID, and
+// parallel edges pair up one-to-one.
+func TestDiffByKey_NodeID(t *testing.T) {
+	a := testGraphStorage(t)
+	b := testGraphStorage(t)
+	for _, g := range []*GraphStorage{a, b} {
+		x := testNode(t, g, []string{"X"}, nil)
+		y := testNode(t, g, []string{"Y"}, nil)
+		testEdge(t, g, x.ID, y.ID, "LINK", nil, 1)
+	}
+	testEdge(t, b, 1, 2, "LINK", nil, 1) // parallel edge only in B
+
+	d, err := DiffByKey(a, b, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.String(); got != "nodes +0 -0 ~0, edges +1 -0 ~0" {
+		t.Errorf("diff = %s", got)
+	}
+	if d.AddedEdges[0].ID != 2 {
+		t.Errorf("added edge ID = %d, want the second parallel edge (2)", d.AddedEdges[0].ID)
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	a := testGraphStorage(t)
+	testNode(t, a, []string{"X"}, map[string]Value{"name": StringValue("dup")})
+	testNode(t, a, []string{"X"}, map[string]Value{"name": StringValue("dup")})
+	b := testGraphStorage(t)
+
+	if _, err := Diff(a, b); err == nil {
+		t.Error("duplicate key: want error")
+	}
+	if _, err := Diff(nil, b); err == nil {
+		t.Error("nil graph: want error")
+	}
+}
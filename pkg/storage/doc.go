@@ -14,6 +14,28 @@
 // [ErrNodeNotFound] / [ErrEdgeNotFound] — the same error as a genuinely missing
 // entity — so a tenant cannot probe another tenant's data via error shape.
 //
+// # Concurrency
+//
+// A [GraphStorage] is safe for concurrent use by multiple goroutines. Reads
+// take the global read lock (or a per-shard read lock); mutations take the
+// global write lock. Nodes and edges returned by getters are clones owned by
+// the caller, and the create paths copy the caller's label slice and property
+// map, so neither side can mutate the other's state after the call returns.
+// [Statistics] counters are maintained with atomics and never block writers.
+// Long whole-graph reads that must not observe concurrent writes should run
+// against a [GraphView] from [GraphStorage.View].
+//
+// # Errors
+//
+// Failures wrap a sentinel from errors.go with %w, so callers classify them
+// with errors.Is (or the [IsNotFound], [IsInvalid], [IsConflict] and
+// [IsClosed] helpers) instead of matching message text: not found
+// ([ErrNodeNotFound], [ErrIndexNotFound], ...), invalid input
+// ([ErrInvalidArgument], [ErrInvalidProperty], ...), conflict with existing
+// state ([ErrUniqueConstraintViolation], [ErrIndexExists], ...) and
+// [ErrStorageClosed]. Anything else is an internal failure. The API layer
+// maps these classes to HTTP status codes.
+//
 // # Internals
 //
 // Nodes and edges live in 256-way partitioned shard maps with per-shard read
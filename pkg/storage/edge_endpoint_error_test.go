@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCreateEdge_MissingEndpoint pins that every edge-create path reports a
+// missing endpoint as an *EdgeEndpointError naming the endpoint, matches
+// ErrNodeNotFound, and leaves no trace of the edge behind.
+func TestCreateEdge_MissingEndpoint(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"N"}, nil)
+	other, err := gs.CreateNodeWithTenant("other", []string{"N"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const missing = 9999
+
+	tx := func(from, to uint64) error {
+		tx, err := gs.BeginTransaction()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.CreateEdge(from, to, "E", nil, 1); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+	batch := func(from, to uint64) error {
+		b := gs.BeginBatch()
+		if _, err := b.AddEdge(from, to, "E", nil, 1); err != nil {
+			t.Fatal(err)
+		}
+		return b.Commit()
+	}
+	create := func(from, to uint64) error {
+		_, err := gs.CreateEdge(from, to, "E", nil, 1)
+		return err
+	}
+	createForTenant := func(from, to uint64) error {
+		_, err := gs.CreateEdgeWithTenant(DefaultTenantID, from, to, "E", nil, 1)
+		return err
+	}
+	upsert := func(from, to uint64) error {
+		_, _, err := gs.UpsertEdge(from, to, "E", nil, 1)
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		create   func(from, to uint64) error
+		from, to uint64
+		endpoint string
+		nodeID   uint64
+	}{
+		{"CreateEdge source", create, missing, a.ID, "source", missing},
+		{"CreateEdge target", create, a.ID, missing, "target", missing},
+		{"CreateEdgeWithTenant cross-tenant target", createForTenant, a.ID, other.ID, "target", other.ID},
+		{"UpsertEdge source", upsert, missing, a.ID, "source", missing},
+		{"Transaction target", tx, a.ID, missing, "target", missing},
+		{"Batch source", batch, missing, a.ID, "source", missing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.create(tt.from, tt.to)
+			if !errors.Is(err, ErrNodeNotFound) {
+				t.Fatalf("err = %v, want ErrNodeNotFound", err)
+			}
+			var endpointErr *EdgeEndpointError
+			if !errors.As(err, &endpointErr) || endpointErr.Endpoint != tt.endpoint || endpointErr.NodeID != tt.nodeID {
+				t.Errorf("err = %v, want %s node %d", err, tt.endpoint, tt.nodeID)
+			}
+		})
+	}
+
+	if got := gs.GetStatistics().EdgeCount; got != 0 {
+		t.Errorf("EdgeCount = %d after failed creates, want 0", got)
+	}
+	if out, _ := gs.GetOutgoingEdges(a.ID); len(out) != 0 {
+		t.Errorf("node %d has %d outgoing edges after failed creates", a.ID, len(out))
+	}
+	if in, _ := gs.GetIncomingEdges(a.ID); len(in) != 0 {
+		t.Errorf("node %d has %d incoming edges after failed creates", a.ID, len(in))
+	}
+}
@@ -33,7 +33,7 @@ func (gs *GraphStorage) CreateEdge(fromID, toID uint64, edgeType string, propert
 		return nil, err
 	}
 
-	edge, p, err := gs.createEdgeWithTenantNoVerify(DefaultTenantID, fromID, toID, edgeType, properties, weight)
+	edge, p, err := gs.createEdgeWithTenantNoVerify(DefaultTenantID, fromID, toID, edgeType, properties, weight, false)
 	walPending = p
 	return edge, err
 }
@@ -63,17 +63,67 @@ func (gs *GraphStorage) CreateEdgeWithTenant(tenantID string, fromID, toID uint6
 		return nil, err
 	}
 
-	edge, p, err := gs.createEdgeWithTenantNoVerify(tenantID, fromID, toID, edgeType, properties, weight)
+	edge, p, err := gs.createEdgeWithTenantNoVerify(tenantID, fromID, toID, edgeType, properties, weight, false)
 	walPending = p
 	return edge, err
 }
 
-// EdgeSpec describes one edge for bulk creation.
+// CreateUndirectedEdge creates an undirected edge between two nodes in the
+// default tenant. It is stored, counted and WAL-logged once, like any edge,
+// but adjacency reads treat it symmetrically: it is returned by
+// GetOutgoingEdges and GetIncomingEdges of both endpoints (oriented away from
+// or towards the node asked about), so traversals and algorithms can cross it
+// either way. It replaces the pattern of creating a directed edge in each
+// direction, which made EdgeCount twice the number of links.
+//
+// Tenant-blind on node verification, like CreateEdge.
+func (gs *GraphStorage) CreateUndirectedEdge(fromID, toID uint64, edgeType string, properties map[string]Value, weight float64) (*Edge, error) {
+	gs.mu.Lock()
+	// Deferred WAL wait runs after gs.mu.Unlock (LIFO). See CreateEdge.
+	var walPending *wal.Pending
+	defer func() { gs.waitWALPending(wal.OpCreateEdge, walPending) }()
+	defer gs.mu.Unlock()
+
+	if err := gs.verifyNodeExists(fromID, "source"); err != nil {
+		return nil, err
+	}
+	if err := gs.verifyNodeExists(toID, "target"); err != nil {
+		return nil, err
+	}
+
+	edge, p, err := gs.createEdgeWithTenantNoVerify(DefaultTenantID, fromID, toID, edgeType, properties, weight, true)
+	walPending = p
+	return edge, err
+}
+
+// CreateUndirectedEdgeWithTenant is CreateUndirectedEdge for a specific
+// tenant, with the same endpoint ownership check as CreateEdgeWithTenant.
+func (gs *GraphStorage) CreateUndirectedEdgeWithTenant(tenantID string, fromID, toID uint64, edgeType string, properties map[string]Value, weight float64) (*Edge, error) {
+	gs.mu.Lock()
+	var walPending *wal.Pending
+	defer func() { gs.waitWALPending(wal.OpCreateEdge, walPending) }()
+	defer gs.mu.Unlock()
+
+	if err := gs.verifyNodeExistsForTenant(fromID, "source", tenantID); err != nil {
+		return nil, err
+	}
+	if err := gs.verifyNodeExistsForTenant(toID, "target", tenantID); err != nil {
+		return nil, err
+	}
+
+	edge, p, err := gs.createEdgeWithTenantNoVerify(tenantID, fromID, toID, edgeType, properties, weight, true)
+	walPending = p
+	return edge, err
+}
+
+// EdgeSpec describes one edge for bulk creation. Undirected creates it as
+// CreateUndirectedEdge would.
 type EdgeSpec struct {
 	FromID, ToID uint64
 	Type         string
 	Properties   map[string]Value
 	Weight       float64
+	Undirected   bool
 }
 
 // CreateEdgesWithTenant creates many edges under one acquisition of gs.mu,
@@ -101,7 +151,7 @@ func (gs *GraphStorage) CreateEdgesWithTenant(tenantID string, specs []EdgeSpec)
 			gs.flushEdgeBatchWAL(pendings)
 			return ids, err
 		}
-		edge, p, err := gs.createEdgeWithTenantNoVerify(tenantID, s.FromID, s.ToID, s.Type, s.Properties, s.Weight)
+		edge, p, err := gs.createEdgeWithTenantNoVerify(tenantID, s.FromID, s.ToID, s.Type, s.Properties, s.Weight, s.Undirected)
 		if err != nil {
 			gs.mu.Unlock()
 			gs.flushEdgeBatchWAL(pendings)
@@ -134,8 +184,28 @@ func (gs *GraphStorage) flushEdgeBatchWAL(pendings []*wal.Pending) {
 // CreateEdge calls this directly (tenant-blind) for replication and
 // other legitimately tenant-blind paths; CreateEdgeWithTenant runs
 // the tenant-strict node check first.
-func (gs *GraphStorage) createEdgeWithTenantNoVerify(tenantID string, fromID, toID uint64, edgeType string, properties map[string]Value, weight float64) (*Edge, *wal.Pending, error) {
-	edge, walPending, err := gs.createEdgeLocked(tenantID, fromID, toID, edgeType, properties, weight)
+//
+// The edge policy (RejectSelfLoops, MultiEdges) is applied here, so every
+// direct create path honors it; under MultiEdgesDedupe a matching edge is
+// returned with a nil WAL handle and nothing is created. An undirected edge
+// is checked in both orientations.
+func (gs *GraphStorage) createEdgeWithTenantNoVerify(tenantID string, fromID, toID uint64, edgeType string, properties map[string]Value, weight float64, undirected bool) (*Edge, *wal.Pending, error) {
+	if existing, err := gs.checkEdgePolicyLocked(tenantID, fromID, toID, edgeType); err != nil {
+		return nil, nil, err
+	} else if existing != nil {
+		return existing.Clone(), nil, nil
+	}
+	if undirected {
+		if existing, err := gs.checkEdgePolicyLocked(tenantID, toID, fromID, edgeType); err != nil {
+			return nil, nil, err
+		} else if existing != nil {
+			return existing.Clone(), nil, nil
+		}
+	}
+
+	start := time.Now()
+	edge, walPending, err := gs.createEdgeLocked(tenantID, fromID, toID, edgeType, properties, weight, undirected)
+	gs.trackOperation(OpCreateEdge, start, &err)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -189,7 +259,8 @@ func (gs *GraphStorage) getEdgeRefForTenant(edgeID uint64, tenantID string) (*Ed
 // DeleteEdge deletes an edge by ID.
 //
 // Tenant-blind. New callers should prefer DeleteEdgeForTenant.
-func (gs *GraphStorage) DeleteEdge(edgeID uint64) error {
+func (gs *GraphStorage) DeleteEdge(edgeID uint64) (err error) {
+	defer gs.trackOperation(OpDeleteEdge, time.Now(), &err)
 	gs.mu.Lock()
 	// Deferred WAL wait runs after gs.mu.Unlock (LIFO) — group commit, Track P
 	// item 1. nil handle (not-found path) => no-op wait.
@@ -204,7 +275,7 @@ func (gs *GraphStorage) DeleteEdge(edgeID uint64) error {
 	edge, exists := gs.resolveEdgeRefLocked(edgeID)
 	if !exists {
 		gs.unlockShard(edgeID)
-		return fmt.Errorf("edge %d not found", edgeID)
+		return fmt.Errorf("edge %d: %w", edgeID, ErrEdgeNotFound)
 	}
 	fromID := edge.FromNodeID
 	toID := edge.ToNodeID
@@ -217,6 +288,8 @@ func (gs *GraphStorage) DeleteEdge(edgeID uint64) error {
 
 	// Remove from tenant-scoped indexes
 	gs.removeEdgeFromTenantIndex(edge)
+	gs.unindexEdgePropertiesLocked(edge)
+	gs.publishChangeLocked(ChangeDelete, EntityEdge, edge.ID, edge.TenantID)
 
 	// Remove from adjacency (disk-backed or in-memory)
 	if err := gs.removeOutgoingEdge(fromID, edgeID); err != nil {
@@ -229,6 +302,8 @@ func (gs *GraphStorage) DeleteEdge(edgeID uint64) error {
 	// Atomic decrement with underflow protection
 	atomicDecrementWithUnderflowProtection(&gs.stats.EdgeCount)
 
+	gs.bumpVersion()
+
 	// Enqueue under gs.mu; the deferred wait above blocks off-lock.
 	walPending = gs.enqueueWAL(wal.OpDeleteEdge, edge)
 
@@ -296,10 +371,38 @@ func (gs *GraphStorage) UpdateEdgeForTenant(edgeID uint64, properties map[string
 	return gs.UpdateEdge(edgeID, properties, weight)
 }
 
+// UpdateEdgeForTenantIfRevision is UpdateEdgeForTenant applied only if the
+// edge is still at the given revision (see Edge.Revision); otherwise it
+// returns ErrConflict and changes nothing.
+func (gs *GraphStorage) UpdateEdgeForTenantIfRevision(edgeID uint64, properties map[string]Value, weight *float64, tenantID string, revision uint64) error {
+	gs.rlockShard(edgeID)
+	if _, err := gs.getEdgeRefForTenant(edgeID, tenantID); err != nil {
+		gs.runlockShard(edgeID)
+		return err
+	}
+	gs.runlockShard(edgeID)
+	return gs.updateEdge(edgeID, properties, weight, &revision)
+}
+
+// UpdateEdgeIfRevision is UpdateEdge applied only if the edge is still at
+// the given revision; otherwise it returns ErrConflict. See
+// UpdateNodeIfRevision.
+//
+// Tenant-blind. New callers should prefer UpdateEdgeForTenantIfRevision.
+func (gs *GraphStorage) UpdateEdgeIfRevision(edgeID uint64, properties map[string]Value, weight *float64, revision uint64) error {
+	return gs.updateEdge(edgeID, properties, weight, &revision)
+}
+
 // UpdateEdge updates an edge's properties and/or weight.
 //
 // Tenant-blind. New callers should prefer UpdateEdgeForTenant.
 func (gs *GraphStorage) UpdateEdge(edgeID uint64, properties map[string]Value, weight *float64) error {
+	return gs.updateEdge(edgeID, properties, weight, nil)
+}
+
+// updateEdge implements UpdateEdge; a non-nil revision makes it conditional.
+func (gs *GraphStorage) updateEdge(edgeID uint64, properties map[string]Value, weight *float64, revision *uint64) (err error) {
+	defer gs.trackOperation(OpUpdateEdge, time.Now(), &err)
 	// Reject a non-finite new weight before taking any lock (#328) — an
 	// Inf/NaN weight can't be WAL-marshaled. nil weight = leave unchanged.
 	if weight != nil {
@@ -328,17 +431,28 @@ func (gs *GraphStorage) UpdateEdge(edgeID uint64, properties map[string]Value, w
 	if !exists {
 		return ErrEdgeNotFound
 	}
+	if revision != nil && edge.Revision() != *revision {
+		return ErrConflict
+	}
 
 	// Update properties (merge with existing)
+	gs.unindexEdgePropertiesLocked(edge)
+	if edge.Properties == nil {
+		edge.Properties = make(map[string]Value, len(properties))
+	}
 	for k, v := range properties {
-		edge.Properties[k] = v
+		edge.Properties[k] = gs.interner.value(v)
 	}
+	gs.indexEdgePropertiesLocked(edge)
+	gs.publishChangeLocked(ChangeUpdate, EntityEdge, edge.ID, edge.TenantID)
 
 	// Update weight if provided
 	if weight != nil {
 		edge.Weight = *weight
 	}
 
+	gs.bumpVersion()
+
 	// Enqueue under gs.mu; the deferred wait above blocks off-lock.
 	walPending = gs.enqueueWAL(wal.OpUpdateEdge, edge)
 
@@ -347,10 +461,10 @@ func (gs *GraphStorage) UpdateEdge(edgeID uint64, properties map[string]Value, w
 
 // createEdgeLocked is the internal edge creation logic that assumes the lock is already held.
 // This follows the DRY principle by extracting common logic used by both CreateEdge and UpsertEdge.
-func (gs *GraphStorage) createEdgeLocked(tenantID string, fromID, toID uint64, edgeType string, properties map[string]Value, weight float64) (*Edge, *wal.Pending, error) {
+func (gs *GraphStorage) createEdgeLocked(tenantID string, fromID, toID uint64, edgeType string, properties map[string]Value, weight float64, undirected bool) (*Edge, *wal.Pending, error) {
 	// Check for ID space exhaustion
 	if gs.nextEdgeID == ^uint64(0) {
-		return nil, nil, fmt.Errorf("edge ID space exhausted")
+		return nil, nil, fmt.Errorf("edge %w", ErrIDSpaceExhausted)
 	}
 
 	edgeID := gs.nextEdgeID
@@ -364,8 +478,9 @@ func (gs *GraphStorage) createEdgeLocked(tenantID string, fromID, toID uint64, e
 		FromNodeID: fromID,
 		ToNodeID:   toID,
 		Type:       edgeType,
-		Properties: properties,
+		Properties: copyProperties(properties),
 		Weight:     weight,
+		Undirected: undirected,
 		CreatedAt:  time.Now().Unix(),
 	}
 
@@ -377,6 +492,8 @@ func (gs *GraphStorage) createEdgeLocked(tenantID string, fromID, toID uint64, e
 		return nil, nil, err
 	}
 
+	gs.bumpVersion()
+
 	// Enqueue under gs.mu (preserves WAL order); the public caller waits on the
 	// returned handle after releasing gs.mu (group commit, Track P item 1).
 	walPending := gs.enqueueWAL(wal.OpCreateEdge, edge)
@@ -412,6 +529,7 @@ func (gs *GraphStorage) persistEdgeLocked(edge *Edge) error {
 
 	// Per-tenant indexes (type + enumeration set + stats).
 	gs.addEdgeToTenantIndex(edge)
+	gs.indexEdgePropertiesLocked(edge)
 
 	if err := gs.storeOutgoingEdge(edge.FromNodeID, edge.ID); err != nil {
 		return fmt.Errorf("failed to store outgoing edge: %w", err)
@@ -420,6 +538,8 @@ func (gs *GraphStorage) persistEdgeLocked(edge *Edge) error {
 		return fmt.Errorf("failed to store incoming edge: %w", err)
 	}
 
+	// Only announce the edge once it is reachable from both endpoints.
+	gs.publishChangeLocked(ChangeCreate, EntityEdge, edge.ID, edge.TenantID)
 	atomic.AddUint64(&gs.stats.EdgeCount, 1)
 	return nil
 }
@@ -600,20 +720,29 @@ func (gs *GraphStorage) upsertEdgeWithTenantNoVerify(tenantID string, fromID, to
 		edge, _ := gs.materializeEdgeLocked(existing.ID) // mmap mode: promote base edge
 
 		// Merge properties (new values override existing)
+		gs.unindexEdgePropertiesLocked(edge)
 		for k, v := range properties {
-			edge.Properties[k] = v
+			edge.Properties[k] = gs.interner.value(v)
 		}
+		gs.indexEdgePropertiesLocked(edge)
+		gs.publishChangeLocked(ChangeUpdate, EntityEdge, edge.ID, edge.TenantID)
 		edge.Weight = weight
 		gs.unlockShard(existing.ID)
 
+		gs.bumpVersion()
+
 		// Enqueue under gs.mu (preserves WAL order); caller waits off-lock.
 		walPending := gs.enqueueWAL(wal.OpUpdateEdge, edge)
 
 		return edge.Clone(), false, walPending, nil
 	}
 
-	// Create new edge using shared helper
-	edge, walPending, err := gs.createEdgeLocked(tenantID, fromID, toID, edgeType, properties, weight)
+	// Create new edge using shared helper. No duplicate can exist here, but
+	// a self-loop may still be refused.
+	if gs.rejectSelfLoops && fromID == toID {
+		return nil, false, nil, fmt.Errorf("%w: node %d", ErrSelfLoop, fromID)
+	}
+	edge, walPending, err := gs.createEdgeLocked(tenantID, fromID, toID, edgeType, properties, weight, false)
 	if err != nil {
 		return nil, false, nil, err
 	}
@@ -673,6 +802,8 @@ func (gs *GraphStorage) DeleteEdgeBetweenAcrossTenants(fromID, toID uint64, edge
 
 	// Remove from tenant-scoped indexes
 	gs.removeEdgeFromTenantIndex(edgeToDelete)
+	gs.unindexEdgePropertiesLocked(edgeToDelete)
+	gs.publishChangeLocked(ChangeDelete, EntityEdge, edgeToDelete.ID, edgeToDelete.TenantID)
 
 	// Remove from adjacency
 	if err := gs.removeOutgoingEdge(fromID, edgeToDelete.ID); err != nil {
@@ -683,6 +814,8 @@ func (gs *GraphStorage) DeleteEdgeBetweenAcrossTenants(fromID, toID uint64, edge
 	}
 
 	atomicDecrementWithUnderflowProtection(&gs.stats.EdgeCount)
+	gs.bumpVersion()
+
 	// Enqueue under gs.mu; the deferred wait above blocks off-lock.
 	walPending = gs.enqueueWAL(wal.OpDeleteEdge, edgeToDelete)
 
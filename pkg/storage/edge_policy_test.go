@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestEdgePolicy_Defaults pins that a zero-valued config keeps accepting
+// self-loops and parallel edges, as every store did before the policy.
+func TestEdgePolicy_Defaults(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"N"}, nil)
+	b := testNode(t, gs, []string{"N"}, nil)
+
+	testEdge(t, gs, a.ID, a.ID, "LOOP", nil, 1)
+	e1 := testEdge(t, gs, a.ID, b.ID, "LINK", nil, 1)
+	e2 := testEdge(t, gs, a.ID, b.ID, "LINK", nil, 1)
+	if e1.ID == e2.ID {
+		t.Fatal("parallel edge was deduplicated under the default policy")
+	}
+	if got := gs.GetStatistics().EdgeCount; got != 3 {
+		t.Errorf("EdgeCount = %d, want 3", got)
+	}
+}
+
+func TestEdgePolicy_RejectSelfLoops(t *testing.T) {
+	gs := testGraphStorage(t, StorageConfig{RejectSelfLoops: true})
+	a := testNode(t, gs, []string{"N"}, nil)
+	b := testNode(t, gs, []string{"N"}, nil)
+
+	if _, err := gs.CreateEdge(a.ID, a.ID, "LOOP", nil, 1); !errors.Is(err, ErrSelfLoop) {
+		t.Errorf("CreateEdge self-loop = %v, want ErrSelfLoop", err)
+	}
+	if _, _, err := gs.UpsertEdge(b.ID, b.ID, "LOOP", nil, 1); !errors.Is(err, ErrSelfLoop) {
+		t.Errorf("UpsertEdge self-loop = %v, want ErrSelfLoop", err)
+	}
+	tx, err := gs.BeginTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.CreateEdge(a.ID, a.ID, "LOOP", nil, 1); !errors.Is(err, ErrSelfLoop) {
+		t.Errorf("Transaction.CreateEdge self-loop = %v, want ErrSelfLoop", err)
+	}
+	_ = tx.Rollback()
+	batch := gs.BeginBatch()
+	if _, err := batch.AddEdge(b.ID, b.ID, "LOOP", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Commit(); !errors.Is(err, ErrSelfLoop) {
+		t.Errorf("Batch.Commit self-loop = %v, want ErrSelfLoop", err)
+	}
+
+	testEdge(t, gs, a.ID, b.ID, "LINK", nil, 1)
+	if got := gs.GetStatistics().EdgeCount; got != 1 {
+		t.Errorf("EdgeCount = %d, want 1", got)
+	}
+}
+
+func TestEdgePolicy_RejectMultiEdges(t *testing.T) {
+	gs := testGraphStorage(t, StorageConfig{MultiEdges: MultiEdgesReject})
+	a := testNode(t, gs, []string{"N"}, nil)
+	b := testNode(t, gs, []string{"N"}, nil)
+
+	first := testEdge(t, gs, a.ID, b.ID, "LINK", nil, 1)
+	if _, err := gs.CreateEdge(a.ID, b.ID, "LINK", nil, 2); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("duplicate CreateEdge = %v, want ErrDuplicateEdge", err)
+	}
+	// The key is directed and typed.
+	testEdge(t, gs, b.ID, a.ID, "LINK", nil, 1)
+	testEdge(t, gs, a.ID, b.ID, "OTHER", nil, 1)
+
+	// Upsert is unaffected: it updates the existing edge.
+	if _, created, err := gs.UpsertEdge(a.ID, b.ID, "LINK", nil, 3); err != nil || created {
+		t.Errorf("UpsertEdge = created %v, %v; want update of edge %d", created, err, first.ID)
+	}
+
+	tx, err := gs.BeginTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.CreateEdge(a.ID, b.ID, "LINK", nil, 1); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("tx duplicate of stored edge = %v, want ErrDuplicateEdge", err)
+	}
+	if _, err := tx.CreateEdge(b.ID, b.ID, "NEW", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.CreateEdge(b.ID, b.ID, "NEW", nil, 1); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("tx duplicate of buffered edge = %v, want ErrDuplicateEdge", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := gs.BeginBatch()
+	if _, err := batch.AddEdge(b.ID, a.ID, "LINK", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Commit(); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("Batch.Commit duplicate = %v, want ErrDuplicateEdge", err)
+	}
+
+	if got := gs.GetStatistics().EdgeCount; got != 4 {
+		t.Errorf("EdgeCount = %d, want 4", got)
+	}
+}
+
+// TestEdgePolicy_TransactionRechecksAtCommit tests a duplicate created
+// after a transaction buffered its edge fails the commit instead of being
+// stored twice.
+func TestEdgePolicy_TransactionRechecksAtCommit(t *testing.T) {
+	for _, policy := range []MultiEdgePolicy{MultiEdgesReject, MultiEdgesDedupe} {
+		gs := testGraphStorage(t, StorageConfig{MultiEdges: policy})
+		a := testNode(t, gs, []string{"N"}, nil)
+		b := testNode(t, gs, []string{"N"}, nil)
+
+		tx, err := gs.BeginTransaction()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.CreateEdge(a.ID, b.ID, "LINK", nil, 1); err != nil {
+			t.Fatal(err)
+		}
+		testEdge(t, gs, a.ID, b.ID, "LINK", nil, 1)
+
+		if err := tx.Commit(); !errors.Is(err, ErrDuplicateEdge) {
+			t.Errorf("%v: Commit after a concurrent duplicate = %v, want ErrDuplicateEdge", policy, err)
+		}
+		if got := gs.GetStatistics().EdgeCount; got != 1 {
+			t.Errorf("%v: EdgeCount = %d, want 1", policy, got)
+		}
+	}
+}
+
+func TestEdgePolicy_DedupeMultiEdges(t *testing.T) {
+	gs := testGraphStorage(t, StorageConfig{MultiEdges: MultiEdgesDedupe})
+	a, err := gs.CreateNodeWithTenant("acme", []string{"N"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := gs.CreateNodeWithTenant("acme", []string{"N"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := gs.CreateEdgeWithTenant("acme", a.ID, b.ID, "LINK", map[string]Value{"v": IntValue(1)}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := gs.CreateEdgeWithTenant("acme", a.ID, b.ID, "LINK", map[string]Value{"v": IntValue(2)}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != first.ID || again.Weight != 1 {
+		t.Errorf("dedupe returned edge %d (weight %v), want existing edge %d unchanged", again.ID, again.Weight, first.ID)
+	}
+
+	ids, err := gs.CreateEdgesWithTenant("acme", []EdgeSpec{
+		{FromID: a.ID, ToID: b.ID, Type: "LINK", Weight: 1},
+		{FromID: b.ID, ToID: a.ID, Type: "LINK", Weight: 1},
+		{FromID: b.ID, ToID: a.ID, Type: "LINK", Weight: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids[0] != first.ID || ids[1] != ids[2] || ids[1] == first.ID {
+		t.Errorf("CreateEdgesWithTenant ids = %v, want [%d x x] with a new x", ids, first.ID)
+	}
+
+	tx, err := gs.BeginTransactionForTenant("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, err := tx.CreateEdge(a.ID, b.ID, "LINK", nil, 1); err != nil || e.ID != first.ID {
+		t.Errorf("tx dedupe = %v, %v; want edge %d", e, err, first.ID)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := gs.GetStatistics().EdgeCount; got != 2 {
+		t.Errorf("EdgeCount = %d, want 2", got)
+	}
+}
+
+func TestParseMultiEdgePolicy(t *testing.T) {
+	for _, p := range []MultiEdgePolicy{MultiEdgesAllow, MultiEdgesReject, MultiEdgesDedupe} {
+		got, err := ParseMultiEdgePolicy(p.String())
+		if err != nil || got != p {
+			t.Errorf("ParseMultiEdgePolicy(%q) = %v, %v", p.String(), got, err)
+		}
+	}
+	if _, err := ParseMultiEdgePolicy("merge"); err == nil {
+		t.Error("ParseMultiEdgePolicy(merge) succeeded")
+	}
+}
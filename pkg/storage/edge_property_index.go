@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/dd0wney/graphdb/pkg/wal"
+)
+
+// Edge property indexes map (edge type, property key, value) to edge IDs, so
+// "every DATA_FLOW edge with enforcement = data_diode" is a lookup instead of
+// a scan. They are optional: FindEdgesByProperty* work without one (scanning
+// the type's edges) and switch to the index once it exists.
+//
+// Only the DEFINITIONS are persisted (WAL + snapshot metadata, like vector
+// indexes); the entries are rebuilt from the edge set after WAL replay by
+// rebuildEdgePropertyIndexes. All index state is guarded by gs.mu.
+
+// EdgePropertyIndexDef identifies an edge property index.
+type EdgePropertyIndexDef struct {
+	EdgeType    string
+	PropertyKey string
+}
+
+// edgePropertyIndex maps an encoded value (see edgeIndexValueKey) to the set
+// of edge IDs carrying it.
+type edgePropertyIndex map[string]map[uint64]struct{}
+
+// edgeIndexValueKey encodes a Value as a map key. The type byte keeps
+// IntValue(1) and StringValue("\x01...") from colliding.
+func edgeIndexValueKey(v Value) string {
+	return string(append([]byte{byte(v.Type)}, v.Data...))
+}
+
+// CreateEdgePropertyIndex indexes propertyKey on edges of edgeType and
+// populates it from the existing edges.
+func (gs *GraphStorage) CreateEdgePropertyIndex(edgeType, propertyKey string) error {
+	if edgeType == "" || propertyKey == "" {
+		return fmt.Errorf("%w: edge property index needs an edge type and a property key", ErrInvalidArgument)
+	}
+	def := EdgePropertyIndexDef{EdgeType: edgeType, PropertyKey: propertyKey}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if _, exists := gs.edgePropertyIndexes[def]; exists {
+		return fmt.Errorf("%w on edge property %s.%s", ErrIndexExists, edgeType, propertyKey)
+	}
+	gs.edgePropertyIndexes[def] = gs.buildEdgePropertyIndexLocked(def)
+
+	gs.bumpVersion()
+	gs.writeToWAL(wal.OpCreateEdgePropertyIndex, def)
+	return nil
+}
+
+// DropEdgePropertyIndex removes an edge property index.
+func (gs *GraphStorage) DropEdgePropertyIndex(edgeType, propertyKey string) error {
+	def := EdgePropertyIndexDef{EdgeType: edgeType, PropertyKey: propertyKey}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if _, exists := gs.edgePropertyIndexes[def]; !exists {
+		return fmt.Errorf("%w on edge property %s.%s", ErrIndexNotFound, edgeType, propertyKey)
+	}
+	delete(gs.edgePropertyIndexes, def)
+
+	gs.bumpVersion()
+	gs.writeToWAL(wal.OpDropEdgePropertyIndex, def)
+	return nil
+}
+
+// HasEdgePropertyIndex reports whether propertyKey is indexed on edgeType.
+func (gs *GraphStorage) HasEdgePropertyIndex(edgeType, propertyKey string) bool {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	_, exists := gs.edgePropertyIndexes[EdgePropertyIndexDef{EdgeType: edgeType, PropertyKey: propertyKey}]
+	return exists
+}
+
+// FindEdgesByTypeForTenant returns the tenant's edges of edgeType, sorted by
+// edge ID. It is the tenant-scoped counterpart of
+// FindEdgesByTypeAcrossTenants and, unlike GetEdgesByTypeForTenant, counts
+// toward query statistics.
+func (gs *GraphStorage) FindEdgesByTypeForTenant(tenantID, edgeType string) ([]*Edge, error) {
+	defer gs.startQueryTiming()()
+
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	edgeIDs := gs.membershipEdgeIDsByTypeLocked(effectiveTenantID(tenantID), edgeType)
+	return gs.buildEdgeListFromIDs(edgeIDs), nil
+}
+
+// FindEdgesByPropertyAcrossTenants returns every edge of edgeType whose
+// propertyKey equals value, from every tenant, sorted by edge ID. Uses the
+// edge property index when one exists, else scans the type's edges.
+// Tenant-scoped callers must use FindEdgesByPropertyForTenant.
+func (gs *GraphStorage) FindEdgesByPropertyAcrossTenants(edgeType, propertyKey string, value Value) ([]*Edge, error) {
+	defer gs.startQueryTiming()()
+
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	edgeIDs := gs.findEdgeIDsByPropertyLocked(edgeType, propertyKey, value, "")
+	return gs.buildEdgeListFromIDs(edgeIDs), nil
+}
+
+// FindEdgesByPropertyForTenant is FindEdgesByPropertyAcrossTenants limited to
+// the edges owned by tenantID.
+func (gs *GraphStorage) FindEdgesByPropertyForTenant(tenantID, edgeType, propertyKey string, value Value) ([]*Edge, error) {
+	defer gs.startQueryTiming()()
+
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	expected := effectiveTenantID(tenantID).String()
+	edgeIDs := gs.findEdgeIDsByPropertyLocked(edgeType, propertyKey, value, expected)
+	return gs.buildEdgeListFromIDs(edgeIDs), nil
+}
+
+// findEdgeIDsByPropertyLocked returns the sorted IDs of matching edges,
+// restricted to tenant when it is non-empty. Caller holds gs.mu.RLock.
+func (gs *GraphStorage) findEdgeIDsByPropertyLocked(edgeType, propertyKey string, value Value, tenant string) []uint64 {
+	var candidates []uint64
+	idx, indexed := gs.edgePropertyIndexes[EdgePropertyIndexDef{EdgeType: edgeType, PropertyKey: propertyKey}]
+	switch {
+	case indexed:
+		for id := range idx[edgeIndexValueKey(value)] {
+			candidates = append(candidates, id)
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+		if tenant == "" {
+			return candidates
+		}
+	case tenant != "":
+		candidates = gs.membershipEdgeIDsByTypeLocked(effectiveTenantID(tenant), edgeType)
+	default:
+		candidates = gs.membershipEdgeIDsByTypeGlobalLocked(edgeType)
+	}
+
+	// Index hits only need the tenant filter; scans also compare the value.
+	out := make([]uint64, 0, len(candidates))
+	for _, id := range candidates {
+		edge, exists := gs.resolveEdgeRefLocked(id)
+		if !exists {
+			continue
+		}
+		if tenant != "" && effectiveTenantID(edge.TenantID).String() != tenant {
+			continue
+		}
+		if !indexed {
+			prop, ok := edge.Properties[propertyKey]
+			if !ok || prop.Type != value.Type || string(prop.Data) != string(value.Data) {
+				continue
+			}
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+// buildEdgePropertyIndexLocked populates an index for def from the current
+// edges. Caller holds gs.mu.
+func (gs *GraphStorage) buildEdgePropertyIndexLocked(def EdgePropertyIndexDef) edgePropertyIndex {
+	idx := make(edgePropertyIndex)
+	for _, id := range gs.membershipEdgeIDsByTypeGlobalLocked(def.EdgeType) {
+		edge, exists := gs.resolveEdgeRefLocked(id)
+		if !exists {
+			continue
+		}
+		if v, ok := edge.Properties[def.PropertyKey]; ok {
+			key := edgeIndexValueKey(v)
+			if idx[key] == nil {
+				idx[key] = make(map[uint64]struct{})
+			}
+			idx[key][id] = struct{}{}
+		}
+	}
+	return idx
+}
+
+// indexEdgePropertiesLocked adds edge to every index on its type. Called
+// wherever an edge is published (create, and after an update's new values
+// are applied). Caller holds gs.mu.Lock.
+func (gs *GraphStorage) indexEdgePropertiesLocked(edge *Edge) {
+	for def, idx := range gs.edgePropertyIndexes {
+		if def.EdgeType != edge.Type {
+			continue
+		}
+		if v, ok := edge.Properties[def.PropertyKey]; ok {
+			key := edgeIndexValueKey(v)
+			if idx[key] == nil {
+				idx[key] = make(map[uint64]struct{})
+			}
+			idx[key][edge.ID] = struct{}{}
+		}
+	}
+}
+
+// unindexEdgePropertiesLocked removes edge from every index on its type.
+// Called on delete, and before an update mutates the values. Caller holds
+// gs.mu.Lock.
+func (gs *GraphStorage) unindexEdgePropertiesLocked(edge *Edge) {
+	for def, idx := range gs.edgePropertyIndexes {
+		if def.EdgeType != edge.Type {
+			continue
+		}
+		if v, ok := edge.Properties[def.PropertyKey]; ok {
+			key := edgeIndexValueKey(v)
+			delete(idx[key], edge.ID)
+			if len(idx[key]) == 0 {
+				delete(idx, key)
+			}
+		}
+	}
+}
+
+// edgePropertyIndexDefs returns the index definitions, sorted, for snapshot
+// metadata. Caller holds gs.mu.
+func (gs *GraphStorage) edgePropertyIndexDefs() []EdgePropertyIndexDef {
+	defs := make([]EdgePropertyIndexDef, 0, len(gs.edgePropertyIndexes))
+	for def := range gs.edgePropertyIndexes {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].EdgeType != defs[j].EdgeType {
+			return defs[i].EdgeType < defs[j].EdgeType
+		}
+		return defs[i].PropertyKey < defs[j].PropertyKey
+	})
+	return defs
+}
+
+// restoreEdgePropertyIndexDefs registers snapshotted definitions with empty
+// entries; rebuildEdgePropertyIndexes fills them after WAL replay.
+func (gs *GraphStorage) restoreEdgePropertyIndexDefs(defs []EdgePropertyIndexDef) {
+	for _, def := range defs {
+		if _, exists := gs.edgePropertyIndexes[def]; !exists {
+			gs.edgePropertyIndexes[def] = make(edgePropertyIndex)
+		}
+	}
+}
+
+// rebuildEdgePropertyIndexes repopulates every edge property index from the
+// final edge set (snapshot + WAL replay). Replay itself does not maintain the
+// entries, so this must run after replayWAL, like
+// rebuildVectorIndexesFromNodes.
+func (gs *GraphStorage) rebuildEdgePropertyIndexes() {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	for def := range gs.edgePropertyIndexes {
+		gs.edgePropertyIndexes[def] = gs.buildEdgePropertyIndexLocked(def)
+	}
+}
+
+// replayCreateEdgePropertyIndex restores an index definition recovered from
+// the WAL. Idempotent; entries are filled by rebuildEdgePropertyIndexes.
+func (gs *GraphStorage) replayCreateEdgePropertyIndex(entry *wal.Entry) error {
+	var def EdgePropertyIndexDef
+	if err := json.Unmarshal(entry.Data, &def); err != nil {
+		return err
+	}
+	gs.restoreEdgePropertyIndexDefs([]EdgePropertyIndexDef{def})
+	return nil
+}
+
+// replayDropEdgePropertyIndex replays a drop so a snapshotted definition does
+// not resurrect on recovery. A missing index is a no-op.
+func (gs *GraphStorage) replayDropEdgePropertyIndex(entry *wal.Entry) error {
+	var def EdgePropertyIndexDef
+	if err := json.Unmarshal(entry.Data, &def); err != nil {
+		return err
+	}
+	delete(gs.edgePropertyIndexes, def)
+	return nil
+}
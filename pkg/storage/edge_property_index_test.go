@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"testing"
+)
+
+func edgeIDs(edges []*Edge) []uint64 {
+	ids := make([]uint64, len(edges))
+	for i, e := range edges {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// buildFlowFixture creates DATA_FLOW edges with a mix of enforcement values,
+// plus an unrelated edge type sharing the property key.
+func buildFlowFixture(t *testing.T, gs *GraphStorage) (diode, firewall []uint64) {
+	t.Helper()
+	a := testNode(t, gs, []string{"Zone"}, nil)
+	b := testNode(t, gs, []string{"Zone"}, nil)
+	c := testNode(t, gs, []string{"Zone"}, nil)
+	for i, to := range []uint64{b.ID, c.ID, b.ID, c.ID} {
+		enforcement := "data_diode"
+		if i%2 == 1 {
+			enforcement = "firewall"
+		}
+		e := testEdge(t, gs, a.ID, to, "DATA_FLOW",
+			map[string]Value{"enforcement": StringValue(enforcement)}, 1)
+		if enforcement == "data_diode" {
+			diode = append(diode, e.ID)
+		} else {
+			firewall = append(firewall, e.ID)
+		}
+	}
+	testEdge(t, gs, b.ID, c.ID, "DEPENDS_ON",
+		map[string]Value{"enforcement": StringValue("data_diode")}, 1)
+	// Same bytes, different type: must not match a string lookup.
+	testEdge(t, gs, c.ID, a.ID, "DATA_FLOW",
+		map[string]Value{"enforcement": {Type: TypeBytes, Data: []byte("data_diode")}}, 1)
+	return diode, firewall
+}
+
+// TestFindEdgesByProperty_IndexedMatchesScan: the index is a pure
+// accelerator — results are identical with and without it.
+func TestFindEdgesByProperty_IndexedMatchesScan(t *testing.T) {
+	gs := testGraphStorage(t)
+	diode, firewall := buildFlowFixture(t, gs)
+
+	check := func(ctx string) {
+		t.Helper()
+		for value, want := range map[string][]uint64{"data_diode": diode, "firewall": firewall, "none": {}} {
+			got, err := gs.FindEdgesByPropertyAcrossTenants("DATA_FLOW", "enforcement", StringValue(value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalU64(edgeIDs(got), want) {
+				t.Errorf("%s: %s = %v, want %v", ctx, value, edgeIDs(got), want)
+			}
+		}
+	}
+
+	check("scan")
+	if err := gs.CreateEdgePropertyIndex("DATA_FLOW", "enforcement"); err != nil {
+		t.Fatal(err)
+	}
+	if !gs.HasEdgePropertyIndex("DATA_FLOW", "enforcement") {
+		t.Fatal("HasEdgePropertyIndex = false after create")
+	}
+	check("indexed")
+
+	if err := gs.CreateEdgePropertyIndex("DATA_FLOW", "enforcement"); err == nil {
+		t.Error("duplicate CreateEdgePropertyIndex succeeded, want error")
+	}
+	if err := gs.DropEdgePropertyIndex("DATA_FLOW", "enforcement"); err != nil {
+		t.Fatal(err)
+	}
+	if err := gs.DropEdgePropertyIndex("DATA_FLOW", "enforcement"); err == nil {
+		t.Error("dropping a missing index succeeded, want error")
+	}
+	check("dropped")
+}
+
+// TestEdgePropertyIndex_Maintenance: create, update, upsert and delete keep
+// the index in step with the edges.
+func TestEdgePropertyIndex_Maintenance(t *testing.T) {
+	gs := testGraphStorage(t)
+	if err := gs.CreateEdgePropertyIndex("DATA_FLOW", "enforcement"); err != nil {
+		t.Fatal(err)
+	}
+	a := testNode(t, gs, []string{"Zone"}, nil)
+	b := testNode(t, gs, []string{"Zone"}, nil)
+	e := testEdge(t, gs, a.ID, b.ID, "DATA_FLOW",
+		map[string]Value{"enforcement": StringValue("firewall")}, 1)
+
+	find := func(value string) []uint64 {
+		t.Helper()
+		got, err := gs.FindEdgesByPropertyAcrossTenants("DATA_FLOW", "enforcement", StringValue(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return edgeIDs(got)
+	}
+
+	if got := find("firewall"); !equalU64(got, []uint64{e.ID}) {
+		t.Fatalf("after create: %v", got)
+	}
+
+	if err := gs.UpdateEdge(e.ID, map[string]Value{"enforcement": StringValue("data_diode")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := find("firewall"); len(got) != 0 {
+		t.Errorf("after update: stale firewall entry %v", got)
+	}
+	if got := find("data_diode"); !equalU64(got, []uint64{e.ID}) {
+		t.Errorf("after update: data_diode = %v", got)
+	}
+
+	if _, created, err := gs.UpsertEdge(a.ID, b.ID, "DATA_FLOW",
+		map[string]Value{"enforcement": StringValue("vpn")}, 1); err != nil || created {
+		t.Fatalf("UpsertEdge = created %v, err %v", created, err)
+	}
+	if got := find("vpn"); !equalU64(got, []uint64{e.ID}) {
+		t.Errorf("after upsert: vpn = %v", got)
+	}
+	if got := find("data_diode"); len(got) != 0 {
+		t.Errorf("after upsert: stale data_diode entry %v", got)
+	}
+
+	if err := gs.DeleteEdge(e.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := find("vpn"); len(got) != 0 {
+		t.Errorf("after delete: %v", got)
+	}
+
+	// Cascade via node delete.
+	e2 := testEdge(t, gs, a.ID, b.ID, "DATA_FLOW",
+		map[string]Value{"enforcement": StringValue("vpn")}, 1)
+	if got := find("vpn"); !equalU64(got, []uint64{e2.ID}) {
+		t.Fatalf("before cascade: %v", got)
+	}
+	if err := gs.DeleteNode(b.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := find("vpn"); len(got) != 0 {
+		t.Errorf("after node delete: %v", got)
+	}
+}
+
+func TestFindEdgesByProperty_TenantScoped(t *testing.T) {
+	gs := testGraphStorage(t)
+	var want = map[string][]uint64{}
+	for _, tenant := range []string{rtTenantA, rtTenantB} {
+		a, err := gs.CreateNodeWithTenant(tenant, []string{"Zone"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := gs.CreateNodeWithTenant(tenant, []string{"Zone"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e, err := gs.CreateEdgeWithTenant(tenant, a.ID, b.ID, "DATA_FLOW",
+			map[string]Value{"enforcement": StringValue("data_diode")}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want[tenant] = []uint64{e.ID}
+	}
+
+	for _, indexed := range []bool{false, true} {
+		if indexed {
+			if err := gs.CreateEdgePropertyIndex("DATA_FLOW", "enforcement"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for tenant, ids := range want {
+			got, err := gs.FindEdgesByPropertyForTenant(tenant, "DATA_FLOW", "enforcement", StringValue("data_diode"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalU64(edgeIDs(got), ids) {
+				t.Errorf("indexed=%v %s: %v, want %v", indexed, tenant, edgeIDs(got), ids)
+			}
+			byType, err := gs.FindEdgesByTypeForTenant(tenant, "DATA_FLOW")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalU64(edgeIDs(byType), ids) {
+				t.Errorf("FindEdgesByTypeForTenant(%s) = %v, want %v", tenant, edgeIDs(byType), ids)
+			}
+		}
+		all, err := gs.FindEdgesByPropertyAcrossTenants("DATA_FLOW", "enforcement", StringValue("data_diode"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != 2 {
+			t.Errorf("indexed=%v across tenants: %d edges, want 2", indexed, len(all))
+		}
+	}
+}
+
+// TestEdgePropertyIndex_SurvivesReopen: the definition persists through both
+// snapshot formats and through WAL-only recovery, and its entries are rebuilt
+// to include edges written after the definition.
+func TestEdgePropertyIndex_SurvivesReopen(t *testing.T) {
+	for name, cfg := range map[string]func(string) StorageConfig{"json": jsonConfig, "mmap": mmapConfig} {
+		t.Run(name, func(t *testing.T) {
+			t.Run("clean close", func(t *testing.T) {
+				dir := t.TempDir()
+				gs, err := NewGraphStorageWithConfig(cfg(dir))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := gs.CreateEdgePropertyIndex("DATA_FLOW", "enforcement"); err != nil {
+					t.Fatal(err)
+				}
+				diode, _ := buildFlowFixture(t, gs)
+				if err := gs.Close(); err != nil {
+					t.Fatal(err)
+				}
+
+				reopened := testGraphStorage(t, cfg(dir))
+				assertIndexedDiode(t, reopened, diode)
+			})
+
+			t.Run("crash recovery", func(t *testing.T) {
+				dir := t.TempDir()
+				gs := testCrashableStorage(t, dir, cfg(dir))
+				if err := gs.CreateEdgePropertyIndex("DATA_FLOW", "enforcement"); err != nil {
+					t.Fatal(err)
+				}
+				diode, _ := buildFlowFixture(t, gs)
+				if err := gs.CreateEdgePropertyIndex("DEPENDS_ON", "enforcement"); err != nil {
+					t.Fatal(err)
+				}
+				if err := gs.DropEdgePropertyIndex("DEPENDS_ON", "enforcement"); err != nil {
+					t.Fatal(err)
+				}
+
+				recovered := testCrashRecovery(t, dir, cfg(dir))
+				assertIndexedDiode(t, recovered, diode)
+				if recovered.HasEdgePropertyIndex("DEPENDS_ON", "enforcement") {
+					t.Error("dropped index resurrected by WAL replay")
+				}
+			})
+		})
+	}
+}
+
+func assertIndexedDiode(t *testing.T, gs *GraphStorage, want []uint64) {
+	t.Helper()
+	if !gs.HasEdgePropertyIndex("DATA_FLOW", "enforcement") {
+		t.Fatal("index definition lost on reopen")
+	}
+	got, err := gs.FindEdgesByPropertyAcrossTenants("DATA_FLOW", "enforcement", StringValue("data_diode"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalU64(edgeIDs(got), want) {
+		t.Errorf("after reopen: %v, want %v", edgeIDs(got), want)
+	}
+}
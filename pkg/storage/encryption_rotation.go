@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/dd0wney/graphdb/pkg/encryption"
+)
+
+// RotateEncryptionKey moves an encrypted store onto a new key while it is
+// serving traffic. It swaps the engine every later snapshot and WAL append
+// seals with (and sensitive properties too, unless they have their own
+// SensitivePropertyEngine), then checkpoints (CompactWAL): the snapshot is rewritten
+// under the new key and every WAL entry sealed under the old one is
+// dropped. Named snapshots are not rewritten; restoring one taken before
+// the rotation needs an engine that can still decrypt it, such as an
+// encryption.KeyRing holding the old key.
+//
+// If the checkpoint fails the store keeps sealing with the new engine, but
+// the on-disk snapshot is still under the old key: keep both keys (a
+// KeyRing) until a retry succeeds.
+func (gs *GraphStorage) RotateEncryptionKey(engine encryption.EncryptDecrypter) error {
+	if engine == nil {
+		return fmt.Errorf("rotate encryption key: engine is nil")
+	}
+	if gs.closed.Load() {
+		return ErrStorageClosed
+	}
+
+	// gs.mu excludes writers between sealing and appending; the barrier
+	// waits out a Transaction.Commit that sealed its WAL batch under the
+	// old engine but has not appended it yet. Both old-key sources then
+	// land at or below the checkpoint boundary.
+	gs.mu.Lock()
+	gs.txWALBarrier.Lock()
+	if gs.encryptionEngine == nil {
+		gs.txWALBarrier.Unlock()
+		gs.mu.Unlock()
+		return fmt.Errorf("rotate encryption key: encryption is not enabled")
+	}
+	gs.encryptionEngine = engine
+	if fc := gs.fieldCipher; fc != nil && fc.sharesStoreKey {
+		rotated := *fc
+		rotated.engine = engine
+		gs.fieldCipher = &rotated
+	}
+	gs.txWALBarrier.Unlock()
+	gs.mu.Unlock()
+
+	if !gs.hasWAL() {
+		return gs.Snapshot()
+	}
+	return gs.CompactWAL()
+}
+
+// rotatingEncryptionKey reports whether engine still decrypts with retired
+// keys, i.e. whether opening the store should re-encrypt it.
+func rotatingEncryptionKey(engine encryption.EncryptDecrypter) bool {
+	kr, ok := engine.(interface{ HasPrevious() bool })
+	return ok && kr.HasPrevious()
+}
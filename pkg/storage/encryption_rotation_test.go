@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dd0wney/graphdb/pkg/encryption"
+)
+
+var (
+	rotationOldKey = bytes.Repeat([]byte("o"), 32)
+	rotationNewKey = bytes.Repeat([]byte("n"), 32)
+)
+
+func keyedStorageConfig(dir string, key []byte, previous ...[]byte) StorageConfig {
+	cfg := DefaultStorageConfig(dir)
+	cfg.EncryptionKey = key
+	cfg.PreviousEncryptionKeys = previous
+	return cfg
+}
+
+// TestEncryptionKey_RotateOnOpen pins the restart rotation path: opening
+// with the new key plus the old one as previous re-encrypts the snapshot
+// and WAL, after which the old key is no longer needed — and no longer
+// enough.
+func TestEncryptionKey_RotateOnOpen(t *testing.T) {
+	dir := t.TempDir()
+	gs, err := NewGraphStorageWithConfig(keyedStorageConfig(dir, rotationOldKey))
+	if err != nil {
+		t.Fatalf("open with old key: %v", err)
+	}
+	snapNode := createH3Node(t, gs)
+	if err := gs.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	walNode := createH3Node(t, gs) // only in the WAL
+	// Crash-sim: no Close, so the WAL entry is still sealed under the old key.
+
+	gs2, err := NewGraphStorageWithConfig(keyedStorageConfig(dir, rotationNewKey, rotationOldKey))
+	if err != nil {
+		t.Fatalf("open mid-rotation: %v", err)
+	}
+	createH3Node(t, gs2) // sealed under the new key
+	// Crash-sim again.
+
+	gs3, err := NewGraphStorageWithConfig(keyedStorageConfig(dir, rotationNewKey))
+	if err != nil {
+		t.Fatalf("open with new key only: %v", err)
+	}
+	for _, id := range []uint64{snapNode.ID, walNode.ID} {
+		if _, err := gs3.GetNodeForTenant(id, "h3"); err != nil {
+			t.Errorf("node %d lost across rotation: %v", id, err)
+		}
+	}
+	if got := gs3.GetStatistics().NodeCount; got != 3 {
+		t.Errorf("NodeCount = %d, want 3", got)
+	}
+	if err := gs3.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewGraphStorageWithConfig(keyedStorageConfig(dir, rotationOldKey)); err == nil {
+		t.Fatal("store still opens with the retired key after rotation")
+	}
+}
+
+// TestRotateEncryptionKey_Online pins the live rotation path: after
+// RotateEncryptionKey returns, neither the snapshot nor the WAL needs the
+// old key, even across a crash.
+func TestRotateEncryptionKey_Online(t *testing.T) {
+	for _, batched := range []bool{false, true} {
+		name := "plain"
+		if batched {
+			name = "batched"
+		}
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			cfg := keyedStorageConfig(dir, rotationOldKey)
+			cfg.EnableBatching = batched
+			gs, err := NewGraphStorageWithConfig(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			before := createH3Node(t, gs)
+
+			newEngine, err := encryption.NewEngine(rotationNewKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gs.RotateEncryptionKey(newEngine); err != nil {
+				t.Fatalf("RotateEncryptionKey: %v", err)
+			}
+			after := createH3Node(t, gs)
+			// Crash-sim: no Close.
+
+			cfg.EncryptionKey = rotationNewKey
+			gs2, err := NewGraphStorageWithConfig(cfg)
+			if err != nil {
+				t.Fatalf("reopen with new key only: %v", err)
+			}
+			defer func() { _ = gs2.Close() }()
+			for _, id := range []uint64{before.ID, after.ID} {
+				if _, err := gs2.GetNodeForTenant(id, "h3"); err != nil {
+					t.Errorf("node %d lost across online rotation: %v", id, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRotateEncryptionKey_RequiresEncryption(t *testing.T) {
+	gs := testGraphStorage(t)
+	if err := gs.RotateEncryptionKey(testEncryptionEngine(t)); err == nil {
+		t.Error("RotateEncryptionKey on an unencrypted store succeeded")
+	}
+	if err := gs.RotateEncryptionKey(nil); err == nil {
+		t.Error("RotateEncryptionKey(nil) succeeded")
+	}
+}
@@ -6,7 +6,23 @@ import (
 	"math"
 )
 
-// Common sentinel errors
+// Sentinel errors. Storage methods wrap these with %w (or return one of the
+// typed errors below, which unwrap to them), so callers classify failures
+// with errors.Is rather than by matching message text:
+//
+//   - not found: ErrNodeNotFound, ErrEdgeNotFound, ErrIndexNotFound,
+//     ErrSnapshotNotFound. Cross-tenant lookups report not found too.
+//   - invalid input: ErrInvalidArgument, ErrInvalidProperty, ErrInvalidID,
+//     ErrInvalidEdgeWeight, ErrSelfLoop, ErrInvalidSnapshotName,
+//     ErrInvalidBackup, ErrSensitivePropertyIndex.
+//   - conflict: ErrUniqueConstraintViolation, ErrDuplicateEdge,
+//     ErrDuplicateNodeKey, ErrIndexExists, ErrConflict.
+//   - unavailable: ErrStorageClosed.
+//   - internal: ErrWALAppendFailed, ErrMarshalFailed, ErrIndexFailed,
+//     ErrIDSpaceExhausted.
+//
+// Errors matching none of these are internal failures (I/O, corrupt
+// on-disk state) that the caller can't fix by changing the request.
 var (
 	ErrNodeNotFound              = errors.New("node not found")
 	ErrEdgeNotFound              = errors.New("edge not found")
@@ -21,6 +37,46 @@ var (
 	// non-finite floats, so such an edge would silently fail to persist and be
 	// lost on crash — reject it at the boundary instead (#328).
 	ErrInvalidEdgeWeight = errors.New("edge weight must be a finite number")
+	// ErrSnapshotNotFound is returned by RestoreSnapshot when no named
+	// snapshot exists under the requested name.
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	// ErrInvalidSnapshotName is returned when a named-snapshot name contains
+	// characters outside [A-Za-z0-9._-], starts with '.', or is too long.
+	ErrInvalidSnapshotName = errors.New("invalid snapshot name")
+	// ErrInvalidBackup is returned by Restore when the stream is not a
+	// complete backup in a supported version of the portable format.
+	ErrInvalidBackup = errors.New("invalid backup stream")
+	// ErrSelfLoop is returned by edge creation when the source and target
+	// are the same node and StorageConfig.RejectSelfLoops is set.
+	ErrSelfLoop = errors.New("self-loop edges are not allowed")
+	// ErrDuplicateEdge is returned by edge creation when an edge with the
+	// same (from, to, type) already exists in the tenant and
+	// StorageConfig.MultiEdges is MultiEdgesReject.
+	ErrDuplicateEdge = errors.New("duplicate edge")
+	// ErrInvalidArgument is returned when a parameter of the call itself is
+	// unusable: an empty key or tenant ID, an unknown direction or policy,
+	// a negative radius.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrInvalidProperty is returned when a property value has the wrong
+	// type for the operation (a typed accessor such as Value.AsString, a
+	// property index of another type, a vector of the wrong dimension) or
+	// its encoded data is malformed.
+	ErrInvalidProperty = errors.New("invalid property value")
+	// ErrIndexNotFound is returned by index lookups and drops when no index
+	// exists on the property.
+	ErrIndexNotFound = errors.New("index does not exist")
+	// ErrIndexExists is returned by index creation when the property is
+	// already indexed.
+	ErrIndexExists = errors.New("index already exists")
+	// ErrIDSpaceExhausted is returned by create when the next node or edge
+	// ID would overflow uint64.
+	ErrIDSpaceExhausted = errors.New("ID space exhausted")
+	// ErrDuplicateNodeKey is returned by CreateNodeWithKey when another node
+	// in the tenant already holds the key.
+	ErrDuplicateNodeKey = errors.New("node key already in use")
+	// ErrConflict is returned by the IfRevision updates when the node or
+	// edge has changed since the caller read the revision it passed.
+	ErrConflict = errors.New("entity was modified since it was read")
 )
 
 // validateEdgeWeight rejects non-finite (±Inf/NaN) edge weights, which the WAL
@@ -63,6 +119,27 @@ func (e *UniqueConstraintError) Unwrap() error {
 	return ErrUniqueConstraintViolation
 }
 
+// EdgeEndpointError is returned by edge creation when the source or
+// target node does not exist (or, on the tenant-scoped paths, belongs to
+// another tenant — reported identically so a probe can't tell the two
+// apart). It is returned before anything is mutated. Use
+// errors.Is(err, ErrNodeNotFound) to detect the class and errors.As to
+// learn which endpoint was missing.
+type EdgeEndpointError struct {
+	Endpoint string // "source" or "target"
+	NodeID   uint64
+}
+
+// Error implements the error interface.
+func (e *EdgeEndpointError) Error() string {
+	return fmt.Sprintf("%s node %d not found", e.Endpoint, e.NodeID)
+}
+
+// Unwrap allows errors.Is(err, ErrNodeNotFound).
+func (e *EdgeEndpointError) Unwrap() error {
+	return ErrNodeNotFound
+}
+
 // StorageError provides structured error information for storage operations.
 type StorageError struct {
 	Op      string // Operation that failed (e.g., "CreateNode", "DeleteEdge")
@@ -200,9 +277,34 @@ func MarshalError(entity string, id uint64, cause error) error {
 	}
 }
 
-// IsNotFound returns true if the error is a not found error.
+// IsNotFound returns true if the error is a not found error for a node,
+// edge, index or named snapshot.
 func IsNotFound(err error) bool {
-	return errors.Is(err, ErrNodeNotFound) || errors.Is(err, ErrEdgeNotFound)
+	return errors.Is(err, ErrNodeNotFound) || errors.Is(err, ErrEdgeNotFound) ||
+		errors.Is(err, ErrIndexNotFound) || errors.Is(err, ErrSnapshotNotFound)
+}
+
+// IsInvalid returns true if the error was caused by the caller's input:
+// any of the invalid-input sentinels listed above.
+func IsInvalid(err error) bool {
+	for _, target := range []error{
+		ErrInvalidArgument, ErrInvalidProperty, ErrInvalidID, ErrInvalidEdgeWeight,
+		ErrSelfLoop, ErrInvalidSnapshotName, ErrInvalidBackup, ErrSensitivePropertyIndex,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConflict returns true if the error reports a clash with existing
+// state: a unique constraint, duplicate edge or key, existing index, or a
+// revision that no longer matches.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrUniqueConstraintViolation) || errors.Is(err, ErrDuplicateEdge) ||
+		errors.Is(err, ErrDuplicateNodeKey) || errors.Is(err, ErrIndexExists) ||
+		errors.Is(err, ErrConflict)
 }
 
 // IsClosed returns true if the error indicates the storage is closed.
@@ -220,6 +220,7 @@ func TestIsNotFound(t *testing.T) {
 	}{
 		{"node not found", NodeNotFoundError(1), true},
 		{"edge not found", EdgeNotFoundError(1), true},
+		{"index not found", fmt.Errorf("%w on property age", ErrIndexNotFound), true},
 		{"other error", fmt.Errorf("other"), false},
 		{"nil error", nil, false},
 	}
@@ -259,3 +260,53 @@ func TestIsClosed(t *testing.T) {
 		})
 	}
 }
+
+// TestErrorTaxonomy checks that storage operations surface the documented
+// sentinels, so callers can classify failures with errors.Is.
+func TestErrorTaxonomy(t *testing.T) {
+	gs := testGraphStorage(t)
+	node := testNode(t, gs, []string{"Person"}, map[string]Value{"age": IntValue(30)})
+
+	missingEdge := gs.UpdateEdge(999, nil, nil)
+	_, noIndex := gs.FindNodesByPropertyIndexed("age", IntValue(30))
+	if err := gs.CreatePropertyIndex("age", TypeInt); err != nil {
+		t.Fatal(err)
+	}
+	_, notString := node.Properties["age"].AsString()
+	_, badDirection := gs.Neighbors(node.ID, Direction(99), nil)
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		class  func(error) bool
+	}{
+		{"missing node", gs.DeleteNode(999), ErrNodeNotFound, IsNotFound},
+		{"missing edge", missingEdge, ErrEdgeNotFound, IsNotFound},
+		{"missing index", noIndex, ErrIndexNotFound, IsNotFound},
+		{"drop missing index", gs.DropPropertyIndex("name"), ErrIndexNotFound, IsNotFound},
+		{"duplicate index", gs.CreatePropertyIndex("age", TypeInt), ErrIndexExists, IsConflict},
+		{"wrong value type", notString, ErrInvalidProperty, IsInvalid},
+		{"bad direction", badDirection, ErrInvalidArgument, IsInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.target) {
+				t.Errorf("err = %v, want errors.Is %v", tt.err, tt.target)
+			}
+			if !tt.class(tt.err) {
+				t.Errorf("err = %v not classified", tt.err)
+			}
+		})
+	}
+
+	if err := gs.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gs.GetNode(node.ID); !IsClosed(err) {
+		t.Errorf("after Close: err = %v, want ErrStorageClosed", err)
+	}
+	if err := gs.Close(); !IsClosed(err) {
+		t.Errorf("second Close: err = %v, want ErrStorageClosed", err)
+	}
+}
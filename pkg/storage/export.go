@@ -0,0 +1,40 @@
+package storage
+
+import "sort"
+
+// exportSubgraph returns the nodes and edges an exporter should emit, both in
+// ascending-ID order. A nil nodeIDs selects the whole tenant graph; otherwise
+// only the listed nodes are kept, along with the edges whose endpoints are
+// both in the set.
+func exportSubgraph(g *GraphStorage, tenantID string, nodeIDs map[uint64]struct{}) ([]*Node, []*Edge) {
+	nodes := g.GetAllNodesForTenant(tenantID)
+	if nodeIDs != nil {
+		filtered := nodes[:0]
+		for _, n := range nodes {
+			if _, ok := nodeIDs[n.ID]; ok {
+				filtered = append(filtered, n)
+			}
+		}
+		nodes = filtered
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	included := make(map[uint64]struct{}, len(nodes))
+	for _, n := range nodes {
+		included[n.ID] = struct{}{}
+	}
+
+	edges := g.GetAllEdgesForTenant(tenantID)
+	filteredEdges := edges[:0]
+	for _, e := range edges {
+		_, fromOK := included[e.FromNodeID]
+		_, toOK := included[e.ToNodeID]
+		if fromOK && toOK {
+			filteredEdges = append(filteredEdges, e)
+		}
+	}
+	edges = filteredEdges
+	sort.Slice(edges, func(i, j int) bool { return edges[i].ID < edges[j].ID })
+
+	return nodes, edges
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportDOT(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	plc := testNode(t, gs, []string{"PLC"}, map[string]Value{
+		"name": StringValue("PLC-1"),
+		"zone": StringValue("control"),
+	})
+	hmi := testNode(t, gs, []string{"HMI"}, map[string]Value{
+		"name": StringValue(`HMI "main"`),
+		"zone": StringValue("dmz"),
+	})
+	fw := testNode(t, gs, []string{"Firewall"}, map[string]Value{
+		"zone": StringValue("dmz"),
+	})
+	testEdge(t, gs, hmi.ID, plc.ID, "CONTROLS", nil, 1.0)
+	testEdge(t, gs, fw.ID, hmi.ID, "ROUTES_TO", nil, 1.0)
+
+	t.Run("full graph", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportDOT(gs, &buf, DOTOptions{
+			ColorBy:    "zone",
+			ColorMap:   map[string]string{"control": "red"},
+			EdgeLabels: true,
+		})
+		if err != nil {
+			t.Fatalf("ExportDOT: %v", err)
+		}
+		out := buf.String()
+
+		for _, want := range []string{
+			`digraph "graphdb" {`,
+			`label="PLC-1", fillcolor="red"`,
+			`label="HMI \"main\""`,
+			`label="Firewall 3"`,
+			`n2 -> n1 [label="CONTROLS"];`,
+			`n3 -> n2 [label="ROUTES_TO"];`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+		// Both dmz nodes share the first palette colour.
+		if got := strings.Count(out, `fillcolor="`+dotPalette[0]+`"`); got != 2 {
+			t.Errorf("dmz palette colour used %d times, want 2:\n%s", got, out)
+		}
+	})
+
+	t.Run("subgraph filter", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportDOT(gs, &buf, DOTOptions{
+			NodeIDs: map[uint64]struct{}{plc.ID: {}, hmi.ID: {}},
+		})
+		if err != nil {
+			t.Fatalf("ExportDOT: %v", err)
+		}
+		out := buf.String()
+		if strings.Contains(out, "n3 ") {
+			t.Errorf("filtered node n3 present:\n%s", out)
+		}
+		if !strings.Contains(out, "n2 -> n1;") {
+			t.Errorf("edge inside subgraph missing:\n%s", out)
+		}
+		if strings.Contains(out, "n3 -> n2") {
+			t.Errorf("edge leaving subgraph present:\n%s", out)
+		}
+	})
+
+	t.Run("undirected edge", func(t *testing.T) {
+		if _, err := gs.CreateUndirectedEdge(plc.ID, fw.ID, "PEER", nil, 1.0); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := ExportDOT(gs, &buf, DOTOptions{EdgeLabels: true}); err != nil {
+			t.Fatalf("ExportDOT: %v", err)
+		}
+		if want := `n1 -> n3 [label="PEER", dir=none];`; !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GraphMLOptions controls ExportGraphML output.
+type GraphMLOptions struct {
+	// GraphID is the <graph id="..."> attribute. Defaults to "graphdb".
+	GraphID string
+
+	// TenantID scopes the export; empty means the default tenant.
+	TenantID string
+
+	// NodeIDs restricts the export to a subgraph. Only edges with both
+	// endpoints in the set are emitted. Nil exports the whole tenant graph.
+	NodeIDs map[uint64]struct{}
+}
+
+// graphMLKey is one <key> declaration: a property name in a domain ("node"
+// or "edge") with its GraphML attr.type.
+type graphMLKey struct {
+	id, domain, name, attrType string
+}
+
+// ExportGraphML writes the graph (or the NodeIDs subgraph) to w as GraphML,
+// which Gephi, yEd and Cytoscape open directly. Node labels are joined with
+// ';' into a "labels" attribute; edges carry "type" and "weight". Every
+// property gets a <key> declaration; a property whose type differs between
+// elements is declared as a string. Nodes and edges are emitted in
+// ascending-ID order. Undirected edges override the directed default with
+// directed="false".
+func ExportGraphML(g *GraphStorage, w io.Writer, opts GraphMLOptions) error {
+	if g == nil {
+		return fmt.Errorf("export GraphML: nil graph")
+	}
+	graphID := opts.GraphID
+	if graphID == "" {
+		graphID = "graphdb"
+	}
+	nodes, edges := exportSubgraph(g, opts.TenantID, opts.NodeIDs)
+
+	nodeProps := make([]map[string]Value, len(nodes))
+	for i, n := range nodes {
+		nodeProps[i] = n.Properties
+	}
+	edgeProps := make([]map[string]Value, len(edges))
+	for i, e := range edges {
+		edgeProps[i] = e.Properties
+	}
+	nodeKeys := graphMLPropertyKeys("node", "n_", nodeProps)
+	edgeKeys := graphMLPropertyKeys("edge", "e_", edgeProps)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, xml.Header)
+	fmt.Fprintln(bw, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	fmt.Fprintln(bw, `  <key id="labels" for="node" attr.name="labels" attr.type="string"/>`)
+	fmt.Fprintln(bw, `  <key id="type" for="edge" attr.name="type" attr.type="string"/>`)
+	fmt.Fprintln(bw, `  <key id="weight" for="edge" attr.name="weight" attr.type="double"/>`)
+	for _, keys := range [][]graphMLKey{nodeKeys, edgeKeys} {
+		for _, k := range keys {
+			fmt.Fprintf(bw, "  <key id=%s for=%q attr.name=%s attr.type=%q/>\n",
+				graphMLAttr(k.id), k.domain, graphMLAttr(k.name), k.attrType)
+		}
+	}
+	fmt.Fprintf(bw, "  <graph id=%s edgedefault=\"directed\">\n", graphMLAttr(graphID))
+
+	for _, n := range nodes {
+		fmt.Fprintf(bw, "    <node id=\"n%d\">\n", n.ID)
+		if len(n.Labels) > 0 {
+			fmt.Fprintf(bw, "      <data key=\"labels\">%s</data>\n", graphMLText(strings.Join(n.Labels, csvLabelSeparator)))
+		}
+		writeGraphMLData(bw, "n_", n.Properties)
+		fmt.Fprintln(bw, "    </node>")
+	}
+
+	for _, e := range edges {
+		if e.Undirected {
+			fmt.Fprintf(bw, "    <edge id=\"e%d\" source=\"n%d\" target=\"n%d\" directed=\"false\">\n", e.ID, e.FromNodeID, e.ToNodeID)
+		} else {
+			fmt.Fprintf(bw, "    <edge id=\"e%d\" source=\"n%d\" target=\"n%d\">\n", e.ID, e.FromNodeID, e.ToNodeID)
+		}
+		fmt.Fprintf(bw, "      <data key=\"type\">%s</data>\n", graphMLText(e.Type))
+		fmt.Fprintf(bw, "      <data key=\"weight\">%g</data>\n", e.Weight)
+		writeGraphMLData(bw, "e_", e.Properties)
+		fmt.Fprintln(bw, "    </edge>")
+	}
+
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</graphml>")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("export GraphML: %w", err)
+	}
+	return nil
+}
+
+// graphMLPropertyKeys declares one key per distinct property name across
+// props, sorted by name. Key IDs are the name with a domain prefix so node
+// and edge properties of the same name don't collide.
+func graphMLPropertyKeys(domain, prefix string, props []map[string]Value) []graphMLKey {
+	types := make(map[string]string)
+	for _, p := range props {
+		for name, v := range p {
+			t := graphMLAttrType(v.Type)
+			if prev, ok := types[name]; ok && prev != t {
+				t = "string"
+			}
+			types[name] = t
+		}
+	}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := make([]graphMLKey, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, graphMLKey{id: prefix + name, domain: domain, name: name, attrType: types[name]})
+	}
+	return keys
+}
+
+// graphMLAttrType maps a value type to a GraphML attr.type. Anything without
+// a scalar GraphML equivalent (arrays, vectors, JSON, timestamps) is a string.
+func graphMLAttrType(t ValueType) string {
+	switch t {
+	case TypeInt:
+		return "long"
+	case TypeFloat:
+		return "double"
+	case TypeBool:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// writeGraphMLData writes a <data> element per property in sorted key order.
+func writeGraphMLData(bw *bufio.Writer, prefix string, props map[string]Value) {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(bw, "      <data key=%s>%s</data>\n", graphMLAttr(prefix+name), graphMLText(props[name].String()))
+	}
+}
+
+// graphMLText escapes s for use as XML character data.
+func graphMLText(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// graphMLAttr renders s as a double-quoted XML attribute value.
+func graphMLAttr(s string) string {
+	return `"` + graphMLText(s) + `"`
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestExportGraphML(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	plc := testNode(t, gs, []string{"PLC", "Controller"}, map[string]Value{
+		"name": StringValue("PLC <1>"),
+		"slot": IntValue(3),
+	})
+	hmi := testNode(t, gs, []string{"HMI"}, map[string]Value{
+		"name": StringValue("HMI-1"),
+		"slot": StringValue("rack-a"),
+	})
+	testEdge(t, gs, hmi.ID, plc.ID, "CONTROLS", map[string]Value{"latency": FloatValue(0.5)}, 2.5)
+
+	var buf bytes.Buffer
+	if err := ExportGraphML(gs, &buf, GraphMLOptions{}); err != nil {
+		t.Fatalf("ExportGraphML: %v", err)
+	}
+	out := buf.String()
+
+	// Must be well-formed XML.
+	dec := xml.NewDecoder(strings.NewReader(out))
+	for {
+		if _, err := dec.Token(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("malformed XML: %v\n%s", err, out)
+			}
+			break
+		}
+	}
+
+	for _, want := range []string{
+		`<graph id="graphdb" edgedefault="directed">`,
+		`<key id="n_name" for="node" attr.name="name" attr.type="string"/>`,
+		`<key id="n_slot" for="node" attr.name="slot" attr.type="string"/>`, // int vs string: widened
+		`<key id="e_latency" for="edge" attr.name="latency" attr.type="double"/>`,
+		`<data key="labels">PLC;Controller</data>`,
+		`<data key="n_name">PLC &lt;1&gt;</data>`,
+		`<data key="type">CONTROLS</data>`,
+		`<data key="weight">2.5</data>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "<node ") != 2 || strings.Count(out, "<edge ") != 1 {
+		t.Errorf("want 2 nodes and 1 edge\n%s", out)
+	}
+
+	if err := ExportGraphML(nil, &bytes.Buffer{}, GraphMLOptions{}); err == nil {
+		t.Error("expected error for nil graph")
+	}
+}
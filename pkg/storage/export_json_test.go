@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestExportJSON(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	plc := testNode(t, gs, []string{"PLC"}, map[string]Value{
+		"name": StringValue("PLC-1"),
+		"slot": IntValue(3),
+	})
+	hmi := testNode(t, gs, []string{"HMI", "Workstation"}, map[string]Value{
+		"name": StringValue("HMI-1"),
+	})
+	historian := testNode(t, gs, nil, nil)
+	testEdge(t, gs, hmi.ID, plc.ID, "CONTROLS", map[string]Value{"protocol": StringValue("modbus")}, 2.5)
+	testEdge(t, gs, historian.ID, plc.ID, "POLLS", nil, 1.0)
+
+	t.Run("full graph", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ExportJSON(gs, &buf, JSONOptions{}); err != nil {
+			t.Fatalf("ExportJSON: %v", err)
+		}
+		var doc JSONGraph
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+		}
+		if len(doc.Nodes) != 3 || len(doc.Edges) != 2 {
+			t.Fatalf("got %d nodes, %d edges; want 3, 2", len(doc.Nodes), len(doc.Edges))
+		}
+		if doc.Nodes[0].ID != plc.ID || doc.Nodes[1].ID != hmi.ID {
+			t.Errorf("nodes not in ascending-ID order: %+v", doc.Nodes)
+		}
+		if got := ValueFromJSON(doc.Nodes[0].Properties["slot"]); got.Type != TypeInt {
+			t.Errorf("slot round-tripped as type %v, want TypeInt", got.Type)
+		}
+		if doc.Nodes[2].Labels == nil || doc.Nodes[2].Properties == nil {
+			t.Errorf("unlabelled node should emit [] and {}, got %+v", doc.Nodes[2])
+		}
+		e := doc.Edges[0]
+		if e.From != hmi.ID || e.To != plc.ID || e.Type != "CONTROLS" || e.Weight != 2.5 || e.Properties["protocol"] != "modbus" {
+			t.Errorf("edge = %+v", e)
+		}
+	})
+
+	t.Run("subgraph drops dangling edges", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportJSON(gs, &buf, JSONOptions{
+			NodeIDs: map[uint64]struct{}{plc.ID: {}, historian.ID: {}},
+		})
+		if err != nil {
+			t.Fatalf("ExportJSON: %v", err)
+		}
+		var doc JSONGraph
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("output is not valid JSON: %v", err)
+		}
+		if len(doc.Nodes) != 2 || len(doc.Edges) != 1 || doc.Edges[0].Type != "POLLS" {
+			t.Errorf("subgraph = %+v", doc)
+		}
+	})
+
+	t.Run("nil graph", func(t *testing.T) {
+		if err := ExportJSON(nil, &bytes.Buffer{}, JSONOptions{}); err == nil {
+			t.Error("expected error for nil graph")
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dd0wney/graphdb/pkg/encryption"
+)
+
+// typeSealed tags a property value sealed by a fieldCipher. It only ever
+// appears in persisted form (snapshot, WAL): values are sealed as they are
+// written and opened as they are loaded, so the live graph never holds one.
+// Pinned far above the iota range so appending ValueTypes can't collide.
+const typeSealed ValueType = 255
+
+// ErrSensitivePropertyIndex is returned when a property index is requested
+// on a sensitive property: the index would hold the plaintext values and
+// persist them in every snapshot.
+var ErrSensitivePropertyIndex = errors.New("cannot index a sensitive property")
+
+// fieldCipher seals the values of sensitive property keys (see
+// StorageConfig.SensitiveProperties) with AES-GCM on their way to disk and
+// opens them on load. Immutable once built: RotateEncryptionKey swaps in a
+// new one under gs.mu, so readers may capture gs.fieldCipher under the lock
+// and use it after releasing it.
+type fieldCipher struct {
+	engine encryption.EncryptDecrypter
+	keys   map[string]struct{}
+	// sharesStoreKey: engine is the store's EncryptionEngine, so
+	// RotateEncryptionKey rotates it too.
+	sharesStoreKey bool
+}
+
+func newFieldCipher(config StorageConfig) (*fieldCipher, error) {
+	if len(config.SensitiveProperties) == 0 {
+		return nil, nil
+	}
+	fc := &fieldCipher{
+		engine: config.SensitivePropertyEngine,
+		keys:   make(map[string]struct{}, len(config.SensitiveProperties)),
+	}
+	if fc.engine == nil {
+		fc.engine = config.EncryptionEngine
+		fc.sharesStoreKey = true
+	}
+	if fc.engine == nil {
+		return nil, fmt.Errorf("SensitiveProperties needs an encryption engine (SensitivePropertyEngine, EncryptionEngine or EncryptionKey)")
+	}
+	for _, key := range config.SensitiveProperties {
+		fc.keys[key] = struct{}{}
+	}
+	return fc, nil
+}
+
+// sensitive reports whether values of key are sealed on disk. Safe on a
+// nil receiver (field encryption disabled).
+func (fc *fieldCipher) sensitive(key string) bool {
+	if fc == nil {
+		return false
+	}
+	_, ok := fc.keys[key]
+	return ok
+}
+
+// sealProperties returns props with every sensitive value sealed. props is
+// never modified; it is returned as-is when it holds no sensitive key.
+func (fc *fieldCipher) sealProperties(props map[string]Value) (map[string]Value, error) {
+	var sealed map[string]Value
+	for key, v := range props {
+		if !fc.sensitive(key) || v.Type == typeSealed {
+			continue
+		}
+		if sealed == nil {
+			sealed = make(map[string]Value, len(props))
+			for k, pv := range props {
+				sealed[k] = pv
+			}
+		}
+		plain := make([]byte, 1+len(v.Data))
+		plain[0] = byte(v.Type)
+		copy(plain[1:], v.Data)
+		ciphertext, err := fc.engine.Encrypt(plain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt property %q: %w", key, err)
+		}
+		sealed[key] = Value{Type: typeSealed, Data: ciphertext}
+	}
+	if sealed == nil {
+		return props, nil
+	}
+	return sealed, nil
+}
+
+// openProperties replaces every sealed value in props with its plaintext,
+// in place. Sealed values are opened whether or not their key is still
+// configured as sensitive; one with no engine to open it is an error, since
+// loading it as-is would hand ciphertext to the application.
+func (fc *fieldCipher) openProperties(props map[string]Value) error {
+	for key, v := range props {
+		if v.Type != typeSealed {
+			continue
+		}
+		if fc == nil {
+			return fmt.Errorf("property %q is encrypted but SensitiveProperties is not configured", key)
+		}
+		plain, err := fc.engine.Decrypt(v.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt property %q: %w", key, err)
+		}
+		if len(plain) == 0 {
+			return fmt.Errorf("failed to decrypt property %q: empty plaintext", key)
+		}
+		props[key] = Value{Type: ValueType(plain[0]), Data: plain[1:]}
+	}
+	return nil
+}
+
+// sealNode and sealEdge seal a snapshot clone in place.
+func (fc *fieldCipher) sealNode(n *Node) error {
+	props, err := fc.sealProperties(n.Properties)
+	if err != nil {
+		return fmt.Errorf("node %d: %w", n.ID, err)
+	}
+	n.Properties = props
+	return nil
+}
+
+func (fc *fieldCipher) sealEdge(e *Edge) error {
+	props, err := fc.sealProperties(e.Properties)
+	if err != nil {
+		return fmt.Errorf("edge %d: %w", e.ID, err)
+	}
+	e.Properties = props
+	return nil
+}
+
+// openNode and openEdge open a loaded or replayed entity in place.
+func (fc *fieldCipher) openNode(n *Node) error {
+	if n == nil {
+		return nil
+	}
+	if err := fc.openProperties(n.Properties); err != nil {
+		return fmt.Errorf("node %d: %w", n.ID, err)
+	}
+	return nil
+}
+
+func (fc *fieldCipher) openEdge(e *Edge) error {
+	if e == nil {
+		return nil
+	}
+	if err := fc.openProperties(e.Properties); err != nil {
+		return fmt.Errorf("edge %d: %w", e.ID, err)
+	}
+	return nil
+}
+
+// nodePropertiesUpdate is the OpUpdateNode WAL payload.
+type nodePropertiesUpdate struct {
+	NodeID     uint64
+	Properties map[string]Value
+}
+
+// marshalWAL encodes a WAL payload, sealing sensitive property values
+// first. Every WAL append goes through it so no write path can persist a
+// sensitive value in the clear. Callers hold gs.mu.
+func (gs *GraphStorage) marshalWAL(v any) ([]byte, error) {
+	if fc := gs.fieldCipher; fc != nil {
+		switch p := v.(type) {
+		case *Node:
+			props, err := fc.sealProperties(p.Properties)
+			if err != nil {
+				return nil, err
+			}
+			sealed := *p
+			sealed.Properties = props
+			v = &sealed
+		case *Edge:
+			props, err := fc.sealProperties(p.Properties)
+			if err != nil {
+				return nil, err
+			}
+			sealed := *p
+			sealed.Properties = props
+			v = &sealed
+		case nodePropertiesUpdate:
+			props, err := fc.sealProperties(p.Properties)
+			if err != nil {
+				return nil, err
+			}
+			v = nodePropertiesUpdate{NodeID: p.NodeID, Properties: props}
+		}
+	}
+	return json.Marshal(v)
+}
+
+// dropSensitivePropertyIndexesLocked removes property indexes on keys that
+// are now sensitive — created before the key was configured, and restored
+// from the snapshot or WAL. Caller holds gs.mu.
+func (gs *GraphStorage) dropSensitivePropertyIndexesLocked() {
+	for key := range gs.propertyIndexes {
+		if gs.fieldCipher.sensitive(key) {
+			delete(gs.propertyIndexes, key)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const fieldPlainMarker = "field-encryption-plain-marker-83b1"
+
+var fieldPlainWALMarker = base64.StdEncoding.EncodeToString([]byte(fieldPlainMarker))
+
+// sensitiveStorageConfig leaves the store itself unencrypted so the tests
+// can see that only the sensitive values are sealed.
+func sensitiveStorageConfig(t *testing.T, dir string) StorageConfig {
+	t.Helper()
+	cfg := DefaultStorageConfig(dir)
+	cfg.SensitiveProperties = []string{"secret"}
+	cfg.SensitivePropertyEngine = testEncryptionEngine(t)
+	return cfg
+}
+
+// createSensitiveGraph writes a node (create + update) and an edge, each
+// carrying a sensitive and a plain property.
+func createSensitiveGraph(t *testing.T, gs *GraphStorage) (nodeID, edgeID uint64) {
+	t.Helper()
+	node := createH3Node(t, gs)
+	if err := gs.UpdateNode(node.ID, map[string]Value{
+		"secret": StringValue(h3Marker),
+		"title":  StringValue(fieldPlainMarker),
+	}); err != nil {
+		t.Fatalf("update node: %v", err)
+	}
+	other := createH3Node(t, gs)
+	edge, err := gs.CreateEdge(node.ID, other.ID, "LINKS", map[string]Value{
+		"secret": StringValue(h3Marker),
+		"title":  StringValue(fieldPlainMarker),
+	}, 1)
+	if err != nil {
+		t.Fatalf("create edge: %v", err)
+	}
+	return node.ID, edge.ID
+}
+
+func assertSensitiveGraph(t *testing.T, gs *GraphStorage, nodeID, edgeID uint64) {
+	t.Helper()
+	node, err := gs.GetNode(nodeID)
+	if err != nil {
+		t.Fatalf("get node: %v", err)
+	}
+	edge, err := gs.GetEdge(edgeID)
+	if err != nil {
+		t.Fatalf("get edge: %v", err)
+	}
+	for what, props := range map[string]map[string]Value{"node": node.Properties, "edge": edge.Properties} {
+		if s, err := props["secret"].AsString(); err != nil || s != h3Marker {
+			t.Errorf("%s secret = %q (%v), want the plaintext", what, s, err)
+		}
+		if s, err := props["title"].AsString(); err != nil || s != fieldPlainMarker {
+			t.Errorf("%s title = %q (%v), want %q", what, s, err, fieldPlainMarker)
+		}
+	}
+}
+
+func TestSensitiveProperties_WALIsSealed(t *testing.T) {
+	dir := t.TempDir()
+	gs, err := NewGraphStorageWithConfig(sensitiveStorageConfig(t, dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeID, edgeID := createSensitiveGraph(t, gs)
+	// Crash-sim: no Close, so everything is only in the WAL.
+
+	var sawPlain bool
+	for _, e := range readPlainWALEntries(t, dir) {
+		if bytes.Contains(e.Data, []byte(h3WALMarker)) {
+			t.Fatalf("WAL entry (op=%d) holds a sensitive value in the clear", e.OpType)
+		}
+		sawPlain = sawPlain || bytes.Contains(e.Data, []byte(fieldPlainWALMarker))
+	}
+	if !sawPlain {
+		t.Error("non-sensitive property missing from the WAL in the clear")
+	}
+
+	gs2, err := NewGraphStorageWithConfig(sensitiveStorageConfig(t, dir))
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer func() { _ = gs2.Close() }()
+	assertSensitiveGraph(t, gs2, nodeID, edgeID)
+}
+
+func TestSensitiveProperties_SnapshotIsSealed(t *testing.T) {
+	dir := t.TempDir()
+	gs, err := NewGraphStorageWithConfig(sensitiveStorageConfig(t, dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeID, edgeID := createSensitiveGraph(t, gs)
+	if err := gs.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "snapshot.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(h3WALMarker)) {
+		t.Fatal("snapshot holds a sensitive value in the clear")
+	}
+	if !bytes.Contains(data, []byte(fieldPlainWALMarker)) {
+		t.Error("non-sensitive property missing from the snapshot in the clear")
+	}
+
+	gs2, err := NewGraphStorageWithConfig(sensitiveStorageConfig(t, dir))
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer func() { _ = gs2.Close() }()
+	assertSensitiveGraph(t, gs2, nodeID, edgeID)
+
+	// Sealed values never load as ciphertext: without the key, opening fails.
+	if _, err := NewGraphStorageWithConfig(DefaultStorageConfig(dir)); err == nil {
+		t.Error("store with sealed properties opened without SensitiveProperties")
+	}
+}
+
+func TestSensitiveProperties_RefusesPropertyIndex(t *testing.T) {
+	gs := testGraphStorage(t, sensitiveStorageConfig(t, t.TempDir()))
+	if err := gs.CreatePropertyIndex("secret", TypeString); !errors.Is(err, ErrSensitivePropertyIndex) {
+		t.Errorf("CreatePropertyIndex(secret) = %v, want ErrSensitivePropertyIndex", err)
+	}
+	if err := gs.CreatePropertyIndex("title", TypeString); err != nil {
+		t.Errorf("CreatePropertyIndex(title) = %v", err)
+	}
+}
+
+func TestSensitiveProperties_RequiresEngine(t *testing.T) {
+	cfg := DefaultStorageConfig(t.TempDir())
+	cfg.SensitiveProperties = []string{"secret"}
+	if _, err := NewGraphStorageWithConfig(cfg); err == nil {
+		t.Error("SensitiveProperties without an engine was accepted")
+	}
+}
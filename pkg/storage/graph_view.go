@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// GraphView is an immutable, point-in-time copy of the graph for long-running
+// read-only work such as whole-graph algorithms. It is built by
+// [GraphStorage.View] under the global read lock, so it reflects exactly the
+// writes that completed before the call; writes that land afterwards are not
+// visible and cannot disturb a running analysis.
+//
+// A GraphView holds deep copies of every node and edge, so it costs memory
+// proportional to the graph and takes no locks afterwards. Its read methods
+// mirror the GraphStorage getters of the same name and also return clones, so
+// a caller mutating a result cannot corrupt the view for other readers. A
+// GraphView is safe for concurrent use.
+type GraphView struct {
+	nodes    map[uint64]*Node
+	edges    map[uint64]*Edge
+	outgoing map[uint64][]uint64 // node ID -> outgoing edge IDs
+	incoming map[uint64][]uint64 // node ID -> incoming edge IDs
+	nodeIDs  []uint64            // ascending, for deterministic enumeration
+	stats    Statistics
+	takenAt  time.Time
+
+	// ID counters at the time of the view, for Backup. Writers bump them
+	// under gs.mu, so they are read under the same lock as the graph.
+	nextNodeID uint64
+	nextEdgeID uint64
+}
+
+// View returns a consistent read-only snapshot of the graph. See [GraphView].
+func (gs *GraphStorage) View() (*GraphView, error) {
+	if err := gs.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	v := &GraphView{
+		nodes:    make(map[uint64]*Node, gs.nodeCount()),
+		edges:    make(map[uint64]*Edge, gs.edgeCount()),
+		outgoing: make(map[uint64][]uint64),
+		incoming: make(map[uint64][]uint64),
+		takenAt:  time.Now(),
+	}
+
+	// forEachNodeUnlocked covers the mmap base as well as the shard overlay;
+	// adjacency goes through getEdgeIDsForNode for the same reason (it knows
+	// about the compressed and disk-backed edge layouts).
+	gs.forEachNodeUnlocked(func(node *Node) bool {
+		v.nodes[node.ID] = node.Clone()
+		v.nodeIDs = append(v.nodeIDs, node.ID)
+		return true
+	})
+	slices.Sort(v.nodeIDs)
+
+	for _, id := range v.nodeIDs {
+		v.outgoing[id] = v.copyEdgesLocked(gs, gs.getEdgeIDsForNode(id, true))
+		v.incoming[id] = v.copyEdgesLocked(gs, gs.getEdgeIDsForNode(id, false))
+	}
+	// Undirected edges are listed at both endpoints, as GraphStorage's
+	// readers do; edgeList orients them.
+	if gs.hasUndirected.Load() {
+		for _, id := range v.nodeIDs {
+			out, in := v.outgoing[id], v.incoming[id]
+			v.outgoing[id] = v.appendMirrored(out, in)
+			v.incoming[id] = v.appendMirrored(in, out)
+		}
+	}
+
+	v.stats = Statistics{
+		NodeCount:    uint64(len(v.nodes)),
+		EdgeCount:    uint64(len(v.edges)),
+		TotalQueries: atomic.LoadUint64(&gs.stats.TotalQueries),
+		LastSnapshot: gs.lastSnapshotTime(),
+		Version:      gs.Version(),
+	}
+	v.nextNodeID = gs.nextNodeID
+	v.nextEdgeID = gs.nextEdgeID
+	return v, nil
+}
+
+// copyEdgesLocked clones the edges named by ids into v (once per edge) and
+// returns a private copy of the ID list. Caller holds gs.mu.RLock.
+func (v *GraphView) copyEdgesLocked(gs *GraphStorage, ids []uint64) []uint64 {
+	out := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if _, seen := v.edges[id]; !seen {
+			edge, _, ok := gs.resolveEdgeRefOwnedLocked(id)
+			if !ok {
+				continue
+			}
+			v.edges[id] = edge.Clone()
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+// appendMirrored appends to dst the non-loop undirected edges among ids.
+func (v *GraphView) appendMirrored(dst, ids []uint64) []uint64 {
+	for _, id := range ids {
+		if edge := v.edges[id]; edge.Undirected && edge.FromNodeID != edge.ToNodeID {
+			dst = append(dst, id)
+		}
+	}
+	return dst
+}
+
+// TakenAt returns the time the view was captured.
+func (v *GraphView) TakenAt() time.Time {
+	return v.takenAt
+}
+
+// GetStatistics returns the node and edge counts as of the view. Query
+// timings are not tracked for views.
+func (v *GraphView) GetStatistics() Statistics {
+	return v.stats
+}
+
+// GetNode returns a node from the view.
+func (v *GraphView) GetNode(nodeID uint64) (*Node, error) {
+	node, ok := v.nodes[nodeID]
+	if !ok {
+		return nil, ErrNodeNotFound
+	}
+	return node.Clone(), nil
+}
+
+// GetNodeForTenant returns a node from the view if it belongs to tenantID.
+// Cross-tenant lookups return ErrNodeNotFound, as in GraphStorage.
+func (v *GraphView) GetNodeForTenant(nodeID uint64, tenantID string) (*Node, error) {
+	node, ok := v.nodes[nodeID]
+	if !ok || node.TenantID != effectiveTenantID(tenantID).String() {
+		return nil, ErrNodeNotFound
+	}
+	return node.Clone(), nil
+}
+
+// GetEdge returns an edge from the view.
+func (v *GraphView) GetEdge(edgeID uint64) (*Edge, error) {
+	edge, ok := v.edges[edgeID]
+	if !ok {
+		return nil, ErrEdgeNotFound
+	}
+	return edge.Clone(), nil
+}
+
+// GetEdgeForTenant returns an edge from the view if it belongs to tenantID.
+func (v *GraphView) GetEdgeForTenant(edgeID uint64, tenantID string) (*Edge, error) {
+	edge, ok := v.edges[edgeID]
+	if !ok || edge.TenantID != effectiveTenantID(tenantID).String() {
+		return nil, ErrEdgeNotFound
+	}
+	return edge.Clone(), nil
+}
+
+// GetAllNodeIDs returns every node ID in the view in ascending order.
+func (v *GraphView) GetAllNodeIDs() []uint64 {
+	return slices.Clone(v.nodeIDs)
+}
+
+// GetAllNodesAcrossTenants returns every node in the view, in ID order.
+func (v *GraphView) GetAllNodesAcrossTenants() []*Node {
+	nodes := make([]*Node, 0, len(v.nodeIDs))
+	for _, id := range v.nodeIDs {
+		nodes = append(nodes, v.nodes[id].Clone())
+	}
+	return nodes
+}
+
+// GetAllNodesForTenant returns the tenant's nodes in the view, in ID order.
+func (v *GraphView) GetAllNodesForTenant(tenantID string) []*Node {
+	expected := effectiveTenantID(tenantID).String()
+	nodes := make([]*Node, 0)
+	for _, id := range v.nodeIDs {
+		if node := v.nodes[id]; node.TenantID == expected {
+			nodes = append(nodes, node.Clone())
+		}
+	}
+	return nodes
+}
+
+// GetOutgoingEdges returns the outgoing edges of a node in the view.
+func (v *GraphView) GetOutgoingEdges(nodeID uint64) ([]*Edge, error) {
+	return v.edgeList(nodeID, true, v.outgoing[nodeID], ""), nil
+}
+
+// GetOutgoingEdgesForTenant returns the tenant's outgoing edges of a node.
+func (v *GraphView) GetOutgoingEdgesForTenant(nodeID uint64, tenantID string) ([]*Edge, error) {
+	return v.edgeList(nodeID, true, v.outgoing[nodeID], effectiveTenantID(tenantID).String()), nil
+}
+
+// GetIncomingEdges returns the incoming edges of a node in the view.
+func (v *GraphView) GetIncomingEdges(nodeID uint64) ([]*Edge, error) {
+	return v.edgeList(nodeID, false, v.incoming[nodeID], ""), nil
+}
+
+// GetIncomingEdgesForTenant returns the tenant's incoming edges of a node.
+func (v *GraphView) GetIncomingEdgesForTenant(nodeID uint64, tenantID string) ([]*Edge, error) {
+	return v.edgeList(nodeID, false, v.incoming[nodeID], effectiveTenantID(tenantID).String()), nil
+}
+
+// edgeList clones the edges named by ids, keeping only tenantID's edges
+// when tenantID is non-empty. ids is nodeID's outgoing or incoming list;
+// undirected edges stored the other way round are returned reversed.
+func (v *GraphView) edgeList(nodeID uint64, outgoing bool, ids []uint64, tenantID string) []*Edge {
+	edges := make([]*Edge, 0, len(ids))
+	for _, id := range ids {
+		edge := v.edges[id]
+		if tenantID != "" && edge.TenantID != tenantID {
+			continue
+		}
+		if edge.Undirected && (outgoing && edge.FromNodeID != nodeID || !outgoing && edge.ToNodeID != nodeID) {
+			edges = append(edges, edge.reversed())
+			continue
+		}
+		edges = append(edges, edge.Clone())
+	}
+	return edges
+}
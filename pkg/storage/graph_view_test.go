@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGraphView_IsolatedFromLaterWrites(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	a := testNode(t, gs, []string{"A"}, map[string]Value{"name": StringValue("a")})
+	b := testNode(t, gs, []string{"B"}, nil)
+	e := testEdge(t, gs, a.ID, b.ID, "LINKS", nil, 1.0)
+
+	view, err := gs.View()
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+
+	// Mutate the live graph after the view is taken.
+	c := testNode(t, gs, []string{"C"}, nil)
+	testEdge(t, gs, a.ID, c.ID, "LINKS", nil, 1.0)
+	if err := gs.UpdateNode(a.ID, map[string]Value{"name": StringValue("changed")}); err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+	if err := gs.DeleteNode(b.ID); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+
+	if got := view.GetStatistics(); got.NodeCount != 2 || got.EdgeCount != 1 {
+		t.Errorf("view stats = %d nodes / %d edges, want 2 / 1", got.NodeCount, got.EdgeCount)
+	}
+	if ids := view.GetAllNodeIDs(); len(ids) != 2 || ids[0] != a.ID || ids[1] != b.ID {
+		t.Errorf("GetAllNodeIDs = %v, want [%d %d]", ids, a.ID, b.ID)
+	}
+	if _, err := view.GetNode(c.ID); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("node created after View visible: err = %v", err)
+	}
+	if _, err := view.GetNode(b.ID); err != nil {
+		t.Errorf("node deleted after View missing from view: %v", err)
+	}
+
+	node, err := view.GetNode(a.ID)
+	if err != nil {
+		t.Fatalf("GetNode: %v", err)
+	}
+	if name, _ := node.Properties["name"].AsString(); name != "a" {
+		t.Errorf("view saw later update: name = %q", name)
+	}
+
+	out, _ := view.GetOutgoingEdges(a.ID)
+	if len(out) != 1 || out[0].ID != e.ID {
+		t.Errorf("outgoing edges = %v, want only edge %d", out, e.ID)
+	}
+	in, _ := view.GetIncomingEdges(b.ID)
+	if len(in) != 1 || in[0].ID != e.ID {
+		t.Errorf("incoming edges = %v, want only edge %d", in, e.ID)
+	}
+}
+
+func TestGraphView_ReadsReturnClones(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"A"}, map[string]Value{"name": StringValue("a")})
+
+	view, err := gs.View()
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+
+	node, _ := view.GetNode(a.ID)
+	node.Properties["name"] = StringValue("mutated")
+	node.Labels[0] = "Mutated"
+
+	again, _ := view.GetNode(a.ID)
+	if name, _ := again.Properties["name"].AsString(); name != "a" || again.Labels[0] != "A" {
+		t.Errorf("mutating a returned node changed the view: %+v", again)
+	}
+}
+
+func TestGraphView_TenantScoping(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	a, err := gs.CreateNodeWithTenant("acme", []string{"X"}, nil)
+	if err != nil {
+		t.Fatalf("create acme node: %v", err)
+	}
+	b, err := gs.CreateNodeWithTenant("acme", []string{"X"}, nil)
+	if err != nil {
+		t.Fatalf("create acme node: %v", err)
+	}
+	other, err := gs.CreateNodeWithTenant("globex", []string{"X"}, nil)
+	if err != nil {
+		t.Fatalf("create globex node: %v", err)
+	}
+	e, err := gs.CreateEdgeWithTenant("acme", a.ID, b.ID, "LINKS", nil, 1.0)
+	if err != nil {
+		t.Fatalf("create edge: %v", err)
+	}
+
+	view, err := gs.View()
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+
+	if nodes := view.GetAllNodesForTenant("acme"); len(nodes) != 2 {
+		t.Errorf("acme nodes = %d, want 2", len(nodes))
+	}
+	if _, err := view.GetNodeForTenant(other.ID, "acme"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("cross-tenant GetNodeForTenant err = %v, want ErrNodeNotFound", err)
+	}
+	if _, err := view.GetEdgeForTenant(e.ID, "globex"); !errors.Is(err, ErrEdgeNotFound) {
+		t.Errorf("cross-tenant GetEdgeForTenant err = %v, want ErrEdgeNotFound", err)
+	}
+	if out, _ := view.GetOutgoingEdgesForTenant(a.ID, "globex"); len(out) != 0 {
+		t.Errorf("globex sees %d of acme's edges", len(out))
+	}
+	if out, _ := view.GetOutgoingEdgesForTenant(a.ID, "acme"); len(out) != 1 {
+		t.Errorf("acme outgoing edges = %d, want 1", len(out))
+	}
+}
+
+func TestGraphView_ClosedStorage(t *testing.T) {
+	gs := testGraphStorage(t)
+	if err := gs.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := gs.View(); err == nil {
+		t.Error("View on closed storage should fail")
+	}
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvLabelSeparator splits the nodes CSV "labels" column into multiple
+// labels (e.g. "PLC;Controller").
+const csvLabelSeparator = ";"
+
+// ImportNodesCSV creates one node per CSV row in the default tenant and
+// returns the number created.
+//
+// The header row names the columns; names are trimmed and lower-cased (see
+// csvColumnKey), and the result is both the column's lookup key and its
+// property key. "name" is required and stored as the
+// "name" property; it is the key ImportEdgesCSV resolves endpoints by.
+// "labels" is optional and split on ';'. Every other column ("zone" and any
+// model-specific fields) becomes a string property; empty cells are skipped.
+func ImportNodesCSV(g *GraphStorage, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("import nodes CSV: read header: %w", err)
+	}
+	cols := csvColumnIndex(header)
+	nameCol, ok := cols["name"]
+	if !ok {
+		return 0, fmt.Errorf("import nodes CSV: missing required column %q", "name")
+	}
+	labelsCol, hasLabels := cols["labels"]
+
+	created := 0
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return created, nil
+		}
+		if err != nil {
+			return created, fmt.Errorf("import nodes CSV: line %d: %w", line, err)
+		}
+
+		name := strings.TrimSpace(record[nameCol])
+		if name == "" {
+			return created, fmt.Errorf("import nodes CSV: line %d: empty name", line)
+		}
+
+		var labels []string
+		if hasLabels {
+			for _, l := range strings.Split(record[labelsCol], csvLabelSeparator) {
+				if l = strings.TrimSpace(l); l != "" {
+					labels = append(labels, l)
+				}
+			}
+		}
+
+		props := make(map[string]Value, len(header))
+		for i, key := range header {
+			if i == nameCol || (hasLabels && i == labelsCol) {
+				continue
+			}
+			val := strings.TrimSpace(record[i])
+			if val == "" {
+				continue
+			}
+			props[csvColumnKey(key)] = StringValue(val)
+		}
+		props["name"] = StringValue(name)
+
+		if _, err := g.CreateNode(labels, props); err != nil {
+			return created, fmt.Errorf("import nodes CSV: line %d: %w", line, err)
+		}
+		created++
+	}
+}
+
+// ImportEdgesCSV creates one edge per CSV row in the default tenant and
+// returns the number created.
+//
+// Required columns are "from", "to" and "type"; "from"/"to" hold node names
+// and are resolved against the "name" property of existing nodes, so nodes
+// must be imported first. "weight" is optional and defaults to 1.0. Any other
+// column becomes a string edge property, keyed as in ImportNodesCSV. A name
+// that matches no node, or more than one, is an error.
+func ImportEdgesCSV(g *GraphStorage, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("import edges CSV: read header: %w", err)
+	}
+	cols := csvColumnIndex(header)
+	for _, required := range []string{"from", "to", "type"} {
+		if _, ok := cols[required]; !ok {
+			return 0, fmt.Errorf("import edges CSV: missing required column %q", required)
+		}
+	}
+	fromCol, toCol, typeCol := cols["from"], cols["to"], cols["type"]
+	weightCol, hasWeight := cols["weight"]
+
+	names := buildNodeNameIndex(g)
+	resolve := func(name string) (uint64, error) {
+		ids := names[name]
+		switch len(ids) {
+		case 0:
+			return 0, fmt.Errorf("unknown node name %q", name)
+		case 1:
+			return ids[0], nil
+		default:
+			return 0, fmt.Errorf("ambiguous node name %q matches %d nodes", name, len(ids))
+		}
+	}
+
+	created := 0
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return created, nil
+		}
+		if err != nil {
+			return created, fmt.Errorf("import edges CSV: line %d: %w", line, err)
+		}
+
+		fromID, err := resolve(strings.TrimSpace(record[fromCol]))
+		if err != nil {
+			return created, fmt.Errorf("import edges CSV: line %d: from: %w", line, err)
+		}
+		toID, err := resolve(strings.TrimSpace(record[toCol]))
+		if err != nil {
+			return created, fmt.Errorf("import edges CSV: line %d: to: %w", line, err)
+		}
+		edgeType := strings.TrimSpace(record[typeCol])
+		if edgeType == "" {
+			return created, fmt.Errorf("import edges CSV: line %d: empty type", line)
+		}
+
+		weight := 1.0
+		if hasWeight {
+			if raw := strings.TrimSpace(record[weightCol]); raw != "" {
+				weight, err = strconv.ParseFloat(raw, 64)
+				if err != nil {
+					return created, fmt.Errorf("import edges CSV: line %d: weight %q: %w", line, raw, err)
+				}
+			}
+		}
+
+		props := make(map[string]Value)
+		for i, key := range header {
+			if i == fromCol || i == toCol || i == typeCol || (hasWeight && i == weightCol) {
+				continue
+			}
+			if val := strings.TrimSpace(record[i]); val != "" {
+				props[csvColumnKey(key)] = StringValue(val)
+			}
+		}
+
+		if _, err := g.CreateEdge(fromID, toID, edgeType, props, weight); err != nil {
+			return created, fmt.Errorf("import edges CSV: line %d: %w", line, err)
+		}
+		created++
+	}
+}
+
+// csvColumnIndex maps header names, by csvColumnKey, to column positions.
+func csvColumnIndex(header []string) map[string]int {
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[csvColumnKey(h)] = i
+	}
+	return cols
+}
+
+// csvColumnKey is the canonical key for a header name: trimmed and
+// lower-cased, so "Name" and " name" both find the name column and store
+// one "name" property.
+func csvColumnKey(header string) string {
+	return strings.ToLower(strings.TrimSpace(header))
+}
+
+// buildNodeNameIndex maps each "name" property in the default tenant to the
+// IDs of the nodes carrying it.
+func buildNodeNameIndex(g *GraphStorage) map[string][]uint64 {
+	index := make(map[string][]uint64)
+	for _, n := range g.GetAllNodesForTenant(DefaultTenantID) {
+		if v, ok := n.Properties["name"]; ok && v.Type == TypeString {
+			name, _ := v.AsString()
+			index[name] = append(index[name], n.ID)
+		}
+	}
+	return index
+}
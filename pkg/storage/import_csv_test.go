@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImportCSV(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	nodesCSV := `name,labels,zone,vendor
+PLC-1,PLC;Controller,control,Siemens
+HMI-1,HMI,dmz,
+Historian,Server,dmz,OSIsoft
+`
+	n, err := ImportNodesCSV(gs, strings.NewReader(nodesCSV))
+	if err != nil {
+		t.Fatalf("ImportNodesCSV: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("ImportNodesCSV created %d nodes, want 3", n)
+	}
+
+	plcs, err := gs.FindNodesByProperty("name", StringValue("PLC-1"))
+	if err != nil || len(plcs) != 1 {
+		t.Fatalf("find PLC-1: %v (n=%d)", err, len(plcs))
+	}
+	plc := plcs[0]
+	if len(plc.Labels) != 2 || plc.Labels[0] != "PLC" || plc.Labels[1] != "Controller" {
+		t.Errorf("PLC-1 labels = %v, want [PLC Controller]", plc.Labels)
+	}
+	if zone, _ := plc.Properties["zone"].AsString(); zone != "control" {
+		t.Errorf("PLC-1 zone = %q, want control", zone)
+	}
+	if vendor, _ := plc.Properties["vendor"].AsString(); vendor != "Siemens" {
+		t.Errorf("PLC-1 vendor = %q, want Siemens", vendor)
+	}
+	if _, ok := plc.Properties["labels"]; ok {
+		t.Error("labels column leaked into properties")
+	}
+
+	edgesCSV := `from,to,type,weight,protocol
+HMI-1,PLC-1,CONTROLS,0.5,modbus
+Historian,HMI-1,READS,,
+`
+	e, err := ImportEdgesCSV(gs, strings.NewReader(edgesCSV))
+	if err != nil {
+		t.Fatalf("ImportEdgesCSV: %v", err)
+	}
+	if e != 2 {
+		t.Fatalf("ImportEdgesCSV created %d edges, want 2", e)
+	}
+
+	in, err := gs.GetIncomingEdges(plc.ID)
+	if err != nil || len(in) != 1 {
+		t.Fatalf("PLC-1 incoming: %v (n=%d)", err, len(in))
+	}
+	if in[0].Type != "CONTROLS" || in[0].Weight != 0.5 {
+		t.Errorf("edge = %s/%g, want CONTROLS/0.5", in[0].Type, in[0].Weight)
+	}
+	if proto, _ := in[0].Properties["protocol"].AsString(); proto != "modbus" {
+		t.Errorf("edge protocol = %q, want modbus", proto)
+	}
+
+	reads, err := gs.FindEdgesByTypeAcrossTenants("READS")
+	if err != nil || len(reads) != 1 {
+		t.Fatalf("READS edges: %v (n=%d)", err, len(reads))
+	}
+	if reads[0].Weight != 1.0 {
+		t.Errorf("default weight = %g, want 1.0", reads[0].Weight)
+	}
+}
+
+func TestImportCSVHeaderCase(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	if _, err := ImportNodesCSV(gs, strings.NewReader("Name, Zone\nPLC-1,control\nHMI-1,dmz\n")); err != nil {
+		t.Fatalf("ImportNodesCSV: %v", err)
+	}
+	plcs, err := gs.FindNodesByProperty("name", StringValue("PLC-1"))
+	if err != nil || len(plcs) != 1 {
+		t.Fatalf("find PLC-1: %v (n=%d)", err, len(plcs))
+	}
+	props := plcs[0].Properties
+	if len(props) != 2 {
+		t.Errorf("properties = %v, want only name and zone", props)
+	}
+	if zone, _ := props["zone"].AsString(); zone != "control" {
+		t.Errorf("zone = %q, want control", zone)
+	}
+
+	if _, err := ImportEdgesCSV(gs, strings.NewReader("From,TO,Type,Protocol\nHMI-1,PLC-1,CONTROLS,modbus\n")); err != nil {
+		t.Fatalf("ImportEdgesCSV: %v", err)
+	}
+	in, err := gs.GetIncomingEdges(plcs[0].ID)
+	if err != nil || len(in) != 1 {
+		t.Fatalf("PLC-1 incoming: %v (n=%d)", err, len(in))
+	}
+	if proto, _ := in[0].Properties["protocol"].AsString(); proto != "modbus" || len(in[0].Properties) != 1 {
+		t.Errorf("edge properties = %v, want only protocol", in[0].Properties)
+	}
+}
+
+func TestImportCSVErrors(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	if _, err := ImportNodesCSV(gs, strings.NewReader("labels,zone\nPLC,control\n")); err == nil {
+		t.Error("nodes CSV without name column: want error")
+	}
+	if _, err := ImportEdgesCSV(gs, strings.NewReader("from,to\na,b\n")); err == nil {
+		t.Error("edges CSV without type column: want error")
+	}
+
+	if _, err := ImportNodesCSV(gs, strings.NewReader("name\nA\nA\n")); err != nil {
+		t.Fatalf("ImportNodesCSV: %v", err)
+	}
+	_, err := ImportEdgesCSV(gs, strings.NewReader("from,to,type\nA,B,LINKS\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("unresolvable edge: err = %v, want line-numbered error", err)
+	}
+	_, err = ImportEdgesCSV(gs, strings.NewReader("from,to,type\nA,A,LINKS\n"))
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("duplicate name: err = %v, want ambiguous-name error", err)
+	}
+}
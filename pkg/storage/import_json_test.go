@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestImportJSON_RoundTrip(t *testing.T) {
+	src := testGraphStorage(t)
+	plc := testNode(t, src, []string{"PLC"}, map[string]Value{
+		"name": StringValue("PLC-1"),
+		"slot": IntValue(3),
+	})
+	hmi := testNode(t, src, []string{"HMI"}, map[string]Value{"name": StringValue("HMI-1")})
+	testEdge(t, src, hmi.ID, plc.ID, "CONTROLS", map[string]Value{"protocol": StringValue("modbus")}, 0.5)
+
+	var buf bytes.Buffer
+	if err := ExportJSON(src, &buf, JSONOptions{}); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+
+	dst := testGraphStorage(t)
+	testNode(t, dst, []string{"Existing"}, nil) // shifts IDs so remapping matters
+	nodes, edges, err := ImportJSON(dst, &buf)
+	if err != nil {
+		t.Fatalf("ImportJSON: %v", err)
+	}
+	if nodes != 2 || edges != 1 {
+		t.Fatalf("ImportJSON = %d nodes, %d edges; want 2, 1", nodes, edges)
+	}
+
+	found, err := dst.FindNodesByProperty("name", StringValue("PLC-1"))
+	if err != nil || len(found) != 1 {
+		t.Fatalf("find PLC-1: %v (n=%d)", err, len(found))
+	}
+	if slot, _ := found[0].Properties["slot"].AsInt(); slot != 3 {
+		t.Errorf("slot = %d, want 3", slot)
+	}
+	in, err := dst.GetIncomingEdges(found[0].ID)
+	if err != nil || len(in) != 1 {
+		t.Fatalf("PLC-1 incoming: %v (n=%d)", err, len(in))
+	}
+	if in[0].Type != "CONTROLS" || in[0].Weight != 0.5 {
+		t.Errorf("edge = %s/%g, want CONTROLS/0.5", in[0].Type, in[0].Weight)
+	}
+	from, err := dst.GetNode(in[0].FromNodeID)
+	if err != nil || from.Labels[0] != "HMI" {
+		t.Errorf("edge source = %+v, want the imported HMI", from)
+	}
+}
+
+func TestImportJSON_UndirectedEdge(t *testing.T) {
+	src := testGraphStorage(t)
+	a := testNode(t, src, []string{"Switch"}, nil)
+	b := testNode(t, src, []string{"Switch"}, nil)
+	if _, err := src.CreateUndirectedEdge(a.ID, b.ID, "TRUNK", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := ExportJSON(src, &buf, JSONOptions{}); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"undirected":true`) {
+		t.Fatalf("export does not mark the edge undirected: %s", buf.String())
+	}
+
+	dst := testGraphStorage(t)
+	if _, edges, err := ImportJSON(dst, &buf); err != nil || edges != 1 {
+		t.Fatalf("ImportJSON = %d edges, %v; want 1", edges, err)
+	}
+	out, err := dst.GetOutgoingEdges(b.ID)
+	if err != nil || len(out) != 1 || !out[0].Undirected || out[0].ToNodeID != a.ID {
+		t.Errorf("imported edge from node %d = %v, %v; want undirected edge to %d", b.ID, out, err, a.ID)
+	}
+}
+
+func TestImportJSON_RejectsBeforeCreating(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"malformed", `{"nodes": [`},
+		{"dangling edge", `{"nodes":[{"id":1}],"edges":[{"from":1,"to":9,"type":"X"}]}`},
+		{"duplicate id", `{"nodes":[{"id":1},{"id":1}]}`},
+		{"empty type", `{"nodes":[{"id":1}],"edges":[{"from":1,"to":1}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := testGraphStorage(t)
+			nodes, edges, err := ImportJSON(gs, strings.NewReader(tt.doc))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if nodes != 0 || edges != 0 || gs.GetStatistics().NodeCount != 0 {
+				t.Errorf("created %d nodes, %d edges before failing", nodes, edges)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ func NewPropertyIndex(propertyKey string, indexType ValueType) *PropertyIndex {
 // Insert adds a node to the index
 func (idx *PropertyIndex) Insert(nodeID uint64, value Value) error {
 	if value.Type != idx.indexType {
-		return fmt.Errorf("value type mismatch: expected %v, got %v", idx.indexType, value.Type)
+		return fmt.Errorf("%w: value type mismatch: expected %v, got %v", ErrInvalidProperty, idx.indexType, value.Type)
 	}
 
 	idx.mu.Lock()
@@ -72,7 +72,7 @@ func (idx *PropertyIndex) Remove(nodeID uint64, value Value) error {
 // Lookup finds all nodes with a specific property value
 func (idx *PropertyIndex) Lookup(value Value) ([]uint64, error) {
 	if value.Type != idx.indexType {
-		return nil, fmt.Errorf("value type mismatch: expected %v, got %v", idx.indexType, value.Type)
+		return nil, fmt.Errorf("%w: value type mismatch: expected %v, got %v", ErrInvalidProperty, idx.indexType, value.Type)
 	}
 
 	idx.mu.RLock()
@@ -92,7 +92,7 @@ func (idx *PropertyIndex) Lookup(value Value) ([]uint64, error) {
 // This is useful for numeric and timestamp ranges
 func (idx *PropertyIndex) RangeLookup(start, end Value) ([]uint64, error) {
 	if start.Type != idx.indexType || end.Type != idx.indexType {
-		return nil, fmt.Errorf("value type mismatch")
+		return nil, fmt.Errorf("%w: value type mismatch", ErrInvalidProperty)
 	}
 
 	idx.mu.RLock()
@@ -115,7 +115,7 @@ func (idx *PropertyIndex) RangeLookup(start, end Value) ([]uint64, error) {
 // PrefixLookup finds all nodes with string properties starting with a prefix
 func (idx *PropertyIndex) PrefixLookup(prefix string) ([]uint64, error) {
 	if idx.indexType != TypeString {
-		return nil, fmt.Errorf("prefix lookup only supported for string indexes")
+		return nil, fmt.Errorf("%w: prefix lookup only supported for string indexes", ErrInvalidArgument)
 	}
 
 	idx.mu.RLock()
@@ -276,7 +276,7 @@ func (idx *CompositeIndex) Insert(nodeID uint64, values []Value) error {
 	// Validate types
 	for i, val := range values {
 		if val.Type != idx.indexTypes[i] {
-			return fmt.Errorf("type mismatch at position %d: expected %v, got %v",
+			return fmt.Errorf("%w: type mismatch at position %d: expected %v, got %v", ErrInvalidProperty,
 				i, idx.indexTypes[i], val.Type)
 		}
 	}
@@ -326,7 +326,7 @@ func (idx *CompositeIndex) Lookup(values []Value) ([]uint64, error) {
 	// Validate types
 	for i, val := range values {
 		if val.Type != idx.indexTypes[i] {
-			return nil, fmt.Errorf("type mismatch at position %d: expected %v, got %v",
+			return nil, fmt.Errorf("%w: type mismatch at position %d: expected %v, got %v", ErrInvalidProperty,
 				i, idx.indexTypes[i], val.Type)
 		}
 	}
@@ -358,7 +358,7 @@ func (idx *CompositeIndex) PrefixLookup(values []Value) ([]uint64, error) {
 	// Validate types for provided values
 	for i, val := range values {
 		if val.Type != idx.indexTypes[i] {
-			return nil, fmt.Errorf("type mismatch at position %d: expected %v, got %v",
+			return nil, fmt.Errorf("%w: type mismatch at position %d: expected %v, got %v", ErrInvalidProperty,
 				i, idx.indexTypes[i], val.Type)
 		}
 	}
@@ -10,10 +10,19 @@ import (
 func (gs *GraphStorage) CreatePropertyIndex(propertyKey string, valueType ValueType) error {
 	gs.mu.Lock()
 	defer gs.mu.Unlock()
+	return gs.createPropertyIndexLocked(propertyKey, valueType)
+}
+
+// createPropertyIndexLocked is CreatePropertyIndex minus the lock. Caller
+// must hold gs.mu.Lock.
+func (gs *GraphStorage) createPropertyIndexLocked(propertyKey string, valueType ValueType) error {
+	if gs.fieldCipher.sensitive(propertyKey) {
+		return fmt.Errorf("%w: %s", ErrSensitivePropertyIndex, propertyKey)
+	}
 
 	// Check if index already exists
 	if _, exists := gs.propertyIndexes[propertyKey]; exists {
-		return fmt.Errorf("index on property %s already exists", propertyKey)
+		return fmt.Errorf("%w on property %s", ErrIndexExists, propertyKey)
 	}
 
 	// Create new index
@@ -38,6 +47,8 @@ func (gs *GraphStorage) CreatePropertyIndex(propertyKey string, valueType ValueT
 
 	gs.propertyIndexes[propertyKey] = idx
 
+	gs.bumpVersion()
+
 	// Write to WAL for durability
 	gs.writeToWAL(wal.OpCreatePropertyIndex, struct {
 		PropertyKey string
@@ -56,11 +67,13 @@ func (gs *GraphStorage) DropPropertyIndex(propertyKey string) error {
 	defer gs.mu.Unlock()
 
 	if _, exists := gs.propertyIndexes[propertyKey]; !exists {
-		return fmt.Errorf("index on property %s does not exist", propertyKey)
+		return fmt.Errorf("%w on property %s", ErrIndexNotFound, propertyKey)
 	}
 
 	delete(gs.propertyIndexes, propertyKey)
 
+	gs.bumpVersion()
+
 	// Write to WAL for durability
 	gs.writeToWAL(wal.OpDropPropertyIndex, struct {
 		PropertyKey string
@@ -84,7 +97,7 @@ func (gs *GraphStorage) FindNodesByPropertyIndexed(key string, value Value) ([]*
 	// Check if index exists
 	idx, exists := gs.propertyIndexes[key]
 	if !exists {
-		return nil, fmt.Errorf("no index on property %s", key)
+		return nil, fmt.Errorf("%w on property %s", ErrIndexNotFound, key)
 	}
 
 	// Use index for O(1) lookup
@@ -114,7 +127,7 @@ func (gs *GraphStorage) FindNodesByPropertyIndexedForTenant(key string, value Va
 
 	idx, exists := gs.propertyIndexes[key]
 	if !exists {
-		return nil, fmt.Errorf("no index on property %s", key)
+		return nil, fmt.Errorf("%w on property %s", ErrIndexNotFound, key)
 	}
 
 	nodeIDs, err := idx.Lookup(value)
@@ -152,7 +165,7 @@ func (gs *GraphStorage) FindNodesByPropertyRangeAcrossTenants(key string, start,
 	// Check if index exists
 	idx, exists := gs.propertyIndexes[key]
 	if !exists {
-		return nil, fmt.Errorf("no index on property %s", key)
+		return nil, fmt.Errorf("%w on property %s", ErrIndexNotFound, key)
 	}
 
 	// Use index for range lookup
@@ -177,7 +190,7 @@ func (gs *GraphStorage) FindNodesByPropertyPrefixAcrossTenants(key string, prefi
 	// Check if index exists
 	idx, exists := gs.propertyIndexes[key]
 	if !exists {
-		return nil, fmt.Errorf("no index on property %s", key)
+		return nil, fmt.Errorf("%w on property %s", ErrIndexNotFound, key)
 	}
 
 	// Use index for prefix lookup
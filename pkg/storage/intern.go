@@ -0,0 +1,66 @@
+package storage
+
+import "sync"
+
+// maxInternedLength bounds the string values the interner keeps. Repeated
+// values are typically short categorical ones (zones, roles, statuses);
+// long free text rarely repeats and would only grow the table.
+const maxInternedLength = 128
+
+// stringInterner makes identical string property values share one backing
+// array (StorageConfig.InternPropertyStrings). Stored values are never
+// mutated in place, so sharing is safe. The table only grows: it holds one
+// copy of every distinct short string value ever stored, including values
+// since overwritten or deleted.
+//
+// A nil *stringInterner is valid and interns nothing, so call sites need
+// no check for whether the option is on.
+type stringInterner struct {
+	mu      sync.Mutex
+	strings map[string][]byte
+}
+
+func newStringInterner() *stringInterner {
+	return &stringInterner{strings: make(map[string][]byte)}
+}
+
+// value returns v with its data replaced by the shared copy when v is an
+// internable string. The first occurrence is copied, so the table never
+// aliases caller memory or an mmap'd snapshot.
+func (in *stringInterner) value(v Value) Value {
+	if in == nil || v.Type != TypeString || len(v.Data) == 0 || len(v.Data) > maxInternedLength {
+		return v
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	// The string(...) conversion in a map index doesn't allocate.
+	if shared, ok := in.strings[string(v.Data)]; ok {
+		v.Data = shared
+		return v
+	}
+	shared := append([]byte(nil), v.Data...)
+	in.strings[string(shared)] = shared
+	v.Data = shared
+	return v
+}
+
+// properties interns every value of props in place. props must be a map
+// storage owns, not one a caller still holds.
+func (in *stringInterner) properties(props map[string]Value) {
+	if in == nil {
+		return
+	}
+	for k, v := range props {
+		props[k] = in.value(v)
+	}
+}
+
+// size returns the number of distinct values held.
+func (in *stringInterner) size() int {
+	if in == nil {
+		return 0
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return len(in.strings)
+}
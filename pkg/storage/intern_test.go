@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+// sharesData reports whether a and b are backed by the same array.
+func sharesData(a, b Value) bool {
+	return len(a.Data) > 0 && len(b.Data) > 0 && &a.Data[0] == &b.Data[0]
+}
+
+func TestInternPropertyStrings(t *testing.T) {
+	dir := t.TempDir()
+	gs := testGraphStorage(t, StorageConfig{DataDir: dir, InternPropertyStrings: true})
+
+	a := testNode(t, gs, []string{"Host"}, map[string]Value{"zone": StringValue("pop1")})
+	b := testNode(t, gs, []string{"Host"}, map[string]Value{"zone": StringValue("pop1"), "age": IntValue(3)})
+	c := testNode(t, gs, []string{"Host"}, map[string]Value{"zone": StringValue("management")})
+	if err := gs.UpdateNode(c.ID, map[string]Value{"zone": StringValue("pop1")}); err != nil {
+		t.Fatal(err)
+	}
+	e := testEdge(t, gs, a.ID, b.ID, "LINK", map[string]Value{"zone": StringValue("pop1")}, 1)
+
+	zone := func(gs *GraphStorage, id uint64) Value {
+		t.Helper()
+		n, err := gs.GetNode(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n.Properties["zone"]
+	}
+	za := zone(gs, a.ID)
+	if !sharesData(za, zone(gs, b.ID)) || !sharesData(za, zone(gs, c.ID)) {
+		t.Error("nodes created or updated with the same zone don't share it")
+	}
+	if edge, err := gs.GetEdge(e.ID); err != nil || !sharesData(za, edge.Properties["zone"]) {
+		t.Errorf("edge zone isn't shared with the nodes' (err %v)", err)
+	}
+	if s, _ := za.AsString(); s != "pop1" {
+		t.Errorf("zone = %q, want pop1", s)
+	}
+
+	// A reopened store interns what it loads.
+	if err := gs.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := NewGraphStorageWithConfig(StorageConfig{DataDir: dir, InternPropertyStrings: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if !sharesData(zone(reopened, a.ID), zone(reopened, c.ID)) {
+		t.Error("reopened nodes don't share their zone")
+	}
+
+	// Off by default.
+	plain := testGraphStorage(t)
+	x := testNode(t, plain, nil, map[string]Value{"zone": StringValue("pop1")})
+	y := testNode(t, plain, nil, map[string]Value{"zone": StringValue("pop1")})
+	if sharesData(zone(plain, x.ID), zone(plain, y.ID)) {
+		t.Error("values are interned without InternPropertyStrings")
+	}
+}
+
+func TestStringInterner_SkipsLongAndNonStringValues(t *testing.T) {
+	in := newStringInterner()
+	long := make([]byte, maxInternedLength+1)
+	in.value(Value{Type: TypeString, Data: long})
+	in.value(IntValue(7))
+	in.value(StringValue(""))
+	if n := in.size(); n != 0 {
+		t.Errorf("interner holds %d values, want 0", n)
+	}
+
+	var nilInterner *stringInterner
+	v := StringValue("pop1")
+	if got := nilInterner.value(v); !sharesData(got, v) {
+		t.Error("a nil interner changed the value")
+	}
+}
+
+// internBenchNodes is the graph size BenchmarkPropertyInterning builds.
+const internBenchNodes = 20000
+
+// BenchmarkPropertyInterning measures the retained heap of a graph whose
+// nodes repeat a handful of categorical string values — the shape of the
+// infrastructure examples, where every node carries one of a few zones,
+// roles and statuses — with and without InternPropertyStrings. The
+// heap-B/node metric is the one to compare:
+//
+//	go test -run '^$' -bench BenchmarkPropertyInterning ./pkg/storage/
+func BenchmarkPropertyInterning(b *testing.B) {
+	zones := []string{"pop1-edge-network", "pop2-edge-network", "management-network", "core-backbone", "customer-dmz"}
+	roles := []string{"router", "switch", "firewall", "load-balancer"}
+	statuses := []string{"active", "maintenance", "decommissioned"}
+
+	for _, intern := range []bool{false, true} {
+		b.Run(fmt.Sprintf("intern=%v", intern), func(b *testing.B) {
+			var heap uint64
+			for i := 0; i < b.N; i++ {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+
+				gs, err := NewGraphStorageWithConfig(StorageConfig{
+					DataDir:               b.TempDir(),
+					BulkImportMode:        true,
+					InternPropertyStrings: intern,
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+				for n := 0; n < internBenchNodes; n++ {
+					if _, err := gs.CreateNode([]string{"Device"}, map[string]Value{
+						"zone":   StringValue(zones[n%len(zones)]),
+						"role":   StringValue(roles[n%len(roles)]),
+						"status": StringValue(statuses[n%len(statuses)]),
+					}); err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				heap = after.HeapAlloc - before.HeapAlloc
+				runtime.KeepAlive(gs)
+				gs.Close()
+			}
+			b.ReportMetric(float64(heap)/internBenchNodes, "heap-B/node")
+		})
+	}
+}
@@ -42,6 +42,15 @@ const (
 	mmapSnapshotVersion uint32 = 4 // v4 adds the membership section
 	dirAbsent           int64  = -1
 
+	// mmapFlagEdgeFlags (header flags) marks snapshots whose edge records end
+	// with an edge-flags byte. Records are located through the directory, so
+	// the trailing byte is invisible to readers that predate it and the
+	// version stays at 4; readers here only look for it when the bit is set.
+	mmapFlagEdgeFlags uint32 = 1 << 0
+
+	// Edge-flags byte bits.
+	edgeFlagUndirected byte = 1 << 0
+
 	// Header field byte offsets.
 	hMagic         = 0
 	hVersion       = 4
@@ -90,16 +99,20 @@ type mmapSnapshotHeader struct {
 // mmapMetadata is the small, eagerly-loaded tail: everything the JSON snapshot struct
 // holds except the bulk node/edge records (which live in the lazy mmap'd sections).
 type mmapMetadata struct {
-	PropertyIndexes  map[string]PropertyIndexSnapshot
-	VectorIndexes    []VectorIndexDef
-	Stats            Statistics
-	NextNodeID       uint64
-	NextEdgeID       uint64
-	StickyNodeLabels []string // label keys that must survive even with no members
-	StickyEdgeTypes  []string
+	PropertyIndexes     map[string]PropertyIndexSnapshot
+	VectorIndexes       []VectorIndexDef
+	EdgePropertyIndexes []EdgePropertyIndexDef
+	Stats               Statistics
+	NextNodeID          uint64
+	NextEdgeID          uint64
+	StickyNodeLabels    []string // label keys that must survive even with no members
+	StickyEdgeTypes     []string
 	// TenantStats persists per-tenant counts so reopen restores them without the
 	// (now-lazy) membership build. Keyed by tenant ID string.
 	TenantStats map[string]TenantStats
+	// UndirectedEdges records that some edge is undirected, so reopen can
+	// enable the symmetric adjacency reads without decoding every edge.
+	UndirectedEdges bool `json:",omitempty"`
 }
 
 func (h *mmapSnapshotHeader) marshal() []byte {
@@ -489,10 +502,17 @@ func encodeEdgeRecord(e *Edge) []byte {
 	buf = appendProps(buf, e.Properties)
 	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(e.Weight))
 	buf = binary.LittleEndian.AppendUint64(buf, uint64(e.CreatedAt))
+	var flags byte
+	if e.Undirected {
+		flags |= edgeFlagUndirected
+	}
+	buf = append(buf, flags)
 	return buf
 }
 
-func decodeEdgeRecordAt(buf []byte, off int64) *Edge {
+// decodeEdgeRecordAt decodes the edge record at off. hasFlags says whether
+// the record carries the trailing edge-flags byte (mmapFlagEdgeFlags).
+func decodeEdgeRecordAt(buf []byte, off int64, hasFlags bool) *Edge {
 	p := int(off)
 	e := &Edge{}
 	e.ID = binary.LittleEndian.Uint64(buf[p:])
@@ -513,6 +533,10 @@ func decodeEdgeRecordAt(buf []byte, off int64) *Edge {
 	e.Weight = math.Float64frombits(binary.LittleEndian.Uint64(buf[p:]))
 	p += 8
 	e.CreatedAt = int64(binary.LittleEndian.Uint64(buf[p:]))
+	if hasFlags {
+		p += 8
+		e.Undirected = buf[p]&edgeFlagUndirected != 0
+	}
 	return e
 }
 
@@ -31,12 +31,14 @@ func fileExists(path string) bool {
 }
 
 // mmapEligible reports whether the mmap reopen path may be used. Stage 1 supports
-// the plaintext, in-memory-adjacency case only; encryption (mmap can't map
-// ciphertext) and disk-backed edges fall back to the JSON path. The snapshot.mmap
+// the plaintext, in-memory-adjacency case only; encryption and sensitive
+// properties (mmap can't map ciphertext) and disk-backed edges fall back to the
+// JSON path. The snapshot.mmap
 // file existence is checked by the caller.
 func mmapEligible(config StorageConfig) bool {
 	return config.UseMmapSnapshot &&
 		config.EncryptionEngine == nil &&
+		len(config.SensitiveProperties) == 0 &&
 		!config.UseDiskBackedEdges
 }
 
@@ -88,6 +90,10 @@ func (gs *GraphStorage) loadFromDiskMmap() error {
 		}
 	}
 
+	// Edge property index definitions; entries rebuilt after WAL replay.
+	gs.edgePropertyIndexes = make(map[EdgePropertyIndexDef]edgePropertyIndex)
+	gs.restoreEdgePropertyIndexDefs(meta.EdgePropertyIndexes)
+
 	// Vector index DEFINITIONS (empty HNSW graphs); vectors are inserted by
 	// rebuildVectorIndexesFromNodes after WAL replay, over the final node set.
 	for _, def := range meta.VectorIndexes {
@@ -106,6 +112,9 @@ func (gs *GraphStorage) loadFromDiskMmap() error {
 	gs.nextNodeID = meta.NextNodeID
 	gs.nextEdgeID = meta.NextEdgeID
 	gs.stats = meta.Stats
+	if meta.UndirectedEdges {
+		gs.hasUndirected.Store(true)
+	}
 	atomic.StoreUint64(&gs.avgQueryTimeBits, math.Float64bits(meta.Stats.AvgQueryTime))
 
 	// Per-tenant counts: restored from metadata and intentionally decoupled from
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"time"
 )
 
 // snapshotMmapLocked is the mmap-mode branch of snapshotWithBoundary. Caller holds
@@ -35,7 +34,7 @@ func (gs *GraphStorage) snapshotMmapLocked(boundary uint64) (uint64, error) {
 			if _, shadowed := gs.lookupEdgeShard(id); shadowed || gs.isEdgeDeletedLocked(id) {
 				return
 			}
-			edges = append(edges, decodeEdgeRecordAt(gs.mmapSnap.data, off))
+			edges = append(edges, gs.mmapSnap.decodeEdge(off))
 		})
 	}
 
@@ -53,6 +52,6 @@ func (gs *GraphStorage) snapshotMmapLocked(boundary uint64) (uint64, error) {
 	if err := os.Rename(tmpPath, finalPath); err != nil {
 		return 0, fmt.Errorf("failed to rename mmap snapshot: %w", err)
 	}
-	gs.stats.LastSnapshot = time.Now()
+	gs.noteSnapshot(boundary)
 	return boundary, nil
 }
@@ -136,7 +136,12 @@ func (m *mmapSnapshot) getEdge(id uint64) (*Edge, bool) {
 	if !ok {
 		return nil, false
 	}
-	return decodeEdgeRecordAt(m.data, off), true
+	return m.decodeEdge(off), true
+}
+
+// decodeEdge decodes the edge record at off in this snapshot's layout.
+func (m *mmapSnapshot) decodeEdge(off int64) *Edge {
+	return decodeEdgeRecordAt(m.data, off, m.hdr.flags&mmapFlagEdgeFlags != 0)
 }
 
 func (m *mmapSnapshot) nodeOffset(id uint64) (int64, bool) {
@@ -39,7 +39,7 @@ func TestMmapSnapshotCodec_RoundTrip(t *testing.T) {
 		Weight:     2.5, // v2 records carry Weight (the prototype dropped it)
 		CreatedAt:  3000,
 	}
-	gotEdge := decodeEdgeRecordAt(encodeEdgeRecord(edge), 0)
+	gotEdge := decodeEdgeRecordAt(encodeEdgeRecord(edge), 0, true)
 	if gotEdge.ID != edge.ID || gotEdge.TenantID != edge.TenantID ||
 		gotEdge.FromNodeID != edge.FromNodeID || gotEdge.ToNodeID != edge.ToNodeID ||
 		gotEdge.Type != edge.Type || gotEdge.Weight != edge.Weight ||
@@ -27,7 +27,7 @@ func writeMmapSnapshotData(path string, nodes []*Node, edges []*Edge, meta *mmap
 	defer f.Close()
 
 	w := bufio.NewWriterSize(f, 1<<20)
-	hdr := &mmapSnapshotHeader{nodeCount: uint64(len(nodes)), edgeCount: uint64(len(edges))}
+	hdr := &mmapSnapshotHeader{flags: mmapFlagEdgeFlags, nodeCount: uint64(len(nodes)), edgeCount: uint64(len(edges))}
 	offset := int64(mmapHeaderSize)
 	if _, err := w.Write(make([]byte, mmapHeaderSize)); err != nil {
 		return err
@@ -223,14 +223,16 @@ func buildMmapMetadata(gs *GraphStorage) *mmapMetadata {
 		}
 	}
 	return &mmapMetadata{
-		PropertyIndexes:  propIdx,
-		VectorIndexes:    gs.vectorIndex.IndexDefinitions(),
-		Stats:            gs.GetStatistics(),
-		NextNodeID:       atomic.LoadUint64(&gs.nextNodeID),
-		NextEdgeID:       atomic.LoadUint64(&gs.nextEdgeID),
-		StickyNodeLabels: labelIndexKeys(gs.nodesByLabel),
-		StickyEdgeTypes:  labelIndexKeys(gs.edgesByType),
-		TenantStats:      tenantStats,
+		PropertyIndexes:     propIdx,
+		VectorIndexes:       gs.vectorIndex.IndexDefinitions(),
+		EdgePropertyIndexes: gs.edgePropertyIndexDefs(),
+		Stats:               gs.GetStatistics(),
+		NextNodeID:          atomic.LoadUint64(&gs.nextNodeID),
+		NextEdgeID:          atomic.LoadUint64(&gs.nextEdgeID),
+		StickyNodeLabels:    labelIndexKeys(gs.nodesByLabel),
+		StickyEdgeTypes:     labelIndexKeys(gs.edgesByType),
+		TenantStats:         tenantStats,
+		UndirectedEdges:     gs.hasUndirected.Load(),
 	}
 }
 
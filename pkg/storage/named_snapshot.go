@@ -0,0 +1,392 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dd0wney/graphdb/pkg/wal"
+)
+
+// Named snapshots are save slots: point-in-time copies of the graph data
+// (nodes + edges, every tenant) written to dataDir/snapshots/<name>.snapshot
+// and restored on demand. They are independent of the recovery snapshot that
+// Snapshot/CompactWAL maintain — saving a slot never touches snapshot.json,
+// snapshot.mmap, or the WAL, and restoring one goes through the ordinary
+// WAL-logged write path, so the result survives a restart like any other
+// write.
+//
+// Only data is captured. Property and vector index DEFINITIONS belong to the
+// live store, not the slot: indexes that exist at restore time are kept and
+// repopulated from the restored nodes.
+
+const (
+	namedSnapshotDir = "snapshots"
+	namedSnapshotExt = ".snapshot"
+)
+
+// namedSnapshotNamePattern bounds slot names to a portable, path-safe
+// alphabet: no separators, no leading dot, at most 128 characters.
+var namedSnapshotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// SnapshotInfo describes a named snapshot on disk.
+type SnapshotInfo struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	SizeBytes int64     `json:"size_bytes"`
+}
+
+// namedSnapshot is the payload of a named snapshot file. It is wrapped in the
+// same versioned envelope (and optional encryption) as snapshot.json.
+type namedSnapshot struct {
+	Name       string
+	CreatedAt  time.Time
+	Nodes      []*Node
+	Edges      []*Edge
+	NextNodeID uint64
+	NextEdgeID uint64
+}
+
+func validateSnapshotName(name string) error {
+	if !namedSnapshotNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidSnapshotName, name)
+	}
+	return nil
+}
+
+func (gs *GraphStorage) namedSnapshotPath(name string) string {
+	return filepath.Join(gs.dataDir, namedSnapshotDir, name+namedSnapshotExt)
+}
+
+// SnapshotAs saves the current graph to the named snapshot slot, replacing
+// any earlier snapshot with the same name. Names may contain letters, digits,
+// '.', '_' and '-' and must not start with a dot.
+//
+// This is a separate method rather than a `Snapshot(name)` overload: the
+// recovery Snapshot() signature is kept stable (see interface.go).
+func (gs *GraphStorage) SnapshotAs(name string) error {
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+	if err := gs.checkClosed(); err != nil {
+		return err
+	}
+
+	gs.mu.RLock()
+	snap := namedSnapshot{
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+		Nodes:     make([]*Node, 0, gs.nodeCount()),
+		Edges:     make([]*Edge, 0, gs.edgeCount()),
+		// Atomic loads: Transaction ops allocate IDs without gs.mu (see
+		// snapshotWithBoundary).
+		NextNodeID: atomic.LoadUint64(&gs.nextNodeID),
+		NextEdgeID: atomic.LoadUint64(&gs.nextEdgeID),
+	}
+	// Every field is a deep copy; json.Marshal runs after the RUnlock.
+	gs.forEachNodeUnlocked(func(n *Node) bool {
+		snap.Nodes = append(snap.Nodes, n.Clone())
+		return true
+	})
+	for _, tid := range gs.membershipTenantsLocked() {
+		for _, id := range gs.membershipEdgeIDsForTenantLocked(tid) {
+			if e, ok := gs.resolveEdgeRefLocked(id); ok {
+				snap.Edges = append(snap.Edges, e.Clone())
+			}
+		}
+	}
+	// Same engine for encrypt + envelope flag (see snapshotWithBoundary).
+	engine := gs.encryptionEngine
+	fieldCipher := gs.fieldCipher
+	gs.mu.RUnlock()
+
+	sort.Slice(snap.Nodes, func(i, j int) bool { return snap.Nodes[i].ID < snap.Nodes[j].ID })
+	sort.Slice(snap.Edges, func(i, j int) bool { return snap.Edges[i].ID < snap.Edges[j].ID })
+
+	if fieldCipher != nil {
+		for _, node := range snap.Nodes {
+			if err := fieldCipher.sealNode(node); err != nil {
+				return fmt.Errorf("failed to encrypt snapshot %q: %w", name, err)
+			}
+		}
+		for _, edge := range snap.Edges {
+			if err := fieldCipher.sealEdge(edge); err != nil {
+				return fmt.Errorf("failed to encrypt snapshot %q: %w", name, err)
+			}
+		}
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot %q: %w", name, err)
+	}
+	if engine != nil {
+		if data, err = engine.Encrypt(data); err != nil {
+			return fmt.Errorf("failed to encrypt snapshot %q: %w", name, err)
+		}
+	}
+	data = encodeSnapshotEnvelope(data, engine != nil)
+
+	if err := os.MkdirAll(filepath.Join(gs.dataDir, namedSnapshotDir), dirPermissions); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	path := gs.namedSnapshotPath(name)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, filePermissions); err != nil {
+		return fmt.Errorf("failed to write snapshot %q: %w", name, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename snapshot %q: %w", name, err)
+	}
+	return nil
+}
+
+// ListSnapshots returns the named snapshots on disk, sorted by name.
+// CreatedAt is the file's modification time, so listing never has to read
+// (or decrypt) the snapshot payloads.
+func (gs *GraphStorage) ListSnapshots() ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(gs.dataDir, namedSnapshotDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return []SnapshotInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	infos := make([]SnapshotInfo, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), namedSnapshotExt)
+		if !ok || entry.IsDir() || validateSnapshotName(name) != nil {
+			continue
+		}
+		fi, err := entry.Info()
+		if err != nil {
+			continue // removed between ReadDir and Info
+		}
+		infos = append(infos, SnapshotInfo{
+			Name:      name,
+			CreatedAt: fi.ModTime().UTC(),
+			SizeBytes: fi.Size(),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// RestoreSnapshot replaces the graph's contents with the named snapshot.
+// Nodes and edges come back with their original IDs; ID counters never move
+// backwards, so IDs handed out since the snapshot was taken are not reused.
+//
+// The swap happens under a single gs.mu.Lock, so readers see either the old
+// graph or the restored one. Every delete and create is WAL-logged like a
+// normal write. The snapshot — IDs, edge endpoints, weights, and vector
+// dimensions against the live indexes — is validated before anything is
+// touched, so a bad file leaves the graph unchanged.
+//
+// The restore is not transactional beyond that: an I/O error from the
+// disk-backed edge store mid-swap leaves the graph partly restored. The
+// steps already applied are durable and observers hear about them; the
+// error says so, and restoring again completes the swap.
+func (gs *GraphStorage) RestoreSnapshot(name string) error {
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+	if err := gs.checkClosed(); err != nil {
+		return err
+	}
+
+	snap, err := gs.readNamedSnapshot(name)
+	if err != nil {
+		return err
+	}
+	if err := validateNamedSnapshot(snap); err != nil {
+		return fmt.Errorf("snapshot %q: %w", name, err)
+	}
+
+	type deletedNode struct {
+		id       uint64
+		tenantID string
+	}
+	type walWait struct {
+		op      wal.OpType
+		pending *wal.Pending
+	}
+	var (
+		deleted     []deletedNode
+		created     []*Node
+		waits       []walWait
+		vectorPlans []vectorInsertPlan
+	)
+
+	gs.mu.Lock()
+	// Plan vector inserts before mutating anything: this is the one check
+	// that depends on live state (index dimensions).
+	for _, node := range snap.Nodes {
+		if _, err := gs.planNodeVectorInserts(node); err != nil {
+			gs.mu.Unlock()
+			return fmt.Errorf("snapshot %q: node %d: %w", name, node.ID, err)
+		}
+	}
+
+	// From here on the graph is mutated. Everything that can be checked up
+	// front has been, so the only failure left is an I/O error from the
+	// disk-backed edge store; it stops the swap part-way, and the steps
+	// already applied are still WAL-waited and observed below.
+	applyErr := func() error {
+		var existing []uint64
+		gs.forEachNodeIDUnlocked(func(id uint64) bool {
+			existing = append(existing, id)
+			return true
+		})
+		for _, id := range existing {
+			tenantID, pending, err := gs.deleteNodeLocked(id)
+			if err != nil {
+				return fmt.Errorf("failed to clear node %d: %w", id, err)
+			}
+			deleted = append(deleted, deletedNode{id, tenantID})
+			waits = append(waits, walWait{wal.OpDeleteNode, pending})
+		}
+
+		for _, node := range snap.Nodes {
+			plans, err := gs.persistNodeLocked(node)
+			if err != nil {
+				return fmt.Errorf("failed to restore node %d: %w", node.ID, err)
+			}
+			vectorPlans = append(vectorPlans, plans...)
+			gs.bumpVersion()
+			waits = append(waits, walWait{wal.OpCreateNode, gs.enqueueWAL(wal.OpCreateNode, node)})
+			created = append(created, node.Clone())
+			raiseIDCounter(&gs.nextNodeID, node.ID+1)
+		}
+		for _, edge := range snap.Edges {
+			if err := gs.persistEdgeLocked(edge); err != nil {
+				return fmt.Errorf("failed to restore edge %d: %w", edge.ID, err)
+			}
+			gs.bumpVersion()
+			waits = append(waits, walWait{wal.OpCreateEdge, gs.enqueueWAL(wal.OpCreateEdge, edge)})
+			raiseIDCounter(&gs.nextEdgeID, edge.ID+1)
+		}
+		return nil
+	}()
+
+	raiseIDCounter(&gs.nextNodeID, snap.NextNodeID)
+	raiseIDCounter(&gs.nextEdgeID, snap.NextEdgeID)
+	gs.mu.Unlock()
+
+	gs.applyNodeVectorInserts(vectorPlans)
+	for _, w := range waits {
+		gs.waitWALPending(w.op, w.pending)
+	}
+	for _, d := range deleted {
+		gs.notifyNodeDeleted(context.Background(), d.id, d.tenantID)
+	}
+	for _, node := range created {
+		gs.notifyNodeCreated(context.Background(), node)
+	}
+	if applyErr != nil {
+		return fmt.Errorf("snapshot %q only partly restored: %w", name, applyErr)
+	}
+	return nil
+}
+
+// readNamedSnapshot loads and decodes a named snapshot file.
+func (gs *GraphStorage) readNamedSnapshot(name string) (*namedSnapshot, error) {
+	data, err := os.ReadFile(gs.namedSnapshotPath(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %q", ErrSnapshotNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %q: %w", name, err)
+	}
+
+	payload, encrypted, legacy, err := decodeSnapshotEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		// Named snapshots have always been written with the envelope.
+		return nil, fmt.Errorf("snapshot %q: missing snapshot header", name)
+	}
+	if encrypted {
+		gs.mu.RLock()
+		engine := gs.encryptionEngine
+		gs.mu.RUnlock()
+		if engine == nil {
+			return nil, fmt.Errorf("snapshot %q is encrypted but encryption is not configured", name)
+		}
+		if payload, err = engine.Decrypt(payload); err != nil {
+			return nil, fmt.Errorf("failed to decrypt snapshot %q: %w", name, err)
+		}
+	}
+
+	var snap namedSnapshot
+	if err := json.Unmarshal(payload, &snap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot %q: %w", name, err)
+	}
+
+	gs.mu.RLock()
+	fieldCipher := gs.fieldCipher
+	gs.mu.RUnlock()
+	for _, node := range snap.Nodes {
+		if err := fieldCipher.openNode(node); err != nil {
+			return nil, fmt.Errorf("failed to decrypt snapshot %q: %w", name, err)
+		}
+	}
+	for _, edge := range snap.Edges {
+		if err := fieldCipher.openEdge(edge); err != nil {
+			return nil, fmt.Errorf("failed to decrypt snapshot %q: %w", name, err)
+		}
+	}
+	return &snap, nil
+}
+
+// validateNamedSnapshot checks the invariants RestoreSnapshot relies on so a
+// corrupt or hand-edited file is rejected before the live graph is cleared.
+func validateNamedSnapshot(snap *namedSnapshot) error {
+	nodeIDs := make(map[uint64]struct{}, len(snap.Nodes))
+	for _, node := range snap.Nodes {
+		if node == nil || node.ID == 0 {
+			return fmt.Errorf("%w: node", ErrInvalidID)
+		}
+		if _, dup := nodeIDs[node.ID]; dup {
+			return fmt.Errorf("duplicate node ID %d", node.ID)
+		}
+		nodeIDs[node.ID] = struct{}{}
+	}
+	edgeIDs := make(map[uint64]struct{}, len(snap.Edges))
+	for _, edge := range snap.Edges {
+		if edge == nil || edge.ID == 0 {
+			return fmt.Errorf("%w: edge", ErrInvalidID)
+		}
+		if _, dup := edgeIDs[edge.ID]; dup {
+			return fmt.Errorf("duplicate edge ID %d", edge.ID)
+		}
+		edgeIDs[edge.ID] = struct{}{}
+		if _, ok := nodeIDs[edge.FromNodeID]; !ok {
+			return fmt.Errorf("edge %d: %w: from node %d", edge.ID, ErrNodeNotFound, edge.FromNodeID)
+		}
+		if _, ok := nodeIDs[edge.ToNodeID]; !ok {
+			return fmt.Errorf("edge %d: %w: to node %d", edge.ID, ErrNodeNotFound, edge.ToNodeID)
+		}
+		if err := validateEdgeWeight(edge.Weight); err != nil {
+			return fmt.Errorf("edge %d: %w", edge.ID, err)
+		}
+	}
+	return nil
+}
+
+// raiseIDCounter moves an ID counter up to at least floor. Counters only
+// grow: IDs issued after a snapshot was taken are never handed out again.
+func raiseIDCounter(counter *uint64, floor uint64) {
+	if floor > atomic.LoadUint64(counter) {
+		atomic.StoreUint64(counter, floor)
+	}
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNamedSnapshot_RestoreRoundTrip: save a slot, mutate the graph, restore,
+// and the graph is back to the saved state — IDs, properties, and edges.
+func TestNamedSnapshot_RestoreRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  func(string) StorageConfig
+	}{
+		{"json", jsonConfig},
+		{"mmap", mmapConfig},
+		{"disk-edges", func(dir string) StorageConfig {
+			return StorageConfig{DataDir: dir, UseDiskBackedEdges: true, EdgeCacheSize: 100}
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := testGraphStorage(t, tc.cfg(t.TempDir()))
+			buildReopenFixture(t, gs)
+			wantA := fingerprintTenant(t, gs, rtTenantA)
+			wantB := fingerprintTenant(t, gs, rtTenantB)
+
+			if err := gs.SnapshotAs("baseline"); err != nil {
+				t.Fatalf("SnapshotAs: %v", err)
+			}
+			applyMutations(t, gs)
+			if err := gs.DeleteNodeForTenant(45, rtTenantB); err != nil {
+				t.Fatalf("DeleteNodeForTenant: %v", err)
+			}
+			nextBefore := gs.nextNodeID
+
+			if err := gs.RestoreSnapshot("baseline"); err != nil {
+				t.Fatalf("RestoreSnapshot: %v", err)
+			}
+			assertFingerprintEqual(t, wantA, fingerprintTenant(t, gs, rtTenantA), "restored tenant A")
+			assertFingerprintEqual(t, wantB, fingerprintTenant(t, gs, rtTenantB), "restored tenant B")
+
+			// IDs issued after the snapshot are not handed out again.
+			n := testNode(t, gs, []string{"Person"}, nil)
+			if n.ID < nextBefore {
+				t.Errorf("new node ID %d reuses an ID below %d", n.ID, nextBefore)
+			}
+		})
+	}
+}
+
+// TestNamedSnapshot_RestoreSurvivesReopen: a restore is WAL-logged, so the
+// restored graph (not the pre-restore one) is what comes back after restart.
+func TestNamedSnapshot_RestoreSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	gs, err := NewGraphStorageWithConfig(mmapConfig(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildReopenFixture(t, gs)
+	// Close + reopen so the fixture lives in the mmap base and the restore
+	// exercises the tombstone + overlay paths.
+	if err := gs.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gs, err = NewGraphStorageWithConfig(mmapConfig(dir)); err != nil {
+		t.Fatal(err)
+	}
+	want := fingerprintTenant(t, gs, rtTenantA)
+	if err := gs.SnapshotAs("before"); err != nil {
+		t.Fatalf("SnapshotAs: %v", err)
+	}
+	applyMutations(t, gs)
+	if err := gs.RestoreSnapshot("before"); err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+	assertFingerprintEqual(t, want, fingerprintTenant(t, gs, rtTenantA), "live after restore")
+	if err := gs.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewGraphStorageWithConfig(mmapConfig(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	assertFingerprintEqual(t, want, fingerprintTenant(t, reopened, rtTenantA), "after reopen")
+}
+
+func TestNamedSnapshot_List(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	infos, err := gs.ListSnapshots()
+	if err != nil {
+		t.Fatalf("ListSnapshots on empty dir: %v", err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("got %d snapshots, want 0", len(infos))
+	}
+
+	testNode(t, gs, []string{"Person"}, nil)
+	for _, name := range []string{"scenario-b", "scenario-a", "v1.2_final"} {
+		if err := gs.SnapshotAs(name); err != nil {
+			t.Fatalf("SnapshotAs(%q): %v", name, err)
+		}
+	}
+	// Leftovers that are not snapshots are ignored.
+	if err := os.WriteFile(filepath.Join(gs.dataDir, namedSnapshotDir, "junk.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err = gs.ListSnapshots()
+	if err != nil {
+		t.Fatalf("ListSnapshots: %v", err)
+	}
+	want := []string{"scenario-a", "scenario-b", "v1.2_final"}
+	if len(infos) != len(want) {
+		t.Fatalf("got %d snapshots, want %d: %+v", len(infos), len(want), infos)
+	}
+	for i, info := range infos {
+		if info.Name != want[i] {
+			t.Errorf("infos[%d].Name = %q, want %q", i, info.Name, want[i])
+		}
+		if info.SizeBytes == 0 || info.CreatedAt.IsZero() {
+			t.Errorf("infos[%d] missing size or time: %+v", i, info)
+		}
+	}
+}
+
+func TestNamedSnapshot_Errors(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	for _, name := range []string{"", ".hidden", "../escape", "a/b", "sp ace"} {
+		if err := gs.SnapshotAs(name); !errors.Is(err, ErrInvalidSnapshotName) {
+			t.Errorf("SnapshotAs(%q) = %v, want ErrInvalidSnapshotName", name, err)
+		}
+		if err := gs.RestoreSnapshot(name); !errors.Is(err, ErrInvalidSnapshotName) {
+			t.Errorf("RestoreSnapshot(%q) = %v, want ErrInvalidSnapshotName", name, err)
+		}
+	}
+
+	if err := gs.RestoreSnapshot("missing"); !errors.Is(err, ErrSnapshotNotFound) {
+		t.Errorf("RestoreSnapshot(missing) = %v, want ErrSnapshotNotFound", err)
+	}
+}
+
+// TestNamedSnapshot_CorruptFileLeavesGraphUnchanged: a snapshot that fails
+// validation is rejected before the live graph is cleared.
+func TestNamedSnapshot_CorruptFileLeavesGraphUnchanged(t *testing.T) {
+	gs := testGraphStorage(t)
+	testNode(t, gs, []string{"Person"}, nil)
+	if err := gs.SnapshotAs("good"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dangling edge endpoint.
+	bad := `{"Nodes":[{"ID":1}],"Edges":[{"ID":1,"FromNodeID":1,"ToNodeID":2,"Type":"X"}]}`
+	path := gs.namedSnapshotPath("bad")
+	if err := os.WriteFile(path, encodeSnapshotEnvelope([]byte(bad), false), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	testNode(t, gs, []string{"Person"}, nil)
+
+	if err := gs.RestoreSnapshot("bad"); !errors.Is(err, ErrNodeNotFound) {
+		t.Fatalf("RestoreSnapshot(bad) = %v, want ErrNodeNotFound", err)
+	}
+	if got := gs.GetStatistics().NodeCount; got != 2 {
+		t.Errorf("NodeCount = %d after rejected restore, want 2", got)
+	}
+}
@@ -0,0 +1,108 @@
+package storage
+
+import "fmt"
+
+// Direction selects which of a node's edges Neighbors follows.
+type Direction int
+
+const (
+	DirectionOutgoing Direction = iota // edges from the node
+	DirectionIncoming                  // edges to the node
+	DirectionBoth                      // either
+)
+
+func (d Direction) String() string {
+	switch d {
+	case DirectionOutgoing:
+		return "outgoing"
+	case DirectionIncoming:
+		return "incoming"
+	case DirectionBoth:
+		return "both"
+	default:
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+}
+
+// Neighbors returns the distinct nodes adjacent to nodeID along edges in dir,
+// optionally restricted to edgeTypes (nil or empty = every type). Each
+// neighbor appears once however many edges connect it; order is first
+// appearance, outgoing edges before incoming. A self-loop makes the node its
+// own neighbor. Undirected edges are followed in either direction.
+//
+// Tenant-blind, like GetOutgoingEdges. Tenant-scoped callers should use
+// NeighborsForTenant.
+func (gs *GraphStorage) Neighbors(nodeID uint64, dir Direction, edgeTypes []string) ([]*Node, error) {
+	return gs.neighbors(nodeID, dir, edgeTypes, "")
+}
+
+// NeighborsForTenant is Neighbors scoped to tenantID: the node must belong to
+// the tenant (ErrNodeNotFound otherwise, as GetNodeForTenant) and only the
+// tenant's edges are followed.
+func (gs *GraphStorage) NeighborsForTenant(nodeID uint64, tenantID string, dir Direction, edgeTypes []string) ([]*Node, error) {
+	return gs.neighbors(nodeID, dir, edgeTypes, effectiveTenantID(tenantID).String())
+}
+
+// neighbors implements Neighbors*; tenant == "" means tenant-blind.
+func (gs *GraphStorage) neighbors(nodeID uint64, dir Direction, edgeTypes []string, tenant string) ([]*Node, error) {
+	if dir < DirectionOutgoing || dir > DirectionBoth {
+		return nil, fmt.Errorf("%w: direction %d", ErrInvalidArgument, int(dir))
+	}
+	defer gs.startQueryTiming()()
+
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	node, exists := gs.resolveNodeRefLocked(nodeID)
+	if !exists || (tenant != "" && effectiveTenantID(node.TenantID).String() != tenant) {
+		return nil, ErrNodeNotFound
+	}
+
+	var typeFilter map[string]bool
+	if len(edgeTypes) > 0 {
+		typeFilter = make(map[string]bool, len(edgeTypes))
+		for _, t := range edgeTypes {
+			typeFilter[t] = true
+		}
+	}
+
+	seen := make(map[uint64]bool)
+	var peerIDs []uint64
+	// collect walks the outgoing or incoming list; undirectedOnly keeps just
+	// the undirected edges (walking the opposite list for their mirror).
+	collect := func(outgoing, undirectedOnly bool) {
+		for _, edgeID := range gs.getEdgeIDsForNode(nodeID, outgoing) {
+			edge, exists := gs.resolveEdgeRefLocked(edgeID)
+			if !exists || (undirectedOnly && !edge.Undirected) {
+				continue
+			}
+			if typeFilter != nil && !typeFilter[edge.Type] {
+				continue
+			}
+			if tenant != "" && effectiveTenantID(edge.TenantID).String() != tenant {
+				continue
+			}
+			peer := edge.ToNodeID
+			if !outgoing {
+				peer = edge.FromNodeID
+			}
+			if !seen[peer] {
+				seen[peer] = true
+				peerIDs = append(peerIDs, peer)
+			}
+		}
+	}
+	if dir == DirectionOutgoing || dir == DirectionBoth {
+		collect(true, false)
+	}
+	if dir == DirectionIncoming || dir == DirectionBoth {
+		collect(false, false)
+	}
+	// Undirected edges count in both directions; DirectionBoth already
+	// walked both lists.
+	if dir != DirectionBoth && gs.hasUndirected.Load() {
+		collect(dir == DirectionIncoming, true)
+	}
+
+	return gs.buildNodeListFromIDs(peerIDs), nil
+}
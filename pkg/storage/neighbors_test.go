@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func nodeIDs(nodes []*Node) []uint64 {
+	ids := make([]uint64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	return ids
+}
+
+func TestNeighbors_DirectionAndTypeFilter(t *testing.T) {
+	gs := testGraphStorage(t)
+	center := testNode(t, gs, []string{"Hub"}, nil)
+	a := testNode(t, gs, []string{"Peer"}, nil)
+	b := testNode(t, gs, []string{"Peer"}, nil)
+	c := testNode(t, gs, []string{"Peer"}, nil)
+
+	testEdge(t, gs, center.ID, a.ID, "KNOWS", nil, 1)
+	testEdge(t, gs, center.ID, a.ID, "WORKS_WITH", nil, 1) // parallel edge: a listed once
+	testEdge(t, gs, center.ID, b.ID, "WORKS_WITH", nil, 1)
+	testEdge(t, gs, c.ID, center.ID, "KNOWS", nil, 1)
+	testEdge(t, gs, b.ID, center.ID, "KNOWS", nil, 1) // b is both out- and in-neighbor
+
+	cases := []struct {
+		name  string
+		dir   Direction
+		types []string
+		want  []uint64
+	}{
+		{"outgoing", DirectionOutgoing, nil, []uint64{a.ID, b.ID}},
+		{"incoming", DirectionIncoming, nil, []uint64{c.ID, b.ID}},
+		{"both dedups", DirectionBoth, nil, []uint64{a.ID, b.ID, c.ID}},
+		{"outgoing KNOWS", DirectionOutgoing, []string{"KNOWS"}, []uint64{a.ID}},
+		{"both KNOWS", DirectionBoth, []string{"KNOWS"}, []uint64{a.ID, c.ID, b.ID}},
+		{"multiple types", DirectionOutgoing, []string{"KNOWS", "WORKS_WITH"}, []uint64{a.ID, b.ID}},
+		{"unknown type", DirectionBoth, []string{"NONE"}, []uint64{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := gs.Neighbors(center.ID, tc.dir, tc.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalU64(nodeIDs(got), tc.want) {
+				t.Errorf("Neighbors = %v, want %v", nodeIDs(got), tc.want)
+			}
+		})
+	}
+
+	// Self-loop: the node is its own neighbor, once.
+	testEdge(t, gs, a.ID, a.ID, "SELF", nil, 1)
+	got, err := gs.Neighbors(a.ID, DirectionBoth, []string{"SELF"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalU64(nodeIDs(got), []uint64{a.ID}) {
+		t.Errorf("self-loop Neighbors = %v, want [%d]", nodeIDs(got), a.ID)
+	}
+}
+
+// TestNeighbors_ReturnsCopies: mutating a returned node must not touch storage.
+func TestNeighbors_ReturnsCopies(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"A"}, nil)
+	b := testNode(t, gs, []string{"B"}, map[string]Value{"name": StringValue("b")})
+	testEdge(t, gs, a.ID, b.ID, "LINK", nil, 1)
+
+	got, err := gs.Neighbors(a.ID, DirectionOutgoing, nil)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("Neighbors = %v, %v", got, err)
+	}
+	got[0].Properties["name"] = StringValue("mutated")
+
+	stored, err := gs.GetNode(b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := stored.Properties["name"].AsString(); s != "b" {
+		t.Errorf("stored name = %q, want b", s)
+	}
+}
+
+func TestNeighbors_Errors(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"A"}, nil)
+
+	if _, err := gs.Neighbors(9999, DirectionBoth, nil); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("missing node: err = %v, want ErrNodeNotFound", err)
+	}
+	if _, err := gs.Neighbors(a.ID, Direction(7), nil); err == nil {
+		t.Error("invalid direction: want error")
+	}
+	got, err := gs.Neighbors(a.ID, DirectionBoth, nil)
+	if err != nil || len(got) != 0 {
+		t.Errorf("isolated node: Neighbors = %v, %v; want empty", got, err)
+	}
+}
+
+func TestNeighborsForTenant(t *testing.T) {
+	gs := testGraphStorage(t)
+	a, err := gs.CreateNodeWithTenant(rtTenantA, []string{"A"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := gs.CreateNodeWithTenant(rtTenantA, []string{"B"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gs.CreateEdgeWithTenant(rtTenantA, a.ID, b.ID, "LINK", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := gs.NeighborsForTenant(a.ID, rtTenantA, DirectionOutgoing, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalU64(nodeIDs(got), []uint64{b.ID}) {
+		t.Errorf("owner tenant: %v, want [%d]", nodeIDs(got), b.ID)
+	}
+	if _, err := gs.NeighborsForTenant(a.ID, rtTenantB, DirectionOutgoing, nil); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("foreign tenant: err = %v, want ErrNodeNotFound", err)
+	}
+}
@@ -83,6 +83,8 @@ func (gs *GraphStorage) cascadeDeleteOutgoingEdge(edgeID uint64) error {
 	// operation — the cascade-path sibling of the #288/#298 tenant-index gaps.
 	// Caller (DeleteNode) holds gs.mu.Lock, which guards the tenant index.
 	gs.removeEdgeFromTenantIndex(edge)
+	gs.unindexEdgePropertiesLocked(edge)
+	gs.publishChangeLocked(ChangeDelete, EntityEdge, edge.ID, edge.TenantID)
 	// Decrement stats with underflow protection
 	atomicDecrementWithUnderflowProtection(&gs.stats.EdgeCount)
 	return nil
@@ -109,6 +111,8 @@ func (gs *GraphStorage) cascadeDeleteIncomingEdge(edgeID uint64) error {
 	gs.removeEdgeFromTypeIndex(edge.Type, edgeID)
 	// Remove from the per-tenant edge index — see cascadeDeleteOutgoingEdge.
 	gs.removeEdgeFromTenantIndex(edge)
+	gs.unindexEdgePropertiesLocked(edge)
+	gs.publishChangeLocked(ChangeDelete, EntityEdge, edge.ID, edge.TenantID)
 	// Decrement stats with underflow protection
 	atomicDecrementWithUnderflowProtection(&gs.stats.EdgeCount)
 	return nil
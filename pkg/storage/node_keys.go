@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/dd0wney/graphdb/pkg/wal"
+)
+
+// NodeKeyProperty is the reserved property holding a node's external key:
+// a caller-chosen string identity (an asset tag, a hostname) unique within
+// the tenant, alongside the internal uint64 ID that adjacency uses.
+//
+// Keys live in an ordinary property with a property index on it, so they
+// persist, replay and reload like any other property. CreateNodeWithKey
+// creates the index, and it and every property update (UpdateNode, SET, a
+// transaction) refuse a key another node of the tenant holds. A plain
+// create that sets the property isn't checked.
+const NodeKeyProperty = "_key"
+
+// CreateNodeWithKey creates a node in the default tenant under an external
+// key, failing with ErrDuplicateNodeKey if another node already holds it.
+// For multi-tenant operations, use CreateNodeWithKeyForTenant instead.
+func (gs *GraphStorage) CreateNodeWithKey(key string, labels []string, properties map[string]Value) (*Node, error) {
+	return gs.CreateNodeWithKeyForTenant(DefaultTenantID, key, labels, properties)
+}
+
+// CreateNodeWithKeyForTenant creates a node owned by tenantID under an
+// external key. The check and the create run under one gs.mu.Lock
+// acquisition, so two concurrent calls with the same key can't both
+// succeed. Nodes of other tenants may hold the same key.
+func (gs *GraphStorage) CreateNodeWithKeyForTenant(tenantID, key string, labels []string, properties map[string]Value) (*Node, error) {
+	if key == "" {
+		return nil, fmt.Errorf("%w: node key must not be empty", ErrInvalidArgument)
+	}
+	props := make(map[string]Value, len(properties)+1)
+	for k, v := range properties {
+		props[k] = v
+	}
+	props[NodeKeyProperty] = StringValue(key)
+
+	gs.mu.Lock()
+	if err := gs.checkClosed(); err != nil {
+		gs.mu.Unlock()
+		return nil, err
+	}
+	if err := gs.ensureNodeKeyIndexLocked(); err != nil {
+		gs.mu.Unlock()
+		return nil, err
+	}
+	if existing, ok := gs.nodeIDByKeyLocked(tenantID, key); ok {
+		gs.mu.Unlock()
+		return nil, fmt.Errorf("%w: %q is held by node %d", ErrDuplicateNodeKey, key, existing)
+	}
+	node, walPending, vectorPlans, err := gs.createNodeLocked(tenantID, labels, props)
+	gs.mu.Unlock()
+
+	// Post-lock steps as in CreateNodeWithTenant.
+	gs.applyNodeVectorInserts(vectorPlans)
+	gs.waitWALPending(wal.OpCreateNode, walPending)
+	if err == nil && node != nil {
+		gs.notifyNodeCreated(context.Background(), node)
+	}
+	return node, err
+}
+
+// GetNodeByKey retrieves the default-tenant node holding an external key.
+// Returns ErrNodeNotFound if none does.
+func (gs *GraphStorage) GetNodeByKey(key string) (*Node, error) {
+	return gs.GetNodeByKeyForTenant(key, DefaultTenantID)
+}
+
+// GetNodeByKeyForTenant retrieves the node of tenantID holding an external
+// key. Returns ErrNodeNotFound if none does.
+func (gs *GraphStorage) GetNodeByKeyForTenant(key, tenantID string) (*Node, error) {
+	if err := gs.checkClosed(); err != nil {
+		return nil, err
+	}
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.nodeByKeyLocked(tenantID, key)
+}
+
+// nodeByKeyLocked returns a copy of the node nodeIDByKeyLocked finds.
+// Caller must hold gs.mu (read or write).
+func (gs *GraphStorage) nodeByKeyLocked(tenantID, key string) (*Node, error) {
+	id, ok := gs.nodeIDByKeyLocked(tenantID, key)
+	if !ok {
+		return nil, ErrNodeNotFound
+	}
+	node, owned, exists := gs.resolveNodeRefOwnedLocked(id)
+	if !exists {
+		return nil, ErrNodeNotFound
+	}
+	if !owned {
+		node = node.Clone()
+	}
+	return node, nil
+}
+
+// nodeIDByKeyLocked returns the ID of the tenant's node holding key. It
+// looks the key up in the key index, or scans every node when there is no
+// index (no key was ever created through CreateNodeWithKey, or the index was
+// dropped). Caller must hold gs.mu (read or write).
+func (gs *GraphStorage) nodeIDByKeyLocked(tenantID, key string) (uint64, bool) {
+	tid := effectiveTenantID(tenantID).String()
+	value := StringValue(key)
+
+	idx, ok := gs.propertyIndexes[NodeKeyProperty]
+	if !ok {
+		var found uint64
+		gs.forEachNodeUnlocked(func(node *Node) bool {
+			if prop, has := node.Properties[NodeKeyProperty]; has && node.TenantID == tid &&
+				prop.Type == value.Type && string(prop.Data) == key {
+				found = node.ID
+				return false
+			}
+			return true
+		})
+		return found, found != 0
+	}
+
+	ids, err := idx.Lookup(value)
+	if err != nil {
+		return 0, false
+	}
+	for _, id := range ids {
+		if node, exists := gs.resolveNodeRefLocked(id); exists && node.TenantID == tid {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// checkNodeKeyUpdateLocked fails if properties would give node nodeID of
+// tenantID a key that is not a non-empty string (ErrInvalidArgument), or
+// one another node of the tenant holds (ErrDuplicateNodeKey). Rewriting the
+// key the node already has is always allowed, so updates that echo every
+// property back keep working. Caller must hold gs.mu.
+func (gs *GraphStorage) checkNodeKeyUpdateLocked(tenantID string, nodeID uint64, properties map[string]Value) error {
+	value, ok := properties[NodeKeyProperty]
+	if !ok {
+		return nil
+	}
+	if node, exists := gs.resolveNodeRefLocked(nodeID); exists {
+		if current, has := node.Properties[NodeKeyProperty]; has &&
+			current.Type == value.Type && bytes.Equal(current.Data, value.Data) {
+			return nil
+		}
+	}
+	key, err := value.AsString()
+	if err != nil || key == "" {
+		return fmt.Errorf("%w: node key must be a non-empty string", ErrInvalidArgument)
+	}
+	if holder, held := gs.nodeIDByKeyLocked(tenantID, key); held && holder != nodeID {
+		return fmt.Errorf("%w: %q is held by node %d", ErrDuplicateNodeKey, key, holder)
+	}
+	return nil
+}
+
+// ensureNodeKeyIndexLocked creates the property index on NodeKeyProperty
+// if it doesn't exist yet. Caller must hold gs.mu.Lock.
+func (gs *GraphStorage) ensureNodeKeyIndexLocked() error {
+	if _, ok := gs.propertyIndexes[NodeKeyProperty]; ok {
+		return nil
+	}
+	return gs.createPropertyIndexLocked(NodeKeyProperty, TypeString)
+}
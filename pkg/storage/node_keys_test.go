@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNodeKeys(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	router, err := gs.CreateNodeWithKey("rtr-01", []string{"Router"}, map[string]Value{"zone": StringValue("pop1")})
+	if err != nil {
+		t.Fatalf("CreateNodeWithKey: %v", err)
+	}
+	got, err := gs.GetNodeByKey("rtr-01")
+	if err != nil || got.ID != router.ID {
+		t.Fatalf("GetNodeByKey = %v, %v; want node %d", got, err, router.ID)
+	}
+	if zone, _ := got.Properties["zone"].AsString(); zone != "pop1" {
+		t.Errorf("zone = %q, want pop1", zone)
+	}
+
+	if _, err := gs.CreateNodeWithKey("rtr-01", []string{"Router"}, nil); !errors.Is(err, ErrDuplicateNodeKey) {
+		t.Errorf("duplicate key: err = %v, want ErrDuplicateNodeKey", err)
+	}
+	if _, err := gs.CreateNodeWithKey("", nil, nil); err == nil {
+		t.Error("empty key accepted")
+	}
+	if _, err := gs.GetNodeByKey("rtr-02"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("unknown key: err = %v, want ErrNodeNotFound", err)
+	}
+
+	// Keys are unique per tenant.
+	other, err := gs.CreateNodeWithKeyForTenant("other", "rtr-01", []string{"Router"}, nil)
+	if err != nil {
+		t.Fatalf("same key in another tenant: %v", err)
+	}
+	if got, err := gs.GetNodeByKeyForTenant("rtr-01", "other"); err != nil || got.ID != other.ID {
+		t.Errorf("GetNodeByKeyForTenant = %v, %v; want node %d", got, err, other.ID)
+	}
+
+	// Deleting the node frees its key.
+	if err := gs.DeleteNode(router.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gs.GetNodeByKey("rtr-01"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("deleted node's key: err = %v, want ErrNodeNotFound", err)
+	}
+	if _, err := gs.CreateNodeWithKey("rtr-01", []string{"Router"}, nil); err != nil {
+		t.Errorf("reusing a freed key: %v", err)
+	}
+}
+
+func TestNodeKeys_UniqueOnUpdate(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	a, err := gs.CreateNodeWithKey("host-a", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := gs.CreateNodeWithKey("host-b", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gs.UpdateNode(b.ID, map[string]Value{NodeKeyProperty: StringValue("host-a")}); !errors.Is(err, ErrDuplicateNodeKey) {
+		t.Errorf("update to a held key: err = %v, want ErrDuplicateNodeKey", err)
+	}
+	if err := gs.UpdateNode(b.ID, map[string]Value{NodeKeyProperty: IntValue(7)}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("update to a non-string key: err = %v, want ErrInvalidArgument", err)
+	}
+	if err := gs.UpdateNode(a.ID, map[string]Value{NodeKeyProperty: StringValue("host-a")}); err != nil {
+		t.Errorf("rewriting a node's own key: %v", err)
+	}
+	if err := gs.UpdateNode(b.ID, map[string]Value{NodeKeyProperty: StringValue("host-c")}); err != nil {
+		t.Fatalf("renaming to a free key: %v", err)
+	}
+	if got, err := gs.GetNodeByKey("host-c"); err != nil || got.ID != b.ID {
+		t.Errorf("GetNodeByKey(host-c) = %v, %v; want node %d", got, err, b.ID)
+	}
+
+	// A key shared before updates were checked can still be written back
+	// unchanged.
+	dup, err := gs.CreateNode(nil, map[string]Value{NodeKeyProperty: StringValue("host-a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gs.UpdateNode(dup.ID, map[string]Value{NodeKeyProperty: StringValue("host-a")}); err != nil {
+		t.Errorf("echoing a node's existing key: %v", err)
+	}
+
+	tx, err := gs.BeginTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.UpdateNode(b.ID, map[string]Value{NodeKeyProperty: StringValue("host-a")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); !errors.Is(err, ErrDuplicateNodeKey) {
+		t.Errorf("transaction update to a held key: err = %v, want ErrDuplicateNodeKey", err)
+	}
+}
+
+// TestNodeKeys_LookupWithoutIndex checks that a keyed read works without the
+// key index and does not create it.
+func TestNodeKeys_LookupWithoutIndex(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	node, err := gs.CreateNode(nil, map[string]Value{NodeKeyProperty: StringValue("host-a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := gs.GetNodeByKey("host-a"); err != nil || got.ID != node.ID {
+		t.Errorf("GetNodeByKey = %v, %v; want node %d", got, err, node.ID)
+	}
+	if gs.HasPropertyIndex(NodeKeyProperty) {
+		t.Error("GetNodeByKey created the key index")
+	}
+	if _, err := gs.CreateNodeWithKey("host-a", nil, nil); !errors.Is(err, ErrDuplicateNodeKey) {
+		t.Errorf("CreateNodeWithKey over an unindexed key: err = %v, want ErrDuplicateNodeKey", err)
+	}
+}
+
+func TestNodeKeys_SurviveReopen(t *testing.T) {
+	for _, mmap := range []bool{false, true} {
+		name := "json"
+		if mmap {
+			name = "mmap"
+		}
+		t.Run(name, func(t *testing.T) {
+			cfg := StorageConfig{DataDir: t.TempDir(), UseMmapSnapshot: mmap}
+			gs, err := NewGraphStorageWithConfig(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			node, err := gs.CreateNodeWithKey("host-a", []string{"Host"}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gs.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			reopened, err := NewGraphStorageWithConfig(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reopened.Close()
+			if got, err := reopened.GetNodeByKey("host-a"); err != nil || got.ID != node.ID {
+				t.Errorf("after reopen: GetNodeByKey = %v, %v; want node %d", got, err, node.ID)
+			}
+			if _, err := reopened.CreateNodeWithKey("host-a", nil, nil); !errors.Is(err, ErrDuplicateNodeKey) {
+				t.Errorf("after reopen: duplicate err = %v, want ErrDuplicateNodeKey", err)
+			}
+		})
+	}
+}
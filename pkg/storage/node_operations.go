@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"sync/atomic"
 	"time"
 
@@ -14,6 +15,10 @@ import (
 
 // CreateNode creates a new node in the default tenant.
 // For multi-tenant operations, use CreateNodeWithTenant instead.
+//
+// Node IDs come from a monotonic counter that survives restarts: a deleted
+// node's ID is never handed out again, so a stale reference can't silently
+// resolve to an unrelated node.
 func (gs *GraphStorage) CreateNode(labels []string, properties map[string]Value) (*Node, error) {
 	return gs.CreateNodeWithTenant(DefaultTenantID, labels, properties)
 }
@@ -45,6 +50,14 @@ func (gs *GraphStorage) CreateNodeWithTenant(tenantID string, labels []string, p
 	return node, err
 }
 
+// CreateNodeForTenant creates a new node owned by tenantID. It is the
+// *ForTenant-named spelling of CreateNodeWithTenant, so tenant-scoped call
+// sites read uniformly (CreateNodeForTenant / GetNodeForTenant /
+// DeleteNodeForTenant); both share the same implementation.
+func (gs *GraphStorage) CreateNodeForTenant(tenantID string, labels []string, properties map[string]Value) (*Node, error) {
+	return gs.CreateNodeWithTenant(tenantID, labels, properties)
+}
+
 // NodeSpec describes one node for bulk creation.
 type NodeSpec struct {
 	Labels     []string
@@ -127,17 +140,17 @@ func (gs *GraphStorage) CreateNodeWithUniquePropertyForTenant(
 	uniquePropertyKey string,
 ) (*Node, error) {
 	if uniqueLabel == "" || uniquePropertyKey == "" {
-		return nil, fmt.Errorf("uniqueLabel and uniquePropertyKey are required")
+		return nil, fmt.Errorf("%w: uniqueLabel and uniquePropertyKey are required", ErrInvalidArgument)
 	}
 
 	// Caller-side sanity: the new node must carry the labelled property
 	// so the uniqueness rule is meaningful.
 	if !containsString(labels, uniqueLabel) {
-		return nil, fmt.Errorf("uniqueLabel %q must be present in labels", uniqueLabel)
+		return nil, fmt.Errorf("%w: uniqueLabel %q must be present in labels", ErrInvalidArgument, uniqueLabel)
 	}
 	newVal, ok := properties[uniquePropertyKey]
 	if !ok {
-		return nil, fmt.Errorf("property %q is required for uniqueness check", uniquePropertyKey)
+		return nil, fmt.Errorf("%w: property %q is required for uniqueness check", ErrInvalidArgument, uniquePropertyKey)
 	}
 
 	gs.mu.Lock()
@@ -192,14 +205,14 @@ func (gs *GraphStorage) createNodeLocked(tenantID string, labels []string, prope
 
 	// Check if storage is closed
 	if err := gs.checkClosed(); err != nil {
-		gs.recordOperation("create_node", "error", start)
+		gs.recordOperation(OpCreateNode, "error", start)
 		return nil, nil, nil, err
 	}
 
 	// Check for ID space exhaustion
 	if gs.nextNodeID == ^uint64(0) { // MaxUint64
-		gs.recordOperation("create_node", "error", start)
-		return nil, nil, nil, fmt.Errorf("node ID space exhausted")
+		gs.recordOperation(OpCreateNode, "error", start)
+		return nil, nil, nil, fmt.Errorf("node %w", ErrIDSpaceExhausted)
 	}
 
 	nodeID := gs.nextNodeID
@@ -211,8 +224,8 @@ func (gs *GraphStorage) createNodeLocked(tenantID string, labels []string, prope
 		// Node.TenantID is still string — A3 will migrate it. For now,
 		// .String() preserves the existing wire format.
 		TenantID:   effectiveTenantID(tenantID).String(),
-		Labels:     labels,
-		Properties: properties,
+		Labels:     slices.Clone(labels),
+		Properties: copyProperties(properties),
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}
@@ -222,17 +235,19 @@ func (gs *GraphStorage) createNodeLocked(tenantID string, labels []string, prope
 	// plans for the caller to apply off-lock.
 	vectorPlans, err := gs.persistNodeLocked(node)
 	if err != nil {
-		gs.recordOperation("create_node", "error", start)
+		gs.recordOperation(OpCreateNode, "error", start)
 		return nil, nil, nil, err
 	}
 
+	gs.bumpVersion()
+
 	// Enqueue to WAL for durability. For the batched WAL this does NOT block on
 	// the fsync — the caller waits on the returned handle AFTER releasing gs.mu
 	// so concurrent writers can fill the same batch (Track P item 1). Enqueue
 	// happens here, under gs.mu, so WAL order matches in-memory mutation order.
 	walPending := gs.enqueueWAL(wal.OpCreateNode, node)
 
-	gs.recordOperation("create_node", "success", start)
+	gs.trackOperation(OpCreateNode, start, nil)
 	return node.Clone(), walPending, vectorPlans, nil
 }
 
@@ -445,6 +460,29 @@ func (gs *GraphStorage) UpdateNodeForTenant(nodeID uint64, properties map[string
 	return gs.UpdateNode(nodeID, properties)
 }
 
+// UpdateNodeForTenantIfRevision is UpdateNodeForTenant applied only if the
+// node is still at the given revision (see Node.Revision); otherwise it
+// returns ErrConflict and changes nothing.
+func (gs *GraphStorage) UpdateNodeForTenantIfRevision(nodeID uint64, properties map[string]Value, tenantID string, revision uint64) error {
+	gs.rlockShard(nodeID)
+	if _, err := gs.getNodeRefForTenant(nodeID, tenantID); err != nil {
+		gs.runlockShard(nodeID)
+		return err
+	}
+	gs.runlockShard(nodeID)
+	return gs.updateNode(nodeID, properties, &revision)
+}
+
+// UpdateNodeIfRevision is UpdateNode applied only if the node is still at
+// the given revision; otherwise it returns ErrConflict. The check and the
+// update happen under the same write lock, so two writers that read the
+// same revision cannot both succeed.
+//
+// Tenant-blind. New callers should prefer UpdateNodeForTenantIfRevision.
+func (gs *GraphStorage) UpdateNodeIfRevision(nodeID uint64, properties map[string]Value, revision uint64) error {
+	return gs.updateNode(nodeID, properties, &revision)
+}
+
 // UpdateNode updates a node's properties.
 //
 // Tenant-blind. New callers should prefer UpdateNodeForTenant.
@@ -455,6 +493,12 @@ func (gs *GraphStorage) UpdateNodeForTenant(nodeID uint64, properties map[string
 // only allocated when observers are registered — observerless callers pay
 // zero clone cost.
 func (gs *GraphStorage) UpdateNode(nodeID uint64, properties map[string]Value) error {
+	return gs.updateNode(nodeID, properties, nil)
+}
+
+// updateNode implements UpdateNode; a non-nil revision makes it conditional.
+func (gs *GraphStorage) updateNode(nodeID uint64, properties map[string]Value, revision *uint64) (err error) {
+	defer gs.trackOperation(OpUpdateNode, time.Now(), &err)
 	gs.mu.Lock()
 
 	// mmap mode: promote a base-resident node into the shard overlay (copy-on-write)
@@ -466,11 +510,21 @@ func (gs *GraphStorage) UpdateNode(nodeID uint64, properties map[string]Value) e
 		gs.mu.Unlock()
 		return ErrNodeNotFound
 	}
+	// gs.mu excludes every other writer, so the revision read here is the
+	// one this update replaces.
+	if revision != nil && node.Revision() != *revision {
+		gs.mu.Unlock()
+		return ErrConflict
+	}
+	if err := gs.checkNodeKeyUpdateLocked(node.TenantID, nodeID, properties); err != nil {
+		gs.mu.Unlock()
+		return err
+	}
 
 	// R2.1: snapshot pre-update state for observer dispatch. Only allocate
 	// when observers are registered.
 	var oldNode *Node
-	if len(gs.observers) > 0 {
+	if gs.wantsNodeNotifyLocked() {
 		oldNode = node.Clone()
 	}
 
@@ -483,8 +537,11 @@ func (gs *GraphStorage) UpdateNode(nodeID uint64, properties map[string]Value) e
 	// Per-shard write lock (A4) excludes shard.RLock readers during
 	// the in-place Node-struct mutation that follows.
 	gs.lockShard(nodeID)
+	if node.Properties == nil {
+		node.Properties = make(map[string]Value, len(properties))
+	}
 	for k, v := range properties {
-		node.Properties[k] = v
+		node.Properties[k] = gs.interner.value(v)
 	}
 	node.UpdatedAt = time.Now().Unix()
 	gs.unlockShard(nodeID)
@@ -502,13 +559,12 @@ func (gs *GraphStorage) UpdateNode(nodeID uint64, properties map[string]Value) e
 		return err
 	}
 
+	gs.bumpVersion()
+
 	// Enqueue to WAL under gs.mu (preserves WAL order); wait on durability
 	// after releasing gs.mu so concurrent writers can fill the batch (group
 	// commit, Track P item 1).
-	walPending := gs.enqueueWAL(wal.OpUpdateNode, struct {
-		NodeID     uint64
-		Properties map[string]Value
-	}{
+	walPending := gs.enqueueWAL(wal.OpUpdateNode, nodePropertiesUpdate{
 		NodeID:     nodeID,
 		Properties: properties,
 	})
@@ -550,7 +606,7 @@ func (gs *GraphStorage) RemoveNodeProperties(nodeID uint64, keys []string) error
 	// R2.1: snapshot pre-removal state for observer dispatch. Only
 	// allocate when observers are registered.
 	var oldNode *Node
-	if len(gs.observers) > 0 {
+	if gs.wantsNodeNotifyLocked() {
 		oldNode = node.Clone()
 	}
 
@@ -597,15 +653,14 @@ func (gs *GraphStorage) RemoveNodeProperties(nodeID uint64, keys []string) error
 		walProps[k] = v
 	}
 	gs.unlockShard(nodeID)
+	gs.bumpVersion()
+
 	// Enqueue under gs.mu (preserves WAL order); wait on durability after
 	// releasing gs.mu so concurrent writers can fill the same batch (group
 	// commit, Track P item 1 — the create/update/delete paths already do this;
 	// this finishes RemoveNodeProperties, the last node write path on the
 	// synchronous writeToWAL).
-	walPending := gs.enqueueWAL(wal.OpUpdateNode, struct {
-		NodeID     uint64
-		Properties map[string]Value
-	}{
+	walPending := gs.enqueueWAL(wal.OpUpdateNode, nodePropertiesUpdate{
 		NodeID:     nodeID,
 		Properties: walProps,
 	})
@@ -674,6 +729,40 @@ func (gs *GraphStorage) DeleteAllNodesForTenant(tenantID string) error {
 	return nil
 }
 
+// DeleteNodesByLabel removes every default-tenant node carrying label, and
+// their edges, returning how many nodes were removed.
+// For multi-tenant operations, use DeleteNodesByLabelForTenant instead.
+func (gs *GraphStorage) DeleteNodesByLabel(label string) (int, error) {
+	return gs.DeleteNodesByLabelForTenant(DefaultTenantID, label)
+}
+
+// DeleteNodesByLabelForTenant removes every node of one tenant carrying
+// label, and their edges, returning how many nodes were removed. The label's
+// members are snapshotted first and each is deleted through DeleteNode, so
+// WAL records and mmap tombstones are written as for single deletes (see
+// DeleteAllNodesForTenant). A node a concurrent writer removes first isn't
+// counted.
+func (gs *GraphStorage) DeleteNodesByLabelForTenant(tenantID, label string) (int, error) {
+	if err := gs.checkClosed(); err != nil {
+		return 0, err
+	}
+	gs.mu.RLock()
+	ids := gs.membershipNodeIDsByLabelLocked(effectiveTenantID(tenantID), label)
+	gs.mu.RUnlock()
+
+	deleted := 0
+	for _, id := range ids {
+		if err := gs.DeleteNodeForTenant(id, tenantID); err != nil {
+			if errors.Is(err, ErrNodeNotFound) {
+				continue // concurrent delete already removed it — that's the goal
+			}
+			return deleted, fmt.Errorf("delete node %d with label %s: %w", id, label, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
 // DeleteNodeForTenant deletes a node and all its edges, scoped to the
 // given tenant. Returns ErrNodeNotFound on missing or cross-tenant
 // (same rationale as GetNodeForTenant).
@@ -698,38 +787,49 @@ func (gs *GraphStorage) DeleteNodeForTenant(nodeID uint64, tenantID string) erro
 //
 // Tenant-blind. New callers should prefer DeleteNodeForTenant.
 //
-// Lock discipline (R2.1, S11 spike §7.4): defer-unlock was replaced with
-// explicit unlock at every return path so notifyNodeDeleted can dispatch
-// strictly after gs.mu.Lock is released. The deleted node's TenantID is
-// captured under lock (from the lookup at line 514) and passed to the
-// notify call after unlock — the node's data is not accessible by then.
-func (gs *GraphStorage) DeleteNode(nodeID uint64) error {
+// Lock discipline (R2.1, S11 spike §7.4): the mutation runs in
+// deleteNodeLocked under gs.mu.Lock; notifyNodeDeleted dispatches strictly
+// after the lock is released. The deleted node's TenantID is captured under
+// lock and passed to the notify call after unlock — the node's data is not
+// accessible by then.
+func (gs *GraphStorage) DeleteNode(nodeID uint64) (err error) {
+	defer gs.trackOperation(OpDeleteNode, time.Now(), &err)
 	gs.mu.Lock()
+	tenantID, walPending, err := gs.deleteNodeLocked(nodeID)
+	gs.mu.Unlock()
+	if err != nil {
+		return err
+	}
 
+	gs.waitWALPending(wal.OpDeleteNode, walPending)
+	// R2.1: dispatch after lock release. See lock-discipline comment in
+	// pkg/storage/observation.go.
+	gs.notifyNodeDeleted(context.Background(), nodeID, tenantID)
+	return nil
+}
+
+// deleteNodeLocked removes a node, cascades its edges, and enqueues the
+// OpDeleteNode WAL entry. It returns the node's TenantID for the caller's
+// post-unlock notify and the WAL handle to wait on. Caller must hold
+// gs.mu.Lock; shared by DeleteNode and RestoreSnapshot.
+func (gs *GraphStorage) deleteNodeLocked(nodeID uint64) (string, *wal.Pending, error) {
 	// resolve overlay → base; the node's fields drive index removal below.
 	node, exists := gs.resolveNodeRefLocked(nodeID)
 	if !exists {
-		gs.mu.Unlock()
-		return ErrNodeNotFound
+		return "", nil, ErrNodeNotFound
 	}
 
-	// Capture for OnNodeDeleted dispatch after unlock. node.TenantID is
-	// stable for the lifetime of the node (immutable after creation).
-	tenantID := node.TenantID
-
 	// Get edges to delete (disk-backed or in-memory)
 	var outgoingEdgeIDs, incomingEdgeIDs []uint64
 	if gs.useDiskBackedEdges {
 		var err error
 		outgoingEdgeIDs, err = gs.edgeStore.GetOutgoingEdges(nodeID)
 		if err != nil {
-			gs.mu.Unlock()
-			return fmt.Errorf("failed to get outgoing edges for node %d: %w", nodeID, err)
+			return "", nil, fmt.Errorf("failed to get outgoing edges for node %d: %w", nodeID, err)
 		}
 		incomingEdgeIDs, err = gs.edgeStore.GetIncomingEdges(nodeID)
 		if err != nil {
-			gs.mu.Unlock()
-			return fmt.Errorf("failed to get incoming edges for node %d: %w", nodeID, err)
+			return "", nil, fmt.Errorf("failed to get incoming edges for node %d: %w", nodeID, err)
 		}
 	} else {
 		// Use getEdgeIDsForNode so that mmap mode picks up CSR-base edges
@@ -742,16 +842,14 @@ func (gs *GraphStorage) DeleteNode(nodeID uint64) error {
 	// Cascade delete all outgoing edges
 	for _, edgeID := range outgoingEdgeIDs {
 		if err := gs.cascadeDeleteOutgoingEdge(edgeID); err != nil {
-			gs.mu.Unlock()
-			return fmt.Errorf("failed to cascade delete outgoing edge %d: %w", edgeID, err)
+			return "", nil, fmt.Errorf("failed to cascade delete outgoing edge %d: %w", edgeID, err)
 		}
 	}
 
 	// Cascade delete all incoming edges
 	for _, edgeID := range incomingEdgeIDs {
 		if err := gs.cascadeDeleteIncomingEdge(edgeID); err != nil {
-			gs.mu.Unlock()
-			return fmt.Errorf("failed to cascade delete incoming edge %d: %w", edgeID, err)
+			return "", nil, fmt.Errorf("failed to cascade delete incoming edge %d: %w", edgeID, err)
 		}
 	}
 
@@ -765,23 +863,20 @@ func (gs *GraphStorage) DeleteNode(nodeID uint64) error {
 
 	// Remove from property indexes
 	if err := gs.removeNodeFromPropertyIndexes(nodeID, node.Properties); err != nil {
-		gs.mu.Unlock()
-		return err
+		return "", nil, err
 	}
 
 	// Remove from vector indexes (R1.2: routes by node.TenantID; empty
 	// TenantID on legacy tenant-blind nodes falls back to tenantid.Default
 	// inside RemoveNodeFromVectorIndexes).
 	if err := gs.RemoveNodeFromVectorIndexes(nodeID, node.TenantID); err != nil {
-		gs.mu.Unlock()
-		return err
+		return "", nil, err
 	}
 
 	// Delete node — per-shard write lock (A4) excludes shard.RLock
 	// readers during the nodeShards delete. The cascade work above
 	// (label/property/vector index removal, edge cascades) all touches
-	// global structures under the gs.mu.Lock that's held throughout
-	// this function.
+	// global structures under the gs.mu.Lock the caller holds.
 	gs.lockShard(nodeID)
 	gs.deleteNodeShardEntry(nodeID)
 	gs.markNodeDeletedLocked(nodeID) // mmap mode: mask the base-resident node
@@ -789,38 +884,37 @@ func (gs *GraphStorage) DeleteNode(nodeID uint64) error {
 
 	// Delete adjacency lists (disk-backed or in-memory)
 	if err := gs.clearNodeAdjacency(nodeID); err != nil {
-		gs.mu.Unlock()
-		return fmt.Errorf("failed to clear adjacency for node %d: %w", nodeID, err)
+		return "", nil, fmt.Errorf("failed to clear adjacency for node %d: %w", nodeID, err)
 	}
 
 	// Atomic decrement with underflow protection
 	atomicDecrementWithUnderflowProtection(&gs.stats.NodeCount)
 
-	// Enqueue to WAL under gs.mu (preserves WAL order); wait on durability after
-	// releasing gs.mu so concurrent writers can fill the batch (Track P item 1).
-	walPending := gs.enqueueWAL(wal.OpDeleteNode, node)
-
-	gs.mu.Unlock()
+	gs.bumpVersion()
 
-	gs.waitWALPending(wal.OpDeleteNode, walPending)
-	// R2.1: dispatch after lock release. See lock-discipline comment in
-	// pkg/storage/observation.go.
-	gs.notifyNodeDeleted(context.Background(), nodeID, tenantID)
-	return nil
+	// Enqueue to WAL under gs.mu (preserves WAL order); the caller waits on
+	// durability after releasing gs.mu so concurrent writers can fill the
+	// batch (Track P item 1). node.TenantID is stable for the lifetime of
+	// the node (immutable after creation).
+	return node.TenantID, gs.enqueueWAL(wal.OpDeleteNode, node), nil
 }
 
-// GetAllNodeIDs returns all node IDs in the storage.
-// This is the preferred way to iterate over all nodes, as it handles
-// deleted nodes correctly (unlike iterating from 1 to NodeCount).
+// GetAllNodeIDs returns all node IDs in the storage, in ascending order.
+//
+// Node IDs are assigned from a monotonic counter and never reused, so once
+// nodes are deleted the live IDs have gaps and the highest one exceeds
+// NodeCount. Enumerate with GetAllNodeIDs (or ForEachNode) rather than
+// iterating from 1 to NodeCount, which skips nodes created after a delete.
 func (gs *GraphStorage) GetAllNodeIDs() []uint64 {
 	gs.mu.RLock()
-	defer gs.mu.RUnlock()
-
 	nodeIDs := make([]uint64, 0, gs.nodeCount())
 	gs.forEachNodeIDUnlocked(func(id uint64) bool {
 		nodeIDs = append(nodeIDs, id)
 		return true
 	})
+	gs.mu.RUnlock()
+
+	slices.Sort(nodeIDs)
 	return nodeIDs
 }
 
@@ -879,6 +973,9 @@ func (gs *GraphStorage) DeleteAllNodes() error {
 	gs.outgoingEdges = make(map[uint64][]uint64)
 	gs.incomingEdges = make(map[uint64][]uint64)
 	gs.propertyIndexes = make(map[string]*PropertyIndex)
+	for def := range gs.edgePropertyIndexes {
+		gs.edgePropertyIndexes[def] = make(edgePropertyIndex)
+	}
 	gs.tenantNodesByLabel = make(map[tenantid.TenantID]labelIndex)
 	gs.tenantEdgesByType = make(map[tenantid.TenantID]labelIndex)
 	gs.tenantStats = make(map[tenantid.TenantID]*TenantStats)
@@ -109,6 +109,7 @@ func (gs *GraphStorage) snapshotObservers() []NodeObserver {
 // live shard pointer. The callers in node_operations.go capture this
 // snapshot before unlocking.
 func (gs *GraphStorage) notifyNodeCreated(ctx context.Context, node *Node) {
+	gs.publishChange(ChangeCreate, EntityNode, node.ID, node.TenantID)
 	observers := gs.snapshotObservers()
 	for _, obs := range observers {
 		obs.OnNodeCreated(ctx, node)
@@ -119,6 +120,7 @@ func (gs *GraphStorage) notifyNodeCreated(ctx context.Context, node *Node) {
 // Both node and oldNode should be clones captured BEFORE the public
 // mutation method's unlock.
 func (gs *GraphStorage) notifyNodeUpdated(ctx context.Context, node *Node, oldNode *Node) {
+	gs.publishChange(ChangeUpdate, EntityNode, node.ID, node.TenantID)
 	observers := gs.snapshotObservers()
 	for _, obs := range observers {
 		obs.OnNodeUpdated(ctx, node, oldNode)
@@ -129,6 +131,7 @@ func (gs *GraphStorage) notifyNodeUpdated(ctx context.Context, node *Node, oldNo
 // nodeID and tenantID are captured from the looked-up node BEFORE the
 // deletion runs.
 func (gs *GraphStorage) notifyNodeDeleted(ctx context.Context, nodeID uint64, tenantID string) {
+	gs.publishChange(ChangeDelete, EntityNode, nodeID, tenantID)
 	observers := gs.snapshotObservers()
 	for _, obs := range observers {
 		obs.OnNodeDeleted(ctx, nodeID, tenantID)
@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 	"sync/atomic"
-	"time"
 
 	"github.com/dd0wney/graphdb/pkg/encryption"
 	"github.com/dd0wney/graphdb/pkg/tenantid"
@@ -67,6 +66,7 @@ func (gs *GraphStorage) snapshotWithBoundary() (uint64, error) {
 	// SetEncryption writes this field under gs.mu.Lock, and the encrypt
 	// call + envelope flag below must agree on one value.
 	engine := gs.encryptionEngine
+	fieldCipher := gs.fieldCipher
 
 	// Get statistics atomically before creating snapshot
 	stats := gs.GetStatistics()
@@ -85,17 +85,18 @@ func (gs *GraphStorage) snapshotWithBoundary() (uint64, error) {
 	}
 
 	snapshot := struct {
-		Nodes           map[uint64]*Node
-		Edges           map[uint64]*Edge
-		NodesByLabel    map[string][]uint64
-		EdgesByType     map[string][]uint64
-		OutgoingEdges   map[uint64][]uint64
-		IncomingEdges   map[uint64][]uint64
-		PropertyIndexes map[string]PropertyIndexSnapshot
-		VectorIndexes   []VectorIndexDef
-		NextNodeID      uint64
-		NextEdgeID      uint64
-		Stats           Statistics
+		Nodes               map[uint64]*Node
+		Edges               map[uint64]*Edge
+		NodesByLabel        map[string][]uint64
+		EdgesByType         map[string][]uint64
+		OutgoingEdges       map[uint64][]uint64
+		IncomingEdges       map[uint64][]uint64
+		PropertyIndexes     map[string]PropertyIndexSnapshot
+		VectorIndexes       []VectorIndexDef
+		EdgePropertyIndexes []EdgePropertyIndexDef
+		NextNodeID          uint64
+		NextEdgeID          uint64
+		Stats               Statistics
 	}{
 		// ISOLATION: every field below must be a deep copy, never a
 		// reference to a live structure. json.Marshal runs after
@@ -121,6 +122,9 @@ func (gs *GraphStorage) snapshotWithBoundary() (uint64, error) {
 		// readable (absent field -> no indexes recreated -> the prior
 		// vectors-lost-on-restart behaviour, unchanged for old files).
 		VectorIndexes: gs.vectorIndex.IndexDefinitions(),
+		// Edge property index definitions only, rebuilt after replay like
+		// the vector indexes. Additive field.
+		EdgePropertyIndexes: gs.edgePropertyIndexDefs(),
 		// Atomic loads: Transaction ops allocate IDs via atomic.AddUint64
 		// WITHOUT gs.mu, so a plain read here races them (a high-water
 		// counter that runs slightly ahead of visible state is fine —
@@ -132,6 +136,21 @@ func (gs *GraphStorage) snapshotWithBoundary() (uint64, error) {
 
 	gs.mu.RUnlock()
 
+	// Sensitive property values are sealed on the clones, never the live
+	// nodes and edges.
+	if fieldCipher != nil {
+		for _, node := range snapshot.Nodes {
+			if err := fieldCipher.sealNode(node); err != nil {
+				return 0, err
+			}
+		}
+		for _, edge := range snapshot.Edges {
+			if err := fieldCipher.sealEdge(edge); err != nil {
+				return 0, err
+			}
+		}
+	}
+
 	data, err := json.Marshal(snapshot)
 	if err != nil {
 		return 0, fmt.Errorf("failed to marshal snapshot: %w", err)
@@ -163,8 +182,7 @@ func (gs *GraphStorage) snapshotWithBoundary() (uint64, error) {
 		return 0, fmt.Errorf("failed to rename snapshot: %w", err)
 	}
 
-	// Update LastSnapshot timestamp (safe to modify after releasing lock)
-	gs.stats.LastSnapshot = time.Now()
+	gs.noteSnapshot(boundary)
 
 	return boundary, nil
 }
@@ -215,17 +233,18 @@ func (gs *GraphStorage) loadFromDisk() error {
 	}
 
 	var snapshot struct {
-		Nodes           map[uint64]*Node
-		Edges           map[uint64]*Edge
-		NodesByLabel    map[string][]uint64
-		EdgesByType     map[string][]uint64
-		OutgoingEdges   map[uint64][]uint64
-		IncomingEdges   map[uint64][]uint64
-		PropertyIndexes map[string]PropertyIndexSnapshot
-		VectorIndexes   []VectorIndexDef
-		NextNodeID      uint64
-		NextEdgeID      uint64
-		Stats           Statistics
+		Nodes               map[uint64]*Node
+		Edges               map[uint64]*Edge
+		NodesByLabel        map[string][]uint64
+		EdgesByType         map[string][]uint64
+		OutgoingEdges       map[uint64][]uint64
+		IncomingEdges       map[uint64][]uint64
+		PropertyIndexes     map[string]PropertyIndexSnapshot
+		VectorIndexes       []VectorIndexDef
+		EdgePropertyIndexes []EdgePropertyIndexDef
+		NextNodeID          uint64
+		NextEdgeID          uint64
+		Stats               Statistics
 	}
 
 	prof.mark("decode/decrypt envelope")
@@ -235,6 +254,17 @@ func (gs *GraphStorage) loadFromDisk() error {
 	}
 	prof.mark("json.Unmarshal")
 
+	for _, node := range snapshot.Nodes {
+		if err := gs.fieldCipher.openNode(node); err != nil {
+			return err
+		}
+	}
+	for _, edge := range snapshot.Edges {
+		if err := gs.fieldCipher.openEdge(edge); err != nil {
+			return err
+		}
+	}
+
 	gs.rebucketSnapshotNodes(snapshot.Nodes)
 	gs.rebucketSnapshotEdges(snapshot.Edges)
 	prof.mark("rebucket nodes+edges")
@@ -295,6 +325,9 @@ func (gs *GraphStorage) loadFromDisk() error {
 		gs.propertyIndexes[key] = idx
 	}
 
+	gs.edgePropertyIndexes = make(map[EdgePropertyIndexDef]edgePropertyIndex)
+	gs.restoreEdgePropertyIndexDefs(snapshot.EdgePropertyIndexes)
+
 	// Recreate the vector index DEFINITIONS (empty HNSW graphs). The vectors
 	// themselves are inserted after WAL replay, over the final node set, by
 	// rebuildVectorIndexesFromNodes — so post-snapshot writes recovered from the
@@ -360,11 +393,17 @@ func (gs *GraphStorage) Close() error {
 	// if closed was already true, giving us the "already closed" branch
 	// without holding gs.mu.
 	if !gs.closed.CompareAndSwap(false, true) {
-		return fmt.Errorf("storage already closed")
+		return fmt.Errorf("close: %w", ErrStorageClosed)
 	}
 
-	// Save snapshot on close (without holding the lock to avoid deadlock)
-	if err := gs.Snapshot(); err != nil {
+	// End every Subscribe stream; no further mutations can publish.
+	gs.closeSubscribers()
+
+	// Save snapshot on close (without holding the lock to avoid deadlock).
+	// A scratch graph has nothing worth keeping; its dataDir goes instead.
+	if gs.scratch {
+		defer os.RemoveAll(gs.dataDir)
+	} else if err := gs.Snapshot(); err != nil {
 		return err
 	}
 
@@ -9,23 +9,38 @@ import (
 	"github.com/dd0wney/graphdb/pkg/wal"
 )
 
-// replayWAL replays WAL entries to recover state
+// replayWAL replays WAL entries to recover state. The WAL holds only what
+// the last checkpoint did not, so the replayed entries are also the WAL lag
+// HealthCheck reports until the next snapshot: snapshotLSN is seeded just
+// below the first of them.
 func (gs *GraphStorage) replayWAL() error {
+	var first uint64
+	replay := func(entry *wal.Entry) error {
+		if first == 0 || entry.LSN < first {
+			first = entry.LSN
+		}
+		return gs.replayEntry(entry)
+	}
+
+	var err error
 	if gs.useBatching && gs.batchedWAL != nil {
-		return gs.batchedWAL.Replay(func(entry *wal.Entry) error {
-			return gs.replayEntry(entry)
-		})
+		err = gs.batchedWAL.Replay(replay)
 	} else if gs.useCompression && gs.compressedWAL != nil {
 		// This branch was MISSING: even entries that did reach the
 		// compressed WAL (the batch executor's appendToWAL) were never
 		// replayed on recovery.
-		return gs.compressedWAL.Replay(func(entry *wal.Entry) error {
-			return gs.replayEntry(entry)
-		})
+		err = gs.compressedWAL.Replay(replay)
 	} else if gs.wal != nil {
-		return gs.wal.Replay(func(entry *wal.Entry) error {
-			return gs.replayEntry(entry)
-		})
+		err = gs.wal.Replay(replay)
+	}
+	if err != nil {
+		return err
+	}
+
+	if first > 0 {
+		gs.snapshotLSN.Store(first - 1)
+	} else {
+		gs.snapshotLSN.Store(gs.GetCurrentLSN())
 	}
 	return nil
 }
@@ -65,6 +80,10 @@ func (gs *GraphStorage) replayEntry(entry *wal.Entry) error {
 		return gs.replayCreateVectorIndex(entry)
 	case wal.OpDropVectorIndex:
 		return gs.replayDropVectorIndex(entry)
+	case wal.OpCreateEdgePropertyIndex:
+		return gs.replayCreateEdgePropertyIndex(entry)
+	case wal.OpDropEdgePropertyIndex:
+		return gs.replayDropEdgePropertyIndex(entry)
 	}
 	return nil
 }
@@ -74,6 +93,9 @@ func (gs *GraphStorage) replayCreateNode(entry *wal.Entry) error {
 	if err := json.Unmarshal(entry.Data, &node); err != nil {
 		return err
 	}
+	if err := gs.fieldCipher.openNode(&node); err != nil {
+		return err
+	}
 
 	// Skip if node already exists (already in snapshot — overlay or mmap base)
 	if _, exists := gs.resolveNodeRefLocked(node.ID); exists {
@@ -115,13 +137,13 @@ func (gs *GraphStorage) replayCreateNode(entry *wal.Entry) error {
 }
 
 func (gs *GraphStorage) replayUpdateNode(entry *wal.Entry) error {
-	var updateInfo struct {
-		NodeID     uint64
-		Properties map[string]Value
-	}
+	var updateInfo nodePropertiesUpdate
 	if err := json.Unmarshal(entry.Data, &updateInfo); err != nil {
 		return err
 	}
+	if err := gs.fieldCipher.openProperties(updateInfo.Properties); err != nil {
+		return fmt.Errorf("node %d: %w", updateInfo.NodeID, err)
+	}
 
 	// Skip if node doesn't exist; promote a base-resident node into the overlay.
 	node, exists := gs.materializeNodeLocked(updateInfo.NodeID)
@@ -136,7 +158,7 @@ func (gs *GraphStorage) replayUpdateNode(entry *wal.Entry) error {
 
 	// Apply property updates
 	for key, value := range updateInfo.Properties {
-		node.Properties[key] = value
+		node.Properties[key] = gs.interner.value(value)
 	}
 
 	return nil
@@ -154,6 +176,9 @@ func (gs *GraphStorage) replayUpdateEdge(entry *wal.Entry) error {
 	if err := json.Unmarshal(entry.Data, &edge); err != nil {
 		return err
 	}
+	if err := gs.fieldCipher.openEdge(&edge); err != nil {
+		return err
+	}
 
 	// Skip if the edge doesn't exist (e.g. deleted later in the WAL); promote a
 	// base-resident edge into the overlay before mutating.
@@ -162,6 +187,7 @@ func (gs *GraphStorage) replayUpdateEdge(entry *wal.Entry) error {
 		return nil
 	}
 
+	gs.interner.properties(edge.Properties)
 	existing.Properties = edge.Properties
 	existing.Weight = edge.Weight
 	return nil
@@ -172,6 +198,9 @@ func (gs *GraphStorage) replayCreateEdge(entry *wal.Entry) error {
 	if err := json.Unmarshal(entry.Data, &edge); err != nil {
 		return err
 	}
+	if err := gs.fieldCipher.openEdge(&edge); err != nil {
+		return err
+	}
 
 	// Skip if edge already exists (already in snapshot — overlay or mmap base)
 	if _, exists := gs.resolveEdgeRefLocked(edge.ID); exists {
@@ -213,6 +242,9 @@ func (gs *GraphStorage) replayDeleteEdge(entry *wal.Entry) error {
 	if err := json.Unmarshal(entry.Data, &edge); err != nil {
 		return err
 	}
+	if err := gs.fieldCipher.openEdge(&edge); err != nil {
+		return err
+	}
 
 	// Skip if edge doesn't exist (already deleted or never existed)
 	if _, exists := gs.resolveEdgeRefLocked(edge.ID); !exists {
@@ -253,6 +285,9 @@ func (gs *GraphStorage) replayDeleteNode(entry *wal.Entry) error {
 	if err := json.Unmarshal(entry.Data, &node); err != nil {
 		return err
 	}
+	if err := gs.fieldCipher.openNode(&node); err != nil {
+		return err
+	}
 
 	// Skip if node doesn't exist (already deleted or never existed)
 	if _, exists := gs.resolveNodeRefLocked(node.ID); !exists {
@@ -1,7 +1,6 @@
 package storage
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 
@@ -23,7 +22,7 @@ func (gs *GraphStorage) writeToWAL(operation wal.OpType, data any) {
 // writeToWALWithError writes an operation to the WAL and returns any error
 // Use this for operations that require durability guarantees
 func (gs *GraphStorage) writeToWALWithError(operation wal.OpType, data any) error {
-	encoded, err := json.Marshal(data)
+	encoded, err := gs.marshalWAL(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal WAL data: %w", err)
 	}
@@ -32,7 +31,7 @@ func (gs *GraphStorage) writeToWALWithError(operation wal.OpType, data any) erro
 	}
 
 	if gs.useBatching && gs.batchedWAL != nil {
-		if _, err := gs.batchedWAL.Append(operation, encoded); err != nil {
+		if _, err := gs.batchedWAL.Append(operation, encoded); gs.trackWAL(err) != nil {
 			return fmt.Errorf("failed to append to batched WAL: %w", err)
 		}
 	} else if gs.useCompression && gs.compressedWAL != nil {
@@ -40,11 +39,11 @@ func (gs *GraphStorage) writeToWALWithError(operation wal.OpType, data any) erro
 		// with EnableCompression every single-op write silently skipped
 		// the WAL (and replayWAL never read it back) — zero crash
 		// durability on the compressed backend.
-		if _, err := gs.compressedWAL.Append(operation, encoded); err != nil {
+		if _, err := gs.compressedWAL.Append(operation, encoded); gs.trackWAL(err) != nil {
 			return fmt.Errorf("failed to append to compressed WAL: %w", err)
 		}
 	} else if gs.wal != nil {
-		if _, err := gs.wal.Append(operation, encoded); err != nil {
+		if _, err := gs.wal.Append(operation, encoded); gs.trackWAL(err) != nil {
 			return fmt.Errorf("failed to append to WAL: %w", err)
 		}
 	}
@@ -69,7 +68,7 @@ func (gs *GraphStorage) writeToWALWithError(operation wal.OpType, data any) erro
 // are logged, not returned. The caller likewise logs (does not propagate) the
 // deferred Wait() error.
 func (gs *GraphStorage) enqueueWAL(operation wal.OpType, data any) *wal.Pending {
-	encoded, err := json.Marshal(data)
+	encoded, err := gs.marshalWAL(data)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "WAL write error (op=%d): %v\n", operation, err)
 		return nil
@@ -85,11 +84,11 @@ func (gs *GraphStorage) enqueueWAL(operation wal.OpType, data any) *wal.Pending
 		// Synchronous like the plain path. This branch was MISSING (see
 		// writeToWALWithError) — single-op writes never reached the
 		// compressed WAL.
-		if _, err := gs.compressedWAL.Append(operation, encoded); err != nil {
+		if _, err := gs.compressedWAL.Append(operation, encoded); gs.trackWAL(err) != nil {
 			fmt.Fprintf(os.Stderr, "WAL write error (op=%d): %v\n", operation, err)
 		}
 	} else if gs.wal != nil {
-		if _, err := gs.wal.Append(operation, encoded); err != nil {
+		if _, err := gs.wal.Append(operation, encoded); gs.trackWAL(err) != nil {
 			fmt.Fprintf(os.Stderr, "WAL write error (op=%d): %v\n", operation, err)
 		}
 	}
@@ -104,7 +103,7 @@ func (gs *GraphStorage) waitWALPending(operation wal.OpType, pending *wal.Pendin
 	if pending == nil {
 		return
 	}
-	if err := pending.Wait(); err != nil {
+	if err := gs.trackWAL(pending.Wait()); err != nil {
 		fmt.Fprintf(os.Stderr, "WAL write error (op=%d): %v\n", operation, err)
 	}
 }
@@ -143,17 +142,17 @@ func (gs *GraphStorage) appendWALBatch(entries []wal.BatchEntry) error {
 	}
 	switch {
 	case gs.useBatching && gs.batchedWAL != nil:
-		return gs.batchedWAL.AppendBatchAtomic(entries)
+		return gs.trackWAL(gs.batchedWAL.AppendBatchAtomic(entries))
 	case gs.useCompression && gs.compressedWAL != nil:
 		// Non-atomic fallback (see doc comment): each entry appended in order.
 		for _, e := range entries {
-			if _, err := gs.compressedWAL.Append(e.OpType, e.Data); err != nil {
+			if _, err := gs.compressedWAL.Append(e.OpType, e.Data); gs.trackWAL(err) != nil {
 				return err
 			}
 		}
 		return nil
 	case gs.wal != nil:
-		return gs.wal.AppendBatchAtomic(entries)
+		return gs.trackWAL(gs.wal.AppendBatchAtomic(entries))
 	}
 	return nil // No WAL configured (in-memory only).
 }
@@ -30,7 +30,8 @@ func (gs *GraphStorage) buildEdgeListFromIDs(edgeIDs []uint64) []*Edge {
 	return edges
 }
 
-// GetOutgoingEdges gets all outgoing edges from a node.
+// GetOutgoingEdges gets all outgoing edges from a node, including undirected
+// edges stored towards it (returned reversed; see CreateUndirectedEdge).
 //
 // Tenant-blind. Returns edges across all tenants — used by replication,
 // snapshotting, and legitimately tenant-blind callers (CLI, examples).
@@ -45,12 +46,10 @@ func (gs *GraphStorage) GetOutgoingEdges(nodeID uint64) ([]*Edge, error) {
 
 	// Get edge IDs using helper (checks disk/compressed/uncompressed storage)
 	edgeIDs := gs.getEdgeIDsForNode(nodeID, true)
-	if edgeIDs == nil {
-		return []*Edge{}, nil
-	}
 
-	// Build edge list from IDs
+	// Build edge list from IDs, plus undirected edges stored the other way
 	edges := gs.buildEdgeListFromIDs(edgeIDs)
+	edges = append(edges, gs.mirroredUndirectedEdgesLocked(nodeID, true)...)
 
 	return edges, nil
 }
@@ -82,7 +81,8 @@ func (gs *GraphStorage) GetOutgoingEdgesForTenant(nodeID uint64, tenantID string
 	return out, nil
 }
 
-// GetIncomingEdges gets all incoming edges to a node.
+// GetIncomingEdges gets all incoming edges to a node, including undirected
+// edges stored away from it (returned reversed; see CreateUndirectedEdge).
 //
 // Tenant-blind. New callers in tenant-scoped code paths should prefer
 // GetIncomingEdgesForTenant.
@@ -95,12 +95,10 @@ func (gs *GraphStorage) GetIncomingEdges(nodeID uint64) ([]*Edge, error) {
 
 	// Get edge IDs using helper (checks disk/compressed/uncompressed storage)
 	edgeIDs := gs.getEdgeIDsForNode(nodeID, false)
-	if edgeIDs == nil {
-		return []*Edge{}, nil
-	}
 
-	// Build edge list from IDs
+	// Build edge list from IDs, plus undirected edges stored the other way
 	edges := gs.buildEdgeListFromIDs(edgeIDs)
+	edges = append(edges, gs.mirroredUndirectedEdgesLocked(nodeID, false)...)
 
 	return edges, nil
 }
@@ -155,6 +153,20 @@ func (gs *GraphStorage) FindNodesByLabelAcrossTenants(label string) ([]*Node, er
 	return gs.buildNodeListFromIDs(nodeIDs), nil
 }
 
+// FindNodesByLabelForTenant returns the tenant's nodes carrying label, sorted
+// by node ID. It is the tenant-scoped counterpart of
+// FindNodesByLabelAcrossTenants and, unlike GetNodesByLabelForTenant, counts
+// toward query statistics.
+func (gs *GraphStorage) FindNodesByLabelForTenant(tenantID, label string) ([]*Node, error) {
+	defer gs.startQueryTiming()()
+
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	nodeIDs := gs.membershipNodeIDsByLabelLocked(effectiveTenantID(tenantID), label)
+	return gs.buildNodeListFromIDs(nodeIDs), nil
+}
+
 // FindNodesByProperty finds nodes with a specific property value.
 //
 // Tenant-blind. New callers in tenant-scoped code paths should prefer
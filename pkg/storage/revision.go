@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Revision identifies this state of the node: a digest of its tenant,
+// labels, properties and timestamps. Any change to those gives a new
+// revision, so a client that read revision r can ask for its write to be
+// applied only if the node is still at r (UpdateNodeIfRevision).
+//
+// The digest is derived rather than stored, so it needs no room in the WAL,
+// snapshots or backups, and is the same on every replica and across
+// restarts. It is not a secret-safe fingerprint: callers that expose it to
+// readers who may not see every property should key it first.
+func (n *Node) Revision() uint64 {
+	h := fnv.New64a()
+	writeUint64(h, n.ID)
+	writeString(h, n.TenantID)
+	writeUint64(h, uint64(len(n.Labels)))
+	for _, label := range n.Labels {
+		writeString(h, label)
+	}
+	writeProperties(h, n.Properties)
+	writeUint64(h, uint64(n.CreatedAt))
+	writeUint64(h, uint64(n.UpdatedAt))
+	return h.Sum64()
+}
+
+// Revision identifies this state of the edge; see Node.Revision.
+func (e *Edge) Revision() uint64 {
+	h := fnv.New64a()
+	writeUint64(h, e.ID)
+	writeString(h, e.TenantID)
+	writeUint64(h, e.FromNodeID)
+	writeUint64(h, e.ToNodeID)
+	writeString(h, e.Type)
+	writeProperties(h, e.Properties)
+	writeUint64(h, math.Float64bits(e.Weight))
+	writeUint64(h, uint64(e.CreatedAt))
+	if e.Undirected {
+		_, _ = h.Write([]byte{1})
+	}
+	return h.Sum64()
+}
+
+// writeProperties hashes a property map in key order, each field length
+// prefixed so adjacent fields can't run together.
+func writeProperties(h hash.Hash64, properties map[string]Value) {
+	writeUint64(h, uint64(len(properties)))
+	for _, key := range slices.Sorted(maps.Keys(properties)) {
+		value := properties[key]
+		writeString(h, key)
+		_, _ = h.Write([]byte{byte(value.Type)})
+		writeUint64(h, uint64(len(value.Data)))
+		_, _ = h.Write(value.Data)
+	}
+}
+
+func writeString(h hash.Hash64, s string) {
+	writeUint64(h, uint64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
+
+func writeUint64(h hash.Hash64, v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	_, _ = h.Write(buf[:])
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestUpdateNodeIfRevision(t *testing.T) {
+	gs, err := NewGraphStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gs.Close()
+
+	node, err := gs.CreateNodeWithTenant("tenant-A", []string{"N"}, map[string]Value{"n": IntValue(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, _ := gs.GetNodeForTenant(node.ID, "tenant-A")
+	if read.Revision() != node.Revision() {
+		t.Fatal("a read changed the revision")
+	}
+
+	// Two writers that read the same revision: exactly one wins.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = gs.UpdateNodeForTenantIfRevision(node.ID, map[string]Value{"n": IntValue(int64(i + 1))}, "tenant-A", read.Revision())
+		}()
+	}
+	wg.Wait()
+	var conflicts int
+	for _, err := range errs {
+		switch {
+		case errors.Is(err, ErrConflict):
+			conflicts++
+		case err != nil:
+			t.Fatal(err)
+		}
+	}
+	if conflicts != 1 {
+		t.Fatalf("errors = %v, want one ErrConflict", errs)
+	}
+	if !IsConflict(errs[0]) && !IsConflict(errs[1]) {
+		t.Error("IsConflict does not report ErrConflict")
+	}
+
+	updated, _ := gs.GetNodeForTenant(node.ID, "tenant-A")
+	if updated.Revision() == read.Revision() {
+		t.Fatal("update left the revision unchanged")
+	}
+	if err := gs.UpdateNodeIfRevision(node.ID, map[string]Value{"n": IntValue(9)}, updated.Revision()); err != nil {
+		t.Fatalf("update at the current revision: %v", err)
+	}
+	if err := gs.UpdateNodeForTenantIfRevision(node.ID, nil, "tenant-B", updated.Revision()); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("cross-tenant update: err = %v, want ErrNodeNotFound", err)
+	}
+}
+
+func TestUpdateEdgeIfRevision(t *testing.T) {
+	gs, err := NewGraphStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gs.Close()
+
+	a, _ := gs.CreateNode([]string{"N"}, nil)
+	b, _ := gs.CreateNode([]string{"N"}, nil)
+	edge, err := gs.CreateEdge(a.ID, b.ID, "REL", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := edge.Revision()
+
+	weight := 2.0
+	if err := gs.UpdateEdgeIfRevision(edge.ID, nil, &weight, stale); err != nil {
+		t.Fatalf("update at the current revision: %v", err)
+	}
+	weight = 3.0
+	if err := gs.UpdateEdgeIfRevision(edge.ID, nil, &weight, stale); !errors.Is(err, ErrConflict) {
+		t.Fatalf("update at a stale revision: err = %v, want ErrConflict", err)
+	}
+	if got, _ := gs.GetEdge(edge.ID); got.Weight != 2 {
+		t.Errorf("weight = %v after a conflicting update, want 2", got.Weight)
+	}
+}
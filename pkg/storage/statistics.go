@@ -12,8 +12,65 @@ func (gs *GraphStorage) GetStatistics() Statistics {
 		NodeCount:    atomic.LoadUint64(&gs.stats.NodeCount),
 		EdgeCount:    atomic.LoadUint64(&gs.stats.EdgeCount),
 		TotalQueries: atomic.LoadUint64(&gs.stats.TotalQueries),
-		LastSnapshot: gs.stats.LastSnapshot,
+		LastSnapshot: gs.lastSnapshotTime(),
 		AvgQueryTime: math.Float64frombits(atomic.LoadUint64(&gs.avgQueryTimeBits)),
+		Operations:   gs.operationStats(),
+		Version:      gs.Version(),
+	}
+}
+
+// operationStats snapshots the per-mutation counters for GetStatistics.
+func (gs *GraphStorage) operationStats() map[string]OperationStats {
+	ops := make(map[string]OperationStats, len(trackedOperations))
+	for i, name := range trackedOperations {
+		c := &gs.opStats[i]
+		ops[name] = OperationStats{
+			Count:        atomic.LoadUint64(&c.count),
+			AvgLatencyMs: math.Float64frombits(atomic.LoadUint64(&c.avgLatency)),
+		}
+	}
+	return ops
+}
+
+// trackOperation records the outcome of a mutation started at start: the
+// Prometheus storage metrics always, and on success the Statistics
+// counter and moving-average latency for op. Intended for
+// `defer gs.trackOperation(OpX, time.Now(), &err)` at the top of a mutator
+// with a named error result.
+//
+// The counters are atomics and are NOT updated under gs.mu: for most
+// mutators the deferred call runs after the lock is released and the WAL
+// wait has finished, so the latency covers the whole call. A concurrent
+// GetStatistics may therefore see NodeCount/EdgeCount already changed and
+// the matching Operations count not yet bumped.
+func (gs *GraphStorage) trackOperation(op string, start time.Time, errp *error) {
+	if errp != nil && *errp != nil {
+		gs.recordOperation(op, "error", start)
+		return
+	}
+	gs.recordOperation(op, "success", start)
+
+	for i, name := range trackedOperations {
+		if name == op {
+			c := &gs.opStats[i]
+			atomic.AddUint64(&c.count, 1)
+			updateMovingAverage(&c.avgLatency, float64(time.Since(start).Nanoseconds())/1000000.0)
+			return
+		}
+	}
+}
+
+// updateMovingAverage folds value into the float64 stored as bits at addr
+// using the same 0.9/0.1 exponential moving average as AvgQueryTime.
+func updateMovingAverage(addr *uint64, value float64) {
+	// Thread-safe update using compare-and-swap loop
+	for {
+		oldBits := atomic.LoadUint64(addr)
+		newAvg := 0.9*math.Float64frombits(oldBits) + 0.1*value
+		if atomic.CompareAndSwapUint64(addr, oldBits, math.Float64bits(newAvg)) {
+			return
+		}
+		// CAS failed, retry with new value
 	}
 }
 
@@ -25,19 +82,7 @@ func (gs *GraphStorage) trackQueryTime(duration time.Duration) {
 	// Update average query time (milliseconds)
 	// Using exponential moving average: new_avg = 0.9 * old_avg + 0.1 * new_value
 	durationMs := float64(duration.Nanoseconds()) / 1000000.0
-
-	// Thread-safe update using compare-and-swap loop
-	for {
-		oldBits := atomic.LoadUint64(&gs.avgQueryTimeBits)
-		oldAvg := math.Float64frombits(oldBits)
-		newAvg := 0.9*oldAvg + 0.1*durationMs
-		newBits := math.Float64bits(newAvg)
-
-		if atomic.CompareAndSwapUint64(&gs.avgQueryTimeBits, oldBits, newBits) {
-			break
-		}
-		// CAS failed, retry with new value
-	}
+	updateMovingAverage(&gs.avgQueryTimeBits, durationMs)
 }
 
 // startQueryTiming begins query time tracking and returns a cleanup function
@@ -48,3 +93,59 @@ func (gs *GraphStorage) startQueryTiming() func() {
 		gs.trackQueryTime(time.Since(start))
 	}
 }
+
+// LabelCounts returns the number of nodes carrying each label, across all
+// tenants. A node with several labels is counted under each. Labels whose
+// last node was deleted are omitted.
+//
+// The counts are read off the label index, which create/delete keep up to
+// date, so this costs one pass over the labels rather than over the nodes.
+// After an mmap reopen the snapshot's membership runs are tombstone-filtered
+// per label, as FindNodesByLabelAcrossTenants does.
+func (gs *GraphStorage) LabelCounts() map[string]int {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	counts := make(map[string]int)
+	if gs.mmapSnap == nil {
+		for label, bucket := range gs.nodesByLabel {
+			if len(bucket) > 0 {
+				counts[label] = len(bucket)
+			}
+		}
+		return counts
+	}
+	for _, tid := range gs.membershipTenantsLocked() {
+		for _, label := range gs.membershipLabelsForTenantLocked(tid) {
+			if n := len(gs.membershipNodeIDsByLabelLocked(tid, label)); n > 0 {
+				counts[label] += n
+			}
+		}
+	}
+	return counts
+}
+
+// EdgeTypeCounts returns the number of edges of each type across all
+// tenants, read off the edge type index the same way as LabelCounts.
+func (gs *GraphStorage) EdgeTypeCounts() map[string]int {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	counts := make(map[string]int)
+	if gs.mmapSnap == nil {
+		for etype, bucket := range gs.edgesByType {
+			if len(bucket) > 0 {
+				counts[etype] = len(bucket)
+			}
+		}
+		return counts
+	}
+	for _, tid := range gs.membershipTenantsLocked() {
+		for _, etype := range gs.membershipEdgeTypesForTenantLocked(tid) {
+			if n := len(gs.membershipEdgeIDsByTypeLocked(tid, etype)); n > 0 {
+				counts[etype] += n
+			}
+		}
+	}
+	return counts
+}
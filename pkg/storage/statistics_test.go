@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetStatistics_OperationCounters(t *testing.T) {
+	gs := testGraphStorage(t)
+
+	a := testNode(t, gs, []string{"PLC"}, map[string]Value{"name": StringValue("PLC-1")})
+	b := testNode(t, gs, []string{"HMI"}, nil)
+	c := testNode(t, gs, []string{"HMI"}, nil)
+	e := testEdge(t, gs, a.ID, b.ID, "CONTROLS", map[string]Value{}, 1.0)
+
+	if err := gs.UpdateNode(a.ID, map[string]Value{"zone": StringValue("control")}); err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+	if err := gs.UpdateEdge(e.ID, map[string]Value{"protocol": StringValue("modbus")}, nil); err != nil {
+		t.Fatalf("UpdateEdge: %v", err)
+	}
+	if err := gs.DeleteEdge(e.ID); err != nil {
+		t.Fatalf("DeleteEdge: %v", err)
+	}
+	if err := gs.DeleteNode(c.ID); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	// Failed mutations are not counted.
+	if err := gs.DeleteNode(c.ID); err == nil {
+		t.Fatal("second DeleteNode: want ErrNodeNotFound")
+	}
+
+	ops := gs.GetStatistics().Operations
+	want := map[string]uint64{
+		OpCreateNode: 3,
+		OpUpdateNode: 1,
+		OpDeleteNode: 1,
+		OpCreateEdge: 1,
+		OpUpdateEdge: 1,
+		OpDeleteEdge: 1,
+	}
+	for op, n := range want {
+		if ops[op].Count != n {
+			t.Errorf("%s count = %d, want %d", op, ops[op].Count, n)
+		}
+		if ops[op].AvgLatencyMs <= 0 {
+			t.Errorf("%s avg latency = %g, want > 0", op, ops[op].AvgLatencyMs)
+		}
+	}
+}
+
+// TestLabelAndEdgeTypeCounts pins the per-label/per-type breakdown through
+// creates, deletes (including the edge cascade of a node delete) and an
+// mmap reopen, where the counts come from the snapshot's membership runs.
+func TestLabelAndEdgeTypeCounts(t *testing.T) {
+	dir := t.TempDir()
+	gs, err := NewGraphStorageWithConfig(mmapConfig(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plc := testNode(t, gs, []string{"PLC", "Asset"}, nil)
+	hmi := testNode(t, gs, []string{"HMI", "Asset"}, nil)
+	other, err := gs.CreateNodeWithTenant("other", []string{"PLC"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gone := testNode(t, gs, []string{"Historian"}, nil)
+	testEdge(t, gs, hmi.ID, plc.ID, "CONTROLS", nil, 1.0)
+	testEdge(t, gs, gone.ID, plc.ID, "READS", nil, 1.0)
+	if _, err := gs.CreateEdgeWithTenant("other", other.ID, other.ID, "CONTROLS", nil, 1.0); err != nil {
+		t.Fatal(err)
+	}
+	if err := gs.DeleteNode(gone.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	wantLabels := map[string]int{"PLC": 2, "HMI": 1, "Asset": 2}
+	wantTypes := map[string]int{"CONTROLS": 2}
+	check := func(gs *GraphStorage, ctx string) {
+		t.Helper()
+		if got := gs.LabelCounts(); !reflect.DeepEqual(got, wantLabels) {
+			t.Errorf("%s: LabelCounts() = %v, want %v", ctx, got, wantLabels)
+		}
+		if got := gs.EdgeTypeCounts(); !reflect.DeepEqual(got, wantTypes) {
+			t.Errorf("%s: EdgeTypeCounts() = %v, want %v", ctx, got, wantTypes)
+		}
+	}
+	check(gs, "live")
+
+	if err := gs.Close(); err != nil {
+		t.Fatal(err)
+	}
+	gs, err = NewGraphStorageWithConfig(mmapConfig(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = gs.Close() }()
+	check(gs, "reopened")
+
+	if err := gs.DeleteNode(hmi.ID); err != nil {
+		t.Fatal(err)
+	}
+	testNode(t, gs, []string{"HMI"}, nil)
+	wantLabels["Asset"] = 1
+	wantTypes["CONTROLS"] = 1
+	check(gs, "mutated after reopen")
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dd0wney/graphdb/pkg/encryption"
 	"github.com/dd0wney/graphdb/pkg/metrics"
 	"github.com/dd0wney/graphdb/pkg/tenantid"
 	"github.com/dd0wney/graphdb/pkg/wal"
@@ -54,13 +55,26 @@ func NewGraphStorage(dataDir string) (*GraphStorage, error) {
 
 // NewGraphStorageWithConfig creates a new graph storage engine with custom config
 func NewGraphStorageWithConfig(config StorageConfig) (*GraphStorage, error) {
+	if config.EncryptionEngine == nil && config.EncryptionKey != nil {
+		ring, err := encryption.NewKeyRing(config.EncryptionKey, config.PreviousEncryptionKeys...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key: %w", err)
+		}
+		config.EncryptionEngine = ring
+	}
+	fieldCipher, err := newFieldCipher(config)
+	if err != nil {
+		return nil, err
+	}
+
 	gs := &GraphStorage{
-		nodesByLabel:    make(labelIndex),
-		edgesByType:     make(labelIndex),
-		outgoingEdges:   make(map[uint64][]uint64),
-		incomingEdges:   make(map[uint64][]uint64),
-		propertyIndexes: make(map[string]*PropertyIndex),
-		vectorIndex:     NewVectorIndex(),
+		nodesByLabel:        make(labelIndex),
+		edgesByType:         make(labelIndex),
+		outgoingEdges:       make(map[uint64][]uint64),
+		incomingEdges:       make(map[uint64][]uint64),
+		propertyIndexes:     make(map[string]*PropertyIndex),
+		vectorIndex:         NewVectorIndex(),
+		edgePropertyIndexes: make(map[EdgePropertyIndexDef]edgePropertyIndex),
 		// Tenant-scoped indexes for multi-tenancy.
 		// Keyed by tenantid.TenantID since audit task A1 (2026-05-06).
 		tenantNodesByLabel: make(map[tenantid.TenantID]labelIndex),
@@ -82,6 +96,12 @@ func NewGraphStorageWithConfig(config StorageConfig) (*GraphStorage, error) {
 		// decrypt during construction (M-14).
 		encryptionEngine: config.EncryptionEngine,
 		keyManager:       config.KeyManager,
+		fieldCipher:      fieldCipher,
+		rejectSelfLoops:  config.RejectSelfLoops,
+		multiEdges:       config.MultiEdges,
+	}
+	if config.InternPropertyStrings {
+		gs.interner = newStringInterner()
 	}
 
 	// Initialize shard locks for fine-grained concurrency
@@ -152,7 +172,9 @@ func NewGraphStorageWithConfig(config StorageConfig) (*GraphStorage, error) {
 	// here and writes snapshot.mmap on its next Snapshot.)
 	gs.useMmapSnapshot = mmapEligible(config)
 	loadErr := error(nil)
+	loadedPath := filepath.Join(config.DataDir, "snapshot.json")
 	if gs.useMmapSnapshot && fileExists(mmapSnapshotPath(config.DataDir)) {
+		loadedPath = mmapSnapshotPath(config.DataDir)
 		loadErr = gs.loadFromDiskMmap()
 	} else {
 		loadErr = gs.loadFromDisk()
@@ -162,6 +184,11 @@ func NewGraphStorageWithConfig(config StorageConfig) (*GraphStorage, error) {
 		if !os.IsNotExist(loadErr) {
 			return nil, fmt.Errorf("failed to load from disk: %w", loadErr)
 		}
+	} else if info, err := os.Stat(loadedPath); err == nil {
+		// The loaded snapshot's own write time; the persisted
+		// Statistics.LastSnapshot predates it (it is captured first).
+		written := info.ModTime()
+		gs.lastSnapshot.Store(&written)
 	}
 
 	// Replay WAL entries since last snapshot
@@ -169,6 +196,10 @@ func NewGraphStorageWithConfig(config StorageConfig) (*GraphStorage, error) {
 		return nil, fmt.Errorf("failed to replay WAL: %w", err)
 	}
 
+	// A property index on a key that has since been marked sensitive would
+	// keep writing its plaintext values into every snapshot.
+	gs.dropSensitivePropertyIndexesLocked()
+
 	// Rebuild the HNSW vector index from the FINAL node set (snapshot + WAL
 	// replay). The index definitions were recreated in loadFromDisk; the graph
 	// itself is not serialized, so without this every vector search silently
@@ -176,6 +207,10 @@ func NewGraphStorageWithConfig(config StorageConfig) (*GraphStorage, error) {
 	// recovered above are indexed too.
 	gs.rebuildVectorIndexesFromNodes()
 
+	// Same for edge property indexes: definitions came from the snapshot and
+	// WAL, entries are derived from the final edge set.
+	gs.rebuildEdgePropertyIndexes()
+
 	// H-3 toggle hygiene: encryption is on but the replay saw pre-toggle
 	// plaintext entries. Checkpoint once (the snapshot is encrypted; WAL
 	// entries ≤ boundary are dropped) so the plaintext leaves the disk
@@ -184,6 +219,12 @@ func NewGraphStorageWithConfig(config StorageConfig) (*GraphStorage, error) {
 		if err := gs.CompactWAL(); err != nil {
 			return nil, fmt.Errorf("failed to purge plaintext WAL entries after enabling encryption: %w", err)
 		}
+	} else if rotatingEncryptionKey(gs.encryptionEngine) {
+		// Key rotation: the same checkpoint rewrites everything sealed
+		// under a previous key, so those keys can be retired.
+		if err := gs.CompactWAL(); err != nil {
+			return nil, fmt.Errorf("failed to re-encrypt under the current key: %w", err)
+		}
 	}
 
 	return gs, nil
@@ -69,18 +69,19 @@ func (gs *GraphStorage) hasWAL() bool {
 // to read a single bool (audit task A4).
 func (gs *GraphStorage) checkClosed() error {
 	if gs.closed.Load() {
-		return fmt.Errorf("storage is closed")
+		return ErrStorageClosed
 	}
 	return nil
 }
 
-// verifyNodeExists checks if a node exists and returns an error if
-// not. Tenant-blind — used by replication and other intentionally
-// tenant-blind callers (CreateEdge, UpsertEdge). New tenant-aware
-// callers should prefer verifyNodeExistsForTenant.
+// verifyNodeExists checks if a node exists and returns an
+// *EdgeEndpointError (errors.Is ErrNodeNotFound) if not. Tenant-blind —
+// used by replication and other intentionally tenant-blind callers
+// (CreateEdge, UpsertEdge). New tenant-aware callers should prefer
+// verifyNodeExistsForTenant.
 func (gs *GraphStorage) verifyNodeExists(nodeID uint64, nodeType string) error {
 	if _, exists := gs.resolveNodeRefLocked(nodeID); !exists {
-		return fmt.Errorf("%s node %d not found", nodeType, nodeID)
+		return &EdgeEndpointError{Endpoint: nodeType, NodeID: nodeID}
 	}
 	return nil
 }
@@ -99,12 +100,12 @@ func (gs *GraphStorage) verifyNodeExists(nodeID uint64, nodeType string) error {
 func (gs *GraphStorage) verifyNodeExistsForTenant(nodeID uint64, nodeType string, tenantID string) error {
 	node, exists := gs.resolveNodeRefLocked(nodeID)
 	if !exists {
-		return fmt.Errorf("%s node %d not found: %w", nodeType, nodeID, ErrNodeNotFound)
+		return &EdgeEndpointError{Endpoint: nodeType, NodeID: nodeID}
 	}
 	expected := effectiveTenantID(tenantID).String()
 	if node.TenantID != expected {
 		// Cross-tenant: same error as missing to avoid existence leak.
-		return fmt.Errorf("%s node %d not found: %w", nodeType, nodeID, ErrNodeNotFound)
+		return &EdgeEndpointError{Endpoint: nodeType, NodeID: nodeID}
 	}
 	return nil
 }
@@ -265,6 +266,7 @@ func (gs *GraphStorage) lookupNodeShard(id uint64) (*Node, bool) {
 // storeNodeInShard writes node into its owning shard. Caller must hold
 // the appropriate write lock.
 func (gs *GraphStorage) storeNodeInShard(node *Node) {
+	gs.interner.properties(node.Properties)
 	gs.nodeShards[gs.getShardIndex(node.ID)][node.ID] = node
 }
 
@@ -357,6 +359,7 @@ func (gs *GraphStorage) rebucketSnapshotNodes(flat map[uint64]*Node) {
 		gs.nodeShards[i] = make(map[uint64]*Node)
 	}
 	for id, node := range flat {
+		gs.interner.properties(node.Properties)
 		gs.nodeShards[gs.getShardIndex(id)][id] = node
 	}
 }
@@ -379,7 +382,11 @@ func (gs *GraphStorage) lookupEdgeShard(id uint64) (*Edge, bool) {
 // storeEdgeInShard writes edge into its owning shard. Caller must hold
 // the appropriate write lock.
 func (gs *GraphStorage) storeEdgeInShard(edge *Edge) {
+	gs.interner.properties(edge.Properties)
 	gs.edgeShards[gs.getShardIndex(edge.ID)][edge.ID] = edge
+	if edge.Undirected {
+		gs.hasUndirected.Store(true)
+	}
 }
 
 // deleteEdgeShardEntry removes the entry for id from its owning shard.
@@ -407,7 +414,11 @@ func (gs *GraphStorage) rebucketSnapshotEdges(flat map[uint64]*Edge) {
 		gs.edgeShards[i] = make(map[uint64]*Edge)
 	}
 	for id, edge := range flat {
+		gs.interner.properties(edge.Properties)
 		gs.edgeShards[gs.getShardIndex(id)][id] = edge
+		if edge.Undirected {
+			gs.hasUndirected.Store(true)
+		}
 	}
 }
 
@@ -444,7 +455,7 @@ func (gs *GraphStorage) allocateNodeID() (uint64, error) {
 	// Detect ID space exhaustion. AddUint64 will wrap at MaxUint64, so
 	// a returned nodeID near the cap means we've consumed the space.
 	if nodeID >= ^uint64(0)-1 {
-		return 0, fmt.Errorf("node ID space exhausted")
+		return 0, fmt.Errorf("node %w", ErrIDSpaceExhausted)
 	}
 
 	return nodeID, nil
@@ -459,7 +470,7 @@ func (gs *GraphStorage) allocateEdgeID() (uint64, error) {
 
 	// Check for ID space exhaustion
 	if edgeID >= ^uint64(0)-1 { // Near MaxUint64
-		return 0, fmt.Errorf("edge ID space exhausted")
+		return 0, fmt.Errorf("edge %w", ErrIDSpaceExhausted)
 	}
 
 	return edgeID, nil
@@ -472,3 +483,15 @@ func (gs *GraphStorage) recordOperation(operation string, status string, start t
 		gs.metricsRegistry.RecordStorageOperation(operation, status, duration)
 	}
 }
+
+// copyProperties returns a fresh, never-nil copy of a caller-supplied property
+// map. Create paths store the copy so the caller keeps no alias into live
+// storage: mutating (or reusing) the map after CreateNode/CreateEdge returns
+// must not race with readers or with UpdateNode writing under gs.mu.
+func copyProperties(properties map[string]Value) map[string]Value {
+	out := make(map[string]Value, len(properties))
+	for k, v := range properties {
+		out[k] = v
+	}
+	return out
+}
@@ -49,6 +49,10 @@ type GraphStorage struct {
 	propertyIndexes map[string]*PropertyIndex // property key -> index
 	vectorIndex     *VectorIndex              // vector search indexes
 
+	// edgePropertyIndexes: (edge type, property key) -> value -> edge IDs.
+	// Optional; see edge_property_index.go.
+	edgePropertyIndexes map[EdgePropertyIndexDef]edgePropertyIndex
+
 	// Tenant-scoped indexes for multi-tenancy.
 	// Keyed by tenantid.TenantID since audit task A1 (2026-05-06); public
 	// methods that take "tenantID string" still convert at the boundary
@@ -102,6 +106,19 @@ type GraphStorage struct {
 	deletedNodes    [256]map[uint64]struct{}
 	deletedEdges    [256]map[uint64]struct{}
 
+	// Edge policy (see edge_policy.go); fixed at construction.
+	rejectSelfLoops bool
+	multiEdges      MultiEdgePolicy
+
+	// interner shares the backing of repeated string property values; nil
+	// unless StorageConfig.InternPropertyStrings is set (see intern.go).
+	interner *stringInterner
+
+	// hasUndirected is set once any undirected edge is stored and never
+	// cleared. While false the adjacency reads skip the opposite-direction
+	// scan that undirected edges need (see undirected_edges.go).
+	hasUndirected atomic.Bool
+
 	// ID generators
 	nextNodeID uint64
 	nextEdgeID uint64
@@ -126,6 +143,9 @@ type GraphStorage struct {
 	// checkpoint at a time). Independent of gs.mu — the checkpoint takes
 	// gs.mu.RLock internally via snapshotWithBoundary.
 	compactMu sync.Mutex
+	// scratch marks a throwaway graph (ExtractSubgraph) that owns a private
+	// temp dataDir: Close skips the final snapshot and deletes the dir.
+	scratch bool
 	// txWALBarrier closes the Transaction.Commit window where buffered
 	// changes are applied in-memory under gs.mu.Lock but the WAL batch is
 	// appended AFTER the unlock: Commit holds the read side from before
@@ -136,11 +156,34 @@ type GraphStorage struct {
 	// (mu.Lock→barrier.RLock vs mu.RLock→barrier.Lock) cannot overlap
 	// because gs.mu already excludes them from each other.
 	txWALBarrier sync.RWMutex
+	// snapshotLSN is the WAL boundary of the last snapshot this process
+	// wrote, or at open the LSN just below the first replayed entry;
+	// HealthCheck reports WAL lag against it.
+	snapshotLSN atomic.Uint64
+	// lastSnapshot is when the last snapshot was written (nil if none).
+	// Snapshots set it after releasing gs.mu, so it is atomic rather than
+	// a plain Statistics field.
+	lastSnapshot atomic.Pointer[time.Time]
+	// healthProbe caches probeDataDir's result for healthProbeTTL, so
+	// unauthenticated health checks cannot turn into an fsync per request.
+	healthProbe struct {
+		sync.Mutex
+		at  time.Time
+		err error
+	}
+	// walFailure holds the most recent WAL append/flush error until the
+	// next successful one clears it (see trackWAL).
+	walFailure atomic.Pointer[walFailure]
+
+	// version counts mutations; see Version.
+	version atomic.Uint64
 
 	// Statistics (using atomic operations for thread-safety)
 	stats Statistics
 	// Internal field for atomic float64 operations on AvgQueryTime
 	avgQueryTimeBits uint64 // Stores AvgQueryTime as bits for atomic access
+	// Per-mutation counters, indexed like trackedOperations
+	opStats [len(trackedOperations)]opCounter
 
 	// Transaction management
 	txIDCounter uint64
@@ -151,6 +194,7 @@ type GraphStorage struct {
 	// Encryption (using typed interfaces for compile-time safety)
 	encryptionEngine encryption.EncryptDecrypter // Handles data encryption/decryption
 	keyManager       encryption.KeyProvider      // Manages encryption keys
+	fieldCipher      *fieldCipher                // Seals SensitiveProperties on disk; nil when none
 	// walReplaySawPlaintext is set during constructor-time WAL replay
 	// when encryption is enabled but a legacy plaintext entry was
 	// replayed — the constructor then runs CompactWAL once so pre-toggle
@@ -162,6 +206,10 @@ type GraphStorage struct {
 	// gs.mu.RLock by snapshotObservers (pkg/storage/observation.go).
 	// R2.1 / S11 spike §7.4.
 	observers []NodeObserver
+
+	// subscribers are the live Subscribe channels. Mutated under
+	// gs.mu.Lock; see subscribe.go for the dispatch rules.
+	subscribers map[*subscription]struct{}
 }
 
 // StorageConfig holds configuration for GraphStorage
@@ -190,15 +238,97 @@ type StorageConfig struct {
 	// restart (the server exited with "encryption is not enabled").
 	EncryptionEngine encryption.EncryptDecrypter
 	KeyManager       encryption.KeyProvider
+
+	// EncryptionKey is the shorthand for EncryptionEngine: a 32-byte key
+	// under which the snapshot and WAL are sealed with AES-256-GCM.
+	// Ignored when EncryptionEngine is set.
+	EncryptionKey []byte
+
+	// PreviousEncryptionKeys are retired keys, used only to decrypt. When
+	// any are given (or EncryptionEngine is an encryption.KeyRing holding
+	// previous keys) the store re-encrypts its snapshot and WAL under the
+	// current key right after opening; see RotateEncryptionKey.
+	PreviousEncryptionKeys [][]byte
+
+	// SensitiveProperties lists property keys (on nodes and edges) whose
+	// values are sealed individually with AES-256-GCM in the snapshot and
+	// WAL, so they stay protected even where the rest of the record is
+	// plaintext. Values are plaintext in memory: reads, filters and
+	// queries work as usual, but property indexes on these keys are
+	// refused (ErrSensitivePropertyIndex) and lookups on them scan.
+	SensitiveProperties []string
+
+	// SensitivePropertyEngine seals SensitiveProperties. Defaults to
+	// EncryptionEngine; one of the two is required when
+	// SensitiveProperties is set.
+	SensitivePropertyEngine encryption.EncryptDecrypter
+
+	// RejectSelfLoops refuses edges whose source and target are the same
+	// node (ErrSelfLoop). Off by default: self-loops are allowed.
+	RejectSelfLoops bool
+
+	// MultiEdges decides what creating a second edge with the same
+	// (from, to, type) in a tenant does. The default, MultiEdgesAllow,
+	// keeps parallel edges; see MultiEdgePolicy.
+	MultiEdges MultiEdgePolicy
+
+	// InternPropertyStrings makes nodes and edges that store the same short
+	// string property value share one copy of it, trading a lookup per
+	// stored value for memory on graphs with many repeated values (a zone
+	// or status on every node). Off by default.
+	InternPropertyStrings bool
 }
 
 // Statistics tracks database statistics
 type Statistics struct {
+	// NodeCount and EdgeCount are the number of live nodes and edges, not
+	// the highest ID: IDs are never reused, so after deletes they have gaps.
+	// Enumerate with GetAllNodeIDs rather than iterating 1..NodeCount.
 	NodeCount    uint64
 	EdgeCount    uint64
 	LastSnapshot time.Time
 	TotalQueries uint64
 	AvgQueryTime float64
+
+	// Operations holds per-mutation-type counters and latencies since the
+	// process started, keyed by operation name (OpCreateNode etc.). Filled
+	// in by GetStatistics; not persisted in snapshots. Counted when the
+	// mutation returns rather than under gs.mu, so it can briefly trail
+	// NodeCount and EdgeCount.
+	Operations map[string]OperationStats `json:"-"`
+
+	// Version is the mutation counter (see GraphStorage.Version). Filled
+	// in by GetStatistics; not persisted in snapshots.
+	Version uint64 `json:"-"`
+}
+
+// OperationStats describes one mutation type in Statistics.Operations.
+type OperationStats struct {
+	Count        uint64  // Successful operations
+	AvgLatencyMs float64 // Exponential moving average, same weighting as AvgQueryTime
+}
+
+// Operation names used as Statistics.Operations keys and as the
+// "operation" label on storage Prometheus metrics.
+const (
+	OpCreateNode = "create_node"
+	OpUpdateNode = "update_node"
+	OpDeleteNode = "delete_node"
+	OpCreateEdge = "create_edge"
+	OpUpdateEdge = "update_edge"
+	OpDeleteEdge = "delete_edge"
+)
+
+// trackedOperations fixes the order of GraphStorage.opStats slots.
+var trackedOperations = [...]string{
+	OpCreateNode, OpUpdateNode, OpDeleteNode,
+	OpCreateEdge, OpUpdateEdge, OpDeleteEdge,
+}
+
+// opCounter is one opStats slot; both fields are accessed atomically.
+type opCounter struct {
+	count      uint64
+	avgLatency uint64 // float64 bits, milliseconds
 }
 
 // TenantStats tracks per-tenant usage statistics for multi-tenancy
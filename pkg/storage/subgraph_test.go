@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// buildChain creates n0 -> n1 -> n2 -> n3 -> n4 (KNOWS, weight = index) plus
+// a side edge n2 <- n5, so hops must follow incoming edges too.
+func buildChain(t *testing.T, gs *GraphStorage) []*Node {
+	t.Helper()
+	var nodes []*Node
+	for i := 0; i < 6; i++ {
+		nodes = append(nodes, testNode(t, gs, []string{"Host"},
+			map[string]Value{"name": StringValue("n" + itoa(i))}))
+	}
+	for i := 0; i < 4; i++ {
+		testEdge(t, gs, nodes[i].ID, nodes[i+1].ID, "KNOWS",
+			map[string]Value{"hop": IntValue(int64(i))}, float64(i))
+	}
+	testEdge(t, gs, nodes[5].ID, nodes[2].ID, "FEEDS", nil, 2.5)
+	return nodes
+}
+
+func TestExtractSubgraph_Radius(t *testing.T) {
+	gs := testGraphStorage(t)
+	nodes := buildChain(t, gs)
+
+	cases := []struct {
+		name      string
+		radius    int
+		wantNames []string
+		wantEdges int
+	}{
+		{"seed only", 0, []string{"n2"}, 0},
+		{"one hop both directions", 1, []string{"n1", "n2", "n3", "n5"}, 3},
+		{"two hops", 2, []string{"n0", "n1", "n2", "n3", "n4", "n5"}, 5},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sub, idMap, err := ExtractSubgraph(gs, []uint64{nodes[2].ID}, tc.radius)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sub.Close()
+
+			if len(idMap) != len(tc.wantNames) {
+				t.Fatalf("idMap has %d entries, want %d", len(idMap), len(tc.wantNames))
+			}
+			stats := sub.GetStatistics()
+			if int(stats.NodeCount) != len(tc.wantNames) || int(stats.EdgeCount) != tc.wantEdges {
+				t.Errorf("subgraph has %d nodes / %d edges, want %d / %d",
+					stats.NodeCount, stats.EdgeCount, len(tc.wantNames), tc.wantEdges)
+			}
+			for _, want := range tc.wantNames {
+				old := nodes[want[1]-'0']
+				newID, ok := idMap[old.ID]
+				if !ok {
+					t.Errorf("%s missing from idMap", want)
+					continue
+				}
+				got, err := sub.GetNode(newID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if name, _ := got.Properties["name"].AsString(); name != want {
+					t.Errorf("idMap[%d] -> node named %q, want %q", old.ID, name, want)
+				}
+			}
+		})
+	}
+}
+
+// TestExtractSubgraph_PreservesData: labels, properties, tenant, edge type,
+// weight and properties all survive the copy; the source is untouched.
+func TestExtractSubgraph_PreservesData(t *testing.T) {
+	gs := testGraphStorage(t)
+	a, err := gs.CreateNodeWithTenant(rtTenantA, []string{"Server", "Critical"},
+		map[string]Value{"zone": StringValue("ot"), "cves": IntValue(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := gs.CreateNodeWithTenant(rtTenantA, []string{"PLC"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := gs.CreateEdgeWithTenant(rtTenantA, a.ID, b.ID, "CONTROLS",
+		map[string]Value{"protocol": StringValue("modbus")}, 0.75)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, idMap, err := ExtractSubgraph(gs, []uint64{a.ID}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	gotA, err := sub.GetNodeForTenant(idMap[a.ID], rtTenantA)
+	if err != nil {
+		t.Fatalf("copied node not in tenant %s: %v", rtTenantA, err)
+	}
+	if renderProps(gotA.Properties) != renderProps(a.Properties) {
+		t.Errorf("properties = %s, want %s", renderProps(gotA.Properties), renderProps(a.Properties))
+	}
+	if len(gotA.Labels) != 2 || gotA.Labels[0] != "Server" || gotA.Labels[1] != "Critical" {
+		t.Errorf("labels = %v, want [Server Critical]", gotA.Labels)
+	}
+
+	edges, err := sub.GetOutgoingEdges(idMap[a.ID])
+	if err != nil || len(edges) != 1 {
+		t.Fatalf("outgoing edges = %v, %v", edges, err)
+	}
+	e := edges[0]
+	if e.ToNodeID != idMap[b.ID] || e.Type != src.Type || e.Weight != src.Weight ||
+		renderProps(e.Properties) != renderProps(src.Properties) || e.TenantID != src.TenantID {
+		t.Errorf("copied edge = %+v, want copy of %+v", e, src)
+	}
+
+	if got := gs.GetStatistics(); got.NodeCount != 2 || got.EdgeCount != 1 {
+		t.Errorf("source graph changed: %d nodes / %d edges", got.NodeCount, got.EdgeCount)
+	}
+}
+
+func TestExtractSubgraph_CloseRemovesDir(t *testing.T) {
+	gs := testGraphStorage(t)
+	n := testNode(t, gs, []string{"A"}, nil)
+
+	sub, _, err := ExtractSubgraph(gs, []uint64{n.ID}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := sub.dataDir
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("subgraph dir missing while open: %v", err)
+	}
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("subgraph dir still present after Close (stat err = %v)", err)
+	}
+}
+
+func TestExtractSubgraph_Errors(t *testing.T) {
+	gs := testGraphStorage(t)
+	n := testNode(t, gs, []string{"A"}, nil)
+
+	if _, _, err := ExtractSubgraph(gs, []uint64{n.ID, 9999}, 1); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("missing seed: err = %v, want ErrNodeNotFound", err)
+	}
+	if _, _, err := ExtractSubgraph(gs, []uint64{n.ID}, -1); err == nil {
+		t.Error("negative radius: want error")
+	}
+	if _, _, err := ExtractSubgraph(nil, []uint64{n.ID}, 1); err == nil {
+		t.Error("nil graph: want error")
+	}
+}
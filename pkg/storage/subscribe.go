@@ -0,0 +1,139 @@
+package storage
+
+import "sync"
+
+// ChangeOp is the kind of mutation a ChangeEvent reports.
+type ChangeOp string
+
+const (
+	ChangeCreate ChangeOp = "create"
+	ChangeUpdate ChangeOp = "update"
+	ChangeDelete ChangeOp = "delete"
+)
+
+// EntityType says whether a ChangeEvent concerns a node or an edge.
+type EntityType string
+
+const (
+	EntityNode EntityType = "node"
+	EntityEdge EntityType = "edge"
+)
+
+// ChangeEvent is one graph mutation delivered to Subscribe channels.
+type ChangeEvent struct {
+	Op         ChangeOp   `json:"op"`
+	EntityType EntityType `json:"entity_type"`
+	ID         uint64     `json:"id"`
+	TenantID   string     `json:"tenant_id"` // owning tenant; never empty
+}
+
+// subscriptionBuffer is each Subscribe channel's capacity. A subscriber
+// that falls further behind than this loses events (see Subscribe).
+const subscriptionBuffer = 256
+
+// subscription is one Subscribe channel. Its own mutex lets unsubscribe
+// close the channel while a dispatch that snapshotted it is still sending,
+// without a send-on-closed-channel panic.
+type subscription struct {
+	mu     sync.Mutex
+	ch     chan ChangeEvent
+	closed bool
+}
+
+// send delivers ev without blocking; a full buffer drops it.
+func (s *subscription) send(ev ChangeEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- ev:
+	default:
+	}
+}
+
+func (s *subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// Subscribe returns a channel of ChangeEvents for every node and edge
+// create, update and delete, plus a func that unsubscribes and closes the
+// channel (safe to call more than once). Close also closes every channel,
+// so `for ev := range ch` ends with the store.
+//
+// Delivery never blocks a writer: each channel is buffered and an event that
+// does not fit is dropped. Subscribers are meant for refresh triggers
+// (dashboards, caches) that re-read state, not as a change log — use the WAL
+// or Backup for that. Deleting a node also reports a delete for each edge
+// removed with it. Events are published once the change is visible to
+// readers; ordering across concurrent writers is not guaranteed.
+//
+// Tenant-blind: events cover every tenant; filter on ChangeEvent.TenantID.
+func (gs *GraphStorage) Subscribe() (<-chan ChangeEvent, func()) {
+	sub := &subscription{ch: make(chan ChangeEvent, subscriptionBuffer)}
+
+	gs.mu.Lock()
+	if gs.closed.Load() {
+		gs.mu.Unlock()
+		sub.close()
+		return sub.ch, func() {}
+	}
+	if gs.subscribers == nil {
+		gs.subscribers = make(map[*subscription]struct{})
+	}
+	gs.subscribers[sub] = struct{}{}
+	gs.mu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			gs.mu.Lock()
+			delete(gs.subscribers, sub)
+			gs.mu.Unlock()
+			sub.close()
+		})
+	}
+}
+
+// wantsNodeNotifyLocked reports whether node mutations must capture
+// snapshots for dispatch (observers or subscribers). Caller holds gs.mu.
+func (gs *GraphStorage) wantsNodeNotifyLocked() bool {
+	return len(gs.observers) > 0 || len(gs.subscribers) > 0
+}
+
+// publishChangeLocked delivers an event to every subscriber. Caller holds
+// gs.mu; sends never block, so this is safe under the lock.
+func (gs *GraphStorage) publishChangeLocked(op ChangeOp, entity EntityType, id uint64, tenantID string) {
+	if len(gs.subscribers) == 0 {
+		return
+	}
+	ev := ChangeEvent{Op: op, EntityType: entity, ID: id, TenantID: effectiveTenantID(tenantID).String()}
+	for sub := range gs.subscribers {
+		sub.send(ev)
+	}
+}
+
+// publishChange is publishChangeLocked for callers holding no storage lock
+// (the notify* helpers).
+func (gs *GraphStorage) publishChange(op ChangeOp, entity EntityType, id uint64, tenantID string) {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	gs.publishChangeLocked(op, entity, id, tenantID)
+}
+
+// closeSubscribers closes every Subscribe channel; called by Close.
+func (gs *GraphStorage) closeSubscribers() {
+	gs.mu.Lock()
+	subs := gs.subscribers
+	gs.subscribers = nil
+	gs.mu.Unlock()
+	for sub := range subs {
+		sub.close()
+	}
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// drainEvents collects whatever is buffered on ch right now.
+func drainEvents(ch <-chan ChangeEvent) []ChangeEvent {
+	var events []ChangeEvent
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return events
+			}
+			events = append(events, ev)
+		default:
+			return events
+		}
+	}
+}
+
+func TestSubscribe_NodeAndEdgeLifecycle(t *testing.T) {
+	gs := testGraphStorage(t)
+	ch, unsubscribe := gs.Subscribe()
+	defer unsubscribe()
+
+	a := testNode(t, gs, []string{"A"}, nil)
+	b := testNode(t, gs, []string{"B"}, nil)
+	e := testEdge(t, gs, a.ID, b.ID, "LINK", nil, 1)
+	if err := gs.UpdateNode(a.ID, map[string]Value{"x": IntValue(1)}); err != nil {
+		t.Fatal(err)
+	}
+	w := 2.0
+	if err := gs.UpdateEdge(e.ID, nil, &w); err != nil {
+		t.Fatal(err)
+	}
+	if err := gs.DeleteEdge(e.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := gs.DeleteNode(b.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ChangeEvent{
+		{ChangeCreate, EntityNode, a.ID, DefaultTenantID},
+		{ChangeCreate, EntityNode, b.ID, DefaultTenantID},
+		{ChangeCreate, EntityEdge, e.ID, DefaultTenantID},
+		{ChangeUpdate, EntityNode, a.ID, DefaultTenantID},
+		{ChangeUpdate, EntityEdge, e.ID, DefaultTenantID},
+		{ChangeDelete, EntityEdge, e.ID, DefaultTenantID},
+		{ChangeDelete, EntityNode, b.ID, DefaultTenantID},
+	}
+	if got := drainEvents(ch); !slices.Equal(got, want) {
+		t.Errorf("events =\n  %v\nwant\n  %v", got, want)
+	}
+}
+
+// TestSubscribe_CascadeAndBatch: edges removed with their node, and writes
+// made through a Batch, are reported too.
+func TestSubscribe_CascadeAndBatch(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"A"}, nil)
+	b := testNode(t, gs, []string{"B"}, nil)
+	e := testEdge(t, gs, a.ID, b.ID, "LINK", nil, 1)
+
+	ch, unsubscribe := gs.Subscribe()
+	defer unsubscribe()
+
+	if err := gs.DeleteNode(a.ID); err != nil {
+		t.Fatal(err)
+	}
+	want := []ChangeEvent{{ChangeDelete, EntityEdge, e.ID, DefaultTenantID}, {ChangeDelete, EntityNode, a.ID, DefaultTenantID}}
+	if got := drainEvents(ch); !slices.Equal(got, want) {
+		t.Errorf("cascade events = %v, want %v", got, want)
+	}
+
+	batch := gs.BeginBatch()
+	batch.AddNode([]string{"C"}, nil)
+	if err := batch.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	got := drainEvents(ch)
+	if len(got) != 1 || got[0].Op != ChangeCreate || got[0].EntityType != EntityNode {
+		t.Errorf("batch events = %v, want one node create", got)
+	}
+}
+
+func TestSubscribe_TenantID(t *testing.T) {
+	gs := testGraphStorage(t)
+	ch, unsubscribe := gs.Subscribe()
+	defer unsubscribe()
+
+	a, err := gs.CreateNodeWithTenant(rtTenantA, []string{"A"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := gs.CreateNodeWithTenant(rtTenantA, []string{"B"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := gs.CreateEdgeWithTenant(rtTenantA, a.ID, b.ID, "LINK", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gs.DeleteNode(a.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ChangeEvent{
+		{ChangeCreate, EntityNode, a.ID, rtTenantA},
+		{ChangeCreate, EntityNode, b.ID, rtTenantA},
+		{ChangeCreate, EntityEdge, e.ID, rtTenantA},
+		{ChangeDelete, EntityEdge, e.ID, rtTenantA},
+		{ChangeDelete, EntityNode, a.ID, rtTenantA},
+	}
+	if got := drainEvents(ch); !slices.Equal(got, want) {
+		t.Errorf("events =\n  %v\nwant\n  %v", got, want)
+	}
+}
+
+func TestSubscribe_UnsubscribeAndClose(t *testing.T) {
+	dir := t.TempDir()
+	gs, err := NewGraphStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, unsubscribe := gs.Subscribe()
+	second, unsubscribeSecond := gs.Subscribe()
+	defer unsubscribeSecond()
+
+	unsubscribe()
+	unsubscribe() // idempotent
+	if _, err := gs.CreateNode([]string{"A"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-first; ok {
+		t.Error("unsubscribed channel still delivering")
+	}
+	if got := drainEvents(second); len(got) != 1 {
+		t.Errorf("remaining subscriber got %v, want one event", got)
+	}
+
+	if err := gs.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case _, ok := <-second:
+		if ok {
+			t.Error("event after Close")
+		}
+	case <-time.After(time.Second):
+		t.Error("Close did not close the subscriber channel")
+	}
+
+	late, _ := gs.Subscribe()
+	if _, ok := <-late; ok {
+		t.Error("Subscribe on a closed store returned an open channel")
+	}
+}
+
+// TestSubscribe_SlowSubscriberNeverBlocks: a subscriber that never reads
+// must not stall writers; overflow is dropped.
+func TestSubscribe_SlowSubscriberNeverBlocks(t *testing.T) {
+	gs := testGraphStorage(t)
+	ch, unsubscribe := gs.Subscribe()
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < subscriptionBuffer*2; i++ {
+			if _, err := gs.CreateNode([]string{"N"}, nil); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("writers blocked by an unread subscriber")
+	}
+	if got := len(drainEvents(ch)); got != subscriptionBuffer {
+		t.Errorf("buffered %d events, want %d (rest dropped)", got, subscriptionBuffer)
+	}
+}
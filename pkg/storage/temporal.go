@@ -4,6 +4,37 @@ import (
 	"time"
 )
 
+// An edge's valid time is kept in two optional integer properties, Unix
+// seconds: PropValidFrom (absent: valid from the beginning) and
+// PropValidTo (absent or 0: still valid). Both bounds are inclusive.
+const (
+	PropValidFrom = "valid_from"
+	PropValidTo   = "valid_to"
+)
+
+// ValidAt reports whether the edge was valid at timestamp (Unix seconds).
+// Edges without validity properties are always valid.
+func (e *Edge) ValidAt(timestamp int64) bool {
+	if from, ok := e.timeProperty(PropValidFrom); ok && timestamp < from {
+		return false
+	}
+	if to, ok := e.timeProperty(PropValidTo); ok && to != 0 && timestamp > to {
+		return false
+	}
+	return true
+}
+
+// timeProperty reads an integer validity property; a value of another
+// type counts as absent.
+func (e *Edge) timeProperty(key string) (int64, bool) {
+	v, ok := e.Properties[key]
+	if !ok {
+		return 0, false
+	}
+	t, err := v.AsInt()
+	return t, err == nil
+}
+
 // TemporalEdge is an edge with time validity
 type TemporalEdge struct {
 	*Edge
@@ -32,34 +63,16 @@ func (tq *TemporalQuery) GetEdgesAtTime(nodeID uint64, timestamp int64) ([]*Temp
 	// Filter by time
 	temporalEdges := make([]*TemporalEdge, 0)
 	for _, edge := range edges {
-		// Check if edge has temporal properties
-		validFrom, hasFrom := edge.Properties["valid_from"]
-		validTo, hasTo := edge.Properties["valid_to"]
-
-		if !hasFrom {
-			// No temporal info - always valid
-			temporalEdges = append(temporalEdges, &TemporalEdge{
-				Edge:      edge,
-				ValidFrom: 0,
-				ValidTo:   0,
-			})
+		if !edge.ValidAt(timestamp) {
 			continue
 		}
-
-		from, _ := validFrom.AsInt()
-		to := int64(0)
-		if hasTo {
-			to, _ = validTo.AsInt()
-		}
-
-		// Check if edge is valid at timestamp
-		if timestamp >= from && (to == 0 || timestamp <= to) {
-			temporalEdges = append(temporalEdges, &TemporalEdge{
-				Edge:      edge,
-				ValidFrom: from,
-				ValidTo:   to,
-			})
-		}
+		from, _ := edge.timeProperty(PropValidFrom)
+		to, _ := edge.timeProperty(PropValidTo)
+		temporalEdges = append(temporalEdges, &TemporalEdge{
+			Edge:      edge,
+			ValidFrom: from,
+			ValidTo:   to,
+		})
 	}
 
 	return temporalEdges, nil
@@ -167,9 +180,6 @@ type TemporalMetrics struct {
 
 // ComputeTemporalMetrics analyzes temporal patterns
 func ComputeTemporalMetrics(graph *GraphStorage, startTime, endTime int64) (*TemporalMetrics, error) {
-	stats := graph.GetStatistics()
-	nodeCount := int(stats.NodeCount)
-
 	totalLifetime := int64(0)
 	edgeCount := 0    // edges with temporal data created in range
 	deletedCount := 0 // edges with valid_to in range (tombstoned)
@@ -180,7 +190,7 @@ func ComputeTemporalMetrics(graph *GraphStorage, startTime, endTime int64) (*Tem
 	}
 
 	// Analyze all edges
-	for nodeID := uint64(1); nodeID <= uint64(nodeCount); nodeID++ {
+	for _, nodeID := range graph.GetAllNodeIDs() {
 		edges, err := graph.GetOutgoingEdges(nodeID)
 		if err != nil {
 			continue
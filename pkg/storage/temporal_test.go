@@ -699,3 +699,27 @@ func TestTemporalEdge_Structure(t *testing.T) {
 		t.Errorf("Expected ValidTo=200, got %d", te.ValidTo)
 	}
 }
+
+// TestEdge_ValidAt covers each combination of validity bounds.
+func TestEdge_ValidAt(t *testing.T) {
+	edge := func(props map[string]Value) *Edge { return &Edge{Properties: props} }
+	tests := []struct {
+		name  string
+		edge  *Edge
+		at    int64
+		valid bool
+	}{
+		{"no bounds", edge(nil), -1, true},
+		{"before from", edge(map[string]Value{PropValidFrom: IntValue(100)}), 99, false},
+		{"at from", edge(map[string]Value{PropValidFrom: IntValue(100)}), 100, true},
+		{"to only, before", edge(map[string]Value{PropValidTo: IntValue(200)}), 200, true},
+		{"to only, after", edge(map[string]Value{PropValidTo: IntValue(200)}), 201, false},
+		{"to of 0 is open", edge(map[string]Value{PropValidFrom: IntValue(100), PropValidTo: IntValue(0)}), 1 << 40, true},
+		{"non-integer bound ignored", edge(map[string]Value{PropValidFrom: StringValue("soon")}), 0, true},
+	}
+	for _, tt := range tests {
+		if got := tt.edge.ValidAt(tt.at); got != tt.valid {
+			t.Errorf("%s: ValidAt(%d) = %v, want %v", tt.name, tt.at, got, tt.valid)
+		}
+	}
+}
@@ -176,6 +176,17 @@ func (gs *GraphStorage) CountNodesByLabelForTenant(tenantID, label string) int {
 	return len(gs.membershipNodeIDsByLabelLocked(tid, label))
 }
 
+// CountEdgesByTypeForTenant returns how many edges of the given type a tenant
+// has, sizing the edge type index the same way CountNodesByLabelForTenant
+// sizes the label index.
+func (gs *GraphStorage) CountEdgesByTypeForTenant(tenantID, edgeType string) int {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	tid := effectiveTenantID(tenantID)
+	return len(gs.membershipEdgeIDsByTypeLocked(tid, edgeType))
+}
+
 // GetEdgesByTypeForTenant returns all edges with the given type for a specific tenant.
 func (gs *GraphStorage) GetEdgesByTypeForTenant(tenantID, edgeType string) []*Edge {
 	gs.mu.RLock()
@@ -397,7 +408,7 @@ func (gs *GraphStorage) CountEdgesForTenant(tenantID string) uint64 {
 // correctness fix. A bulk in-storage purge is a future optimization.
 func (gs *GraphStorage) DeleteTenant(tenantID string) (nodesDeleted, edgesDeleted int, err error) {
 	if effectiveTenantID(tenantID) == tenantid.Default {
-		return 0, 0, fmt.Errorf("cannot delete the default tenant")
+		return 0, 0, fmt.Errorf("%w: cannot delete the default tenant", ErrInvalidArgument)
 	}
 
 	for _, n := range gs.GetAllNodesForTenant(tenantID) {
@@ -516,3 +516,34 @@ func TestFindNodesByPropertyIndexedForTenant_FiltersCrossTenant(t *testing.T) {
 		}
 	})
 }
+
+// TestFindNodesByLabelForTenant_FiltersCrossTenant: tenant B's node with the
+// same label must be invisible to tenant A, both via the label scan and via a
+// direct by-ID read.
+func TestFindNodesByLabelForTenant_FiltersCrossTenant(t *testing.T) {
+	gs := mustNewGraphStorage(t)
+
+	aNode, err := gs.CreateNodeForTenant("tenant-A", []string{"PLC"}, nil)
+	if err != nil {
+		t.Fatalf("seed A: %v", err)
+	}
+	bNode, err := gs.CreateNodeForTenant("tenant-B", []string{"PLC"}, nil)
+	if err != nil {
+		t.Fatalf("seed B: %v", err)
+	}
+
+	got, err := gs.FindNodesByLabelForTenant("tenant-A", "PLC")
+	if err != nil {
+		t.Fatalf("FindNodesByLabelForTenant: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != aNode.ID {
+		t.Errorf("want only tenant-A's node id=%d, got %v", aNode.ID, got)
+	}
+
+	if _, err := gs.GetNodeForTenant(bNode.ID, "tenant-A"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("tenant-A reading tenant-B node: err = %v, want ErrNodeNotFound", err)
+	}
+	if stats := gs.GetTenantStats("tenant-A"); stats.NodeCount != 1 {
+		t.Errorf("tenant-A NodeCount = %d, want 1", stats.NodeCount)
+	}
+}
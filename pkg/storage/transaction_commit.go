@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -70,7 +69,7 @@ func (tx *Transaction) Commit() error {
 
 	walEntries := make([]wal.BatchEntry, 0, len(tx.createdNodes)+len(tx.createdEdges)+len(tx.updatedNodes))
 	var vectorPlans []vectorInsertPlan
-	haveObservers := len(tx.gs.observers) > 0
+	haveObservers := tx.gs.wantsNodeNotifyLocked()
 	var createdForNotify []*Node
 	type updateNotify struct{ oldNode, newNode *Node }
 	var updatesForNotify []updateNotify
@@ -86,7 +85,7 @@ func (tx *Transaction) Commit() error {
 			return fmt.Errorf("commit: persist node %d: %w", node.ID, err)
 		}
 		vectorPlans = append(vectorPlans, plans...)
-		data, err := json.Marshal(node)
+		data, err := tx.gs.marshalWAL(node)
 		if err != nil {
 			tx.gs.mu.Unlock()
 			return fmt.Errorf("commit: marshal node %d: %w", node.ID, err)
@@ -105,7 +104,7 @@ func (tx *Transaction) Commit() error {
 			tx.gs.mu.Unlock()
 			return fmt.Errorf("commit: persist edge %d: %w", edge.ID, err)
 		}
-		data, err := json.Marshal(edge)
+		data, err := tx.gs.marshalWAL(edge)
 		if err != nil {
 			tx.gs.mu.Unlock()
 			return fmt.Errorf("commit: marshal edge %d: %w", edge.ID, err)
@@ -127,7 +126,7 @@ func (tx *Transaction) Commit() error {
 		if !exists {
 			// validateLocked guaranteed existence + ownership; defensive only.
 			tx.gs.mu.Unlock()
-			return fmt.Errorf("commit: update target %d vanished", nodeID)
+			return fmt.Errorf("commit: update target %d: %w", nodeID, ErrNodeNotFound)
 		}
 		var oldNode *Node
 		if haveObservers {
@@ -144,7 +143,7 @@ func (tx *Transaction) Commit() error {
 		}
 		tx.gs.lockShard(nodeID)
 		for k, v := range props {
-			node.Properties[k] = v
+			node.Properties[k] = tx.gs.interner.value(v)
 		}
 		node.UpdatedAt = time.Now().Unix()
 		tx.gs.unlockShard(nodeID)
@@ -158,10 +157,7 @@ func (tx *Transaction) Commit() error {
 		}
 		vectorPlans = append(vectorPlans, plans...)
 
-		data, err := json.Marshal(struct {
-			NodeID     uint64
-			Properties map[string]Value
-		}{NodeID: nodeID, Properties: props})
+		data, err := tx.gs.marshalWAL(nodePropertiesUpdate{NodeID: nodeID, Properties: props})
 		if err != nil {
 			tx.gs.mu.Unlock()
 			return fmt.Errorf("commit: marshal update %d: %w", nodeID, err)
@@ -180,6 +176,7 @@ func (tx *Transaction) Commit() error {
 	// still unappended (LSN > boundary), and the surviving WAL would
 	// re-apply them over the snapshot on recovery (M-1).
 	tx.gs.txWALBarrier.RLock()
+	tx.gs.bumpVersion()
 	tx.gs.mu.Unlock()
 
 	// (3) Atomic durability — one fsync for the whole batch. Propagate the
@@ -207,7 +204,9 @@ func (tx *Transaction) Commit() error {
 
 // validateLocked checks that every created edge's endpoints and every update
 // target resolve to this transaction's tenant — either a node created in this
-// same transaction or an existing node owned by the tenant. Caller holds gs.mu.
+// same transaction or an existing node owned by the tenant — and re-applies
+// the edge policy, since a matching edge may have been created since
+// CreateEdge buffered this one. Caller holds gs.mu.
 // Returning an error here aborts the commit before any mutation, giving
 // all-or-none semantics for reference errors.
 func (tx *Transaction) validateLocked() error {
@@ -220,15 +219,29 @@ func (tx *Transaction) validateLocked() error {
 	}
 	for _, edge := range tx.createdEdges {
 		if !resolvable(edge.FromNodeID) {
-			return fmt.Errorf("commit: edge %d from-node %d not found in tenant", edge.ID, edge.FromNodeID)
+			return fmt.Errorf("commit: edge %d: %w", edge.ID, &EdgeEndpointError{Endpoint: "source", NodeID: edge.FromNodeID})
 		}
 		if !resolvable(edge.ToNodeID) {
-			return fmt.Errorf("commit: edge %d to-node %d not found in tenant", edge.ID, edge.ToNodeID)
+			return fmt.Errorf("commit: edge %d: %w", edge.ID, &EdgeEndpointError{Endpoint: "target", NodeID: edge.ToNodeID})
+		}
+		// The buffered edge was handed out with its own ID, so even under
+		// MultiEdgesDedupe a concurrent match fails the commit rather than
+		// silently becoming a different edge.
+		existing, err := tx.gs.checkEdgePolicyLocked(tx.tenantID, edge.FromNodeID, edge.ToNodeID, edge.Type)
+		if err != nil {
+			return fmt.Errorf("commit: edge %d: %w", edge.ID, err)
+		}
+		if existing != nil {
+			return fmt.Errorf("commit: edge %d: %w: %s edge %d->%d was created concurrently as edge %d",
+				edge.ID, ErrDuplicateEdge, edge.Type, edge.FromNodeID, edge.ToNodeID, existing.ID)
 		}
 	}
-	for nodeID := range tx.updatedNodes {
+	for nodeID, props := range tx.updatedNodes {
 		if !resolvable(nodeID) {
-			return fmt.Errorf("commit: update target %d not found in tenant", nodeID)
+			return fmt.Errorf("commit: update target %d: %w", nodeID, ErrNodeNotFound)
+		}
+		if err := tx.gs.checkNodeKeyUpdateLocked(tx.tenantID, nodeID, props); err != nil {
+			return fmt.Errorf("commit: update target %d: %w", nodeID, err)
 		}
 	}
 	return nil
@@ -1,7 +1,7 @@
 package storage
 
 import (
-	"errors"
+	"fmt"
 	"time"
 )
 
@@ -82,6 +82,14 @@ func (tx *Transaction) CreateEdge(fromID, toID uint64, edgeType string, properti
 		return nil, ErrTransactionNotActive
 	}
 
+	// The edge policy is checked now, against the store and this
+	// transaction's own buffer; a dedupe hit returns the matching edge.
+	if existing, err := tx.checkEdgePolicy(fromID, toID, edgeType); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return existing, nil
+	}
+
 	// Allocate ID but don't add to storage yet
 	edgeID, err := tx.gs.allocateEdgeID()
 	if err != nil {
@@ -111,6 +119,33 @@ func (tx *Transaction) CreateEdge(fromID, toID uint64, edgeType string, properti
 	return edge, nil
 }
 
+// checkEdgePolicy applies the store's edge policy (see
+// checkEdgePolicyLocked) to an edge about to be buffered, treating edges
+// already buffered in this transaction like stored ones. Caller holds tx.mu.
+func (tx *Transaction) checkEdgePolicy(fromID, toID uint64, edgeType string) (*Edge, error) {
+	tx.gs.mu.RLock()
+	existing, err := tx.gs.checkEdgePolicyLocked(tx.tenantID, fromID, toID, edgeType)
+	if existing != nil {
+		existing = existing.Clone()
+	}
+	tx.gs.mu.RUnlock()
+	if err != nil || existing != nil || tx.gs.multiEdges == MultiEdgesAllow {
+		return existing, err
+	}
+
+	for _, edge := range tx.createdEdges {
+		if edge.FromNodeID != fromID || edge.ToNodeID != toID || edge.Type != edgeType {
+			continue
+		}
+		if tx.gs.multiEdges == MultiEdgesReject {
+			return nil, fmt.Errorf("%w: %s edge %d->%d already created in this transaction",
+				ErrDuplicateEdge, edgeType, fromID, toID)
+		}
+		return edge, nil
+	}
+	return nil, nil
+}
+
 // GetNodeByID gets a node by ID within the transaction context
 func (tx *Transaction) GetNodeByID(nodeID uint64) (*Node, error) {
 	tx.mu.RLock()
@@ -122,7 +157,7 @@ func (tx *Transaction) GetNodeByID(nodeID uint64) (*Node, error) {
 
 	// Check if node was deleted in this transaction
 	if tx.deletedNodes[nodeID] {
-		return nil, errors.New("node not found")
+		return nil, ErrNodeNotFound
 	}
 
 	// Check if node was created in this transaction
@@ -145,7 +180,7 @@ func (tx *Transaction) GetEdgeByID(edgeID uint64) (*Edge, error) {
 
 	// Check if edge was deleted in this transaction
 	if tx.deletedEdges[edgeID] {
-		return nil, errors.New("edge not found")
+		return nil, ErrEdgeNotFound
 	}
 
 	// Check if edge was created in this transaction
@@ -174,7 +174,7 @@ func BoolArrayValue(arr []bool) Value {
 // Decode methods
 func (v Value) AsString() (string, error) {
 	if v.Type != TypeString {
-		return "", fmt.Errorf("value is not a string")
+		return "", fmt.Errorf("%w: value is not a string", ErrInvalidProperty)
 	}
 	return string(v.Data), nil
 }
@@ -185,7 +185,7 @@ func (v Value) AsString() (string, error) {
 // as JSON.
 func (v Value) AsJSON() (any, error) {
 	if v.Type != TypeJSON {
-		return nil, fmt.Errorf("value is not JSON")
+		return nil, fmt.Errorf("%w: value is not JSON", ErrInvalidProperty)
 	}
 	var out any
 	if err := json.Unmarshal(v.Data, &out); err != nil {
@@ -196,45 +196,45 @@ func (v Value) AsJSON() (any, error) {
 
 func (v Value) AsInt() (int64, error) {
 	if v.Type != TypeInt {
-		return 0, fmt.Errorf("value is not an int")
+		return 0, fmt.Errorf("%w: value is not an int", ErrInvalidProperty)
 	}
 	return int64(binary.LittleEndian.Uint64(v.Data)), nil
 }
 
 func (v Value) AsFloat() (float64, error) {
 	if v.Type != TypeFloat {
-		return 0, fmt.Errorf("value is not a float")
+		return 0, fmt.Errorf("%w: value is not a float", ErrInvalidProperty)
 	}
 	return math.Float64frombits(binary.LittleEndian.Uint64(v.Data)), nil
 }
 
 func (v Value) AsBool() (bool, error) {
 	if v.Type != TypeBool {
-		return false, fmt.Errorf("value is not a bool")
+		return false, fmt.Errorf("%w: value is not a bool", ErrInvalidProperty)
 	}
 	return v.Data[0] == 1, nil
 }
 
 func (v Value) AsTimestamp() (time.Time, error) {
 	if v.Type != TypeTimestamp {
-		return time.Time{}, fmt.Errorf("value is not a timestamp")
+		return time.Time{}, fmt.Errorf("%w: value is not a timestamp", ErrInvalidProperty)
 	}
 	return time.Unix(int64(binary.LittleEndian.Uint64(v.Data)), 0), nil
 }
 
 func (v Value) AsVector() ([]float32, error) {
 	if v.Type != TypeVector {
-		return nil, fmt.Errorf("value is not a vector")
+		return nil, fmt.Errorf("%w: value is not a vector", ErrInvalidProperty)
 	}
 	if len(v.Data) < 4 {
-		return nil, fmt.Errorf("invalid vector data: too short")
+		return nil, fmt.Errorf("%w: invalid vector data: too short", ErrInvalidProperty)
 	}
 
 	// Decode dimensions
 	dims := binary.LittleEndian.Uint32(v.Data[0:4])
 	expectedLen := 4 + int(dims)*4
 	if len(v.Data) != expectedLen {
-		return nil, fmt.Errorf("invalid vector data: expected %d bytes, got %d", expectedLen, len(v.Data))
+		return nil, fmt.Errorf("%w: invalid vector data: expected %d bytes, got %d", ErrInvalidProperty, expectedLen, len(v.Data))
 	}
 
 	// Decode floats
@@ -250,10 +250,10 @@ func (v Value) AsVector() ([]float32, error) {
 // AsStringArray decodes a string array value
 func (v Value) AsStringArray() ([]string, error) {
 	if v.Type != TypeStringArray {
-		return nil, fmt.Errorf("value is not a string array")
+		return nil, fmt.Errorf("%w: value is not a string array", ErrInvalidProperty)
 	}
 	if len(v.Data) < 4 {
-		return nil, fmt.Errorf("invalid string array data: too short")
+		return nil, fmt.Errorf("%w: invalid string array data: too short", ErrInvalidProperty)
 	}
 
 	count := binary.LittleEndian.Uint32(v.Data[0:4])
@@ -262,13 +262,13 @@ func (v Value) AsStringArray() ([]string, error) {
 	offset := 4
 	for i := uint32(0); i < count; i++ {
 		if offset+4 > len(v.Data) {
-			return nil, fmt.Errorf("invalid string array data: truncated at element %d", i)
+			return nil, fmt.Errorf("%w: invalid string array data: truncated at element %d", ErrInvalidProperty, i)
 		}
 		strLen := binary.LittleEndian.Uint32(v.Data[offset : offset+4])
 		offset += 4
 
 		if offset+int(strLen) > len(v.Data) {
-			return nil, fmt.Errorf("invalid string array data: string %d extends past end", i)
+			return nil, fmt.Errorf("%w: invalid string array data: string %d extends past end", ErrInvalidProperty, i)
 		}
 		result[i] = string(v.Data[offset : offset+int(strLen)])
 		offset += int(strLen)
@@ -280,16 +280,16 @@ func (v Value) AsStringArray() ([]string, error) {
 // AsIntArray decodes an int64 array value
 func (v Value) AsIntArray() ([]int64, error) {
 	if v.Type != TypeIntArray {
-		return nil, fmt.Errorf("value is not an int array")
+		return nil, fmt.Errorf("%w: value is not an int array", ErrInvalidProperty)
 	}
 	if len(v.Data) < 4 {
-		return nil, fmt.Errorf("invalid int array data: too short")
+		return nil, fmt.Errorf("%w: invalid int array data: too short", ErrInvalidProperty)
 	}
 
 	count := binary.LittleEndian.Uint32(v.Data[0:4])
 	expectedLen := 4 + int(count)*8
 	if len(v.Data) != expectedLen {
-		return nil, fmt.Errorf("invalid int array data: expected %d bytes, got %d", expectedLen, len(v.Data))
+		return nil, fmt.Errorf("%w: invalid int array data: expected %d bytes, got %d", ErrInvalidProperty, expectedLen, len(v.Data))
 	}
 
 	result := make([]int64, count)
@@ -303,16 +303,16 @@ func (v Value) AsIntArray() ([]int64, error) {
 // AsFloatArray decodes a float64 array value
 func (v Value) AsFloatArray() ([]float64, error) {
 	if v.Type != TypeFloatArray {
-		return nil, fmt.Errorf("value is not a float array")
+		return nil, fmt.Errorf("%w: value is not a float array", ErrInvalidProperty)
 	}
 	if len(v.Data) < 4 {
-		return nil, fmt.Errorf("invalid float array data: too short")
+		return nil, fmt.Errorf("%w: invalid float array data: too short", ErrInvalidProperty)
 	}
 
 	count := binary.LittleEndian.Uint32(v.Data[0:4])
 	expectedLen := 4 + int(count)*8
 	if len(v.Data) != expectedLen {
-		return nil, fmt.Errorf("invalid float array data: expected %d bytes, got %d", expectedLen, len(v.Data))
+		return nil, fmt.Errorf("%w: invalid float array data: expected %d bytes, got %d", ErrInvalidProperty, expectedLen, len(v.Data))
 	}
 
 	result := make([]float64, count)
@@ -327,16 +327,16 @@ func (v Value) AsFloatArray() ([]float64, error) {
 // AsBoolArray decodes a bool array value
 func (v Value) AsBoolArray() ([]bool, error) {
 	if v.Type != TypeBoolArray {
-		return nil, fmt.Errorf("value is not a bool array")
+		return nil, fmt.Errorf("%w: value is not a bool array", ErrInvalidProperty)
 	}
 	if len(v.Data) < 4 {
-		return nil, fmt.Errorf("invalid bool array data: too short")
+		return nil, fmt.Errorf("%w: invalid bool array data: too short", ErrInvalidProperty)
 	}
 
 	count := binary.LittleEndian.Uint32(v.Data[0:4])
 	expectedLen := 4 + int(count)
 	if len(v.Data) != expectedLen {
-		return nil, fmt.Errorf("invalid bool array data: expected %d bytes, got %d", expectedLen, len(v.Data))
+		return nil, fmt.Errorf("%w: invalid bool array data: expected %d bytes, got %d", ErrInvalidProperty, expectedLen, len(v.Data))
 	}
 
 	result := make([]bool, count)
@@ -358,7 +358,7 @@ func (v Value) ArrayContains(element Value) (bool, error) {
 		}
 		target, err := element.AsString()
 		if err != nil {
-			return false, fmt.Errorf("element must be a string for string array")
+			return false, fmt.Errorf("%w: element must be a string for string array", ErrInvalidProperty)
 		}
 		for _, s := range arr {
 			if s == target {
@@ -374,7 +374,7 @@ func (v Value) ArrayContains(element Value) (bool, error) {
 		}
 		target, err := element.AsInt()
 		if err != nil {
-			return false, fmt.Errorf("element must be an int for int array")
+			return false, fmt.Errorf("%w: element must be an int for int array", ErrInvalidProperty)
 		}
 		for _, i := range arr {
 			if i == target {
@@ -390,7 +390,7 @@ func (v Value) ArrayContains(element Value) (bool, error) {
 		}
 		target, err := element.AsFloat()
 		if err != nil {
-			return false, fmt.Errorf("element must be a float for float array")
+			return false, fmt.Errorf("%w: element must be a float for float array", ErrInvalidProperty)
 		}
 		for _, f := range arr {
 			if f == target {
@@ -406,7 +406,7 @@ func (v Value) ArrayContains(element Value) (bool, error) {
 		}
 		target, err := element.AsBool()
 		if err != nil {
-			return false, fmt.Errorf("element must be a bool for bool array")
+			return false, fmt.Errorf("%w: element must be a bool for bool array", ErrInvalidProperty)
 		}
 		for _, b := range arr {
 			if b == target {
@@ -416,7 +416,7 @@ func (v Value) ArrayContains(element Value) (bool, error) {
 		return false, nil
 
 	default:
-		return false, fmt.Errorf("ArrayContains only works on array types, got %v", v.Type)
+		return false, fmt.Errorf("%w: ArrayContains only works on array types, got %v", ErrInvalidProperty, v.Type)
 	}
 }
 
@@ -425,11 +425,11 @@ func (v Value) ArrayLen() (int, error) {
 	switch v.Type {
 	case TypeStringArray, TypeIntArray, TypeFloatArray, TypeBoolArray:
 		if len(v.Data) < 4 {
-			return 0, fmt.Errorf("invalid array data: too short")
+			return 0, fmt.Errorf("%w: invalid array data: too short", ErrInvalidProperty)
 		}
 		return int(binary.LittleEndian.Uint32(v.Data[0:4])), nil
 	default:
-		return 0, fmt.Errorf("ArrayLen only works on array types, got %v", v.Type)
+		return 0, fmt.Errorf("%w: ArrayLen only works on array types, got %v", ErrInvalidProperty, v.Type)
 	}
 }
 
@@ -453,6 +453,12 @@ type Edge struct {
 	Properties map[string]Value
 	Weight     float64
 	CreatedAt  int64
+
+	// Undirected edges are stored once but traversable both ways: the
+	// adjacency reads (GetOutgoingEdges, GetIncomingEdges, Neighbors)
+	// return one at either endpoint, oriented from or to the node asked
+	// about. See CreateUndirectedEdge.
+	Undirected bool `json:"Undirected,omitempty"`
 }
 
 // Clone creates a deep copy of a node
@@ -499,6 +505,7 @@ func (e *Edge) Clone() *Edge {
 		Properties: make(map[string]Value),
 		Weight:     e.Weight,
 		CreatedAt:  e.CreatedAt,
+		Undirected: e.Undirected,
 	}
 	for k, v := range e.Properties {
 		clone.Properties[k] = v
@@ -0,0 +1,35 @@
+package storage
+
+// Undirected edges are stored like directed ones: one record, one entry in
+// the source's outgoing list and one in the target's incoming list. The
+// adjacency readers add the mirror image themselves, so an undirected edge
+// stored A->B also appears in B's outgoing and A's incoming edges, oriented
+// from (or to) the node asked about. Self-loops already appear in both of
+// their node's lists and are not mirrored.
+
+// mirroredUndirectedEdgesLocked returns the undirected edges that belong in
+// nodeID's outgoing (or incoming) list but are stored in the opposite one,
+// as clones with their endpoints swapped so FromNodeID (or ToNodeID) is
+// nodeID. Nil unless the store has ever held an undirected edge. Caller
+// holds gs.mu.RLock.
+func (gs *GraphStorage) mirroredUndirectedEdgesLocked(nodeID uint64, outgoing bool) []*Edge {
+	if !gs.hasUndirected.Load() {
+		return nil
+	}
+	var edges []*Edge
+	for _, edgeID := range gs.getEdgeIDsForNode(nodeID, !outgoing) {
+		edge, exists := gs.resolveEdgeRefLocked(edgeID)
+		if !exists || !edge.Undirected || edge.FromNodeID == edge.ToNodeID {
+			continue
+		}
+		edges = append(edges, edge.reversed())
+	}
+	return edges
+}
+
+// reversed returns a clone of e with its endpoints swapped.
+func (e *Edge) reversed() *Edge {
+	r := e.Clone()
+	r.FromNodeID, r.ToNodeID = r.ToNodeID, r.FromNodeID
+	return r
+}
@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// assertUndirectedLink checks that the undirected edge id between a and b is
+// listed, oriented, at both endpoints in both directions.
+func assertUndirectedLink(t *testing.T, gs *GraphStorage, id, a, b uint64) {
+	t.Helper()
+	find := func(edges []*Edge) *Edge {
+		for _, e := range edges {
+			if e.ID == id {
+				return e
+			}
+		}
+		return nil
+	}
+	for _, c := range []struct {
+		node     uint64
+		outgoing bool
+		from, to uint64
+	}{
+		{a, true, a, b},
+		{b, true, b, a},
+		{a, false, b, a},
+		{b, false, a, b},
+	} {
+		var edges []*Edge
+		var err error
+		if c.outgoing {
+			edges, err = gs.GetOutgoingEdges(c.node)
+		} else {
+			edges, err = gs.GetIncomingEdges(c.node)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := find(edges)
+		if e == nil || !e.Undirected || e.FromNodeID != c.from || e.ToNodeID != c.to {
+			t.Errorf("node %d (outgoing=%v): edge %d = %+v, want %d->%d undirected", c.node, c.outgoing, id, e, c.from, c.to)
+		}
+	}
+}
+
+func TestCreateUndirectedEdge(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"N"}, nil)
+	b := testNode(t, gs, []string{"N"}, nil)
+	c := testNode(t, gs, []string{"N"}, nil)
+
+	link, err := gs.CreateUndirectedEdge(a.ID, b.ID, "LINK", nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !link.Undirected {
+		t.Error("CreateUndirectedEdge returned a directed edge")
+	}
+	testEdge(t, gs, b.ID, c.ID, "NEXT", nil, 1)
+	loop, err := gs.CreateUndirectedEdge(c.ID, c.ID, "LOOP", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := gs.GetStatistics().EdgeCount; got != 3 {
+		t.Errorf("EdgeCount = %d, want 3 (the undirected link counts once)", got)
+	}
+	assertUndirectedLink(t, gs, link.ID, a.ID, b.ID)
+
+	// Directed edges keep their direction.
+	if in, _ := gs.GetOutgoingEdges(c.ID); len(in) != 1 || in[0].ID != loop.ID {
+		t.Errorf("outgoing edges of c = %v, want only the self-loop once", in)
+	}
+
+	// The stored edge keeps its creation orientation.
+	stored, err := gs.GetEdge(link.ID)
+	if err != nil || stored.FromNodeID != a.ID || stored.ToNodeID != b.ID {
+		t.Errorf("GetEdge = %+v, %v; want %d->%d", stored, err, a.ID, b.ID)
+	}
+
+	for _, tc := range []struct {
+		node uint64
+		dir  Direction
+		want []uint64
+	}{
+		{b.ID, DirectionOutgoing, []uint64{c.ID, a.ID}},
+		{a.ID, DirectionIncoming, []uint64{b.ID}},
+		{b.ID, DirectionBoth, []uint64{c.ID, a.ID}},
+	} {
+		nodes, err := gs.Neighbors(tc.node, tc.dir, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []uint64
+		for _, n := range nodes {
+			got = append(got, n.ID)
+		}
+		if !equalU64(got, tc.want) {
+			t.Errorf("Neighbors(%d, %v) = %v, want %v", tc.node, tc.dir, got, tc.want)
+		}
+	}
+
+	view, err := gs.View()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ := view.GetOutgoingEdges(b.ID)
+	if len(out) != 2 || out[1].ID != link.ID || out[1].FromNodeID != b.ID || out[1].ToNodeID != a.ID {
+		t.Errorf("view outgoing edges of b = %v, want NEXT then LINK reversed", out)
+	}
+	if got := view.GetStatistics().EdgeCount; got != 3 {
+		t.Errorf("view EdgeCount = %d, want 3", got)
+	}
+
+	if _, err := gs.CreateUndirectedEdgeWithTenant("other", a.ID, b.ID, "LINK", nil, 1); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("cross-tenant CreateUndirectedEdgeWithTenant = %v, want ErrNodeNotFound", err)
+	}
+}
+
+// TestUndirectedEdge_MultiEdgePolicy pins that an undirected edge occupies
+// both orientations of its (from, to, type) key.
+func TestUndirectedEdge_MultiEdgePolicy(t *testing.T) {
+	gs := testGraphStorage(t, StorageConfig{MultiEdges: MultiEdgesReject})
+	a := testNode(t, gs, []string{"N"}, nil)
+	b := testNode(t, gs, []string{"N"}, nil)
+
+	if _, err := gs.CreateUndirectedEdge(a.ID, b.ID, "LINK", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gs.CreateEdge(b.ID, a.ID, "LINK", nil, 1); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("directed reverse duplicate = %v, want ErrDuplicateEdge", err)
+	}
+	if _, err := gs.CreateUndirectedEdge(b.ID, a.ID, "LINK", nil, 1); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("undirected reverse duplicate = %v, want ErrDuplicateEdge", err)
+	}
+	testEdge(t, gs, a.ID, b.ID, "OTHER", nil, 1)
+	if _, err := gs.CreateUndirectedEdge(b.ID, a.ID, "OTHER", nil, 1); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("undirected edge over a directed one = %v, want ErrDuplicateEdge", err)
+	}
+}
+
+func TestUndirectedEdge_SurvivesReopen(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		cfg   func(dir string) StorageConfig
+		close bool
+	}{
+		{"json snapshot", jsonConfig, true},
+		{"mmap snapshot", mmapConfig, true},
+		{"wal replay", mmapConfig, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			gs, err := NewGraphStorageWithConfig(tc.cfg(dir))
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := testNode(t, gs, []string{"N"}, nil)
+			b := testNode(t, gs, []string{"N"}, nil)
+			link, err := gs.CreateUndirectedEdge(a.ID, b.ID, "LINK", nil, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.close {
+				if err := gs.Close(); err != nil {
+					t.Fatal(err)
+				}
+			} // else abandon the store: the edge is only in the WAL
+
+			gs2, err := NewGraphStorageWithConfig(tc.cfg(dir))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer gs2.Close()
+			if got := gs2.GetStatistics().EdgeCount; got != 1 {
+				t.Errorf("EdgeCount = %d, want 1", got)
+			}
+			assertUndirectedLink(t, gs2, link.ID, a.ID, b.ID)
+		})
+	}
+}
+
+func TestUndirectedEdge_BackupRoundTrip(t *testing.T) {
+	gs := testGraphStorage(t)
+	a := testNode(t, gs, []string{"N"}, nil)
+	b := testNode(t, gs, []string{"N"}, nil)
+	link, err := gs.CreateUndirectedEdge(a.ID, b.ID, "LINK", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := gs.Backup(&buf); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := Restore(filepath.Join(t.TempDir(), "restored"), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+	assertUndirectedLink(t, restored, link.ID, a.ID, b.ID)
+}
@@ -120,7 +120,7 @@ func TestCreateNodeWithUniquePropertyForTenant_InputValidation(t *testing.T) {
 			properties: map[string]Value{"for_task": StringValue("x")},
 			uniqLabel:  "",
 			uniqProp:   "for_task",
-			wantErr:    "uniqueLabel and uniquePropertyKey are required",
+			wantErr:    "invalid argument: uniqueLabel and uniquePropertyKey are required",
 		},
 		{
 			name:       "missing uniquePropertyKey",
@@ -128,7 +128,7 @@ func TestCreateNodeWithUniquePropertyForTenant_InputValidation(t *testing.T) {
 			properties: map[string]Value{"for_task": StringValue("x")},
 			uniqLabel:  "Claim",
 			uniqProp:   "",
-			wantErr:    "uniqueLabel and uniquePropertyKey are required",
+			wantErr:    "invalid argument: uniqueLabel and uniquePropertyKey are required",
 		},
 		{
 			name:       "uniqueLabel not in labels",
@@ -136,7 +136,7 @@ func TestCreateNodeWithUniquePropertyForTenant_InputValidation(t *testing.T) {
 			properties: map[string]Value{"for_task": StringValue("x")},
 			uniqLabel:  "Claim",
 			uniqProp:   "for_task",
-			wantErr:    "uniqueLabel \"Claim\" must be present in labels",
+			wantErr:    "invalid argument: uniqueLabel \"Claim\" must be present in labels",
 		},
 		{
 			name:       "missing required property",
@@ -144,7 +144,7 @@ func TestCreateNodeWithUniquePropertyForTenant_InputValidation(t *testing.T) {
 			properties: map[string]Value{"other": StringValue("x")},
 			uniqLabel:  "Claim",
 			uniqProp:   "for_task",
-			wantErr:    "property \"for_task\" is required for uniqueness check",
+			wantErr:    "invalid argument: property \"for_task\" is required for uniqueness check",
 		},
 	}
 
@@ -159,6 +159,9 @@ func TestCreateNodeWithUniquePropertyForTenant_InputValidation(t *testing.T) {
 			if got := err.Error(); got != tt.wantErr {
 				t.Errorf("error = %q, want %q", got, tt.wantErr)
 			}
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("error = %v, want errors.Is ErrInvalidArgument", err)
+			}
 		})
 	}
 }
@@ -38,7 +38,7 @@ func NewVectorIndex() *VectorIndex {
 // an empty tenantID. Tenant-blind callers should use the non-ForTenant
 // methods, which default to tenantid.Default rather than surfacing this
 // error.
-var errEmptyTenantID = fmt.Errorf("vector index: tenantID must not be empty (use the tenant-blind method to target tenantid.Default)")
+var errEmptyTenantID = fmt.Errorf("vector index: %w: tenantID must not be empty (use the tenant-blind method to target tenantid.Default)", ErrInvalidArgument)
 
 // CreateIndex creates a new HNSW index for a property under the default
 // tenant. Equivalent to CreateIndexForTenant(tenantid.Default, ...).
@@ -73,7 +73,7 @@ func (vi *VectorIndex) CreateIndexForTenant(
 	inner, ok := vi.indexes[tenantID]
 	if ok {
 		if _, exists := inner[propertyName]; exists {
-			return fmt.Errorf("vector index already exists for property %s", propertyName)
+			return fmt.Errorf("vector %w for property %s", ErrIndexExists, propertyName)
 		}
 	}
 
@@ -112,7 +112,7 @@ func (vi *VectorIndex) AddVectorForTenant(
 	vi.mu.RUnlock()
 
 	if !exists {
-		return fmt.Errorf("no vector index exists for property %s", propertyName)
+		return fmt.Errorf("vector %w for property %s", ErrIndexNotFound, propertyName)
 	}
 
 	return index.Insert(nodeID, vec)
@@ -146,7 +146,7 @@ func (vi *VectorIndex) SearchForTenant(
 	vi.mu.RUnlock()
 
 	if !exists {
-		return nil, fmt.Errorf("no vector index exists for property %s", propertyName)
+		return nil, fmt.Errorf("vector %w for property %s", ErrIndexNotFound, propertyName)
 	}
 
 	return index.Search(query, k, ef)
@@ -173,7 +173,7 @@ func (vi *VectorIndex) RemoveVectorForTenant(
 	vi.mu.RUnlock()
 
 	if !exists {
-		return fmt.Errorf("no vector index exists for property %s", propertyName)
+		return fmt.Errorf("vector %w for property %s", ErrIndexNotFound, propertyName)
 	}
 
 	return index.Delete(nodeID)
@@ -196,10 +196,10 @@ func (vi *VectorIndex) DropIndexForTenant(tenantID tenantid.TenantID, propertyNa
 
 	inner, ok := vi.indexes[tenantID]
 	if !ok {
-		return fmt.Errorf("no vector index exists for property %s", propertyName)
+		return fmt.Errorf("vector %w for property %s", ErrIndexNotFound, propertyName)
 	}
 	if _, exists := inner[propertyName]; !exists {
-		return fmt.Errorf("no vector index exists for property %s", propertyName)
+		return fmt.Errorf("vector %w for property %s", ErrIndexNotFound, propertyName)
 	}
 
 	delete(inner, propertyName)
@@ -284,7 +284,7 @@ func (vi *VectorIndex) GetIndexMetricForTenant(
 
 	index, exists := vi.lookupIndexLocked(tenantID, propertyName)
 	if !exists {
-		return "", fmt.Errorf("no vector index exists for property %s", propertyName)
+		return "", fmt.Errorf("vector %w for property %s", ErrIndexNotFound, propertyName)
 	}
 	return index.Metric(), nil
 }
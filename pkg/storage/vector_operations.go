@@ -30,6 +30,8 @@ func (gs *GraphStorage) CreateVectorIndex(
 	if err := gs.vectorIndex.CreateIndex(propertyName, dimensions, m, efConstruction, metric); err != nil {
 		return err
 	}
+	gs.bumpVersion()
+
 	// Durability: the definition lives only in memory + the snapshot until
 	// logged. Without this, an index created after the last snapshot is lost
 	// on crash (its vectors un-indexed on recovery). Mirrors
@@ -63,6 +65,8 @@ func (gs *GraphStorage) DropVectorIndex(propertyName string) error {
 	if err := gs.vectorIndex.DropIndex(propertyName); err != nil {
 		return err
 	}
+	gs.bumpVersion()
+
 	// Durability: a drop applied after the last snapshot must be logged, or
 	// the snapshotted definition resurrects on recovery.
 	gs.writeToWAL(wal.OpDropVectorIndex, dropVectorIndexWAL{
@@ -125,13 +129,15 @@ func (gs *GraphStorage) CreateVectorIndexForTenant(
 	metric vector.DistanceMetric,
 ) error {
 	if tenantID == "" {
-		return fmt.Errorf("create vector index: tenantID must not be empty")
+		return fmt.Errorf("create vector index: %w: tenantID must not be empty", ErrInvalidArgument)
 	}
 	if err := gs.vectorIndex.CreateIndexForTenant(
 		tenantid.TenantID(tenantID), propertyName, dimensions, m, efConstruction, metric,
 	); err != nil {
 		return err
 	}
+	gs.bumpVersion()
+
 	// Durability — see CreateVectorIndex. The replayed def routes by TenantID.
 	gs.writeToWAL(wal.OpCreateVectorIndex, VectorIndexDef{
 		TenantID:       tenantID,
@@ -184,11 +190,13 @@ func (gs *GraphStorage) HasVectorIndexForTenant(tenantID string, propertyName st
 // propertyName. Returns an error for empty tenantID.
 func (gs *GraphStorage) DropVectorIndexForTenant(tenantID string, propertyName string) error {
 	if tenantID == "" {
-		return fmt.Errorf("drop vector index: tenantID must not be empty")
+		return fmt.Errorf("drop vector index: %w: tenantID must not be empty", ErrInvalidArgument)
 	}
 	if err := gs.vectorIndex.DropIndexForTenant(tenantid.TenantID(tenantID), propertyName); err != nil {
 		return err
 	}
+	gs.bumpVersion()
+
 	// Durability — see DropVectorIndex.
 	gs.writeToWAL(wal.OpDropVectorIndex, dropVectorIndexWAL{
 		TenantID:     tenantID,
@@ -316,7 +324,7 @@ func (gs *GraphStorage) planNodeVectorInserts(node *Node) ([]vectorInsertPlan, e
 		// same check index.Insert does first, hoisted under the lock so a
 		// malformed-dimension write fails before durability rather than after.
 		if dim, ok := gs.vectorIndex.DimensionsForTenant(tenantID, propName); ok && len(vec) != dim {
-			return nil, fmt.Errorf("vector dimensions mismatch for property %s: got %d, want %d", propName, len(vec), dim)
+			return nil, fmt.Errorf("%w: vector dimensions mismatch for property %s: got %d, want %d", ErrInvalidProperty, propName, len(vec), dim)
 		}
 		plans = append(plans, vectorInsertPlan{tenantID: tenantID, propName: propName, nodeID: node.ID, vec: vec})
 	}
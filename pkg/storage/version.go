@@ -0,0 +1,22 @@
+package storage
+
+// Version returns the graph's mutation counter. It increases by at least
+// one with every create, update and delete (of nodes, edges, indexes), and
+// never goes down while the store is open, so a result computed at
+// version v is still valid for as long as Version() == v. It starts at 0
+// on open: it orders changes within a process, it is not an on-disk
+// revision.
+//
+// The counter is bumped once a mutation is applied in memory, so a reader
+// that sees version v sees every change up to v.
+func (gs *GraphStorage) Version() uint64 {
+	return gs.version.Load()
+}
+
+// bumpVersion advances Version. Every mutator calls it itself once its
+// change is applied in memory, rather than relying on the WAL helpers,
+// which Batch skips when no WAL is configured (BulkImportMode).
+// Batch.Commit bumps once for the whole batch.
+func (gs *GraphStorage) bumpVersion() {
+	gs.version.Add(1)
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVersion_AdvancesOnEveryMutation(t *testing.T) {
+	for _, cfg := range []struct {
+		name   string
+		config StorageConfig
+	}{
+		{"wal", StorageConfig{}},
+		{"batched", StorageConfig{EnableBatching: true, BatchSize: 10, FlushInterval: 10 * time.Millisecond}},
+		{"bulk_import", StorageConfig{BulkImportMode: true}},
+	} {
+		t.Run(cfg.name, func(t *testing.T) {
+			config := cfg.config
+			config.DataDir = t.TempDir()
+			gs, err := NewGraphStorageWithConfig(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer gs.Close()
+
+			last := gs.Version()
+			advanced := func(what string) {
+				t.Helper()
+				if v := gs.Version(); v <= last {
+					t.Errorf("%s: version %d, want > %d", what, v, last)
+				} else {
+					last = v
+				}
+			}
+
+			a, err := gs.CreateNode([]string{"N"}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			advanced("CreateNode")
+			b, _ := gs.CreateNode([]string{"N"}, nil)
+			advanced("CreateNode")
+			edge, err := gs.CreateEdge(a.ID, b.ID, "REL", nil, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			advanced("CreateEdge")
+			if err := gs.UpdateNode(a.ID, map[string]Value{"k": IntValue(1)}); err != nil {
+				t.Fatal(err)
+			}
+			advanced("UpdateNode")
+
+			// Reads leave it alone.
+			_, _ = gs.GetNode(a.ID)
+			_, _ = gs.GetOutgoingEdges(a.ID)
+			if v := gs.Version(); v != last {
+				t.Errorf("reads moved version from %d to %d", last, v)
+			}
+
+			tx, err := gs.BeginTransaction()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tx.CreateNode([]string{"N"}, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := tx.Commit(); err != nil {
+				t.Fatal(err)
+			}
+			advanced("Transaction.Commit")
+
+			if err := gs.DeleteEdge(edge.ID); err != nil {
+				t.Fatal(err)
+			}
+			advanced("DeleteEdge")
+			if err := gs.DeleteNode(b.ID); err != nil {
+				t.Fatal(err)
+			}
+			advanced("DeleteNode")
+
+			batch := gs.BeginBatch()
+			if _, err := batch.AddNode([]string{"N"}, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := batch.Commit(); err != nil {
+				t.Fatal(err)
+			}
+			advanced("Batch.Commit")
+
+			if err := gs.CreatePropertyIndex("k", TypeInt); err != nil {
+				t.Fatal(err)
+			}
+			advanced("CreatePropertyIndex")
+
+			if stats := gs.GetStatistics(); stats.Version != last {
+				t.Errorf("GetStatistics().Version = %d, want %d", stats.Version, last)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ const (
 	// never renumber the values above.
 	OpCreateVectorIndex
 	OpDropVectorIndex
+	OpCreateEdgePropertyIndex
+	OpDropEdgePropertyIndex
 )
 
 // Entry represents a single WAL entry